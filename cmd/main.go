@@ -1,8 +1,6 @@
 package main
 
 import (
-	"errors"
-	"fmt"
 	"os"
 
 	"github.com/tyktech/tyk-cli/internal/cli"
@@ -17,16 +15,10 @@ var (
 
 func main() {
 	rootCmd := cli.NewRootCommand(version, commit, buildTime)
-	
-	if err := rootCmd.Execute(); err != nil {
-		// Check for ExitError to use specific exit codes
-		var exitError *cli.ExitError
-		if errors.As(err, &exitError) {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", exitError.Message)
-			os.Exit(exitError.Code)
-		}
-		
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+
+	if cmd, err := rootCmd.ExecuteC(); err != nil {
+		// Every failure is reported with its error code ('tyk explain <code>' describes
+		// it), as a JSON document with --json
+		os.Exit(cli.ReportError(os.Stderr, cmd, err))
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,195 @@
+// Package audit keeps a local, append-only log of the changes the CLI makes to
+// Dashboards and Gateways.
+package audit
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvLogPath overrides the audit log location
+const EnvLogPath = "TYK_AUDIT_LOG"
+
+// ErrNotFound is returned by Find when no entry has the requested ID
+var ErrNotFound = errors.New("audit entry not found")
+
+// Actions recorded in the log
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Entry is one change recorded in the audit log
+type Entry struct {
+	ID          string    `json:"id"`
+	Time        time.Time `json:"time"`
+	User        string    `json:"user"`
+	Command     string    `json:"command"`
+	Environment string    `json:"environment"`
+	Action      string    `json:"action"`
+	Resource    string    `json:"resource"`
+	ResourceID  string    `json:"resource_id,omitempty"`
+	Method      string    `json:"method"`
+	Path        string    `json:"path"`
+	Status      int       `json:"status"`
+	// DiffHash is the SHA-256 of the change sent, so an entry can be matched to the
+	// exact definition that was deployed
+	DiffHash string `json:"diff_hash,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Succeeded reports whether the server accepted the change
+func (e *Entry) Succeeded() bool {
+	return e.Error == "" && e.Status > 0 && e.Status < 400
+}
+
+var (
+	mu      sync.Mutex
+	command string
+)
+
+// SetCommand names the CLI command that following entries are attributed to
+func SetCommand(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	command = name
+}
+
+// Path returns the audit log location: $TYK_AUDIT_LOG, or audit.jsonl next to the
+// CLI configuration
+func Path() (string, error) {
+	if path := os.Getenv(EnvLogPath); path != "" {
+		return path, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "tyk", "audit.jsonl"), nil
+}
+
+// Hash returns the diff hash recorded for a change body
+func Hash(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Record fills in the entry's ID, time, user and command and appends it to the log
+func Record(entry Entry) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = newID()
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.User == "" {
+		entry.User = currentUser()
+	}
+	if entry.Command == "" {
+		entry.Command = command
+	}
+
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Read returns every entry in the log, oldest first. A missing log has no entries.
+func Read() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Find returns the entry with the given ID or unique ID prefix
+func Find(id string) (*Entry, error) {
+	entries, err := Read()
+	if err != nil {
+		return nil, err
+	}
+	var found *Entry
+	for i := range entries {
+		if strings.HasPrefix(entries[i].ID, id) {
+			if found != nil {
+				return nil, fmt.Errorf("audit entry '%s' is ambiguous", id)
+			}
+			found = &entries[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("audit entry '%s' not found: %w", id, ErrNotFound)
+	}
+	return found, nil
+}
+
+func newID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.jsonl")
+	t.Setenv(EnvLogPath, path)
+
+	entries, err := Read()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	SetCommand("tyk api apply")
+	require.NoError(t, Record(Entry{Environment: "dev", Action: ActionCreate, Resource: "api", ResourceID: "a1", DiffHash: Hash([]byte("{}"))}))
+	require.NoError(t, Record(Entry{Environment: "prod", Action: ActionDelete, Resource: "api", ResourceID: "a2"}))
+
+	entries, err = Read()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a1", entries[0].ResourceID)
+	assert.Equal(t, "tyk api apply", entries[0].Command)
+	assert.Len(t, entries[0].ID, 12)
+	assert.False(t, entries[0].Time.IsZero())
+	assert.Equal(t, "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", entries[0].DiffHash)
+	assert.Equal(t, "prod", entries[1].Environment)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFind(t *testing.T) {
+	t.Setenv(EnvLogPath, filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, Record(Entry{ID: "abc123", Action: ActionUpdate}))
+	require.NoError(t, Record(Entry{ID: "abd456", Action: ActionDelete}))
+
+	entry, err := Find("abc")
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdate, entry.Action)
+
+	_, err = Find("ab")
+	assert.ErrorContains(t, err, "ambiguous")
+
+	_, err = Find("zzz")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRead_Malformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	t.Setenv(EnvLogPath, path)
+	require.NoError(t, os.WriteFile(path, []byte("{\"id\":\"a\"}\n\nnot json\n"), 0600))
+
+	_, err := Read()
+	assert.ErrorContains(t, err, "audit.jsonl:3")
+}
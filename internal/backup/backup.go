@@ -0,0 +1,210 @@
+// Package backup reads and writes environment backups: gzipped tar archives of the
+// API definitions, and optionally the policies and keys, of one environment.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// FormatVersion is the version of the archive layout
+const FormatVersion = 1
+
+// maxEntrySize bounds each file of an archive, so a corrupt one cannot exhaust memory
+const maxEntrySize = 64 << 20
+
+const (
+	manifestFile = "manifest.json"
+	policiesFile = "policies.json"
+	keysFile     = "keys.json"
+	apisDir      = "apis"
+)
+
+// Manifest describes a backup; it is the first file of the archive
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	Environment   string    `json:"environment"`
+	DashboardURL  string    `json:"dashboard_url"`
+	APIs          []APIInfo `json:"apis"`
+	// Policies and Keys are counts, or -1 when the backup does not include them
+	Policies int `json:"policies"`
+	Keys     int `json:"keys"`
+}
+
+// APIInfo lists an API of the backup and the archive file holding its definition
+type APIInfo struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Classic is set for APIs backed up as classic definitions, such as GraphQL and
+	// TCP APIs; the others are OAS documents
+	Classic bool   `json:"classic,omitempty"`
+	File    string `json:"file"`
+}
+
+// API is a backed up API definition
+type API struct {
+	ID         string
+	Name       string
+	Classic    bool
+	Definition map[string]interface{}
+}
+
+// Backup is the content of an archive. Policies are the documents the Dashboard stores,
+// with every field, so restoring them loses nothing. Policies and Keys are nil when not
+// backed up.
+type Backup struct {
+	CreatedAt    time.Time
+	Environment  string
+	DashboardURL string
+	APIs         []*API
+	Policies     []map[string]interface{}
+	Keys         map[string]types.Session
+}
+
+// Write writes b as a gzipped tar archive
+func Write(w io.Writer, b *Backup) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     b.CreatedAt.UTC(),
+		Environment:   b.Environment,
+		DashboardURL:  b.DashboardURL,
+		APIs:          make([]APIInfo, len(b.APIs)),
+		Policies:      -1,
+		Keys:          -1,
+	}
+	for i, api := range b.APIs {
+		manifest.APIs[i] = APIInfo{ID: api.ID, Name: api.Name, Classic: api.Classic, File: path.Join(apisDir, url.PathEscape(api.ID)+".json")}
+	}
+	if b.Policies != nil {
+		manifest.Policies = len(b.Policies)
+	}
+	if b.Keys != nil {
+		manifest.Keys = len(b.Keys)
+	}
+
+	if err := writeJSON(tw, manifestFile, manifest, manifest.CreatedAt); err != nil {
+		return err
+	}
+	for i, api := range b.APIs {
+		if err := writeJSON(tw, manifest.APIs[i].File, api.Definition, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	if b.Policies != nil {
+		if err := writeJSON(tw, policiesFile, b.Policies, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+	if b.Keys != nil {
+		if err := writeJSON(tw, keysFile, b.Keys, manifest.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeJSON(tw *tar.Writer, name string, value interface{}, modTime time.Time) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// Read reads an archive written by Write
+func Read(r io.Reader) (*Backup, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxEntrySize {
+			return nil, fmt.Errorf("%s is larger than %d bytes", header.Name, maxEntrySize)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[path.Clean(header.Name)] = data
+	}
+
+	var manifest Manifest
+	if err := readJSON(files, manifestFile, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+
+	b := &Backup{CreatedAt: manifest.CreatedAt, Environment: manifest.Environment, DashboardURL: manifest.DashboardURL}
+	for _, info := range manifest.APIs {
+		api := &API{ID: info.ID, Name: info.Name, Classic: info.Classic}
+		if err := readJSON(files, path.Clean(info.File), &api.Definition); err != nil {
+			return nil, err
+		}
+		b.APIs = append(b.APIs, api)
+	}
+	if manifest.Policies >= 0 {
+		if err := readJSON(files, policiesFile, &b.Policies); err != nil {
+			return nil, err
+		}
+		if b.Policies == nil {
+			b.Policies = []map[string]interface{}{}
+		}
+	}
+	if manifest.Keys >= 0 {
+		if err := readJSON(files, keysFile, &b.Keys); err != nil {
+			return nil, err
+		}
+		if b.Keys == nil {
+			b.Keys = map[string]types.Session{}
+		}
+	}
+	return b, nil
+}
+
+func readJSON(files map[string][]byte, name string, value interface{}) error {
+	data, ok := files[name]
+	if !ok {
+		return fmt.Errorf("the backup archive has no %s", name)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to parse %s of the backup archive: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestWriteRead(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := &Backup{
+		CreatedAt:    created,
+		Environment:  "production",
+		DashboardURL: "https://dashboard.example.com",
+		APIs: []*API{
+			{ID: "users-1", Name: "Users", Definition: map[string]interface{}{"openapi": "3.0.3"}},
+			{ID: "graph/1", Name: "Graph", Classic: true, Definition: map[string]interface{}{"api_id": "graph/1"}},
+		},
+		Policies: []map[string]interface{}{{"_id": "policy-1", "name": "Gold", "partitions": map[string]interface{}{"quota": true}}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, b))
+	read, err := Read(&buf)
+	require.NoError(t, err)
+
+	assert.Equal(t, created, read.CreatedAt)
+	assert.Equal(t, "production", read.Environment)
+	require.Len(t, read.APIs, 2)
+	assert.Equal(t, "3.0.3", read.APIs[0].Definition["openapi"])
+	assert.True(t, read.APIs[1].Classic)
+	assert.Equal(t, "graph/1", read.APIs[1].Definition["api_id"])
+	require.Len(t, read.Policies, 1)
+	assert.Equal(t, "Gold", read.Policies[0]["name"])
+	assert.Equal(t, map[string]interface{}{"quota": true}, read.Policies[0]["partitions"])
+	// Keys were not backed up, which differs from backing up none
+	assert.Nil(t, read.Keys)
+
+	b.Keys = map[string]types.Session{}
+	buf.Reset()
+	require.NoError(t, Write(&buf, b))
+	read, err = Read(&buf)
+	require.NoError(t, err)
+	assert.NotNil(t, read.Keys)
+}
+
+func TestRead_Invalid(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("not an archive")))
+	assert.ErrorContains(t, err, "not a backup archive")
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"format_version": 1, "apis": [{"id": "a", "file": "apis/a.json"}], "policies": -1, "keys": -1}`)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0600, Size: int64(len(manifest))}))
+	_, err = tw.Write(manifest)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	_, err = Read(&buf)
+	assert.ErrorContains(t, err, "has no apis/a.json")
+}
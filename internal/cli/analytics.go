@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// analyticsSummary aggregates an API's traffic over the reporting window
+type analyticsSummary struct {
+	Hits             int64   `json:"hits"`
+	Success          int64   `json:"success"`
+	Errors           int64   `json:"errors"`
+	ErrorRate        float64 `json:"error_rate"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`
+	AvgUpstreamMs    float64 `json:"avg_upstream_latency_ms"`
+	MaxLatencyMs     float64 `json:"max_latency_ms"`
+	weightedLatency  float64
+	weightedUpstream float64
+}
+
+// analyticsEndpoint is one endpoint's share of the traffic
+type analyticsEndpoint struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	analyticsSummary
+}
+
+// analyticsResult is the output of 'tyk analytics'
+type analyticsResult struct {
+	APIID     string               `json:"api_id"`
+	Name      string               `json:"name"`
+	Since     string               `json:"since"`
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Summary   *analyticsSummary    `json:"summary"`
+	Endpoints []*analyticsEndpoint `json:"endpoints"`
+}
+
+// NewAnalyticsCommand creates the 'tyk analytics' command
+func NewAnalyticsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analytics <api-id>",
+		Short: "Show request, error and latency figures for an API",
+		Long: `Summarise an API's recent traffic from the Dashboard analytics: requests, errors
+and error rate, and average, upstream and maximum latency. --top-endpoints adds the
+busiest endpoints with their own figures.
+
+Analytics are recorded by the Gateway and aggregated by the Dashboard, so they are not
+available for gateway environments.
+
+Examples:
+  tyk analytics 7c2f4a1b
+  tyk analytics 7c2f4a1b --since 7d --top-endpoints 5
+  tyk analytics 7c2f4a1b --since 1h -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAnalytics,
+	}
+
+	cmd.Flags().String("since", "24h", "Reporting window ending now, e.g. 1h, 24h or 7d")
+	cmd.Flags().Int("top-endpoints", 0, "Also show the N busiest endpoints")
+
+	return cmd
+}
+
+func runAnalytics(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	since, _ := cmd.Flags().GetString("since")
+	top, _ := cmd.Flags().GetInt("top-endpoints")
+
+	window, err := parseAge("--since", since)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	if top < 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --top-endpoints %d", top)}
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	if c.IsGateway() {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "analytics are aggregated by the Dashboard; use a dashboard environment"}
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-window)
+	records, err := c.APIUsage(ctx, apiID, from, to)
+	if err != nil {
+		return wrapAPIError(err, "failed to get analytics")
+	}
+	result := &analyticsResult{
+		APIID:     apiID,
+		Name:      api.Name,
+		Since:     since,
+		From:      from.Format(time.RFC3339),
+		To:        to.Format(time.RFC3339),
+		Summary:   summarizeAnalytics(records, from),
+		Endpoints: []*analyticsEndpoint{},
+	}
+
+	if top > 0 {
+		records, err := c.EndpointUsage(ctx, apiID, from, to)
+		if err != nil {
+			return wrapAPIError(err, "failed to get endpoint analytics")
+		}
+		result.Endpoints = topEndpoints(records, top)
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, result)
+	}
+	printAnalytics(result, top > 0)
+	return nil
+}
+
+// summarizeAnalytics totals the records whose time bucket overlaps the window. The
+// Dashboard filters by whole days, so earlier hours of the first day are dropped here.
+func summarizeAnalytics(records []types.AnalyticsRecord, from time.Time) *analyticsSummary {
+	summary := &analyticsSummary{}
+	for _, record := range records {
+		if bucket := record.Time(); !bucket.IsZero() && bucket.Add(time.Hour).Before(from) {
+			continue
+		}
+		summary.add(record)
+	}
+	summary.finish()
+	return summary
+}
+
+// topEndpoints groups endpoint records by method and path and returns the n busiest
+func topEndpoints(records []types.AnalyticsRecord, n int) []*analyticsEndpoint {
+	byRoute := make(map[string]*analyticsEndpoint)
+	var endpoints []*analyticsEndpoint
+	for _, record := range records {
+		path := record.IDString("path")
+		if path == "" {
+			path = record.IDString("url")
+		}
+		method := record.IDString("method")
+		endpoint, ok := byRoute[method+" "+path]
+		if !ok {
+			endpoint = &analyticsEndpoint{Method: method, Path: path}
+			byRoute[method+" "+path] = endpoint
+			endpoints = append(endpoints, endpoint)
+		}
+		endpoint.add(record)
+	}
+	for _, endpoint := range endpoints {
+		endpoint.finish()
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool { return endpoints[i].Hits > endpoints[j].Hits })
+	if len(endpoints) > n {
+		endpoints = endpoints[:n]
+	}
+	if endpoints == nil {
+		endpoints = []*analyticsEndpoint{}
+	}
+	return endpoints
+}
+
+// add accumulates a record; latencies are averaged by request count in finish
+func (s *analyticsSummary) add(record types.AnalyticsRecord) {
+	s.Hits += record.Hits
+	s.Success += record.Success
+	s.Errors += record.Error
+	s.weightedLatency += record.RequestTime * float64(record.Hits)
+	s.weightedUpstream += record.UpstreamLatency * float64(record.Hits)
+	s.MaxLatencyMs = math.Max(s.MaxLatencyMs, record.MaxLatency)
+}
+
+func (s *analyticsSummary) finish() {
+	if s.Hits == 0 {
+		return
+	}
+	hits := float64(s.Hits)
+	s.ErrorRate = round2(float64(s.Errors) / hits * 100)
+	s.AvgLatencyMs = round2(s.weightedLatency / hits)
+	s.AvgUpstreamMs = round2(s.weightedUpstream / hits)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// printAnalytics prints the summary and, when requested, the endpoint table
+func printAnalytics(result *analyticsResult, withEndpoints bool) {
+	bold := color.New(color.Bold)
+	cyan := color.New(color.FgCyan)
+	bold.Printf("%s (%s), last %s\n", result.Name, result.APIID, result.Since)
+
+	summary := result.Summary
+	if summary.Hits == 0 {
+		fmt.Println("  No requests recorded")
+		return
+	}
+	cyan.Printf("  Requests:     %d\n", summary.Hits)
+	errorColor := color.New(color.FgGreen)
+	if summary.Errors > 0 {
+		errorColor = color.New(color.FgRed)
+	}
+	cyan.Print("  Errors:       ")
+	errorColor.Printf("%d (%.2f%%)\n", summary.Errors, summary.ErrorRate)
+	cyan.Printf("  Avg latency:  %.0fms (upstream %.0fms)\n", summary.AvgLatencyMs, summary.AvgUpstreamMs)
+	cyan.Printf("  Max latency:  %.0fms\n", summary.MaxLatencyMs)
+
+	if !withEndpoints {
+		return
+	}
+	fmt.Println()
+	if len(result.Endpoints) == 0 {
+		fmt.Println("No endpoint analytics recorded")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "METHOD\tPATH\tREQUESTS\tERRORS\tERROR %\tAVG LATENCY")
+	for _, endpoint := range result.Endpoints {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.2f\t%.0fms\n", endpoint.Method, endpoint.Path, endpoint.Hits, endpoint.Errors, endpoint.ErrorRate, endpoint.AvgLatencyMs)
+	}
+	w.Flush()
+}
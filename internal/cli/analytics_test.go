@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAnalytics(t *testing.T) {
+	dashboard, _ := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+
+	now := time.Now().UTC()
+	bucket := func(at time.Time) map[string]interface{} {
+		return map[string]interface{}{"year": at.Year(), "month": int(at.Month()), "day": at.Day(), "hour": at.Hour()}
+	}
+	var usagePaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/usage/apis/remote-1/"):
+			usagePaths = append(usagePaths, r.URL.Path)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": bucket(now), "hits": 30, "success": 27, "error": 3, "request_time": 20, "upstream_latency": 15, "max_latency": 90},
+				map[string]interface{}{"id": bucket(now.Add(-time.Hour)), "hits": 10, "success": 10, "request_time": 60, "upstream_latency": 55, "max_latency": 120},
+				// Outside a one-hour window, though on the same day as far as the Dashboard is concerned
+				map[string]interface{}{"id": bucket(now.Add(-5 * time.Hour)), "hits": 1000, "error": 1000},
+			}})
+		case strings.HasPrefix(r.URL.Path, "/api/usage/endpoints/"):
+			assert.Equal(t, "remote-1", r.URL.Query().Get("api_id"))
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": map[string]interface{}{"path": "/users", "method": "GET"}, "hits": 25, "success": 25, "request_time": 10},
+				map[string]interface{}{"id": map[string]interface{}{"path": "/users", "method": "POST"}, "hits": 15, "success": 12, "error": 3, "request_time": 50},
+			}})
+		default:
+			dashboard.serve(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "analytics", "remote-1", "--since", "1h", "--top-endpoints", "1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("analytics", out), string(out))
+	require.Len(t, usagePaths, 1)
+	assert.Contains(t, usagePaths[0], now.Format("2/1/2006"))
+
+	var result analyticsResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, int64(40), result.Summary.Hits)
+	assert.Equal(t, int64(3), result.Summary.Errors)
+	assert.Equal(t, 7.5, result.Summary.ErrorRate)
+	assert.Equal(t, 30.0, result.Summary.AvgLatencyMs)
+	assert.Equal(t, 120.0, result.Summary.MaxLatencyMs)
+	require.Len(t, result.Endpoints, 1)
+	assert.Equal(t, "GET", result.Endpoints[0].Method)
+	assert.Equal(t, int64(25), result.Endpoints[0].Hits)
+
+	_, err = runRootCommand(t, "analytics", "remote-1", "--since", "soon")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	_, err = runRootCommand(t, "analytics", "missing")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+}
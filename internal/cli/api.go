@@ -1,21 +1,29 @@
 package cli
 
 import (
+    "bytes"
     "context"
     "encoding/json"
+    "errors"
     "fmt"
     "io"
     "net/http"
+    "net/url"
     "os"
     "path/filepath"
+    "slices"
     "strings"
     "time"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/tyktech/tyk-cli/internal/client"
 	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/logging"
 	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/ownership"
+	"github.com/tyktech/tyk-cli/internal/trash"
     "github.com/tyktech/tyk-cli/pkg/types"
     "golang.org/x/term"
     "gopkg.in/yaml.v3"
@@ -43,79 +51,6 @@ func min(a, b int) int {
     return b
 }
 
-// computeTableLayout returns column widths for ID/Name/Path and whether to use a stacked fallback.
-func computeTableLayout(termWidth int) (idW, nameW, pathW int, stacked bool) {
-    if termWidth < 20 {
-        return 0, 0, 0, true
-    }
-
-    const sepWidth = 6 // two " | " separators
-    contentWidth := termWidth - sepWidth
-    if contentWidth < 15 {
-        return 0, 0, 0, true
-    }
-
-    // Minimums and pleasant defaults
-    minID, minName, minPath := 12, 14, 10
-    idW, nameW, pathW = 16, 20, 14
-    baseTotal := idW + nameW + pathW
-
-    if contentWidth < (minID + minName + minPath) {
-        return 0, 0, 0, true
-    }
-
-    if contentWidth < baseTotal {
-        // shrink in order: id -> name -> path
-        over := baseTotal - contentWidth
-        shrink := func(cur *int, min int, want int) {
-            if over <= 0 {
-                return
-            }
-            can := *cur - min
-            if can <= 0 {
-                return
-            }
-            delta := want
-            if delta > can {
-                delta = can
-            }
-            if delta > over {
-                delta = over
-            }
-            *cur -= delta
-            over -= delta
-        }
-
-        shrink(&idW, minID, 8)
-        shrink(&nameW, minName, 8)
-        shrink(&pathW, minPath, 8)
-
-        if over > 0 {
-            return 0, 0, 0, true
-        }
-    } else if contentWidth > baseTotal {
-        // distribute extra space conservatively to keep deterministic truncation
-        extra := contentWidth - baseTotal
-        grow := func(cur *int, cap int) {
-            if extra <= 0 {
-                return
-            }
-            take := cap
-            if take > extra {
-                take = extra
-            }
-            *cur += take
-            extra -= take
-        }
-        // Limit Name to 24, Path to 16 at 80 cols (ID stays 16)
-        grow(&nameW, 4)  // 20 -> up to 24
-        grow(&idW, 0)    // keep at 16
-        grow(&pathW, 2)  // 14 -> up to 16
-    }
-
-    return idW, nameW, pathW, false
-}
-
 func hideCursor(w io.Writer) { fmt.Fprint(w, "\x1b[?25l") }
 func showCursor(w io.Writer) { fmt.Fprint(w, "\x1b[?25h") }
 
@@ -150,6 +85,30 @@ func alPrintf(w io.Writer, format string, a ...interface{}) {
     fmt.Fprintf(w, format, a...)
 }
 
+// columnZeroWriter is alPrintf as an io.Writer: every line it writes starts at column 0
+type columnZeroWriter struct {
+    w       io.Writer
+    midLine bool
+}
+
+func (c *columnZeroWriter) Write(p []byte) (int, error) {
+    var buf bytes.Buffer
+    for _, b := range p {
+        if !c.midLine {
+            buf.WriteString("\x1b[0G")
+            c.midLine = true
+        }
+        buf.WriteByte(b)
+        if b == '\n' {
+            c.midLine = false
+        }
+    }
+    if _, err := c.w.Write(buf.Bytes()); err != nil {
+        return 0, err
+    }
+    return len(p), nil
+}
+
 // NewAPICommand creates the 'tyk api' command and its subcommands
 func NewAPICommand() *cobra.Command {
 	apiCmd := &cobra.Command{
@@ -161,11 +120,37 @@ func NewAPICommand() *cobra.Command {
 	// Add API subcommands
 	apiCmd.AddCommand(NewAPIListCommand())
 	apiCmd.AddCommand(NewAPIGetCommand())
+	apiCmd.AddCommand(NewAPIDiffCommand())
+	apiCmd.AddCommand(NewAPIBreakingCommand())
+	apiCmd.AddCommand(NewAPIPromoteCommand())
+	apiCmd.AddCommand(NewAPICloneCommand())
+	apiCmd.AddCommand(NewAPISearchCommand())
 	apiCmd.AddCommand(NewAPICreateCommand())
 	apiCmd.AddCommand(NewAPIImportOASCommand())
+	apiCmd.AddCommand(NewAPIImportPostmanCommand())
+	apiCmd.AddCommand(NewAPIImportProtoCommand())
+	apiCmd.AddCommand(NewAPICreateTCPCommand())
+	apiCmd.AddCommand(NewAPICreateStreamCommand())
+	apiCmd.AddCommand(NewAPIExportPostmanCommand())
+	apiCmd.AddCommand(NewAPIExportK8sCommand())
 	apiCmd.AddCommand(NewAPIApplyCommand())
 	apiCmd.AddCommand(NewAPIUpdateOASCommand())
 	apiCmd.AddCommand(NewAPIDeleteCommand())
+	apiCmd.AddCommand(NewAPIUndeleteCommand())
+	apiCmd.AddCommand(NewAPIGCCommand())
+	apiCmd.AddCommand(NewAPIMiddlewareCommand())
+	apiCmd.AddCommand(NewAPIHeadersCommand())
+	apiCmd.AddCommand(NewAPISetRateLimitCommand())
+	apiCmd.AddCommand(NewAPISetInternalCommand())
+	apiCmd.AddCommand(NewAPISetOwnerCommand())
+	apiCmd.AddCommand(NewAPILoopCommand())
+	apiCmd.AddCommand(NewAPITryCommand())
+	apiCmd.AddCommand(NewAPIURLCommand())
+	apiCmd.AddCommand(NewAPICategoryCommand())
+	apiCmd.AddCommand(NewAPIHistoryCommand())
+	apiCmd.AddCommand(NewAPIRollbackCommand())
+	apiCmd.AddCommand(NewAPIDeprecateCommand())
+	apiCmd.AddCommand(NewAPIRetireCommand())
 	// Note: Versioning commands moved to post-v0
 
 	return apiCmd
@@ -239,6 +224,8 @@ Examples:
     --listen-path /payments/v2 --custom-domain api.company.com
   tyk api create --name "Analytics API" --upstream-url https://analytics.service \
     --description "Customer analytics and reporting" --version-name v2
+  tyk api create --name "Edge API" --upstream-url https://edge.internal --gateway-tags edge,eu-west
+  tyk api create --name "Refunds" --upstream-url https://refunds.internal --category team-payments
 
 After creation, you can:
   tyk api get <api-id>                           # View full configuration
@@ -253,6 +240,10 @@ After creation, you can:
 	cmd.Flags().String("version-name", "v1", "Version name for the API")
 	cmd.Flags().String("custom-domain", "", "Custom domain for the API")
 	cmd.Flags().String("description", "", "API description")
+	cmd.Flags().StringSlice("gateway-tags", nil, "Segment tags pinning the API to specific gateways, e.g. edge,eu-west")
+	cmd.Flags().StringSlice("category", nil, "Dashboard categories to file the API under, e.g. team-payments,pci")
+	cmd.Flags().StringSlice("owner-group", nil, "User group ID to own the API (see 'tyk api set-owner'); repeat for several")
+	cmd.Flags().Bool("internal", false, "Make the API reachable only by looping from other APIs")
 
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("upstream-url")
@@ -268,13 +259,29 @@ func NewAPIGetCommand() *cobra.Command {
 
 By default, returns the full API metadata including Tyk-specific extensions.
 Use --oas-only to get a clean OpenAPI specification without Tyk extensions,
-suitable for use with standard OpenAPI tooling.`,
+suitable for use with standard OpenAPI tooling.
+
+Use --fields to output only parts of the document, each a dot-separated path; objects
+on the way keep only the selected keys. Fields missing from the document are reported
+on stderr.
+
+Use --cached to read the definition 'tyk prefetch' cached instead of calling the
+Dashboard; it is as old as the last prefetch.
+
+Examples:
+  tyk api get <api-id> --oas-only > openapi.yaml
+  tyk api get <api-id> --fields info,servers,x-tyk-api-gateway.upstream
+  tyk api get <api-id> --cached`,
 		Args:  cobra.ExactArgs(1),
 		RunE:  runAPIGet,
 	}
 
 	cmd.Flags().String("version-name", "", "Specific version name to retrieve")
 	cmd.Flags().Bool("oas-only", false, "Return only the OpenAPI specification without Tyk extensions")
+	cmd.Flags().StringSlice("fields", nil, "Output only these comma-separated document fields, e.g. info,x-tyk-api-gateway.upstream")
+	cmd.Flags().Bool("cached", false, "Read the definition cached by 'tyk prefetch' instead of the Dashboard")
+	cmd.MarkFlagsMutuallyExclusive("fields", "oas-only")
+	cmd.MarkFlagsMutuallyExclusive("cached", "version-name")
 
 	return cmd
 }
@@ -291,17 +298,82 @@ automatically generated Tyk extensions. Always creates a new API ID.
 Supports:
 - Local files: --file petstore.yaml
 - Remote URLs: --url https://api.example.com/openapi.json
+- Manifests: --manifest apis.yaml, importing many specs in one run
+
+The listen path is derived from the title and the upstream from the first server;
+override them, or any other generated setting, with the flags below. When the spec
+lists several servers and no --upstream-url is given, you are asked which one to use
+(or warned, when not running in a terminal).
+
+--auth enables authentication, reusing a matching security scheme from the spec when
+there is one. Imports that end up keyless print a warning unless --auth none is given.
+
+A manifest lists spec files and URLs, and Kubernetes or Consul sources whose services
+are imported from the spec they serve, with themselves as the upstream. Entries take
+the overrides above (listen_path, upstream_url, custom_domain, inactive, internal,
+tags, categories, auth); defaults apply to every entry. APIs are matched to existing
+ones by name, so re-running a manifest updates them in place:
+
+  defaults:
+    tags: [edge]
+    auth: apikey
+  apis:
+    - url: https://users.internal/openapi.json
+      listen_path: /users/
+    - file: specs/orders.yaml
+      categories: [payments]
+  discovery:
+    kubernetes:
+      namespace: shop
+      label_selector: tyk.io/import=true
+      spec_path: /openapi.json
+    consul:
+      address: http://consul.internal:8500
+      tag: openapi
+
+Kubernetes services can override spec_path and the listen path with the
+tyk.io/spec-path and tyk.io/listen-path annotations; Consul services with the
+tyk-spec-path and tyk-listen-path service meta.
+
+With --strict-yaml a spec, or every spec of a manifest, fails to import when it has
+YAML other parsers read differently, such as unquoted on/off or a version like 1.10.
+
+For Tyk-enhanced OAS files, use 'tyk api apply' instead.
 
-For Tyk-enhanced OAS files, use 'tyk api apply' instead.`,
+Examples:
+  tyk api import-oas --file petstore.yaml
+  tyk api import-oas --file petstore.yaml --listen-path /pets/ --upstream-url https://pets.internal
+  tyk api import-oas --url https://api.example.com/openapi.json --tag edge --tag eu --inactive
+  tyk api import-oas --file petstore.yaml --auth apikey
+  tyk api import-oas --file petstore.yaml --category team-payments
+  tyk api import-oas --manifest apis.yaml --owner-group <group-id>`,
 		RunE: runAPIImportOAS,
 	}
 
 	cmd.Flags().StringP("file", "f", "", "Path to OpenAPI specification file")
 	cmd.Flags().String("url", "", "URL to OpenAPI specification")
+	cmd.Flags().String("manifest", "", "Import every spec a manifest lists or discovers")
+	addImportFlags(cmd)
+	addValidationReportFlag(cmd)
+	addStrictYAMLFlag(cmd)
 
 	return cmd
 }
 
+// addImportFlags adds the flags that override the generated Tyk extension, and the
+// owner groups, to an import command
+func addImportFlags(cmd *cobra.Command) {
+	cmd.Flags().String("listen-path", "", "Listen path (default: derived from the title)")
+	cmd.Flags().String("upstream-url", "", "Upstream URL (default: the spec's first server)")
+	cmd.Flags().String("custom-domain", "", "Custom domain to serve the API on")
+	cmd.Flags().Bool("inactive", false, "Create the API in an inactive state")
+	cmd.Flags().Bool("internal", false, "Make the API reachable only by looping from other APIs")
+	cmd.Flags().StringSlice("tag", nil, "Gateway tag for segmented deployments; repeat for several")
+	cmd.Flags().StringSlice("category", nil, "Dashboard category to file the API under; repeat for several")
+	cmd.Flags().StringSlice("owner-group", nil, "User group ID to own the API (see 'tyk api set-owner'); repeat for several")
+	cmd.Flags().String("auth", "", "Secure the API with apikey, jwt or oauth authentication, or none for keyless")
+}
+
 func NewAPIApplyCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "apply",
@@ -320,13 +392,34 @@ For clean OpenAPI specs without Tyk extensions, use:
 - 'tyk api update-oas <api-id>' to update existing APIs
 
 Examples:
-  tyk api apply --file enhanced-api.yaml    # Idempotent upsert`,
+  tyk api apply --file enhanced-api.yaml    # Idempotent upsert
+  tyk api apply --file enhanced-api.yaml --inject-ownership  # Stamp owner from CODEOWNERS/Git
+  tyk api apply --file enhanced-api.yaml --gateway-tags edge,eu-west  # Pin to tagged gateways
+  tyk api apply --file enhanced-api.yaml --owner-group 5f1a2b3c4d5e  # Owned by a user group (RBAC)
+
+Extension features the environment's Tyk release does not support (see 'tyk config set
+--tyk-version') fail the apply before anything is changed; --skip-compat-check bypasses it.
+
+With --validation-report <file> the spec is validated before it is uploaded, and when it
+is rejected, by that validation or by the Dashboard, every problem found in it is written
+to the file (with line numbers) rather than only the first.
+
+Unquoted YAML values of string fields, such as "version: 1.10" or "openapi: 3.0", are
+kept as written. --strict-yaml fails on YAML other parsers read differently instead:
+unquoted on/off/yes/no, numbers with leading zeros or colons, non-string keys such as
+unquoted response codes, and numbers in string fields.`,
 		RunE: runAPIApply,
 	}
 
 	cmd.Flags().StringP("file", "f", "", "Path to Tyk-enhanced OpenAPI specification file (use '-' for stdin) (required)")
     cmd.Flags().String("version-name", "", "Version name (defaults to info.version or v1)")
     cmd.Flags().Bool("set-default", true, "Set this version as the default")
+	cmd.Flags().Bool("inject-ownership", false, "Fill info.contact from CODEOWNERS or Git metadata, and add the owning team as a category, before applying")
+	cmd.Flags().StringSlice("gateway-tags", nil, "Segment tags pinning the API to specific gateways, replacing any in the file")
+	cmd.Flags().StringSlice("owner-group", nil, "User group ID to own the API, replacing its owner groups (see 'tyk api set-owner'); repeat for several")
+	cmd.Flags().Bool("skip-compat-check", false, "Do not check the spec against the environment's Tyk version")
+	addValidationReportFlag(cmd)
+	addStrictYAMLFlag(cmd)
 
 	cmd.MarkFlagRequired("file")
 
@@ -354,20 +447,39 @@ For full API updates including Tyk config, use 'tyk api apply' instead.`,
 
 	cmd.Flags().StringP("file", "f", "", "Path to OpenAPI specification file")
 	cmd.Flags().String("url", "", "URL to OpenAPI specification")
+	addValidationReportFlag(cmd)
+	addStrictYAMLFlag(cmd)
 
 	return cmd
 }
 
 func NewAPIDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete <api-id>",
-		Short: "Delete an API by ID",
-		Long:  "Delete an OAS API by its ID with confirmation prompt",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runAPIDelete,
+		Use:   "delete [api-id]",
+		Short: "Delete an API by ID, or every API matching a filter",
+		Long: `Delete an OAS API by its ID with confirmation prompt.
+
+With --filter, every API matching all of the given conditions is previewed and then
+deleted in bulk. Conditions take the form <field><op><value> where field is id, name,
+listen_path or status and op is = (equals), != (not equals), ~ (regex) or !~ (regex does not match).
+Bulk deletes run at most --rate deletions per second, so a mistaken selector can
+be interrupted before it empties the environment.
+
+The definition of every deleted API is first copied to a local trash, from which
+'tyk api undelete <api-id>' re-creates it for 7 days (TYK_TRASH_RETENTION);
+--no-trash skips the copy.
+
+Examples:
+  tyk api delete 4c1b8a7e2f3d4a5b --yes
+  tyk api delete --filter 'name~^test-' --yes
+  tyk api delete --filter 'name~^it-' --filter 'listen_path!=/keep/' --rate 2`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAPIDelete,
 	}
 
-	cmd.Flags().Bool("yes", false, "Skip confirmation prompt")
+	cmd.Flags().StringArray("filter", nil, "Delete all APIs matching <field><op><value> (repeatable; conditions are ANDed)")
+	cmd.Flags().Float64("rate", 5, "Maximum deletions per second with --filter (0 for no limit)")
+	cmd.Flags().Bool("no-trash", false, "Delete without keeping a copy for 'tyk api undelete'")
 
 	return cmd
 }
@@ -377,12 +489,40 @@ func NewAPIListCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List OAS APIs",
-		Long:  "List OAS APIs in the Dashboard, paginated with optional interactive navigation",
-		RunE:  runAPIList,
+		Long: `List OAS APIs in the Dashboard, paginated with optional interactive navigation.
+
+--page-size changes how many APIs a page holds, and --all lists every API, fetching
+the pages in turn; table output streams as pages arrive, so '--all -o json' dumps
+the whole catalog in one document.
+
+--filter keeps APIs whose name or listen path contains a term, or that match a
+<field><op><value> condition as in 'tyk api delete --filter', --category keeps
+APIs in any of the given Dashboard categories, and --status keeps APIs with that
+status. These conditions apply to the whole catalog: every API is fetched and
+--page counts pages of the matching ones. --sort orders the
+listed APIs by name, created or updated time, ascending unless --order desc; sorting
+happens client-side on the page shown, or on the whole catalog with --all.
+
+--refresh-cache also lists every API and caches their metadata locally (in the user
+cache directory, or TYK_API_CACHE_DIR). Shell completion of API IDs and
+'tyk api search --cached' read that cache instead of calling the Dashboard.`,
+		RunE: runAPIList,
 	}
 
-	cmd.Flags().Int("page", 1, "Page number (10 per page)")
+	cmd.Flags().Int("page", 1, "Page number")
+	cmd.Flags().Int("page-size", client.DefaultPageSize, "Number of APIs per page")
+	cmd.Flags().Bool("all", false, "List every API instead of one page")
 	cmd.Flags().BoolP("interactive", "i", false, "Enable interactive pagination with arrow key navigation")
+	cmd.Flags().String("status", "", "Only show APIs with this status: active, inactive or internal")
+	cmd.Flags().StringSlice("category", nil, "Only show APIs in any of these Dashboard categories (see 'tyk api category')")
+	cmd.Flags().StringArray("filter", nil, "Only show APIs whose name or listen path contains a term, or matching <field><op><value> (repeatable)")
+	cmd.Flags().String("sort", "", "Sort by name, created or updated")
+	cmd.Flags().String("order", "asc", "Sort order: asc or desc")
+	cmd.Flags().Bool("no-truncate", false, "Never shorten names to fit the terminal; rows that do not fit are stacked")
+	cmd.Flags().Bool("refresh-cache", false, "Also refresh the local API cache used by completion and search --cached")
+	addListOutputFlags(cmd)
+	cmd.MarkFlagsMutuallyExclusive("all", "page")
+	cmd.MarkFlagsMutuallyExclusive("all", "interactive")
 
 	return cmd
 }
@@ -391,10 +531,44 @@ func NewAPIListCommand() *cobra.Command {
 func runAPIList(cmd *cobra.Command, args []string) error {
 	page, _ := cmd.Flags().GetInt("page")
 	interactive, _ := cmd.Flags().GetBool("interactive")
+	status, _ := cmd.Flags().GetString("status")
+	refreshCache, _ := cmd.Flags().GetBool("refresh-cache")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+	all, _ := cmd.Flags().GetBool("all")
+	noTruncate, _ := cmd.Flags().GetBool("no-truncate")
+	filterExprs, _ := cmd.Flags().GetStringArray("filter")
+	categories, _ := cmd.Flags().GetStringSlice("category")
+	sortKey, _ := cmd.Flags().GetString("sort")
+	order, _ := cmd.Flags().GetString("order")
+
+	switch status {
+	case "", types.APIStatusActive, types.APIStatusInactive, types.APIStatusInternal:
+	default:
+		return &ExitError{Code: 2, Message: fmt.Sprintf("invalid --status '%s': must be active, inactive or internal", status)}
+	}
 	
 	if page <= 0 {
 		page = 1
 	}
+	if pageSize <= 0 {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("invalid --page-size %d", pageSize)}
+	}
+	switch sortKey {
+	case "", sortByName, sortByCreated, sortByUpdated:
+	default:
+		return &ExitError{Code: 2, Message: fmt.Sprintf("invalid --sort '%s': must be name, created or updated", sortKey)}
+	}
+	if order != "asc" && order != "desc" {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("invalid --order '%s': must be asc or desc", order)}
+	}
+	filters, err := parseAPIListFilters(filterExprs)
+	if err != nil {
+		return err
+	}
+	if err := checkCategories(categories); err != nil {
+		return err
+	}
+	query := &apiListQuery{status: status, categories: categories, filters: filters, sortKey: sortKey, desc: order == "desc"}
 
 	// Get configuration from context
 	config := GetConfigFromContext(cmd.Context())
@@ -403,7 +577,7 @@ func runAPIList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
@@ -411,43 +585,185 @@ func runAPIList(cmd *cobra.Command, args []string) error {
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
+	listOutput, err := getListOutputOptions(cmd)
+	if err != nil {
+		return err
+	}
+
 	// If interactive mode is requested, switch to interactive pagination
 	if interactive {
-		if outputFormat == types.OutputJSON {
-			return fmt.Errorf("interactive mode is not compatible with JSON output format")
+		if listOutput != nil || status != "" || len(categories) > 0 || len(filters) > 0 || sortKey != "" {
+			return &ExitError{Code: 2, Message: "interactive mode is not compatible with --columns, --template, --status, --category, --filter or --sort"}
 		}
-		return runInteractiveAPIList(c, page)
+		if outputFormat.IsStructured() {
+			return fmt.Errorf("interactive mode is not compatible with JSON or YAML output")
+		}
+		return runInteractiveAPIList(c, config, page, pageSize, noTruncate)
 	}
 
     // Non-interactive mode (existing behavior)
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	budget := 30 * time.Second
+	if all || query.filtering() {
+		budget = 5 * time.Minute
+	}
+	ctx, cancel := apiContext(config, budget)
 	defer cancel()
 
-    // Use dashboard aggregate endpoint for broader compatibility in CLI
-    apis, err := c.ListAPIsDashboard(ctx, page)
-	if err != nil {
-		return fmt.Errorf("failed to list APIs: %w", err)
+	if refreshCache {
+		cache, err := c.RefreshAPICache(ctx)
+		if err != nil {
+			return wrapAPIError(err, "failed to refresh API cache")
+		}
+		fmt.Fprintf(os.Stderr, "Cached %d API(s) for environment '%s'\n", len(cache.APIs), cache.Environment)
 	}
 
-	if outputFormat == types.OutputJSON {
+	if all {
+		return listAllAPIs(ctx, c, query, listOutput, outputFormat, noTruncate)
+	}
+
+	var apis []*types.OASAPI
+	if query.filtering() {
+		// A page of the Dashboard's list would only hold the matches on that page
+		all, err := c.ListAllAPIs(ctx)
+		if err != nil {
+			return wrapAPIError(err, "failed to list APIs")
+		}
+		apis = pageOf(query.apply(all), page, pageSize)
+	} else {
+		// Use dashboard aggregate endpoint for broader compatibility in CLI
+		apis, err = c.ListAPIsPage(ctx, page, pageSize)
+		if err != nil {
+			return wrapAPIError(err, "failed to list APIs")
+		}
+		apis = query.apply(apis)
+	}
+
+	if outputFormat.IsStructured() {
+		if apis == nil {
+			apis = []*types.OASAPI{}
+		}
 		payload := map[string]interface{}{
-			"page":  page,
-			"count": len(apis),
-			"apis":  apis,
+			"page":      page,
+			"page_size": pageSize,
+			"count":     len(apis),
+			"apis":      apis,
 		}
-		encoder := json.NewEncoder(os.Stdout)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(payload)
+		return writeStructured(outputFormat, payload)
+	}
+
+	if listOutput != nil {
+		return listOutput.render(os.Stdout, apis)
+	}
+
+	if outputFormat == types.OutputWide {
+		displayAPIPageWide(apis, page, noTruncate)
+		return nil
 	}
 
 	// Human readable output
-	displayAPIPage(apis, page, false)
+	displayAPIPage(apis, page, false, noTruncate)
+	return nil
+}
+
+// apiListQuery holds the --status, --filter and --sort settings of 'tyk api list'
+type apiListQuery struct {
+	status     string
+	categories []string
+	filters    []*apiFilter
+	sortKey    string
+	desc       bool
+}
+
+// filtering reports whether the query has status, category or filter conditions
+func (q *apiListQuery) filtering() bool {
+	return q.status != "" || len(q.categories) > 0 || len(q.filters) > 0
+}
+
+// pageOf returns page (from 1) of apis split into pages of size
+func pageOf(apis []*types.OASAPI, page, size int) []*types.OASAPI {
+	start := (page - 1) * size
+	if start >= len(apis) {
+		return []*types.OASAPI{}
+	}
+	return apis[start:min(start+size, len(apis))]
+}
+
+// match returns the APIs passing the status, category and filter conditions
+func (q *apiListQuery) match(apis []*types.OASAPI) []*types.OASAPI {
+	if q.status != "" {
+		apis = filterAPIsByStatus(apis, q.status)
+	}
+	if len(q.categories) > 0 {
+		apis = filterAPIsByCategory(apis, q.categories)
+	}
+	if len(q.filters) > 0 {
+		apis = filterAPIs(apis, q.filters)
+	}
+	return apis
+}
+
+// apply filters and sorts APIs
+func (q *apiListQuery) apply(apis []*types.OASAPI) []*types.OASAPI {
+	apis = q.match(apis)
+	if q.sortKey != "" {
+		sortAPIs(apis, q.sortKey, q.desc)
+	}
+	return apis
+}
+
+// listAllAPIs lists every API page by page. Tables are streamed as pages arrive;
+// sorted, structured and --columns/--template output need the whole list, so it is
+// collected and rendered once.
+func listAllAPIs(ctx context.Context, c *client.Client, query *apiListQuery, listOutput *listOutputOptions, outputFormat types.OutputFormat, noTruncate bool) error {
+	streaming := listOutput == nil && !outputFormat.IsStructured() && query.sortKey == ""
+	wide := outputFormat == types.OutputWide
+	tbl := newAPITable(wide, noTruncate)
+	apis := []*types.OASAPI{}
+	count := 0
+	err := c.EachAPIPage(ctx, 1, func(batch []*types.OASAPI) error {
+		batch = query.match(batch)
+		if !streaming {
+			apis = append(apis, batch...)
+			return nil
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		tbl.Write(os.Stdout, apiTableRows(batch, time.Now()))
+		count += len(batch)
+		return nil
+	})
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	if !streaming {
+		apis = query.apply(apis)
+	}
+
+	switch {
+	case outputFormat.IsStructured():
+		return writeStructured(outputFormat, map[string]interface{}{
+			"page":  0,
+			"all":   true,
+			"count": len(apis),
+			"apis":  apis,
+		})
+	case listOutput != nil:
+		return listOutput.render(os.Stdout, apis)
+	case !streaming && len(apis) > 0:
+		tbl.Write(os.Stdout, apiTableRows(apis, time.Now()))
+		fmt.Fprintf(os.Stderr, "\n%d API(s)\n", len(apis))
+	case count == 0 && len(apis) == 0:
+		fmt.Fprintln(os.Stderr, "No APIs found.")
+	default:
+		fmt.Fprintf(os.Stderr, "\n%d API(s)\n", count)
+	}
 	return nil
 }
 
 // displayAPIPage displays a page of APIs in a formatted table
-func displayAPIPage(apis []*types.OASAPI, page int, interactive bool) {
+func displayAPIPage(apis []*types.OASAPI, page int, interactive, noTruncate bool) {
 	if len(apis) == 0 {
 		if interactive {
 			fmt.Fprintf(os.Stderr, "\033[2J\033[H")
@@ -467,47 +783,26 @@ func displayAPIPage(apis []*types.OASAPI, page int, interactive bool) {
         // Clear screen and move cursor to home
         fmt.Fprintf(os.Stderr, "\033[2J\033[H")
 
-        // Determine terminal width (fallback to 80)
-        termWidth := 80
-        if w, _, err := term.GetSize(int(os.Stderr.Fd())); err == nil && w > 0 {
-            termWidth = w
-        }
-        idW, nameW, pathW, stacked := computeTableLayout(termWidth)
-
         // Fixed header width for consistent test expectations
         fixedHeader := 80
         alPrintf(os.Stderr, "%s\n", strings.Repeat("=", fixedHeader))
         color.New(color.FgBlue, color.Bold).Fprintf(os.Stderr, "APIs (page %d)\n", page)
         alPrintf(os.Stderr, "%s\n\n", strings.Repeat("=", fixedHeader))
 
-        if stacked {
-            for _, api := range apis {
-                // Do not truncate the API ID or listen path
-                alPrintf(os.Stderr, "ID: %s\n", api.ID)
-                alPrintf(os.Stderr, "Name: %s\n", truncateWithEllipsis(api.Name, 48))
-                alPrintf(os.Stderr, "Listen Path: %s\n", api.ListenPath)
-                alPrintf(os.Stderr, "%s\n", strings.Repeat("-", 32))
-            }
-        } else {
-            // Table header and divider with color
-            hdr := color.New(color.FgCyan, color.Bold)
-            dim := color.New(color.FgHiBlack)
-            headerLine := fmt.Sprintf("%-*s | %-*s | %-*s", idW, "ID", nameW, "Name", pathW, "Listen Path")
-            fmt.Fprint(os.Stderr, "\x1b[0G")
-            hdr.Fprintln(os.Stderr, headerLine)
-            dividerLine := fmt.Sprintf("%s | %s | %s", strings.Repeat("-", idW), strings.Repeat("-", nameW), strings.Repeat("-", pathW))
-            fmt.Fprint(os.Stderr, "\x1b[0G")
-            dim.Fprintln(os.Stderr, dividerLine)
-
-            // Rows
-            for _, api := range apis {
-                // Do not truncate the API ID or listen path
-                id := api.ID
-                name := truncateWithEllipsis(api.Name, nameW)
-                listenPath := api.ListenPath
-                alPrintf(os.Stderr, "%-*s | %-*s | %-*s\n", idW, id, nameW, name, pathW, listenPath)
-            }
+        // Determine terminal width (fallback to 80)
+        termWidth := terminalWidth(os.Stderr)
+        if termWidth == 0 {
+            termWidth = 80
         }
+        tbl := &table{
+            Columns:      interactiveAPIColumns,
+            Width:        termWidth,
+            NoTruncate:   noTruncate,
+            Separator:    " | ",
+            HeaderColor:  color.New(color.FgCyan, color.Bold),
+            DividerColor: color.New(color.FgHiBlack),
+        }
+        tbl.Write(&columnZeroWriter{w: os.Stderr}, apiTableRows(apis, time.Now()))
 
         dim := color.New(color.FgHiBlack)
         alPrintf(os.Stderr, "\n%s\n", strings.Repeat("=", fixedHeader))
@@ -522,17 +817,78 @@ func displayAPIPage(apis []*types.OASAPI, page int, interactive bool) {
         green := color.New(color.FgGreen, color.Bold)
 		
 		blue.Fprintf(os.Stderr, "APIs (page %d):\n", page)
-		fmt.Fprintf(os.Stdout, "%-36s  %-28s  %-18s  %s\n", "ID", "Name", "Listen Path", "Default Version")
-		fmt.Fprintf(os.Stdout, "%s\n", strings.Repeat("-", 36+2+28+2+18+2+16))
-		for _, api := range apis {
-			fmt.Fprintf(os.Stdout, "%-36s  %-28s  %-18s  %s\n", api.ID, api.Name, api.ListenPath, api.DefaultVersion)
-		}
+		printAPITable(apis, false, noTruncate)
 		green.Fprintf(os.Stderr, "\nUse '--page %d' for next page.\n", page+1)
 	}
 }
 
+// displayAPIPageWide displays a page of APIs with AGE and UPDATED columns
+func displayAPIPageWide(apis []*types.OASAPI, page int, noTruncate bool) {
+	if len(apis) == 0 {
+		fmt.Fprintf(os.Stderr, "No APIs found on page %d.\n", page)
+		return
+	}
+
+	color.New(color.FgBlue, color.Bold).Fprintf(os.Stderr, "APIs (page %d):\n", page)
+	printAPITable(apis, true, noTruncate)
+	color.New(color.FgGreen, color.Bold).Fprintf(os.Stderr, "\nUse '--page %d' for next page.\n", page+1)
+}
+
+// Columns of the API tables: the interactive view keeps to ID, name and listen path,
+// and the wide table adds AGE and UPDATED. Only names are truncated to fit.
+var (
+	apiColumns = []tableColumn{{Header: "ID"}, {Header: "Name", Min: 14}, {Header: "Listen Path"}, {Header: "Default Version"}, {Header: "STATUS"}}
+	apiWideColumns        = append(apiColumns[:len(apiColumns):len(apiColumns)], tableColumn{Header: "AGE"}, tableColumn{Header: "UPDATED"})
+	interactiveAPIColumns = apiColumns[:3]
+)
+
+// newAPITable returns the standard or wide API table for stdout
+func newAPITable(wide, noTruncate bool) *table {
+	columns := apiColumns
+	if wide {
+		columns = apiWideColumns
+	}
+	return &table{Columns: columns, Width: terminalWidth(os.Stdout), NoTruncate: noTruncate, Separator: "  "}
+}
+
+// apiTableRows returns the cells of every API table column, wide ones included
+func apiTableRows(apis []*types.OASAPI, now time.Time) [][]string {
+	rows := make([][]string, 0, len(apis))
+	for _, api := range apis {
+		rows = append(rows, []string{api.ID, api.Name, api.ListenPath, api.DefaultVersion, api.Status(),
+			formatAge(api.CreatedAt, now), formatAge(api.UpdatedAt, now)})
+	}
+	return rows
+}
+
+// printAPITable writes the standard or wide API table to stdout
+func printAPITable(apis []*types.OASAPI, wide, noTruncate bool) {
+	newAPITable(wide, noTruncate).Write(os.Stdout, apiTableRows(apis, time.Now()))
+}
+
+// formatAge renders the time elapsed since an RFC3339 timestamp compactly (45s, 12m, 5h, 3d)
+func formatAge(timestamp string, now time.Time) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return "-"
+	}
+	age := now.Sub(t)
+	switch {
+	case age < 0:
+		return "0s"
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
 // runInteractiveAPIList handles the interactive pagination mode
-func runInteractiveAPIList(c *client.Client, startPage int) error {
+func runInteractiveAPIList(c *client.Client, config *types.Config, startPage, pageSize int, noTruncate bool) error {
     // Make sure we're in a terminal that supports interactive input
     if !term.IsTerminal(int(os.Stdin.Fd())) {
         return fmt.Errorf("interactive mode requires a terminal")
@@ -555,17 +911,17 @@ func runInteractiveAPIList(c *client.Client, startPage int) error {
 	
 	for {
 		// Create context with timeout for each API call
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := apiContext(config, 30*time.Second)
         // Use dashboard endpoint for interactive listing as well
-        apis, err := c.ListAPIsDashboard(ctx, currentPage)
+        apis, err := c.ListAPIsPage(ctx, currentPage, pageSize)
 		cancel()
 		
 		if err != nil {
-			return fmt.Errorf("failed to list APIs: %w", err)
+			return wrapAPIError(err, "failed to list APIs")
 		}
 
 		// Display current page
-		displayAPIPage(apis, currentPage, true)
+		displayAPIPage(apis, currentPage, true, noTruncate)
 
         // Read a single keystroke (robust arrow handling)
         key, err := readKey(os.Stdin)
@@ -601,6 +957,7 @@ func runAPIGet(cmd *cobra.Command, args []string) error {
 	apiID := args[0]
 	versionName, _ := cmd.Flags().GetString("version-name")
 	oasOnly, _ := cmd.Flags().GetBool("oas-only")
+	fields, _ := cmd.Flags().GetStringSlice("fields")
 
 	// Get configuration from context
 	config := GetConfigFromContext(cmd.Context())
@@ -609,40 +966,50 @@ func runAPIGet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
 	// Get the API
-	api, err := c.GetOASAPI(ctx, apiID, versionName)
-	if err != nil {
+	var api *types.OASAPI
+	if cached, _ := cmd.Flags().GetBool("cached"); cached {
+		spec, err := c.CachedAPISpec(apiID)
+		if errors.Is(err, client.ErrNoCachedSpec) {
+			return notFoundError(err, fmt.Sprintf("API '%s' is not cached for environment '%s'; run 'tyk prefetch'", apiID, c.Environment()))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read API cache: %w", err)
+		}
+		api = spec.API
+	} else if api, err = c.GetOASAPI(ctx, apiID, versionName); err != nil {
 		// Check if it's a not found error
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
-			return &ExitError{Code: 3, Message: fmt.Sprintf("API '%s' not found", apiID)}
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
 		}
-		return fmt.Errorf("failed to get API: %w", err)
+		return wrapAPIError(err, "failed to get API")
 	}
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputAPIAsJSON(api, oasOnly)
+	if len(fields) > 0 {
+		return outputAPIFields(outputFormat, api, versionName, fields)
+	}
+
+	if outputFormat.IsStructured() {
+		return outputAPIStructured(outputFormat, api, oasOnly)
 	}
 
 	return outputAPIAsHuman(api, versionName, oasOnly)
 }
 
-// outputAPIAsJSON outputs the API in JSON format
-func outputAPIAsJSON(api *types.OASAPI, oasOnly bool) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	
+// outputAPIStructured outputs the API as JSON or YAML
+func outputAPIStructured(format types.OutputFormat, api *types.OASAPI, oasOnly bool) error {
 	if oasOnly && api.OAS != nil {
 		// Strip the x-tyk-api-gateway extension and return only the OAS
 		oasData := make(map[string]interface{})
@@ -651,10 +1018,40 @@ func outputAPIAsJSON(api *types.OASAPI, oasOnly bool) error {
 				oasData[key] = value
 			}
 		}
-		return encoder.Encode(oasData)
+		return writeStructured(format, oasData)
 	}
 	
-	return encoder.Encode(api)
+	return writeStructured(format, api)
+}
+
+// outputAPIFields outputs the selected fields of the API's OAS document: as JSON or
+// YAML for structured formats and as YAML otherwise
+func outputAPIFields(format types.OutputFormat, api *types.OASAPI, versionName string, fields []string) error {
+	doc := api.OAS
+	if versionData, ok := api.VersionData[versionName]; ok && versionData.OAS != nil {
+		doc = versionData.OAS
+	}
+	if doc == nil {
+		return notFoundError(nil, fmt.Sprintf("API '%s' has no OAS document", api.ID))
+	}
+
+	selected, missing := oas.SelectFields(doc, fields)
+	for _, field := range missing {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Field '%s' not found\n", field)
+	}
+	if len(selected) == 0 {
+		return notFoundError(nil, "none of the requested fields exist in the document")
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, selected)
+	}
+	data, err := yaml.Marshal(selected)
+	if err != nil {
+		return fmt.Errorf("failed to convert fields to YAML: %w", err)
+	}
+	fmt.Print(string(data))
+	return nil
 }
 
 // outputAPIAsHuman outputs the API in human-readable format
@@ -767,44 +1164,70 @@ func runAPIImportOAS(cmd *cobra.Command, args []string) error {
 	// Get flags
 	filePath, _ := cmd.Flags().GetString("file")
 	urlFlag, _ := cmd.Flags().GetString("url")
+	manifestPath, _ := cmd.Flags().GetString("manifest")
 
-	// Validate input: either file or url must be provided
-	if filePath == "" && urlFlag == "" {
-		return &ExitError{Code: 2, Message: "Either --file or --url must be provided"}
+	// Validate input: exactly one of file, url or manifest must be provided
+	if filePath == "" && urlFlag == "" && manifestPath == "" {
+		return &ExitError{Code: 2, Message: "Either --file or --url must be provided, or --manifest to import several specs"}
 	}
 	if filePath != "" && urlFlag != "" {
 		return &ExitError{Code: 2, Message: "Cannot specify both --file and --url"}
 	}
+	if manifestPath != "" && (filePath != "" || urlFlag != "") {
+		return &ExitError{Code: 2, Message: "--manifest cannot be combined with --file or --url"}
+	}
 
 	// Get configuration from context
 	config := GetConfigFromContext(cmd.Context())
 	if config == nil {
 		return fmt.Errorf("configuration not found")
 	}
+	if err := checkOwnerGroups(cmd, config); err != nil {
+		return err
+	}
+	if manifestPath != "" {
+		return runAPIImportManifest(cmd, config, manifestPath)
+	}
 
 	// Load OAS data from file or URL
-	var oasData map[string]interface{}
-	var err error
-
-	if filePath != "" {
-		// Load from file
-		oasData, err = loadOASFromFile(filePath)
-	} else {
-		// Load from URL
-		oasData, err = loadOASFromURL(urlFlag)
-	}
+	oasData, err := loadUploadSpec(cmd, filePath, urlFlag)
 	if err != nil {
 		return err
 	}
 
-	// Auto-generate x-tyk-api-gateway extensions for plain OAS documents
-	if !oas.HasTykExtensions(oasData) {
-		oasData, err = oas.AddTykExtensions(oasData)
-		if err != nil {
-			return &ExitError{Code: 2, Message: fmt.Sprintf("failed to generate Tyk extensions: %v", err)}
+	return importOASDocument(cmd, config, oasData, nil)
+}
+
+// importOASDocument creates a new API from an OAS document, generating its Tyk
+// extension from the import flags; adjust, when given, can change the options the
+// flags make before they are applied
+func importOASDocument(cmd *cobra.Command, config *types.Config, oasData map[string]interface{}, adjust func(*oas.ExtensionOptions)) error {
+	opts, err := extensionOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if adjust != nil {
+		adjust(&opts)
+	}
+	if opts.UpstreamURL == "" && !oas.HasTykExtensions(oasData) {
+		if opts.UpstreamURL, err = chooseUpstreamURL(oasData, GetOutputFormatFromContext(cmd.Context())); err != nil {
+			return err
 		}
 	}
 
+	// Generate x-tyk-api-gateway extensions for plain OAS documents and apply overrides
+	oasData, err = oas.AddTykExtensionsWithOptions(oasData, opts)
+	if err != nil {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("failed to generate Tyk extensions: %v", err)}
+	}
+	if err := expandOperationRateLimits(oasData); err != nil {
+		return err
+	}
+	if err := mountEnvironmentPrefix(config, fmt.Sprintf("API '%s'", oas.GetAPIName(oasData)), oasData); err != nil {
+		return err
+	}
+	warnImportedAuth(oasData, opts.Auth)
+
 	// Strip any existing API ID from OAS file (import always generates new ID)
 	oasData = stripExistingAPIID(oasData)
 
@@ -815,35 +1238,134 @@ func runAPIImportOAS(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
 	// Create the API
+	if err := validateBeforeUpload(cmd, oasData); err != nil {
+		return err
+	}
+	stopSpinner := startSpinner(cmd, "Creating API...")
 	api, err := c.CreateOASAPI(ctx, oasData)
+	stopSpinner()
 	if err != nil {
 		// Check for conflict errors
-		if strings.Contains(err.Error(), "409") || strings.Contains(err.Error(), "conflict") {
-			return &ExitError{Code: 4, Message: fmt.Sprintf("API import failed due to conflict: %v", err)}
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API import failed")
 		}
-		return fmt.Errorf("failed to import API: %w", err)
+		return uploadError(cmd, oasData, err, "failed to import API")
+	}
+	if err := setOwnerGroups(ctx, cmd, c, api.ID); err != nil {
+		return err
 	}
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputImportedAPIAsJSON(api, versionName)
+	if outputFormat.IsStructured() {
+		return outputImportedAPIStructured(outputFormat, api, versionName)
 	}
 
 	return outputImportedAPIAsHuman(api, versionName)
 }
 
+// extensionOptionsFromFlags reads and checks the import-oas extension override flags
+func extensionOptionsFromFlags(cmd *cobra.Command) (oas.ExtensionOptions, error) {
+	var opts oas.ExtensionOptions
+	opts.ListenPath, _ = cmd.Flags().GetString("listen-path")
+	opts.UpstreamURL, _ = cmd.Flags().GetString("upstream-url")
+	opts.CustomDomain, _ = cmd.Flags().GetString("custom-domain")
+	opts.Inactive, _ = cmd.Flags().GetBool("inactive")
+	opts.Internal, _ = cmd.Flags().GetBool("internal")
+	opts.Tags, _ = cmd.Flags().GetStringSlice("tag")
+	opts.Categories, _ = cmd.Flags().GetStringSlice("category")
+	opts.Auth, _ = cmd.Flags().GetString("auth")
+
+	if opts.ListenPath != "" && !strings.HasPrefix(opts.ListenPath, "/") {
+		return opts, &ExitError{Code: 2, Message: fmt.Sprintf("--listen-path must start with '/' (got '%s')", opts.ListenPath)}
+	}
+	if opts.UpstreamURL != "" {
+		if u, err := url.Parse(opts.UpstreamURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return opts, &ExitError{Code: 2, Message: fmt.Sprintf("--upstream-url must be an absolute URL (got '%s')", opts.UpstreamURL)}
+		}
+	}
+	if strings.ContainsAny(opts.CustomDomain, "/:") {
+		return opts, &ExitError{Code: 2, Message: fmt.Sprintf("--custom-domain must be a host name without scheme or path (got '%s')", opts.CustomDomain)}
+	}
+	if err := checkGatewayTags("--tag", opts.Tags); err != nil {
+		return opts, err
+	}
+	if err := checkCategories(opts.Categories); err != nil {
+		return opts, err
+	}
+	if opts.Auth != "" && !slices.Contains(oas.ScaffoldAuthTypes, opts.Auth) {
+		return opts, &ExitError{Code: 2, Message: fmt.Sprintf("--auth must be one of %s (got '%s')", strings.Join(oas.ScaffoldAuthTypes, ", "), opts.Auth)}
+	}
+	return opts, nil
+}
+
+// checkGatewayTags rejects empty segment tags given to flag
+func checkGatewayTags(flag string, tags []string) error {
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return &ExitError{Code: 2, Message: flag + " must not be empty"}
+		}
+	}
+	return nil
+}
+
+// checkCategories rejects --category values that cannot be kept in an API name
+func checkCategories(categories []string) error {
+	for _, category := range categories {
+		if err := oas.CheckCategory(category); err != nil {
+			return &ExitError{Code: 2, Message: "--category: " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// warnImportedAuth flags imports that would be open to anyone, and JWT scaffolds that
+// still need a signing key before they accept tokens
+func warnImportedAuth(oasData map[string]interface{}, authType string) {
+	yellow := color.New(color.FgYellow)
+	switch {
+	case authType == "" && slices.Equal(oas.AuthModes(oasData), []string{oas.AuthKeyless}):
+		yellow.Fprintln(os.Stderr, "⚠ API has no authentication and will accept any request. Pass --auth apikey|jwt|oauth to secure it, or --auth none to silence this warning.")
+	case authType == oas.ScaffoldJWT:
+		yellow.Fprintln(os.Stderr, "⚠ JWT authentication enabled; set the signing key or JWKS URL (source) in the Dashboard before issuing tokens.")
+	}
+}
+
+// chooseUpstreamURL asks which server to proxy to when a plain spec lists several. Without
+// a terminal (or with structured output) it keeps the first and warns. An empty result
+// means the default first-server choice applies.
+func chooseUpstreamURL(oasData map[string]interface{}, format types.OutputFormat) (string, error) {
+	servers := oas.ServerURLs(oasData)
+	if len(servers) < 2 {
+		return "", nil
+	}
+	if format.IsStructured() || !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Spec lists %d servers; using the first (%s). Pass --upstream-url to choose another.\n", len(servers), servers[0])
+		return "", nil
+	}
+
+	var choice string
+	prompt := &survey.Select{
+		Message: "The spec lists several servers. Which one should the API proxy to?",
+		Options: servers,
+	}
+	if err := survey.AskOne(prompt, &choice, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)); err != nil {
+		return "", fmt.Errorf("upstream selection cancelled: %w", err)
+	}
+	return choice, nil
+}
+
 // extractVersionFromOAS extracts version from OAS info.version field
 func extractVersionFromOAS(oasData map[string]interface{}) string {
 	if info, ok := oasData["info"].(map[string]interface{}); ok {
@@ -854,8 +1376,8 @@ func extractVersionFromOAS(oasData map[string]interface{}) string {
 	return ""
 }
 
-// outputImportedAPIAsJSON outputs the imported API result in JSON format
-func outputImportedAPIAsJSON(api *types.OASAPI, versionName string) error {
+// outputImportedAPIStructured outputs the imported API result as JSON or YAML
+func outputImportedAPIStructured(format types.OutputFormat, api *types.OASAPI, versionName string) error {
 	result := map[string]interface{}{
 		"api_id":          api.ID,
 		"version_name":    versionName,
@@ -863,11 +1385,10 @@ func outputImportedAPIAsJSON(api *types.OASAPI, versionName string) error {
 		"listen_path":     api.ListenPath,
 		"default_version": api.DefaultVersion,
 		"operation":       "imported",
+		"suggestions":     suggestions(apiNextSteps(api, "imported")),
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	return writeStructured(format, result)
 }
 
 // outputImportedAPIAsHuman outputs the imported API result in human-readable format
@@ -889,6 +1410,7 @@ func outputImportedAPIAsHuman(api *types.OASAPI, versionName string) error {
 	}
 
 	blue.Printf("  Default Version: %s\n", api.DefaultVersion)
+	printNextSteps(apiNextSteps(api, "imported"))
 
 	return nil
 }
@@ -932,12 +1454,20 @@ func runAPIApply(cmd *cobra.Command, args []string) error {
     filePath, _ := cmd.Flags().GetString("file")
     versionName, _ := cmd.Flags().GetString("version-name")
     setDefault, _ := cmd.Flags().GetBool("set-default")
+	injectOwnership, _ := cmd.Flags().GetBool("inject-ownership")
+	gatewayTags, _ := cmd.Flags().GetStringSlice("gateway-tags")
+	if err := checkGatewayTags("--gateway-tags", gatewayTags); err != nil {
+		return err
+	}
 
 	// Get configuration from context
 	config := GetConfigFromContext(cmd.Context())
 	if config == nil {
 		return fmt.Errorf("configuration not found")
 	}
+	if err := checkOwnerGroups(cmd, config); err != nil {
+		return err
+	}
 
     var oasData map[string]interface{}
     if filePath == "-" {
@@ -949,9 +1479,12 @@ func runAPIApply(cmd *cobra.Command, args []string) error {
         if len(data) == 0 {
             return &ExitError{Code: 2, Message: "no input provided on stdin"}
         }
-        if err := yaml.Unmarshal(data, &oasData); err != nil {
+        if oasData, err = filehandler.ParseYAML(data); err != nil {
             return &ExitError{Code: 2, Message: fmt.Sprintf("failed to parse input as YAML/JSON: %v", err)}
         }
+        if err := checkStrictYAML(cmd, "stdin", data); err != nil {
+            return err
+        }
     } else {
         // Validate and read the OAS file
         if !filepath.IsAbs(filePath) {
@@ -973,6 +1506,9 @@ func runAPIApply(cmd *cobra.Command, args []string) error {
             return &ExitError{Code: 2, Message: fmt.Sprintf("failed to load OAS file: %v", err)}
         }
         oasData = fileInfo.Content
+        if err := checkStrictYAML(cmd, filePath, fileInfo.RawBytes); err != nil {
+            return err
+        }
     }
 
 	// Enhanced validation: Check if it's a Tyk-enhanced OAS file
@@ -982,6 +1518,43 @@ func runAPIApply(cmd *cobra.Command, args []string) error {
             Message: "File lacks required x-tyk-api-gateway extensions. This command requires Tyk-enhanced OAS files.\n\nFor clean OpenAPI specs, use:\n  tyk api import-oas --file " + filepath.Base(filePath) + "  # To create new API\n  tyk api update-oas <api-id> --file " + filepath.Base(filePath) + "  # To update existing API",
         }
     }
+	if err := expandOperationRateLimits(oasData); err != nil {
+		return err
+	}
+	if len(gatewayTags) > 0 {
+		oas.SetGatewayTags(oasData, gatewayTags)
+	}
+
+	// Stamp ownership metadata from CODEOWNERS/Git so deployed APIs carry accurate contacts
+	if injectOwnership {
+		ownerPath := filePath
+		if filePath == "-" {
+			ownerPath = ""
+		}
+		owner, err := ownership.Resolve(ownerPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve ownership: %w", err)
+		}
+		if owner == nil {
+			color.New(color.FgYellow).Fprintln(os.Stderr, "Warning: no ownership metadata found in CODEOWNERS or Git")
+		} else {
+			ownership.Inject(oasData, owner)
+		}
+	}
+
+	resource := fmt.Sprintf("API '%s'", oas.GetAPIName(oasData))
+	if filePath != "-" {
+		resource = fmt.Sprintf("%s (%s)", resource, filepath.Base(filePath))
+	}
+	if err := mountEnvironmentPrefix(config, resource, oasData); err != nil {
+		return err
+	}
+	if err := checkDocumentCompatibility(cmd, config, resource, oasData); err != nil {
+		return err
+	}
+	if err := validateBeforeUpload(cmd, oasData); err != nil {
+		return err
+	}
 
 	// Check for existing API ID in the file
 	apiID, hasID := oas.ExtractAPIIDFromTykExtensions(oasData)
@@ -998,35 +1571,20 @@ func runAPIApply(cmd *cobra.Command, args []string) error {
 // updateExistingAPI handles updating an existing API via apply
 func updateExistingAPI(cmd *cobra.Command, config *types.Config, apiID string, oasData map[string]interface{}, versionName string, setDefault bool) error {
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
     // Check if API exists first. If not found, create it with the same ID (idempotent upsert)
-    _, err = c.GetOASAPI(ctx, apiID, "")
+    existing, err := c.GetOASAPI(ctx, apiID, "")
     if err != nil {
-        // Determine if the error means "not found" for upsert semantics
-        notFound := false
-        if er, ok := err.(*types.ErrorResponse); ok {
-            // Treat 404 as not found; also handle some Dashboard variants that return 400 for missing IDs
-            if er.Status == 404 {
-                notFound = true
-            } else if er.Status == 400 {
-                msg := strings.ToLower(er.Message)
-                if strings.Contains(msg, "could not retrieve api") || strings.Contains(msg, "not found") {
-                    notFound = true
-                }
-            }
-        } else if strings.Contains(err.Error(), "404") || strings.Contains(strings.ToLower(err.Error()), "not found") {
-            notFound = true
-        }
-
-        if notFound {
+        // Not found (including Dashboard variants answering 400 for missing IDs) means create
+        if errors.Is(err, client.ErrNotFound) {
             // Fallback to create with provided ID in the OAS
             if versionName == "" {
                 versionName = extractVersionFromOAS(oasData)
@@ -1035,23 +1593,28 @@ func updateExistingAPI(cmd *cobra.Command, config *types.Config, apiID string, o
                 }
             }
 
+            stopSpinner := startSpinner(cmd, "Creating API...")
             api, cerr := c.CreateOASAPI(ctx, oasData)
+            stopSpinner()
             if cerr != nil {
-                if strings.Contains(cerr.Error(), "409") || strings.Contains(cerr.Error(), "conflict") {
-                    return &ExitError{Code: 4, Message: fmt.Sprintf("API creation failed due to conflict: %v", cerr)}
+                if errors.Is(cerr, client.ErrConflict) {
+                    return conflictError(cerr, "API creation failed")
                 }
-                return fmt.Errorf("failed to create API: %w", cerr)
+                return uploadError(cmd, oasData, cerr, "failed to create API")
+            }
+            if err := setOwnerGroups(ctx, cmd, c, api.ID); err != nil {
+                return err
             }
 
             // Output creation result
             outputFormat := GetOutputFormatFromContext(cmd.Context())
-            if outputFormat == types.OutputJSON {
-                return outputImportedAPIAsJSON(api, versionName)
+            if outputFormat.IsStructured() {
+                return outputImportedAPIStructured(outputFormat, api, versionName)
             }
             return outputImportedAPIAsHuman(api, versionName)
         }
 
-        return fmt.Errorf("failed to verify API exists: %w", err)
+        return wrapAPIError(err, "failed to verify API exists")
     }
 
 	// Extract version name from OAS if not provided
@@ -1063,21 +1626,49 @@ func updateExistingAPI(cmd *cobra.Command, config *types.Config, apiID string, o
 	}
 
 	// Update the API
-	api, err := c.UpdateOASAPI(ctx, apiID, oasData)
+	stopSpinner := startSpinner(cmd, "Updating API...")
+	api, err := replaceDeployedAPI(ctx, cmd, c, apiID, existing, oasData)
+	stopSpinner()
 	if err != nil {
-		return fmt.Errorf("failed to update API: %w", err)
+		return err
 	}
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputUpdatedAPIAsJSON(api, versionName)
+	if outputFormat.IsStructured() {
+		return outputUpdatedAPIStructured(outputFormat, api, versionName)
 	}
 
 	return outputUpdatedAPIAsHuman(api, versionName)
 }
 
+// replaceDeployedAPI replaces the definition of an API, read as existing, with oasData.
+// The existing definition is saved as a revision for rollback and its ETag is sent, so
+// a change made since it was read is refused rather than overwritten. Fields the server
+// dropped are reported, and --owner-group is applied.
+func replaceDeployedAPI(ctx context.Context, cmd *cobra.Command, c *client.Client, apiID string, existing *types.OASAPI, oasData map[string]interface{}) (*types.OASAPI, error) {
+	saveRevision(c, apiID, existing.Name, existing.OAS)
+	api, err := c.UpdateOASAPIIfMatch(ctx, apiID, oasData, existing.ETag)
+	if err != nil {
+		return nil, uploadError(cmd, oasData, err, "failed to update API")
+	}
+	warnServerEcho(oasData, api)
+	if err := setOwnerGroups(ctx, cmd, c, apiID); err != nil {
+		return api, err
+	}
+	return api, nil
+}
+
+// expandOperationRateLimits expands x-tyk-ratelimit operation shorthands into the Tyk
+// extension so the Gateway enforces them
+func expandOperationRateLimits(oasData map[string]interface{}) error {
+	if _, err := oas.ExpandRateLimits(oasData); err != nil {
+		return &ExitError{Code: 2, Message: err.Error()}
+	}
+	return nil
+}
+
 // createNewAPIViaApply handles creating a new API via apply
 func createNewAPIViaApply(cmd *cobra.Command, config *types.Config, oasData map[string]interface{}, versionName string, setDefault bool) error {
 	// Auto-generate x-tyk-api-gateway extensions for plain OAS documents
@@ -1101,30 +1692,35 @@ func createNewAPIViaApply(cmd *cobra.Command, config *types.Config, oasData map[
 	}
 
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
 	// Create the API
+	stopSpinner := startSpinner(cmd, "Creating API...")
 	api, err := c.CreateOASAPI(ctx, oasData)
+	stopSpinner()
 	if err != nil {
 		// Check for conflict errors
-		if strings.Contains(err.Error(), "409") || strings.Contains(err.Error(), "conflict") {
-			return &ExitError{Code: 4, Message: fmt.Sprintf("API creation failed due to conflict: %v", err)}
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API creation failed")
 		}
-		return fmt.Errorf("failed to create API: %w", err)
+		return uploadError(cmd, oasData, err, "failed to create API")
+	}
+	if err := setOwnerGroups(ctx, cmd, c, api.ID); err != nil {
+		return err
 	}
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputImportedAPIAsJSON(api, versionName)
+	if outputFormat.IsStructured() {
+		return outputImportedAPIStructured(outputFormat, api, versionName)
 	}
 
 	return outputImportedAPIAsHuman(api, versionName)
@@ -1154,16 +1750,7 @@ func runAPIUpdateOAS(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load OAS data from file or URL
-	var oasData map[string]interface{}
-	var err error
-
-	if filePath != "" {
-		// Load from file
-		oasData, err = loadOASFromFile(filePath)
-	} else {
-		// Load from URL
-		oasData, err = loadOASFromURL(urlFlag)
-	}
+	oasData, err := loadUploadSpec(cmd, filePath, urlFlag)
 	if err != nil {
 		return err
 	}
@@ -1173,8 +1760,20 @@ func runAPIUpdateOAS(cmd *cobra.Command, args []string) error {
 
 // runAPIDelete implements the 'tyk api delete' command
 func runAPIDelete(cmd *cobra.Command, args []string) error {
+	filterExprs, _ := cmd.Flags().GetStringArray("filter")
+	if len(filterExprs) > 0 {
+		if len(args) > 0 {
+			return &ExitError{Code: 2, Message: "cannot combine an API ID with --filter"}
+		}
+		return runAPIBulkDelete(cmd, filterExprs)
+	}
+	if len(args) == 0 {
+		return &ExitError{Code: 2, Message: "an API ID or --filter is required"}
+	}
+
 	apiID := args[0]
-	skipConfirmation, _ := cmd.Flags().GetBool("yes")
+	skipConfirmation := assumeYes(cmd)
+	noTrash, _ := cmd.Flags().GetBool("no-trash")
 
 	// Get configuration from context
 	config := GetConfigFromContext(cmd.Context())
@@ -1183,56 +1782,66 @@ func runAPIDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
 	// Verify API exists first
 	api, err := c.GetOASAPI(ctx, apiID, "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
-			return &ExitError{Code: 3, Message: fmt.Sprintf("API '%s' not found", apiID)}
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
 		}
-		return fmt.Errorf("failed to verify API exists: %w", err)
+		return wrapAPIError(err, "failed to verify API exists")
 	}
 
 	// Confirmation prompt unless --yes flag is provided
 	if !skipConfirmation {
-		fmt.Printf("Are you sure you want to delete API '%s' (%s)? [y/N]: ", apiID, api.Name)
-		var response string
-		fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+		ok, err := confirm(cmd, fmt.Sprintf("Are you sure you want to delete API '%s' (%s)?", apiID, api.Name))
+		if err != nil {
+			return err
+		}
+		if !ok {
 			fmt.Println("Delete operation cancelled")
 			return nil
 		}
 	}
 
-	// Delete the API
+	// Keep a copy for 'tyk api undelete', then delete the API
+	var trashed *trash.Entry
+	if !noTrash {
+		if trashed, err = trashAPI(c, apiID, api.Name, api.OAS); err != nil {
+			return err
+		}
+	}
 	err = c.DeleteOASAPI(ctx, apiID)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
-			return &ExitError{Code: 3, Message: fmt.Sprintf("API '%s' not found", apiID)}
+		if trashed != nil {
+			trash.Remove(c.Environment(), apiID)
+		}
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
 		}
-		return fmt.Errorf("failed to delete API: %w", err)
+		return wrapAPIError(err, "failed to delete API")
 	}
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputDeletedAPIAsJSON(apiID)
+	if outputFormat.IsStructured() {
+		return outputDeletedAPIStructured(outputFormat, apiID, trashed)
 	}
 
-	return outputDeletedAPIAsHuman(apiID, api.Name)
+	return outputDeletedAPIAsHuman(apiID, api.Name, trashed)
 }
 
-// outputUpdatedAPIAsJSON outputs the updated API result in JSON format
-func outputUpdatedAPIAsJSON(api *types.OASAPI, versionName string) error {
+// outputUpdatedAPIStructured outputs the updated API result as JSON or YAML
+func outputUpdatedAPIStructured(format types.OutputFormat, api *types.OASAPI, versionName string) error {
 	result := map[string]interface{}{
 		"api_id":          api.ID,
 		"version_name":    versionName,
@@ -1240,11 +1849,10 @@ func outputUpdatedAPIAsJSON(api *types.OASAPI, versionName string) error {
 		"listen_path":     api.ListenPath,
 		"default_version": api.DefaultVersion,
 		"operation":       "updated",
+		"suggestions":     suggestions(apiNextSteps(api, "updated")),
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	return writeStructured(format, result)
 }
 
 // outputUpdatedAPIAsHuman outputs the updated API result in human-readable format
@@ -1266,31 +1874,36 @@ func outputUpdatedAPIAsHuman(api *types.OASAPI, versionName string) error {
 	}
 
 	blue.Printf("  Default Version: %s\n", api.DefaultVersion)
+	printNextSteps(apiNextSteps(api, "updated"))
 
 	return nil
 }
 
-// outputDeletedAPIAsJSON outputs the deleted API result in JSON format
-func outputDeletedAPIAsJSON(apiID string) error {
+// outputDeletedAPIStructured outputs the deleted API result as JSON or YAML
+func outputDeletedAPIStructured(format types.OutputFormat, apiID string, trashed *trash.Entry) error {
 	result := map[string]interface{}{
 		"api_id":    apiID,
 		"operation": "deleted",
 		"success":   true,
 	}
+	if trashed != nil {
+		result["undelete_until"] = trashed.ExpiresAt.Format(time.RFC3339)
+	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	return writeStructured(format, result)
 }
 
 // outputDeletedAPIAsHuman outputs the deleted API result in human-readable format
-func outputDeletedAPIAsHuman(apiID, apiName string) error {
+func outputDeletedAPIAsHuman(apiID, apiName string, trashed *trash.Entry) error {
 	green := color.New(color.FgGreen, color.Bold)
 
 	green.Printf("✓ Deleted API '%s'\n", apiID)
 	if apiName != "" {
 		fmt.Printf("  Name: %s\n", apiName)
 	}
+	if trashed != nil {
+		fmt.Printf("  Restore it with 'tyk api undelete %s' until %s\n", apiID, trashed.ExpiresAt.Local().Format("2006-01-02 15:04"))
+	}
 
 	return nil
 }
@@ -1322,9 +1935,19 @@ func loadOASFromFile(filePath string) (map[string]interface{}, error) {
 
 // loadOASFromURL loads and parses an OAS document from a URL
 func loadOASFromURL(urlStr string) (map[string]interface{}, error) {
+	body, err := fetchOASFromURL(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return parseOASDocument(body)
+}
+
+// fetchOASFromURL downloads an OAS document
+func fetchOASFromURL(urlStr string) ([]byte, error) {
 	// Create HTTP client with timeout
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: logging.NewTransport(nil),
 	}
 
 	// Fetch the URL
@@ -1344,18 +1967,22 @@ func loadOASFromURL(urlStr string) (map[string]interface{}, error) {
 	if err != nil {
 		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to read URL response: %v", err)}
 	}
+	return body, nil
+}
 
-	// Parse as JSON or YAML
+// parseOASDocument parses a downloaded OAS document as JSON or YAML
+func parseOASDocument(body []byte) (map[string]interface{}, error) {
 	var oasData map[string]interface{}
-	
+
 	// Try JSON first
-	if err := json.Unmarshal(body, &oasData); err != nil {
-		// Try YAML
-		if err := yaml.Unmarshal(body, &oasData); err != nil {
-			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to parse OAS document: %v", err)}
-		}
+	if json.Unmarshal(body, &oasData) == nil {
+		return oasData, nil
+	}
+	// Try YAML
+	oasData, err := filehandler.ParseYAML(body)
+	if err != nil {
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to parse OAS document: %v", err)}
 	}
-
 	return oasData, nil
 }
 
@@ -1368,10 +1995,28 @@ func runAPICreate(cmd *cobra.Command, args []string) error {
 	versionName, _ := cmd.Flags().GetString("version-name")
 	customDomain, _ := cmd.Flags().GetString("custom-domain")
 	description, _ := cmd.Flags().GetString("description")
+	gatewayTags, _ := cmd.Flags().GetStringSlice("gateway-tags")
+	categories, _ := cmd.Flags().GetStringSlice("category")
+	internal, _ := cmd.Flags().GetBool("internal")
+
+	if strings.TrimSpace(name) == "" {
+		return &ExitError{Code: 2, Message: "--name must not be empty"}
+	}
+	if parsed, err := url.Parse(upstreamURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("invalid --upstream-url '%s': must be an absolute http(s) URL", upstreamURL)}
+	}
+	if err := checkGatewayTags("--gateway-tags", gatewayTags); err != nil {
+		return err
+	}
+	if err := checkCategories(categories); err != nil {
+		return err
+	}
 
 	// Auto-generate listen path if not provided
 	if listenPath == "" {
 		listenPath = oas.GenerateListenPath(name)
+	} else if !strings.HasPrefix(listenPath, "/") {
+		listenPath = "/" + listenPath
 	}
 
 	// Set default description if not provided
@@ -1384,38 +2029,60 @@ func runAPICreate(cmd *cobra.Command, args []string) error {
 	if config == nil {
 		return fmt.Errorf("configuration not found")
 	}
+	if err := checkOwnerGroups(cmd, config); err != nil {
+		return err
+	}
 
 	// Generate the OAS document with Tyk extensions
 	oasData, err := generateOASForCreate(name, description, versionName, upstreamURL, listenPath, customDomain)
 	if err != nil {
 		return fmt.Errorf("failed to generate OAS document: %w", err)
 	}
+	if len(gatewayTags) > 0 {
+		oas.SetGatewayTags(oasData, gatewayTags)
+	}
+	if len(categories) > 0 {
+		if err := oas.AddCategories(oasData, categories); err != nil {
+			return err
+		}
+	}
+	if internal {
+		oas.SetInternal(oasData, true)
+	}
+	if err := mountEnvironmentPrefix(config, fmt.Sprintf("API '%s'", name), oasData); err != nil {
+		return err
+	}
 
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
 	// Create the API
+	stopSpinner := startSpinner(cmd, "Creating API...")
 	api, err := c.CreateOASAPI(ctx, oasData)
+	stopSpinner()
 	if err != nil {
 		// Check for conflict errors
-		if strings.Contains(err.Error(), "409") || strings.Contains(err.Error(), "conflict") {
-			return &ExitError{Code: 4, Message: fmt.Sprintf("API creation failed due to conflict: %v", err)}
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API creation failed")
 		}
-		return fmt.Errorf("failed to create API: %w", err)
+		return wrapAPIError(err, "failed to create API")
+	}
+	if err := setOwnerGroups(ctx, cmd, c, api.ID); err != nil {
+		return err
 	}
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputCreatedAPIAsJSON(api, versionName)
+	if outputFormat.IsStructured() {
+		return outputCreatedAPIStructured(outputFormat, api, versionName)
 	}
 
 	return outputCreatedAPIAsHuman(api, versionName)
@@ -1471,8 +2138,8 @@ func generateOASForCreate(name, description, version, upstreamURL, listenPath, c
 	return oasDoc, nil
 }
 
-// outputCreatedAPIAsJSON outputs the created API result in JSON format
-func outputCreatedAPIAsJSON(api *types.OASAPI, versionName string) error {
+// outputCreatedAPIStructured outputs the created API result as JSON or YAML
+func outputCreatedAPIStructured(format types.OutputFormat, api *types.OASAPI, versionName string) error {
 	result := map[string]interface{}{
 		"api_id":          api.ID,
 		"version_name":    versionName,
@@ -1480,6 +2147,7 @@ func outputCreatedAPIAsJSON(api *types.OASAPI, versionName string) error {
 		"listen_path":     api.ListenPath,
 		"default_version": api.DefaultVersion,
 		"operation":       "created",
+		"suggestions":     suggestions(apiNextSteps(api, "created")),
 	}
 
 	if api.CustomDomain != "" {
@@ -1489,16 +2157,13 @@ func outputCreatedAPIAsJSON(api *types.OASAPI, versionName string) error {
 		result["upstream_url"] = api.UpstreamURL
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	return writeStructured(format, result)
 }
 
 // outputCreatedAPIAsHuman outputs the created API result in human-readable format
 func outputCreatedAPIAsHuman(api *types.OASAPI, versionName string) error {
 	green := color.New(color.FgGreen, color.Bold)
 	blue := color.New(color.FgBlue, color.Bold)
-	dim := color.New(color.FgHiBlack)
 
 	green.Println("✓ API created successfully!")
 	fmt.Printf("  API ID:         %s\n", api.ID)
@@ -1514,11 +2179,7 @@ func outputCreatedAPIAsHuman(api *types.OASAPI, versionName string) error {
 	}
 
 	blue.Printf("  Default Version: %s\n", api.DefaultVersion)
-
-	fmt.Printf("\n")
-	dim.Println("Next steps:")
-	dim.Printf("  tyk api get %s                           # View full configuration\n", api.ID)
-	dim.Printf("  tyk api get %s --oas-only > api.yaml    # Export for editing\n", api.ID)
+	printNextSteps(apiNextSteps(api, "created"))
 
 	return nil
 }
@@ -1526,22 +2187,27 @@ func outputCreatedAPIAsHuman(api *types.OASAPI, versionName string) error {
 // updateExistingAPIWithOAS handles updating an existing API with a clean OAS document
 func updateExistingAPIWithOAS(cmd *cobra.Command, config *types.Config, apiID string, oasData map[string]interface{}) error {
 	// Create client
-	c, err := client.NewClient(config)
+	c, err := commandClient(cmd.Context(), config)
 	if err != nil {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := apiContext(config, 30*time.Second)
 	defer cancel()
 
 	// Check if API exists first and get existing Tyk extensions
 	existingAPI, err := c.GetOASAPI(ctx, apiID, "")
 	if err != nil {
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
-			return &ExitError{Code: 3, Message: fmt.Sprintf("API with ID '%s' not found", apiID)}
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("API with ID '%s' not found", apiID))
 		}
-		return fmt.Errorf("failed to verify API exists: %w", err)
+		return wrapAPIError(err, "failed to verify API exists")
+	}
+	// Copied before the extensions below are shared with, and changed through, oasData
+	previous, err := cloneDocument(existingAPI.OAS)
+	if err != nil {
+		return err
 	}
 
 	// Preserve existing Tyk extensions by merging with new OAS
@@ -1558,6 +2224,9 @@ func updateExistingAPIWithOAS(cmd *cobra.Command, config *types.Config, apiID st
 			return &ExitError{Code: 2, Message: fmt.Sprintf("failed to generate Tyk extensions: %v", err)}
 		}
 	}
+	if err := expandOperationRateLimits(oasData); err != nil {
+		return err
+	}
 
 	// Ensure the API ID matches in the extensions
 	if tykExt, exists := oasData["x-tyk-api-gateway"]; exists {
@@ -1577,16 +2246,23 @@ func updateExistingAPIWithOAS(cmd *cobra.Command, config *types.Config, apiID st
 	}
 
 	// Update the API
-	api, err := c.UpdateOASAPI(ctx, apiID, oasData)
+	if err := validateBeforeUpload(cmd, oasData); err != nil {
+		return err
+	}
+	saveRevision(c, apiID, existingAPI.Name, previous)
+	stopSpinner := startSpinner(cmd, "Updating API...")
+	api, err := c.UpdateOASAPIIfMatch(ctx, apiID, oasData, existingAPI.ETag)
+	stopSpinner()
 	if err != nil {
-		return fmt.Errorf("failed to update API: %w", err)
+		return uploadError(cmd, oasData, err, "failed to update API")
 	}
+	warnServerEcho(oasData, api)
 
 	// Get output format from context
 	outputFormat := GetOutputFormatFromContext(cmd.Context())
 
-	if outputFormat == types.OutputJSON {
-		return outputUpdatedAPIAsJSON(api, versionName)
+	if outputFormat.IsStructured() {
+		return outputUpdatedAPIStructured(outputFormat, api, versionName)
 	}
 
 	return outputUpdatedAPIAsHuman(api, versionName)
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/trash"
+)
+
+// bulkDeleteBudget bounds each deletion of a bulk delete, rather than the whole run,
+// whose length depends on the prompt, --rate and the number of matches
+var bulkDeleteBudget = 30 * time.Second
+
+// runAPIBulkDelete deletes every API matching the --filter conditions
+func runAPIBulkDelete(cmd *cobra.Command, filterExprs []string) error {
+	skipConfirmation := assumeYes(cmd)
+	noTrash, _ := cmd.Flags().GetBool("no-trash")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	if rate < 0 {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("invalid --rate %g: must be 0 or more deletions per second", rate)}
+	}
+
+	filters, err := parseAPIFilters(filterExprs)
+	if err != nil {
+		return err
+	}
+	progress, err := newProgressReporter(cmd, "api delete")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	listCtx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	apis, err := c.ListAllAPIs(listCtx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+
+	targets := filterAPIs(apis, filters)
+	if len(targets) == 0 {
+		if GetOutputFormatFromContext(cmd.Context()).IsStructured() {
+			return outputAPIOperationResults(cmd, nil)
+		}
+		color.New(color.FgYellow).Println("No APIs matched the filter")
+		return nil
+	}
+
+	if !skipConfirmation {
+		fmt.Printf("The following %d API(s) match and will be deleted:\n", len(targets))
+		for _, api := range targets {
+			fmt.Printf("  %s  %-28s  %s\n", api.ID, api.Name, api.ListenPath)
+		}
+		ok, err := confirm(cmd, "Continue?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Delete operation cancelled")
+			return nil
+		}
+	}
+
+	// Pace the deletions, so a selector matching more than intended can be interrupted
+	var pace <-chan time.Time
+	if rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / rate))
+		defer ticker.Stop()
+		pace = ticker.C
+	}
+
+	ctx := cmd.Context()
+	var results []apiOperationResult
+	failed := 0
+	progress.Start(len(targets))
+	for i, api := range targets {
+		if pace != nil && i > 0 {
+			select {
+			case <-pace:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		progress.StepStarted(api.ID)
+		result := apiOperationResult{APIID: api.ID, Name: api.Name, Operation: "deleted"}
+		deleteCtx, cancel := apiContext(config, bulkDeleteBudget)
+		if err := deleteTrashedAPI(deleteCtx, c, api.ID, !noTrash); err != nil {
+			result.Operation = ""
+			result.Error = err.Error()
+			failed++
+		}
+		cancel()
+		progress.StepFinished(api.ID, result.Error)
+		results = append(results, result)
+	}
+	progress.Finish()
+
+	if err := outputAPIOperationResults(cmd, results); err != nil {
+		return err
+	}
+	if len(results) < len(targets) {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("stopped after %d of %d API(s): %v", len(results), len(targets), ctx.Err())}
+	}
+	if failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d API(s) failed to delete", failed, len(targets))}
+	}
+	return nil
+}
+
+// deleteTrashedAPI deletes an API, first copying its definition to the trash when keep is set
+func deleteTrashedAPI(ctx context.Context, c *client.Client, apiID string, keep bool) error {
+	if keep {
+		api, err := c.GetOASAPI(ctx, apiID, "")
+		if err != nil {
+			return wrapAPIError(err, "failed to get API")
+		}
+		if _, err := trashAPI(c, apiID, api.Name, api.OAS); err != nil {
+			return err
+		}
+	}
+	if err := c.DeleteOASAPI(ctx, apiID); err != nil {
+		if keep {
+			trash.Remove(c.Environment(), apiID)
+		}
+		return wrapAPIError(err, "failed to delete API")
+	}
+	return nil
+}
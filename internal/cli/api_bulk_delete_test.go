@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIDelete_BulkFilter(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dir := writePreviewSpecs(t)
+	_, err := runPreviewCommand(t, server.URL, "create", "--from-dir", dir, "--prefix", "test-")
+	require.NoError(t, err)
+	_, err = runPreviewCommand(t, server.URL, "create", "--from-dir", dir, "--prefix", "keep-")
+	require.NoError(t, err)
+	require.Equal(t, 4, dashboard.count())
+
+	cmd := NewAPIDeleteCommand()
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	}
+	cmd.SetContext(withOutputFormat(withConfig(context.Background(), config), types.OutputJSON))
+	t.Setenv(EnvAssumeYes, "1")
+	cmd.SetArgs([]string{"--filter", "name~^test-"})
+
+	out, err := captureStdout(cmd.Execute)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Results []apiOperationResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Len(t, decoded.Results, 2)
+	for _, r := range decoded.Results {
+		assert.Equal(t, "deleted", r.Operation)
+		assert.True(t, strings.HasPrefix(r.Name, "test-"), r.Name)
+	}
+	assert.Equal(t, 2, dashboard.count())
+}
+
+func TestAPIDelete_BulkFilterPacedPastBudget(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	_, err := runPreviewCommand(t, server.URL, "create", "--from-dir", writePreviewSpecs(t), "--prefix", "test-")
+	require.NoError(t, err)
+	require.Equal(t, 2, dashboard.count())
+
+	// Pacing at 5 per second takes longer than each deletion may, which must not matter
+	budget := bulkDeleteBudget
+	bulkDeleteBudget = 50 * time.Millisecond
+	t.Cleanup(func() { bulkDeleteBudget = budget })
+
+	cmd := NewAPIDeleteCommand()
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	}
+	cmd.SetContext(withOutputFormat(withConfig(context.Background(), config), types.OutputJSON))
+	t.Setenv(EnvAssumeYes, "1")
+	cmd.SetArgs([]string{"--filter", "name~^test-", "--rate", "5", "--no-trash"})
+
+	_, err = captureStdout(cmd.Execute)
+	require.NoError(t, err)
+	assert.Equal(t, 0, dashboard.count())
+}
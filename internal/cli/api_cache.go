@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/config"
+)
+
+// registerAPIIDCompletion completes the API ID of every command whose first argument
+// is <api-id> or [api-id]
+func registerAPIIDCompletion(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		registerAPIIDCompletion(sub)
+	}
+	if fields := strings.Fields(cmd.Use); len(fields) > 1 && cmd.ValidArgsFunction == nil {
+		if fields[1] == "<api-id>" || fields[1] == "[api-id]" {
+			cmd.ValidArgsFunction = completeAPIIDs
+		}
+	}
+}
+
+// completeAPIIDs completes an <api-id> argument from the API cache of the selected
+// environment. It never calls the Dashboard, so completion stays instant; the cache is
+// filled by 'tyk api list --refresh-cache'.
+func completeAPIIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	env := completionEnvironment(cmd)
+	if env == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cache, err := client.LoadAPICache(env)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, api := range cache.APIs {
+		if strings.HasPrefix(api.ID, toComplete) {
+			completions = append(completions, fmt.Sprintf("%s\t%s", api.ID, api.Name))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionEnvironment resolves the environment the way a normal run would, without
+// the validation initConfig does: completion runs before the command's hooks
+func completionEnvironment(cmd *cobra.Command) string {
+	if flag := cmd.Flag("env"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String()
+	}
+	if env := os.Getenv(config.EnvEnvName); env != "" {
+		return env
+	}
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return ""
+	}
+	return manager.GetConfig().DefaultEnvironment
+}
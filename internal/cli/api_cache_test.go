@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPICache_CompletionAndSearch(t *testing.T) {
+	t.Setenv(client.EnvAPICacheDir, t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "pay-1", "payments", "1.0.0")
+	seedRemoteAPI(t, dashboard, "usr-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "search", "pay", "--cached")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+
+	out, err := runRootCommand(t, "api", "list", "--refresh-cache", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-list", out), string(out))
+
+	// The cache is used without the Dashboard
+	server.Close()
+
+	out, err = runRootCommand(t, "__complete", "api", "get", "pay")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "pay-1\tpayments")
+	assert.NotContains(t, string(out), "usr-1")
+
+	out, err = runRootCommand(t, "__complete", "api", "rollback", "")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "usr-1\tusers")
+
+	out, err = runRootCommand(t, "api", "search", "pay", "--cached", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-search", out), string(out))
+	var result struct {
+		APIs []*types.OASAPI `json:"apis"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Len(t, result.APIs, 1)
+	assert.Equal(t, "pay-1", result.APIs[0].ID)
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPICategoryCommand creates the 'tyk api category' command and its subcommands
+func NewAPICategoryCommand() *cobra.Command {
+	categoryCmd := &cobra.Command{
+		Use:     "category",
+		Aliases: []string{"categories", "tag"},
+		Short:   "Manage an API's Dashboard categories",
+		Long: `Commands for filing APIs under Dashboard categories, e.g. one per owning team.
+
+The Dashboard keeps categories as "#category" words in the API name
+(x-tyk-api-gateway.info.name), so 'Refunds #team-payments' is the Refunds API in the
+team-payments category. List APIs in a category with 'tyk api list --category'.
+
+Categories are not Gateway tags: those pin an API to tagged Gateways and are set with
+--gateway-tags on create and apply, or --tag on import-oas. That is why the flags
+setting and filtering categories are named --category.`,
+	}
+
+	categoryCmd.AddCommand(NewAPICategoryAddCommand())
+	categoryCmd.AddCommand(NewAPICategoryRemoveCommand())
+	categoryCmd.AddCommand(NewAPICategoryListCommand())
+
+	return categoryCmd
+}
+
+// NewAPICategoryAddCommand creates the 'tyk api category add' command
+func NewAPICategoryAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <api-id> <category>...",
+		Short: "Add an API to Dashboard categories",
+		Long: `Add categories to an API's name and upload the result. Categories the API is
+already in are left as they are.
+
+Examples:
+  tyk api category add 7c2f4a1b team-payments pci
+  tyk api tag add 7c2f4a1b team-payments --dry-run`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAPICategoryEdit(cmd, args, "added", oas.AddCategories)
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+	return cmd
+}
+
+// NewAPICategoryRemoveCommand creates the 'tyk api category remove' command
+func NewAPICategoryRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <api-id> <category>...",
+		Aliases: []string{"rm"},
+		Short:   "Remove an API from Dashboard categories",
+		Long: `Remove categories from an API's name and upload the result. Categories the API
+is not in are ignored.
+
+Examples:
+  tyk api category remove 7c2f4a1b pci`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAPICategoryEdit(cmd, args, "removed", oas.RemoveCategories)
+		},
+	}
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+	return cmd
+}
+
+// NewAPICategoryListCommand creates the 'tyk api category list' command
+func NewAPICategoryListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [api-id]",
+		Short: "List an API's categories, or every category in use",
+		Long: `List the categories of one API, one per line. Without an API ID, list every
+category in use with the number of APIs in it.
+
+Examples:
+  tyk api category list 7c2f4a1b
+  tyk api category list -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAPICategoryList,
+	}
+}
+
+func runAPICategoryEdit(cmd *cobra.Command, args []string, verb string, edit func(map[string]interface{}, []string) error) error {
+	apiID, categories := args[0], args[1:]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	for _, category := range categories {
+		if err := oas.CheckCategory(category); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	label := fmt.Sprintf("%s (%s)", api.Name, apiID)
+
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		return edit(doc, categories)
+	})
+	if err != nil {
+		return err
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":     apiID,
+			"categories": oas.Categories(api.OAS),
+			"dry_run":    dryRun,
+			"changed":    !diff.Empty(),
+			"diff":       diff,
+		})
+	}
+	printAPIEdit(label, fmt.Sprintf("categories %s: %s", verb, strings.Join(categories, ", ")), diff, dryRun)
+	return nil
+}
+
+// categoryCount is one entry of 'tyk api category list' without an API ID
+type categoryCount struct {
+	Category string `json:"category" yaml:"category"`
+	APIs     int    `json:"apis" yaml:"apis"`
+}
+
+func runAPICategoryList(cmd *cobra.Command, args []string) error {
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	format := GetOutputFormatFromContext(cmd.Context())
+
+	if len(args) == 1 {
+		api, err := getAPIForEdit(ctx, c, args[0])
+		if err != nil {
+			return err
+		}
+		categories := oas.Categories(api.OAS)
+		if format.IsStructured() {
+			return writeStructured(format, map[string]interface{}{"api_id": args[0], "categories": categories})
+		}
+		for _, category := range categories {
+			fmt.Println(category)
+		}
+		return nil
+	}
+
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	counts := make(map[string]int)
+	for _, api := range apis {
+		_, categories := oas.SplitCategories(api.Name)
+		for _, category := range categories {
+			counts[category]++
+		}
+	}
+	result := []categoryCount{}
+	for _, category := range sortedKeys(counts) {
+		result = append(result, categoryCount{Category: category, APIs: counts[category]})
+	}
+	if format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{"categories": result})
+	}
+	if len(result) == 0 {
+		fmt.Println("No categories in use.")
+		return nil
+	}
+	tbl := &table{Columns: []tableColumn{{Header: "Category"}, {Header: "APIs"}}, Separator: "  "}
+	rows := make([][]string, len(result))
+	for i, entry := range result {
+		rows[i] = []string{entry.Category, fmt.Sprint(entry.APIs)}
+	}
+	tbl.Write(os.Stdout, rows)
+	return nil
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPICategory(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "orders", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "category", "add", "remote-1", "team-payments", "--dry-run")
+	require.NoError(t, err)
+	assert.Empty(t, oas.Categories(dashboard.apis["remote-1"]))
+
+	out, err := runRootCommand(t, "api", "tag", "add", "remote-1", "team-payments", "pci", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-category", out), string(out))
+	assert.Equal(t, "users #team-payments #pci", oas.GetAPIName(dashboard.apis["remote-1"]))
+
+	_, err = runRootCommand(t, "api", "category", "add", "remote-2", "team-payments")
+	require.NoError(t, err)
+	_, err = runRootCommand(t, "api", "category", "remove", "remote-1", "pci")
+	require.NoError(t, err)
+	assert.Equal(t, "users #team-payments", oas.GetAPIName(dashboard.apis["remote-1"]))
+
+	out, err = runRootCommand(t, "api", "category", "list", "remote-1")
+	require.NoError(t, err)
+	assert.Equal(t, "team-payments\n", string(out))
+
+	out, err = runRootCommand(t, "api", "category", "list", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-category-list", out), string(out))
+	var counts struct {
+		Categories []categoryCount `json:"categories"`
+	}
+	require.NoError(t, json.Unmarshal(out, &counts))
+	assert.Equal(t, []categoryCount{{Category: "team-payments", APIs: 2}}, counts.Categories)
+
+	// tyk api list --category keeps the APIs in any of the categories
+	_, err = runRootCommand(t, "api", "category", "remove", "remote-2", "team-payments")
+	require.NoError(t, err)
+	out, err = runRootCommand(t, "api", "list", "--category", "team-payments", "-o", "json")
+	require.NoError(t, err)
+	var listed struct {
+		APIs []*types.OASAPI `json:"apis"`
+	}
+	require.NoError(t, json.Unmarshal(out, &listed))
+	require.Len(t, listed.APIs, 1)
+	assert.Equal(t, "remote-1", listed.APIs[0].ID)
+
+	_, err = runRootCommand(t, "api", "category", "add", "remote-1", "two words")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPICloneCommand creates the 'tyk api clone' command
+func NewAPICloneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clone <api-id>",
+		Short: "Create a copy of an API under a new name and listen path",
+		Long: `Create a new API from the definition of a deployed one, such as a sandbox copy of
+a production API. The copy gets its own ID, the name given by --name ("Copy of <name>"
+by default) and the listen path given by --listen-path (derived from the name by
+default), which must differ from the original's. The Dashboard categories of the
+original are kept.
+
+--upstream-url, --custom-domain and --inactive override the copied settings, so a
+sandbox can proxy to a mock upstream or stay disabled until it is ready.
+
+Examples:
+  tyk api clone 7c2f4a1b --name "Copy of Users" --listen-path /copy/
+  tyk api clone 7c2f4a1b --name "Users sandbox" --upstream-url https://users.sandbox.internal --inactive`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIClone,
+	}
+
+	cmd.Flags().String("name", "", "Name of the copy (default: \"Copy of <name>\")")
+	cmd.Flags().String("listen-path", "", "Listen path of the copy (default: derived from the name)")
+	cmd.Flags().String("upstream-url", "", "Upstream URL of the copy (default: the original's)")
+	cmd.Flags().String("custom-domain", "", "Custom domain of the copy (default: the original's)")
+	cmd.Flags().Bool("inactive", false, "Create the copy inactive")
+
+	return cmd
+}
+
+func runAPIClone(cmd *cobra.Command, args []string) error {
+	sourceID := args[0]
+	name, _ := cmd.Flags().GetString("name")
+	if cmd.Flags().Changed("name") && strings.TrimSpace(name) == "" {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--name must not be empty"}
+	}
+	opts, err := extensionOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	source, err := c.GetOASAPI(ctx, sourceID, "")
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("API '%s' not found", sourceID))
+		}
+		return wrapAPIError(err, "failed to get API")
+	}
+	doc := source.OAS
+	if !oas.HasTykExtensions(doc) {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("API '%s' has no %s extension to clone", sourceID, oas.TykExtensionKey)}
+	}
+	sourceListenPath, sourceDomain := oas.GetListenPath(doc), oas.CustomDomain(doc)
+
+	if name == "" {
+		base, _ := oas.SplitCategories(oas.GetAPIName(doc))
+		name = "Copy of " + base
+	}
+	oas.PrepareClone(doc)
+	if err := oas.SetAPIName(doc, name); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--name: %v", err)}
+	}
+	if opts.ListenPath == "" {
+		base, _ := oas.SplitCategories(name)
+		opts.ListenPath = oas.GenerateListenPath(base)
+	}
+	if _, err := oas.AddTykExtensionsWithOptions(doc, opts); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	if err := mountEnvironmentPrefix(config, fmt.Sprintf("API '%s'", name), doc); err != nil {
+		return err
+	}
+	listenPath := oas.GetListenPath(doc)
+	if strings.Trim(listenPath, "/") == strings.Trim(sourceListenPath, "/") && oas.CustomDomain(doc) == sourceDomain {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("the copy would listen on %s like API '%s': pass another --listen-path", listenPath, sourceID)}
+	}
+
+	api, err := c.CreateOASAPI(ctx, doc)
+	if err != nil {
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API creation failed")
+		}
+		return uploadError(cmd, doc, err, "failed to create API")
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":        api.ID,
+			"source_api_id": sourceID,
+			"name":          api.Name,
+			"listen_path":   api.ListenPath,
+			"operation":     "cloned",
+			"suggestions":   suggestions(apiNextSteps(api, "created")),
+		})
+	}
+	color.New(color.FgGreen, color.Bold).Printf("✓ Cloned API '%s' (%s)\n", source.Name, sourceID)
+	fmt.Printf("  API ID:         %s\n", api.ID)
+	fmt.Printf("  Name:           %s\n", api.Name)
+	fmt.Printf("  Listen Path:    %s\n", api.ListenPath)
+	if api.CustomDomain != "" {
+		fmt.Printf("  Custom Domain:  %s\n", api.CustomDomain)
+	}
+	if api.UpstreamURL != "" {
+		fmt.Printf("  Upstream URL:   %s\n", api.UpstreamURL)
+	}
+	printNextSteps(apiNextSteps(api, "created"))
+	return nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIClone(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	require.NoError(t, oas.SetCategories(dashboard.apis["users-1"], []string{"payments"}))
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "clone", "users-1", "--name", "Copy of Users", "--listen-path", "/copy/", "--inactive", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-clone", out), string(out))
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &result))
+	cloneID := result["api_id"].(string)
+	assert.NotEqual(t, "users-1", cloneID)
+
+	clone := dashboard.apis[cloneID]
+	require.NotNil(t, clone)
+	assert.Equal(t, "Copy of Users #payments", oas.GetAPIName(clone))
+	assert.Equal(t, "/copy/", oas.GetListenPath(clone))
+	state := clone[oas.TykExtensionKey].(map[string]interface{})["info"].(map[string]interface{})["state"].(map[string]interface{})
+	assert.Equal(t, false, state["active"])
+	// The original is untouched
+	assert.Equal(t, "users #payments", oas.GetAPIName(dashboard.apis["users-1"]))
+
+	// Without flags, the name and listen path are derived
+	out, err = runRootCommand(t, "api", "clone", "users-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "Copy of users #payments", oas.GetAPIName(dashboard.apis[result["api_id"].(string)]))
+	assert.Equal(t, "/copy-of-users/", result["listen_path"])
+}
+
+func TestAPIClone_BadArgs(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	for _, args := range [][]string{
+		{"--listen-path", oas.GetListenPath(dashboard.apis["users-1"])},
+		{"--listen-path", "copy"},
+		{"--name", " "},
+		{"--upstream-url", "users.internal"},
+	} {
+		_, err := runRootCommand(t, append([]string{"api", "clone", "users-1"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, "%v", args)
+		assert.Equal(t, int(types.ExitBadArgs), exitErr.Code, "%v", args)
+	}
+	assert.Equal(t, 1, dashboard.count())
+
+	_, err := runRootCommand(t, "api", "clone", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
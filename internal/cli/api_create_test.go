@@ -1,10 +1,17 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
 )
 
 func TestGenerateOASForCreate(t *testing.T) {
@@ -140,4 +147,96 @@ func TestNewAPICreateCommand(t *testing.T) {
 	
 	descriptionFlag := cmd.Flags().Lookup("description")
 	require.NotNil(t, descriptionFlag)
-}
\ No newline at end of file
+}
+
+func newAPICreateTestCommand(serverURL string) *cobra.Command {
+	cmd := NewAPICreateCommand()
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: serverURL, AuthToken: "token", OrgID: "org"},
+		},
+	}
+	cmd.SetContext(withOutputFormat(withConfig(context.Background(), config), types.OutputJSON))
+	return cmd
+}
+
+func TestRunAPICreate_PostsGeneratedSpec(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+
+	cmd := newAPICreateTestCommand(server.URL)
+	cmd.SetArgs([]string{"--name", "User Service", "--upstream-url", "https://users.internal", "--listen-path", "users/v2"})
+
+	_, err := captureStdout(cmd.Execute)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, dashboard.count())
+	for _, doc := range dashboard.apis {
+		assert.Equal(t, "User Service", oas.GetAPIName(doc))
+		assert.Equal(t, "/users/v2", oas.GetListenPath(doc))
+	}
+}
+
+func TestRunAPICreate_InvalidUpstreamURL(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+
+	cmd := newAPICreateTestCommand(server.URL)
+	cmd.SetArgs([]string{"--name", "User Service", "--upstream-url", "users.internal"})
+
+	err := cmd.Execute()
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Equal(t, 0, dashboard.count())
+}
+
+func TestRunAPICreate_GatewayTags(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+
+	cmd := newAPICreateTestCommand(server.URL)
+	cmd.SetArgs([]string{"--name", "Edge", "--upstream-url", "https://edge.internal", "--gateway-tags", "edge,eu-west", "--category", "team-edge"})
+
+	_, err := captureStdout(cmd.Execute)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, dashboard.count())
+	for _, doc := range dashboard.apis {
+		server := doc[oas.TykExtensionKey].(map[string]interface{})["server"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"enabled": true, "tags": []interface{}{"edge", "eu-west"}}, server["gatewayTags"])
+		assert.Equal(t, "Edge #team-edge", oas.GetAPIName(doc))
+	}
+}
+
+func TestRunAPIApply_GatewayTagsReplaceFileTags(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	doc, err := loadOASFromFile(writePlanSpec(t, t.TempDir(), "users", "1.0.0"))
+	require.NoError(t, err)
+	doc, err = oas.AddTykExtensionsWithOptions(doc, oas.ExtensionOptions{Tags: []string{"internal"}})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "users.json")
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, data, 0644))
+
+	_, err = runRootCommand(t, "api", "apply", "--file", file, "--gateway-tags", "edge", "--gateway-tags", "eu-west")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, dashboard.count())
+	for _, doc := range dashboard.apis {
+		server := doc[oas.TykExtensionKey].(map[string]interface{})["server"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"edge", "eu-west"}, server["gatewayTags"].(map[string]interface{})["tags"])
+	}
+
+	_, err = runRootCommand(t, "api", "apply", "--file", file, "--gateway-tags", " ")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
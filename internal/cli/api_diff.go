@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// NewAPIDiffCommand creates the 'tyk api diff' command
+func NewAPIDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <api-id>",
+		Short: "Show what differs between a deployed API and a local spec",
+		Long: `Compare a deployed API with a local spec and show a semantic diff: operations
+added, removed or changed, upstream and listen path changes, other Tyk extension
+settings and any remaining OAS changes.
+
+The diff reads as "what applying the local file would change". Settings the
+Dashboard fills into x-tyk-api-gateway on its own are ignored. When the local file
+has no x-tyk-api-gateway extension only the OpenAPI parts are compared.
+
+Like git diff, the output ends with counts of additions, deletions and changes,
+--stat shows only the counts per section, and long diffs on a terminal are shown
+through a pager ($TYK_PAGER, $PAGER or less; disable with --no-pager). With
+--exit-code the command exits 1 when there are differences.
+
+Examples:
+  tyk api diff 7c2f4a1b --file users.yaml
+  tyk api diff 7c2f4a1b --file users.yaml --stat
+  tyk api diff 7c2f4a1b --file users.yaml --exit-code
+  tyk api diff 7c2f4a1b --file users.yaml -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIDiff,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Local OAS file to compare (required)")
+	cmd.Flags().String("version-name", "", "Compare against a specific version of the API")
+	cmd.Flags().Bool("exit-code", false, "Exit with status 1 when there are differences")
+	cmd.Flags().Bool("stat", false, "Show only the number of differences per section")
+	cmd.Flags().Bool("no-pager", false, "Do not page long output")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runAPIDiff(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	filePath, _ := cmd.Flags().GetString("file")
+	versionName, _ := cmd.Flags().GetString("version-name")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+	stat, _ := cmd.Flags().GetBool("stat")
+	noPager, _ := cmd.Flags().GetBool("no-pager")
+
+	local, err := loadOASFromFile(filePath)
+	if err != nil {
+		return err
+	}
+	// Compare what apply would send, not the shorthand
+	if oas.HasTykExtensions(local) {
+		if err := expandOperationRateLimits(local); err != nil {
+			return err
+		}
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	api, err := c.GetOASAPI(ctx, apiID, versionName)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
+		}
+		return wrapAPIError(err, "failed to get API")
+	}
+
+	remote := api.OAS
+	if !oas.HasTykExtensions(local) {
+		remote = withoutTykExtension(remote)
+	}
+	diff := oas.Semantic(remote, local)
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if err := writeStructured(format, map[string]interface{}{
+			"api_id":    apiID,
+			"file":      filePath,
+			"identical": diff.Empty(),
+			"stats":     diff.Stats(),
+			"diff":      diff,
+		}); err != nil {
+			return err
+		}
+	} else {
+		label := fmt.Sprintf("%s (%s)", api.Name, apiID)
+		if err := withPager(noPager, func(w io.Writer) {
+			if stat {
+				writeDiffStat(w, label, filePath, diff)
+			} else {
+				writeSemanticDiff(w, label, filePath, diff)
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	if exitCode && !diff.Empty() {
+		return differencesFoundError(cmd)
+	}
+	return nil
+}
+
+// withoutTykExtension returns a shallow copy of doc without x-tyk-api-gateway
+func withoutTykExtension(doc map[string]interface{}) map[string]interface{} {
+	stripped := make(map[string]interface{}, len(doc))
+	for key, value := range doc {
+		if key != oas.TykExtensionKey {
+			stripped[key] = value
+		}
+	}
+	return stripped
+}
+
+// printSemanticDiff prints a human-readable semantic diff
+func printSemanticDiff(remoteLabel, localLabel string, diff *oas.SemanticDiff) {
+	writeSemanticDiff(os.Stdout, remoteLabel, localLabel, diff)
+}
+
+// writeSemanticDiff writes a human-readable semantic diff followed by its stats
+func writeSemanticDiff(w io.Writer, remoteLabel, localLabel string, diff *oas.SemanticDiff) {
+	if diff.Empty() {
+		color.New(color.FgGreen).Fprintf(w, "No differences between %s and %s\n", remoteLabel, localLabel)
+		return
+	}
+
+	bold := color.New(color.Bold)
+	added := color.New(color.FgGreen)
+	removed := color.New(color.FgRed)
+	changed := color.New(color.FgYellow)
+
+	bold.Fprintf(w, "--- remote %s\n", remoteLabel)
+	bold.Fprintf(w, "+++ local  %s\n", localLabel)
+
+	if diff.Upstream != nil {
+		changed.Fprintf(w, "\nUpstream: %s → %s\n", diff.Upstream.Old, diff.Upstream.New)
+	}
+	if diff.ListenPath != nil {
+		changed.Fprintf(w, "\nListen path: %s → %s\n", diff.ListenPath.Old, diff.ListenPath.New)
+	}
+
+	if len(diff.OperationsAdded)+len(diff.OperationsRemoved)+len(diff.OperationsChanged) > 0 {
+		bold.Fprintln(w, "\nOperations:")
+		for _, op := range diff.OperationsAdded {
+			added.Fprintf(w, "  + %s\n", op)
+		}
+		for _, op := range diff.OperationsRemoved {
+			removed.Fprintf(w, "  - %s\n", op)
+		}
+		for _, op := range diff.OperationsChanged {
+			changed.Fprintf(w, "  ~ %s\n", op)
+		}
+	}
+
+	printChangeSection := func(title string, changes []oas.Change) {
+		if len(changes) == 0 {
+			return
+		}
+		bold.Fprintf(w, "\n%s:\n", title)
+		for _, change := range changes {
+			path := strings.Join(change.Path, ".")
+			switch change.Type {
+			case oas.ChangeAdded:
+				added.Fprintf(w, "  + %s: %s\n", path, formatDiffValue(change.New))
+			case oas.ChangeRemoved:
+				removed.Fprintf(w, "  - %s: %s\n", path, formatDiffValue(change.Old))
+			default:
+				changed.Fprintf(w, "  ~ %s: %s → %s\n", path, formatDiffValue(change.Old), formatDiffValue(change.New))
+			}
+		}
+	}
+	printChangeSection("Tyk extension", diff.TykExtension)
+	printChangeSection("Other", diff.Other)
+
+	fmt.Fprintln(w)
+	writeDiffSummary(w, diff.Stats())
+}
+
+// writeDiffStat writes a per-section count of the differences, like git diff --stat
+func writeDiffStat(w io.Writer, remoteLabel, localLabel string, diff *oas.SemanticDiff) {
+	if diff.Empty() {
+		color.New(color.FgGreen).Fprintf(w, "No differences between %s and %s\n", remoteLabel, localLabel)
+		return
+	}
+
+	operations := oas.DiffStats{Added: len(diff.OperationsAdded), Removed: len(diff.OperationsRemoved), Changed: len(diff.OperationsChanged)}
+	var settings oas.DiffStats
+	if diff.Upstream != nil {
+		settings.Changed++
+	}
+	if diff.ListenPath != nil {
+		settings.Changed++
+	}
+	sections := []struct {
+		name  string
+		stats oas.DiffStats
+	}{
+		{"Operations", operations},
+		{"Upstream/listen path", settings},
+		{"Tyk extension", oas.CountChanges(diff.TykExtension)},
+		{"Other", oas.CountChanges(diff.Other)},
+	}
+
+	added := color.New(color.FgGreen)
+	removed := color.New(color.FgRed)
+	changed := color.New(color.FgYellow)
+	for _, section := range sections {
+		if section.stats.Total() == 0 {
+			continue
+		}
+		fmt.Fprintf(w, " %-21s | %3d ", section.name, section.stats.Total())
+		added.Fprint(w, strings.Repeat("+", min(section.stats.Added, 20)))
+		removed.Fprint(w, strings.Repeat("-", min(section.stats.Removed, 20)))
+		changed.Fprint(w, strings.Repeat("~", min(section.stats.Changed, 20)))
+		fmt.Fprintln(w)
+	}
+	writeDiffSummary(w, diff.Stats())
+}
+
+// writeDiffSummary writes the one-line totals that end a diff
+func writeDiffSummary(w io.Writer, stats oas.DiffStats) {
+	fmt.Fprintf(w, " %d addition%s(+), %d deletion%s(-), %d change%s(~)\n",
+		stats.Added, plural(stats.Added), stats.Removed, plural(stats.Removed), stats.Changed, plural(stats.Changed))
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// formatDiffValue renders a value on one line, shortening large objects
+func formatDiffValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(data) > 80 {
+		return string(data[:77]) + "..."
+	}
+	return string(data)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIDiff(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	// An exported copy of the deployed API has no differences
+	same := filepath.Join(t.TempDir(), "same.json")
+	require.NoError(t, filehandler.SaveFile(same, dashboard.apis["remote-1"]))
+	_, err := runRootCommand(t, "api", "diff", "remote-1", "--file", same, "--exit-code")
+	require.NoError(t, err)
+
+	// A plain OAS file is compared without the Tyk extension
+	plain := writePlanSpec(t, t.TempDir(), "users", "1.1.0")
+	out, err := runRootCommand(t, "api", "diff", "remote-1", "--file", plain, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-diff", out), string(out))
+
+	var result struct {
+		Identical bool           `json:"identical"`
+		Stats     map[string]int `json:"stats"`
+		Diff      struct {
+			Upstream     interface{}   `json:"upstream"`
+			TykExtension []interface{} `json:"tyk_extension"`
+			Other        []struct {
+				Path []string `json:"path"`
+			} `json:"other"`
+		} `json:"diff"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.False(t, result.Identical)
+	assert.Nil(t, result.Diff.Upstream)
+	assert.Empty(t, result.Diff.TykExtension)
+	require.Len(t, result.Diff.Other, 1)
+	assert.Equal(t, []string{"info", "version"}, result.Diff.Other[0].Path)
+	assert.Equal(t, map[string]int{"added": 0, "removed": 0, "changed": 1}, result.Stats)
+
+	out, err = runRootCommand(t, "api", "diff", "remote-1", "--file", plain)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "~ info.version: 1.0.0 → 1.1.0")
+	assert.Contains(t, string(out), "0 additions(+), 0 deletions(-), 1 change(~)")
+
+	out, err = runRootCommand(t, "api", "diff", "remote-1", "--file", plain, "--stat")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), " Other                 |   1 ~\n")
+	assert.NotContains(t, string(out), "info.version")
+
+	_, err = runRootCommand(t, "api", "diff", "remote-1", "--file", plain, "--exit-code")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+	assert.Empty(t, exitErr.Message)
+
+	_, err = runRootCommand(t, "api", "diff", "missing", "--file", plain)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+}
+
+func TestAPIDiff_MissingFile(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: "http://127.0.0.1:1", AuthToken: "token", OrgID: "org"},
+		},
+	})
+	_, err := runRootCommand(t, "api", "diff", "a1", "--file", filepath.Join(os.TempDir(), "does-not-exist.yaml"))
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
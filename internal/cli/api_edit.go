@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// apiEditClient returns the client and request context for commands that edit a deployed API
+func apiEditClient(cmd *cobra.Command) (context.Context, *client.Client, context.CancelFunc, error) {
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return nil, nil, nil, fmt.Errorf("configuration not found")
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 30*time.Second)
+	return ctx, c, cancel, nil
+}
+
+// dashboardClient is apiEditClient for commands whose resources (named by what) only
+// the Dashboard has
+func dashboardClient(cmd *cobra.Command, what string) (context.Context, *client.Client, context.CancelFunc, error) {
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if c.IsGateway() {
+		cancel()
+		return nil, nil, nil, &ExitError{Code: int(types.ExitBadArgs), Message: what + " are managed by the Dashboard; use a dashboard environment"}
+	}
+	return ctx, c, cancel, nil
+}
+
+// getAPIForEdit fetches the deployed API an edit command changes
+func getAPIForEdit(ctx context.Context, c *client.Client, apiID string) (*types.OASAPI, error) {
+	api, err := c.GetOASAPI(ctx, apiID, "")
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return nil, notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
+		}
+		return nil, wrapAPIError(err, "failed to get API")
+	}
+	return api, nil
+}
+
+// editDeployedAPI applies edit to a deployed API's definition and uploads the result,
+// unless this is a dry run or nothing changed. Edit errors are bad arguments.
+func editDeployedAPI(ctx context.Context, c *client.Client, api *types.OASAPI, dryRun bool, edit func(doc map[string]interface{}) error) (*oas.SemanticDiff, error) {
+	before, err := cloneDocument(api.OAS)
+	if err != nil {
+		return nil, err
+	}
+	if err := edit(api.OAS); err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	diff := oas.Semantic(before, api.OAS)
+
+	if !dryRun && !diff.Empty() {
+		saveRevision(c, api.ID, api.Name, before)
+		stored, err := c.UpdateOASAPIIfMatch(ctx, api.ID, api.OAS, api.ETag)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to update API")
+		}
+		warnServerEcho(api.OAS, stored)
+	}
+	return diff, nil
+}
+
+// maxEchoChanges caps the fields warnServerEcho lists
+const maxEchoChanges = 5
+
+// warnServerEcho warns when the stored copy of an updated API differs from the document
+// that was sent. The Dashboard accepts unknown extension keys and then drops them, and
+// normalizes some values, without failing the request.
+func warnServerEcho(sent map[string]interface{}, stored *types.OASAPI) {
+	if stored == nil || stored.OAS == nil {
+		return
+	}
+	changes := oas.EchoChanges(sent, stored.OAS)
+	if len(changes) == 0 {
+		return
+	}
+	yellow := color.New(color.FgYellow)
+	yellow.Fprintf(os.Stderr, "⚠ The stored definition differs from the one sent; %d field%s dropped or rewritten by the server:\n", len(changes), plural(len(changes)))
+	for i, change := range changes {
+		if i == maxEchoChanges {
+			yellow.Fprintf(os.Stderr, "  ... and %d more\n", len(changes)-i)
+			break
+		}
+		path := strings.Join(change.Path, ".")
+		if change.Type == oas.ChangeRemoved {
+			yellow.Fprintf(os.Stderr, "  - %s (dropped)\n", path)
+		} else {
+			yellow.Fprintf(os.Stderr, "  ~ %s: %s -> %s\n", path, formatDiffValue(change.Old), formatDiffValue(change.New))
+		}
+	}
+}
+
+// printAPIEdit reports an edit made by editDeployedAPI; change reads like "cache enabled"
+func printAPIEdit(label, change string, diff *oas.SemanticDiff, dryRun bool) {
+	if diff.Empty() {
+		color.New(color.FgGreen).Printf("✓ %s: nothing to change (%s)\n", label, change)
+		return
+	}
+	printSemanticDiff(label, "with "+change, diff)
+	if dryRun {
+		fmt.Println("\nDry run: nothing was uploaded.")
+		return
+	}
+	color.New(color.FgGreen).Printf("\n✓ Updated %s: %s\n", label, change)
+}
+
+// cloneDocument deep-copies a JSON document so it can be compared after editing
+func cloneDocument(doc map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy API definition: %w", err)
+	}
+	var clone map[string]interface{}
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy API definition: %w", err)
+	}
+	return clone, nil
+}
@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// gcCandidate is an API considered by 'tyk api gc'
+type gcCandidate struct {
+	APIID      string `json:"api_id"`
+	Name       string `json:"name"`
+	LastChange string `json:"last_change,omitempty"`
+	Action     string `json:"action"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewAPIGCCommand creates the 'tyk api gc' command
+func NewAPIGCCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete stale APIs matching a name prefix",
+		Long: `Garbage-collect APIs whose name starts with a prefix and that have not been
+created or updated within the given age.
+
+The most recent of the created and updated timestamps is used. APIs without a
+timestamp are never deleted. Ages accept Go durations (72h, 90m) or days (7d).
+Deleted APIs can be re-created with 'tyk api undelete' for 7 days.
+
+Examples:
+  tyk api gc --prefix pr- --older-than 72h --dry-run
+  tyk api gc --prefix pr- --older-than 7d --yes`,
+		RunE: runAPIGC,
+	}
+
+	cmd.Flags().String("prefix", "", "Only consider APIs whose name starts with this prefix (required)")
+	cmd.Flags().String("older-than", "", "Minimum age since last change, e.g. 72h or 7d (required)")
+	cmd.Flags().Bool("dry-run", false, "List the APIs that would be deleted without deleting them")
+	cmd.MarkFlagRequired("prefix")
+	cmd.MarkFlagRequired("older-than")
+
+	return cmd
+}
+
+func runAPIGC(cmd *cobra.Command, args []string) error {
+	prefix, _ := cmd.Flags().GetString("prefix")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	skipConfirmation := assumeYes(cmd)
+
+	// An empty prefix would match every API in the Dashboard
+	if strings.TrimSpace(prefix) == "" {
+		return &ExitError{Code: 2, Message: "--prefix must not be empty"}
+	}
+	maxAge, err := parseAge("--older-than", olderThan)
+	if err != nil {
+		return &ExitError{Code: 2, Message: err.Error()}
+	}
+	progress, err := newProgressReporter(cmd, "api gc")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+
+	candidates := selectStaleAPIs(apis, prefix, time.Now().Add(-maxAge))
+	outputFormat := GetOutputFormatFromContext(cmd.Context())
+
+	var stale []*gcCandidate
+	for _, candidate := range candidates {
+		if candidate.Action == "delete" {
+			stale = append(stale, candidate)
+		}
+	}
+
+	if dryRun || len(stale) == 0 {
+		return outputGCResults(candidates, dryRun, outputFormat)
+	}
+
+	if !skipConfirmation {
+		fmt.Printf("The following %d API(s) will be deleted:\n", len(stale))
+		for _, candidate := range stale {
+			fmt.Printf("  %s  %s  (last change %s)\n", candidate.APIID, candidate.Name, candidate.LastChange)
+		}
+		ok, err := confirm(cmd, "Continue?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Garbage collection cancelled")
+			return nil
+		}
+	}
+
+	failed := 0
+	progress.Start(len(stale))
+	for _, candidate := range stale {
+		progress.StepStarted(candidate.APIID)
+		if err := deleteTrashedAPI(ctx, c, candidate.APIID, true); err != nil {
+			candidate.Action = "failed"
+			candidate.Error = err.Error()
+			failed++
+		} else {
+			candidate.Action = "deleted"
+		}
+		progress.StepFinished(candidate.APIID, candidate.Error)
+	}
+	progress.Finish()
+
+	if err := outputGCResults(candidates, false, outputFormat); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d stale API(s) failed to delete", failed, len(stale))}
+	}
+	return nil
+}
+
+// selectStaleAPIs classifies prefixed APIs as "delete" when last changed before cutoff,
+// "keep" when newer and "unknown" when no usable timestamp is available
+func selectStaleAPIs(apis []*types.OASAPI, prefix string, cutoff time.Time) []*gcCandidate {
+	var candidates []*gcCandidate
+	for _, api := range apis {
+		if !strings.HasPrefix(api.Name, prefix) {
+			continue
+		}
+
+		candidate := &gcCandidate{APIID: api.ID, Name: api.Name, Action: "unknown"}
+		if lastChange, ok := lastChanged(api); ok {
+			candidate.LastChange = lastChange.Format(time.RFC3339)
+			if lastChange.Before(cutoff) {
+				candidate.Action = "delete"
+			} else {
+				candidate.Action = "keep"
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// lastChanged returns the most recent of the API's created and updated timestamps
+func lastChanged(api *types.OASAPI) (time.Time, bool) {
+	var latest time.Time
+	for _, value := range []string{api.CreatedAt, api.UpdatedAt} {
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		if parsed.After(latest) {
+			latest = parsed
+		}
+	}
+	return latest, !latest.IsZero()
+}
+
+// parseAge parses the value of flag as a Go duration, additionally accepting a whole
+// number of days ("7d")
+func parseAge(flag, value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid %s '%s': expected a positive number of days", flag, value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s': expected a positive duration such as 72h or 7d", flag, value)
+	}
+	return age, nil
+}
+
+// outputGCResults reports what gc found and did
+func outputGCResults(candidates []*gcCandidate, dryRun bool, format types.OutputFormat) error {
+	if format.IsStructured() {
+		if candidates == nil {
+			candidates = []*gcCandidate{}
+		}
+		return writeStructured(format, map[string]interface{}{
+			"dry_run": dryRun,
+			"apis":    candidates,
+			"count":   len(candidates),
+		})
+	}
+
+	if len(candidates) == 0 {
+		color.New(color.FgYellow).Println("No APIs matched the prefix")
+		return nil
+	}
+
+	green := color.New(color.FgGreen, color.Bold)
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+	for _, c := range candidates {
+		switch c.Action {
+		case "deleted":
+			green.Printf("✓ Deleted API '%s' (%s)\n", c.Name, c.APIID)
+		case "failed":
+			red.Printf("✗ %s: %s\n", c.Name, c.Error)
+		case "delete":
+			yellow.Printf("Would delete API '%s' (%s), last change %s\n", c.Name, c.APIID, c.LastChange)
+		case "unknown":
+			fmt.Fprintf(os.Stderr, "Skipped API '%s' (%s): no timestamp available\n", c.Name, c.APIID)
+		}
+	}
+	return nil
+}
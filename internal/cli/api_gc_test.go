@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestParseAge(t *testing.T) {
+	age, err := parseAge("--older-than", "72h")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, age)
+
+	age, err = parseAge("--older-than", "7d")
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, age)
+
+	for _, invalid := range []string{"", "soon", "-1h", "0d", "xd"} {
+		_, err := parseAge("--older-than", invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSelectStaleAPIs(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-100 * time.Hour).Format(time.RFC3339)
+	recent := now.Add(-time.Hour).Format(time.RFC3339)
+
+	apis := []*types.OASAPI{
+		{ID: "1", Name: "pr-1-users", CreatedAt: old},
+		{ID: "2", Name: "pr-2-users", CreatedAt: old, UpdatedAt: recent},
+		{ID: "3", Name: "pr-3-users"},
+		{ID: "4", Name: "shared-users", CreatedAt: old},
+	}
+
+	candidates := selectStaleAPIs(apis, "pr-", now.Add(-72*time.Hour))
+	require.Len(t, candidates, 3)
+	assert.Equal(t, "delete", candidates[0].Action)
+	assert.Equal(t, "keep", candidates[1].Action)
+	assert.Equal(t, "unknown", candidates[2].Action)
+}
+
+func TestRunAPIGC_DeletesOnlyStalePrefixedAPIs(t *testing.T) {
+	old := time.Now().Add(-100 * time.Hour).Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			mu.Lock()
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/apis/oas/"))
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/apis/oas/") {
+			// Deleted APIs are fetched first, for the trash
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"openapi":           "3.0.3",
+				"info":              map[string]interface{}{"title": "pr-1-users"},
+				"x-tyk-api-gateway": map[string]interface{}{"info": map[string]interface{}{"id": "stale", "name": "pr-1-users"}},
+			})
+			return
+		}
+		items := []interface{}{}
+		if r.URL.Query().Get("p") == "1" {
+			for _, api := range []struct{ id, name, created string }{
+				{"stale", "pr-1-users", old},
+				{"fresh", "pr-2-users", recent},
+				{"shared", "users", old},
+			} {
+				items = append(items, map[string]interface{}{
+					"created_at":     api.created,
+					"api_definition": map[string]interface{}{"api_id": api.id, "name": api.name},
+				})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"apis": items})
+	}))
+	defer server.Close()
+
+	cmd := NewAPIGCCommand()
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	}
+	cmd.SetContext(withOutputFormat(withConfig(context.Background(), config), types.OutputJSON))
+	t.Setenv(EnvAssumeYes, "1")
+	cmd.SetArgs([]string{"--prefix", "pr-", "--older-than", "72h"})
+
+	out, err := captureStdout(cmd.Execute)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stale"}, deleted)
+
+	var result struct {
+		APIs []gcCandidate `json:"apis"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Len(t, result.APIs, 2)
+	assert.Equal(t, "deleted", result.APIs[0].Action)
+	assert.Equal(t, "keep", result.APIs[1].Action)
+}
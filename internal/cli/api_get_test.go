@@ -359,4 +359,60 @@ func TestAPIGet_ErrorHandling(t *testing.T) {
 	} else {
 		assert.Contains(t, err.Error(), "not found")
 	}
-}
\ No newline at end of file
+}
+func TestAPIGet_UnauthorizedExitCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"Status":"Error","Message":"Not authorised"}`))
+	}))
+	defer server.Close()
+
+	getCmd := NewAPIGetCommand()
+
+	cfg := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "bad-token", OrgID: "org"},
+		},
+	}
+	getCmd.SetContext(withConfig(context.Background(), cfg))
+
+	getCmd.SetArgs([]string{"some-api"})
+	err := getCmd.Execute()
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitUnauthorized), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "authentication failed")
+}
+
+func TestAPIGet_Fields(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "get", "users-1", "--fields", "info,x-tyk-api-gateway.upstream,components", "-o", "json")
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &doc))
+	assert.Len(t, doc, 2)
+	assert.Equal(t, "users", doc["info"].(map[string]interface{})["title"])
+	tykExt := doc["x-tyk-api-gateway"].(map[string]interface{})
+	assert.Len(t, tykExt, 1)
+	assert.Contains(t, tykExt, "upstream")
+
+	// Human output is the pruned document as YAML
+	out, err = runRootCommand(t, "api", "get", "users-1", "--fields", "info.title")
+	require.NoError(t, err)
+	assert.Equal(t, "info:\n    title: users\n", string(out))
+
+	_, err = runRootCommand(t, "api", "get", "users-1", "--fields", "nope")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPIHeadersCommand creates the 'tyk api headers' command and its subcommands
+func NewAPIHeadersCommand() *cobra.Command {
+	headersCmd := &cobra.Command{
+		Use:   "headers",
+		Short: "Manage an API's global header transforms",
+		Long:  `Commands for managing the request and response headers Tyk adds or removes on every call to an API.`,
+	}
+
+	headersCmd.AddCommand(NewAPIHeadersSetCommand())
+
+	return headersCmd
+}
+
+// NewAPIHeadersSetCommand creates the 'tyk api headers set' command
+func NewAPIHeadersSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <api-id>",
+		Short: "Add or remove request and response headers for every call to an API",
+		Long: `Update the API-wide request and response header transforms in
+x-tyk-api-gateway.middleware.global and upload the result.
+
+Added headers replace any existing header of the same name. Adding a header that was
+being removed stops removing it, and the other way round. Values may use Tyk variables
+such as $tyk_context.request_id, which need the context-variables middleware
+(tyk api middleware <api-id> enable context-variables).
+
+Examples:
+  tyk api headers set 7c2f4a1b --request-add 'X-Env: prod' --response-remove Server
+  tyk api headers set 7c2f4a1b --request-add 'X-Env: prod' --request-add 'X-Team: payments'
+  tyk api headers set 7c2f4a1b --response-add 'Cache-Control: no-store' --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIHeadersSet,
+	}
+
+	cmd.Flags().StringArray("request-add", nil, "Header to add to requests, as 'Name: value'; repeat for several")
+	cmd.Flags().StringArray("request-remove", nil, "Header to remove from requests; repeat for several")
+	cmd.Flags().StringArray("response-add", nil, "Header to add to responses, as 'Name: value'; repeat for several")
+	cmd.Flags().StringArray("response-remove", nil, "Header to remove from responses; repeat for several")
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+
+	return cmd
+}
+
+// headerChanges are the headers to add and remove in one direction
+type headerChanges struct {
+	add    []oas.Header
+	remove []string
+}
+
+func runAPIHeadersSet(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	changes := make(map[string]headerChanges)
+	for _, direction := range []string{oas.HeadersRequest, oas.HeadersResponse} {
+		added, _ := cmd.Flags().GetStringArray(direction + "-add")
+		removed, _ := cmd.Flags().GetStringArray(direction + "-remove")
+		var change headerChanges
+		for _, raw := range added {
+			header, err := oas.ParseHeader(raw)
+			if err != nil {
+				return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--%s-add: %v", direction, err)}
+			}
+			change.add = append(change.add, header)
+		}
+		change.remove = removed
+		changes[direction] = change
+	}
+	if len(changes[oas.HeadersRequest].add)+len(changes[oas.HeadersRequest].remove)+
+		len(changes[oas.HeadersResponse].add)+len(changes[oas.HeadersResponse].remove) == 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "nothing to set: pass --request-add, --request-remove, --response-add or --response-remove"}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		for direction, change := range changes {
+			if err := oas.TransformHeaders(doc, direction, change.add, change.remove); err != nil {
+				return fmt.Errorf("--%s-remove: %v", direction, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	warnContextVariables(api.OAS, changes)
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":  apiID,
+			"dry_run": dryRun,
+			"changed": !diff.Empty(),
+			"diff":    diff,
+		})
+	}
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), "header transforms", diff, dryRun)
+	return nil
+}
+
+// warnContextVariables warns when added headers use $tyk_context variables that the API
+// does not populate
+func warnContextVariables(doc map[string]interface{}, changes map[string]headerChanges) {
+	status, _ := oas.MiddlewareStatus(doc, oas.MiddlewareContextVariables)
+	if len(status) > 0 && status[0].Enabled {
+		return
+	}
+	for _, change := range changes {
+		for _, header := range change.add {
+			if strings.Contains(header.Value, "$tyk_context.") {
+				color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ %s uses $tyk_context variables but context variables are disabled; enable them with 'tyk api middleware <api-id> enable context-variables'.\n", header.Name)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIHeadersSet(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	global := func() map[string]interface{} {
+		middleware, _ := dashboard.apis["remote-1"][oas.TykExtensionKey].(map[string]interface{})["middleware"].(map[string]interface{})
+		global, _ := middleware["global"].(map[string]interface{})
+		return global
+	}
+
+	_, err := runRootCommand(t, "api", "headers", "set", "remote-1", "--request-add", "X-Env: prod", "--dry-run")
+	require.NoError(t, err)
+	assert.Nil(t, global())
+
+	out, err := runRootCommand(t, "api", "headers", "set", "remote-1", "--request-add", "X-Env: prod", "--response-remove", "Server", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-headers-set", out), string(out))
+
+	request := global()["transformRequestHeaders"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "X-Env", "value": "prod"}}, request["add"])
+	response := global()["transformResponseHeaders"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"Server"}, response["remove"])
+
+	for _, args := range [][]string{{}, {"--request-add", "X-Env"}} {
+		_, err = runRootCommand(t, append([]string{"api", "headers", "set", "remote-1"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, args)
+		assert.Equal(t, 2, exitErr.Code)
+	}
+}
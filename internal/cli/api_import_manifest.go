@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/importmanifest"
+	"github.com/tyktech/tyk-cli/internal/logging"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// manifestImportResult records what happened to one entry of an import manifest
+type manifestImportResult struct {
+	Source     string `json:"source" yaml:"source"`
+	APIID      string `json:"api_id,omitempty" yaml:"api_id,omitempty"`
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+	ListenPath string `json:"listen_path,omitempty" yaml:"listen_path,omitempty"`
+	// Operation is created or updated; empty when the entry failed
+	Operation string `json:"operation,omitempty" yaml:"operation,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+	keyless   bool
+}
+
+// manifestImportOutput is the structured output of 'tyk api import-oas --manifest'
+type manifestImportOutput struct {
+	Results []manifestImportResult `json:"results" yaml:"results"`
+	Created int                    `json:"created" yaml:"created"`
+	Updated int                    `json:"updated" yaml:"updated"`
+	Failed  int                    `json:"failed" yaml:"failed"`
+}
+
+// perAPIImportFlags are the import flags that cannot apply to every API of a manifest
+var perAPIImportFlags = []string{"listen-path", "upstream-url", "custom-domain"}
+
+// runAPIImportManifest imports every spec a manifest lists or discovers. APIs are matched
+// to existing ones by name, so re-running a manifest updates them in place.
+func runAPIImportManifest(cmd *cobra.Command, config *types.Config, path string) error {
+	if cmd.Flags().Changed("validation-report") {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--validation-report cannot be used with --manifest; the summary lists the error of every API"}
+	}
+	for _, flag := range perAPIImportFlags {
+		if cmd.Flags().Changed(flag) {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--%s cannot be used with --manifest; set %s per entry in the manifest", flag, strings.ReplaceAll(flag, "-", "_"))}
+		}
+	}
+	base, err := extensionOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	manifest, err := importmanifest.Load(path)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	progress, err := newProgressReporter(cmd, "import manifest")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	discovery := &http.Client{Timeout: 30 * time.Second, Transport: logging.NewTransport(nil)}
+	entries, err := manifest.Entries(ctx, discovery)
+	if err != nil {
+		return fmt.Errorf("failed to list the manifest's APIs: %w", err)
+	}
+	if len(entries) == 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: no APIs to import; discovery found no services", path)}
+	}
+
+	// Index existing APIs by name so re-runs update in place
+	existing, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	existingIDs := make(map[string]string, len(existing))
+	for _, api := range existing {
+		existingIDs[api.Name] = api.ID
+	}
+
+	output := manifestImportOutput{Results: []manifestImportResult{}}
+	imported := map[string]string{}
+	progress.Start(len(entries))
+	for _, entry := range entries {
+		progress.StepStarted(entry.Source())
+		result := importManifestEntry(ctx, cmd, c, config, base, entry, existingIDs, imported)
+		progress.StepFinished(entry.Source(), result.Error)
+		switch result.Operation {
+		case "created":
+			output.Created++
+		case "updated":
+			output.Updated++
+		default:
+			output.Failed++
+		}
+		output.Results = append(output.Results, result)
+	}
+	progress.Finish()
+
+	if err := printManifestImport(cmd, output); err != nil {
+		return err
+	}
+	if output.Failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d API(s) failed to import", output.Failed, len(entries))}
+	}
+	return nil
+}
+
+// importManifestEntry imports or updates the API of one manifest entry
+func importManifestEntry(ctx context.Context, cmd *cobra.Command, c *client.Client, config *types.Config, base oas.ExtensionOptions, entry importmanifest.Entry, existingIDs, imported map[string]string) manifestImportResult {
+	result := manifestImportResult{Source: entry.Source()}
+
+	opts, err := manifestExtensionOptions(base, entry.Overrides)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	var oasData map[string]interface{}
+	if oasData, err = loadUploadSpec(cmd, entry.File, entry.URL); err != nil {
+		result.Error = errorMessage(err)
+		return result
+	}
+
+	oasData, err = oas.AddTykExtensionsWithOptions(oasData, opts)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to generate Tyk extensions: %v", err)
+		return result
+	}
+	if err := expandOperationRateLimits(oasData); err != nil {
+		result.Error = errorMessage(err)
+		return result
+	}
+	oasData = stripExistingAPIID(oasData)
+	result.Name = oas.GetAPIName(oasData)
+	if err := mountEnvironmentPrefix(config, fmt.Sprintf("API '%s'", result.Name), oasData); err != nil {
+		result.Error = errorMessage(err)
+		return result
+	}
+	result.ListenPath = oas.GetListenPath(oasData)
+	result.keyless = opts.Auth == "" && slices.Equal(oas.AuthModes(oasData), []string{oas.AuthKeyless})
+
+	// Two entries with the same name would overwrite each other
+	if other, ok := imported[result.Name]; ok {
+		result.Error = fmt.Sprintf("API '%s' is also imported from %s; give one a different title", result.Name, other)
+		return result
+	}
+	imported[result.Name] = result.Source
+
+	if id, ok := existingIDs[result.Name]; ok {
+		existing, err := c.GetOASAPI(ctx, id, "")
+		if err != nil {
+			result.Error = errorMessage(wrapAPIError(err, fmt.Sprintf("failed to get API '%s'", id)))
+			return result
+		}
+		setTykAPIID(oasData, id)
+		api, err := replaceDeployedAPI(ctx, cmd, c, id, existing, oasData)
+		if api != nil {
+			result.APIID = api.ID
+			result.Operation = "updated"
+		}
+		if err != nil {
+			result.Error = errorMessage(err)
+		}
+		return result
+	}
+
+	api, err := c.CreateOASAPI(ctx, oasData)
+	if err != nil {
+		result.Error = errorMessage(wrapAPIError(err, "failed to create API"))
+		return result
+	}
+	result.APIID = api.ID
+	result.Operation = "created"
+	if err := setOwnerGroups(ctx, cmd, c, api.ID); err != nil {
+		result.Error = errorMessage(err)
+	}
+	return result
+}
+
+// manifestExtensionOptions lays the overrides of a manifest entry over the import flags
+func manifestExtensionOptions(base oas.ExtensionOptions, overrides importmanifest.Overrides) (oas.ExtensionOptions, error) {
+	opts := base
+	opts.ListenPath = overrides.ListenPath
+	opts.UpstreamURL = overrides.UpstreamURL
+	opts.CustomDomain = overrides.CustomDomain
+	opts.Inactive = base.Inactive || overrides.Inactive
+	opts.Internal = base.Internal || overrides.Internal
+	opts.Tags = slices.Clone(base.Tags)
+	for _, tag := range overrides.Tags {
+		if !slices.Contains(opts.Tags, tag) {
+			opts.Tags = append(opts.Tags, tag)
+		}
+	}
+	opts.Categories = slices.Clone(base.Categories)
+	for _, category := range overrides.Categories {
+		if !slices.Contains(opts.Categories, category) {
+			opts.Categories = append(opts.Categories, category)
+		}
+	}
+	if overrides.Auth != "" {
+		opts.Auth = overrides.Auth
+	}
+
+	if opts.ListenPath != "" && !strings.HasPrefix(opts.ListenPath, "/") {
+		return opts, fmt.Errorf("listen_path must start with '/' (got '%s')", opts.ListenPath)
+	}
+	if opts.Auth != "" && !slices.Contains(oas.ScaffoldAuthTypes, opts.Auth) {
+		return opts, fmt.Errorf("auth must be one of %s (got '%s')", strings.Join(oas.ScaffoldAuthTypes, ", "), opts.Auth)
+	}
+	for _, category := range opts.Categories {
+		if err := oas.CheckCategory(category); err != nil {
+			return opts, fmt.Errorf("categories: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+// printManifestImport prints the summary table of a manifest import
+func printManifestImport(cmd *cobra.Command, output manifestImportOutput) error {
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, output)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tNAME\tLISTEN PATH\tRESULT\tAPI ID")
+	var keyless []string
+	for _, r := range output.Results {
+		status := r.Operation
+		if r.Error != "" {
+			status = "failed: " + r.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Source, r.Name, r.ListenPath, status, r.APIID)
+		if r.keyless && r.Operation != "" {
+			keyless = append(keyless, r.Name)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	summary := fmt.Sprintf("%d created, %d updated, %d failed", output.Created, output.Updated, output.Failed)
+	if output.Failed > 0 {
+		color.New(color.FgRed).Println("✗ " + summary)
+	} else {
+		color.New(color.FgGreen).Println("✓ " + summary)
+	}
+	if len(keyless) > 0 {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ %d API(s) have no authentication and accept any request: %s. Set auth in the manifest, or pass --auth.\n", len(keyless), strings.Join(keyless, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIImportOAS_Manifest(t *testing.T) {
+	t.Setenv("TYK_HISTORY_DIR", t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	dashboard.etags = true
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	specs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users.json" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"openapi": "3.0.3",
+			"info":    map[string]interface{}{"title": "Users", "version": "1.0.0"},
+			"servers": []interface{}{map[string]interface{}{"url": "https://users.internal"}},
+			"paths":   map[string]interface{}{},
+		})
+	}))
+	defer specs.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "specs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "orders.yaml"), []byte(`openapi: 3.0.3
+info: {title: Orders, version: 1.0.0}
+servers: [{url: "https://orders.internal"}]
+paths: {}
+`), 0644))
+	manifest := filepath.Join(dir, "apis.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(`defaults:
+  auth: apikey
+apis:
+  - file: specs/orders.yaml
+    listen_path: /shop/orders/
+    tags: [edge]
+  - url: `+specs.URL+`/users.json
+  - url: `+specs.URL+`/missing.json
+`), 0644))
+
+	out, err := runRootCommand(t, "api", "import-oas", "--manifest", manifest, "-o", "json")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr, "the missing spec fails the run")
+	require.NoError(t, outputschema.Validate("api-import-manifest", out), string(out))
+	var output manifestImportOutput
+	require.NoError(t, json.Unmarshal(out, &output))
+	assert.Equal(t, 2, output.Created)
+	assert.Equal(t, 1, output.Failed)
+	assert.Equal(t, "/shop/orders/", output.Results[0].ListenPath)
+	assert.Contains(t, output.Results[2].Error, "HTTP 404")
+	require.Len(t, dashboard.apis, 2)
+	orders := dashboard.apis[output.Results[0].APIID]
+	ordersServer := orders[oas.TykExtensionKey].(map[string]interface{})["server"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"edge"}, ordersServer["gatewayTags"].(map[string]interface{})["tags"])
+	assert.Equal(t, []string{oas.AuthAPIKey}, oas.AuthModes(orders))
+
+	// A re-run updates the APIs in place
+	require.NoError(t, os.WriteFile(manifest, []byte(`apis:
+  - file: specs/orders.yaml
+    listen_path: /shop/orders/v2/
+  - url: `+specs.URL+`/users.json
+`), 0644))
+	out, err = runRootCommand(t, "api", "import-oas", "--manifest", manifest, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &output))
+	assert.Equal(t, 0, output.Created)
+	assert.Equal(t, 2, output.Updated)
+	require.Len(t, dashboard.apis, 2)
+	assert.Equal(t, "/shop/orders/v2/", oas.GetListenPath(dashboard.apis[output.Results[0].APIID]))
+	assert.Zero(t, dashboard.blindUpdates, "APIs are replaced only if unchanged since they were read")
+	// Updates keep the replaced definition for rollback, like api apply
+	out, err = runRootCommand(t, "api", "history", output.Results[0].APIID, "-o", "json")
+	require.NoError(t, err)
+	var revisions []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &revisions))
+	assert.Len(t, revisions, 1)
+
+	_, err = runRootCommand(t, "api", "import-oas", "--manifest", manifest, "--listen-path", "/all/")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
 	"github.com/tyktech/tyk-cli/pkg/types"
 	"gopkg.in/yaml.v3"
 )
@@ -424,3 +426,108 @@ func TestLoadOASFromURL_InvalidJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse OAS document")
 }
+
+func TestRunAPIImportOAS_ExtensionOverrides(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	spec := mockCleanOAS()
+	spec["servers"] = []interface{}{
+		map[string]interface{}{"url": "https://staging.example.com"},
+		map[string]interface{}{"url": "https://prod.example.com"},
+	}
+	file := createTempOASFile(t, spec)
+
+	_, err := runRootCommand(t, "api", "import-oas", "--file", file, "-o", "json",
+		"--listen-path", "/clean/", "--upstream-url", "https://clean.internal", "--custom-domain", "api.example.com",
+		"--inactive", "--tag", "edge", "--tag", "eu")
+	require.NoError(t, err)
+	require.Len(t, dashboard.apis, 1)
+
+	for _, doc := range dashboard.apis {
+		tyk := doc["x-tyk-api-gateway"].(map[string]interface{})
+		assert.Equal(t, "https://clean.internal", tyk["upstream"].(map[string]interface{})["url"])
+		server := tyk["server"].(map[string]interface{})
+		assert.Equal(t, "/clean/", server["listenPath"].(map[string]interface{})["value"])
+		assert.Equal(t, "api.example.com", server["customDomain"].(map[string]interface{})["name"])
+		assert.Equal(t, []interface{}{"edge", "eu"}, server["gatewayTags"].(map[string]interface{})["tags"])
+		assert.Equal(t, false, tyk["info"].(map[string]interface{})["state"].(map[string]interface{})["active"])
+	}
+}
+
+func TestRunAPIImportOAS_Auth(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	spec := mockCleanOAS()
+	spec["servers"] = []interface{}{map[string]interface{}{"url": "https://clean.internal"}}
+	file := createTempOASFile(t, spec)
+
+	_, err := runRootCommand(t, "api", "import-oas", "--file", file, "--auth", "apikey", "-o", "json")
+	require.NoError(t, err)
+	require.Len(t, dashboard.apis, 1)
+	for _, doc := range dashboard.apis {
+		assert.Equal(t, []string{oas.AuthAPIKey}, oas.AuthModes(doc))
+	}
+}
+
+func TestRunAPIImportOAS_InvalidOverrides(t *testing.T) {
+	_, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	file := createTempOASFile(t, mockCleanOAS())
+
+	for _, args := range [][]string{
+		{"--listen-path", "clean"},
+		{"--upstream-url", "clean.internal"},
+		{"--custom-domain", "https://api.example.com"},
+		{"--auth", "basic"},
+	} {
+		_, err := runRootCommand(t, append([]string{"api", "import-oas", "--file", file}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, args)
+		assert.Equal(t, 2, exitErr.Code, args)
+	}
+}
+
+func TestRunAPIUpdateOAS_WarnsWhenDashboardDropsFields(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	dashboard.dropOnSave = []string{"x-team-owner"}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	doc, err := cloneDocument(dashboard.apis["remote-1"])
+	require.NoError(t, err)
+	doc["x-team-owner"] = "payments"
+	tmpFile := createTempOASFile(t, doc)
+
+	oldStderr := os.Stderr
+	rErr, wErr, _ := os.Pipe()
+	os.Stderr = wErr
+	_, err = runRootCommand(t, "api", "update-oas", "remote-1", "--file", tmpFile)
+	wErr.Close()
+	os.Stderr = oldStderr
+	require.NoError(t, err)
+	stderr, _ := io.ReadAll(rErr)
+
+	assert.Contains(t, string(stderr), "1 field dropped or rewritten by the server")
+	assert.Contains(t, string(stderr), "x-team-owner (dropped)")
+}
@@ -48,8 +48,8 @@ func TestAPIListInteractiveFlag(t *testing.T) {
 
 // Note: Interactive error testing is challenging in unit tests due to global flag handling
 // The error case is verified through manual testing:
-// ./build/tyk api list --interactive --json
-// Error: interactive mode is not compatible with JSON output format
+// ./build/tyk api list --interactive --output json
+// Error: interactive mode is not compatible with JSON or YAML output
 
 func TestDisplayAPIPage(t *testing.T) {
 	// Test the displayAPIPage function
@@ -69,7 +69,7 @@ func TestDisplayAPIPage(t *testing.T) {
 	os.Stderr = wErr
 
 	// Test non-interactive display
-	displayAPIPage(apis, 1, false)
+	displayAPIPage(apis, 1, false, false)
 
 	// Restore stdout and stderr
 	w.Close()
@@ -109,7 +109,7 @@ func TestDisplayAPIPageEmpty(t *testing.T) {
 	rErr, wErr, _ := os.Pipe()
 	os.Stderr = wErr
 
-	displayAPIPage(apis, 1, false)
+	displayAPIPage(apis, 1, false, false)
 
 	wErr.Close()
 	os.Stderr = oldStderr
@@ -157,7 +157,7 @@ func TestDisplayAPIPageInteractive(t *testing.T) {
 	os.Stderr = wErr
 
 	// Test interactive display
-	displayAPIPage(apis, 2, true)
+	displayAPIPage(apis, 2, true, false)
 
 	// Restore stderr
 	wErr.Close()
@@ -186,8 +186,8 @@ func TestDisplayAPIPageInteractive(t *testing.T) {
 	assert.Contains(t, output, "test-id-1")
 	assert.Contains(t, output, "Test API 1")
 	
-    // Verify truncation rules: Name truncated, Listen Path not truncated
-    assert.Contains(t, output, "Very Long API Name Th...")
+    // Verify truncation rules: Name truncated to fit 80 columns, Listen Path not truncated
+    assert.Contains(t, output, "Very Long API Name Tha...")
     assert.Contains(t, output, "/very/long/path/that/should/be/truncated")
 }
 
@@ -200,7 +200,7 @@ func TestDisplayAPIPageEmptyInteractive(t *testing.T) {
 	rErr, wErr, _ := os.Pipe()
 	os.Stderr = wErr
 
-	displayAPIPage(apis, 5, true)
+	displayAPIPage(apis, 5, true, false)
 
 	wErr.Close()
 	os.Stderr = oldStderr
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPISetInternalCommand creates the 'tyk api set-internal' command
+func NewAPISetInternalCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-internal <api-id>",
+		Short: "Make an API reachable only from other APIs",
+		Long: `Mark a deployed API as internal. The Gateway stops routing outside traffic to an
+internal API; other APIs reach it by looping (see 'tyk api loop').
+
+Examples:
+  tyk api set-internal 7c2f4a1b
+  tyk api set-internal 7c2f4a1b --off      # Route outside traffic to it again`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPISetInternal,
+	}
+
+	cmd.Flags().Bool("off", false, "Make the API externally routable again")
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+
+	return cmd
+}
+
+// NewAPILoopCommand creates the 'tyk api loop' command
+func NewAPILoopCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "loop <api-id>",
+		Short: "Route an API or some of its endpoints to another API inside the Gateway",
+		Long: `Send requests to another API through the Gateway (a tyk:// looping URL) instead of
+over the network. The target is an API ID, an API name, or "self" to loop back into the
+same API. Names are resolved to IDs so the loop keeps working if the target is renamed.
+
+Without --operation the whole API's upstream becomes the target. With --operation each
+listed endpoint is rewritten to the target instead.
+
+Examples:
+  tyk api loop 7c2f4a1b --to "Users Internal"
+  tyk api loop 7c2f4a1b --to 9d3e5b2c --path /v2/users --operation listUsers
+  tyk api loop 7c2f4a1b --to self --path /health --operation ping --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPILoop,
+	}
+
+	cmd.Flags().String("to", "", "Target API ID or name, or self (required)")
+	cmd.Flags().String("path", "/", "Path on the target API")
+	cmd.Flags().StringSlice("operation", nil, "Operation ID to loop instead of the whole API; repeat for several")
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runAPISetInternal(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	off, _ := cmd.Flags().GetBool("off")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		oas.SetInternal(doc, !off)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":   apiID,
+			"internal": !off,
+			"dry_run":  dryRun,
+			"changed":  !diff.Empty(),
+			"diff":     diff,
+		})
+	}
+	change := "internal"
+	if off {
+		change = "externally routable"
+	}
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), change, diff, dryRun)
+	return nil
+}
+
+func runAPILoop(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	to, _ := cmd.Flags().GetString("to")
+	path, _ := cmd.Flags().GetString("path")
+	operations, _ := cmd.Flags().GetStringSlice("operation")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	target := oas.LoopSelf
+	var steps []nextStep
+	if to != oas.LoopSelf {
+		targetAPI, err := resolveLoopTarget(ctx, c, to)
+		if err != nil {
+			return err
+		}
+		target = targetAPI.ID
+		if !targetAPI.Internal {
+			steps = append(steps, nextStep{Command: "tyk api set-internal " + targetAPI.ID, Description: "Only route traffic to it from other APIs"})
+			color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ %s is also reachable from outside the Gateway; run 'tyk api set-internal %s' if only other APIs should call it.\n", targetAPI.Name, targetAPI.ID)
+		}
+	}
+	loopURL := oas.LoopURL(target, path)
+
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		return oas.SetLoop(doc, loopURL, operations)
+	})
+	if err != nil {
+		return err
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if operations == nil {
+			operations = []string{}
+		}
+		return writeStructured(format, map[string]interface{}{
+			"api_id":      apiID,
+			"target":      target,
+			"url":         loopURL,
+			"operations":  operations,
+			"dry_run":     dryRun,
+			"changed":     !diff.Empty(),
+			"diff":        diff,
+			"suggestions": suggestions(steps),
+		})
+	}
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), "looping to "+loopURL, diff, dryRun)
+	return nil
+}
+
+// resolveLoopTarget finds the API a loop points at by ID, or else by exact name
+func resolveLoopTarget(ctx context.Context, c *client.Client, target string) (*types.OASAPI, error) {
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return nil, wrapAPIError(err, "failed to list APIs")
+	}
+	var byName []*types.OASAPI
+	for _, api := range apis {
+		if api.ID == target {
+			return api, nil
+		}
+		if api.Name == target {
+			byName = append(byName, api)
+		}
+	}
+	switch len(byName) {
+	case 0:
+		return nil, &ExitError{Code: int(types.ExitNotFound), Message: fmt.Sprintf("target API '%s' not found", target)}
+	case 1:
+		return byName[0], nil
+	}
+	return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%d APIs are named '%s'; pass the target's API ID instead", len(byName), target)}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPISetInternalAndLoop(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "accounts", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "set-internal", "remote-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-set-internal", out), string(out))
+	assert.True(t, oas.IsInternal(dashboard.apis["remote-1"]))
+
+	// Targets given by name are stored by ID
+	out, err = runRootCommand(t, "api", "loop", "remote-2", "--to", "users", "--path", "/v2", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-loop", out), string(out))
+	upstream := dashboard.apis["remote-2"][oas.TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})
+	assert.Equal(t, "tyk://remote-1/v2", upstream["url"])
+
+	_, err = runRootCommand(t, "api", "loop", "remote-2", "--to", "nope")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/operator"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPIExportK8sCommand creates the 'tyk api export-k8s' command
+func NewAPIExportK8sCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-k8s [api-id]",
+		Short: "Export an API as Tyk Operator resources, or read one back",
+		Long: `Write a deployed API as the custom resources of the Tyk Operator, to move it from a
+CLI-managed environment to a cluster managed by the Operator.
+
+OAS APIs become a TykOasApiDefinition and the ConfigMap holding the OAS document it
+references; classic APIs, such as GraphQL and TCP APIs, become an ApiDefinition. The
+resources are named after the API (--name overrides it) and have no namespace unless
+--namespace is given, so 'kubectl apply -n' decides.
+
+With --from-file the conversion runs the other way: the API of an Operator manifest is
+written as an OAS document (or a classic definition) that 'tyk api apply' and
+'tyk api import-oas' take. A manifest of several APIs needs --name to pick one.
+
+--encrypt encrypts the export with age to each recipient, for manifests kept in shared
+artifact stores. Encrypted manifests are read back, like every file the CLI reads,
+with the identities of $TYK_AGE_IDENTITY_FILE.
+
+Examples:
+  tyk api export-k8s 7c2f4a1b --out tykapi.yaml
+  tyk api export-k8s 7c2f4a1b --namespace apis | kubectl apply -f -
+  tyk api export-k8s --from-file tykapi.yaml --out users.yaml
+  tyk api export-k8s 7c2f4a1b --encrypt age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p --out tykapi.yaml.age`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAPIExportK8s,
+	}
+
+	cmd.Flags().String("out", "", "File to write to (default: standard output)")
+	cmd.Flags().String("name", "", "Resource name (default: derived from the API name); with --from-file, the API to read")
+	cmd.Flags().String("namespace", "", "Namespace of the resources")
+	cmd.Flags().String("from-file", "", "Read the API back from an Operator manifest instead")
+	addEncryptFlag(cmd)
+
+	return cmd
+}
+
+func runAPIExportK8s(cmd *cobra.Command, args []string) error {
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	switch {
+	case fromFile != "" && len(args) > 0:
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "give an API ID or --from-file, not both"}
+	case fromFile != "" && cmd.Flags().Changed("encrypt"):
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--encrypt applies to exports, not --from-file"}
+	case fromFile != "":
+		return runAPIReadK8sManifest(cmd, fromFile)
+	case len(args) == 0:
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "an API ID is required, or --from-file to read an Operator manifest"}
+	}
+	apiID := args[0]
+	out, _ := cmd.Flags().GetString("out")
+	name, _ := cmd.Flags().GetString("name")
+	namespace, _ := cmd.Flags().GetString("namespace")
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	// Every API has a classic definition; OAS APIs are exported from their OAS document
+	var resources []operator.Resource
+	def, err := c.GetClassicAPI(ctx, apiID)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		return wrapAPIError(err, "failed to get API")
+	}
+	if err == nil && def["is_oas"] != true {
+		apiName, _ := def["name"].(string)
+		if name == "" {
+			name = operator.ResourceName(apiName)
+		}
+		resource, err := operator.ClassicResource(def, name, namespace)
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		resources = []operator.Resource{resource}
+	} else {
+		api, err := getAPIForEdit(ctx, c, apiID)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = operator.ResourceName(api.Name)
+		}
+		resources, err = operator.OASResources(api.OAS, name, namespace)
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+	}
+
+	data, err := operator.Marshal(resources)
+	if err != nil {
+		return fmt.Errorf("failed to encode the resources: %w", err)
+	}
+	if data, err = encryptOutput(cmd, data, out); err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", out, err)}
+	}
+	kind := resources[len(resources)-1].Kind
+	color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote %s %s to %s\n", kind, name, out)
+	return nil
+}
+
+// runAPIReadK8sManifest writes the API of an Operator manifest as a definition file
+func runAPIReadK8sManifest(cmd *cobra.Command, path string) error {
+	out, _ := cmd.Flags().GetString("out")
+	name, _ := cmd.Flags().GetString("name")
+
+	data, err := filehandler.ReadFile(path)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read %s: %v", path, err)}
+	}
+	apis, err := operator.Parse(data)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", path, err)}
+	}
+
+	var api *operator.API
+	names := make([]string, len(apis))
+	for i := range apis {
+		names[i] = apis[i].Name
+		if (name == "" && len(apis) == 1) || apis[i].Name == name {
+			api = &apis[i]
+		}
+	}
+	if api == nil {
+		if name == "" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s has %d APIs (%s); pick one with --name", path, len(apis), strings.Join(names, ", "))}
+		}
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s has no API named '%s' (found %s)", path, name, strings.Join(names, ", "))}
+	}
+	doc := api.OAS
+	if doc == nil {
+		doc = api.Classic
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); out == "" && format.IsStructured() {
+		return writeStructured(format, doc)
+	}
+	if out == "" {
+		yamlData, err := filehandler.ConvertToYAML(doc)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(yamlData)
+		return err
+	}
+	if err := filehandler.SaveFile(out, doc); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", out, err)}
+	}
+	color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote the definition of %s %s to %s\n", api.Kind, api.Name, out)
+	return nil
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/operator"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIExportK8s(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "Users API", "1.0.0")
+	dashboard.classic["chat-1"] = map[string]interface{}{"api_id": "chat-1", "id": "record-1", "name": "Chat", "protocol": "tcp", "listen_port": 6380}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	dir := t.TempDir()
+
+	manifest := filepath.Join(dir, "tykapi.yaml")
+	_, err := runRootCommand(t, "api", "export-k8s", "users-1", "--namespace", "apis", "--out", manifest)
+	require.NoError(t, err)
+	data, err := os.ReadFile(manifest)
+	require.NoError(t, err)
+	apis, err := operator.Parse(data)
+	require.NoError(t, err)
+	require.Len(t, apis, 1)
+	assert.Equal(t, operator.KindTykOasApiDefinition, apis[0].Kind)
+	assert.Equal(t, "users-api", apis[0].Name)
+	assert.Contains(t, string(data), "namespace: apis")
+
+	// Reading the manifest back gives the deployed document
+	specFile := filepath.Join(dir, "users.yaml")
+	_, err = runRootCommand(t, "api", "export-k8s", "--from-file", manifest, "--out", specFile)
+	require.NoError(t, err)
+	spec, err := filehandler.LoadFile(specFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Users API", oas.GetAPIName(spec.Content))
+	assert.Equal(t, oas.GetListenPath(dashboard.apis["users-1"]), oas.GetListenPath(spec.Content))
+
+	// Classic APIs become an ApiDefinition
+	out, err := runRootCommand(t, "api", "export-k8s", "chat-1", "--name", "chat")
+	require.NoError(t, err)
+	apis, err = operator.Parse(out)
+	require.NoError(t, err)
+	require.Len(t, apis, 1)
+	assert.Equal(t, operator.KindApiDefinition, apis[0].Kind)
+	assert.Equal(t, "tcp", apis[0].Classic["protocol"])
+	assert.NotContains(t, apis[0].Classic, "id")
+
+	_, err = runRootCommand(t, "api", "export-k8s", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+
+	_, err = runRootCommand(t, "api", "export-k8s", "users-1", "--from-file", manifest)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
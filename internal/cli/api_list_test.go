@@ -3,13 +3,18 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
 	"github.com/tyktech/tyk-cli/pkg/types"
 )
 
@@ -82,3 +87,112 @@ func TestAPIList_HumanOutput_NoAPIs(t *testing.T) {
 	err = listCmd.Execute()
 	require.NoError(t, err)
 }
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "30s", formatAge("2024-05-10T11:59:30Z", now))
+	assert.Equal(t, "15m", formatAge("2024-05-10T11:45:00Z", now))
+	assert.Equal(t, "36h", formatAge("2024-05-09T00:00:00Z", now))
+	assert.Equal(t, "9d", formatAge("2024-05-01T12:00:00Z", now))
+	assert.Equal(t, "-", formatAge("", now))
+}
+
+func TestAPIList_AllAndPageSize(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	for i := 1; i <= 23; i++ {
+		seedRemoteAPI(t, dashboard, fmt.Sprintf("api-%02d", i), fmt.Sprintf("api%02d", i), "1.0.0")
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	var result struct {
+		Count int             `json:"count"`
+		APIs  []*types.OASAPI `json:"apis"`
+	}
+
+	out, err := runRootCommand(t, "api", "list", "--all", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-list", out), string(out))
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 23, result.Count)
+	assert.Equal(t, "api-23", result.APIs[22].ID)
+
+	out, err = runRootCommand(t, "api", "list", "--page-size", "15", "--page", "2", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-list", out), string(out))
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 8, result.Count)
+	assert.Equal(t, "api-16", result.APIs[0].ID)
+
+	// Filters look at every page, and --page counts pages of the matches
+	out, err = runRootCommand(t, "api", "list", "--filter", "name~^api2", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 4, result.Count)
+	out, err = runRootCommand(t, "api", "list", "--filter", "name~^api2", "--page-size", "3", "--page", "2", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-list", out), string(out))
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Equal(t, 1, result.Count)
+	assert.Equal(t, "api-23", result.APIs[0].ID)
+
+	// Tables stream every page under a single header
+	out, err = runRootCommand(t, "api", "list", "--all")
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(out), "Listen Path"))
+	assert.Contains(t, string(out), "api-01")
+	assert.Contains(t, string(out), "api-23")
+
+	_, err = runRootCommand(t, "api", "list", "--page-size", "0")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+func TestAPIList_SortAndFilter(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "api-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "api-2", "billing", "1.0.0")
+	seedRemoteAPI(t, dashboard, "api-3", "user-admin", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	names := func(out []byte) []string {
+		var result struct {
+			APIs []*types.OASAPI `json:"apis"`
+		}
+		require.NoError(t, json.Unmarshal(out, &result))
+		var names []string
+		for _, api := range result.APIs {
+			names = append(names, api.Name)
+		}
+		return names
+	}
+
+	out, err := runRootCommand(t, "api", "list", "--sort", "name", "-o", "json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"billing", "user-admin", "users"}, names(out))
+
+	out, err = runRootCommand(t, "api", "list", "--all", "--filter", "user", "--sort", "name", "--order", "desc", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-list", out), string(out))
+	assert.Equal(t, []string{"users", "user-admin"}, names(out))
+
+	out, err = runRootCommand(t, "api", "list", "--filter", "name~^user", "--filter", "listen_path!=/users/")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "user-admin")
+	assert.NotContains(t, string(out), "billing")
+
+	for _, args := range [][]string{{"--sort", "size"}, {"--order", "up"}, {"--filter", "owner=me"}} {
+		_, err = runRootCommand(t, append([]string{"api", "list"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, args)
+		assert.Equal(t, 2, exitErr.Code)
+	}
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// Actions accepted by 'tyk api middleware'
+const (
+	middlewareEnable  = "enable"
+	middlewareDisable = "disable"
+	middlewareShow    = "show"
+)
+
+// NewAPIMiddlewareCommand creates the 'tyk api middleware' command
+func NewAPIMiddlewareCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "middleware <api-id> enable|disable|show [middleware]",
+		Short: "Enable, disable or show an API's middleware",
+		Long: `Toggle middleware in a deployed API's x-tyk-api-gateway settings and upload the
+result, without editing the definition by hand.
+
+Middleware: ` + strings.Join(oas.MiddlewareNames, ", ") + `
+
+Enabling a middleware that is not configured yet adds sensible defaults; enabling one
+that was disabled keeps its previous settings. rate-limit needs --rate and --per the
+first time. validate-request is set per operation and applies to every operation unless
+--operation is given; rate-limit applies to the whole API unless --operation is given.
+
+'show' without a middleware lists them all.
+
+Examples:
+  tyk api middleware 7c2f4a1b show
+  tyk api middleware 7c2f4a1b enable cache
+  tyk api middleware 7c2f4a1b enable rate-limit --rate 100 --per 60
+  tyk api middleware 7c2f4a1b enable validate-request --operation createUser
+  tyk api middleware 7c2f4a1b disable cors --dry-run`,
+		Args: validateMiddlewareArgs,
+		RunE: runAPIMiddleware,
+	}
+
+	cmd.Flags().StringSlice("operation", nil, "Operation ID to configure instead of the whole API; repeat for several")
+	cmd.Flags().Int("rate", 0, "Requests allowed per period (rate-limit)")
+	cmd.Flags().String("per", "", "Rate limit period in seconds or as a duration such as 1m (rate-limit)")
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+
+	return cmd
+}
+
+func validateMiddlewareArgs(cmd *cobra.Command, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "usage: tyk api middleware <api-id> enable|disable|show [middleware]"}
+	}
+	action := args[1]
+	if action != middlewareEnable && action != middlewareDisable && action != middlewareShow {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("unknown action '%s' (expected enable, disable or show)", action)}
+	}
+	if len(args) == 2 && action != middlewareShow {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s needs a middleware: %s", action, strings.Join(oas.MiddlewareNames, ", "))}
+	}
+	if len(args) == 3 && !slices.Contains(oas.MiddlewareNames, args[2]) {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("unknown middleware '%s' (expected one of %s)", args[2], strings.Join(oas.MiddlewareNames, ", "))}
+	}
+	return nil
+}
+
+func runAPIMiddleware(cmd *cobra.Command, args []string) error {
+	apiID, action := args[0], args[1]
+	names := oas.MiddlewareNames
+	if len(args) == 3 {
+		names = args[2:]
+	}
+	operations, _ := cmd.Flags().GetStringSlice("operation")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var settings map[string]interface{}
+	if cmd.Flags().Changed("rate") || cmd.Flags().Changed("per") {
+		if action != middlewareEnable || names[0] != oas.MiddlewareRateLimit {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "--rate and --per only apply to 'enable rate-limit'"}
+		}
+		rate, _ := cmd.Flags().GetInt("rate")
+		per, _ := cmd.Flags().GetString("per")
+		var err error
+		if settings, err = oas.RateLimitSettings(rate, per); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid rate limit: %v", err)}
+		}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	format := GetOutputFormatFromContext(cmd.Context())
+	if action == middlewareShow {
+		status := []oas.MiddlewareSetting{}
+		for _, name := range names {
+			s, err := oas.MiddlewareStatus(api.OAS, name)
+			if err != nil {
+				return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+			}
+			status = append(status, s...)
+		}
+		if format.IsStructured() {
+			return writeStructured(format, map[string]interface{}{"api_id": apiID, "middleware": status})
+		}
+		printMiddlewareStatus(fmt.Sprintf("%s (%s)", api.Name, apiID), status)
+		return nil
+	}
+
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		return oas.SetMiddleware(doc, names[0], action == middlewareEnable, operations, settings)
+	})
+	if err != nil {
+		return err
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":     apiID,
+			"middleware": names[0],
+			"action":     action,
+			"dry_run":    dryRun,
+			"changed":    !diff.Empty(),
+			"diff":       diff,
+		})
+	}
+
+	past := map[string]string{middlewareEnable: "enabled", middlewareDisable: "disabled"}[action]
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), fmt.Sprintf("%s %s", names[0], past), diff, dryRun)
+	return nil
+}
+
+func printMiddlewareStatus(label string, status []oas.MiddlewareSetting) {
+	color.New(color.Bold).Printf("Middleware for %s\n\n", label)
+	for _, s := range status {
+		name := s.Middleware
+		if s.Operation != "" {
+			name = fmt.Sprintf("%s (%s)", s.Middleware, s.Operation)
+		}
+		if !s.Enabled {
+			color.New(color.FgHiBlack).Printf("  - %-40s disabled\n", name)
+			continue
+		}
+		var details []string
+		for _, key := range sortedSettingKeys(s.Settings) {
+			details = append(details, fmt.Sprintf("%s=%s", key, formatDiffValue(s.Settings[key])))
+		}
+		color.New(color.FgGreen).Printf("  ✓ %-40s enabled", name)
+		if len(details) > 0 {
+			fmt.Printf("  %s", strings.Join(details, " "))
+		}
+		fmt.Println()
+	}
+}
+
+func sortedSettingKeys(settings map[string]interface{}) []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIMiddleware(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	cacheEnabled := func() interface{} {
+		middleware, _ := dashboard.apis["remote-1"][oas.TykExtensionKey].(map[string]interface{})["middleware"].(map[string]interface{})
+		global, _ := middleware["global"].(map[string]interface{})
+		cache, _ := global["cache"].(map[string]interface{})
+		return cache["enabled"]
+	}
+
+	// A dry run shows the change without uploading it
+	out, err := runRootCommand(t, "api", "middleware", "remote-1", "enable", "cache", "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-middleware", out), string(out))
+	var result struct {
+		Changed bool `json:"changed"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.True(t, result.Changed)
+	assert.Nil(t, cacheEnabled())
+
+	_, err = runRootCommand(t, "api", "middleware", "remote-1", "enable", "cache")
+	require.NoError(t, err)
+	assert.Equal(t, true, cacheEnabled())
+
+	out, err = runRootCommand(t, "api", "middleware", "remote-1", "show", "cache", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-middleware-show", out), string(out))
+
+	_, err = runRootCommand(t, "api", "middleware", "remote-1", "disable", "cache")
+	require.NoError(t, err)
+	assert.Equal(t, false, cacheEnabled())
+
+	out, err = runRootCommand(t, "api", "middleware", "remote-1", "disable", "cache", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.False(t, result.Changed)
+}
+
+func TestAPIMiddleware_BadArgs(t *testing.T) {
+	_, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	for _, args := range [][]string{
+		{"remote-1", "toggle", "cache"},
+		{"remote-1", "enable"},
+		{"remote-1", "enable", "gzip"},
+		{"remote-1", "enable", "cache", "--rate", "10"},
+	} {
+		_, err := runRootCommand(t, append([]string{"api", "middleware"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, args)
+		assert.Equal(t, 2, exitErr.Code, args)
+	}
+}
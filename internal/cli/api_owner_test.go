@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPISetOwner(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	dashboard.access["remote-1"] = &types.APIAccess{UserIDs: []string{"u1"}}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "set-owner", "remote-1", "--group", "payments", "--dry-run")
+	require.NoError(t, err)
+	assert.Empty(t, dashboard.access["remote-1"].UserGroupIDs)
+
+	out, err := runRootCommand(t, "api", "set-owner", "remote-1", "--group", "payments", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-set-owner", out), string(out))
+	var result apiOwnerResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.True(t, result.Changed)
+	// Replacing the groups keeps the user owners
+	assert.Equal(t, &types.APIAccess{UserIDs: []string{"u1"}, UserGroupIDs: []string{"payments"}}, dashboard.access["remote-1"])
+
+	_, err = runRootCommand(t, "api", "set-owner", "remote-1", "--group", "platform", "--add")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"payments", "platform"}, dashboard.access["remote-1"].UserGroupIDs)
+
+	_, err = runRootCommand(t, "api", "set-owner", "remote-1", "--clear")
+	require.NoError(t, err)
+	assert.Empty(t, dashboard.access["remote-1"].UserIDs)
+	assert.Empty(t, dashboard.access["remote-1"].UserGroupIDs)
+
+	// apply assigns owner groups to the API it creates
+	file := filepath.Join(t.TempDir(), "api.json")
+	data, err := json.Marshal(dashboard.apis["remote-1"])
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, data, 0644))
+	_, err = runRootCommand(t, "api", "apply", "--file", file, "--owner-group", "payments")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"payments"}, dashboard.access["remote-1"].UserGroupIDs)
+
+	_, err = runRootCommand(t, "api", "set-owner", "missing", "--group", "payments")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/passthrough"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// passthroughResult is the structured output of 'tyk api create-tcp' and 'create-stream'
+type passthroughResult struct {
+	APIID       string `json:"api_id" yaml:"api_id"`
+	Name        string `json:"name" yaml:"name"`
+	Protocol    string `json:"protocol" yaml:"protocol"`
+	ListenPort  int    `json:"listen_port,omitempty" yaml:"listen_port,omitempty"`
+	ListenPath  string `json:"listen_path,omitempty" yaml:"listen_path,omitempty"`
+	UpstreamURL string `json:"upstream_url" yaml:"upstream_url"`
+	// GatewayRequirements are Gateway settings the API needs to be served
+	GatewayRequirements []string `json:"gateway_requirements" yaml:"gateway_requirements"`
+	Operation           string   `json:"operation" yaml:"operation"`
+}
+
+// passthroughProtocolNames are the protocols as human output names them
+var passthroughProtocolNames = map[string]string{
+	passthrough.ProtocolTCP:       "TCP",
+	passthrough.ProtocolTLS:       "TLS",
+	passthrough.ProtocolSSE:       "Server-sent events",
+	passthrough.ProtocolWebSocket: "WebSocket",
+}
+
+// NewAPICreateTCPCommand creates the 'tyk api create-tcp' command
+func NewAPICreateTCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-tcp",
+		Short: "Create a TCP or TLS proxy API",
+		Long: `Create an API proxying raw TCP connections from a Gateway port to an upstream, such as
+a database or message broker. TCP APIs are classic API definitions, not OAS ones, and
+are keyless: the Gateway cannot authenticate raw connections.
+
+--upstream is host:port, or tls://host:port for an upstream expecting TLS. With --tls
+the Gateway terminates TLS on the listen port with --certificate, the ID of a
+certificate in the Dashboard certificate store.
+
+The Gateway only opens ports it is configured to: see ports_whitelist in tyk.conf.
+
+Examples:
+  tyk api create-tcp --name Redis --listen-port 6380 --upstream redis.internal:6379
+  tyk api create-tcp --name Postgres --listen-port 5433 --upstream postgres.internal:5432 --tls --certificate <cert-id>
+  tyk api create-tcp --name Broker --listen-port 9093 --upstream tls://broker.internal:9093 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runAPICreateTCP,
+	}
+
+	cmd.Flags().StringP("name", "n", "", "API name (required)")
+	cmd.Flags().Int("listen-port", 0, "Gateway port clients connect to (required)")
+	cmd.Flags().String("upstream", "", "Upstream host:port, or tls://host:port (required)")
+	cmd.Flags().Bool("tls", false, "Terminate TLS on the listen port")
+	cmd.Flags().String("certificate", "", "Certificate ID the Gateway serves with --tls")
+	cmd.Flags().Bool("dry-run", false, "Print the API definition without creating it")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("listen-port")
+	cmd.MarkFlagRequired("upstream")
+
+	return cmd
+}
+
+// NewAPICreateStreamCommand creates the 'tyk api create-stream' command
+func NewAPICreateStreamCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-stream",
+		Short: "Create a server-sent events or WebSocket API",
+		Long: `Create an API streaming server-sent events (--protocol sse) or proxying WebSocket
+connections (--protocol websocket) to an upstream. Streaming APIs are classic API
+definitions, not OAS ones, with caching off so responses are not held back.
+
+The Gateway must be configured for streaming too: WebSocket APIs need
+http_server_options.enable_websockets, and server-sent events
+http_server_options.flush_interval so events are not buffered.
+
+Examples:
+  tyk api create-stream --name Prices --protocol sse --upstream-url https://prices.internal/events
+  tyk api create-stream --name Chat --protocol websocket --upstream-url wss://chat.internal --listen-path /chat/
+  tyk api create-stream --name Prices --protocol sse --upstream-url https://prices.internal/events --keyless --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runAPICreateStream,
+	}
+
+	cmd.Flags().StringP("name", "n", "", "API name (required)")
+	cmd.Flags().String("protocol", "", "sse or websocket (required)")
+	cmd.Flags().String("upstream-url", "", "Upstream URL: http(s)://, or ws(s):// for WebSocket (required)")
+	cmd.Flags().String("listen-path", "", "Listen path (default: derived from the name)")
+	cmd.Flags().Bool("keyless", false, "Open the API without authentication")
+	cmd.Flags().Bool("dry-run", false, "Print the API definition without creating it")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("protocol")
+	cmd.MarkFlagRequired("upstream-url")
+
+	return cmd
+}
+
+func runAPICreateTCP(cmd *cobra.Command, args []string) error {
+	opts := passthrough.TCPOptions{}
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.ListenPort, _ = cmd.Flags().GetInt("listen-port")
+	opts.Upstream, _ = cmd.Flags().GetString("upstream")
+	opts.TLS, _ = cmd.Flags().GetBool("tls")
+	opts.Certificate, _ = cmd.Flags().GetString("certificate")
+	if opts.Certificate != "" && !opts.TLS {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--certificate is only used with --tls"}
+	}
+
+	orgID, err := passthroughOrgID(cmd)
+	if err != nil {
+		return err
+	}
+	opts.OrgID = orgID
+	def, err := passthrough.NewTCPDefinition(opts)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	protocol, _ := def["protocol"].(string)
+	return createPassthroughAPI(cmd, def, protocol, opts.ListenPort)
+}
+
+func runAPICreateStream(cmd *cobra.Command, args []string) error {
+	opts := passthrough.StreamOptions{}
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.Protocol, _ = cmd.Flags().GetString("protocol")
+	opts.UpstreamURL, _ = cmd.Flags().GetString("upstream-url")
+	opts.ListenPath, _ = cmd.Flags().GetString("listen-path")
+	opts.Keyless, _ = cmd.Flags().GetBool("keyless")
+
+	orgID, err := passthroughOrgID(cmd)
+	if err != nil {
+		return err
+	}
+	opts.OrgID = orgID
+	def, err := passthrough.NewStreamDefinition(opts)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	return createPassthroughAPI(cmd, def, opts.Protocol, 0)
+}
+
+// passthroughOrgID returns the organisation of the active environment
+func passthroughOrgID(cmd *cobra.Command) (string, error) {
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return "", fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return "", err
+	}
+	return env.OrgID, nil
+}
+
+// createPassthroughAPI creates the API of def, or prints def with --dry-run
+func createPassthroughAPI(cmd *cobra.Command, def map[string]interface{}, protocol string, listenPort int) error {
+	requirements := passthrough.GatewayRequirements(protocol, listenPort)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if dryRun {
+		if err := printClassicDefinition(cmd, def); err != nil {
+			return err
+		}
+		printGatewayRequirements(cmd, requirements)
+		return nil
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	apiID, err := c.CreateClassicAPI(ctx, def)
+	if err != nil {
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API creation failed")
+		}
+		return wrapAPIError(err, "failed to create API")
+	}
+
+	proxy, _ := def["proxy"].(map[string]interface{})
+	result := &passthroughResult{
+		APIID:               apiID,
+		Protocol:            protocol,
+		ListenPort:          listenPort,
+		GatewayRequirements: requirements,
+		Operation:           "created",
+	}
+	result.Name, _ = def["name"].(string)
+	result.ListenPath, _ = proxy["listen_path"].(string)
+	result.UpstreamURL, _ = proxy["target_url"].(string)
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, result)
+	}
+	color.New(color.FgGreen).Printf("✓ %s API created: %s (%s)\n", passthroughProtocolNames[protocol], result.Name, apiID)
+	if listenPort != 0 {
+		fmt.Printf("  Listen port:  %d\n", listenPort)
+	} else {
+		fmt.Printf("  Listen path:  %s\n", result.ListenPath)
+	}
+	fmt.Printf("  Upstream:     %s\n", result.UpstreamURL)
+	printGatewayRequirements(cmd, requirements)
+	return nil
+}
+
+// printGatewayRequirements warns on stderr of the Gateway settings an API needs
+func printGatewayRequirements(cmd *cobra.Command, requirements []string) {
+	if GetOutputFormatFromContext(cmd.Context()).IsStructured() {
+		return
+	}
+	for _, requirement := range requirements {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Gateway setting: %s\n", requirement)
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPICreatePassthrough(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "create-tcp", "--name", "Redis", "--listen-port", "6380", "--upstream", "redis.internal:6379", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-create-passthrough", out), string(out))
+	var result passthroughResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "tcp", result.Protocol)
+	assert.Equal(t, "tcp://redis.internal:6379", result.UpstreamURL)
+	assert.Len(t, result.GatewayRequirements, 1)
+	require.Contains(t, dashboard.classic, result.APIID)
+	assert.Equal(t, "org", dashboard.classic[result.APIID]["org_id"])
+	assert.Equal(t, float64(6380), dashboard.classic[result.APIID]["listen_port"])
+
+	out, err = runRootCommand(t, "api", "create-stream", "--name", "Chat", "--protocol", "websocket", "--upstream-url", "ws://chat.internal", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-create-passthrough", out), string(out))
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "/chat/", result.ListenPath)
+	assert.Equal(t, "http://chat.internal", result.UpstreamURL)
+	assert.Len(t, dashboard.classic, 2)
+
+	// A dry run prints the definition without creating it
+	out, err = runRootCommand(t, "api", "create-stream", "--name", "Prices", "--protocol", "sse", "--upstream-url", "https://prices.internal/events", "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	var def map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &def))
+	assert.Equal(t, "Prices", def["name"])
+	assert.Len(t, dashboard.classic, 2)
+
+	var exitErr *ExitError
+	_, err = runRootCommand(t, "api", "create-tcp", "--name", "Redis", "--listen-port", "6380", "--upstream", "redis.internal:6379", "--certificate", "cert-1")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+
+	_, err = runRootCommand(t, "api", "create-stream", "--name", "Prices", "--protocol", "grpc", "--upstream-url", "https://prices.internal")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// postmanSchema identifies the collection format export-postman writes
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// NewAPIImportPostmanCommand creates the 'tyk api import-postman' command
+func NewAPIImportPostmanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-postman",
+		Short: "Import a Postman collection to create new API",
+		Long: `Convert a Postman collection (v2.1, or v2.0) into an OpenAPI specification and import
+it like 'tyk api import-oas'.
+
+Every request becomes an operation tagged with its folder. Path variables (:id) and
+unresolved {{variables}} in paths become path parameters, query strings and headers
+become parameters, and the example bodies of requests and saved responses become
+examples with a schema inferred from them. The hosts requests are sent to, with the
+collection's variables resolved, become the servers the upstream is chosen from.
+
+Keep the converted specification with --output-spec. With --dry-run nothing is
+imported and the specification is printed, unless it is written to --output-spec.
+
+Examples:
+  tyk api import-postman --file users.postman_collection.json
+  tyk api import-postman --file users.postman_collection.json --upstream-url https://users.internal --auth apikey
+  tyk api import-postman --file users.postman_collection.json --output-spec users.yaml --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runAPIImportPostman,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Path to Postman collection file (required)")
+	cmd.Flags().String("output-spec", "", "Also write the converted OpenAPI specification to this file")
+	cmd.Flags().Bool("dry-run", false, "Convert without importing")
+	addImportFlags(cmd)
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// NewAPIExportPostmanCommand creates the 'tyk api export-postman' command
+func NewAPIExportPostmanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-postman <api-id>",
+		Short: "Export a deployed API as a Postman collection for its consumers",
+		Long: `Generate a Postman collection (v2.1) with a request for every operation of a deployed
+API, grouped in folders by tag, so consumers can start calling it through the Gateway.
+
+Requests are sent to {{baseUrl}}: the environment's gateway_url (or --gateway-url or
+$TYK_GATEWAY_URL) and the listen path, with a Host header for a custom domain.
+Parameters and bodies are filled in from the spec's examples, or generated from their
+schemas; optional parameters are included but disabled. The collection authenticates
+the way the API expects, with the credential left in the {{token}} variable (or
+{{username}} and {{password}} for basic authentication) for consumers to fill in.
+
+--encrypt encrypts the collection with age to each recipient; 'tyk api import-postman'
+reads it back with the identities of $TYK_AGE_IDENTITY_FILE.
+
+Examples:
+  tyk api export-postman 7c2f4a1b --out users.postman_collection.json
+  tyk api export-postman 7c2f4a1b --gateway-url https://api.example.com > users.json
+  tyk api export-postman 7c2f4a1b --encrypt age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p --out users.json.age`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIExportPostman,
+	}
+
+	cmd.Flags().String("out", "", "File to write the collection to (default: standard output)")
+	cmd.Flags().String("gateway-url", "", "Gateway URL (default: $"+config.EnvGatewayURL+" or the environment's gateway_url)")
+	addEncryptFlag(cmd)
+
+	return cmd
+}
+
+func runAPIImportPostman(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	outputSpec, _ := cmd.Flags().GetString("output-spec")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	data, err := filehandler.ReadFile(filePath)
+	if err != nil {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("failed to read Postman collection: %v", err)}
+	}
+	oasData, err := oas.FromPostman(data)
+	if err != nil {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("%s: %v", filepath.Base(filePath), err)}
+	}
+
+	if outputSpec != "" {
+		if err := filehandler.SaveFile(outputSpec, oasData); err != nil {
+			return &ExitError{Code: 2, Message: fmt.Sprintf("failed to write the specification: %v", err)}
+		}
+		color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote the converted specification to %s\n", outputSpec)
+	}
+	if dryRun {
+		if outputSpec != "" {
+			return nil
+		}
+		if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+			return writeStructured(format, oasData)
+		}
+		out, err := filehandler.ConvertToYAML(oasData)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	if err := checkOwnerGroups(cmd, config); err != nil {
+		return err
+	}
+	return importOASDocument(cmd, config, oasData, nil)
+}
+
+func runAPIExportPostman(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	out, _ := cmd.Flags().GetString("out")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+	gatewayURL, err := resolveGatewayURL(cmd, env)
+	if err != nil {
+		return err
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	credential, err := oas.ClientCredential(api.OAS)
+	if err != nil {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ %v; the collection sends no credential\n", err)
+	}
+	baseURL := strings.TrimRight(gatewayURL, "/") + strings.TrimRight(oas.GetListenPath(api.OAS), "/")
+	collection, err := postmanCollection(api.OAS, baseURL, credential)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode collection: %w", err)
+	}
+	data = append(data, '\n')
+	if data, err = encryptOutput(cmd, data, out); err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", out, err)}
+	}
+	color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote a Postman collection of %s to %s\n", api.Name, out)
+	return nil
+}
+
+// postmanCollection builds a Postman collection calling doc's operations at baseURL,
+// authenticating with credential when it is set
+func postmanCollection(doc map[string]interface{}, baseURL string, credential *oas.Credential) (map[string]interface{}, error) {
+	info := map[string]interface{}{"name": oas.GetAPIName(doc), "schema": postmanSchema}
+	if specInfo, ok := doc["info"].(map[string]interface{}); ok {
+		if description, _ := specInfo["description"].(string); description != "" {
+			info["description"] = description
+		}
+	}
+	variables := []interface{}{map[string]interface{}{"key": "baseUrl", "value": baseURL}}
+	collection := map[string]interface{}{"info": info}
+
+	if credential != nil {
+		placeholder := func(key string) map[string]interface{} {
+			return map[string]interface{}{"key": key, "value": "", "description": fmt.Sprintf("Credential for the %s security scheme", credential.Scheme)}
+		}
+		kv := func(key, value string) map[string]interface{} {
+			return map[string]interface{}{"key": key, "value": value, "type": "string"}
+		}
+		if credential.Mode == oas.AuthBasic {
+			variables = append(variables, placeholder("username"), placeholder("password"))
+			collection["auth"] = map[string]interface{}{
+				"type":  "basic",
+				"basic": []interface{}{kv("username", "{{username}}"), kv("password", "{{password}}")},
+			}
+		} else {
+			variables = append(variables, placeholder("token"))
+			name, value, in := credential.Name, credential.Prefix+"{{token}}", credential.In
+			if in == oas.CredentialCookie {
+				name, value, in = "Cookie", credential.Name+"={{token}}", oas.CredentialHeader
+			}
+			collection["auth"] = map[string]interface{}{
+				"type":   "apikey",
+				"apikey": []interface{}{kv("key", name), kv("value", value), kv("in", in)},
+			}
+		}
+	}
+	collection["variable"] = variables
+
+	host := oas.CustomDomain(doc)
+	items := []interface{}{}
+	folders := map[string]map[string]interface{}{}
+	for _, op := range oas.Operations(doc) {
+		item, err := postmanItem(doc, op, host)
+		if err != nil {
+			return nil, err
+		}
+		var tag string
+		if tags, _ := op.Spec["tags"].([]interface{}); len(tags) > 0 {
+			tag, _ = tags[0].(string)
+		}
+		if tag == "" {
+			items = append(items, item)
+			continue
+		}
+		folder, ok := folders[tag]
+		if !ok {
+			folder = map[string]interface{}{"name": tag, "item": []interface{}{}}
+			folders[tag] = folder
+			items = append(items, folder)
+		}
+		folder["item"] = append(folder["item"].([]interface{}), item)
+	}
+	collection["item"] = items
+	return collection, nil
+}
+
+// postmanItem builds the collection request for one operation
+func postmanItem(doc map[string]interface{}, op oas.Operation, host string) (map[string]interface{}, error) {
+	target, err := tryOperation(doc, op.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := []interface{}{}
+	for _, segment := range strings.Split(strings.Trim(op.Path, "/"), "/") {
+		if segment != "" {
+			segments = append(segments, pathParameter.ReplaceAllString(segment, ":$1"))
+		}
+	}
+	raw := "{{baseUrl}}" + pathParameter.ReplaceAllString(op.Path, ":$1")
+	requestURL := map[string]interface{}{"host": []interface{}{"{{baseUrl}}"}, "path": segments}
+	headers := []interface{}{}
+	if host != "" {
+		headers = append(headers, map[string]interface{}{"key": "Host", "value": host})
+	}
+
+	var query, variables []interface{}
+	for _, param := range operationParameters(doc, target) {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+		value, _ := parameterExample(doc, param)
+		entry := map[string]interface{}{"key": name, "value": value}
+		if description, _ := param["description"].(string); description != "" {
+			entry["description"] = description
+		}
+		if !required && in != "path" {
+			entry["disabled"] = true
+		}
+		switch in {
+		case "path":
+			variables = append(variables, entry)
+		case "query":
+			query = append(query, entry)
+		case "header":
+			headers = append(headers, entry)
+		}
+	}
+	if query != nil {
+		requestURL["query"] = query
+		var enabled []string
+		for _, entry := range query {
+			if entry := entry.(map[string]interface{}); entry["disabled"] == nil {
+				enabled = append(enabled, fmt.Sprintf("%s=%s", entry["key"], entry["value"]))
+			}
+		}
+		if len(enabled) > 0 {
+			raw += "?" + strings.Join(enabled, "&")
+		}
+	}
+	if variables != nil {
+		requestURL["variable"] = variables
+	}
+	requestURL["raw"] = raw
+
+	request := map[string]interface{}{"method": op.Method, "url": requestURL}
+	if target.contentType != "" {
+		headers = append(headers, map[string]interface{}{"key": "Content-Type", "value": target.contentType})
+		if body, err := requestBodyExample(doc, target); err == nil {
+			postmanBody := map[string]interface{}{"mode": "raw", "raw": string(body)}
+			if strings.Contains(target.contentType, "json") {
+				var pretty interface{}
+				if json.Unmarshal(body, &pretty) == nil {
+					indented, _ := json.MarshalIndent(pretty, "", "  ")
+					postmanBody["raw"] = string(indented)
+				}
+				postmanBody["options"] = map[string]interface{}{"raw": map[string]interface{}{"language": "json"}}
+			}
+			request["body"] = postmanBody
+		}
+	}
+	request["header"] = headers
+	if description, _ := op.Spec["description"].(string); description != "" {
+		request["description"] = description
+	}
+
+	name, _ := op.Spec["summary"].(string)
+	if name == "" {
+		name = op.ID
+	}
+	return map[string]interface{}{"name": name, "request": request, "response": []interface{}{}}, nil
+}
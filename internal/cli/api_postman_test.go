@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const testCollection = `{
+  "info": {"name": "Orders", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "variable": [{"key": "baseUrl", "value": "https://orders.internal"}],
+  "auth": {"type": "apikey", "apikey": [{"key": "key", "value": "X-API-Key"}]},
+  "item": [
+    {"name": "List orders", "request": {"method": "GET", "url": "{{baseUrl}}/orders"}},
+    {"name": "Get order", "request": {"method": "GET", "url": {"host": ["{{baseUrl}}"], "path": ["orders", ":id"]}}}
+  ]
+}`
+
+func writeTestCollection(t *testing.T) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "orders.postman_collection.json")
+	require.NoError(t, os.WriteFile(file, []byte(testCollection), 0644))
+	return file
+}
+
+func TestRunAPIImportPostman(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "import-postman", "--file", writeTestCollection(t), "--auth", "apikey", "-o", "json")
+	require.NoError(t, err)
+	require.Len(t, dashboard.apis, 1)
+	for _, doc := range dashboard.apis {
+		assert.Equal(t, "Orders", oas.GetAPIName(doc))
+		assert.Equal(t, "https://orders.internal", doc["x-tyk-api-gateway"].(map[string]interface{})["upstream"].(map[string]interface{})["url"])
+		assert.Contains(t, doc["paths"], "/orders/{id}")
+		// The collection's API key scheme is the one enabled
+		assert.Equal(t, []string{oas.AuthAPIKey}, oas.AuthModes(doc))
+		assert.Contains(t, doc["x-tyk-api-gateway"].(map[string]interface{})["server"].(map[string]interface{})["authentication"].(map[string]interface{})["securitySchemes"], "apiKeyAuth")
+	}
+}
+
+func TestRunAPIImportPostman_DryRun(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	spec := filepath.Join(t.TempDir(), "orders.yaml")
+
+	_, err := runRootCommand(t, "api", "import-postman", "--file", writeTestCollection(t), "--output-spec", spec, "--dry-run")
+	require.NoError(t, err)
+	assert.Empty(t, dashboard.apis)
+
+	info, err := filehandler.LoadFile(spec)
+	require.NoError(t, err)
+	assert.Equal(t, "Orders", filehandler.GetOASTitle(info.Content))
+
+	_, err = runRootCommand(t, "api", "import-postman", "--file", spec, "--dry-run")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+func TestRunAPIExportPostman(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	_, err := runRootCommand(t, "api", "import-postman", "--file", writeTestCollection(t), "--listen-path", "/orders/", "--auth", "apikey", "-o", "json")
+	require.NoError(t, err)
+	require.Len(t, dashboard.apis, 1)
+	var apiID string
+	for id := range dashboard.apis {
+		apiID = id
+	}
+
+	out, err := runRootCommand(t, "api", "export-postman", apiID, "--gateway-url", "https://gw.example.com/")
+	require.NoError(t, err)
+	var collection map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &collection), string(out))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "baseUrl", "value": "https://gw.example.com/orders"},
+		map[string]interface{}{"key": "token", "value": "", "description": "Credential for the apiKeyAuth security scheme"},
+	}, collection["variable"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "key", "value": "X-API-Key", "type": "string"},
+		map[string]interface{}{"key": "value", "value": "{{token}}", "type": "string"},
+		map[string]interface{}{"key": "in", "value": "header", "type": "string"},
+	}, collection["auth"].(map[string]interface{})["apikey"])
+
+	// The collection converts back into the same operations
+	doc, err := oas.FromPostman(out)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://gw.example.com/orders"}}, doc["servers"])
+	assert.Contains(t, doc["paths"], "/orders")
+	assert.Contains(t, doc["paths"], "/orders/{id}")
+
+	file := filepath.Join(t.TempDir(), "orders.json")
+	_, err = runRootCommand(t, "api", "export-postman", apiID, "--gateway-url", "https://gw.example.com", "--out", file)
+	require.NoError(t, err)
+	assert.FileExists(t, file)
+}
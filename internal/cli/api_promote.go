@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// NewAPIPromoteCommand creates the 'tyk api promote' command
+func NewAPIPromoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote <api-id>",
+		Short: "Promote an API from one environment to another",
+		Long: `Copy a deployed API from one configured environment into another: the API is
+exported from --from, reshaped for --to and created or updated there under the same ID.
+
+The API is reshaped by the promote_from rules the target environment declares for the
+source one, and by the rules of a --mapping file, which take precedence. A mapping file
+is YAML or JSON in the layout of promote_from:
+
+  listen_path_prefixes:
+    - {from: /staging, to: /}
+  domains:
+    - {from: api.staging.example.com, to: api.example.com}
+  upstream_hosts:
+    - {from: users.staging.internal, to: users.internal}
+
+When the environments have a listen_path_prefix, the API also moves from the source
+prefix to the target one unless a rule already rewrote its listen path.
+
+Before anything is changed, the command shows the rewrites and a semantic diff against
+the API in the target environment and asks for confirmation; --yes skips the prompt
+and --dry-run stops after the preview. Structured output needs one of the two.
+
+Examples:
+  tyk api promote 7c2f4a1b --from staging --to production
+  tyk api promote 7c2f4a1b --from staging --to production --mapping promote.yaml
+  tyk api promote 7c2f4a1b --from staging --to production --dry-run
+  tyk api promote 7c2f4a1b --from staging --to production --yes -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIPromote,
+	}
+
+	cmd.Flags().String("from", "", "Environment to promote the API from (required)")
+	cmd.Flags().String("to", "", "Environment to promote the API to (required)")
+	cmd.Flags().String("mapping", "", "YAML or JSON file of listen path, domain and upstream host rewrites")
+	cmd.Flags().Bool("dry-run", false, "Show what would change without promoting")
+	cmd.Flags().Bool("skip-compat-check", false, "Do not check the API against the target environment's Tyk version")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// apiPromotion is the outcome of promoting an API
+type apiPromotion struct {
+	APIID string `json:"api_id"`
+	Name  string `json:"name"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	// Action is create, update or no-change, like a plan action
+	Action  string `json:"action"`
+	DryRun  bool   `json:"dry_run"`
+	Applied bool   `json:"applied"`
+	// TargetAPIID is the ID of the API in the target environment, once applied
+	TargetAPIID string       `json:"target_api_id,omitempty"`
+	Rewrites    []oas.Change `json:"rewrites"`
+	// Diff is what the promotion changes in an existing API
+	Diff *oas.SemanticDiff `json:"diff,omitempty"`
+}
+
+func runAPIPromote(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	mappingPath, _ := cmd.Flags().GetString("mapping")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	skipConfirmation := assumeYes(cmd)
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	if from == to {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--from and --to must name different environments"}
+	}
+	sourceEnv, ok := config.Environments[from]
+	if !ok {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("environment '%s' not found", from)}
+	}
+	targetEnv, ok := config.Environments[to]
+	if !ok {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("environment '%s' not found", to)}
+	}
+	format := GetOutputFormatFromContext(cmd.Context())
+	if format.IsStructured() && !dryRun && !skipConfirmation {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "structured output cannot prompt for confirmation: pass --yes or --dry-run"}
+	}
+
+	var mapping *types.PromotionRules
+	if mappingPath != "" {
+		var err error
+		if mapping, err = loadPromotionMapping(mappingPath); err != nil {
+			return err
+		}
+	}
+	rules := promotionRewriteRules(from, sourceEnv, targetEnv, mapping)
+
+	sourceConfig := *config
+	sourceConfig.DefaultEnvironment = from
+	source, err := commandClient(cmd.Context(), &sourceConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client for environment '%s': %w", from, err)
+	}
+	targetConfig := *config
+	targetConfig.DefaultEnvironment = to
+	target, err := commandClient(cmd.Context(), &targetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client for environment '%s': %w", to, err)
+	}
+
+	ctx, cancel := apiContext(config, time.Minute)
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, source, apiID)
+	if err != nil {
+		return err
+	}
+	doc := api.OAS
+	resource := fmt.Sprintf("API '%s' (%s)", api.Name, apiID)
+	rewrites, err := oas.Rewrite(doc, rules)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", resource, err)}
+	}
+	if err := mountEnvironmentPrefix(&targetConfig, resource, doc); err != nil {
+		return err
+	}
+	if err := checkDocumentCompatibility(cmd, &targetConfig, resource, doc); err != nil {
+		return err
+	}
+
+	existing, err := target.GetOASAPI(ctx, apiID, "")
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		return wrapAPIError(err, fmt.Sprintf("failed to get API from environment '%s'", to))
+	}
+
+	promotion := &apiPromotion{APIID: apiID, Name: api.Name, From: from, To: to, Action: planCreate, DryRun: dryRun, Rewrites: rewrites}
+	if promotion.Rewrites == nil {
+		promotion.Rewrites = []oas.Change{}
+	}
+	if existing != nil {
+		promotion.Diff = oas.Semantic(existing.OAS, doc)
+		promotion.Action = planUpdate
+		if promotion.Diff.Empty() {
+			promotion.Action = planNoChange
+		}
+	}
+
+	if !format.IsStructured() {
+		printPromotionPreview(os.Stdout, promotion, existing)
+	}
+	if promotion.Action == planNoChange || dryRun {
+		return outputPromotion(format, promotion)
+	}
+
+	if !skipConfirmation {
+		ok, err := confirm(cmd, fmt.Sprintf("Promote %s from %s to %s?", resource, from, to))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Promotion cancelled")
+			return nil
+		}
+	}
+
+	var stored *types.OASAPI
+	if existing != nil {
+		saveRevision(target, apiID, existing.Name, existing.OAS)
+		if stored, err = target.UpdateOASAPIIfMatch(ctx, apiID, doc, existing.ETag); err != nil {
+			return uploadError(cmd, doc, err, fmt.Sprintf("failed to update API in environment '%s'", to))
+		}
+		warnServerEcho(doc, stored)
+	} else if stored, err = target.CreateOASAPI(ctx, doc); err != nil {
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API creation failed")
+		}
+		return uploadError(cmd, doc, err, fmt.Sprintf("failed to create API in environment '%s'", to))
+	}
+	promotion.Applied = true
+	promotion.TargetAPIID = apiID
+	if stored != nil && stored.ID != "" {
+		promotion.TargetAPIID = stored.ID
+	}
+	return outputPromotion(format, promotion)
+}
+
+// loadPromotionMapping reads a --mapping file, which has the layout of a promote_from
+// entry of the config
+func loadPromotionMapping(path string) (*types.PromotionRules, error) {
+	data, err := filehandler.ReadFile(path)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read mapping file: %v", err)}
+	}
+	rules := &types.PromotionRules{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to parse mapping file %s: %v", path, err)}
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("mapping file %s: %v", path, err)}
+	}
+	return rules, nil
+}
+
+// promotionRewriteRules combines the rewrites of a promotion: the mapping file first,
+// then the target's promote_from rules for the source, then the move between the
+// environments' listen path prefixes. The first matching rule of each kind wins.
+func promotionRewriteRules(from string, source, target *types.Environment, mapping *types.PromotionRules) oas.RewriteRules {
+	var rules oas.RewriteRules
+	for _, r := range []*types.PromotionRules{mapping, target.PromoteFrom[from]} {
+		if r == nil {
+			continue
+		}
+		rules.ListenPathPrefixes = append(rules.ListenPathPrefixes, swaps(r.ListenPathPrefixes)...)
+		rules.Domains = append(rules.Domains, swaps(r.Domains)...)
+		rules.UpstreamHosts = append(rules.UpstreamHosts, swaps(r.UpstreamHosts)...)
+	}
+	if source.ListenPathPrefix != target.ListenPathPrefix {
+		rules.ListenPathPrefixes = append(rules.ListenPathPrefixes, oas.Swap{From: "/" + strings.Trim(source.ListenPathPrefix, "/"), To: "/" + strings.Trim(target.ListenPathPrefix, "/")})
+	}
+	return rules
+}
+
+func swaps(rewrites []types.Rewrite) []oas.Swap {
+	result := make([]oas.Swap, len(rewrites))
+	for i, rewrite := range rewrites {
+		result[i] = oas.Swap{From: rewrite.From, To: rewrite.To}
+	}
+	return result
+}
+
+// printPromotionPreview shows the rewrites of a promotion and what it changes in the
+// target environment
+func printPromotionPreview(w io.Writer, p *apiPromotion, existing *types.OASAPI) {
+	fmt.Fprintf(w, "Promoting API '%s' (%s) from %s to %s\n", p.Name, p.APIID, p.From, p.To)
+	if len(p.Rewrites) > 0 {
+		fmt.Fprintln(w, "Rewrites:")
+		for _, change := range p.Rewrites {
+			fmt.Fprintf(w, "  %s: %v → %v\n", strings.Join(change.Path, "."), change.Old, change.New)
+		}
+	}
+	if existing == nil {
+		color.New(color.FgGreen).Fprintf(w, "The API does not exist in %s yet and will be created\n", p.To)
+		return
+	}
+	writeSemanticDiff(w, fmt.Sprintf("%s (%s)", existing.Name, p.To), fmt.Sprintf("%s (%s)", p.Name, p.From), p.Diff)
+}
+
+func outputPromotion(format types.OutputFormat, p *apiPromotion) error {
+	if format.IsStructured() {
+		return writeStructured(format, p)
+	}
+	switch {
+	case p.Action == planNoChange:
+		color.New(color.FgGreen).Printf("✓ API '%s' is already up to date in %s\n", p.Name, p.To)
+	case p.DryRun:
+		fmt.Printf("Dry run: API '%s' would be %sd in %s\n", p.Name, p.Action, p.To)
+	case p.Action == planCreate:
+		color.New(color.FgGreen).Printf("✓ Promoted API '%s' to %s as new API %s\n", p.Name, p.To, p.TargetAPIID)
+	default:
+		color.New(color.FgGreen).Printf("✓ Promoted API '%s' to %s\n", p.Name, p.To)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func writePromoteConfig(t *testing.T, stagingURL, productionURL string) {
+	t.Helper()
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging": {Name: "staging", DashboardURL: stagingURL, AuthToken: "token", OrgID: "org", ListenPathPrefix: "/staging"},
+			"production": {Name: "production", DashboardURL: productionURL, AuthToken: "token", OrgID: "org", PromoteFrom: map[string]*types.PromotionRules{
+				"staging": {UpstreamHosts: []types.Rewrite{{From: "users.internal", To: "users.prod.internal"}}},
+			}},
+		},
+	})
+}
+
+func TestAPIPromote(t *testing.T) {
+	staging, stagingServer := newFakeDashboard(t)
+	production, productionServer := newFakeDashboard(t)
+	seedRemoteAPI(t, staging, "users-1", "users", "1.0.0")
+	oas.SetListenPath(staging.apis["users-1"], "/staging/users/")
+	writePromoteConfig(t, stagingServer.URL, productionServer.URL)
+
+	out, err := runRootCommand(t, "api", "promote", "users-1", "--from", "staging", "--to", "production", "--yes", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-promote", out), string(out))
+	var result apiPromotion
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, planCreate, result.Action)
+	assert.True(t, result.Applied)
+	assert.Len(t, result.Rewrites, 2)
+
+	require.Len(t, production.apis, 1)
+	promoted := production.apis[result.TargetAPIID]
+	require.NotNil(t, promoted)
+	assert.Equal(t, "/users/", oas.GetListenPath(promoted))
+	assert.Equal(t, "https://users.prod.internal", promoted[oas.TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})["url"])
+
+	// Promoting a changed API previews the diff; the dry run leaves production alone
+	production.apis["users-1"] = production.apis[result.TargetAPIID]
+	delete(production.apis, result.TargetAPIID)
+	staging.apis["users-1"]["info"].(map[string]interface{})["version"] = "1.1.0"
+	out, err = runRootCommand(t, "api", "promote", "users-1", "--from", "staging", "--to", "production", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "Promoting API 'users' (users-1) from staging to production")
+	assert.Contains(t, string(out), "would be updated in production")
+	assert.Equal(t, "1.0.0", production.apis["users-1"]["info"].(map[string]interface{})["version"])
+
+	out, err = runRootCommand(t, "api", "promote", "users-1", "--from", "staging", "--to", "production", "--yes", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, planUpdate, result.Action)
+	assert.Equal(t, "users-1", result.TargetAPIID)
+	assert.Equal(t, "1.1.0", production.apis["users-1"]["info"].(map[string]interface{})["version"])
+
+	out, err = runRootCommand(t, "api", "promote", "users-1", "--from", "staging", "--to", "production", "--yes", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, planNoChange, result.Action)
+	assert.False(t, result.Applied)
+}
+
+func TestAPIPromote_Mapping(t *testing.T) {
+	staging, stagingServer := newFakeDashboard(t)
+	production, productionServer := newFakeDashboard(t)
+	seedRemoteAPI(t, staging, "users-1", "users", "1.0.0")
+	oas.SetListenPath(staging.apis["users-1"], "/staging/users/")
+	writePromoteConfig(t, stagingServer.URL, productionServer.URL)
+
+	// Mapping rules come before the environment's promote_from rules
+	mapping := filepath.Join(t.TempDir(), "promote.yaml")
+	require.NoError(t, os.WriteFile(mapping, []byte("listen_path_prefixes:\n  - {from: /staging, to: /v1}\nupstream_hosts:\n  - {from: users.internal, to: users.mapped.internal}\n"), 0644))
+
+	out, err := runRootCommand(t, "api", "promote", "users-1", "--from", "staging", "--to", "production", "--mapping", mapping, "--yes", "-o", "json")
+	require.NoError(t, err)
+	var result apiPromotion
+	require.NoError(t, json.Unmarshal(out, &result))
+	promoted := production.apis[result.TargetAPIID]
+	require.NotNil(t, promoted)
+	assert.Equal(t, "/v1/users/", oas.GetListenPath(promoted))
+	assert.Equal(t, "https://users.mapped.internal", promoted[oas.TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})["url"])
+
+	require.NoError(t, os.WriteFile(mapping, []byte("upstream_host:\n  - {from: a, to: b}\n"), 0644))
+	_, err = runRootCommand(t, "api", "promote", "users-1", "--from", "staging", "--to", "production", "--mapping", mapping, "--yes")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
+
+func TestAPIPromote_BadArgs(t *testing.T) {
+	_, stagingServer := newFakeDashboard(t)
+	_, productionServer := newFakeDashboard(t)
+	writePromoteConfig(t, stagingServer.URL, productionServer.URL)
+
+	for _, args := range [][]string{
+		{"--from", "staging", "--to", "staging", "--yes"},
+		{"--from", "staging", "--to", "qa", "--yes"},
+		{"--from", "staging", "--to", "production", "-o", "json"},
+	} {
+		_, err := runRootCommand(t, append([]string{"api", "promote", "users-1"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, "%v", args)
+		assert.Equal(t, int(types.ExitBadArgs), exitErr.Code, "%v", args)
+	}
+
+	_, err := runRootCommand(t, "api", "promote", "missing", "--from", "staging", "--to", "production", "--yes")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
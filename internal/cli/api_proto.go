@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// NewAPIImportProtoCommand creates the 'tyk api import-proto' command
+func NewAPIImportProtoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-proto",
+		Short: "Import a gRPC service from a .proto file to create new API",
+		Long: `Describe a gRPC service of a proto3 file in an OpenAPI specification and import it
+like 'tyk api import-oas'.
+
+By default the API passes gRPC calls through to the service: every method is a POST
+operation on its gRPC route, the listen path is /<package>.<Service>/ and is kept on
+proxied requests, and --upstream-url must be h2c:// for a plaintext service or
+https:// for one using TLS. The Gateway must serve and proxy HTTP/2 for gRPC clients
+to reach it (http_server_options.enable_http2 and proxy_enable_http2).
+
+With --http-rules the API is described by the methods' google.api.http annotations
+instead, for a grpc-gateway serving them as REST: path variables become path
+parameters, the rule's body the JSON request body, and the other fields of GET and
+DELETE requests query parameters. The upstream is then the grpc-gateway.
+
+Messages become schemas in their proto3 JSON form. Types imported from other files,
+other than the google.protobuf ones, are described as plain objects.
+
+Examples:
+  tyk api import-proto --file greeter.proto --upstream-url h2c://greeter.internal:50051
+  tyk api import-proto --file shop.proto --service Orders --upstream-url https://orders.internal:443
+  tyk api import-proto --file shop.proto --service Orders --http-rules --upstream-url http://orders-gateway.internal:8080
+  tyk api import-proto --file greeter.proto --output-spec greeter.yaml --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runAPIImportProto,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Path to .proto file (required)")
+	cmd.Flags().String("service", "", "Service to import, when the file defines several")
+	cmd.Flags().Bool("http-rules", false, "Describe the google.api.http REST routes instead of passing gRPC through")
+	cmd.Flags().String("output-spec", "", "Also write the generated OpenAPI specification to this file")
+	cmd.Flags().Bool("dry-run", false, "Generate the specification without importing")
+	addImportFlags(cmd)
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runAPIImportProto(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	service, _ := cmd.Flags().GetString("service")
+	httpRules, _ := cmd.Flags().GetBool("http-rules")
+	outputSpec, _ := cmd.Flags().GetString("output-spec")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	upstreamURL, _ := cmd.Flags().GetString("upstream-url")
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("failed to read proto file: %v", err)}
+	}
+	oasData, described, err := oas.FromProto(data, oas.ProtoOptions{Service: service, HTTPRules: httpRules})
+	if err != nil {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("%s: %v", filepath.Base(filePath), err)}
+	}
+
+	if outputSpec != "" {
+		if err := filehandler.SaveFile(outputSpec, oasData); err != nil {
+			return &ExitError{Code: 2, Message: fmt.Sprintf("failed to write the specification: %v", err)}
+		}
+		color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote the generated specification to %s\n", outputSpec)
+	}
+	if dryRun {
+		if outputSpec != "" {
+			return nil
+		}
+		if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+			return writeStructured(format, oasData)
+		}
+		out, err := filehandler.ConvertToYAML(oasData)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+
+	if upstreamURL == "" {
+		return &ExitError{Code: 2, Message: "--upstream-url is required: a .proto file does not say where the service runs"}
+	}
+	if !httpRules {
+		if u, err := url.Parse(upstreamURL); err != nil || (u.Scheme != "h2c" && u.Scheme != "https") {
+			return &ExitError{Code: 2, Message: fmt.Sprintf("--upstream-url must be h2c:// for a plaintext gRPC service or https:// for one using TLS (got '%s')", upstreamURL)}
+		}
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	if err := checkOwnerGroups(cmd, config); err != nil {
+		return err
+	}
+	if !httpRules {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ gRPC passthrough needs the Gateway to serve and proxy HTTP/2: set http_server_options.enable_http2 and proxy_enable_http2.\n")
+		if len(described.Streaming) > 0 {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Streaming methods (%s) need http_server_options.flush_interval set, so the Gateway does not buffer them.\n", strings.Join(described.Streaming, ", "))
+		}
+	}
+	return importOASDocument(cmd, config, oasData, func(opts *oas.ExtensionOptions) {
+		if httpRules {
+			return
+		}
+		if opts.ListenPath == "" {
+			opts.ListenPath = described.GRPCListenPath()
+		}
+		opts.KeepListenPath = true
+	})
+}
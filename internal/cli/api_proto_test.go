@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const testProtoFile = `syntax = "proto3";
+package greeter.v1;
+
+message HelloRequest { string name = 1; }
+message HelloReply { string message = 1; }
+
+service Greeter {
+  rpc SayHello(HelloRequest) returns (HelloReply) {
+    option (google.api.http) = { get: "/v1/hello/{name}" };
+  }
+}
+`
+
+func TestRunAPIImportProto(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	file := filepath.Join(t.TempDir(), "greeter.proto")
+	require.NoError(t, os.WriteFile(file, []byte(testProtoFile), 0644))
+
+	// gRPC passthrough needs an HTTP/2 upstream
+	_, err := runRootCommand(t, "api", "import-proto", "--file", file, "--upstream-url", "http://greeter.internal:50051")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "h2c://")
+
+	_, err = runRootCommand(t, "api", "import-proto", "--file", file, "--upstream-url", "h2c://greeter.internal:50051", "-o", "json")
+	require.NoError(t, err)
+	require.Len(t, dashboard.apis, 1)
+	for id, doc := range dashboard.apis {
+		tyk := doc["x-tyk-api-gateway"].(map[string]interface{})
+		assert.Equal(t, "h2c://greeter.internal:50051", tyk["upstream"].(map[string]interface{})["url"])
+		assert.Equal(t, map[string]interface{}{"value": "/greeter.v1.Greeter/", "strip": false}, tyk["server"].(map[string]interface{})["listenPath"])
+		assert.Contains(t, doc["paths"], "/SayHello")
+		delete(dashboard.apis, id)
+	}
+
+	// With HTTP rules the API is the grpc-gateway's REST routes
+	_, err = runRootCommand(t, "api", "import-proto", "--file", file, "--http-rules", "--upstream-url", "http://greeter-gateway.internal:8080", "-o", "json")
+	require.NoError(t, err)
+	require.Len(t, dashboard.apis, 1)
+	for _, doc := range dashboard.apis {
+		tyk := doc["x-tyk-api-gateway"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"value": "/greeter/", "strip": true}, tyk["server"].(map[string]interface{})["listenPath"])
+		assert.Contains(t, doc["paths"], "/v1/hello/{name}")
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPISetRateLimitCommand creates the 'tyk api set-rate-limit' command
+func NewAPISetRateLimitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-rate-limit <api-id>",
+		Short: "Set the rate limit for an API or some of its endpoints",
+		Long: `Set the rate limit Tyk enforces for a deployed API and upload the result.
+
+Without --operation the limit applies to the whole API (x-tyk-api-gateway.upstream.rateLimit).
+With --operation it applies to each listed endpoint on its own
+(x-tyk-api-gateway.middleware.operations.<operationId>.rateLimit).
+
+Quotas are not part of an API definition: Tyk counts them per key, so they are set on
+keys and policies. Use 'tyk policy generate' to give every key on an API the same quota.
+
+Examples:
+  tyk api set-rate-limit 7c2f4a1b --rate 100 --per 60
+  tyk api set-rate-limit 7c2f4a1b --rate 10 --per 1s --operation createUser --operation deleteUser
+  tyk api set-rate-limit 7c2f4a1b --disable --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPISetRateLimit,
+	}
+
+	cmd.Flags().Int("rate", 0, "Requests allowed per period")
+	cmd.Flags().String("per", "", "Period in seconds or as a duration such as 1m")
+	cmd.Flags().StringSlice("operation", nil, "Operation ID to limit instead of the whole API; repeat for several")
+	cmd.Flags().Bool("disable", false, "Turn the rate limit off, keeping its settings")
+	cmd.Flags().Int64("quota", 0, "Not supported: quotas are set on keys and policies")
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+
+	return cmd
+}
+
+func runAPISetRateLimit(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	operations, _ := cmd.Flags().GetStringSlice("operation")
+	disable, _ := cmd.Flags().GetBool("disable")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if cmd.Flags().Changed("quota") {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "quotas are counted per key, so they are set on keys and policies rather than the API; use 'tyk policy generate' to apply one quota to every key on this API"}
+	}
+
+	var settings map[string]interface{}
+	switch {
+	case disable && (cmd.Flags().Changed("rate") || cmd.Flags().Changed("per")):
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--disable cannot be combined with --rate or --per"}
+	case !disable:
+		if !cmd.Flags().Changed("rate") || !cmd.Flags().Changed("per") {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "--rate and --per are required (or --disable)"}
+		}
+		rate, _ := cmd.Flags().GetInt("rate")
+		per, _ := cmd.Flags().GetString("per")
+		var err error
+		if settings, err = oas.RateLimitSettings(rate, per); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid rate limit: %v", err)}
+		}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		return oas.SetMiddleware(doc, oas.MiddlewareRateLimit, !disable, operations, settings)
+	})
+	if err != nil {
+		return err
+	}
+
+	scope := "api"
+	if len(operations) > 0 {
+		scope = "operations"
+	} else {
+		operations = []string{}
+	}
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		result := map[string]interface{}{
+			"api_id":     apiID,
+			"scope":      scope,
+			"operations": operations,
+			"enabled":    !disable,
+			"dry_run":    dryRun,
+			"changed":    !diff.Empty(),
+			"diff":       diff,
+		}
+		if settings != nil {
+			result["rate"] = settings["rate"]
+			result["per"] = settings["per"]
+		}
+		return writeStructured(format, result)
+	}
+
+	change := "rate limit disabled"
+	if !disable {
+		change = fmt.Sprintf("rate limit %v per %v", settings["rate"], settings["per"])
+	}
+	if scope == "operations" {
+		change += fmt.Sprintf(" on %d operation(s)", len(operations))
+	}
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), change, diff, dryRun)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPISetRateLimit(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	rateLimit := func() map[string]interface{} {
+		upstream, _ := dashboard.apis["remote-1"][oas.TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})
+		limit, _ := upstream["rateLimit"].(map[string]interface{})
+		return limit
+	}
+
+	out, err := runRootCommand(t, "api", "set-rate-limit", "remote-1", "--rate", "100", "--per", "1m", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-set-rate-limit", out), string(out))
+	assert.Equal(t, map[string]interface{}{"enabled": true, "rate": float64(100), "per": "60s"}, rateLimit())
+
+	_, err = runRootCommand(t, "api", "set-rate-limit", "remote-1", "--disable")
+	require.NoError(t, err)
+	assert.Equal(t, false, rateLimit()["enabled"])
+	assert.Equal(t, float64(100), rateLimit()["rate"])
+
+	for _, args := range [][]string{
+		{"--rate", "100"},
+		{"--rate", "0", "--per", "60"},
+		{"--rate", "100", "--per", "60", "--quota", "10000"},
+		{"--disable", "--rate", "100"},
+		{"--rate", "100", "--per", "60", "--operation", "missing"},
+	} {
+		_, err = runRootCommand(t, append([]string{"api", "set-rate-limit", "remote-1"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, args)
+		assert.Equal(t, 2, exitErr.Code, args)
+	}
+}
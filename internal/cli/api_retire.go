@@ -0,0 +1,395 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPIDeprecateCommand creates the 'tyk api deprecate' command
+func NewAPIDeprecateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deprecate <api-id>",
+		Short: "Announce an API's end of life to its consumers",
+		Long: `Deprecate a deployed API ahead of retiring it with 'tyk api retire'.
+
+The dates are recorded under info.x-tyk-deprecation and every operation is marked
+deprecated. The Gateway adds Deprecation and Sunset headers (RFC 9745 and RFC 8594) to
+every response, and a Link header to --link, so consumers can see the API is going
+away and when. The API is served as before until it is retired.
+
+--consumers-csv writes the portal developers and keys with access to the API to a CSV
+file, so they can be told directly.
+
+Deprecating again moves the dates; 'tyk api rollback' undoes a deprecation.
+
+Examples:
+  tyk api deprecate 7c2f4a1b --sunset 2025-12-31
+  tyk api deprecate 7c2f4a1b --sunset 2025-12-31 --link https://docs.example.com/users-v2
+  tyk api deprecate 7c2f4a1b --sunset 2025-12-31 --consumers-csv users-consumers.csv
+  tyk api deprecate 7c2f4a1b --sunset 2026-03-31 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIDeprecate,
+	}
+
+	cmd.Flags().String("sunset", "", "Last day the API is served, as YYYY-MM-DD (required)")
+	cmd.Flags().String("since", "", "Date the API is deprecated from, as YYYY-MM-DD (default: today)")
+	cmd.Flags().String("link", "", "Page about the deprecation, such as a migration guide")
+	cmd.Flags().String("consumers-csv", "", "Write the developers and keys to notify to this CSV file")
+	cmd.Flags().Bool("dry-run", false, "Show the change without uploading it")
+	cmd.MarkFlagRequired("sunset")
+
+	return cmd
+}
+
+// NewAPIRetireCommand creates the 'tyk api retire' command
+func NewAPIRetireCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retire <api-id>",
+		Short: "Disable a deprecated API after its sunset, and later delete it",
+		Long: `Retire an API deprecated with 'tyk api deprecate' once its sunset date has passed.
+
+Retiring disables the API, so the Gateway stops serving it, and records the date. Once
+it has been retired for the --grace period, retire it again with --delete to delete
+it; its last definition stays in 'tyk api history'. --consumers-csv lists who still
+has access, as for 'tyk api deprecate'.
+
+Examples:
+  tyk api retire 7c2f4a1b
+  tyk api retire 7c2f4a1b --delete
+  tyk api retire 7c2f4a1b --delete --grace 7d --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIRetire,
+	}
+
+	cmd.Flags().Bool("delete", false, "Delete the API once it has been retired for the grace period")
+	cmd.Flags().String("grace", "30d", "How long an API stays retired before --delete removes it, e.g. 72h or 30d")
+	cmd.Flags().String("consumers-csv", "", "Write the developers and keys to notify to this CSV file")
+	cmd.Flags().Bool("dry-run", false, "Show the change without making it")
+
+	return cmd
+}
+
+func runAPIDeprecate(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	sunset, _ := cmd.Flags().GetString("sunset")
+	since, _ := cmd.Flags().GetString("since")
+	link, _ := cmd.Flags().GetString("link")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if since == "" {
+		since = time.Now().UTC().Format(oas.DateLayout)
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+		return oas.Deprecate(doc, oas.Deprecation{Since: since, Sunset: sunset, Link: link})
+	})
+	if err != nil {
+		return err
+	}
+	notify, err := exportConsumers(ctx, c, cmd, apiID)
+	if err != nil {
+		return err
+	}
+
+	steps := []nextStep{{Command: "tyk api retire " + apiID, Description: "Disable it after " + sunset}}
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, notify.addTo(map[string]interface{}{
+			"api_id":      apiID,
+			"since":       since,
+			"sunset":      sunset,
+			"dry_run":     dryRun,
+			"changed":     !diff.Empty(),
+			"diff":        diff,
+			"suggestions": suggestions(steps),
+		}))
+	}
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), "deprecated until "+sunset, diff, dryRun)
+	notify.print()
+	if !dryRun {
+		printNextSteps(steps)
+	}
+	return nil
+}
+
+func runAPIRetire(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	deleteAPI, _ := cmd.Flags().GetBool("delete")
+	graceFlag, _ := cmd.Flags().GetString("grace")
+	skipConfirmation := assumeYes(cmd)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	grace, err := parseAge("--grace", graceFlag)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	deprecation := oas.GetDeprecation(api.OAS)
+	if deprecation == nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("API '%s' is not deprecated; run 'tyk api deprecate %s --sunset <date>' first", apiID, apiID)}
+	}
+	now := time.Now().UTC()
+	label := fmt.Sprintf("%s (%s)", api.Name, apiID)
+	format := GetOutputFormatFromContext(cmd.Context())
+
+	if !deleteAPI {
+		if now.Before(deprecation.SunsetTime()) {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("API '%s' is served until its sunset on %s; retire it after that", apiID, deprecation.Sunset)}
+		}
+		notify, err := exportConsumers(ctx, c, cmd, apiID)
+		if err != nil {
+			return err
+		}
+		today := now.Format(oas.DateLayout)
+		diff, err := editDeployedAPI(ctx, c, api, dryRun, func(doc map[string]interface{}) error {
+			return oas.Retire(doc, today)
+		})
+		if err != nil {
+			return err
+		}
+
+		retired := oas.GetDeprecation(api.OAS).Retired
+		deletable := retiredUntil(retired, grace).Format(oas.DateLayout)
+		steps := []nextStep{{Command: "tyk api retire " + apiID + " --delete", Description: "Delete it from " + deletable}}
+		if format.IsStructured() {
+			return writeStructured(format, notify.addTo(map[string]interface{}{
+				"api_id":      apiID,
+				"operation":   "retired",
+				"retired":     retired,
+				"dry_run":     dryRun,
+				"changed":     !diff.Empty(),
+				"diff":        diff,
+				"suggestions": suggestions(steps),
+			}))
+		}
+		printAPIEdit(label, "retired (inactive)", diff, dryRun)
+		notify.print()
+		if !dryRun {
+			printNextSteps(steps)
+		}
+		return nil
+	}
+
+	if deprecation.Retired == "" {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("API '%s' has not been retired; run 'tyk api retire %s' first", apiID, apiID)}
+	}
+	if until := retiredUntil(deprecation.Retired, grace); now.Before(until) {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("API '%s' was retired on %s and can be deleted from %s (--grace %s)", apiID, deprecation.Retired, until.Format(oas.DateLayout), graceFlag)}
+	}
+	notify, err := exportConsumers(ctx, c, cmd, apiID)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		if !skipConfirmation {
+			ok, err := confirm(cmd, fmt.Sprintf("Are you sure you want to delete retired API '%s' (%s)?", apiID, api.Name))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Delete operation cancelled")
+				return nil
+			}
+		}
+		saveRevision(c, apiID, api.Name, api.OAS)
+		if err := c.DeleteOASAPI(ctx, apiID); err != nil {
+			return wrapAPIError(err, "failed to delete API")
+		}
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, notify.addTo(map[string]interface{}{
+			"api_id":    apiID,
+			"operation": "deleted",
+			"retired":   deprecation.Retired,
+			"dry_run":   dryRun,
+		}))
+	}
+	notify.print()
+	if dryRun {
+		fmt.Printf("Would delete %s, retired on %s.\n\nDry run: nothing was deleted.\n", label, deprecation.Retired)
+		return nil
+	}
+	color.New(color.FgGreen).Printf("✓ Deleted %s, retired on %s\n", label, deprecation.Retired)
+	fmt.Printf("  Its last definition is kept in 'tyk api history %s'.\n", apiID)
+	return nil
+}
+
+// retiredUntil returns when an API retired on day has been retired for grace
+func retiredUntil(day string, grace time.Duration) time.Time {
+	retired, _ := time.Parse(oas.DateLayout, day)
+	return retired.Add(grace)
+}
+
+// apiConsumer is someone to tell about an API's end of life: a portal developer
+// subscribed through a policy that grants access to the API, or a key with access to it
+type apiConsumer struct {
+	Source      string
+	Email       string
+	DeveloperID string
+	KeyID       string
+	Alias       string
+	PolicyID    string
+	PolicyName  string
+}
+
+// consumerCSVHeader are the columns writeConsumersCSV writes
+var consumerCSVHeader = []string{"source", "email", "developer_id", "key_id", "alias", "policy_id", "policy_name"}
+
+// consumerExport is the CSV file --consumers-csv asked for, once written
+type consumerExport struct {
+	path  string
+	count int
+}
+
+// exportConsumers writes the API's consumers to --consumers-csv; it does nothing, and
+// returns nil, without the flag
+func exportConsumers(ctx context.Context, c *client.Client, cmd *cobra.Command, apiID string) (*consumerExport, error) {
+	path, _ := cmd.Flags().GetString("consumers-csv")
+	if path == "" {
+		return nil, nil
+	}
+	consumers, err := findAPIConsumers(ctx, c, apiID)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeConsumersCSV(path, consumers); err != nil {
+		return nil, err
+	}
+	return &consumerExport{path: path, count: len(consumers)}, nil
+}
+
+// addTo adds the export to a structured result
+func (e *consumerExport) addTo(result map[string]interface{}) map[string]interface{} {
+	if e != nil {
+		result["consumers_csv"] = e.path
+		result["consumers"] = e.count
+	}
+	return result
+}
+
+func (e *consumerExport) print() {
+	if e != nil {
+		color.New(color.FgGreen).Printf("✓ Wrote %d consumer%s to notify to %s\n", e.count, plural(e.count), e.path)
+	}
+}
+
+// findAPIConsumers lists the portal developers and keys with access to an API. Gateway
+// environments have no portal and no policies to look through, only keys.
+func findAPIConsumers(ctx context.Context, c *client.Client, apiID string) ([]apiConsumer, error) {
+	granting := map[string]*types.Policy{}
+	developers := map[string]*types.PortalDeveloper{}
+	var consumers []apiConsumer
+	seen := map[string]bool{}
+
+	if !c.IsGateway() {
+		policies, err := c.ListPolicies(ctx)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to list policies")
+		}
+		for _, policy := range policies {
+			if _, ok := policy.AccessRights[apiID]; ok {
+				granting[policy.ID] = policy
+			}
+		}
+		portalDevelopers, err := c.ListPortalDevelopers(ctx)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to list portal developers")
+		}
+		for _, developer := range portalDevelopers {
+			developers[developer.ID] = developer
+			for _, policyID := range slices.Sorted(maps.Keys(developer.Subscriptions)) {
+				policy, ok := granting[policyID]
+				if !ok {
+					continue
+				}
+				keyID := developer.Subscriptions[policyID]
+				seen[keyID] = true
+				consumers = append(consumers, apiConsumer{
+					Source: "portal", Email: developer.Email, DeveloperID: developer.ID,
+					KeyID: keyID, PolicyID: policy.ID, PolicyName: policy.Name,
+				})
+			}
+		}
+	}
+
+	keyIDs, err := c.ListAPIKeys(ctx, apiID)
+	if err != nil {
+		return nil, wrapAPIError(err, "failed to list the API's keys")
+	}
+	for _, keyID := range keyIDs {
+		if seen[keyID] {
+			continue
+		}
+		consumer := apiConsumer{Source: "key", KeyID: keyID}
+		// Keys that cannot be read are still listed, by ID
+		if session, err := c.GetKey(ctx, keyID); err == nil {
+			consumer.Alias, _ = session["alias"].(string)
+			meta, _ := session["meta_data"].(map[string]interface{})
+			consumer.DeveloperID, _ = meta["tyk_developer_id"].(string)
+			if developer, ok := developers[consumer.DeveloperID]; ok {
+				consumer.Email = developer.Email
+			} else {
+				consumer.Email, _ = meta["email"].(string)
+			}
+			applied, _ := session["apply_policies"].([]interface{})
+			for _, id := range applied {
+				if policy, ok := granting[fmt.Sprint(id)]; ok {
+					consumer.PolicyID, consumer.PolicyName = policy.ID, policy.Name
+					break
+				}
+			}
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, nil
+}
+
+// writeConsumersCSV writes the consumers of an API to path
+func writeConsumersCSV(path string, consumers []apiConsumer) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", path, err)}
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write(consumerCSVHeader)
+	for _, consumer := range consumers {
+		w.Write([]string{consumer.Source, consumer.Email, consumer.DeveloperID, consumer.KeyID, consumer.Alias, consumer.PolicyID, consumer.PolicyName})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
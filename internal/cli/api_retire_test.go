@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIDeprecateAndRetire(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "accounts", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	var exitErr *ExitError
+
+	// Only deprecated APIs are retired, and only after their sunset
+	_, err := runRootCommand(t, "api", "retire", "remote-1")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Contains(t, exitErr.Message, "not deprecated")
+	_, err = runRootCommand(t, "api", "deprecate", "remote-2", "--sunset", "2999-12-31")
+	require.NoError(t, err)
+	_, err = runRootCommand(t, "api", "retire", "remote-2")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Contains(t, exitErr.Message, "served until its sunset on 2999-12-31")
+
+	out, err := runRootCommand(t, "api", "deprecate", "remote-1", "--since", "2020-01-01", "--sunset", "2020-06-30", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-deprecate", out), string(out))
+	assert.Equal(t, "2020-06-30", oas.GetDeprecation(dashboard.apis["remote-1"]).Sunset)
+
+	out, err = runRootCommand(t, "api", "retire", "remote-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-retire", out), string(out))
+	state := dashboard.apis["remote-1"][oas.TykExtensionKey].(map[string]interface{})["info"].(map[string]interface{})["state"].(map[string]interface{})
+	assert.Equal(t, false, state["active"])
+
+	// Deleting waits out the grace period from the day it was retired
+	_, err = runRootCommand(t, "api", "retire", "remote-1", "--delete", "--yes")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Contains(t, exitErr.Message, "can be deleted from")
+
+	dashboard.apis["remote-1"]["info"].(map[string]interface{})[oas.DeprecationKey].(map[string]interface{})["retired"] = "2020-07-01"
+	out, err = runRootCommand(t, "api", "retire", "remote-1", "--delete", "--yes", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-retire", out), string(out))
+	assert.NotContains(t, dashboard.apis, "remote-1")
+}
+
+func TestAPIDeprecate_ConsumersCSV(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	dashboard.policies["pol-1"] = &types.Policy{ID: "pol-1", Name: "Users Gold", AccessRights: map[string]*types.PolicyAccessRights{"remote-1": {APIID: "remote-1"}}}
+	dashboard.policies["pol-2"] = &types.Policy{ID: "pol-2", Name: "Orders"}
+	dashboard.developers = []*types.PortalDeveloper{
+		{ID: "dev-1", Email: "ada@example.com", Subscriptions: map[string]string{"pol-1": "key-1", "pol-2": "key-9"}},
+		{ID: "dev-2", Email: "bob@example.com", Subscriptions: map[string]string{"pol-2": "key-8"}},
+	}
+	access := map[string]interface{}{"remote-1": map[string]interface{}{"api_id": "remote-1"}}
+	dashboard.keys["key-1"] = types.Session{"access_rights": access}
+	dashboard.keys["key-2"] = types.Session{"access_rights": access, "alias": "batch-job", "apply_policies": []interface{}{"pol-1"}, "meta_data": map[string]interface{}{"tyk_developer_id": "dev-2"}}
+	dashboard.keys["key-3"] = types.Session{"access_rights": map[string]interface{}{"remote-2": map[string]interface{}{}}}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	file := filepath.Join(t.TempDir(), "consumers.csv")
+
+	out, err := runRootCommand(t, "api", "deprecate", "remote-1", "--sunset", "2999-12-31", "--consumers-csv", file, "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-deprecate", out), string(out))
+	assert.Contains(t, string(out), `"consumers": 2`)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"source", "email", "developer_id", "key_id", "alias", "policy_id", "policy_name"},
+		{"portal", "ada@example.com", "dev-1", "key-1", "", "pol-1", "Users Gold"},
+		{"key", "bob@example.com", "dev-2", "key-2", "batch-job", "pol-1", "Users Gold"},
+	}, rows)
+}
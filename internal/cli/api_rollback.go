@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/history"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// saveRevision stores the deployed definition an update is about to replace, so the
+// update can be rolled back. A failure only warns: the update itself can still go ahead.
+func saveRevision(c *client.Client, apiID, name string, previous map[string]interface{}) {
+	if previous == nil {
+		return
+	}
+	if _, err := history.Save(c.Environment(), apiID, name, previous); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save revision of API '%s', it cannot be rolled back: %v\n", apiID, err)
+	}
+}
+
+// NewAPIHistoryCommand creates the 'tyk api history' command
+func NewAPIHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <api-id>",
+		Short: "List the saved revisions of an API",
+		Long: `List the revisions of an API saved before the CLI updated it, newest first. Each
+revision is the definition that was deployed until the update recorded at its time;
+restore one with 'tyk api rollback'.
+
+Revisions are stored per environment next to the CLI configuration
+(~/.config/tyk/history on Linux, or TYK_HISTORY_DIR). The last 20 are kept per API.
+
+Examples:
+  tyk api history 7c2f4a1b
+  tyk api history 7c2f4a1b -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIHistory,
+	}
+}
+
+// NewAPIRollbackCommand creates the 'tyk api rollback' command
+func NewAPIRollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <api-id>",
+		Short: "Restore a saved revision of an API",
+		Long: `Restore the definition an API had before the CLI last updated it, or with --to an
+earlier revision listed by 'tyk api history'.
+
+The rollback is itself an update, so the definition it replaces is saved as a new
+revision and the rollback can be undone the same way.
+
+Examples:
+  tyk api rollback 7c2f4a1b
+  tyk api rollback 7c2f4a1b --to 3 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIRollback,
+	}
+
+	cmd.Flags().Int("to", 0, "Revision number to restore (default: the latest)")
+	cmd.Flags().Bool("dry-run", false, "Show the changes without uploading them")
+
+	return cmd
+}
+
+func runAPIHistory(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+
+	revisions, err := history.List(env.Name, apiID)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	for i, j := 0, len(revisions)-1; i < j; i, j = i+1, j-1 {
+		revisions[i], revisions[j] = revisions[j], revisions[i]
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, revisions)
+	}
+	if len(revisions) == 0 {
+		fmt.Printf("No saved revisions of API '%s' in environment '%s'\n", apiID, env.Name)
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tREPLACED\tNAME\tHASH")
+	for _, r := range revisions {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.Number, r.Time.Local().Format("2006-01-02 15:04:05"), r.Name, r.Hash[:min(len(r.Hash), 19)])
+	}
+	w.Flush()
+	return nil
+}
+
+func runAPIRollback(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	to, _ := cmd.Flags().GetInt("to")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if to < 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --to %d", to)}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	revision, err := findRevision(c.Environment(), apiID, to)
+	if err != nil {
+		return err
+	}
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	diff := rollbackDiff(api.OAS, revision.Document)
+	if !dryRun && !diff.Empty() {
+		saveRevision(c, apiID, api.Name, api.OAS)
+		stored, err := c.UpdateOASAPIIfMatch(ctx, apiID, revision.Document, api.ETag)
+		if err != nil {
+			return wrapAPIError(err, "failed to update API")
+		}
+		warnServerEcho(revision.Document, stored)
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":   apiID,
+			"revision": revision.Number,
+			"saved_at": revision.Time.Format(time.RFC3339),
+			"dry_run":  dryRun,
+			"changed":  !diff.Empty(),
+			"diff":     diff,
+		})
+	}
+	printAPIEdit(fmt.Sprintf("%s (%s)", api.Name, apiID), fmt.Sprintf("revision %d", revision.Number), diff, dryRun)
+	return nil
+}
+
+// rollbackDiff is the semantic diff from the deployed definition to a revision. Unlike
+// other edits, settings the revision lacks count as changes: restoring removes them.
+func rollbackDiff(deployed, revision map[string]interface{}) *oas.SemanticDiff {
+	diff := oas.Semantic(deployed, revision)
+	for _, change := range oas.Diff(deployed, revision) {
+		if change.Type != oas.ChangeRemoved || len(change.Path) < 2 || change.Path[0] != oas.TykExtensionKey {
+			continue
+		}
+		if path := strings.Join(change.Path, "."); path == oas.TykExtensionKey+".upstream.url" || path == oas.TykExtensionKey+".server.listenPath.value" {
+			continue
+		}
+		diff.TykExtension = append(diff.TykExtension, change)
+	}
+	return diff
+}
+
+// findRevision loads revision number, or the latest when number is 0
+func findRevision(env, apiID string, number int) (*history.Revision, error) {
+	if number == 0 {
+		revisions, err := history.List(env, apiID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		if len(revisions) == 0 {
+			return nil, notFoundError(nil, fmt.Sprintf("no saved revisions of API '%s' in environment '%s'", apiID, env))
+		}
+		number = revisions[len(revisions)-1].Number
+	}
+	revision, err := history.Load(env, apiID, number)
+	if errors.Is(err, history.ErrNotFound) {
+		return nil, notFoundError(err, fmt.Sprintf("revision %d of API '%s' not found; see 'tyk api history %s'", number, apiID, apiID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return revision, nil
+}
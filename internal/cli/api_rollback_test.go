@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIRollback(t *testing.T) {
+	t.Setenv("TYK_HISTORY_DIR", t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	upstream := func() map[string]interface{} {
+		return dashboard.apis["remote-1"][oas.TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})
+	}
+
+	_, err := runRootCommand(t, "api", "rollback", "remote-1")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code, "nothing to roll back yet")
+
+	// Revision 1 is the seeded definition, revision 2 the rate-limited one
+	_, err = runRootCommand(t, "api", "set-rate-limit", "remote-1", "--rate", "100", "--per", "60")
+	require.NoError(t, err)
+	doc, err := cloneDocument(dashboard.apis["remote-1"])
+	require.NoError(t, err)
+	doc[oas.TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})["url"] = "https://broken.internal"
+	file := filepath.Join(t.TempDir(), "users.yaml")
+	require.NoError(t, filehandler.SaveFile(file, doc))
+	_, err = runRootCommand(t, "api", "apply", "--file", file)
+	require.NoError(t, err)
+	require.Equal(t, "https://broken.internal", upstream()["url"])
+
+	out, err := runRootCommand(t, "api", "history", "remote-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-history", out), string(out))
+	var revisions []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &revisions))
+	require.Len(t, revisions, 2)
+	assert.Equal(t, float64(2), revisions[0]["number"], "newest first")
+	assert.Equal(t, "users", revisions[0]["name"])
+
+	// Undo the apply
+	out, err = runRootCommand(t, "api", "rollback", "remote-1", "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-rollback", out), string(out))
+	assert.Equal(t, "https://broken.internal", upstream()["url"], "dry run uploads nothing")
+
+	_, err = runRootCommand(t, "api", "rollback", "remote-1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://users.internal", upstream()["url"])
+	assert.NotNil(t, upstream()["rateLimit"])
+
+	// Back to the original; the rollbacks themselves were saved as revisions 3 and 4
+	_, err = runRootCommand(t, "api", "rollback", "remote-1", "--to", "1")
+	require.NoError(t, err)
+	assert.Nil(t, upstream()["rateLimit"])
+
+	out, err = runRootCommand(t, "api", "history", "remote-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &revisions))
+	assert.Len(t, revisions, 4)
+
+	_, err = runRootCommand(t, "api", "rollback", "remote-1", "--to", "9")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
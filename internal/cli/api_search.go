@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPISearchCommand creates the 'tyk api search' command
+func NewAPISearchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Find APIs by name, listen path or tag",
+		Long: `Search APIs by a case-insensitive substring of their name, listen path or tags.
+
+The Dashboard search endpoint is used when available; otherwise every page of the
+API listing is fetched and filtered locally. With --cached the local API cache is
+searched instead, without calling the Dashboard; refresh it with 'tyk prefetch' or
+'tyk api list --refresh-cache'.
+
+Examples:
+  tyk api search payments
+  tyk api search /users/ -o json
+  tyk api search internal --columns id,name --no-headers
+  tyk api search pay --cached`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPISearch,
+	}
+
+	cmd.Flags().Bool("cached", false, "Search the local API cache instead of the Dashboard")
+	cmd.Flags().Bool("no-truncate", false, "Never shorten names to fit the terminal; rows that do not fit are stacked")
+	addListOutputFlags(cmd)
+
+	return cmd
+}
+
+func runAPISearch(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(args[0])
+	noTruncate, _ := cmd.Flags().GetBool("no-truncate")
+	if query == "" {
+		return &ExitError{Code: 2, Message: "search query must not be empty"}
+	}
+
+	listOutput, err := getListOutputOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 2*time.Minute)
+	defer cancel()
+
+	var apis []*types.OASAPI
+	if cached, _ := cmd.Flags().GetBool("cached"); cached {
+		cache, err := c.CachedAPIs()
+		if errors.Is(err, client.ErrNoAPICache) {
+			return notFoundError(err, fmt.Sprintf("no cached API list for environment '%s'; run 'tyk prefetch' or 'tyk api list --refresh-cache'", c.Environment()))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read API cache: %w", err)
+		}
+		apis = matchAPIs(cache.APIs, query)
+	} else if apis, err = searchAPIs(ctx, c, query); err != nil {
+		return wrapAPIError(err, "failed to search APIs")
+	}
+
+	outputFormat := GetOutputFormatFromContext(cmd.Context())
+	if outputFormat.IsStructured() {
+		if apis == nil {
+			apis = []*types.OASAPI{}
+		}
+		return writeStructured(outputFormat, map[string]interface{}{
+			"query": query,
+			"count": len(apis),
+			"apis":  apis,
+		})
+	}
+
+	if listOutput != nil {
+		return listOutput.render(os.Stdout, apis)
+	}
+
+	if len(apis) == 0 {
+		fmt.Fprintf(os.Stderr, "No APIs match '%s'.\n", query)
+		return nil
+	}
+
+	color.New(color.FgBlue, color.Bold).Fprintf(os.Stderr, "%d API(s) matching '%s':\n", len(apis), query)
+	printAPITable(apis, outputFormat == types.OutputWide, noTruncate)
+	return nil
+}
+
+// searchAPIs prefers server-side search and falls back to filtering every page locally.
+// Server results are re-checked locally so both paths apply the same matching rules.
+func searchAPIs(ctx context.Context, c *client.Client, query string) ([]*types.OASAPI, error) {
+	apis, err := c.SearchAPIs(ctx, query)
+	if err != nil {
+		if errors.Is(err, client.ErrUnauthorized) || errors.Is(err, client.ErrRateLimited) {
+			return nil, err
+		}
+		apis = nil
+	}
+
+	matched := matchAPIs(apis, query)
+	if len(matched) > 0 {
+		return matched, nil
+	}
+
+	all, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return matchAPIs(all, query), nil
+}
+
+// matchAPIs returns the APIs whose name, listen path or tags contain query, ignoring case
+func matchAPIs(apis []*types.OASAPI, query string) []*types.OASAPI {
+	needle := strings.ToLower(query)
+	var matched []*types.OASAPI
+	for _, api := range apis {
+		if apiMatchesQuery(api, needle) {
+			matched = append(matched, api)
+		}
+	}
+	return matched
+}
+
+func apiMatchesQuery(api *types.OASAPI, needle string) bool {
+	if strings.Contains(strings.ToLower(api.Name), needle) || strings.Contains(strings.ToLower(api.ListenPath), needle) {
+		return true
+	}
+	for _, tag := range api.Tags {
+		if strings.Contains(strings.ToLower(tag), needle) {
+			return true
+		}
+	}
+	return false
+}
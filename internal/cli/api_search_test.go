@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestMatchAPIs(t *testing.T) {
+	apis := []*types.OASAPI{
+		{ID: "1", Name: "Payments", ListenPath: "/pay/"},
+		{ID: "2", Name: "Users", ListenPath: "/users/"},
+		{ID: "3", Name: "Ledger", ListenPath: "/ledger/", Tags: []string{"payments-team"}},
+	}
+
+	matched := matchAPIs(apis, "PAYMENTS")
+	require.Len(t, matched, 2)
+	assert.Equal(t, "1", matched[0].ID)
+	assert.Equal(t, "3", matched[1].ID)
+
+	assert.Len(t, matchAPIs(apis, "/users"), 1)
+	assert.Empty(t, matchAPIs(apis, "orders"))
+}
+
+func runAPISearchAgainst(t *testing.T, handler http.HandlerFunc, query string) []*types.OASAPI {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	cmd := NewAPISearchCommand()
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	}
+	cmd.SetContext(withOutputFormat(withConfig(context.Background(), config), types.OutputJSON))
+	cmd.SetArgs([]string{query})
+
+	out, err := captureStdout(cmd.Execute)
+	require.NoError(t, err)
+
+	var result struct {
+		APIs []*types.OASAPI `json:"apis"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	return result.APIs
+}
+
+func dashboardListItem(id, name, listenPath string) map[string]interface{} {
+	return map[string]interface{}{
+		"api_definition": map[string]interface{}{
+			"api_id": id,
+			"name":   name,
+			"proxy":  map[string]interface{}{"listen_path": listenPath},
+		},
+	}
+}
+
+func TestAPISearch_UsesServerSearch(t *testing.T) {
+	apis := runAPISearchAgainst(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/apis/search", r.URL.Path)
+		assert.Equal(t, "users", r.URL.Query().Get("q"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"apis": []interface{}{dashboardListItem("u1", "Users", "/users/")},
+		})
+	}, "users")
+
+	require.Len(t, apis, 1)
+	assert.Equal(t, "u1", apis[0].ID)
+}
+
+func TestAPISearch_FallsBackToClientSide(t *testing.T) {
+	apis := runAPISearchAgainst(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/apis/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		items := []interface{}{}
+		switch r.URL.Query().Get("p") {
+		case "1":
+			items = append(items, dashboardListItem("a", "Accounts", "/accounts/"))
+		case "2":
+			items = append(items, dashboardListItem("u2", "Users v2", "/v2/users/"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"apis": items})
+	}, "users")
+
+	require.Len(t, apis, 1)
+	assert.Equal(t, "u2", apis[0].ID)
+}
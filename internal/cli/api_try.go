@@ -0,0 +1,478 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/mock"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// tryCredentialEnv supplies the credential for 'tyk api try' when --key is not given
+const tryCredentialEnv = "TYK_API_CREDENTIAL"
+
+// pathParameter matches the {name} templates of an OAS path
+var pathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// tryRequest is the request 'tyk api try' sends for one operation
+type tryRequest struct {
+	Operation string
+	Method    string
+	URL       string
+	// Host is the API's custom domain, sent as the Host header
+	Host    string
+	Headers []oas.Header
+	Body    []byte
+}
+
+// NewAPITryCommand creates the 'tyk api try' command
+func NewAPITryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "try <api-id> [operation-id]",
+		Short: "Send a test request to a deployed API through the Gateway",
+		Long: `Build a request for one operation of a deployed API and send it through the Gateway,
+printing the status, latency and response body. Handy as a smoke test after 'tyk apply'.
+
+The request is assembled from the API definition:
+  URL          the environment's gateway_url (or --gateway-url or $TYK_GATEWAY_URL), the
+               listen path and the operation path; a custom domain is sent as the Host header
+  parameters   required path, query and header parameters use the spec's examples,
+               defaults or a value generated from their schema; --param overrides them
+  body         --data, or an example built from the request body schema
+  credential   --key (or $TYK_API_CREDENTIAL), placed where the API's security scheme
+               expects it: header, query parameter or cookie, with a Bearer or Basic
+               prefix for HTTP schemes
+
+Without an operation ID the first GET operation is used. Use --curl to print the
+equivalent curl command instead of sending it. The command exits with 1 when the
+Gateway answers with a 4xx or 5xx status.
+
+Examples:
+  tyk api try 7c2f4a1b
+  tyk api try 7c2f4a1b getUser --param id=42 --key $KEY
+  tyk api try 7c2f4a1b createUser --data @user.json
+  tyk api try 7c2f4a1b listUsers --curl`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: runAPITry,
+	}
+
+	cmd.Flags().StringArray("param", nil, "Parameter as name=value; undeclared names are sent as query parameters")
+	cmd.Flags().StringArray("header", nil, "Extra request header as 'Name: value'")
+	cmd.Flags().String("data", "", "Request body, or @file to read it from a file")
+	cmd.Flags().String("key", "", "Credential to authenticate with (default $"+tryCredentialEnv+")")
+	cmd.Flags().String("gateway-url", "", "Gateway URL to send the request to (default: $"+config.EnvGatewayURL+" or the environment's gateway_url)")
+	cmd.Flags().Bool("curl", false, "Print the equivalent curl command instead of sending the request")
+
+	return cmd
+}
+
+func runAPITry(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	key, _ := cmd.Flags().GetString("key")
+	printCurl, _ := cmd.Flags().GetBool("curl")
+	if key == "" {
+		key = os.Getenv(tryCredentialEnv)
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+	gatewayURL, err := resolveGatewayURL(cmd, env)
+	if err != nil {
+		return err
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	operationID := ""
+	if len(args) > 1 {
+		operationID = args[1]
+	}
+	req, err := buildTryRequest(cmd, api.OAS, gatewayURL, operationID, key)
+	if err != nil {
+		return err
+	}
+
+	if printCurl {
+		fmt.Println(curlCommand(req))
+		return nil
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	for _, header := range req.Headers {
+		httpReq.Header.Add(header.Name, header.Value)
+	}
+	if req.Host != "" {
+		httpReq.Host = req.Host
+	}
+	httpClient, err := client.NewHTTPClient(env)
+	if err != nil {
+		return err
+	}
+	// Report redirects as the Gateway sent them
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	start := time.Now()
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("request failed: %v", err)}
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	latency := time.Since(start)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("failed to read response: %v", err)}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		headers := make(map[string]string, len(resp.Header))
+		for name, values := range resp.Header {
+			headers[name] = strings.Join(values, ", ")
+		}
+		var payload interface{} = string(body)
+		var decoded interface{}
+		if strings.Contains(resp.Header.Get("Content-Type"), "json") && json.Unmarshal(body, &decoded) == nil {
+			payload = decoded
+		}
+		if err := writeStructured(format, map[string]interface{}{
+			"api_id":     apiID,
+			"operation":  req.Operation,
+			"method":     req.Method,
+			"url":        req.URL,
+			"status":     resp.StatusCode,
+			"latency_ms": latency.Milliseconds(),
+			"headers":    headers,
+			"body":       payload,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printTryResponse(req, resp, body, latency)
+	}
+
+	if resp.StatusCode >= 400 {
+		// The response has been shown; only the exit status is left to report
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &ExitError{Code: int(types.ExitGeneral)}
+	}
+	return nil
+}
+
+// buildTryRequest assembles the request for an operation of a deployed API
+func buildTryRequest(cmd *cobra.Command, doc map[string]interface{}, gatewayURL, operationID, key string) (*tryRequest, error) {
+	params, _ := cmd.Flags().GetStringArray("param")
+	extraHeaders, _ := cmd.Flags().GetStringArray("header")
+	data, _ := cmd.Flags().GetString("data")
+
+	overrides := make(map[string]string)
+	for _, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || name == "" {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --param '%s' (expected name=value)", param)}
+		}
+		overrides[name] = value
+	}
+
+	op, err := tryOperation(doc, operationID)
+	if err != nil {
+		return nil, err
+	}
+	req := &tryRequest{Operation: op.ID, Method: op.Method, Host: oas.CustomDomain(doc)}
+	query := url.Values{}
+
+	// Declared parameters: overrides first, then examples for the required ones
+	path := op.Path
+	for _, param := range operationParameters(doc, op) {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+		value, ok := overrides[name]
+		delete(overrides, name)
+		if !ok && (required || in == "path") {
+			value, ok = parameterExample(doc, param)
+		}
+		if !ok {
+			if in == "path" {
+				return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("path parameter '%s' has no example; pass --param %s=<value>", name, name)}
+			}
+			continue
+		}
+		switch in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+		case "query":
+			query.Add(name, value)
+		case "header":
+			req.Headers = append(req.Headers, oas.Header{Name: name, Value: value})
+		case "cookie":
+			req.Headers = append(req.Headers, oas.Header{Name: "Cookie", Value: name + "=" + value})
+		}
+	}
+	if unresolved := pathParameter.FindStringSubmatch(path); unresolved != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("path parameter '%s' is not declared; pass --param %s=<value>", unresolved[1], unresolved[1])}
+	}
+	for name, value := range overrides {
+		query.Set(name, value)
+	}
+
+	if data != "" {
+		req.Body = []byte(data)
+		if strings.HasPrefix(data, "@") {
+			if req.Body, err = os.ReadFile(strings.TrimPrefix(data, "@")); err != nil {
+				return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read --data: %v", err)}
+			}
+		}
+		if op.contentType != "" {
+			req.Headers = append(req.Headers, oas.Header{Name: "Content-Type", Value: op.contentType})
+		}
+	} else if op.contentType != "" {
+		req.Body, err = requestBodyExample(doc, op)
+		if err != nil {
+			return nil, &ExitError{Code: int(types.ExitGeneral), Message: err.Error()}
+		}
+		req.Headers = append(req.Headers, oas.Header{Name: "Content-Type", Value: op.contentType})
+	}
+
+	credential, err := oas.ClientCredential(doc)
+	if err != nil {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ %v; sending the request without one\n", err)
+	}
+	if credential != nil {
+		if key == "" {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ The API requires a %s credential in %s '%s'; pass --key or set %s\n", credential.Mode, credential.In, credential.Name, tryCredentialEnv)
+		} else {
+			if credential.Mode == oas.AuthBasic && strings.Contains(key, ":") {
+				key = base64.StdEncoding.EncodeToString([]byte(key))
+			}
+			switch credential.In {
+			case oas.CredentialQuery:
+				query.Set(credential.Name, credential.Prefix+key)
+			case oas.CredentialCookie:
+				req.Headers = append(req.Headers, oas.Header{Name: "Cookie", Value: credential.Name + "=" + key})
+			default:
+				req.Headers = append(req.Headers, oas.Header{Name: credential.Name, Value: credential.Prefix + key})
+			}
+		}
+	}
+
+	for _, raw := range extraHeaders {
+		header, err := oas.ParseHeader(raw)
+		if err != nil {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --header: %v", err)}
+		}
+		req.Headers = append(req.Headers, header)
+	}
+
+	req.URL = strings.TrimRight(gatewayURL, "/") + strings.TrimRight(oas.GetListenPath(doc), "/") + path
+	if len(query) > 0 {
+		req.URL += "?" + query.Encode()
+	}
+	return req, nil
+}
+
+// tryTarget is the operation a request is built for, with its request body media type
+type tryTarget struct {
+	oas.Operation
+	contentType string
+	media       map[string]interface{}
+}
+
+// tryOperation finds the operation to call: the given ID, or else the first GET
+// operation, or else the first operation. Specs without operations call the listen path.
+func tryOperation(doc map[string]interface{}, operationID string) (*tryTarget, error) {
+	ops := oas.Operations(doc)
+	var found *oas.Operation
+	for i := range ops {
+		if operationID != "" && ops[i].ID == operationID {
+			found = &ops[i]
+			break
+		}
+		if operationID == "" && ops[i].Method == http.MethodGet {
+			found = &ops[i]
+			break
+		}
+	}
+	if found == nil && operationID == "" && len(ops) > 0 {
+		found = &ops[0]
+	}
+	if found == nil {
+		if operationID != "" {
+			ids := make([]string, len(ops))
+			for i, op := range ops {
+				ids[i] = op.ID
+			}
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("operation '%s' not found (available: %s)", operationID, strings.Join(ids, ", "))}
+		}
+		return &tryTarget{Operation: oas.Operation{Method: http.MethodGet, Path: "/"}}, nil
+	}
+
+	target := &tryTarget{Operation: *found}
+	body, _ := resolveRef(doc, found.Spec["requestBody"]).(map[string]interface{})
+	content, _ := body["content"].(map[string]interface{})
+	for _, mediaType := range sortedMapKeys(content) {
+		if target.contentType == "" || (strings.Contains(mediaType, "json") && !strings.Contains(target.contentType, "json")) {
+			target.contentType = mediaType
+			target.media, _ = content[mediaType].(map[string]interface{})
+		}
+	}
+	return target, nil
+}
+
+// operationParameters returns the parameters of an operation and its path item, with
+// operation-level declarations taking precedence
+func operationParameters(doc map[string]interface{}, op *tryTarget) []map[string]interface{} {
+	paths, _ := doc["paths"].(map[string]interface{})
+	item, _ := paths[op.Path].(map[string]interface{})
+	itemParams, _ := item["parameters"].([]interface{})
+	opParams, _ := op.Spec["parameters"].([]interface{})
+
+	var params []map[string]interface{}
+	seen := make(map[string]bool)
+	for _, list := range [][]interface{}{opParams, itemParams} {
+		for _, raw := range list {
+			param, _ := resolveRef(doc, raw).(map[string]interface{})
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			if name == "" || seen[in+":"+name] {
+				continue
+			}
+			seen[in+":"+name] = true
+			params = append(params, param)
+		}
+	}
+	return params
+}
+
+// parameterExample returns a parameter's example, or one generated from its schema
+func parameterExample(doc, param map[string]interface{}) (string, bool) {
+	if example, ok := param["example"]; ok {
+		return fmt.Sprint(example), true
+	}
+	if examples, ok := param["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		example, _ := resolveRef(doc, examples[sortedMapKeys(examples)[0]]).(map[string]interface{})
+		if value, ok := example["value"]; ok {
+			return fmt.Sprint(value), true
+		}
+	}
+	schema, _ := param["schema"].(map[string]interface{})
+	if value := mock.Example(doc, schema); value != nil {
+		return fmt.Sprint(value), true
+	}
+	return "", false
+}
+
+// requestBodyExample returns the body example for the operation's request media type
+func requestBodyExample(doc map[string]interface{}, op *tryTarget) ([]byte, error) {
+	var value interface{}
+	if examples, ok := op.media["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		example, _ := resolveRef(doc, examples[sortedMapKeys(examples)[0]]).(map[string]interface{})
+		value = example["value"]
+	} else if example, ok := op.media["example"]; ok {
+		value = example
+	} else {
+		schema, _ := op.media["schema"].(map[string]interface{})
+		value = mock.Example(doc, schema)
+	}
+	if text, ok := value.(string); ok && !strings.Contains(op.contentType, "json") {
+		return []byte(text), nil
+	}
+	return json.Marshal(value)
+}
+
+// resolveRef follows value's $ref within doc, if it has one
+func resolveRef(doc map[string]interface{}, value interface{}) interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		if ref, ok := object["$ref"].(string); ok {
+			return mock.Resolve(doc, ref)
+		}
+	}
+	return value
+}
+
+// sortedMapKeys returns the keys of m in order
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// curlCommand renders the request as a curl command line
+func curlCommand(req *tryRequest) string {
+	parts := []string{"curl -X " + req.Method + " " + shellQuote(req.URL)}
+	if req.Host != "" {
+		parts = append(parts, "-H "+shellQuote("Host: "+req.Host))
+	}
+	for _, header := range req.Headers {
+		parts = append(parts, "-H "+shellQuote(header.Name+": "+header.Value))
+	}
+	if len(req.Body) > 0 {
+		parts = append(parts, "--data-raw "+shellQuote(string(req.Body)))
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// shellQuote quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// printTryResponse prints the status line, latency and body of a try response
+func printTryResponse(req *tryRequest, resp *http.Response, body []byte, latency time.Duration) {
+	label := req.Method + " " + req.URL
+	if req.Operation != "" {
+		label += "  (" + req.Operation + ")"
+	}
+	color.New(color.Bold).Println(label)
+
+	status := color.New(color.FgGreen)
+	if resp.StatusCode >= 400 {
+		status = color.New(color.FgRed)
+	}
+	status.Printf("%s", resp.Status)
+	fmt.Printf(" in %dms\n", latency.Milliseconds())
+
+	if len(body) == 0 {
+		return
+	}
+	fmt.Println()
+	var pretty bytes.Buffer
+	if strings.Contains(resp.Header.Get("Content-Type"), "json") && json.Indent(&pretty, body, "", "  ") == nil {
+		body = pretty.Bytes()
+	}
+	fmt.Println(strings.TrimRight(string(body), "\n"))
+}
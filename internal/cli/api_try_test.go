@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPITry(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	doc := dashboard.apis["remote-1"]
+	doc["paths"] = map[string]interface{}{
+		"/users/{id}": map[string]interface{}{
+			"parameters": []interface{}{
+				map[string]interface{}{"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "integer", "example": 42}},
+			},
+			"get": map[string]interface{}{"operationId": "getUser"},
+			"put": map[string]interface{}{
+				"operationId": "updateUser",
+				"requestBody": map[string]interface{}{"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": map[string]interface{}{
+						"type": "object", "properties": map[string]interface{}{"name": map[string]interface{}{"type": "string", "example": "Ada"}},
+					}},
+				}},
+			},
+		},
+	}
+	_, err := oas.AddAuthentication(doc, oas.ScaffoldAPIKey)
+	require.NoError(t, err)
+
+	var got *http.Request
+	var gotBody map[string]interface{}
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		if r.URL.Query().Get("missing") != "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":42}`))
+	}))
+	t.Cleanup(gateway.Close)
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, GatewayURL: gateway.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "try", "remote-1", "--key", "secret", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-try", out), string(out))
+	assert.Equal(t, "/users/users/42", got.URL.Path)
+	assert.Equal(t, "secret", got.Header.Get("Authorization"))
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "getUser", result["operation"])
+	assert.Equal(t, map[string]interface{}{"id": float64(42)}, result["body"])
+
+	_, err = runRootCommand(t, "api", "try", "remote-1", "updateUser", "--param", "id=7")
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPut, got.Method)
+	assert.Equal(t, "/users/users/7", got.URL.Path)
+	assert.Equal(t, map[string]interface{}{"name": "Ada"}, gotBody)
+
+	out, err = runRootCommand(t, "api", "try", "remote-1", "getUser", "--key", "secret", "--curl")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "curl -X GET '"+gateway.URL+"/users/users/42'")
+	assert.Contains(t, string(out), "-H 'Authorization: secret'")
+
+	// Error statuses are shown and fail the command
+	_, err = runRootCommand(t, "api", "try", "remote-1", "--param", "missing=1")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+
+	_, err = runRootCommand(t, "api", "try", "remote-1", "deleteUser")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
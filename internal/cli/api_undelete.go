@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/trash"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// trashAPI keeps the definition of an API about to be deleted, so 'tyk api undelete'
+// can re-create it
+func trashAPI(c *client.Client, apiID, name string, doc map[string]interface{}) (*trash.Entry, error) {
+	entry, err := trash.Save(c.Environment(), apiID, name, doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save API '%s' to the trash, so it was not deleted: %w", apiID, err)
+	}
+	return entry, nil
+}
+
+// NewAPIUndeleteCommand creates the 'tyk api undelete' command
+func NewAPIUndeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undelete [api-id]",
+		Short: "Re-create an API deleted by the CLI",
+		Long: `Re-create an API that 'tyk api delete', 'tyk api gc' or an applied plan removed,
+from the copy of its definition kept in the trash, under its original ID.
+
+Deleted APIs are stored per environment next to the CLI configuration
+(~/.config/tyk/trash on Linux, or TYK_TRASH_DIR) and kept for 7 days, or for
+TYK_TRASH_RETENTION (a duration such as 72h). --list shows what can be restored.
+
+Examples:
+  tyk api undelete --list
+  tyk api undelete 7c2f4a1b`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runAPIUndelete,
+	}
+
+	cmd.Flags().Bool("list", false, "List the deleted APIs that can be restored")
+
+	return cmd
+}
+
+func runAPIUndelete(cmd *cobra.Command, args []string) error {
+	list, _ := cmd.Flags().GetBool("list")
+	if list != (len(args) == 0) {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "an API ID or --list is required, but not both"}
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	if list {
+		env, err := config.GetActiveEnvironment()
+		if err != nil {
+			return err
+		}
+		return runAPITrashList(cmd, env.Name)
+	}
+
+	apiID := args[0]
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	entry, err := trash.Load(c.Environment(), apiID)
+	if errors.Is(err, trash.ErrNotFound) {
+		return notFoundError(err, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the trash: %w", err)
+	}
+
+	if _, err := c.GetOASAPI(ctx, apiID, ""); err == nil {
+		return &ExitError{Code: int(types.ExitConflict), Message: fmt.Sprintf("API '%s' exists again in environment '%s'; compare it with 'tyk api diff' before replacing it", apiID, c.Environment())}
+	} else if !errors.Is(err, client.ErrNotFound) {
+		return wrapAPIError(err, "failed to verify API does not exist")
+	}
+
+	api, err := c.CreateOASAPI(ctx, entry.Document)
+	if err != nil {
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "API creation failed")
+		}
+		return wrapAPIError(err, "failed to create API")
+	}
+	if err := trash.Remove(c.Environment(), apiID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove API '%s' from the trash: %v\n", apiID, err)
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"api_id":     api.ID,
+			"name":       api.Name,
+			"deleted_at": entry.DeletedAt.Format(time.RFC3339),
+			"operation":  "restored",
+		})
+	}
+	color.New(color.FgGreen, color.Bold).Printf("✓ Restored API '%s'\n", api.ID)
+	fmt.Printf("  Name:       %s\n", api.Name)
+	fmt.Printf("  Deleted at: %s\n", entry.DeletedAt.Local().Format("2006-01-02 15:04:05"))
+	return nil
+}
+
+func runAPITrashList(cmd *cobra.Command, env string) error {
+	entries, err := trash.List(env)
+	if err != nil {
+		return fmt.Errorf("failed to read the trash: %w", err)
+	}
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, entries)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No deleted APIs in the trash of environment '%s'\n", env)
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "API ID\tNAME\tDELETED\tEXPIRES")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", e.APIID, e.Name, e.DeletedAt.Local().Format("2006-01-02 15:04:05"), e.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/internal/trash"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIUndelete(t *testing.T) {
+	t.Setenv(trash.EnvDir, t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "orders-1", "orders", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "delete", "users-1", "--yes", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-delete", out), string(out))
+	assert.Contains(t, string(out), "undelete_until")
+	_, err = runRootCommand(t, "api", "delete", "orders-1", "--yes", "--no-trash")
+	require.NoError(t, err)
+	assert.Equal(t, 0, dashboard.count())
+
+	out, err = runRootCommand(t, "api", "undelete", "--list", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-undelete-list", out), string(out))
+	var entries []trash.Entry
+	require.NoError(t, json.Unmarshal(out, &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "users-1", entries[0].APIID)
+
+	out, err = runRootCommand(t, "api", "undelete", "users-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-undelete", out), string(out))
+	require.Equal(t, 1, dashboard.count())
+
+	// The restored API left the trash; the one deleted with --no-trash never entered it
+	for _, apiID := range []string{"users-1", "orders-1"} {
+		_, err = runRootCommand(t, "api", "undelete", apiID)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, apiID)
+		assert.Equal(t, int(types.ExitNotFound), exitErr.Code, apiID)
+	}
+
+	_, err = runRootCommand(t, "api", "undelete")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
+
+func TestAPIUndelete_Conflict(t *testing.T) {
+	t.Setenv(trash.EnvDir, t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "delete", "users-1", "--yes")
+	require.NoError(t, err)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "2.0.0")
+
+	_, err = runRootCommand(t, "api", "undelete", "users-1")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitConflict), exitErr.Code)
+	assert.Equal(t, "2.0.0", dashboard.apis["users-1"]["info"].(map[string]interface{})["version"])
+}
+
+func TestAPIDelete_BulkFilterTrash(t *testing.T) {
+	t.Setenv(trash.EnvDir, t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "test-1", "test-users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "test-2", "test-orders", "1.0.0")
+	seedRemoteAPI(t, dashboard, "keep-1", "keep-users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "delete", "--filter", "name~^test-", "--yes", "--rate", "100")
+	require.NoError(t, err)
+	assert.Equal(t, 1, dashboard.count())
+	entries, err := trash.List("test")
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	_, err = runRootCommand(t, "api", "delete", "--filter", "name~^keep-", "--yes", "--rate", "-1")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
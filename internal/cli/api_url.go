@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAPIURLCommand creates the 'tyk api url' command
+func NewAPIURLCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "url <api-id>",
+		Short: "Print the Gateway URL an API is served on",
+		Long: `Print the URL clients reach an API on: the environment's gateway_url joined with the
+API's listen path. APIs on a custom domain are served for that Host, which is printed
+alongside.
+
+The Dashboard and the Gateway usually live at different addresses; set the Gateway's
+with 'tyk config set --gateway-url', or override it with --gateway-url or
+$TYK_GATEWAY_URL.
+
+--probe sends an unauthenticated GET to the listen path and reports whether the
+Gateway serves the API; it exits with 1 when the Gateway cannot be reached or answers
+404 because the API is not loaded. Any other status, including 401 and 403, means the
+API is live.
+
+Examples:
+  tyk api url 7c2f4a1b
+  tyk api url 7c2f4a1b --probe
+  curl "$(tyk api url 7c2f4a1b)/health"`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIURL,
+	}
+
+	cmd.Flags().String("gateway-url", "", "Gateway URL (default: $"+config.EnvGatewayURL+" or the environment's gateway_url)")
+	cmd.Flags().Bool("probe", false, "Check that the Gateway serves the API")
+
+	return cmd
+}
+
+// apiURLResult is the structured output of 'tyk api url'
+type apiURLResult struct {
+	APIID string          `json:"api_id" yaml:"api_id"`
+	URL   string          `json:"url" yaml:"url"`
+	Host  string          `json:"host,omitempty" yaml:"host,omitempty"`
+	Probe *apiProbeResult `json:"probe,omitempty" yaml:"probe,omitempty"`
+}
+
+type apiProbeResult struct {
+	OK        bool   `json:"ok" yaml:"ok"`
+	Status    int    `json:"status,omitempty" yaml:"status,omitempty"`
+	LatencyMS int64  `json:"latency_ms" yaml:"latency_ms"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func runAPIURL(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	probe, _ := cmd.Flags().GetBool("probe")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+	gatewayURL, err := resolveGatewayURL(cmd, env)
+	if err != nil {
+		return err
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	result := &apiURLResult{
+		APIID: apiID,
+		URL:   strings.TrimRight(gatewayURL, "/") + oas.GetListenPath(api.OAS),
+		Host:  oas.CustomDomain(api.OAS),
+	}
+	if probe {
+		httpClient, err := client.NewHTTPClient(env)
+		if err != nil {
+			return err
+		}
+		result.Probe = probeAPI(httpClient, result.URL, result.Host)
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if err := writeStructured(format, result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println(result.URL)
+		if result.Host != "" {
+			fmt.Fprintf(os.Stderr, "Host: %s\n", result.Host)
+		}
+		if result.Probe != nil {
+			printProbe(result.Probe)
+		}
+	}
+
+	if result.Probe != nil && !result.Probe.OK {
+		// The probe result has been shown; only the exit status is left to report
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &ExitError{Code: int(types.ExitGeneral)}
+	}
+	return nil
+}
+
+// resolveGatewayURL returns the data plane URL for commands that call the Gateway:
+// --gateway-url, then $TYK_GATEWAY_URL, then the environment's gateway_url
+func resolveGatewayURL(cmd *cobra.Command, env *types.Environment) (string, error) {
+	gatewayURL, _ := cmd.Flags().GetString("gateway-url")
+	if gatewayURL == "" {
+		gatewayURL = os.Getenv(config.EnvGatewayURL)
+	}
+	if gatewayURL == "" {
+		gatewayURL = env.GatewayURL
+	}
+	if gatewayURL == "" {
+		return "", &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("no Gateway URL for environment '%s'; set one with 'tyk config set --gateway-url' or pass --gateway-url", env.Name)}
+	}
+	return gatewayURL, nil
+}
+
+// probeAPI sends an unauthenticated GET to an API's listen path. The API is served
+// unless the Gateway is unreachable or answers 404.
+func probeAPI(httpClient *http.Client, url, host string) *apiProbeResult {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return &apiProbeResult{Error: err.Error()}
+	}
+	if host != "" {
+		req.Host = host
+	}
+	// A redirect is an answer from the Gateway, not something to follow
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	result := &apiProbeResult{LatencyMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp.Body.Close()
+	result.Status = resp.StatusCode
+	result.OK = resp.StatusCode != http.StatusNotFound
+	if !result.OK {
+		result.Error = "the Gateway does not serve this listen path; is the API loaded?"
+	}
+	return result
+}
+
+func printProbe(probe *apiProbeResult) {
+	switch {
+	case probe.OK:
+		color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Served by the Gateway (%d in %dms)\n", probe.Status, probe.LatencyMS)
+	case probe.Status != 0:
+		color.New(color.FgRed).Fprintf(os.Stderr, "✗ %d: %s\n", probe.Status, probe.Error)
+	default:
+		color.New(color.FgRed).Fprintf(os.Stderr, "✗ Gateway unreachable: %s\n", probe.Error)
+	}
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPIURL(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/users/") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer gateway.Close()
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, GatewayURL: gateway.URL + "/", AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "url", "remote-1")
+	require.NoError(t, err)
+	assert.Equal(t, gateway.URL+"/users/\n", string(out))
+
+	// An auth challenge still means the Gateway serves the API
+	out, err = runRootCommand(t, "api", "url", "remote-1", "--probe", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-url", out), string(out))
+	var result apiURLResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.NotNil(t, result.Probe)
+	assert.True(t, result.Probe.OK)
+	assert.Equal(t, http.StatusUnauthorized, result.Probe.Status)
+
+	// A Gateway that has not loaded the API answers 404
+	oas.SetListenPath(dashboard.apis["remote-1"], "/gone/")
+	_, err = runRootCommand(t, "api", "url", "remote-1", "--probe")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+
+	// --gateway-url wins over the environment's gateway_url
+	out, err = runRootCommand(t, "api", "url", "remote-1", "--gateway-url", "https://edge.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "https://edge.example.com/gone/\n", string(out))
+}
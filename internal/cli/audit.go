@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/audit"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewAuditCommand creates the 'tyk audit' command and its subcommands
+func NewAuditCommand() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the local log of changes made by the CLI",
+		Long: `Every create, update and delete the CLI sends to a Dashboard or Gateway is appended
+to a local audit log with its time, user, command, environment, resource ID and the
+SHA-256 hash of the change sent.
+
+The log is stored in audit.jsonl next to the CLI configuration (~/.config/tyk/audit.jsonl
+on Linux); set TYK_AUDIT_LOG to use another file. Key IDs are masked.`,
+	}
+
+	auditCmd.AddCommand(NewAuditListCommand())
+	auditCmd.AddCommand(NewAuditShowCommand())
+
+	return auditCmd
+}
+
+// NewAuditListCommand creates the 'tyk audit list' command
+func NewAuditListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded changes, newest first",
+		Long: `List the changes recorded in the audit log, newest first.
+
+Examples:
+  tyk audit list
+  tyk audit list --since 7d --env production
+  tyk audit list --api 7c2f4a1b -o json`,
+		Args: cobra.NoArgs,
+		RunE: runAuditList,
+	}
+
+	cmd.Flags().String("since", "", "Only changes made within this window, e.g. 1h, 24h or 7d")
+	cmd.Flags().String("api", "", "Only changes to this API ID")
+	cmd.Flags().Int("limit", 50, "Maximum number of entries to show (0 for all)")
+
+	return cmd
+}
+
+// NewAuditShowCommand creates the 'tyk audit show' command
+func NewAuditShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <entry-id>",
+		Short: "Show one recorded change",
+		Long: `Show every field of one audit log entry. The ID may be shortened to any unique prefix.
+
+Examples:
+  tyk audit show 3fa2c1
+  tyk audit show 3fa2c19d04be -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAuditShow,
+	}
+}
+
+func runAuditList(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetString("since")
+	apiID, _ := cmd.Flags().GetString("api")
+	env, _ := cmd.Flags().GetString("env")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if limit < 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --limit %d", limit)}
+	}
+	var cutoff time.Time
+	if since != "" {
+		window, err := parseAge("--since", since)
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		cutoff = time.Now().Add(-window)
+	}
+
+	entries, err := audit.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	matched := []audit.Entry{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !cutoff.IsZero() && entry.Time.Before(cutoff) {
+			continue
+		}
+		if env != "" && entry.Environment != env {
+			continue
+		}
+		if apiID != "" && (entry.Resource != "api" || entry.ResourceID != apiID) {
+			continue
+		}
+		matched = append(matched, entry)
+		if limit > 0 && len(matched) == limit {
+			break
+		}
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, matched)
+	}
+	printAuditList(matched)
+	return nil
+}
+
+func runAuditShow(cmd *cobra.Command, args []string) error {
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	entry, err := audit.Find(args[0])
+	if err != nil {
+		if errors.Is(err, audit.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("audit entry '%s' not found", args[0]))
+		}
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, entry)
+	}
+	printAuditEntry(entry)
+	return nil
+}
+
+// printAuditList prints entries as a table
+func printAuditList(entries []audit.Entry) {
+	if len(entries) == 0 {
+		fmt.Println("No changes recorded")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tENVIRONMENT\tACTION\tRESOURCE\tRESOURCE ID\tSTATUS\tCOMMAND")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.ID, e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.Environment, e.Action, e.Resource, valueOrDash(e.ResourceID), auditStatus(&e), e.Command)
+	}
+	w.Flush()
+}
+
+// printAuditEntry prints every field of one entry
+func printAuditEntry(e *audit.Entry) {
+	cyan := color.New(color.FgCyan)
+	color.New(color.Bold).Printf("Audit entry %s\n", e.ID)
+	cyan.Printf("  Time:         %s\n", e.Time.Local().Format(time.RFC3339))
+	cyan.Printf("  User:         %s\n", e.User)
+	cyan.Printf("  Command:      %s\n", e.Command)
+	cyan.Printf("  Environment:  %s\n", e.Environment)
+	cyan.Printf("  Action:       %s %s %s\n", e.Action, e.Resource, valueOrDash(e.ResourceID))
+	cyan.Printf("  Request:      %s %s\n", e.Method, e.Path)
+	cyan.Print("  Result:       ")
+	if e.Succeeded() {
+		color.New(color.FgGreen).Printf("✓ %s\n", auditStatus(e))
+	} else {
+		color.New(color.FgRed).Printf("✗ %s\n", auditStatus(e))
+	}
+	cyan.Printf("  Diff hash:    %s\n", valueOrDash(e.DiffHash))
+}
+
+// auditStatus describes the outcome of a recorded request
+func auditStatus(e *audit.Entry) string {
+	if e.Error != "" {
+		return "error: " + e.Error
+	}
+	return fmt.Sprintf("%d", e.Status)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/audit"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAudit_RecordsCLIChanges(t *testing.T) {
+	t.Setenv(audit.EnvLogPath, filepath.Join(t.TempDir(), "audit.jsonl"))
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "delete", "remote-1", "--yes")
+	require.NoError(t, err)
+
+	out, err := runRootCommand(t, "audit", "list", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("audit-list", out), string(out))
+
+	var entries []audit.Entry
+	require.NoError(t, json.Unmarshal(out, &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "tyk api delete", entries[0].Command)
+	assert.Equal(t, "test", entries[0].Environment)
+	assert.Equal(t, audit.ActionDelete, entries[0].Action)
+	assert.Equal(t, "remote-1", entries[0].ResourceID)
+
+	out, err = runRootCommand(t, "audit", "show", entries[0].ID[:6], "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("audit-show", out), string(out))
+}
+
+func TestAuditList_Filters(t *testing.T) {
+	t.Setenv(audit.EnvLogPath, filepath.Join(t.TempDir(), "audit.jsonl"))
+	writeTestConfigFile(t, &types.Config{})
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, audit.Record(audit.Entry{ID: "e1", Time: old, Environment: "prod", Action: audit.ActionCreate, Resource: "api", ResourceID: "a1"}))
+	require.NoError(t, audit.Record(audit.Entry{ID: "e2", Environment: "prod", Action: audit.ActionUpdate, Resource: "api", ResourceID: "a1"}))
+	require.NoError(t, audit.Record(audit.Entry{ID: "e3", Environment: "dev", Action: audit.ActionUpdate, Resource: "api", ResourceID: "a2"}))
+	require.NoError(t, audit.Record(audit.Entry{ID: "e4", Environment: "prod", Action: audit.ActionCreate, Resource: "policy", ResourceID: "a1"}))
+
+	ids := func(args ...string) []string {
+		out, err := runRootCommand(t, append([]string{"audit", "list", "-o", "json"}, args...)...)
+		require.NoError(t, err)
+		var entries []audit.Entry
+		require.NoError(t, json.Unmarshal(out, &entries))
+		ids := []string{}
+		for _, e := range entries {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"e4", "e3", "e2", "e1"}, ids())
+	assert.Equal(t, []string{"e4", "e3", "e2"}, ids("--since", "24h"))
+	assert.Equal(t, []string{"e4", "e2", "e1"}, ids("--env", "prod"))
+	assert.Equal(t, []string{"e2", "e1"}, ids("--api", "a1"))
+	assert.Equal(t, []string{"e4"}, ids("--limit", "1"))
+
+	out, err := runRootCommand(t, "audit", "list")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "ENVIRONMENT")
+
+	_, err = runRootCommand(t, "audit", "show", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
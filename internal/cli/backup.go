@@ -0,0 +1,471 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/backup"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// Restore actions
+const (
+	restoreCreate = "create"
+	restoreUpdate = "update"
+	restoreSkip   = "skip"
+)
+
+// NewBackupCommand creates the 'tyk backup' command
+func NewBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up the APIs of the active environment to an archive",
+		Long: `Export every API of the active environment, and optionally its policies and keys,
+to a gzipped tar archive that 'tyk restore' loads into this or another environment.
+
+The archive holds a manifest.json listing its content, an apis/<id>.json definition
+per API (OAS APIs as OAS documents, GraphQL, TCP and other classic APIs as classic
+definitions), and policies.json and keys.json when --policies and --keys are given.
+Keys are their session objects, so an archive with keys is a secret: it is written
+readable only by you, and --encrypt encrypts it with age.
+
+Examples:
+  tyk backup --out backup-2024.tar.gz
+  tyk backup --out backup-2024.tar.gz --policies --keys
+  tyk backup --out backup-2024.tar.gz.age --policies --keys --encrypt age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p`,
+		Args: cobra.NoArgs,
+		RunE: runBackup,
+	}
+
+	cmd.Flags().String("out", "", "Archive file to write (required)")
+	cmd.Flags().Bool("policies", false, "Include security policies")
+	cmd.Flags().Bool("keys", false, "Include the keys of the backed up APIs")
+	addEncryptFlag(cmd)
+	cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+// NewRestoreCommand creates the 'tyk restore' command
+func NewRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a backup into the active environment",
+		Long: `Load the APIs, policies and keys of a 'tyk backup' archive into the active
+environment, under their original IDs. APIs are restored first, then the policies and
+keys that refer to them.
+
+Nothing is changed when resources of the backup already exist in the environment,
+unless --skip-existing leaves them alone or --overwrite replaces them; --dry-run
+shows what would be done. Archives encrypted with age are opened with the identity
+file named by $` + filehandler.EnvAgeIdentityFile + `.
+
+Examples:
+  tyk restore --file backup-2024.tar.gz --dry-run
+  tyk restore --file backup-2024.tar.gz --skip-existing
+  tyk restore --file backup-2024.tar.gz.age --overwrite`,
+		Args: cobra.NoArgs,
+		RunE: runRestore,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Backup archive to restore (required)")
+	cmd.Flags().Bool("skip-existing", false, "Leave resources that already exist unchanged")
+	cmd.Flags().Bool("overwrite", false, "Replace resources that already exist")
+	cmd.Flags().Bool("dry-run", false, "Show what would be restored without changing anything")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	withPolicies, _ := cmd.Flags().GetBool("policies")
+	withKeys, _ := cmd.Flags().GetBool("keys")
+
+	progress, err := newProgressReporter(cmd, "backup")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 10*time.Minute)
+	defer cancel()
+
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+	b := &backup.Backup{CreatedAt: time.Now(), Environment: env.Name, DashboardURL: env.DashboardURL}
+	if c.IsGateway() {
+		b.DashboardURL = env.GatewayURL
+	}
+
+	stopSpinner := startSpinner(cmd, "Listing APIs...")
+	apis, err := c.ListAllAPIs(ctx)
+	stopSpinner()
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	progress.Start(len(apis))
+	for _, api := range apis {
+		progress.StepStarted(api.ID)
+		backedUp, err := backupAPI(ctx, c, api)
+		if err != nil {
+			progress.StepFinished(api.ID, err.Error())
+			return err
+		}
+		progress.StepFinished(api.ID, "")
+		b.APIs = append(b.APIs, backedUp)
+	}
+	progress.Finish()
+
+	if withPolicies {
+		if b.Policies, err = c.ListPolicyDocuments(ctx); err != nil {
+			return wrapAPIError(err, "failed to list policies")
+		}
+		if b.Policies == nil {
+			b.Policies = []map[string]interface{}{}
+		}
+	}
+	if withKeys {
+		if b.Keys, err = backupKeys(ctx, c, b.APIs); err != nil {
+			return err
+		}
+	}
+
+	stopSpinner = startSpinner(cmd, "Writing the backup archive...")
+	var buf bytes.Buffer
+	if err := backup.Write(&buf, b); err != nil {
+		stopSpinner()
+		return fmt.Errorf("failed to write the backup archive: %w", err)
+	}
+	data, err := encryptOutput(cmd, buf.Bytes(), out)
+	stopSpinner()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, data, 0600); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", out, err)}
+	}
+
+	summary := map[string]interface{}{
+		"file":        out,
+		"environment": b.Environment,
+		"apis":        len(b.APIs),
+		"policies":    nil,
+		"keys":        nil,
+		"encrypted":   cmd.Flags().Changed("encrypt"),
+	}
+	if b.Policies != nil {
+		summary["policies"] = len(b.Policies)
+	}
+	if b.Keys != nil {
+		summary["keys"] = len(b.Keys)
+	}
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, summary)
+	}
+	parts := []string{fmt.Sprintf("%d API(s)", len(b.APIs))}
+	if b.Policies != nil {
+		parts = append(parts, fmt.Sprintf("%d policies", len(b.Policies)))
+	}
+	if b.Keys != nil {
+		parts = append(parts, fmt.Sprintf("%d key(s)", len(b.Keys)))
+	}
+	color.New(color.FgGreen).Printf("✓ Backed up %s from %s to %s\n", strings.Join(parts, ", "), b.Environment, out)
+	return nil
+}
+
+// backupAPI fetches the definition of an API: its OAS document, or the classic
+// definition of APIs that have none
+func backupAPI(ctx context.Context, c *client.Client, api *types.OASAPI) (*backup.API, error) {
+	def, err := c.GetClassicAPI(ctx, api.ID)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		return nil, wrapAPIError(err, fmt.Sprintf("failed to get API '%s'", api.ID))
+	}
+	if err == nil && def["is_oas"] != true {
+		return &backup.API{ID: api.ID, Name: api.Name, Classic: true, Definition: def}, nil
+	}
+	oasAPI, err := c.GetOASAPI(ctx, api.ID, "")
+	if err != nil {
+		return nil, wrapAPIError(err, fmt.Sprintf("failed to get API '%s'", api.ID))
+	}
+	return &backup.API{ID: api.ID, Name: oasAPI.Name, Definition: oasAPI.OAS}, nil
+}
+
+// backupKeys fetches the keys with access to the backed up APIs
+func backupKeys(ctx context.Context, c *client.Client, apis []*backup.API) (map[string]types.Session, error) {
+	keys := make(map[string]types.Session)
+	for _, api := range apis {
+		ids, err := c.ListAPIKeys(ctx, api.ID)
+		if err != nil {
+			return nil, wrapAPIError(err, fmt.Sprintf("failed to list the keys of API '%s'", api.ID))
+		}
+		for _, keyID := range ids {
+			if _, seen := keys[keyID]; seen {
+				continue
+			}
+			session, err := c.GetKey(ctx, keyID)
+			if err != nil {
+				return nil, wrapAPIError(err, fmt.Sprintf("failed to get key '%s'", keyID))
+			}
+			keys[keyID] = session
+		}
+	}
+	return keys, nil
+}
+
+// restoreResult records what restoring one resource of a backup does
+type restoreResult struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action"`
+	Error  string `json:"error,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	file, _ := cmd.Flags().GetString("file")
+	skipExisting, _ := cmd.Flags().GetBool("skip-existing")
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if skipExisting && overwrite {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--skip-existing and --overwrite cannot be combined"}
+	}
+
+	data, err := filehandler.ReadFile(file)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read backup: %v", err)}
+	}
+	b, err := backup.Read(bytes.NewReader(data))
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", file, err)}
+	}
+
+	progress, err := newProgressReporter(cmd, "restore")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 10*time.Minute)
+	defer cancel()
+
+	results, err := planRestore(ctx, c, b)
+	if err != nil {
+		return err
+	}
+	var existing []string
+	for _, result := range results {
+		if result.Action == restoreUpdate {
+			existing = append(existing, result.Kind+" "+result.ID)
+			if skipExisting {
+				result.Action = restoreSkip
+			}
+		}
+	}
+	if len(existing) > 0 && !skipExisting && !overwrite {
+		return &ExitError{Code: int(types.ExitConflict), Message: fmt.Sprintf("%d resource(s) of the backup already exist in environment '%s' (%s): pass --skip-existing or --overwrite", len(existing), c.Environment(), strings.Join(existing, ", "))}
+	}
+
+	failed := 0
+	if !dryRun {
+		progress.Start(len(results))
+		for _, result := range results {
+			step := result.Kind + " " + result.ID
+			progress.StepStarted(step)
+			if result.Action != restoreSkip {
+				if err := result.apply(ctx); err != nil {
+					result.Error = err.Error()
+					failed++
+				}
+			}
+			progress.StepFinished(step, result.Error)
+		}
+		progress.Finish()
+	}
+
+	if err := outputRestore(cmd, file, b, c.Environment(), results, dryRun); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("%d of %d resource(s) failed to restore", failed, len(results))}
+	}
+	return nil
+}
+
+// planRestore decides whether each resource of a backup is created or, since it
+// already exists, updated: APIs first, then the policies and keys that refer to them
+func planRestore(ctx context.Context, c *client.Client, b *backup.Backup) ([]*restoreResult, error) {
+	var results []*restoreResult
+	for _, api := range b.APIs {
+		result := &restoreResult{Kind: "api", ID: api.ID, Name: api.Name, Action: restoreCreate}
+		var err error
+		if api.Classic {
+			_, err = c.GetClassicAPI(ctx, api.ID)
+			result.apply = func(ctx context.Context) error {
+				if result.Action == restoreUpdate {
+					return apiErrorOrNil(c.UpdateClassicAPI(ctx, api.ID, api.Definition), "failed to update API")
+				}
+				_, err := c.CreateClassicAPI(ctx, api.Definition)
+				return apiErrorOrNil(err, "failed to create API")
+			}
+		} else {
+			var current *types.OASAPI
+			current, err = c.GetOASAPI(ctx, api.ID, "")
+			result.apply = func(ctx context.Context) error {
+				if result.Action == restoreUpdate {
+					saveRevision(c, api.ID, current.Name, current.OAS)
+					_, err := c.UpdateOASAPIIfMatch(ctx, api.ID, api.Definition, current.ETag)
+					return apiErrorOrNil(err, "failed to update API")
+				}
+				_, err := c.CreateOASAPI(ctx, api.Definition)
+				return apiErrorOrNil(err, "failed to create API")
+			}
+		}
+		if err == nil {
+			result.Action = restoreUpdate
+		} else if !errors.Is(err, client.ErrNotFound) {
+			return nil, wrapAPIError(err, fmt.Sprintf("failed to get API '%s'", api.ID))
+		}
+		results = append(results, result)
+	}
+
+	if b.Policies != nil {
+		current, err := c.ListPolicies(ctx)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to list policies")
+		}
+		exists := make(map[string]bool, len(current))
+		for _, policy := range current {
+			exists[policy.ID] = true
+		}
+		for _, policy := range b.Policies {
+			policyID, _ := policy["_id"].(string)
+			name, _ := policy["name"].(string)
+			result := &restoreResult{Kind: "policy", ID: policyID, Name: name, Action: restoreCreate}
+			if exists[policyID] {
+				result.Action = restoreUpdate
+			}
+			result.apply = func(ctx context.Context) error {
+				if result.Action == restoreUpdate {
+					return apiErrorOrNil(c.UpdatePolicyDocument(ctx, policyID, policy), "failed to update policy")
+				}
+				_, err := c.CreatePolicyDocument(ctx, policy)
+				return apiErrorOrNil(err, "failed to create policy")
+			}
+			results = append(results, result)
+		}
+	}
+
+	keyIDs := make([]string, 0, len(b.Keys))
+	for keyID := range b.Keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		session := b.Keys[keyID]
+		result := &restoreResult{Kind: "key", ID: keyID, Action: restoreCreate}
+		if _, err := c.GetKey(ctx, keyID); err == nil {
+			result.Action = restoreUpdate
+		} else if !errors.Is(err, client.ErrNotFound) {
+			return nil, wrapAPIError(err, fmt.Sprintf("failed to get key '%s'", keyID))
+		}
+		result.apply = func(ctx context.Context) error {
+			if result.Action == restoreUpdate {
+				return apiErrorOrNil(c.UpdateKey(ctx, keyID, session), "failed to update key")
+			}
+			return apiErrorOrNil(c.CreateCustomKey(ctx, keyID, session), "failed to create key")
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// apiErrorOrNil wraps err like wrapAPIError, and returns nil when there is no error
+func apiErrorOrNil(err error, action string) error {
+	if err == nil {
+		return nil
+	}
+	return wrapAPIError(err, action)
+}
+
+func outputRestore(cmd *cobra.Command, file string, b *backup.Backup, environment string, results []*restoreResult, dryRun bool) error {
+	summary := map[string]int{restoreCreate: 0, restoreUpdate: 0, restoreSkip: 0, "failed": 0}
+	for _, result := range results {
+		if result.Error != "" {
+			summary["failed"]++
+		} else {
+			summary[result.Action]++
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if results == nil {
+			results = []*restoreResult{}
+		}
+		return writeStructured(format, map[string]interface{}{
+			"file":               file,
+			"environment":        environment,
+			"source_environment": b.Environment,
+			"backed_up_at":       b.CreatedAt,
+			"dry_run":            dryRun,
+			"results":            results,
+			"summary":            summary,
+		})
+	}
+
+	verbs := map[string]string{restoreCreate: "Created", restoreUpdate: "Updated", restoreSkip: "Skipped existing"}
+	if dryRun {
+		verbs = map[string]string{restoreCreate: "Would create", restoreUpdate: "Would update", restoreSkip: "Would skip existing"}
+	}
+	for _, result := range results {
+		label := result.Kind + " " + result.ID
+		if result.Name != "" {
+			label = fmt.Sprintf("%s '%s' (%s)", result.Kind, result.Name, result.ID)
+		}
+		switch {
+		case result.Error != "":
+			color.New(color.FgRed).Printf("✗ %s: %s\n", label, result.Error)
+		case result.Action == restoreSkip:
+			fmt.Printf("  %s %s\n", verbs[result.Action], label)
+		default:
+			color.New(color.FgGreen).Printf("✓ %s %s\n", verbs[result.Action], label)
+		}
+	}
+	source := fmt.Sprintf("%s (backup of %s, %s)", file, b.Environment, b.CreatedAt.Format(time.RFC3339))
+	if dryRun {
+		fmt.Printf("Dry run of restoring %s into %s: %d to create, %d to update, %d to skip\n",
+			source, environment, summary[restoreCreate], summary[restoreUpdate], summary[restoreSkip])
+		return nil
+	}
+	fmt.Printf("Restored %s into %s: %d created, %d updated, %d skipped, %d failed\n",
+		source, environment, summary[restoreCreate], summary[restoreUpdate], summary[restoreSkip], summary["failed"])
+	return nil
+}
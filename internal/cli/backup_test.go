@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	staging, stagingServer := newFakeDashboard(t)
+	production, productionServer := newFakeDashboard(t)
+	seedRemoteAPI(t, staging, "users-1", "users", "1.0.0")
+	seedRemoteAPI(t, staging, "orders-1", "orders", "1.0.0")
+	staging.policies["policy-1"] = &types.Policy{ID: "policy-1", Name: "Gold"}
+	staging.policyDocs["policy-1"] = map[string]interface{}{"_id": "policy-1", "name": "Gold", "partitions": map[string]interface{}{"quota": true}}
+	staging.etags = true
+	staging.keys["key-1"] = types.Session{"access_rights": map[string]interface{}{"users-1": map[string]interface{}{"api_id": "users-1"}}}
+	staging.keys["key-2"] = types.Session{"access_rights": map[string]interface{}{}}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging":    {Name: "staging", DashboardURL: stagingServer.URL, AuthToken: "token", OrgID: "org"},
+			"production": {Name: "production", DashboardURL: productionServer.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	out, err := runRootCommand(t, "backup", "--out", archive, "--policies", "--keys", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("backup", out), string(out))
+	var summary map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &summary))
+	assert.Equal(t, float64(2), summary["apis"])
+	assert.Equal(t, float64(1), summary["policies"])
+	// Only keys with access to a backed up API are included
+	assert.Equal(t, float64(1), summary["keys"])
+	info, err := os.Stat(archive)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	out, err = runRootCommand(t, "restore", "--file", archive, "--env", "production", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "4 to create, 0 to update")
+	assert.Equal(t, 0, production.count())
+
+	out, err = runRootCommand(t, "restore", "--file", archive, "--env", "production", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("restore", out), string(out))
+	assert.Equal(t, 2, production.count())
+	assert.Len(t, production.policies, 1)
+	// Policies are restored with the fields types.Policy does not model
+	assert.Equal(t, map[string]interface{}{"quota": true}, production.policyDocs["policy-1"]["partitions"])
+	assert.Contains(t, production.keys, "key-1")
+
+	// Restoring over existing resources needs a decision
+	_, err = runRootCommand(t, "restore", "--file", archive)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitConflict), exitErr.Code)
+
+	staging.apis["users-1"]["info"].(map[string]interface{})["version"] = "2.0.0"
+	out, err = runRootCommand(t, "restore", "--file", archive, "--skip-existing", "-o", "json")
+	require.NoError(t, err)
+	var result struct {
+		Summary map[string]int `json:"summary"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 4, result.Summary[restoreSkip])
+	assert.Equal(t, "2.0.0", staging.apis["users-1"]["info"].(map[string]interface{})["version"])
+
+	out, err = runRootCommand(t, "restore", "--file", archive, "--overwrite", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 4, result.Summary[restoreUpdate])
+	assert.Equal(t, "1.0.0", staging.apis["users-1"]["info"].(map[string]interface{})["version"])
+	assert.Zero(t, staging.blindUpdates, "APIs are replaced only if unchanged since they were read")
+	assert.Equal(t, map[string]interface{}{"quota": true}, staging.policyDocs["policy-1"]["partitions"])
+}
+
+func TestRestore_BadArgs(t *testing.T) {
+	_, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	notArchive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	require.NoError(t, os.WriteFile(notArchive, []byte("{}"), 0644))
+
+	for _, args := range [][]string{
+		{"--file", notArchive},
+		{"--file", notArchive, "--skip-existing", "--overwrite"},
+		{"--file", filepath.Join(t.TempDir(), "missing.tar.gz")},
+	} {
+		_, err := runRootCommand(t, append([]string{"restore"}, args...)...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, "%v", args)
+		assert.Equal(t, int(types.ExitBadArgs), exitErr.Code, "%v", args)
+	}
+}
@@ -0,0 +1,376 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// bootstrapFile is the declarative description consumed by 'tyk bootstrap'
+type bootstrapFile struct {
+	Environments []bootstrapEnvironment `yaml:"environments"`
+	APIs         []bootstrapAPI         `yaml:"apis"`
+	Policies     []bootstrapPolicy      `yaml:"policies"`
+}
+
+type bootstrapEnvironment struct {
+	Name         string `yaml:"name"`
+	Type         string `yaml:"type"`
+	DashboardURL string `yaml:"dashboard_url"`
+	GatewayURL   string `yaml:"gateway_url"`
+	AuthToken    string `yaml:"auth_token"`
+	OrgID        string `yaml:"org_id"`
+	Timeout      string `yaml:"timeout"`
+	ProxyURL     string `yaml:"proxy_url"`
+	CACert       string `yaml:"ca_cert"`
+	ClientCert   string `yaml:"client_cert"`
+	ClientKey    string `yaml:"client_key"`
+	Insecure     bool   `yaml:"insecure_skip_verify"`
+	Default      bool   `yaml:"default"`
+}
+
+// bootstrapAPI is either a reference to an OAS file or a minimal inline definition
+type bootstrapAPI struct {
+	File         string `yaml:"file"`
+	Name         string `yaml:"name"`
+	UpstreamURL  string `yaml:"upstream_url"`
+	ListenPath   string `yaml:"listen_path"`
+	VersionName  string `yaml:"version_name"`
+	CustomDomain string `yaml:"custom_domain"`
+	Description  string `yaml:"description"`
+}
+
+type bootstrapPolicy struct {
+	Name             string   `yaml:"name"`
+	Rate             float64  `yaml:"rate"`
+	Per              float64  `yaml:"per"`
+	QuotaMax         int64    `yaml:"quota_max"`
+	QuotaRenewalRate int64    `yaml:"quota_renewal_rate"`
+	Tags             []string `yaml:"tags"`
+	// APIs lists API names (as declared above) or existing API IDs the policy grants access to
+	APIs []string `yaml:"apis"`
+}
+
+// bootstrapResult records a single created resource for reporting
+type bootstrapResult struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	ID   string `json:"id,omitempty"`
+}
+
+// NewBootstrapCommand creates the 'tyk bootstrap' command
+func NewBootstrapCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Set up environments, APIs and policies from a single file",
+		Long: `Bootstrap a fresh Dashboard from a declarative YAML file.
+
+The file may declare environments (saved to the CLI configuration), APIs to create
+and policies granting access to them. Values may reference environment variables
+using ${VAR} syntax. API file paths are resolved relative to the bootstrap file.
+
+Policy API references, certificate IDs and webhook URLs are all checked before anything
+is created, so a bad reference does not leave a half-bootstrapped Dashboard.
+
+Example environment.yaml:
+  environments:
+    - name: demo
+      dashboard_url: http://localhost:3000
+      auth_token: ${TYK_AUTH_TOKEN}
+      org_id: ${TYK_ORG_ID}
+      default: true
+  apis:
+    - file: apis/petstore.yaml
+    - name: Users
+      upstream_url: https://users.internal
+  policies:
+    - name: Free tier
+      rate: 100
+      per: 60
+      quota_max: 10000
+      apis: [Users, Swagger Petstore]
+
+Examples:
+  tyk bootstrap -f environment.yaml
+  tyk bootstrap -f environment.yaml --env demo -o json`,
+		RunE: runBootstrap,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Path to bootstrap file (required)")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runBootstrap(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	envFlag, _ := cmd.Flags().GetString("env")
+	outputFormat, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	spec, err := loadBootstrapFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	// Persist declared environments first so later commands can use them
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	var results []bootstrapResult
+	for _, e := range spec.Environments {
+		env := &types.Environment{
+			Name:               e.Name,
+			Type:               e.Type,
+			DashboardURL:       e.DashboardURL,
+			GatewayURL:         e.GatewayURL,
+			AuthToken:          e.AuthToken,
+			OrgID:              e.OrgID,
+			Timeout:            e.Timeout,
+			ProxyURL:           e.ProxyURL,
+			CACert:             e.CACert,
+			ClientCert:         e.ClientCert,
+			ClientKey:          e.ClientKey,
+			InsecureSkipVerify: e.Insecure,
+		}
+		if err := env.Validate(); err != nil {
+			return &ExitError{Code: 2, Message: fmt.Sprintf("invalid environment in bootstrap file: %v", err)}
+		}
+		if err := manager.SaveEnvironment(env, e.Default); err != nil {
+			return fmt.Errorf("failed to save environment: %w", err)
+		}
+		results = append(results, bootstrapResult{Kind: "environment", Name: e.Name})
+	}
+	if len(spec.Environments) > 0 {
+		if err := saveConfigToFile(manager); err != nil {
+			return err
+		}
+	}
+
+	if envFlag != "" {
+		if err := manager.SetDefaultEnvironment(envFlag); err != nil {
+			return &ExitError{Code: 2, Message: err.Error()}
+		}
+	}
+
+	if len(spec.APIs) > 0 || len(spec.Policies) > 0 {
+		cfg := manager.GetConfig()
+		if err := applyTimeoutFlag(cmd, cfg); err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+
+		c, err := commandClient(cmd.Context(), cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		created, err := bootstrapResources(c, spec, filepath.Dir(filePath), cfg)
+		results = append(results, created...)
+		if err != nil {
+			outputBootstrapResults(results, outputFormat)
+			return err
+		}
+	}
+
+	return outputBootstrapResults(results, outputFormat)
+}
+
+// loadBootstrapFile reads the bootstrap file, expanding ${VAR} references before parsing
+func loadBootstrapFile(filePath string) (*bootstrapFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to read bootstrap file: %v", err)}
+	}
+
+	var spec bootstrapFile
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &spec); err != nil {
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to parse bootstrap file: %v", err)}
+	}
+	return &spec, nil
+}
+
+// bootstrapResources creates the declared APIs and then the policies that reference them
+func bootstrapResources(c *client.Client, spec *bootstrapFile, baseDir string, cfg *types.Config) ([]bootstrapResult, error) {
+	var results []bootstrapResult
+	apiIDs := make(map[string]string)
+
+	docs := make([]map[string]interface{}, len(spec.APIs))
+	for i, a := range spec.APIs {
+		oasData, err := bootstrapAPIDocument(a, baseDir)
+		if err != nil {
+			return results, err
+		}
+		if err := mountEnvironmentPrefix(cfg, fmt.Sprintf("API '%s'", a.displayName()), oasData); err != nil {
+			return results, err
+		}
+		docs[i] = oasData
+	}
+	if err := checkBootstrapReferences(c, spec, docs, cfg); err != nil {
+		return results, err
+	}
+
+	for i, a := range spec.APIs {
+		oasData := docs[i]
+		ctx, cancel := apiContext(cfg, 30*time.Second)
+		api, err := c.CreateOASAPI(ctx, oasData)
+		cancel()
+		if err != nil {
+			return results, wrapAPIError(err, fmt.Sprintf("failed to create API '%s'", a.displayName()))
+		}
+
+		apiIDs[api.Name] = api.ID
+		results = append(results, bootstrapResult{Kind: "api", Name: api.Name, ID: api.ID})
+	}
+
+	env, err := cfg.GetActiveEnvironment()
+	if err != nil {
+		return results, err
+	}
+
+	for _, p := range spec.Policies {
+		policy := &types.Policy{
+			Name:             p.Name,
+			OrgID:            env.OrgID,
+			Active:           true,
+			Rate:             p.Rate,
+			Per:              p.Per,
+			QuotaMax:         p.QuotaMax,
+			QuotaRenewalRate: p.QuotaRenewalRate,
+			Tags:             p.Tags,
+			AccessRights:     make(map[string]*types.PolicyAccessRights),
+		}
+		for _, ref := range p.APIs {
+			id, name := ref, ref
+			if createdID, ok := apiIDs[ref]; ok {
+				id = createdID
+			}
+			policy.AccessRights[id] = &types.PolicyAccessRights{APIID: id, APIName: name, Versions: []string{"Default"}}
+		}
+
+		ctx, cancel := apiContext(cfg, 30*time.Second)
+		id, err := c.CreatePolicy(ctx, policy)
+		cancel()
+		if err != nil {
+			return results, wrapAPIError(err, fmt.Sprintf("failed to create policy '%s'", p.Name))
+		}
+		results = append(results, bootstrapResult{Kind: "policy", Name: p.Name, ID: id})
+	}
+
+	return results, nil
+}
+
+// checkBootstrapReferences validates every certificate, webhook and policy API reference
+// before anything is created
+func checkBootstrapReferences(c *client.Client, spec *bootstrapFile, docs []map[string]interface{}, cfg *types.Config) error {
+	ctx, cancel := apiContext(cfg, 5*time.Minute)
+	defer cancel()
+
+	checker := newReferenceChecker(ctx, c)
+	declared := make(map[string]bool)
+	for i, doc := range docs {
+		name := oas.GetAPIName(doc)
+		declared[name] = true
+		if err := checker.checkAPI(fmt.Sprintf("API '%s'", spec.APIs[i].displayName()), doc); err != nil {
+			return err
+		}
+	}
+	for _, p := range spec.Policies {
+		for _, ref := range p.APIs {
+			if declared[ref] {
+				continue
+			}
+			if err := checker.checkAPIID(fmt.Sprintf("policy '%s'", p.Name), ref); err != nil {
+				return err
+			}
+		}
+	}
+	return checker.err()
+}
+
+// bootstrapAPIDocument builds the OAS document to create for a bootstrap API entry
+func bootstrapAPIDocument(a bootstrapAPI, baseDir string) (map[string]interface{}, error) {
+	if a.File != "" {
+		path := a.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		oasData, err := loadOASFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !oas.HasTykExtensions(oasData) {
+			oasData, err = oas.AddTykExtensions(oasData)
+			if err != nil {
+				return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to generate Tyk extensions for %s: %v", a.File, err)}
+			}
+		}
+		if _, err := oas.ExpandRateLimits(oasData); err != nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s: %v", a.File, err)}
+		}
+		return oasData, nil
+	}
+
+	if a.Name == "" || a.UpstreamURL == "" {
+		return nil, &ExitError{Code: 2, Message: "bootstrap API entries need either 'file' or both 'name' and 'upstream_url'"}
+	}
+
+	listenPath := a.ListenPath
+	if listenPath == "" {
+		listenPath = oas.GenerateListenPath(a.Name)
+	}
+	versionName := a.VersionName
+	if versionName == "" {
+		versionName = "v1"
+	}
+	description := a.Description
+	if description == "" {
+		description = "Auto-generated API specification"
+	}
+	return generateOASForCreate(a.Name, description, versionName, a.UpstreamURL, listenPath, a.CustomDomain)
+}
+
+func (a bootstrapAPI) displayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.File
+}
+
+// outputBootstrapResults reports everything that was created
+func outputBootstrapResults(results []bootstrapResult, format types.OutputFormat) error {
+	if format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"created": results,
+			"count":   len(results),
+		})
+	}
+
+	green := color.New(color.FgGreen, color.Bold)
+	for _, r := range results {
+		if r.ID != "" {
+			green.Printf("✓ Created %s '%s' (%s)\n", r.Kind, r.Name, r.ID)
+		} else {
+			green.Printf("✓ Configured %s '%s'\n", r.Kind, r.Name)
+		}
+	}
+	if len(results) == 0 {
+		color.New(color.FgYellow).Println("Bootstrap file declared nothing to create.")
+	}
+	return nil
+}
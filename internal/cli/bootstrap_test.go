@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestBootstrap_CreatesEnvironmentAPIsAndPolicies(t *testing.T) {
+	var createdPolicy types.Policy
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/apis/oas":
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK", ID: "users-id"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/apis/oas/users-id":
+			doc, _ := generateOASForCreate("Users", "desc", "v1", "https://users.internal", "/users/", "")
+			doc["x-tyk-api-gateway"].(map[string]interface{})["info"].(map[string]interface{})["id"] = "users-id"
+			json.NewEncoder(w).Encode(doc)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/portal/policies":
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &createdPolicy))
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK", Message: "policy-id"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	writeTestConfigFile(t, &types.Config{})
+	t.Setenv("BOOTSTRAP_TOKEN", "secret-token")
+
+	bootstrap := `environments:
+  - name: demo
+    dashboard_url: ` + server.URL + `
+    auth_token: ${BOOTSTRAP_TOKEN}
+    org_id: demo-org
+    default: true
+apis:
+  - name: Users
+    upstream_url: https://users.internal
+policies:
+  - name: Free tier
+    rate: 100
+    per: 60
+    apis: [Users]
+`
+	path := filepath.Join(t.TempDir(), "environment.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(bootstrap), 0644))
+
+	root := NewRootCommand("test", "", "")
+	root.SetArgs([]string{"bootstrap", "-f", path, "--json"})
+	out, err := captureStdout(root.Execute)
+	require.NoError(t, err)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, float64(3), result["count"])
+
+	// Policy grants access to the API created earlier in the same run
+	assert.Equal(t, "Free tier", createdPolicy.Name)
+	assert.Equal(t, "demo-org", createdPolicy.OrgID)
+	require.Contains(t, createdPolicy.AccessRights, "users-id")
+	assert.Equal(t, "Users", createdPolicy.AccessRights["users-id"].APIName)
+
+	// Environment was persisted with the expanded token
+	manager := config.NewManager()
+	require.NoError(t, manager.LoadConfig())
+	env, err := manager.GetEnvironment("demo")
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", env.AuthToken)
+	assert.Equal(t, "demo", manager.GetConfig().DefaultEnvironment)
+}
+
+func TestBootstrap_InvalidAPIEntry(t *testing.T) {
+	_, err := bootstrapAPIDocument(bootstrapAPI{Name: "No upstream"}, ".")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.True(t, strings.Contains(exitErr.Message, "upstream_url"))
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/logging"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// compatibilityChecker rejects API definitions using extension features the target
+// environment's Tyk release does not support, before anything is changed. It does
+// nothing when the release is unknown.
+type compatibilityChecker struct {
+	env      string
+	version  string
+	problems []string
+}
+
+// newCompatibilityChecker finds the environment's Tyk release from its tyk_version
+// setting, else from the capabilities cached by 'tyk prefetch' while they are fresh,
+// or else from the version its health endpoint reports
+func newCompatibilityChecker(ctx context.Context, c *client.Client, env *types.Environment) (*compatibilityChecker, error) {
+	checker := &compatibilityChecker{env: env.Name, version: env.TykVersion}
+	if checker.version != "" {
+		if _, err := oas.ParseTykVersion(checker.version); err != nil {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid tyk_version for environment '%s': %v", env.Name, err)}
+		}
+		return checker, nil
+	}
+
+	var reported string
+	if capabilities, err := c.CachedCapabilities(); err == nil && capabilities.Fresh() && capabilities.Version != "" {
+		reported = capabilities.Version
+	} else {
+		status, err := c.CheckHealth(ctx)
+		if err != nil {
+			logging.Debugf("compatibility check skipped: %v", err)
+			return checker, nil
+		}
+		reported = status.Version
+	}
+	version := strings.TrimPrefix(reported, "v")
+	if _, err := oas.ParseTykVersion(version); err != nil {
+		logging.Debugf("compatibility check skipped: server reported version %q", reported)
+		return checker, nil
+	}
+	checker.version = version
+	return checker, nil
+}
+
+// checkDocumentCompatibility validates one API definition against the active
+// environment's Tyk release, unless --skip-compat-check is set
+func checkDocumentCompatibility(cmd *cobra.Command, config *types.Config, resource string, doc map[string]interface{}) error {
+	if skip, _ := cmd.Flags().GetBool("skip-compat-check"); skip {
+		return nil
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	checker, err := newCompatibilityChecker(ctx, c, env)
+	if err != nil {
+		return err
+	}
+	checker.checkAPI(resource, doc)
+	return checker.err()
+}
+
+// checkAPI records every unsupported feature doc uses
+func (k *compatibilityChecker) checkAPI(resource string, doc map[string]interface{}) {
+	if k.version == "" {
+		return
+	}
+	problems, _ := oas.CheckCompatibility(doc, k.version)
+	for _, p := range problems {
+		k.problems = append(k.problems, fmt.Sprintf("%s: %s: %s", resource, p.Path, p.Message))
+	}
+}
+
+// err returns one error listing every problem found, or nil
+func (k *compatibilityChecker) err() error {
+	if len(k.problems) == 0 {
+		return nil
+	}
+	return &ExitError{
+		Code: int(types.ExitBadArgs),
+		Message: fmt.Sprintf("environment '%s' runs Tyk %s, which does not support %d feature use(s), nothing was changed:\n  %s",
+			k.env, k.version, len(k.problems), strings.Join(k.problems, "\n  ")),
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/oas"
 	"github.com/tyktech/tyk-cli/pkg/types"
 )
 
@@ -27,6 +29,9 @@ Examples:
   tyk config list                    # List all environments
   tyk config use staging             # Switch to staging environment  
   tyk config current                 # Show current environment
+  tyk config resolve                 # Show effective settings and their sources
+  tyk config export --out envs.yaml  # Share environment definitions, without secrets
+  tyk config import envs.yaml --merge
   tyk config add dev --dashboard-url http://localhost:3000 --auth-token token --org-id org
   tyk config set dashboard-url https://api.tyk.io  # Update current environment`,
 	}
@@ -37,6 +42,10 @@ Examples:
 	configCmd.AddCommand(NewConfigAddCommand())
 	configCmd.AddCommand(NewConfigSetCommand())
 	configCmd.AddCommand(NewConfigRemoveCommand())
+	configCmd.AddCommand(NewConfigSyncRemoteCommand())
+	configCmd.AddCommand(NewConfigResolveCommand())
+	configCmd.AddCommand(NewConfigExportCommand())
+	configCmd.AddCommand(NewConfigImportCommand())
 
 	return configCmd
 }
@@ -46,10 +55,17 @@ func NewConfigListCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all configured environments",
-		Long:  "Display all configured environments and show which one is active",
-		RunE:  runConfigList,
+		Long: `Display all configured environments and show which one is active.
+
+Examples:
+  tyk config list
+  tyk config list --columns name,dashboard_url --no-headers
+  tyk config list --template '{{.Name}}\t{{.DashboardURL}}'`,
+		RunE: runConfigList,
 	}
 
+	addListOutputFlags(cmd)
+
 	return cmd
 }
 
@@ -85,21 +101,31 @@ func NewConfigAddCommand() *cobra.Command {
 		Short: "Add a new environment",
 		Long: `Add a new environment configuration.
 
+An environment can carry its own environment variables (--var), set for every command
+run against it unless already set in the shell, and extra headers sent with every API
+request (--header), whose values may reference variables as ${NAME}.
+
 Examples:
   tyk config add development --dashboard-url http://localhost:3000 --auth-token token --org-id org
-  tyk config add production --dashboard-url https://prod-dashboard.com --auth-token prod-token --org-id prod-org --set-default`,
+  tyk config add production --dashboard-url https://prod-dashboard.com --auth-token prod-token --org-id prod-org --set-default
+  tyk config add oss --type gateway --gateway-url http://localhost:8080 --auth-token <gateway-secret>
+  tyk config add edge --dashboard-url https://edge-dashboard.com --auth-token token --org-id org \
+    --var TYK_GATEWAY_URL=https://edge-gw.example.com --header 'X-Team: ${TEAM_TOKEN}'`,
 		Args: cobra.ExactArgs(1),
 		RunE: runConfigAdd,
 	}
 
-	cmd.Flags().String("dashboard-url", "", "Tyk Dashboard URL")
-	cmd.Flags().String("auth-token", "", "Dashboard API auth token")
-	cmd.Flags().String("org-id", "", "Organization ID")
+	cmd.Flags().String("type", types.EnvTypeDashboard, "Environment type: dashboard or gateway (OSS Gateway API)")
+	cmd.Flags().String("dashboard-url", "", "Tyk Dashboard URL (required for dashboard environments)")
+	cmd.Flags().String("gateway-url", "", "Tyk Gateway URL (required for gateway environments; used by 'api try' and 'api url' for dashboard ones)")
+	cmd.Flags().String("auth-token", "", "Dashboard API auth token, or Gateway secret for gateway environments")
+	cmd.Flags().String("org-id", "", "Organization ID (required for dashboard environments)")
+	cmd.Flags().String("request-timeout", "", "Timeout for each API request to the environment, e.g. 2m (default 30s)")
+	addTransportFlags(cmd)
+	addEnvironmentVarFlags(cmd)
 	cmd.Flags().Bool("set-default", false, "Set this environment as the default")
 
-	cmd.MarkFlagRequired("dashboard-url")
 	cmd.MarkFlagRequired("auth-token")
-	cmd.MarkFlagRequired("org-id")
 
 	return cmd
 }
@@ -117,13 +143,22 @@ Examples:
   tyk config set org-id new-org-id
   
   # Set multiple values at once
-  tyk config set dashboard-url https://api.tyk.io auth-token token org-id org`,
+  tyk config set dashboard-url https://api.tyk.io auth-token token org-id org
+
+  # Sign every API request for a Dashboard that verifies HMAC signatures
+  tyk config set --signing-secret env:DASHBOARD_SIGNING_SECRET --signing-key-id cli`,
 		RunE: runConfigSet,
 	}
 
 	cmd.Flags().String("dashboard-url", "", "Update dashboard URL")
+	cmd.Flags().String("gateway-url", "", "Update gateway URL")
 	cmd.Flags().String("auth-token", "", "Update auth token")  
 	cmd.Flags().String("org-id", "", "Update organization ID")
+	cmd.Flags().String("request-timeout", "", "Update the timeout for each API request, e.g. 2m")
+	cmd.Flags().String("tyk-version", "", "Set the Tyk release the environment runs, e.g. 5.3 (checked before apply)")
+	cmd.Flags().String("listen-path-prefix", "", "Mount every API deployed to the environment under this listen path, e.g. /staging")
+	addTransportFlags(cmd)
+	addEnvironmentVarFlags(cmd)
 
 	return cmd
 }
@@ -150,6 +185,14 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	cfg := manager.GetConfig()
 	environments := manager.ListEnvironments()
 
+	listOutput, err := getListOutputOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if listOutput != nil {
+		return listOutput.render(os.Stdout, maskedEnvironments(environments))
+	}
+
 	if len(environments) == 0 {
 		yellow := color.New(color.FgYellow)
 		yellow.Println("No environments configured.")
@@ -180,9 +223,21 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Printf("  %s:\n", name)
 		}
-		cyan.Printf("    dashboard_url = %s\n", env.DashboardURL)
+		if env.IsGateway() {
+			cyan.Printf("    type          = %s\n", env.Type)
+			cyan.Printf("    gateway_url   = %s\n", env.GatewayURL)
+		} else {
+			cyan.Printf("    dashboard_url = %s\n", env.DashboardURL)
+		}
 		cyan.Printf("    auth_token    = %s\n", maskToken(env.AuthToken))
-		cyan.Printf("    org_id        = %s\n", env.OrgID)
+		if env.OrgID != "" {
+			cyan.Printf("    org_id        = %s\n", env.OrgID)
+		}
+		if env.Timeout != "" {
+			cyan.Printf("    timeout       = %s\n", env.Timeout)
+		}
+		printTransportSettings(cyan.Printf, "    ", env)
+		printEnvironmentVars(cyan.Printf, "    ", env)
 		fmt.Println()
 	}
 
@@ -209,6 +264,9 @@ func runConfigUse(cmd *cobra.Command, args []string) error {
 		envName = args[0]
 	} else {
 		// Interactive selection
+		if err := requireTerminal("an environment", "give its name: tyk config use <environment>"); err != nil {
+			return err
+		}
 		var err error
 		envName, err = selectEnvironmentInteractively(environments, cfg.DefaultEnvironment)
 		if err != nil {
@@ -264,26 +322,53 @@ func runConfigCurrent(cmd *cobra.Command, args []string) error {
 
 	blue.Println("Current environment:")
 	green.Printf("● %s (active)\n", activeEnv.Name)
-	cyan.Printf("  dashboard_url = %s\n", activeEnv.DashboardURL)
+	if activeEnv.IsGateway() {
+		cyan.Printf("  type          = %s\n", activeEnv.Type)
+		cyan.Printf("  gateway_url   = %s\n", activeEnv.GatewayURL)
+	} else {
+		cyan.Printf("  dashboard_url = %s\n", activeEnv.DashboardURL)
+	}
 	cyan.Printf("  auth_token    = %s\n", maskToken(activeEnv.AuthToken))
-	cyan.Printf("  org_id        = %s\n", activeEnv.OrgID)
+	if activeEnv.OrgID != "" {
+		cyan.Printf("  org_id        = %s\n", activeEnv.OrgID)
+	}
+	if activeEnv.Timeout != "" {
+		cyan.Printf("  timeout       = %s\n", activeEnv.Timeout)
+	}
+	printTransportSettings(cyan.Printf, "  ", activeEnv)
+	printEnvironmentVars(cyan.Printf, "  ", activeEnv)
 
 	return nil
 }
 
 func runConfigAdd(cmd *cobra.Command, args []string) error {
 	envName := args[0]
+	envType, _ := cmd.Flags().GetString("type")
 	dashboardURL, _ := cmd.Flags().GetString("dashboard-url")
+	gatewayURL, _ := cmd.Flags().GetString("gateway-url")
 	authToken, _ := cmd.Flags().GetString("auth-token")
 	orgID, _ := cmd.Flags().GetString("org-id")
+	timeout, _ := cmd.Flags().GetString("request-timeout")
 	setDefault, _ := cmd.Flags().GetBool("set-default")
 
+	// Dashboard is the implicit default and is not written to the config file
+	if envType == types.EnvTypeDashboard {
+		envType = ""
+	}
+
 	// Create the environment
 	env := &types.Environment{
 		Name:         envName,
+		Type:         envType,
 		DashboardURL: dashboardURL,
+		GatewayURL:   gatewayURL,
 		AuthToken:    authToken,
 		OrgID:        orgID,
+		Timeout:      timeout,
+	}
+	applyTransportFlags(cmd, env)
+	if err := applyEnvironmentVarFlags(cmd, env); err != nil {
+		return err
 	}
 
 	// Validate the environment
@@ -323,10 +408,14 @@ func runConfigAdd(cmd *cobra.Command, args []string) error {
 
 func runConfigSet(cmd *cobra.Command, args []string) error {
 	dashboardURL, _ := cmd.Flags().GetString("dashboard-url")
+	gatewayURL, _ := cmd.Flags().GetString("gateway-url")
 	authToken, _ := cmd.Flags().GetString("auth-token")
 	orgID, _ := cmd.Flags().GetString("org-id")
+	timeout, _ := cmd.Flags().GetString("request-timeout")
+	tykVersion, _ := cmd.Flags().GetString("tyk-version")
+	listenPathPrefix, _ := cmd.Flags().GetString("listen-path-prefix")
 
-	if dashboardURL == "" && authToken == "" && orgID == "" {
+	if dashboardURL == "" && gatewayURL == "" && authToken == "" && orgID == "" && timeout == "" && tykVersion == "" && listenPathPrefix == "" && !transportFlagsChanged(cmd) && !environmentVarFlagsChanged(cmd) {
 		return fmt.Errorf("at least one configuration value must be provided")
 	}
 
@@ -352,12 +441,35 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	if dashboardURL != "" {
 		activeEnv.DashboardURL = dashboardURL
 	}
+	if gatewayURL != "" {
+		activeEnv.GatewayURL = gatewayURL
+	}
 	if authToken != "" {
 		activeEnv.AuthToken = authToken
 	}
 	if orgID != "" {
 		activeEnv.OrgID = orgID
 	}
+	if timeout != "" {
+		activeEnv.Timeout = timeout
+	}
+	if tykVersion != "" {
+		if _, err := oas.ParseTykVersion(tykVersion); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		activeEnv.TykVersion = tykVersion
+	}
+	if listenPathPrefix != "" {
+		listenPathPrefix = "/" + strings.Trim(listenPathPrefix, "/")
+		if listenPathPrefix == "/" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "--listen-path-prefix must not be /"}
+		}
+		activeEnv.ListenPathPrefix = listenPathPrefix
+	}
+	applyTransportFlags(cmd, activeEnv)
+	if err := applyEnvironmentVarFlags(cmd, activeEnv); err != nil {
+		return err
+	}
 
 	// Validate updated environment
 	if err := activeEnv.Validate(); err != nil {
@@ -376,12 +488,27 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	if dashboardURL != "" {
 		fmt.Printf("  dashboard_url = %s\n", dashboardURL)
 	}
+	if gatewayURL != "" {
+		fmt.Printf("  gateway_url   = %s\n", gatewayURL)
+	}
 	if authToken != "" {
 		fmt.Printf("  auth_token    = %s\n", maskToken(authToken))
 	}
 	if orgID != "" {
 		fmt.Printf("  org_id        = %s\n", orgID)
 	}
+	if timeout != "" {
+		fmt.Printf("  timeout       = %s\n", timeout)
+	}
+	if tykVersion != "" {
+		fmt.Printf("  tyk_version   = %s\n", tykVersion)
+	}
+	if listenPathPrefix != "" {
+		fmt.Printf("  listen_path_prefix = %s\n", listenPathPrefix)
+	}
+	if transportFlagsChanged(cmd) {
+		printTransportSettings(fmt.Printf, "  ", activeEnv)
+	}
 
 	return nil
 }
@@ -468,15 +595,73 @@ func generateTOMLConfigUnified(cfg *types.Config) string {
 	if cfg.DefaultEnvironment != "" {
 		content += fmt.Sprintf("default_environment = \"%s\"\n\n", cfg.DefaultEnvironment)
 	}
+
+	// Shared environment definitions
+	if cfg.Remote != nil && cfg.Remote.URL != "" {
+		content += "[remote]\n"
+		content += fmt.Sprintf("url = %q\n", cfg.Remote.URL)
+		if cfg.Remote.Path != "" {
+			content += fmt.Sprintf("path = %q\n", cfg.Remote.Path)
+		}
+		if cfg.Remote.Ref != "" {
+			content += fmt.Sprintf("ref = %q\n", cfg.Remote.Ref)
+		}
+		content += "\n"
+	}
 	
 	// Add all environments
 	if len(cfg.Environments) > 0 {
 		for name, env := range cfg.Environments {
 			content += fmt.Sprintf("[environments.%s]\n", name)
 			content += fmt.Sprintf("name = \"%s\"\n", env.Name)
+			if env.Type != "" {
+				content += fmt.Sprintf("type = \"%s\"\n", env.Type)
+			}
 			content += fmt.Sprintf("dashboard_url = \"%s\"\n", env.DashboardURL)
+			if env.GatewayURL != "" {
+				content += fmt.Sprintf("gateway_url = \"%s\"\n", env.GatewayURL)
+			}
 			content += fmt.Sprintf("auth_token = \"%s\"\n", env.AuthToken)
 			content += fmt.Sprintf("org_id = \"%s\"\n", env.OrgID)
+			if env.Timeout != "" {
+				content += fmt.Sprintf("timeout = \"%s\"\n", env.Timeout)
+			}
+			if env.ProxyURL != "" {
+				content += fmt.Sprintf("proxy_url = \"%s\"\n", env.ProxyURL)
+			}
+			if env.CACert != "" {
+				content += fmt.Sprintf("ca_cert = \"%s\"\n", env.CACert)
+			}
+			if env.ClientCert != "" {
+				content += fmt.Sprintf("client_cert = \"%s\"\n", env.ClientCert)
+				content += fmt.Sprintf("client_key = \"%s\"\n", env.ClientKey)
+			}
+			if env.InsecureSkipVerify {
+				content += "insecure_skip_verify = true\n"
+			}
+			if env.TykVersion != "" {
+				content += fmt.Sprintf("tyk_version = \"%s\"\n", env.TykVersion)
+			}
+			if env.ListenPathPrefix != "" {
+				content += fmt.Sprintf("listen_path_prefix = \"%s\"\n", env.ListenPathPrefix)
+			}
+			if env.Shared {
+				content += "shared = true\n"
+			}
+			if len(env.Env) > 0 {
+				content += fmt.Sprintf("\n[environments.%s.env]\n", name)
+				for _, key := range sortedKeys(env.Env) {
+					content += fmt.Sprintf("%q = %q\n", strings.ToUpper(key), env.Env[key])
+				}
+			}
+			if len(env.Headers) > 0 {
+				content += fmt.Sprintf("\n[environments.%s.headers]\n", name)
+				for _, key := range sortedKeys(env.Headers) {
+					content += fmt.Sprintf("%q = %q\n", key, env.Headers[key])
+				}
+			}
+			content += signingTOML(name, env.Signing)
+			content += promotionRulesTOML(name, env.PromoteFrom)
 			content += "\n"
 		}
 	}
@@ -484,6 +669,149 @@ func generateTOMLConfigUnified(cfg *types.Config) string {
 	return content
 }
 
+// signingTOML writes the request signing settings of an environment as a table
+func signingTOML(name string, signing *types.RequestSigning) string {
+	if signing == nil {
+		return ""
+	}
+	content := fmt.Sprintf("\n[environments.%s.signing]\n", name)
+	if signing.Algorithm != "" {
+		content += fmt.Sprintf("algorithm = %q\n", signing.Algorithm)
+	}
+	if signing.KeyID != "" {
+		content += fmt.Sprintf("key_id = %q\n", signing.KeyID)
+	}
+	content += fmt.Sprintf("secret = %q\n", signing.Secret)
+	if signing.Header != "" {
+		content += fmt.Sprintf("header = %q\n", signing.Header)
+	}
+	if len(signing.Headers) > 0 {
+		quoted := make([]string, len(signing.Headers))
+		for i, header := range signing.Headers {
+			quoted[i] = fmt.Sprintf("%q", header)
+		}
+		content += fmt.Sprintf("headers = [%s]\n", strings.Join(quoted, ", "))
+	}
+	return content
+}
+
+// promotionRulesTOML writes the promote_from rules of an environment as arrays of tables
+func promotionRulesTOML(name string, promoteFrom map[string]*types.PromotionRules) string {
+	content := ""
+	for _, from := range sortedKeys(promoteFrom) {
+		rules := promoteFrom[from]
+		if rules == nil {
+			continue
+		}
+		content += fmt.Sprintf("\n[environments.%s.promote_from.%s]\n", name, from)
+		for _, list := range []struct {
+			key      string
+			rewrites []types.Rewrite
+		}{
+			{"listen_path_prefixes", rules.ListenPathPrefixes},
+			{"domains", rules.Domains},
+			{"upstream_hosts", rules.UpstreamHosts},
+		} {
+			for _, rewrite := range list.rewrites {
+				content += fmt.Sprintf("[[environments.%s.promote_from.%s.%s]]\n", name, from, list.key)
+				content += fmt.Sprintf("from = %q\nto = %q\n", rewrite.From, rewrite.To)
+			}
+		}
+	}
+	return content
+}
+
+// addTransportFlags adds the proxy, TLS and request signing settings shared by
+// 'config add' and 'config set'
+func addTransportFlags(cmd *cobra.Command) {
+	cmd.Flags().String("proxy-url", "", "Proxy for API requests (default: HTTPS_PROXY/HTTP_PROXY)")
+	cmd.Flags().String("ca-cert", "", "PEM bundle of additional CAs to trust")
+	cmd.Flags().String("client-cert", "", "PEM client certificate for mutual TLS")
+	cmd.Flags().String("client-key", "", "PEM private key for --client-cert")
+	cmd.Flags().Bool("insecure-skip-verify", false, "Do not verify the server's TLS certificate (unsafe)")
+	cmd.Flags().String("signing-secret", "", "Sign every API request with an HMAC keyed by this secret, given as env:NAME or file:PATH")
+	cmd.Flags().String("signing-algorithm", "", "Request signing algorithm: hmac-sha256 (default), hmac-sha512 or hmac-sha1")
+	cmd.Flags().String("signing-key-id", "", "Key ID sent with request signatures")
+	cmd.Flags().StringSlice("signing-headers", nil, "Request parts to sign, e.g. (request-target),host,date,digest (default)")
+	cmd.Flags().Bool("no-signing", false, "Stop signing API requests")
+}
+
+var transportFlags = []string{"proxy-url", "ca-cert", "client-cert", "client-key", "insecure-skip-verify",
+	"signing-secret", "signing-algorithm", "signing-key-id", "signing-headers", "no-signing"}
+
+// transportFlagsChanged reports whether any proxy, TLS or request signing flag was given
+func transportFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range transportFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyTransportFlags copies the proxy, TLS and request signing flags that were given
+// onto env; env.Validate checks the result
+func applyTransportFlags(cmd *cobra.Command, env *types.Environment) {
+	flags := cmd.Flags()
+	if flags.Changed("proxy-url") {
+		env.ProxyURL, _ = flags.GetString("proxy-url")
+	}
+	if flags.Changed("ca-cert") {
+		env.CACert, _ = flags.GetString("ca-cert")
+	}
+	if flags.Changed("client-cert") {
+		env.ClientCert, _ = flags.GetString("client-cert")
+	}
+	if flags.Changed("client-key") {
+		env.ClientKey, _ = flags.GetString("client-key")
+	}
+	if flags.Changed("insecure-skip-verify") {
+		env.InsecureSkipVerify, _ = flags.GetBool("insecure-skip-verify")
+	}
+
+	if noSigning, _ := flags.GetBool("no-signing"); noSigning {
+		env.Signing = nil
+		return
+	}
+	for _, name := range []string{"signing-secret", "signing-algorithm", "signing-key-id", "signing-headers"} {
+		if flags.Changed(name) && env.Signing == nil {
+			env.Signing = &types.RequestSigning{}
+		}
+	}
+	if flags.Changed("signing-secret") {
+		env.Signing.Secret, _ = flags.GetString("signing-secret")
+	}
+	if flags.Changed("signing-algorithm") {
+		env.Signing.Algorithm, _ = flags.GetString("signing-algorithm")
+	}
+	if flags.Changed("signing-key-id") {
+		env.Signing.KeyID, _ = flags.GetString("signing-key-id")
+	}
+	if flags.Changed("signing-headers") {
+		env.Signing.Headers, _ = flags.GetStringSlice("signing-headers")
+	}
+}
+
+// printTransportSettings prints the proxy, TLS and request signing settings that are set on env
+func printTransportSettings(printf func(format string, a ...interface{}) (int, error), indent string, env *types.Environment) {
+	if env.ProxyURL != "" {
+		printf("%sproxy_url     = %s\n", indent, env.ProxyURL)
+	}
+	if env.CACert != "" {
+		printf("%sca_cert       = %s\n", indent, env.CACert)
+	}
+	if env.ClientCert != "" {
+		printf("%sclient_cert   = %s\n", indent, env.ClientCert)
+		printf("%sclient_key    = %s\n", indent, env.ClientKey)
+	}
+	if env.InsecureSkipVerify {
+		printf("%sinsecure_skip_verify = true\n", indent)
+	}
+	if env.Signing != nil {
+		printf("%ssigning       = %s\n", indent, describeSigning(env.Signing))
+	}
+}
+
 func maskToken(token string) string {
 	if token == "" {
 		return "(not set)"
@@ -494,6 +822,37 @@ func maskToken(token string) string {
 	return token[:4] + "****" + token[len(token)-4:]
 }
 
+// maskedEnvironments returns environments sorted by name with auth tokens masked
+func maskedEnvironments(environments map[string]*types.Environment) []*types.Environment {
+	var names []string
+	for name := range environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	masked := make([]*types.Environment, 0, len(names))
+	for _, name := range names {
+		env := *environments[name]
+		env.AuthToken = maskToken(env.AuthToken)
+		env.Env = maskValues(env.Env)
+		env.Headers = maskValues(env.Headers)
+		masked = append(masked, &env)
+	}
+	return masked
+}
+
+// maskValues returns a copy of m with every value masked
+func maskValues(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(m))
+	for key, value := range m {
+		masked[key] = maskToken(value)
+	}
+	return masked
+}
+
 func selectEnvironmentInteractively(environments map[string]*types.Environment, currentDefault string) (string, error) {
 	// Create sorted list of environment names for consistent display
 	var envNames []string
@@ -518,7 +877,7 @@ func selectEnvironmentInteractively(environments map[string]*types.Environment,
 		}
 		
 		// Add environment details
-		displayName += yellow.Sprintf(" - %s", env.DashboardURL)
+		displayName += yellow.Sprintf(" - %s", env.ManagementURL())
 		options = append(options, displayName)
 	}
 
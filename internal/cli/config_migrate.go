@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/tyktech/tyk-cli/internal/config"
+)
+
+// migrateLegacyConfig rewrites a config file in the legacy layout, with top-level
+// dash_url, auth_token and org_id keys, into a "default" environment, keeping the
+// original next to it as cli.toml.bak. Failing to migrate is only reported on w: the
+// legacy layout still loads.
+func migrateLegacyConfig(w io.Writer) {
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return
+	}
+	env, err := manager.LegacyEnvironment()
+	if err != nil || env == nil {
+		return
+	}
+
+	file := manager.ConfigFileUsed()
+	original, err := os.ReadFile(file)
+	if err == nil {
+		err = os.WriteFile(file+".bak", original, 0600)
+	}
+	if err == nil {
+		migrated := config.NewManager()
+		migrated.SaveEnvironment(env, true)
+		err = saveConfigToFile(migrated)
+	}
+	if err != nil {
+		color.New(color.FgYellow).Fprintf(w, "⚠ Could not migrate the legacy config file %s: %v\n", file, err)
+		return
+	}
+	fmt.Fprintf(w, "Migrated %s to the environments format as environment 'default' (original kept as %s.bak)\n", file, file)
+}
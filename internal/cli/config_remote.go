@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewConfigSyncRemoteCommand creates the 'tyk config sync-remote' command
+func NewConfigSyncRemoteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync-remote [url]",
+		Short: "Sync environment definitions shared by your team",
+		Long: `Fetch the environments a platform team maintains centrally and merge them into
+your configuration, so everyone targets the same Dashboards with the same settings.
+
+The URL is an HTTPS link to a YAML, JSON or TOML file, or a Git repository
+(git+https://..., git@host:org/repo.git or any URL ending in .git) holding the file at
+--path (default ` + config.DefaultRemotePath + `). The file has the layout of the config file,
+without secrets:
+
+  default_environment: staging
+  environments:
+    staging:
+      dashboard_url: https://dashboard.staging.example.com
+      org_id: 5e9d9544a1dcd60001d0ed20
+      listen_path_prefix: /staging
+    production:
+      dashboard_url: https://dashboard.example.com
+      org_id: 5e9d9544a1dcd60001d0ed20
+
+Each shared environment replaces the local one of that name, which keeps its own auth
+token, proxy and certificates, and its own headers and env variables over the shared
+ones; add a token with 'tyk config use <env>' and 'tyk config set auth-token <token>'.
+Files carrying auth tokens, client keys or header values other than references to
+variables, such as ${PROXY_TOKEN}, are refused. The URL is remembered, so later syncs
+need no arguments. Shared environments dropped from the file are kept unless --prune
+is given.
+
+A header in the file may only reference a variable the environment lists in its env
+block. TLS verification stays as set locally. When the file changes the Dashboard or
+Gateway URL of an environment that has your auth token, the sync asks before sending
+the token there; without a terminal it is refused unless --allow-url-change is given.
+
+Examples:
+  tyk config sync-remote https://platform.example.com/tyk/environments.yaml
+  tyk config sync-remote git@github.com:acme/platform-config.git --path tyk/environments.yaml
+  tyk config sync-remote --prune
+  tyk config sync-remote --dry-run`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runConfigSyncRemote,
+	}
+
+	cmd.Flags().String("path", "", "Path of the file in a Git repository (default: "+config.DefaultRemotePath+")")
+	cmd.Flags().String("ref", "", "Branch or tag of a Git repository (default: its default branch)")
+	cmd.Flags().Bool("prune", false, "Remove shared environments that are no longer in the remote config")
+	cmd.Flags().Bool("dry-run", false, "Show what would change without saving")
+	cmd.Flags().Bool("allow-url-change", false, "Keep local auth tokens of environments whose Dashboard or Gateway URL the remote config changes")
+
+	return cmd
+}
+
+func runConfigSyncRemote(cmd *cobra.Command, args []string) error {
+	prune, _ := cmd.Flags().GetBool("prune")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	allowURLChange, _ := cmd.Flags().GetBool("allow-url-change")
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+
+	source := &types.RemoteSource{}
+	if cfg.Remote != nil {
+		*source = *cfg.Remote
+	}
+	if len(args) > 0 && args[0] != source.URL {
+		source = &types.RemoteSource{URL: args[0]}
+	}
+	if cmd.Flags().Changed("path") {
+		source.Path, _ = cmd.Flags().GetString("path")
+	}
+	if cmd.Flags().Changed("ref") {
+		source.Ref, _ = cmd.Flags().GetString("ref")
+	}
+	if source.URL == "" {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "no remote config yet; give its URL: tyk config sync-remote <url>"}
+	}
+	if (source.Path != "" || source.Ref != "") && !config.IsGitRemote(source.URL) {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--path and --ref apply to Git repositories"}
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+	defer cancel()
+	data, err := config.FetchRemote(ctx, source)
+	if err != nil {
+		return err
+	}
+	remote, err := config.ParseRemote(source, data)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	sync := config.MergeRemote(cfg, remote, prune)
+	cfg.Remote = source
+	if len(sync.Redirected) > 0 && !allowURLChange && !dryRun {
+		ok, err := confirmRedirect(cmd, cfg, sync.Redirected)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Sync cancelled")
+			return nil
+		}
+	}
+	if !dryRun {
+		if err := saveConfigToFile(manager); err != nil {
+			return err
+		}
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, struct {
+			Remote string `json:"remote"`
+			DryRun bool   `json:"dry_run"`
+			*config.RemoteSync
+		}{source.URL, dryRun, sync})
+	}
+	printRemoteSync(source.URL, sync, dryRun)
+	return nil
+}
+
+// confirmRedirect asks before saving environments that would send their local auth
+// token to a URL the remote config changed. Without a terminal the sync is refused:
+// only --allow-url-change lets it through unattended.
+func confirmRedirect(cmd *cobra.Command, cfg *types.Config, redirected []string) (bool, error) {
+	if err := requireTerminal("confirmation", "the remote config changes the URL of "+strings.Join(redirected, ", ")+"; check it and pass --allow-url-change to send your auth tokens there"); err != nil {
+		return false, err
+	}
+	for _, name := range redirected {
+		color.New(color.FgYellow).Printf("⚠ The remote config moves %s to %s\n", name, cfg.Environments[name].ManagementURL())
+	}
+	return confirm(cmd, "Send your auth tokens to the new URLs?")
+}
+
+// printRemoteSync lists the environments a sync added, updated and removed, and what
+// is left for the user to do
+func printRemoteSync(remoteURL string, sync *config.RemoteSync, dryRun bool) {
+	synced := len(sync.Added) + len(sync.Updated) + len(sync.Unchanged)
+	if dryRun {
+		fmt.Printf("Would sync %d environment(s) from %s (dry run, nothing saved)\n", synced, remoteURL)
+	} else {
+		color.New(color.FgGreen).Printf("✓ Synced %d environment(s) from %s\n", synced, remoteURL)
+	}
+	for _, name := range sync.Added {
+		color.New(color.FgGreen).Printf("  + %s\n", name)
+	}
+	for _, name := range sync.Updated {
+		color.New(color.FgYellow).Printf("  ~ %s\n", name)
+	}
+	for _, name := range sync.Removed {
+		color.New(color.FgRed).Printf("  - %s\n", name)
+	}
+	if sync.DefaultEnvironment != "" {
+		fmt.Printf("Default environment: %s\n", sync.DefaultEnvironment)
+	}
+
+	yellow := color.New(color.FgYellow)
+	if len(sync.Stale) > 0 {
+		yellow.Printf("⚠ No longer shared: %s (remove with --prune)\n", strings.Join(sync.Stale, ", "))
+	}
+	if dryRun && len(sync.Redirected) > 0 {
+		yellow.Printf("⚠ The remote config changes the URL of %s: syncing asks before sending your auth tokens there (or pass --allow-url-change)\n", strings.Join(sync.Redirected, ", "))
+	}
+	for _, name := range sync.MissingToken {
+		yellow.Printf("⚠ %s has no auth token: tyk config use %s && tyk config set auth-token <token>\n", name, name)
+	}
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const sharedEnvironmentsFile = `default_environment: staging
+environments:
+  staging:
+    dashboard_url: https://dashboard.staging.example.com
+    org_id: org
+  production:
+    dashboard_url: https://dashboard.example.com
+    org_id: org
+    env:
+      TYK_TEAM: platform
+    headers:
+      X-Team: ${TYK_TEAM}
+    promote_from:
+      staging:
+        listen_path_prefixes:
+          - from: /staging
+            to: /
+        upstream_hosts:
+          - from: users.staging.internal
+            to: users.internal
+`
+
+func loadSavedConfig(t *testing.T) *types.Config {
+	t.Helper()
+	manager := config.NewManager()
+	require.NoError(t, manager.LoadConfig())
+	return manager.GetConfig()
+}
+
+func TestConfigSyncRemote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(sharedEnvironmentsFile))
+	}))
+	defer server.Close()
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "production",
+		Environments: map[string]*types.Environment{
+			"production": {Name: "production", DashboardURL: "https://old.example.com", AuthToken: "prod-token", OrgID: "org"},
+		},
+	})
+	remoteURL := server.URL + "/environments.yaml"
+
+	out, err := runRootCommand(t, "config", "sync-remote", remoteURL, "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("config-sync-remote", out), string(out))
+	assert.Equal(t, "https://old.example.com", loadSavedConfig(t).Environments["production"].DashboardURL)
+
+	var result config.RemoteSync
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, []string{"production"}, result.Redirected)
+
+	// Moving an environment with a local token to a new URL needs approval
+	_, err = runRootCommand(t, "config", "sync-remote", remoteURL, "--yes")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "--allow-url-change")
+	assert.Equal(t, "https://old.example.com", loadSavedConfig(t).Environments["production"].DashboardURL)
+
+	out, err = runRootCommand(t, "config", "sync-remote", remoteURL, "--allow-url-change", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, []string{"staging"}, result.Added)
+	assert.Equal(t, []string{"production"}, result.Updated)
+	assert.Equal(t, []string{"staging"}, result.MissingToken)
+
+	cfg := loadSavedConfig(t)
+	assert.Equal(t, "production", cfg.DefaultEnvironment)
+	require.NotNil(t, cfg.Remote)
+	assert.Equal(t, remoteURL, cfg.Remote.URL)
+	production := cfg.Environments["production"]
+	assert.Equal(t, "https://dashboard.example.com", production.DashboardURL)
+	assert.Equal(t, "prod-token", production.AuthToken)
+	assert.True(t, production.Shared)
+	assert.Equal(t, map[string]string{"x-team": "${TYK_TEAM}"}, production.Headers)
+	assert.Equal(t, map[string]string{"tyk_team": "platform"}, production.Env)
+	require.Contains(t, production.PromoteFrom, "staging")
+	assert.Equal(t, []types.Rewrite{{From: "/staging", To: "/"}}, production.PromoteFrom["staging"].ListenPathPrefixes)
+	assert.Equal(t, []types.Rewrite{{From: "users.staging.internal", To: "users.internal"}}, production.PromoteFrom["staging"].UpstreamHosts)
+
+	// The URL is remembered
+	out, err = runRootCommand(t, "config", "sync-remote", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, []string{"production", "staging"}, result.Unchanged)
+
+	_, err = runRootCommand(t, "config", "sync-remote", "http://dashboard.example.com/environments.yaml")
+	assert.ErrorContains(t, err, "must use HTTPS")
+}
+
+func TestConfigSyncRemote_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := filepath.Join(t.TempDir(), "platform")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "tyk"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "tyk", "environments.yaml"), []byte(sharedEnvironmentsFile), 0644))
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "Add environments"},
+	} {
+		git := exec.Command("git", args...)
+		git.Dir = repo
+		out, err := git.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	writeTestConfigFile(t, &types.Config{})
+
+	_, err := runRootCommand(t, "config", "sync-remote", "git+file://"+repo, "--path", "tyk/environments.yaml")
+	require.NoError(t, err)
+	cfg := loadSavedConfig(t)
+	assert.Equal(t, "staging", cfg.DefaultEnvironment)
+	assert.Contains(t, cfg.Environments, "production")
+	assert.Equal(t, "tyk/environments.yaml", cfg.Remote.Path)
+}
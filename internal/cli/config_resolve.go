@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/logging"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// Where a resolved setting came from, highest precedence first
+const (
+	sourceFlag       = "flag"
+	sourceEnv        = "env"
+	sourceUserConfig = "user config"
+	sourceDefault    = "default"
+)
+
+// resolvedSetting is one effective setting and where its value came from
+type resolvedSetting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// One of flag, env, user config or default
+	Source string `json:"source"`
+	// The flag, environment variable or config file that set the value
+	Origin string `json:"origin,omitempty"`
+}
+
+// NewConfigResolveCommand creates the 'tyk config resolve' command
+func NewConfigResolveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resolve",
+		Short: "Show the effective configuration and where each value comes from",
+		Long: `Print the settings the next command would run with and where each one came from,
+highest precedence first:
+
+  flag         --env, --dash-url, --auth-token, --org-id, --timeout, --output, -v
+  env          TYK_ENV, TYK_DASH_URL, TYK_GATEWAY_URL, TYK_CLI_DEBUG, or
+               TYK_ENVIRONMENTS_<ENV>_<KEY> overriding a key of the config file
+  user config  ~/.config/tyk/cli.toml (including an environment's [env] variables)
+  default      built-in defaults
+
+Give the same flags as the command you are debugging to see their effect. The auth
+token is masked.
+
+Examples:
+  tyk config resolve
+  tyk config resolve --env staging --timeout 2m
+  tyk config resolve -o json`,
+		Args: cobra.NoArgs,
+		RunE: runConfigResolve,
+	}
+}
+
+func runConfigResolve(cmd *cobra.Command, args []string) error {
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+	configFile := manager.ConfigFileUsed()
+	// Without environments in the file, LoadConfig builds "default" from TYK_DASH_URL,
+	// TYK_AUTH_TOKEN and TYK_ORG_ID or the legacy top-level keys of the file
+	legacy := !manager.InConfigFile("environments")
+
+	settings := []resolvedSetting{resolveEnvironmentName(cmd, manager, configFile)}
+	envName, _ := cmd.Flags().GetString("env")
+	if envName == "" {
+		envName = os.Getenv(config.EnvEnvName)
+	}
+	if envName != "" {
+		if err := manager.SetDefaultEnvironment(envName); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+	}
+	dashURL, _ := cmd.Flags().GetString("dash-url")
+	authToken, _ := cmd.Flags().GetString("auth-token")
+	orgID, _ := cmd.Flags().GetString("org-id")
+	manager.SetFromFlags(dashURL, authToken, orgID)
+	if err := applyTimeoutFlag(cmd, cfg); err != nil {
+		return err
+	}
+
+	env, err := cfg.GetActiveEnvironment()
+	if err != nil {
+		env = &types.Environment{}
+	}
+	settings[0].Value = env.Name
+
+	r := &settingResolver{cmd: cmd, manager: manager, configFile: configFile, env: env, legacy: legacy}
+	envType := env.Type
+	if envType == "" {
+		envType = "dashboard"
+	}
+	timeout := env.Timeout
+	if timeout == "" {
+		timeout = client.DefaultTimeout.String()
+	}
+	settings = append(settings,
+		r.resolve("type", envType, ""),
+		r.resolve("dashboard_url", env.DashboardURL, "dash-url"),
+		r.resolveGatewayURL(),
+		r.resolve("auth_token", maskToken(env.AuthToken), "auth-token"),
+		r.resolve("org_id", env.OrgID, "org-id"),
+		r.resolve("timeout", timeout, "timeout"),
+		r.resolve("proxy_url", env.ProxyURL, ""),
+		r.resolve("ca_cert", env.CACert, ""),
+		r.resolve("client_cert", env.ClientCert, ""),
+		r.resolve("client_key", env.ClientKey, ""),
+		r.resolve("insecure_skip_verify", strconv.FormatBool(env.InsecureSkipVerify), ""),
+		r.resolve("tyk_version", env.TykVersion, ""),
+		r.resolve("listen_path_prefix", env.ListenPathPrefix, ""),
+		r.resolve("signing", describeSigning(env.Signing), ""),
+		resolveOutput(cmd, format),
+		resolveVerbosity(cmd),
+	)
+
+	if format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"environment": env.Name,
+			"config_file": configFile,
+			"settings":    settings,
+		})
+	}
+	if configFile == "" {
+		fmt.Println("Config file: (none)")
+	} else {
+		fmt.Printf("Config file: %s\n", configFile)
+	}
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, s := range settings {
+		value, source := s.Value, s.Source
+		if value == "" {
+			value = "-"
+		}
+		if s.Origin != "" && s.Origin != configFile {
+			source += " (" + s.Origin + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Key, value, source)
+	}
+	return w.Flush()
+}
+
+// settingResolver attributes the settings of the active environment
+type settingResolver struct {
+	cmd        *cobra.Command
+	manager    *config.Manager
+	configFile string
+	env        *types.Environment
+	legacy     bool
+}
+
+// legacyKeys are the top-level keys the default environment is built from when the
+// config file has no environments
+var legacyKeys = map[string]string{
+	"dashboard_url": "dash_url",
+	"auth_token":    "auth_token",
+	"org_id":        "org_id",
+}
+
+// resolve attributes value, the setting key of the active environment, to flag (the
+// global flag overriding it, if any), env, user config or default
+func (r *settingResolver) resolve(key, value, flag string) resolvedSetting {
+	s := resolvedSetting{Key: key, Value: value}
+	if flag != "" && r.cmd.Flags().Changed(flag) {
+		s.Source, s.Origin = sourceFlag, "--"+flag
+		return s
+	}
+
+	if legacyKey, ok := legacyKeys[key]; ok && r.legacy {
+		if name := config.EnvVarName(legacyKey); os.Getenv(name) != "" {
+			s.Source, s.Origin = sourceEnv, name
+		} else if r.manager.InConfigFile(legacyKey) {
+			s.Source, s.Origin = sourceUserConfig, r.configFile
+		} else {
+			s.Source = sourceDefault
+		}
+		return s
+	}
+
+	// Environment variables only override keys that are present in the file
+	fileKey := "environments." + r.env.Name + "." + key
+	if r.env.Name == "" || !r.manager.InConfigFile(fileKey) {
+		s.Source = sourceDefault
+		return s
+	}
+	if name := config.EnvVarName(fileKey); os.Getenv(name) != "" {
+		s.Source, s.Origin = sourceEnv, name
+		return s
+	}
+	s.Source, s.Origin = sourceUserConfig, r.configFile
+	return s
+}
+
+// describeSigning summarises request signing settings without the secret itself
+func describeSigning(signing *types.RequestSigning) string {
+	if signing == nil {
+		return ""
+	}
+	key := ""
+	if signing.KeyID != "" {
+		key = ", key " + signing.KeyID
+	}
+	return signing.SigningAlgorithm() + " (secret " + signing.Secret + key + ")"
+}
+
+// resolveGatewayURL attributes gateway_url, which TYK_GATEWAY_URL overrides from the
+// shell or the environment's own variables
+func (r *settingResolver) resolveGatewayURL() resolvedSetting {
+	if value := os.Getenv(config.EnvGatewayURL); value != "" {
+		return resolvedSetting{Key: "gateway_url", Value: value, Source: sourceEnv, Origin: config.EnvGatewayURL}
+	}
+	for name, value := range r.env.Env {
+		if strings.ToUpper(name) == config.EnvGatewayURL && value != "" {
+			return resolvedSetting{Key: "gateway_url", Value: value, Source: sourceUserConfig, Origin: "environments." + r.env.Name + ".env." + name}
+		}
+	}
+	return r.resolve("gateway_url", r.env.GatewayURL, "")
+}
+
+// resolveEnvironmentName attributes the environment selection; the caller fills in
+// the value once the environment is resolved
+func resolveEnvironmentName(cmd *cobra.Command, manager *config.Manager, configFile string) resolvedSetting {
+	s := resolvedSetting{Key: "environment"}
+	switch name := config.EnvVarName("default_environment"); {
+	case cmd.Flags().Changed("env"):
+		s.Source, s.Origin = sourceFlag, "--env"
+	case os.Getenv(config.EnvEnvName) != "":
+		s.Source, s.Origin = sourceEnv, config.EnvEnvName
+	case os.Getenv(name) != "":
+		s.Source, s.Origin = sourceEnv, name
+	case manager.InConfigFile("default_environment"):
+		s.Source, s.Origin = sourceUserConfig, configFile
+	default:
+		s.Source = sourceDefault
+	}
+	return s
+}
+
+func resolveOutput(cmd *cobra.Command, format types.OutputFormat) resolvedSetting {
+	s := resolvedSetting{Key: "output", Value: string(format), Source: sourceDefault}
+	if cmd.Flags().Changed("output") {
+		s.Source, s.Origin = sourceFlag, "--output"
+	} else if cmd.Flags().Changed("json") {
+		s.Source, s.Origin = sourceFlag, "--json"
+	}
+	return s
+}
+
+func resolveVerbosity(cmd *cobra.Command) resolvedSetting {
+	verbose, _ := cmd.Flags().GetCount("verbose")
+	fromEnv := logging.LevelFromEnv()
+	s := resolvedSetting{Key: "verbose", Value: strconv.Itoa(max(verbose, fromEnv)), Source: sourceDefault}
+	if verbose > 0 && verbose >= fromEnv {
+		s.Source, s.Origin = sourceFlag, "--verbose"
+	} else if fromEnv > 0 {
+		s.Source, s.Origin = sourceEnv, logging.EnvDebug
+	}
+	return s
+}
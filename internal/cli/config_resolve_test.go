@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestConfigResolve(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging":    {Name: "staging", DashboardURL: "https://staging.example.com", AuthToken: "staging-token-1234", OrgID: "org"},
+			"production": {Name: "production", DashboardURL: "https://example.com", AuthToken: "production-token", OrgID: "org", Timeout: "1m"},
+		},
+	})
+	t.Setenv(config.EnvEnvName, "")
+	t.Setenv(config.EnvGatewayURL, "https://gw.example.com")
+	t.Setenv("TYK_ENVIRONMENTS_PRODUCTION_ORG_ID", "other-org")
+
+	resolve := func(args ...string) (map[string]resolvedSetting, string) {
+		t.Helper()
+		out, err := runRootCommand(t, append([]string{"config", "resolve", "-o", "json"}, args...)...)
+		require.NoError(t, err)
+		require.NoError(t, outputschema.Validate("config-resolve", out), string(out))
+		var result struct {
+			Environment string            `json:"environment"`
+			Settings    []resolvedSetting `json:"settings"`
+		}
+		require.NoError(t, json.Unmarshal(out, &result))
+		settings := map[string]resolvedSetting{}
+		for _, s := range result.Settings {
+			settings[s.Key] = s
+		}
+		return settings, result.Environment
+	}
+
+	settings, env := resolve()
+	assert.Equal(t, "staging", env)
+	assert.Equal(t, sourceUserConfig, settings["environment"].Source)
+	assert.Equal(t, resolvedSetting{Key: "dashboard_url", Value: "https://staging.example.com", Source: sourceUserConfig, Origin: settings["dashboard_url"].Origin}, settings["dashboard_url"])
+	assert.Equal(t, "stag****1234", settings["auth_token"].Value)
+	assert.Equal(t, resolvedSetting{Key: "timeout", Value: "30s", Source: sourceDefault}, settings["timeout"])
+	assert.Equal(t, resolvedSetting{Key: "gateway_url", Value: "https://gw.example.com", Source: sourceEnv, Origin: config.EnvGatewayURL}, settings["gateway_url"])
+	assert.Equal(t, resolvedSetting{Key: "output", Value: "json", Source: sourceFlag, Origin: "--output"}, settings["output"])
+
+	settings, env = resolve("--env", "production", "--dash-url", "https://override.example.com", "--timeout", "2m")
+	assert.Equal(t, "production", env)
+	assert.Equal(t, resolvedSetting{Key: "environment", Value: "production", Source: sourceFlag, Origin: "--env"}, settings["environment"])
+	assert.Equal(t, resolvedSetting{Key: "dashboard_url", Value: "https://override.example.com", Source: sourceFlag, Origin: "--dash-url"}, settings["dashboard_url"])
+	assert.Equal(t, resolvedSetting{Key: "timeout", Value: "2m0s", Source: sourceFlag, Origin: "--timeout"}, settings["timeout"])
+	assert.Equal(t, resolvedSetting{Key: "org_id", Value: "other-org", Source: sourceEnv, Origin: "TYK_ENVIRONMENTS_PRODUCTION_ORG_ID"}, settings["org_id"])
+
+	_, err := runRootCommand(t, "config", "resolve", "--env", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
+
+func TestConfigResolve_EnvironmentVariablesOnly(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{})
+	t.Setenv(config.EnvEnvName, "")
+	t.Setenv(config.EnvDashURL, "http://localhost:3000")
+	t.Setenv(config.EnvAuthToken, "token")
+
+	out, err := runRootCommand(t, "config", "resolve", "-o", "json")
+	require.NoError(t, err)
+	var result struct {
+		Settings []resolvedSetting `json:"settings"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	settings := map[string]resolvedSetting{}
+	for _, s := range result.Settings {
+		settings[s.Key] = s
+	}
+	assert.Equal(t, resolvedSetting{Key: "dashboard_url", Value: "http://localhost:3000", Source: sourceEnv, Origin: config.EnvDashURL}, settings["dashboard_url"])
+	assert.Equal(t, sourceEnv, settings["auth_token"].Source)
+	assert.Equal(t, sourceDefault, settings["org_id"].Source)
+}
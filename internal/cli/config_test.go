@@ -2,12 +2,15 @@ package cli
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/config"
 	"github.com/tyktech/tyk-cli/pkg/types"
 )
 
@@ -124,7 +127,7 @@ func TestNewConfigCommand(t *testing.T) {
 	
 	// Check subcommands
 	subcommands := cmd.Commands()
-	assert.Len(t, subcommands, 6)
+	assert.Len(t, subcommands, 10)
 	
 	var cmdNames []string
 	for _, subcmd := range subcommands {
@@ -137,6 +140,10 @@ func TestNewConfigCommand(t *testing.T) {
 	assert.Contains(t, cmdNames, "add <environment-name>")
 	assert.Contains(t, cmdNames, "set") 
 	assert.Contains(t, cmdNames, "remove <environment-name>")
+	assert.Contains(t, cmdNames, "sync-remote [url]")
+	assert.Contains(t, cmdNames, "resolve")
+	assert.Contains(t, cmdNames, "export [env...]")
+	assert.Contains(t, cmdNames, "import <file>")
 }
 
 func TestNewInitCommand(t *testing.T) {
@@ -200,4 +207,127 @@ func TestConfigSetValidation(t *testing.T) {
 	err := cmd.Execute()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "at least one configuration value must be provided")
-}
\ No newline at end of file
+}
+func TestGenerateTOMLConfig_GatewayEnvironment(t *testing.T) {
+	config := &types.Config{
+		DefaultEnvironment: "oss",
+		Environments: map[string]*types.Environment{
+			"oss": {
+				Name:       "oss",
+				Type:       types.EnvTypeGateway,
+				GatewayURL: "http://localhost:8080",
+				AuthToken:  "secret",
+			},
+		},
+	}
+
+	toml := generateTOMLConfigUnified(config)
+
+	assert.Contains(t, toml, `type = "gateway"`)
+	assert.Contains(t, toml, `gateway_url = "http://localhost:8080"`)
+	assert.NoError(t, config.Validate())
+}
+
+func TestGenerateTOMLConfig_TransportSettings(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "corp",
+		Environments: map[string]*types.Environment{
+			"corp": {
+				Name:               "corp",
+				DashboardURL:       "https://dashboard.corp",
+				AuthToken:          "token",
+				OrgID:              "org",
+				ProxyURL:           "http://proxy.corp:8080",
+				CACert:             "/etc/ssl/corp-root.pem",
+				ClientCert:         "/etc/tyk/client.pem",
+				ClientKey:          "/etc/tyk/client-key.pem",
+				InsecureSkipVerify: true,
+			},
+		},
+	})
+
+	manager := config.NewManager()
+	require.NoError(t, manager.LoadConfig())
+	env, err := manager.GetEnvironment("corp")
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.corp:8080", env.ProxyURL)
+	assert.Equal(t, "/etc/ssl/corp-root.pem", env.CACert)
+	assert.Equal(t, "/etc/tyk/client.pem", env.ClientCert)
+	assert.Equal(t, "/etc/tyk/client-key.pem", env.ClientKey)
+	assert.True(t, env.InsecureSkipVerify)
+}
+
+func TestGenerateTOMLConfig_PromotionRules(t *testing.T) {
+	rules := &types.PromotionRules{
+		ListenPathPrefixes: []types.Rewrite{{From: "/staging", To: "/"}},
+		Domains:            []types.Rewrite{{From: "staging.example.com", To: "example.com"}},
+		UpstreamHosts:      []types.Rewrite{{From: "users.staging.internal", To: "users.internal"}, {From: "orders.staging.internal", To: "orders.internal"}},
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "production",
+		Environments: map[string]*types.Environment{
+			"production": {
+				Name:         "production",
+				DashboardURL: "https://dashboard.example.com",
+				AuthToken:    "token",
+				OrgID:        "org",
+				PromoteFrom:  map[string]*types.PromotionRules{"staging": rules},
+			},
+		},
+	})
+
+	// The rules survive being saved and loaded again
+	env := loadSavedConfig(t).Environments["production"]
+	require.NotNil(t, env)
+	assert.Equal(t, map[string]*types.PromotionRules{"staging": rules}, env.PromoteFrom)
+}
+
+func TestConfigSetSigning(t *testing.T) {
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get("Signature")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apis":[]}`))
+	}))
+	defer server.Close()
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "hardened",
+		Environments: map[string]*types.Environment{
+			"hardened": {Name: "hardened", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	t.Setenv("DASHBOARD_SIGNING_SECRET", "shared-secret")
+
+	_, err := runRootCommand(t, "config", "set", "--signing-secret", "env:DASHBOARD_SIGNING_SECRET", "--signing-key-id", "cli", "--signing-algorithm", "hmac-sha512")
+	require.NoError(t, err)
+	assert.Equal(t, &types.RequestSigning{Algorithm: "hmac-sha512", KeyID: "cli", Secret: "env:DASHBOARD_SIGNING_SECRET"}, loadSavedConfig(t).Environments["hardened"].Signing)
+
+	_, err = runRootCommand(t, "api", "list")
+	require.NoError(t, err)
+	assert.Contains(t, signature, `keyId="cli",algorithm="hmac-sha512",headers="(request-target) host date"`)
+
+	_, err = runRootCommand(t, "config", "set", "--signing-secret", "shared-secret")
+	assert.ErrorContains(t, err, "env:NAME or file:PATH")
+
+	_, err = runRootCommand(t, "config", "set", "--no-signing")
+	require.NoError(t, err)
+	assert.Nil(t, loadSavedConfig(t).Environments["hardened"].Signing)
+}
+
+func TestConfigRequestTimeout(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging": {Name: "staging", DashboardURL: "https://staging.example.com", AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "config", "set", "--request-timeout", "2m")
+	require.NoError(t, err)
+	assert.Equal(t, "2m", loadSavedConfig(t).Environments["staging"].Timeout)
+
+	// The global --timeout is a duration for this command only, never saved
+	_, err = runRootCommand(t, "config", "add", "production", "--dashboard-url", "https://prod.example.com", "--auth-token", "token", "--org-id", "org", "--timeout", "5s")
+	require.NoError(t, err)
+	assert.Empty(t, loadSavedConfig(t).Environments["production"].Timeout)
+}
@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// NewConfigExportCommand creates the 'tyk config export' command
+func NewConfigExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [env...]",
+		Short: "Export environment definitions to share with your team",
+		Long: `Write the definitions of your environments (all of them, or the ones named) in the
+layout of the config file, for teammates to load with 'tyk config import'.
+
+Secrets are never exported: each auth token, client key, header value and env
+variable value is replaced by a placeholder naming the variable that supplies it on
+import, e.g.
+
+  auth_token: ${TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN}
+  headers:
+    X-Proxy-Auth: ${TYK_ENVIRONMENTS_STAGING_HEADERS_X_PROXY_AUTH}
+
+Header values that only reference a variable, such as ${PROXY_TOKEN}, are kept.
+
+The format follows the extension of --out: YAML (the default), JSON or TOML. Without
+--out the definitions are printed, as JSON with -o json and YAML otherwise.
+
+Examples:
+  tyk config export --out envs.yaml
+  tyk config export staging production --out envs.json
+  tyk config export -o json`,
+		RunE: runConfigExport,
+	}
+
+	cmd.Flags().String("out", "", "File to write the definitions to (default: stdout)")
+
+	return cmd
+}
+
+// NewConfigImportCommand creates the 'tyk config import' command
+func NewConfigImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import environment definitions exported by 'tyk config export'",
+		Long: `Load environment definitions from a YAML, JSON or TOML file in the layout of the
+config file, such as one written by 'tyk config export'; give - to read YAML or JSON
+from stdin.
+
+Each imported environment replaces the local one of that name. Secrets given as
+placeholders such as ${TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN} are read from that
+variable when it is set; otherwise the local environment keeps its own secret, and
+environments left without an auth token are reported. Header and env values are
+resolved the same way; a header still unresolved keeps its placeholder, read from the
+variable on every request, and an env variable still unresolved is left out.
+
+By default the file replaces your configuration: environments it does not define are
+removed, after confirmation, and its default environment is used. With --merge they
+are kept, and so is your default environment.
+
+Examples:
+  tyk config import envs.yaml
+  tyk config import envs.yaml --merge
+  TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN=... tyk config import envs.yaml --merge
+  tyk config import envs.yaml --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runConfigImport,
+	}
+
+	cmd.Flags().Bool("merge", false, "Keep environments the file does not define, and the current default environment")
+	cmd.Flags().Bool("dry-run", false, "Show what would change without saving")
+
+	return cmd
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	outFile, _ := cmd.Flags().GetString("out")
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	exported, err := config.ExportEnvironments(manager.GetConfig(), args)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	fileFormat := "yaml"
+	if outFile != "" {
+		fileFormat = strings.TrimPrefix(strings.ToLower(filepath.Ext(outFile)), ".")
+	} else if format == types.OutputJSON {
+		fileFormat = "json"
+	}
+	data, err := marshalEnvironments(exported, fileFormat)
+	if err != nil {
+		return err
+	}
+	if outFile == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(outFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outFile, err)
+	}
+	color.New(color.FgGreen).Printf("✓ Exported %d environment(s) to %s\n", len(exported.Environments), outFile)
+	return nil
+}
+
+// marshalEnvironments writes environment definitions as YAML, JSON or TOML; other
+// formats are written as YAML
+func marshalEnvironments(cfg *types.Config, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "toml":
+		return []byte(generateTOMLConfigUnified(cfg)), nil
+	default:
+		return yaml.Marshal(cfg)
+	}
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	merge, _ := cmd.Flags().GetBool("merge")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	file := args[0]
+	var data []byte
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read %s: %v", file, err)}
+	}
+	imported, err := config.ParseImport(file, data)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := manager.GetConfig()
+	result := config.ImportEnvironments(cfg, imported, merge)
+
+	if !dryRun {
+		if len(result.Removed) > 0 && !assumeYes(cmd) {
+			fmt.Printf("Importing %s removes the environments it does not define: %s\n", file, strings.Join(result.Removed, ", "))
+			ok, err := confirm(cmd, "Continue? (use --merge to keep them)")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Import cancelled")
+				return nil
+			}
+		}
+		if err := saveConfigToFile(manager); err != nil {
+			return err
+		}
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, struct {
+			File   string `json:"file"`
+			Merge  bool   `json:"merge"`
+			DryRun bool   `json:"dry_run"`
+			*config.EnvironmentImport
+		}{file, merge, dryRun, result})
+	}
+	printEnvironmentImport(file, result, dryRun)
+	return nil
+}
+
+// printEnvironmentImport lists the environments an import added, updated and removed,
+// and the ones still needing an auth token
+func printEnvironmentImport(file string, result *config.EnvironmentImport, dryRun bool) {
+	imported := len(result.Added) + len(result.Updated) + len(result.Unchanged)
+	if dryRun {
+		fmt.Printf("Would import %d environment(s) from %s (dry run, nothing saved)\n", imported, file)
+	} else {
+		color.New(color.FgGreen).Printf("✓ Imported %d environment(s) from %s\n", imported, file)
+	}
+	for _, name := range result.Added {
+		color.New(color.FgGreen).Printf("  + %s\n", name)
+	}
+	for _, name := range result.Updated {
+		color.New(color.FgYellow).Printf("  ~ %s\n", name)
+	}
+	for _, name := range result.Removed {
+		color.New(color.FgRed).Printf("  - %s\n", name)
+	}
+	if result.DefaultEnvironment != "" {
+		fmt.Printf("Default environment: %s\n", result.DefaultEnvironment)
+	}
+	for _, missing := range result.MissingValues {
+		color.New(color.FgYellow).Printf("⚠ %s has no value: set %s and import again\n", missing, missingValueVariable(missing))
+	}
+	for _, name := range result.MissingToken {
+		color.New(color.FgYellow).Printf("⚠ %s has no auth token: set %s and import again, or run tyk config use %s && tyk config set auth-token <token>\n",
+			name, config.EnvVarName("environments."+name+".auth_token"), name)
+	}
+}
+
+// missingValueVariable returns the variable supplying a value reported as missing by
+// an import, given as "<env> <field>.<name>"
+func missingValueVariable(missing string) string {
+	envName, key, _ := strings.Cut(missing, " ")
+	field, name, _ := strings.Cut(key, ".")
+	return strings.Trim(config.ValuePlaceholder(envName, field, name), "${}")
+}
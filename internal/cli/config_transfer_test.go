@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestConfigExportImport(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging":    {Name: "staging", DashboardURL: "https://staging.example.com", AuthToken: "staging-token", OrgID: "org", ListenPathPrefix: "/staging"},
+			"production": {Name: "production", DashboardURL: "https://example.com", AuthToken: "production-token", OrgID: "org", Headers: map[string]string{"x-team": "platform"}},
+		},
+	})
+	t.Setenv(EnvAssumeYes, "1")
+
+	for _, name := range []string{"envs.yaml", "envs.json", "envs.toml"} {
+		file := filepath.Join(t.TempDir(), name)
+		_, err := runRootCommand(t, "config", "export", "--out", file)
+		require.NoError(t, err)
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "staging-token", name)
+		assert.Contains(t, string(data), "${TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN}", name)
+
+		exported, err := config.ParseImport(file, data)
+		require.NoError(t, err, name)
+		assert.Equal(t, "staging", exported.DefaultEnvironment, name)
+		assert.Equal(t, "/staging", exported.Environments["staging"].ListenPathPrefix, name)
+		assert.Equal(t, map[string]string{"x-team": "${TYK_ENVIRONMENTS_PRODUCTION_HEADERS_X_TEAM}"}, exported.Environments["production"].Headers, name)
+	}
+
+	out, err := runRootCommand(t, "config", "export", "production", "-o", "json")
+	require.NoError(t, err)
+	var exported types.Config
+	require.NoError(t, json.Unmarshal(out, &exported))
+	assert.Equal(t, []string{"production"}, sortedKeys(exported.Environments))
+
+	// A teammate with a config of their own imports the definitions
+	envsFile := filepath.Join(t.TempDir(), "envs.yaml")
+	_, err = runRootCommand(t, "config", "export", "--out", envsFile)
+	require.NoError(t, err)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "dev",
+		Environments: map[string]*types.Environment{
+			"dev":        {Name: "dev", DashboardURL: "http://localhost:3000", AuthToken: "dev-token", OrgID: "org"},
+			"production": {Name: "production", DashboardURL: "https://old.example.com", AuthToken: "my-token", OrgID: "org"},
+		},
+	})
+	t.Setenv("TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN", "my-staging-token")
+	t.Setenv("TYK_ENVIRONMENTS_PRODUCTION_HEADERS_X_TEAM", "platform")
+
+	out, err = runRootCommand(t, "config", "import", envsFile, "--merge", "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("config-import", out), string(out))
+	assert.Equal(t, "https://old.example.com", loadSavedConfig(t).Environments["production"].DashboardURL)
+
+	out, err = runRootCommand(t, "config", "import", envsFile, "--merge", "-o", "json")
+	require.NoError(t, err)
+	var result config.EnvironmentImport
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, []string{"staging"}, result.Added)
+	assert.Equal(t, []string{"production"}, result.Updated)
+	assert.Empty(t, result.MissingToken)
+
+	cfg := loadSavedConfig(t)
+	assert.Equal(t, "dev", cfg.DefaultEnvironment)
+	assert.Equal(t, "my-staging-token", cfg.Environments["staging"].AuthToken)
+	assert.Equal(t, "my-token", cfg.Environments["production"].AuthToken)
+	assert.Equal(t, "https://example.com", cfg.Environments["production"].DashboardURL)
+	assert.Equal(t, map[string]string{"x-team": "platform"}, cfg.Environments["production"].Headers)
+
+	out, err = runRootCommand(t, "config", "import", envsFile, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, []string{"dev"}, result.Removed)
+	assert.Equal(t, "staging", result.DefaultEnvironment)
+	cfg = loadSavedConfig(t)
+	assert.NotContains(t, cfg.Environments, "dev")
+	assert.Equal(t, "staging", cfg.DefaultEnvironment)
+
+	_, err = runRootCommand(t, "config", "import", filepath.Join(t.TempDir(), "missing.yaml"))
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv(config.EnvEnvName, "")
+	t.Setenv(config.EnvAuthToken, "")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tyk"), 0755))
+	file := filepath.Join(dir, "tyk", "cli.toml")
+	legacy := "dash_url = \"http://localhost:3000\"\nauth_token = \"legacy-token\"\norg_id = \"org\"\n"
+	require.NoError(t, os.WriteFile(file, []byte(legacy), 0600))
+
+	out, err := runRootCommand(t, "config", "current", "-o", "json")
+	require.NoError(t, err, string(out))
+
+	backup, err := os.ReadFile(file + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, legacy, string(backup))
+	cfg := loadSavedConfig(t)
+	assert.Equal(t, "default", cfg.DefaultEnvironment)
+	assert.Equal(t, &types.Environment{Name: "default", DashboardURL: "http://localhost:3000", AuthToken: "legacy-token", OrgID: "org"}, cfg.Environments["default"])
+
+	// Migrated files are left alone
+	require.NoError(t, os.Remove(file+".bak"))
+	_, err = runRootCommand(t, "config", "current")
+	require.NoError(t, err)
+	assert.NoFileExists(t, file+".bak")
+}
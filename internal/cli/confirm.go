@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"golang.org/x/term"
+)
+
+// EnvAssumeYes answers yes to every confirmation prompt, like --yes ("1" or "true")
+const EnvAssumeYes = "TYK_CLI_ASSUME_YES"
+
+// assumeYes reports whether confirmations are pre-approved with --yes, --force or
+// TYK_CLI_ASSUME_YES
+func assumeYes(cmd *cobra.Command) bool {
+	for _, name := range []string{"yes", "force"} {
+		if value, err := cmd.Flags().GetBool(name); err == nil && value {
+			return true
+		}
+	}
+	value, _ := strconv.ParseBool(os.Getenv(EnvAssumeYes))
+	return value
+}
+
+// requireTerminal fails with exit code 2 when stdin is not a terminal, instead of
+// waiting on input that never comes in CI jobs and pipes; what says what was to be
+// asked, and hint how to proceed without a prompt
+func requireTerminal(what, hint string) error {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+	return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("refusing to prompt for %s: stdin is not a terminal; %s", what, hint)}
+}
+
+// confirm asks question and reports whether the user answered yes. It does not ask
+// when confirmations are pre-approved, and fails without asking when stdin is not a
+// terminal.
+func confirm(cmd *cobra.Command, question string) (bool, error) {
+	if assumeYes(cmd) {
+		return true, nil
+	}
+	if err := requireTerminal("confirmation", "pass --yes or set "+EnvAssumeYes+"=1 to proceed"); err != nil {
+		return false, err
+	}
+	fmt.Printf("%s [y/N]: ", question)
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(response)
+	return response == "y" || response == "yes", nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/trash"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestConfirm_NonInteractive(t *testing.T) {
+	t.Setenv(trash.EnvDir, t.TempDir())
+	t.Setenv(EnvAssumeYes, "")
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "orders-1", "orders", "1.0.0")
+	seedRemoteAPI(t, dashboard, "billing-1", "billing", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	// Test stdin is not a terminal: fail instead of waiting for an answer
+	for _, args := range [][]string{
+		{"api", "delete", "users-1"},
+		{"api", "delete", "--filter", "name=users"},
+		{"config", "use"},
+	} {
+		_, err := runRootCommand(t, args...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, "%v", args)
+		assert.Equal(t, int(types.ExitBadArgs), exitErr.Code, "%v", args)
+		assert.Contains(t, exitErr.Message, "stdin is not a terminal", "%v", args)
+	}
+	assert.Equal(t, 3, dashboard.count())
+
+	_, err := runRootCommand(t, "api", "delete", "users-1", "--force")
+	require.NoError(t, err)
+	t.Setenv(EnvAssumeYes, "1")
+	_, err = runRootCommand(t, "api", "delete", "orders-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, dashboard.count())
+
+	t.Setenv(EnvAssumeYes, "false")
+	_, err = runRootCommand(t, "api", "delete", "billing-1")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, dashboard.count())
+}
@@ -2,7 +2,11 @@ package cli
 
 import (
 	"context"
-	
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
 	"github.com/tyktech/tyk-cli/pkg/types"
 )
 
@@ -12,11 +16,46 @@ type contextKey string
 const (
 	configKey       contextKey = "config"
 	outputFormatKey contextKey = "outputFormat"
+	clientKey       contextKey = "client"
 )
 
-// withConfig adds configuration to the context
+// withConfig adds configuration to the context, and a slot for the API client made from it
 func withConfig(ctx context.Context, config *types.Config) context.Context {
-	return context.WithValue(ctx, configKey, config)
+	ctx = context.WithValue(ctx, configKey, config)
+	return context.WithValue(ctx, clientKey, &sharedClient{config: config})
+}
+
+// sharedClient is the API client of a command, created on first use
+type sharedClient struct {
+	mu     sync.Mutex
+	config *types.Config
+	env    string
+	client *client.Client
+}
+
+// commandClient returns the command's API client for config, so every call a command
+// makes, from any goroutine, shares one client and its connections. A config other
+// than the command's own, or a switch to another environment, gets a new client.
+func commandClient(ctx context.Context, config *types.Config) (*client.Client, error) {
+	shared, ok := ctx.Value(clientKey).(*sharedClient)
+	if !ok || shared.config != config {
+		return client.NewClient(config)
+	}
+	env := ""
+	if active, err := config.GetActiveEnvironment(); err == nil {
+		env = active.Name
+	}
+
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	if shared.client == nil || shared.env != env {
+		c, err := client.NewClient(config)
+		if err != nil {
+			return nil, err
+		}
+		shared.client, shared.env = c, env
+	}
+	return shared.client, nil
 }
 
 // GetConfigFromContext retrieves configuration from context
@@ -27,6 +66,33 @@ func GetConfigFromContext(ctx context.Context) *types.Config {
 	return nil
 }
 
+// apiContext returns the context a command's API calls run under. budget is the command's
+// own time limit; a longer timeout set on the active environment (or with --timeout)
+// extends it so slow uploads are not cut short.
+func apiContext(config *types.Config, budget time.Duration) (context.Context, context.CancelFunc) {
+	if config != nil {
+		if env, err := config.GetActiveEnvironment(); err == nil && env.RequestTimeout() > budget {
+			budget = env.RequestTimeout()
+		}
+	}
+	return context.WithTimeout(context.Background(), budget)
+}
+
+// applyTimeoutFlag overrides the active environment's timeout with --timeout, if given
+func applyTimeoutFlag(cmd *cobra.Command, config *types.Config) error {
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil || timeout == 0 {
+		return nil
+	}
+	if timeout < 0 {
+		return &ExitError{Code: 2, Message: "--timeout must be positive"}
+	}
+	if env, err := config.GetActiveEnvironment(); err == nil {
+		env.Timeout = timeout.String()
+	}
+	return nil
+}
+
 // withOutputFormat adds output format to the context
 func withOutputFormat(ctx context.Context, format types.OutputFormat) context.Context {
 	return context.WithValue(ctx, outputFormatKey, format)
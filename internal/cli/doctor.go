@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// Doctor check outcomes
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// Clock skew thresholds for the doctor clock check
+const (
+	clockSkewWarn = time.Minute
+	clockSkewFail = 5 * time.Minute
+)
+
+// NewDoctorCommand creates the 'tyk doctor' command
+func NewDoctorCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check configuration, connectivity and credentials",
+		Long: `Run a series of checks against the active environment and print what passed, what
+failed and how to fix it:
+
+  config       the config file loads and the environment is valid
+  reachable    the Dashboard (or Gateway) health endpoint answers
+  token        the auth token is accepted
+  org          the configured org ID matches the token's user
+  clock        the local clock agrees with the server's
+  api-list     the token is allowed to list APIs
+
+Checks that depend on an earlier failed check are skipped. Exits 1 if any check fails.
+
+Examples:
+  tyk doctor
+  tyk doctor --env prod
+  tyk doctor -o json`,
+		Args: cobra.NoArgs,
+		RunE: runDoctor,
+	}
+}
+
+// doctorCheck is the outcome of one doctor check
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// doctorReport is the output of 'tyk doctor'
+type doctorReport struct {
+	Environment string        `json:"environment"`
+	Checks      []doctorCheck `json:"checks"`
+	Passed      int           `json:"passed"`
+	Warnings    int           `json:"warnings"`
+	Failed      int           `json:"failed"`
+}
+
+func (r *doctorReport) add(name, status, message, hint string) {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: status, Message: message, Hint: hint})
+	switch status {
+	case checkPass:
+		r.Passed++
+	case checkWarn:
+		r.Warnings++
+	case checkFail:
+		r.Failed++
+	}
+}
+
+func (r *doctorReport) skip(reason string, names ...string) {
+	for _, name := range names {
+		r.add(name, checkSkip, reason, "")
+	}
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	// doctor loads the configuration itself so a broken config is reported as a check
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	report := runDoctorChecks(cmd)
+
+	if format.IsStructured() {
+		if err := writeStructured(format, report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(report)
+	}
+
+	if report.Failed > 0 {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("%d check(s) failed", report.Failed)}
+	}
+	return nil
+}
+
+func runDoctorChecks(cmd *cobra.Command) *doctorReport {
+	report := &doctorReport{Checks: []doctorCheck{}}
+
+	flags := &GlobalFlags{}
+	flags.DashURL, _ = cmd.Flags().GetString("dash-url")
+	flags.AuthToken, _ = cmd.Flags().GetString("auth-token")
+	flags.OrgID, _ = cmd.Flags().GetString("org-id")
+	flags.Env, _ = cmd.Flags().GetString("env")
+	flags.Output, _ = cmd.Flags().GetString("output")
+	flags.JSON, _ = cmd.Flags().GetBool("json")
+
+	if err := initConfig(cmd, flags); err != nil {
+		report.add("config", checkFail, err.Error(), "run 'tyk init' to create a configuration, or 'tyk config list' to inspect it")
+		report.skip("configuration failed", "reachable", "token", "org", "clock", "api-list")
+		return report
+	}
+	config := GetConfigFromContext(cmd.Context())
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		report.add("config", checkFail, err.Error(), "run 'tyk config use <name>' to choose an environment")
+		report.skip("configuration failed", "reachable", "token", "org", "clock", "api-list")
+		return report
+	}
+	report.Environment = env.Name
+	report.add("config", checkPass, fmt.Sprintf("environment %s (%s) at %s", env.Name, envTypeName(env), env.ManagementURL()), "")
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		report.add("reachable", checkFail, err.Error(), transportHint(err))
+		report.skip("not reachable", "token", "org", "clock", "api-list")
+		return report
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	health, err := c.CheckHealth(ctx)
+	if err != nil {
+		report.add("reachable", checkFail, err.Error(), transportHint(err))
+		report.skip("not reachable", "token", "org", "clock", "api-list")
+		return report
+	}
+	report.add("reachable", checkPass, fmt.Sprintf("%s responded in %s", env.ManagementURL(), health.Latency.Round(time.Millisecond)), "")
+
+	// The token and org checks need the user for dashboards; gateways only have a secret
+	var listErr error
+	listed := false
+	if c.IsGateway() {
+		_, listErr = c.ListAPIsDashboard(ctx, 1)
+		listed = true
+		switch {
+		case listErr == nil:
+			report.add("token", checkPass, "gateway secret accepted", "")
+		case errors.Is(listErr, client.ErrUnauthorized):
+			report.add("token", checkFail, "gateway secret rejected", "set auth_token to the gateway's 'secret' from tyk.conf")
+		default:
+			report.add("token", checkFail, listErr.Error(), "")
+		}
+		report.add("org", checkSkip, "gateway environments have no organisation", "")
+	} else {
+		user, err := c.CurrentUser(ctx)
+		var apiErr *types.ErrorResponse
+		switch {
+		case errors.As(err, &apiErr) && apiErr.Status == http.StatusForbidden:
+			report.add("token", checkPass, "token accepted (not permitted to look up its user)", "")
+			report.add("org", checkSkip, "cannot look up the token's user", "")
+		case errors.Is(err, client.ErrUnauthorized):
+			report.add("token", checkFail, "token rejected", "copy the API key from your user profile in the Dashboard and run 'tyk config set --auth-token <token>'")
+			report.skip("token rejected", "org")
+		case err != nil:
+			report.add("token", checkFail, err.Error(), "")
+			report.skip("token not verified", "org")
+		case user == nil:
+			report.add("token", checkPass, "token accepted", "")
+			report.add("org", checkSkip, "token matches no user in the organisation", "")
+		default:
+			report.add("token", checkPass, fmt.Sprintf("token belongs to %s <%s>", user.Name(), user.Email), "")
+			switch {
+			case env.OrgID == "":
+				report.add("org", checkWarn, fmt.Sprintf("no org_id configured; the token's organisation is %s", user.OrgID), "run 'tyk config set --org-id "+user.OrgID+"'")
+			case env.OrgID != user.OrgID:
+				report.add("org", checkFail, fmt.Sprintf("configured org_id %s does not match the token's organisation %s", env.OrgID, user.OrgID), "run 'tyk config set --org-id "+user.OrgID+"'")
+			default:
+				report.add("org", checkPass, "org_id "+env.OrgID+" matches the token's organisation", "")
+			}
+		}
+	}
+
+	checkClock(report, health)
+
+	if !listed {
+		_, listErr = c.ListAPIsDashboard(ctx, 1)
+	}
+	var apiErr *types.ErrorResponse
+	switch {
+	case listErr == nil:
+		report.add("api-list", checkPass, "token can list APIs", "")
+	case errors.As(listErr, &apiErr) && apiErr.Status == http.StatusForbidden:
+		report.add("api-list", checkFail, "token is not allowed to list APIs", "grant the user at least read access to APIs in the Dashboard's user permissions")
+	case errors.Is(listErr, client.ErrUnauthorized):
+		report.add("api-list", checkFail, "token rejected", "")
+	default:
+		report.add("api-list", checkFail, listErr.Error(), "")
+	}
+
+	return report
+}
+
+// envTypeName returns "dashboard" or "gateway"
+func envTypeName(env *types.Environment) string {
+	if env.IsGateway() {
+		return types.EnvTypeGateway
+	}
+	return types.EnvTypeDashboard
+}
+
+// checkClock compares the server's Date header with the local clock
+func checkClock(report *doctorReport, health *client.HealthStatus) {
+	if health.ServerTime.IsZero() {
+		report.add("clock", checkSkip, "server sent no Date header", "")
+		return
+	}
+	skew := health.ClockSkew
+	if skew < 0 {
+		skew = -skew
+	}
+	message := fmt.Sprintf("local clock is %s off the server's", skew)
+	hint := "synchronise the system clock (e.g. enable NTP); key expiry times are computed locally"
+	switch {
+	case skew >= clockSkewFail:
+		report.add("clock", checkFail, message, hint)
+	case skew >= clockSkewWarn:
+		report.add("clock", checkWarn, message, hint)
+	default:
+		report.add("clock", checkPass, "local clock agrees with the server's", "")
+	}
+}
+
+// transportHint suggests a fix for a connection failure
+func transportHint(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "certificate"):
+		return "set ca_cert to your CA bundle with 'tyk config set --ca-cert <file>'"
+	case strings.Contains(msg, "no such host"):
+		return "check the URL with 'tyk config list'"
+	case strings.Contains(msg, "connection refused"), strings.Contains(msg, "timeout"), strings.Contains(msg, "deadline exceeded"):
+		return "check the URL, that the service is running, and any proxy or VPN in between (proxy_url, HTTPS_PROXY)"
+	case strings.Contains(msg, "health check failed"):
+		return "the service is up but unhealthy; check its logs and its Redis and database connections"
+	}
+	return ""
+}
+
+func printDoctorReport(report *doctorReport) {
+	for _, check := range report.Checks {
+		var symbol string
+		var c *color.Color
+		switch check.Status {
+		case checkPass:
+			symbol, c = "✓", color.New(color.FgGreen)
+		case checkWarn:
+			symbol, c = "⚠", color.New(color.FgYellow)
+		case checkFail:
+			symbol, c = "✗", color.New(color.FgRed)
+		default:
+			symbol, c = "-", color.New(color.Faint)
+		}
+		c.Printf("%s %-10s", symbol, check.Name)
+		fmt.Printf(" %s\n", check.Message)
+		if check.Hint != "" {
+			fmt.Printf("  %-10s → %s\n", "", check.Hint)
+		}
+	}
+	summary := fmt.Sprintf("\n%d passed, %d warning(s), %d failed\n", report.Passed, report.Warnings, report.Failed)
+	if report.Failed > 0 {
+		color.New(color.FgRed).Fprint(os.Stderr, summary)
+	} else {
+		color.New(color.FgGreen).Fprint(os.Stderr, summary)
+	}
+}
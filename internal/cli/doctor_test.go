@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// newDoctorServer serves the endpoints 'tyk doctor' calls; apisStatus is the status of the API listing
+func newDoctorServer(t *testing.T, apisStatus int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/health" && r.Header.Get("Authorization") != "token-1234567890" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"Status":"Error","Message":"Not authorised"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/health":
+			w.Write([]byte("OK"))
+		case "/api/users":
+			json.NewEncoder(w).Encode(map[string]interface{}{"users": []map[string]interface{}{
+				{"id": "u-1", "first_name": "Jane", "email_address": "jane@example.com", "org_id": "org", "active": true, "access_key": "token-1234567890"},
+			}})
+		case "/api/apis":
+			w.WriteHeader(apisStatus)
+			w.Write([]byte(`{"apis":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runDoctorJSON(t *testing.T, env *types.Environment) (*doctorReport, error) {
+	t.Helper()
+	env.Name = "dev"
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "dev",
+		Environments:       map[string]*types.Environment{"dev": env},
+	})
+
+	out, err := runRootCommand(t, "doctor", "-o", "json")
+	require.NoError(t, outputschema.Validate("doctor", out), string(out))
+	var report doctorReport
+	require.NoError(t, json.Unmarshal(out, &report))
+	return &report, err
+}
+
+func doctorStatuses(report *doctorReport) map[string]string {
+	statuses := make(map[string]string)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestDoctor(t *testing.T) {
+	server := newDoctorServer(t, http.StatusOK)
+
+	t.Run("all checks pass", func(t *testing.T) {
+		report, err := runDoctorJSON(t, &types.Environment{DashboardURL: server.URL, AuthToken: "token-1234567890", OrgID: "org"})
+		require.NoError(t, err)
+		assert.Equal(t, "dev", report.Environment)
+		assert.Equal(t, map[string]string{
+			"config": "pass", "reachable": "pass", "token": "pass", "org": "pass", "clock": "pass", "api-list": "pass",
+		}, doctorStatuses(report))
+		assert.Equal(t, 6, report.Passed)
+	})
+
+	t.Run("org mismatch fails", func(t *testing.T) {
+		report, err := runDoctorJSON(t, &types.Environment{DashboardURL: server.URL, AuthToken: "token-1234567890", OrgID: "other"})
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+		assert.Equal(t, "fail", doctorStatuses(report)["org"])
+		assert.Equal(t, 1, report.Failed)
+	})
+
+	t.Run("bad token", func(t *testing.T) {
+		report, err := runDoctorJSON(t, &types.Environment{DashboardURL: server.URL, AuthToken: "wrong-token-value", OrgID: "org"})
+		require.Error(t, err)
+		statuses := doctorStatuses(report)
+		assert.Equal(t, "pass", statuses["reachable"])
+		assert.Equal(t, "fail", statuses["token"])
+		assert.Equal(t, "skip", statuses["org"])
+		assert.Equal(t, "fail", statuses["api-list"])
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+		report, err := runDoctorJSON(t, &types.Environment{DashboardURL: closed.URL, AuthToken: "token-1234567890", OrgID: "org"})
+		require.Error(t, err)
+		statuses := doctorStatuses(report)
+		assert.Equal(t, "fail", statuses["reachable"])
+		assert.Equal(t, "skip", statuses["token"])
+		assert.Equal(t, "skip", statuses["api-list"])
+		assert.Equal(t, 1, report.Failed)
+	})
+}
+
+func TestDoctor_APIListForbidden(t *testing.T) {
+	server := newDoctorServer(t, http.StatusForbidden)
+	report, err := runDoctorJSON(t, &types.Environment{DashboardURL: server.URL, AuthToken: "token-1234567890", OrgID: "org"})
+	require.Error(t, err)
+	statuses := doctorStatuses(report)
+	assert.Equal(t, "pass", statuses["token"])
+	assert.Equal(t, "fail", statuses["api-list"])
+}
+
+func TestDoctor_InvalidConfig(t *testing.T) {
+	report, err := runDoctorJSON(t, &types.Environment{DashboardURL: "http://localhost:3000", AuthToken: "token", OrgID: "org", Timeout: "soon"})
+	require.Error(t, err)
+	assert.Equal(t, "fail", doctorStatuses(report)["config"])
+	assert.Empty(t, report.Environment)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// Drift states
+const (
+	driftModified   = "modified"
+	driftRemoteOnly = "remote_only"
+	driftLocalOnly  = "local_only"
+)
+
+// driftEntry describes one API whose remote state differs from the local source of truth
+type driftEntry struct {
+	Status string            `json:"status"`
+	Name   string            `json:"name"`
+	APIID  string            `json:"api_id,omitempty"`
+	File   string            `json:"file,omitempty"`
+	Diff   *oas.SemanticDiff `json:"diff,omitempty"`
+}
+
+// NewDriftCommand creates the 'tyk drift' command
+func NewDriftCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Report APIs whose deployed state differs from a directory of specs",
+		Long: `Compare every spec in a directory with the environment and report drift:
+
+  modified     the deployed API differs from its spec (e.g. edited in the Dashboard)
+  remote_only  the API is deployed but has no spec
+  local_only   the spec has never been deployed
+
+Specs are matched to APIs the same way as 'tyk plan'. Run it on a schedule with
+--exit-code to fail the job when anything has drifted.
+
+Examples:
+  tyk drift --dir ./apis
+  tyk drift --dir ./apis -o json --exit-code`,
+		Args: cobra.NoArgs,
+		RunE: runDrift,
+	}
+
+	cmd.Flags().String("dir", "", "Directory of OAS specs that are the source of truth (required)")
+	cmd.Flags().Bool("exit-code", false, "Exit with status 1 when any API has drifted")
+	cmd.Flags().Bool("categories-from-dirs", false, "Expect the categories 'tyk plan --categories-from-dirs' files APIs under")
+	cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+func runDrift(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+	categoriesFromDirs, _ := cmd.Flags().GetBool("categories-from-dirs")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+
+	files, err := filehandler.FindSpecFiles(dir)
+	if err != nil {
+		return &ExitError{Code: 2, Message: err.Error()}
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	scope := planScope{prune: true, envPrefix: env.ListenPathPrefix}
+	if categoriesFromDirs {
+		scope.categoryRoot = dir
+	}
+	plan, err := buildPlan(ctx, c, files, scope)
+	if err != nil {
+		return err
+	}
+	entries := driftFromPlan(plan)
+	inSync := plan.Summary[planNoChange]
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if err := writeStructured(format, map[string]interface{}{
+			"environment": env.Name,
+			"dir":         dir,
+			"drifted":     len(entries),
+			"in_sync":     inSync,
+			"apis":        entries,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printDrift(env.Name, entries, inSync)
+	}
+
+	if exitCode && len(entries) > 0 {
+		return differencesFoundError(cmd)
+	}
+	return nil
+}
+
+// driftFromPlan turns plan actions into drift entries, skipping APIs that are in sync
+func driftFromPlan(plan *apiPlan) []*driftEntry {
+	entries := []*driftEntry{}
+	for _, action := range plan.Actions {
+		entry := &driftEntry{Name: action.Name, APIID: action.APIID, File: action.File}
+		switch action.Action {
+		case planUpdate:
+			entry.Status = driftModified
+			entry.Diff = oas.Semantic(action.remote, action.Document)
+		case planDelete:
+			entry.Status = driftRemoteOnly
+		case planCreate:
+			entry.Status = driftLocalOnly
+		default:
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// printDrift prints a drift table and summary
+func printDrift(envName string, entries []*driftEntry, inSync int) {
+	if len(entries) == 0 {
+		color.New(color.FgGreen).Printf("No drift: %d API(s) in '%s' match their specs\n", inSync, envName)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tNAME\tAPI ID\tFILE\tDETAILS")
+	for _, entry := range entries {
+		file := ""
+		if entry.File != "" {
+			file = filepath.Base(entry.File)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entry.Status, entry.Name, entry.APIID, file, summarizeSemanticDiff(entry.Diff))
+	}
+	w.Flush()
+
+	fmt.Fprintf(os.Stderr, "\n%d API(s) drifted, %d in sync\n", len(entries), inSync)
+}
+
+// summarizeSemanticDiff describes a diff in a few words for table output
+func summarizeSemanticDiff(d *oas.SemanticDiff) string {
+	if d == nil {
+		return ""
+	}
+	var parts []string
+	if n := len(d.OperationsAdded) + len(d.OperationsRemoved) + len(d.OperationsChanged); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d operation(s)", n))
+	}
+	if d.Upstream != nil {
+		parts = append(parts, "upstream")
+	}
+	if d.ListenPath != nil {
+		parts = append(parts, "listen path")
+	}
+	if len(d.TykExtension) > 0 {
+		parts = append(parts, fmt.Sprintf("%d Tyk setting(s)", len(d.TykExtension)))
+	}
+	if len(d.Other) > 0 {
+		parts = append(parts, fmt.Sprintf("%d other change(s)", len(d.Other)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestDrift(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "orders", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-3", "console-only", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	writePlanSpec(t, dir, "users", "1.0.0")
+	writePlanSpec(t, dir, "orders", "1.0.0")
+	writePlanSpec(t, dir, "payments", "1.0.0")
+
+	// Simulate an edit made in the Dashboard
+	dashboard.apis["remote-2"]["x-tyk-api-gateway"].(map[string]interface{})["upstream"] = map[string]interface{}{"url": "http://orders.legacy"}
+
+	out, err := runRootCommand(t, "drift", "--dir", dir, "-o", "json", "--exit-code")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+	require.NoError(t, outputschema.Validate("drift", out), string(out))
+
+	var report struct {
+		Drifted int           `json:"drifted"`
+		InSync  int           `json:"in_sync"`
+		APIs    []*driftEntry `json:"apis"`
+	}
+	require.NoError(t, json.Unmarshal(out, &report))
+	assert.Equal(t, 3, report.Drifted)
+	assert.Equal(t, 1, report.InSync)
+
+	statuses := map[string]*driftEntry{}
+	for _, entry := range report.APIs {
+		statuses[entry.Name] = entry
+	}
+	assert.Equal(t, driftModified, statuses["orders"].Status)
+	assert.Equal(t, "http://orders.legacy", statuses["orders"].Diff.Upstream.Old)
+	assert.Equal(t, driftLocalOnly, statuses["payments"].Status)
+	assert.Equal(t, driftRemoteOnly, statuses["console-only"].Status)
+	assert.Equal(t, "remote-3", statuses["console-only"].APIID)
+
+	// Drift is read-only
+	assert.Equal(t, 3, dashboard.count())
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"golang.org/x/term"
+)
+
+// addEncryptFlag adds --encrypt to a command writing exports
+func addEncryptFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("encrypt", nil, "Encrypt the output with age to this recipient (age1...); repeat for several")
+}
+
+// encryptOutput encrypts data to the --encrypt recipients, and returns it unchanged when
+// there are none. Encrypted output is not written to a terminal.
+func encryptOutput(cmd *cobra.Command, data []byte, out string) ([]byte, error) {
+	recipients, _ := cmd.Flags().GetStringSlice("encrypt")
+	if len(recipients) == 0 {
+		return data, nil
+	}
+	if out == "" && term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: "refusing to write encrypted output to a terminal; use --out or redirect it"}
+	}
+	encrypted, err := filehandler.Encrypt(data, recipients)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--encrypt: %v", err)}
+	}
+	return encrypted, nil
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestEncryptedExport(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "Users API", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	dir := t.TempDir()
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	identityFile := filepath.Join(dir, "key.txt")
+	require.NoError(t, os.WriteFile(identityFile, []byte("# created: today\n"+identity.String()+"\n"), 0600))
+
+	manifest := filepath.Join(dir, "tykapi.yaml.age")
+	_, err = runRootCommand(t, "api", "export-k8s", "users-1", "--encrypt", identity.Recipient().String(), "--out", manifest)
+	require.NoError(t, err)
+	data, err := os.ReadFile(manifest)
+	require.NoError(t, err)
+	assert.True(t, filehandler.IsEncrypted(data))
+	assert.NotContains(t, string(data), "Users API")
+
+	// Reading it back needs the identity
+	specFile := filepath.Join(dir, "users.yaml")
+	t.Setenv(filehandler.EnvAgeIdentityFile, "")
+	_, err = runRootCommand(t, "api", "export-k8s", "--from-file", manifest, "--out", specFile)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, filehandler.EnvAgeIdentityFile)
+
+	t.Setenv(filehandler.EnvAgeIdentityFile, identityFile)
+	_, err = runRootCommand(t, "api", "export-k8s", "--from-file", manifest, "--out", specFile)
+	require.NoError(t, err)
+	spec, err := filehandler.LoadFile(specFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Users API", oas.GetAPIName(spec.Content))
+
+	// Encrypted specs are applied like plain ones
+	encrypted, err := filehandler.Encrypt(spec.RawBytes, []string{identity.Recipient().String()})
+	require.NoError(t, err)
+	encryptedSpec := filepath.Join(dir, "users.yaml.age")
+	require.NoError(t, os.WriteFile(encryptedSpec, encrypted, 0644))
+	loaded, err := filehandler.LoadFile(encryptedSpec)
+	require.NoError(t, err)
+	assert.Equal(t, filehandler.FileTypeYAML, loaded.Type)
+	assert.Equal(t, spec.Content, loaded.Content)
+
+	_, err = runRootCommand(t, "api", "export-k8s", "users-1", "--encrypt", "age1invalid", "--out", manifest)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	_, err = runRootCommand(t, "api", "export-k8s", "--from-file", manifest, "--encrypt", identity.Recipient().String())
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
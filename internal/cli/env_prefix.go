@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// mountEnvironmentPrefix moves an API under the active environment's listen_path_prefix
+// before it is deployed, so environments sharing a gateway never claim the same paths.
+// It does nothing when the environment has no prefix.
+func mountEnvironmentPrefix(config *types.Config, resource string, doc map[string]interface{}) error {
+	env, err := config.GetActiveEnvironment()
+	if err != nil || env.ListenPathPrefix == "" {
+		return nil
+	}
+	if err := oas.MountListenPath(doc, env.ListenPathPrefix); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", resource, err)}
+	}
+	return nil
+}
+
+// checkEnvironmentPrefix rejects plan documents that would listen outside the
+// environment's listen_path_prefix, e.g. a plan saved before the prefix was configured
+func checkEnvironmentPrefix(env *types.Environment, plan *apiPlan) error {
+	if env.ListenPathPrefix == "" {
+		return nil
+	}
+	for _, action := range plan.Actions {
+		if action.Action != planCreate && action.Action != planUpdate {
+			continue
+		}
+		if listenPath := oas.GetListenPath(action.Document); !oas.UnderListenPath(listenPath, env.ListenPathPrefix) {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s listens on %s, outside environment '%s' listen_path_prefix %s: re-run tyk plan", actionResource(action), listenPath, env.Name, env.ListenPathPrefix)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestEnvironmentListenPathPrefix(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	// Production's API on the shared gateway, outside the staging prefix
+	seedRemoteAPI(t, dashboard, "prod-users", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "staging-old", "old", "1.0.0")
+	oas.SetListenPath(dashboard.apis["staging-old"], "/staging/old/")
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging": {Name: "staging", DashboardURL: server.URL, AuthToken: "token", OrgID: "org", ListenPathPrefix: "/staging"},
+			"shared":  {Name: "shared", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	writePlanSpec(t, dir, "orders", "1.0.0")
+
+	out, err := runRootCommand(t, "plan", "--dir", dir, "--prune", "-o", "json")
+	require.NoError(t, err)
+	var plan apiPlan
+	require.NoError(t, json.Unmarshal(out, &plan))
+	actions := map[string]*planAction{}
+	for _, action := range plan.Actions {
+		actions[action.Name] = action
+	}
+	require.Len(t, actions, 2, "only APIs under the prefix are pruned")
+	assert.Equal(t, planCreate, actions["orders"].Action)
+	assert.Equal(t, "/staging/orders/", oas.GetListenPath(actions["orders"].Document))
+	assert.Equal(t, planDelete, actions["old"].Action)
+
+	out, err = runRootCommand(t, "api", "create", "--name", "Payments", "--upstream-url", "https://payments.internal", "-o", "json")
+	require.NoError(t, err)
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &created))
+	assert.Equal(t, "/staging/payments/", oas.GetListenPath(dashboard.apis[created["api_id"].(string)]))
+
+	// A plan saved before the prefix was configured is refused
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	_, err = runRootCommand(t, "plan", "--dir", dir, "--env", "shared", "--out", planFile)
+	require.NoError(t, err)
+	saved, err := loadPlan(planFile)
+	require.NoError(t, err)
+	saved.Environment = "staging"
+	data, err := json.Marshal(saved)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(planFile, data, 0644))
+
+	_, err = runRootCommand(t, "apply", "--plan", planFile)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "listen_path_prefix")
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// exportedEnvVars are the variables applyEnvironmentVariables set on behalf of the
+// current environment, so a later environment in the same process (foreach-env)
+// starts from the shell's own variables
+var exportedEnvVars []string
+
+// applyEnvironmentVariables sets the environment's env variables in the process, where
+// commands and the programs they start (pagers, editors) see them. Variables set in
+// the shell are left alone.
+func applyEnvironmentVariables(env *types.Environment) {
+	for _, name := range exportedEnvVars {
+		os.Unsetenv(name)
+	}
+	exportedEnvVars = nil
+
+	for name, value := range env.Env {
+		name = strings.ToUpper(name)
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+		os.Setenv(name, value)
+		exportedEnvVars = append(exportedEnvVars, name)
+	}
+}
+
+// addEnvironmentVarFlags adds the env variable and header flags of 'config add' and 'config set'
+func addEnvironmentVarFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("var", nil, "Set an environment variable for commands run against the environment, as NAME=value (repeatable)")
+	cmd.Flags().StringArray("unset-var", nil, "Remove an environment variable set with --var (repeatable)")
+	cmd.Flags().StringArray("header", nil, "Send an extra header with every API request, as 'Name: value'; values may use ${VAR} (repeatable)")
+	cmd.Flags().StringArray("unset-header", nil, "Remove a header set with --header (repeatable)")
+}
+
+var environmentVarFlags = []string{"var", "unset-var", "header", "unset-header"}
+
+// environmentVarFlagsChanged reports whether any env variable or header flag was given
+func environmentVarFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range environmentVarFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEnvironmentVarFlags updates env's variables and headers from the flags that were given
+func applyEnvironmentVarFlags(cmd *cobra.Command, env *types.Environment) error {
+	vars, _ := cmd.Flags().GetStringArray("var")
+	unsetVars, _ := cmd.Flags().GetStringArray("unset-var")
+	headers, _ := cmd.Flags().GetStringArray("header")
+	unsetHeaders, _ := cmd.Flags().GetStringArray("unset-header")
+
+	for _, name := range unsetVars {
+		delete(env.Env, strings.ToUpper(name))
+		delete(env.Env, strings.ToLower(name))
+	}
+	for _, assignment := range vars {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || name == "" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --var '%s': expected NAME=value", assignment)}
+		}
+		if env.Env == nil {
+			env.Env = map[string]string{}
+		}
+		env.Env[strings.ToUpper(name)] = value
+	}
+
+	for _, name := range unsetHeaders {
+		for existing := range env.Headers {
+			if strings.EqualFold(existing, name) {
+				delete(env.Headers, existing)
+			}
+		}
+	}
+	for _, raw := range headers {
+		header, err := oas.ParseHeader(raw)
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --header: %v", err)}
+		}
+		if env.Headers == nil {
+			env.Headers = map[string]string{}
+		}
+		env.Headers[header.Name] = header.Value
+	}
+	return nil
+}
+
+// printEnvironmentVars prints the environment's variables and headers with masked values
+func printEnvironmentVars(printf func(format string, a ...interface{}) (int, error), indent string, env *types.Environment) {
+	for _, name := range sortedKeys(env.Env) {
+		printf("%senv.%s = %s\n", indent, strings.ToUpper(name), maskToken(env.Env[name]))
+	}
+	for _, name := range sortedKeys(env.Headers) {
+		printf("%sheader %s: %s\n", indent, name, maskToken(env.Headers[name]))
+	}
+}
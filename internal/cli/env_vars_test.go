@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestEnvironmentVarsAndHeaders(t *testing.T) {
+	var teamHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		teamHeader = r.Header.Get("X-Team")
+		assert.Equal(t, "token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apis":[]}`))
+	}))
+	defer server.Close()
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "edge",
+		Environments: map[string]*types.Environment{
+			"edge": {
+				Name: "edge", DashboardURL: server.URL, AuthToken: "token", OrgID: "org",
+				Env:     map[string]string{"TEAM_TOKEN": "t-123", config.EnvGatewayURL: "https://edge-gw.example.com"},
+				Headers: map[string]string{"X-Team": "${TEAM_TOKEN}", "Authorization": "ignored"},
+			},
+		},
+	})
+	manager := config.NewManager()
+	require.NoError(t, manager.LoadConfig())
+	env, err := manager.GetEnvironment("edge")
+	require.NoError(t, err)
+	assert.Equal(t, "t-123", env.Env["team_token"])
+
+	t.Setenv("TEAM_TOKEN", "")
+	os.Unsetenv("TEAM_TOKEN")
+	t.Setenv(config.EnvGatewayURL, "https://shell-gw.example.com")
+	_, err = runRootCommand(t, "api", "list")
+	require.NoError(t, err)
+	assert.Equal(t, "t-123", teamHeader)
+	// The shell's own variables win
+	assert.Equal(t, "https://shell-gw.example.com", os.Getenv(config.EnvGatewayURL))
+
+	// Switching environments drops the variables the previous one set
+	applyEnvironmentVariables(&types.Environment{Name: "other"})
+	_, ok := os.LookupEnv("TEAM_TOKEN")
+	assert.False(t, ok)
+
+	_, err = runRootCommand(t, "config", "set", "--var", "REGION=eu", "--unset-var", "team_token", "--header", "X-Trace: on", "--unset-header", "x-team")
+	require.NoError(t, err)
+	manager = config.NewManager()
+	require.NoError(t, manager.LoadConfig())
+	env, err = manager.GetEnvironment("edge")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"region": "eu", "tyk_gateway_url": "https://edge-gw.example.com"}, env.Env)
+	assert.Equal(t, "on", env.Headers["x-trace"])
+	assert.NotContains(t, env.Headers, "x-team")
+
+	_, err = runRootCommand(t, "config", "set", "--var", "1BAD=x")
+	assert.Error(t, err)
+}
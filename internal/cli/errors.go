@@ -1,11 +1,171 @@
 package cli
 
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
 // ExitError represents an error with a specific exit code
 type ExitError struct {
 	Code    int
 	Message string
+	Err     error
 }
 
 func (e *ExitError) Error() string {
 	return e.Message
-}
\ No newline at end of file
+}
+
+// Unwrap exposes the underlying error so callers can still match typed client errors
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCode returns the catalog code for the exit code (see 'tyk explain')
+func (e *ExitError) ErrorCode() types.ErrorCode {
+	return types.ExitCode(e.Code).ErrorCode()
+}
+
+// ErrorCodeOf returns the catalog code a command failure is reported with: the exit
+// error's own, else the classification of a Dashboard error response
+func ErrorCodeOf(err error) types.ErrorCode {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ErrorCode()
+	}
+	var resp *types.ErrorResponse
+	if errors.As(err, &resp) {
+		return resp.ErrorCode()
+	}
+	return types.ErrCodeGeneral
+}
+
+// errorReport is the structured form of a failure, written to stderr with --json or
+// -o yaml
+type errorReport struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	// The process exit code
+	Code      int             `json:"code"`
+	ErrorCode types.ErrorCode `json:"error_code"`
+	Message   string          `json:"message"`
+	// HTTP status and details of the Dashboard error response behind the failure
+	Status  int                    `json:"status,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// ReportError writes the failure of cmd, the command that ran, to w and returns the
+// exit code to end the process with. Failures are reported as "Error [E_CODE]: ...",
+// or as a JSON or YAML error document when cmd was asked for structured output. Exit
+// errors without a message, like differencesFoundError, are not reported.
+func ReportError(w io.Writer, cmd *cobra.Command, err error) int {
+	code := ErrorCodeOf(err)
+	detail := errorDetail{Code: int(code.ExitCode()), ErrorCode: code, Message: err.Error()}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		detail.Code, detail.Message = exitErr.Code, exitErr.Message
+	}
+	if detail.Message == "" {
+		return detail.Code
+	}
+	var resp *types.ErrorResponse
+	if errors.As(err, &resp) {
+		detail.Status, detail.Details = resp.Status, resp.Details
+	}
+
+	if format := structuredErrorFormat(cmd); format != "" {
+		encodeStructured(w, format, errorReport{Error: detail})
+		return detail.Code
+	}
+	fmt.Fprintf(w, "Error [%s]: %v\n", code, detail.Message)
+	return detail.Code
+}
+
+// structuredErrorFormat returns the format cmd reports failures in: JSON or YAML when
+// it was asked for structured output, else ""
+func structuredErrorFormat(cmd *cobra.Command) types.OutputFormat {
+	if cmd == nil {
+		return ""
+	}
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil || !format.IsStructured() {
+		return ""
+	}
+	return format
+}
+
+// silenceUsageForStructuredOutput keeps cobra from printing usage next to structured
+// error documents: after flag errors, argument errors and command failures
+func silenceUsageForStructuredOutput(root *cobra.Command) {
+	silence := func(cmd *cobra.Command) {
+		if structuredErrorFormat(cmd) != "" {
+			cmd.SilenceUsage = true
+		}
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		silence(cmd)
+		return err
+	})
+	var wrapArgs func(cmd *cobra.Command)
+	wrapArgs = func(cmd *cobra.Command) {
+		for _, sub := range cmd.Commands() {
+			wrapArgs(sub)
+		}
+		if cmd.Args == nil {
+			return
+		}
+		validate := cmd.Args
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			err := validate(cmd, args)
+			if err != nil {
+				silence(cmd)
+			}
+			return err
+		}
+	}
+	wrapArgs(root)
+	preRun := root.PersistentPreRunE
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		silence(cmd)
+		return preRun(cmd, args)
+	}
+}
+
+// wrapAPIError maps authentication, rate-limit and stale-update failures to their exit
+// codes and wraps any other error with the failed action
+func wrapAPIError(err error, action string) error {
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		return &ExitError{Code: int(types.ExitUnauthorized), Message: fmt.Sprintf("%s: authentication failed: %v", action, err), Err: err}
+	case errors.Is(err, client.ErrRateLimited):
+		return &ExitError{Code: int(types.ExitRateLimited), Message: fmt.Sprintf("%s: rate limited by Dashboard: %v", action, err), Err: err}
+	case errors.Is(err, client.ErrPreconditionFailed):
+		return &ExitError{Code: int(types.ExitConflict), Message: fmt.Sprintf("%s: the API was changed by someone else since it was read; review the change and run the command again", action), Err: err}
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// differencesFoundError is returned by --exit-code commands when differences exist.
+// Like git diff it exits 1 without printing anything, since the diff was already shown.
+func differencesFoundError(cmd *cobra.Command) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &ExitError{Code: int(types.ExitGeneral)}
+}
+
+// notFoundError builds the exit-code-3 error for a missing API
+func notFoundError(err error, message string) error {
+	return &ExitError{Code: int(types.ExitNotFound), Message: message, Err: err}
+}
+
+// conflictError builds the exit-code-4 error for a conflicting write
+func conflictError(err error, action string) error {
+	return &ExitError{Code: int(types.ExitConflict), Message: fmt.Sprintf("%s due to conflict: %v", action, err), Err: err}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// reportRootCommand runs the root command like main does and returns what it reported
+// on stderr and the exit code
+func reportRootCommand(t *testing.T, args ...string) (string, int) {
+	t.Helper()
+	root := NewRootCommand("test", "", "")
+	root.SetArgs(args)
+	var stderr bytes.Buffer
+	root.SetErr(&stderr)
+	code := 0
+	_, err := captureStdout(func() error {
+		if cmd, err := root.ExecuteC(); err != nil {
+			code = ReportError(&stderr, cmd, err)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return stderr.String(), code
+}
+
+func TestReportError(t *testing.T) {
+	_, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	stderr, code := reportRootCommand(t, "api", "get", "missing")
+	assert.Equal(t, int(types.ExitNotFound), code)
+	assert.Contains(t, stderr, "Error [E_NOT_FOUND]: ")
+
+	stderr, code = reportRootCommand(t, "api", "get", "missing", "-o", "json")
+	assert.Equal(t, int(types.ExitNotFound), code)
+	require.NoError(t, outputschema.Validate("error", []byte(stderr)), stderr)
+	var report errorReport
+	require.NoError(t, json.Unmarshal([]byte(stderr), &report))
+	assert.Equal(t, int(types.ExitNotFound), report.Error.Code)
+	assert.Equal(t, types.ErrCodeNotFound, report.Error.ErrorCode)
+	assert.Equal(t, 404, report.Error.Status)
+	assert.NotEmpty(t, report.Error.Message)
+
+	// Usage is not printed next to the document, whatever went wrong
+	for _, args := range [][]string{
+		{"api", "get", "-o", "json"},
+		{"api", "get", "missing", "-o", "json", "--bogus"},
+	} {
+		stderr, code = reportRootCommand(t, args...)
+		assert.Equal(t, int(types.ExitGeneral), code, "%v", args)
+		assert.NotContains(t, stderr, "Usage:", "%v", args)
+		require.NoError(t, outputschema.Validate("error", []byte(stderr)), "%v: %s", args, stderr)
+	}
+}
+
+func TestReportError_Silent(t *testing.T) {
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	assert.Equal(t, 1, ReportError(&stderr, cmd, differencesFoundError(cmd)))
+	assert.Empty(t, stderr.String())
+}
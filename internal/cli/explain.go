@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewExplainCommand creates the 'tyk explain' command
+func NewExplainCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain [exit-codes | <error-code>]",
+		Short: "Explain the CLI's error codes and exit codes",
+		Long: `Describe the error codes failures are reported with ("Error [E_CONFLICT]: ...") and
+what to do about them. Each error code has its own exit code, so scripts can branch on
+either. With --json (or -o yaml) a failure is instead written to stderr as a document:
+
+  {"error": {"code": 3, "error_code": "E_NOT_FOUND", "message": "...", "status": 404}}
+
+Without an argument, or with exit-codes, every code is listed. A single code can be
+looked up by name, with or without the E_ prefix, or by exit code.
+
+Examples:
+  tyk explain exit-codes
+  tyk explain E_CONFLICT
+  tyk explain 5 -o json`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: explainTopics(),
+		RunE:      runExplain,
+	}
+}
+
+// explainTopics are the arguments 'tyk explain' completes
+func explainTopics() []string {
+	topics := []string{"exit-codes"}
+	for _, info := range types.ErrorCatalog {
+		topics = append(topics, string(info.Code))
+	}
+	return topics
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	codes := types.ErrorCatalog
+	if len(args) == 1 && args[0] != "exit-codes" {
+		info, ok := types.LookupErrorCode(args[0])
+		if !ok {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("unknown error code '%s'; 'tyk explain exit-codes' lists them", args[0])}
+		}
+		codes = []types.ErrorCodeInfo{info}
+	}
+
+	if format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{"codes": codes})
+	}
+
+	if len(codes) == 1 {
+		info := codes[0]
+		fmt.Printf("%s (exit code %d)\n\n%s\n\nWhat to do: %s\n", info.Code, info.ExitCode, info.Summary, info.Remediation)
+		return nil
+	}
+	tbl := &table{
+		Columns:   []tableColumn{{Header: "Exit"}, {Header: "Code"}, {Header: "Meaning", Min: 20}},
+		Width:     terminalWidth(os.Stdout),
+		Separator: "  ",
+	}
+	rows := make([][]string, len(codes))
+	for i, info := range codes {
+		summary, _, _ := strings.Cut(info.Summary, ":")
+		rows[i] = []string{fmt.Sprint(info.ExitCode), string(info.Code), strings.TrimSuffix(summary, ".")}
+	}
+	tbl.Write(os.Stdout, rows)
+	fmt.Println("\nRun 'tyk explain <code>' for what to do about one.")
+	return nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestExplain(t *testing.T) {
+	out, err := runRootCommand(t, "explain", "exit-codes", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("explain", out), string(out))
+	var result struct {
+		Codes []types.ErrorCodeInfo `json:"codes"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Len(t, result.Codes, len(types.ErrorCatalog))
+
+	// Codes are found by name, with or without the prefix, or by exit code
+	for _, query := range []string{"E_CONFLICT", "conflict", "4"} {
+		out, err = runRootCommand(t, "explain", query)
+		require.NoError(t, err, query)
+		assert.Contains(t, string(out), "E_CONFLICT (exit code 4)", query)
+	}
+
+	_, err = runRootCommand(t, "explain", "E_NOPE")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, types.ErrCodeBadArgs, exitErr.ErrorCode())
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	assert.Equal(t, types.ErrCodeNotFound, ErrorCodeOf(notFoundError(client.ErrNotFound, "API 'x' not found")))
+	assert.Equal(t, types.ErrCodeGeneral, ErrorCodeOf(fmt.Errorf("boom")))
+	// Dashboard errors carry their code even when not mapped to an exit error
+	conflict := fmt.Errorf("failed to create API: %w", &types.ErrorResponse{Status: 409, Message: "exists"})
+	assert.Equal(t, types.ErrCodeConflict, ErrorCodeOf(conflict))
+	assert.Equal(t, types.ExitConflict, ErrorCodeOf(conflict).ExitCode())
+
+	// Every exit code maps to one error code and back
+	for _, info := range types.ErrorCatalog {
+		assert.Equal(t, info.Code, info.ExitCode.ErrorCode())
+		assert.Equal(t, info.ExitCode, info.Code.ExitCode())
+	}
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// apiFilter is a single "<field><op><value>" condition parsed from --filter. A filter
+// without a field matches Value as a case-insensitive substring of the name or listen path.
+type apiFilter struct {
+	Field string
+	Op    string
+	Value string
+	re    *regexp.Regexp
+}
+
+// apiFilterFields maps filterable field names to accessors on an API
+var apiFilterFields = map[string]func(*types.OASAPI) string{
+	"id":          func(api *types.OASAPI) string { return api.ID },
+	"name":        func(api *types.OASAPI) string { return api.Name },
+	"listen_path": func(api *types.OASAPI) string { return api.ListenPath },
+	"status":      func(api *types.OASAPI) string { return api.Status() },
+}
+
+// parseAPIFilters parses expressions such as 'name~^test-' or 'listen_path=/users/'.
+// Supported operators are = (equals), != (not equals), ~ (regex) and !~ (regex does not match).
+func parseAPIFilters(exprs []string) ([]*apiFilter, error) {
+	var filters []*apiFilter
+	for _, expr := range exprs {
+		filter, err := parseAPIFilter(expr)
+		if err != nil {
+			return nil, &ExitError{Code: 2, Message: err.Error()}
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// parseAPIListFilters is parseAPIFilters for listings, where a bare term with no
+// operator, such as 'users', matches APIs whose name or listen path contains it
+func parseAPIListFilters(exprs []string) ([]*apiFilter, error) {
+	var conditions []string
+	var filters []*apiFilter
+	for _, expr := range exprs {
+		if !strings.ContainsAny(expr, "!=~") {
+			filters = append(filters, &apiFilter{Value: strings.ToLower(expr)})
+			continue
+		}
+		conditions = append(conditions, expr)
+	}
+	parsed, err := parseAPIFilters(conditions)
+	if err != nil {
+		return nil, err
+	}
+	return append(filters, parsed...), nil
+}
+
+func parseAPIFilter(expr string) (*apiFilter, error) {
+	// The operator starts at the first '!', '=' or '~' so values may contain them freely
+	idx := strings.IndexAny(expr, "!=~")
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid filter '%s': expected <field><op><value> with op one of =, !=, ~, !~", expr)
+	}
+	op := expr[idx : idx+1]
+	if op == "!" {
+		if idx+1 >= len(expr) || (expr[idx+1] != '=' && expr[idx+1] != '~') {
+			return nil, fmt.Errorf("invalid filter '%s': '!' must be followed by '=' or '~'", expr)
+		}
+		op = expr[idx : idx+2]
+	}
+
+	filter := &apiFilter{Field: strings.TrimSpace(expr[:idx]), Op: op, Value: expr[idx+len(op):]}
+	if _, ok := apiFilterFields[filter.Field]; !ok {
+		return nil, fmt.Errorf("invalid filter '%s': unknown field '%s' (use id, name, listen_path or status)", expr, filter.Field)
+	}
+	if op == "~" || op == "!~" {
+		re, err := regexp.Compile(filter.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter '%s': %v", expr, err)
+		}
+		filter.re = re
+	}
+	return filter, nil
+}
+
+// Match reports whether the API satisfies the condition
+func (f *apiFilter) Match(api *types.OASAPI) bool {
+	if f.Field == "" {
+		return strings.Contains(strings.ToLower(api.Name), f.Value) || strings.Contains(strings.ToLower(api.ListenPath), f.Value)
+	}
+	value := apiFilterFields[f.Field](api)
+	switch f.Op {
+	case "=":
+		return value == f.Value
+	case "!=":
+		return value != f.Value
+	case "~":
+		return f.re.MatchString(value)
+	case "!~":
+		return !f.re.MatchString(value)
+	}
+	return false
+}
+
+// filterAPIsByStatus returns the APIs whose Status() equals status
+func filterAPIsByStatus(apis []*types.OASAPI, status string) []*types.OASAPI {
+	var matched []*types.OASAPI
+	for _, api := range apis {
+		if api.Status() == status {
+			matched = append(matched, api)
+		}
+	}
+	return matched
+}
+
+// filterAPIsByCategory returns the APIs in any of the Dashboard categories
+func filterAPIsByCategory(apis []*types.OASAPI, categories []string) []*types.OASAPI {
+	var matched []*types.OASAPI
+	for _, api := range apis {
+		_, apiCategories := oas.SplitCategories(api.Name)
+		for _, category := range categories {
+			if slices.Contains(apiCategories, category) {
+				matched = append(matched, api)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// filterAPIs returns the APIs matching every filter
+func filterAPIs(apis []*types.OASAPI, filters []*apiFilter) []*types.OASAPI {
+	var matched []*types.OASAPI
+	for _, api := range apis {
+		ok := true
+		for _, filter := range filters {
+			if !filter.Match(api) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, api)
+		}
+	}
+	return matched
+}
+
+// API list sort keys accepted by --sort
+const (
+	sortByName    = "name"
+	sortByCreated = "created"
+	sortByUpdated = "updated"
+)
+
+// sortAPIs orders APIs by name, created or updated time, breaking ties by name and
+// then ID. APIs without a timestamp sort as the oldest.
+func sortAPIs(apis []*types.OASAPI, key string, desc bool) {
+	timestamp := func(api *types.OASAPI) time.Time {
+		value := api.CreatedAt
+		if key == sortByUpdated {
+			value = api.UpdatedAt
+		}
+		t, _ := time.Parse(time.RFC3339, value)
+		return t
+	}
+	less := func(a, b *types.OASAPI) bool {
+		if key != sortByName {
+			if ta, tb := timestamp(a), timestamp(b); !ta.Equal(tb) {
+				return ta.Before(tb)
+			}
+		}
+		if an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name); an != bn {
+			return an < bn
+		}
+		return a.ID < b.ID
+	}
+	sort.SliceStable(apis, func(i, j int) bool {
+		if desc {
+			return less(apis[j], apis[i])
+		}
+		return less(apis[i], apis[j])
+	})
+}
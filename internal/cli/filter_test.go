@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestParseAPIFilters(t *testing.T) {
+	filters, err := parseAPIFilters([]string{"name~^test-", "listen_path!=/keep/", "id=a=b", "name!~tmp"})
+	require.NoError(t, err)
+	require.Len(t, filters, 4)
+	assert.Equal(t, "~", filters[0].Op)
+	assert.Equal(t, "^test-", filters[0].Value)
+	assert.Equal(t, "!=", filters[1].Op)
+	assert.Equal(t, "a=b", filters[2].Value)
+	assert.Equal(t, "!~", filters[3].Op)
+
+	for _, invalid := range []string{"name", "=value", "owner=team", "name~[", "name!value"} {
+		_, err := parseAPIFilters([]string{invalid})
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, invalid)
+		assert.Equal(t, 2, exitErr.Code)
+	}
+}
+
+func TestFilterAPIs(t *testing.T) {
+	apis := []*types.OASAPI{
+		{ID: "1", Name: "test-users", ListenPath: "/test-users/"},
+		{ID: "2", Name: "test-keep", ListenPath: "/keep/"},
+		{ID: "3", Name: "orders", ListenPath: "/orders/"},
+	}
+
+	filters, err := parseAPIFilters([]string{"name~^test-", "listen_path!=/keep/"})
+	require.NoError(t, err)
+
+	matched := filterAPIs(apis, filters)
+	require.Len(t, matched, 1)
+	assert.Equal(t, "1", matched[0].ID)
+}
+
+func TestFilterAPIsByStatus(t *testing.T) {
+	apis := []*types.OASAPI{
+		{ID: "1", Active: true},
+		{ID: "2", Active: true, Internal: true},
+		{ID: "3", Active: false, Internal: true},
+	}
+
+	assert.Equal(t, "1", filterAPIsByStatus(apis, types.APIStatusActive)[0].ID)
+	assert.Equal(t, "2", filterAPIsByStatus(apis, types.APIStatusInternal)[0].ID)
+	assert.Equal(t, "3", filterAPIsByStatus(apis, types.APIStatusInactive)[0].ID)
+
+	filters, err := parseAPIFilters([]string{"status!=active"})
+	require.NoError(t, err)
+	assert.Len(t, filterAPIs(apis, filters), 2)
+}
+
+func TestParseAPIListFilters(t *testing.T) {
+	apis := []*types.OASAPI{
+		{ID: "1", Name: "Users", ListenPath: "/people/"},
+		{ID: "2", Name: "orders", ListenPath: "/shop/users/"},
+		{ID: "3", Name: "billing", ListenPath: "/billing/"},
+	}
+
+	filters, err := parseAPIListFilters([]string{"USERS"})
+	require.NoError(t, err)
+	assert.Len(t, filterAPIs(apis, filters), 2)
+
+	filters, err = parseAPIListFilters([]string{"users", "name~^o"})
+	require.NoError(t, err)
+	matched := filterAPIs(apis, filters)
+	require.Len(t, matched, 1)
+	assert.Equal(t, "2", matched[0].ID)
+
+	_, err = parseAPIListFilters([]string{"owner=team"})
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+func TestSortAPIs(t *testing.T) {
+	apis := []*types.OASAPI{
+		{ID: "1", Name: "orders", CreatedAt: "2024-03-01T00:00:00Z", UpdatedAt: "2024-03-02T00:00:00Z"},
+		{ID: "2", Name: "Billing", CreatedAt: "2024-01-01T00:00:00Z", UpdatedAt: "2024-06-01T00:00:00Z"},
+		{ID: "3", Name: "users"},
+	}
+	ids := func() []string {
+		var out []string
+		for _, api := range apis {
+			out = append(out, api.ID)
+		}
+		return out
+	}
+
+	sortAPIs(apis, sortByName, false)
+	assert.Equal(t, []string{"2", "1", "3"}, ids())
+	sortAPIs(apis, sortByCreated, false)
+	assert.Equal(t, []string{"3", "2", "1"}, ids())
+	sortAPIs(apis, sortByUpdated, true)
+	assert.Equal(t, []string{"2", "1", "3"}, ids())
+}
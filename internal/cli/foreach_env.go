@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// envResult holds the outcome of running a command against a single environment
+type envResult struct {
+	Output interface{} `json:"output,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// NewForeachEnvCommand creates the 'tyk foreach-env' command
+func NewForeachEnvCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "foreach-env [flags] -- <command> [args...]",
+		Short: "Run a command against every configured environment",
+		Long: `Run any tyk command once per configured environment and aggregate the results.
+
+Each run behaves as if '--env <name>' had been passed. With --output json or yaml
+the output of every run is collected into a single document keyed by environment name.
+
+Examples:
+  tyk foreach-env -- api list
+  tyk foreach-env -o json -- api list
+  tyk foreach-env --envs staging,prod -- api get my-api-id -o yaml`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: runForeachEnv,
+	}
+
+	cmd.Flags().StringSlice("envs", nil, "Only run against these environments (comma-separated)")
+
+	return cmd
+}
+
+func runForeachEnv(cmd *cobra.Command, args []string) error {
+	only, _ := cmd.Flags().GetStringSlice("envs")
+	outputFormat, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	manager := config.NewManager()
+	if err := manager.LoadConfig(); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	envNames, err := selectEnvironments(manager, only)
+	if err != nil {
+		return err
+	}
+
+	// The aggregated document is only useful if every run emits JSON, so an output
+	// format given to the inner command applies to the aggregate instead
+	innerArgs, innerFormat := extractOutputFormat(args)
+	if innerFormat != "" && !outputFormat.IsStructured() {
+		outputFormat = innerFormat
+	}
+	structured := outputFormat.IsStructured()
+	if structured {
+		innerArgs = append(innerArgs, "--output", string(types.OutputJSON))
+	}
+
+	results := make(map[string]*envResult, len(envNames))
+	failed := 0
+	for _, name := range envNames {
+		runArgs := append([]string{"--env", name}, innerArgs...)
+
+		if !structured {
+			color.New(color.FgBlue, color.Bold).Fprintf(os.Stderr, "==> %s\n", name)
+			if err := executeInEnvironment(cmd, runArgs); err != nil {
+				failed++
+				color.New(color.FgRed).Fprintf(os.Stderr, "Error (%s): %v\n", name, err)
+			}
+			fmt.Fprintln(os.Stderr)
+			continue
+		}
+
+		var runErr error
+		out, captureErr := captureStdout(func() error {
+			runErr = executeInEnvironment(cmd, runArgs)
+			return nil
+		})
+		if captureErr != nil {
+			return captureErr
+		}
+
+		result := &envResult{}
+		if runErr != nil {
+			failed++
+			result.Error = errorMessage(runErr)
+		}
+		if len(bytes.TrimSpace(out)) > 0 {
+			var parsed interface{}
+			if err := json.Unmarshal(out, &parsed); err == nil {
+				result.Output = parsed
+			} else {
+				result.Output = strings.TrimSpace(string(out))
+			}
+		}
+		results[name] = result
+	}
+
+	if structured {
+		payload := map[string]interface{}{
+			"command":      strings.Join(args, " "),
+			"environments": results,
+			"failed":       failed,
+		}
+		if err := writeStructured(outputFormat, payload); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("command failed in %d of %d environment(s)", failed, len(envNames))}
+	}
+	return nil
+}
+
+// extractOutputFormat removes --json and -o/--output from args, returning the
+// remaining args and the structured format requested, if any
+func extractOutputFormat(args []string) ([]string, types.OutputFormat) {
+	var remaining []string
+	var format types.OutputFormat
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--json":
+			format = types.OutputJSON
+		case (arg == "-o" || arg == "--output") && i+1 < len(args):
+			format = types.OutputFormat(args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--output="):
+			format = types.OutputFormat(strings.TrimPrefix(arg, "--output="))
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	if !format.IsStructured() {
+		format = ""
+	}
+	return remaining, format
+}
+
+// selectEnvironments returns the sorted environment names to run against,
+// restricted to the given subset when provided
+func selectEnvironments(manager *config.Manager, only []string) ([]string, error) {
+	environments := manager.ListEnvironments()
+	if len(environments) == 0 {
+		return nil, fmt.Errorf("no environments configured. Use 'tyk config add' to add an environment")
+	}
+
+	var names []string
+	if len(only) > 0 {
+		for _, name := range only {
+			if _, ok := environments[name]; !ok {
+				return nil, &ExitError{Code: 2, Message: fmt.Sprintf("environment '%s' not found", name)}
+			}
+			names = append(names, name)
+		}
+	} else {
+		for name := range environments {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// executeInEnvironment runs a fresh root command so flag state never leaks between runs
+func executeInEnvironment(cmd *cobra.Command, args []string) error {
+	root := NewRootCommand(cmd.Root().Version, "", "")
+	root.SetArgs(args)
+	root.SilenceUsage = true
+	root.SilenceErrors = true
+	return root.Execute()
+}
+
+// captureStdout redirects os.Stdout while fn runs and returns everything written to it
+func captureStdout(fn func() error) ([]byte, error) {
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture output: %w", err)
+	}
+	os.Stdout = w
+
+	done := make(chan []byte)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.Bytes()
+	}()
+
+	fnErr := fn()
+
+	w.Close()
+	os.Stdout = oldStdout
+	out := <-done
+	r.Close()
+
+	return out, fnErr
+}
+
+// errorMessage returns the user-facing message for an error, unwrapping ExitError
+func errorMessage(err error) string {
+	if exitErr, ok := err.(*ExitError); ok {
+		return exitErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// writeTestConfigFile points the user config dir at a temp dir and writes cfg there
+func writeTestConfigFile(t *testing.T, cfg *types.Config) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tyk"), 0755))
+	content := generateTOMLConfigUnified(cfg)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tyk", "cli.toml"), []byte(content), 0600))
+}
+
+func newDashboardListServer(t *testing.T, apiID string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/apis", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"apis": []interface{}{
+				map[string]interface{}{
+					"api_definition": map[string]interface{}{
+						"api_id": apiID,
+						"name":   "API " + apiID,
+						"proxy":  map[string]interface{}{"listen_path": "/" + apiID + "/"},
+					},
+				},
+			},
+		})
+	}))
+}
+
+func TestForeachEnv_AggregatesJSONByEnvironment(t *testing.T) {
+	devServer := newDashboardListServer(t, "dev-api")
+	defer devServer.Close()
+	prodServer := newDashboardListServer(t, "prod-api")
+	defer prodServer.Close()
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "dev",
+		Environments: map[string]*types.Environment{
+			"dev":  {Name: "dev", DashboardURL: devServer.URL, AuthToken: "token", OrgID: "org"},
+			"prod": {Name: "prod", DashboardURL: prodServer.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	root := NewRootCommand("test", "", "")
+	root.SetArgs([]string{"foreach-env", "--json", "--", "api", "list"})
+
+	out, err := captureStdout(root.Execute)
+	require.NoError(t, err)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, float64(0), result["failed"])
+
+	envs := result["environments"].(map[string]interface{})
+	require.Len(t, envs, 2)
+	devOutput := envs["dev"].(map[string]interface{})["output"].(map[string]interface{})
+	devAPIs := devOutput["apis"].([]interface{})
+	assert.Equal(t, "dev-api", devAPIs[0].(map[string]interface{})["id"])
+	prodOutput := envs["prod"].(map[string]interface{})["output"].(map[string]interface{})
+	prodAPIs := prodOutput["apis"].([]interface{})
+	assert.Equal(t, "prod-api", prodAPIs[0].(map[string]interface{})["id"])
+}
+
+func TestForeachEnv_UnknownEnvironment(t *testing.T) {
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "dev",
+		Environments: map[string]*types.Environment{
+			"dev": {Name: "dev", DashboardURL: "http://localhost:3000", AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	root := NewRootCommand("test", "", "")
+	root.SetArgs([]string{"foreach-env", "--envs", "missing", "--", "api", "list"})
+	err := root.Execute()
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
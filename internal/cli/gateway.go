@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewGatewayCommand creates the 'tyk gateway' command and its subcommands
+func NewGatewayCommand() *cobra.Command {
+	gatewayCmd := &cobra.Command{
+		Use:   "gateway",
+		Short: "Operate on an open-source Tyk Gateway",
+		Long: `Commands for environments of type "gateway", which talk to the Tyk Gateway
+REST API directly instead of going through the Dashboard.`,
+	}
+
+	gatewayCmd.AddCommand(NewGatewayReloadCommand())
+
+	return gatewayCmd
+}
+
+// NewGatewayReloadCommand creates the 'tyk gateway reload' command
+func NewGatewayReloadCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reload",
+		Short: "Hot reload the Gateway group",
+		Long: `Trigger a hot reload of every Gateway in the group so API changes take effect.
+
+The Gateway API does not apply created, updated or deleted APIs until it reloads.
+
+Examples:
+  tyk api apply --file api.yaml --env oss && tyk gateway reload --env oss`,
+		RunE: runGatewayReload,
+	}
+}
+
+func runGatewayReload(cmd *cobra.Command, args []string) error {
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	if !c.IsGateway() {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "the active environment is not a gateway environment (set type = \"gateway\")"}
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	if err := c.ReloadGateway(ctx); err != nil {
+		return wrapAPIError(err, "failed to reload gateway")
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"operation": "reloaded",
+			"success":   true,
+		})
+	}
+
+	color.New(color.FgGreen, color.Bold).Println("✓ Gateway reload triggered")
+	return nil
+}
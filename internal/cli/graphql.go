@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/graphql"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// graphqlResult is the structured output of 'tyk graphql create', 'update' and 'get'
+type graphqlResult struct {
+	APIID            string   `json:"api_id" yaml:"api_id"`
+	Name             string   `json:"name" yaml:"name"`
+	ListenPath       string   `json:"listen_path" yaml:"listen_path"`
+	ExecutionMode    string   `json:"execution_mode" yaml:"execution_mode"`
+	UpstreamURL      string   `json:"upstream_url,omitempty" yaml:"upstream_url,omitempty"`
+	DataSources      []string `json:"data_sources" yaml:"data_sources"`
+	UnresolvedFields []string `json:"unresolved_fields,omitempty" yaml:"unresolved_fields,omitempty"`
+	Operation        string   `json:"operation,omitempty" yaml:"operation,omitempty"`
+}
+
+// NewGraphQLCommand creates the 'tyk graphql' command and its subcommands
+func NewGraphQLCommand() *cobra.Command {
+	graphqlCmd := &cobra.Command{
+		Use:   "graphql",
+		Short: "Manage GraphQL proxies and Universal Data Graphs",
+		Long: `Commands for GraphQL APIs: proxies passing queries through to a GraphQL server, and
+Universal Data Graphs the Gateway resolves from REST and GraphQL data sources.
+
+GraphQL APIs are classic API definitions, not OAS ones, so the 'tyk api' commands do
+not manage them.`,
+	}
+
+	graphqlCmd.AddCommand(NewGraphQLCreateCommand())
+	graphqlCmd.AddCommand(NewGraphQLUpdateCommand())
+	graphqlCmd.AddCommand(NewGraphQLGetCommand())
+
+	return graphqlCmd
+}
+
+// graphqlDataSourcesHelp documents the --data-sources file
+const graphqlDataSourcesHelp = `A --data-sources file lists the upstreams of a Universal Data Graph and the fields,
+as Type.field, each resolves. REST URLs and bodies can use the field's arguments
+({{.arguments.id}}) and the parent object ({{.object.id}}):
+
+  data_sources:
+    - name: users
+      kind: REST
+      url: https://users.internal/users/{{.arguments.id}}
+      fields: [Query.user]
+    - name: reviews
+      kind: GraphQL
+      url: https://reviews.internal/query
+      headers:
+        Authorization: Bearer ${REVIEWS_TOKEN}
+      fields: [Query.reviews, User.reviews]`
+
+// NewGraphQLCreateCommand creates the 'tyk graphql create' command
+func NewGraphQLCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a GraphQL proxy or Universal Data Graph",
+		Long: `Create a GraphQL API from a schema file.
+
+With --upstream-url the API is a proxy to that GraphQL server; the Gateway validates
+queries against the schema before passing them on. With --data-sources the API is a
+Universal Data Graph, resolving each field from the data source listed for it.
+
+The API takes auth tokens unless --keyless is given.
+
+` + graphqlDataSourcesHelp + `
+
+Examples:
+  tyk graphql create --name Countries --schema countries.graphql --upstream-url https://countries.internal/graphql
+  tyk graphql create --name "Users Graph" --schema users.graphql --data-sources sources.yaml --playground
+  tyk graphql create --name Countries --schema countries.graphql --upstream-url https://countries.internal/graphql --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: runGraphQLCreate,
+	}
+
+	cmd.Flags().String("name", "", "API name (required)")
+	cmd.Flags().String("schema", "", "GraphQL schema file, in SDL (required)")
+	cmd.Flags().String("upstream-url", "", "GraphQL server to proxy queries to")
+	cmd.Flags().String("data-sources", "", "Data source file making the API a Universal Data Graph")
+	cmd.Flags().String("listen-path", "", "Listen path (default: derived from the name)")
+	cmd.Flags().Bool("keyless", false, "Open the API without authentication")
+	cmd.Flags().Bool("playground", false, "Serve the GraphiQL playground at <listen-path>/playground")
+	cmd.Flags().Bool("dry-run", false, "Print the API definition without creating it")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("schema")
+
+	return cmd
+}
+
+// NewGraphQLUpdateCommand creates the 'tyk graphql update' command
+func NewGraphQLUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update <api-id>",
+		Short: "Upload a new schema, upstream or data sources to a GraphQL API",
+		Long: `Update a GraphQL API created with 'tyk graphql create' or the Dashboard. Only what is
+given changes: --schema replaces the schema, --upstream-url makes the API a proxy to
+that server and --data-sources replaces its data sources, making it a Universal Data
+Graph. The data sources of a Universal Data Graph must resolve fields of its schema.
+
+Examples:
+  tyk graphql update 7c2f4a1b --schema countries.graphql
+  tyk graphql update 7c2f4a1b --data-sources sources.yaml --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: runGraphQLUpdate,
+	}
+
+	cmd.Flags().String("schema", "", "GraphQL schema file, in SDL")
+	cmd.Flags().String("upstream-url", "", "GraphQL server to proxy queries to")
+	cmd.Flags().String("data-sources", "", "Data source file making the API a Universal Data Graph")
+	cmd.Flags().Bool("dry-run", false, "Print the API definition without uploading it")
+
+	return cmd
+}
+
+// NewGraphQLGetCommand creates the 'tyk graphql get' command
+func NewGraphQLGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <api-id>",
+		Short: "Show a GraphQL API, or print its schema",
+		Long: `Show how a GraphQL API is served. --schema prints its schema instead, for example to
+edit it and upload it again with 'tyk graphql update'.
+
+Examples:
+  tyk graphql get 7c2f4a1b
+  tyk graphql get 7c2f4a1b --schema > countries.graphql`,
+		Args: cobra.ExactArgs(1),
+		RunE: runGraphQLGet,
+	}
+
+	cmd.Flags().Bool("schema", false, "Print the schema in SDL")
+
+	return cmd
+}
+
+func runGraphQLCreate(cmd *cobra.Command, args []string) error {
+	opts, err := graphqlOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.ListenPath, _ = cmd.Flags().GetString("listen-path")
+	opts.Keyless, _ = cmd.Flags().GetBool("keyless")
+	opts.Playground, _ = cmd.Flags().GetBool("playground")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	if env, err := config.GetActiveEnvironment(); err == nil {
+		opts.OrgID = env.OrgID
+	}
+	def, err := graphql.NewDefinition(opts)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	if dryRun {
+		return printClassicDefinition(cmd, def)
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	apiID, err := c.CreateClassicAPI(ctx, def)
+	if err != nil {
+		if errors.Is(err, client.ErrConflict) {
+			return conflictError(err, "GraphQL API creation failed")
+		}
+		return wrapAPIError(err, "failed to create GraphQL API")
+	}
+	return printGraphQLResult(cmd, apiID, def, "created")
+}
+
+func runGraphQLUpdate(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	opts, err := graphqlOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if opts.Schema == "" && opts.UpstreamURL == "" && opts.DataSources == nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "nothing to update: give --schema, --upstream-url or --data-sources"}
+	}
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	def, err := getGraphQLAPI(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	if err := graphql.Apply(def, opts); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+	if dryRun {
+		return printClassicDefinition(cmd, def)
+	}
+	if err := c.UpdateClassicAPI(ctx, apiID, def); err != nil {
+		return wrapAPIError(err, "failed to update GraphQL API")
+	}
+	return printGraphQLResult(cmd, apiID, def, "updated")
+}
+
+func runGraphQLGet(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	schemaOnly, _ := cmd.Flags().GetBool("schema")
+
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	def, err := getGraphQLAPI(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	if schemaOnly {
+		gql, _ := def["graphql"].(map[string]interface{})
+		schema, _ := gql["schema"].(string)
+		fmt.Print(strings.TrimSuffix(schema, "\n") + "\n")
+		return nil
+	}
+	return printGraphQLResult(cmd, apiID, def, "")
+}
+
+// graphqlOptionsFromFlags reads the schema, upstream and data source flags
+func graphqlOptionsFromFlags(cmd *cobra.Command) (graphql.Options, error) {
+	var opts graphql.Options
+	schemaPath, _ := cmd.Flags().GetString("schema")
+	dataSourcesPath, _ := cmd.Flags().GetString("data-sources")
+	opts.UpstreamURL, _ = cmd.Flags().GetString("upstream-url")
+
+	if schemaPath != "" {
+		data, err := os.ReadFile(schemaPath)
+		if err != nil {
+			return opts, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read schema: %v", err)}
+		}
+		opts.Schema = string(data)
+	}
+	if dataSourcesPath != "" {
+		sources, err := graphql.LoadDataSources(dataSourcesPath)
+		if err != nil {
+			return opts, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", dataSourcesPath, err)}
+		}
+		opts.DataSources = sources
+	}
+	if opts.UpstreamURL != "" && opts.DataSources != nil {
+		return opts, &ExitError{Code: int(types.ExitBadArgs), Message: "--upstream-url and --data-sources cannot be combined: a GraphQL API is either a proxy or a Universal Data Graph"}
+	}
+	return opts, nil
+}
+
+// getGraphQLAPI fetches a classic API definition and checks it is a GraphQL API
+func getGraphQLAPI(ctx context.Context, c *client.Client, apiID string) (map[string]interface{}, error) {
+	def, err := c.GetClassicAPI(ctx, apiID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return nil, notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
+		}
+		return nil, wrapAPIError(err, "failed to get API")
+	}
+	if gql, _ := def["graphql"].(map[string]interface{}); gql["enabled"] != true {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("API '%s' is not a GraphQL API", apiID)}
+	}
+	return def, nil
+}
+
+// printClassicDefinition prints the classic API definition a dry run would upload
+func printClassicDefinition(cmd *cobra.Command, def map[string]interface{}) error {
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, def)
+	}
+	out, err := filehandler.ConvertToYAML(def)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stdout.Write(out); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "\nDry run: nothing was uploaded.")
+	return nil
+}
+
+func printGraphQLResult(cmd *cobra.Command, apiID string, def map[string]interface{}, operation string) error {
+	gql, _ := def["graphql"].(map[string]interface{})
+	proxy, _ := def["proxy"].(map[string]interface{})
+	result := &graphqlResult{APIID: apiID, Operation: operation, DataSources: []string{}}
+	result.Name, _ = def["name"].(string)
+	result.ListenPath, _ = proxy["listen_path"].(string)
+	result.UpstreamURL, _ = proxy["target_url"].(string)
+	result.ExecutionMode, _ = gql["execution_mode"].(string)
+	engine, _ := gql["engine"].(map[string]interface{})
+	sources, _ := engine["data_sources"].([]interface{})
+	for _, item := range sources {
+		source, _ := item.(map[string]interface{})
+		name, _ := source["name"].(string)
+		result.DataSources = append(result.DataSources, name)
+	}
+	result.UnresolvedFields = graphql.UnresolvedFields(def)
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, result)
+	}
+	kind := "GraphQL proxy"
+	if result.ExecutionMode == graphql.ModeExecutionEngine {
+		kind = "Universal Data Graph"
+	}
+	if operation != "" {
+		color.New(color.FgGreen).Printf("✓ %s %s: %s (%s)\n", kind, operation, result.Name, apiID)
+	} else {
+		fmt.Printf("%s: %s (%s)\n", kind, result.Name, apiID)
+	}
+	fmt.Printf("  Listen path:   %s\n", result.ListenPath)
+	if result.UpstreamURL != "" {
+		fmt.Printf("  Upstream:      %s\n", result.UpstreamURL)
+	}
+	if len(result.DataSources) > 0 {
+		fmt.Printf("  Data sources:  %s\n", strings.Join(result.DataSources, ", "))
+	}
+	if len(result.UnresolvedFields) > 0 {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ No data source resolves %s; queries for them return null.\n", strings.Join(result.UnresolvedFields, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/graphql"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestGraphQLCommands(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	dir := t.TempDir()
+	schemaFile := filepath.Join(dir, "users.graphql")
+	require.NoError(t, os.WriteFile(schemaFile, []byte("type Query { user(id: ID!): User users: [User] }\ntype User { id: ID! name: String }\n"), 0o644))
+	sourcesFile := filepath.Join(dir, "sources.yaml")
+	require.NoError(t, os.WriteFile(sourcesFile, []byte(`data_sources:
+  - name: users
+    kind: REST
+    url: https://users.example.com/users/{{.arguments.id}}
+    headers:
+      Authorization: Bearer ${GRAPHQL_TEST_TOKEN}
+    fields: [Query.user]
+`), 0o644))
+	t.Setenv("GRAPHQL_TEST_TOKEN", "secret")
+
+	out, err := runRootCommand(t, "graphql", "create", "--name", "Users", "--schema", schemaFile, "--upstream-url", "https://users.example.com/graphql", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("graphql", out), string(out))
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &created))
+	apiID := created["api_id"].(string)
+	require.Contains(t, dashboard.classic, apiID)
+	assert.Equal(t, "org", dashboard.classic[apiID]["org_id"])
+	assert.Equal(t, graphql.ModeProxyOnly, created["execution_mode"])
+	assert.Equal(t, "/users/", created["listen_path"])
+
+	// Data sources turn the proxy into a Universal Data Graph
+	out, err = runRootCommand(t, "graphql", "update", apiID, "--data-sources", sourcesFile, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("graphql", out), string(out))
+	var updated map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &updated))
+	assert.Equal(t, graphql.ModeExecutionEngine, updated["execution_mode"])
+	assert.Equal(t, []interface{}{"users"}, updated["data_sources"])
+	assert.Equal(t, []interface{}{"Query.users"}, updated["unresolved_fields"])
+	engine := dashboard.classic[apiID]["graphql"].(map[string]interface{})["engine"].(map[string]interface{})
+	source := engine["data_sources"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Bearer secret", source["config"].(map[string]interface{})["headers"].(map[string]interface{})["Authorization"])
+
+	out, err = runRootCommand(t, "graphql", "get", apiID, "--schema")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "type User { id: ID! name: String }")
+
+	// The schema must keep the fields the data sources resolve
+	require.NoError(t, os.WriteFile(schemaFile, []byte("type Query { users: [String] }\n"), 0o644))
+	_, err = runRootCommand(t, "graphql", "update", apiID, "--schema", schemaFile)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+
+	_, err = runRootCommand(t, "graphql", "get", "missing")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+
+	_, err = runRootCommand(t, "graphql", "create", "--name", "Both", "--schema", schemaFile, "--upstream-url", "https://users.example.com/graphql", "--data-sources", sourcesFile)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
+
+func TestGraphQLCreate_DryRun(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	schemaFile := filepath.Join(t.TempDir(), "countries.graphql")
+	require.NoError(t, os.WriteFile(schemaFile, []byte("type Query { countries: [String] }\n"), 0o644))
+
+	out, err := runRootCommand(t, "graphql", "create", "--name", "Countries", "--schema", schemaFile, "--upstream-url", "https://countries.example.com/graphql", "--keyless", "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	var def map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &def))
+	assert.Equal(t, true, def["use_keyless"])
+	assert.Equal(t, "type Query { countries: [String] }\n", def["graphql"].(map[string]interface{})["schema"])
+	assert.Empty(t, dashboard.classic)
+}
@@ -2,7 +2,6 @@ package cli
 
 import (
 	"bufio"
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -266,7 +265,7 @@ func testConnection(env *types.Environment) error {
 		return err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := apiContext(config, 10*time.Second)
 	defer cancel()
 
 	return client.Health(ctx)
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewKeyCommand creates the 'tyk key' command and its subcommands
+func NewKeyCommand() *cobra.Command {
+	keyCmd := &cobra.Command{
+		Use:   "key",
+		Short: "Manage API keys",
+		Long:  `Commands for managing the API keys consumers use to call your APIs.`,
+	}
+
+	keyCmd.AddCommand(NewKeyRotateCommand())
+	keyCmd.AddCommand(NewKeyImportCommand())
+	keyCmd.AddCommand(NewKeyResetQuotaCommand())
+
+	return keyCmd
+}
+
+// NewKeyRotateCommand creates the 'tyk key rotate' command
+func NewKeyRotateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate <key-id>",
+		Short: "Replace a key with a new one that has the same policies and metadata",
+		Long: `Create a replacement key with the same policies, access rights, quotas and metadata
+as an existing key, then revoke the old key.
+
+With --overlap the old key is not revoked straight away; it is set to expire after the
+overlap window so consumers have time to switch to the new key.
+
+HMAC secrets are not copied; the new key gets a fresh secret.
+
+Examples:
+  tyk key rotate 5e9d9544a1dcd60001d0ed20
+  tyk key rotate 5e9d9544a1dcd60001d0ed20 --overlap 24h
+  tyk key rotate 5e9d9544a1dcd60001d0ed20 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runKeyRotate,
+	}
+
+	cmd.Flags().Duration("overlap", 0, "Keep the old key valid for this long instead of revoking it now (e.g. 24h)")
+
+	return cmd
+}
+
+// keyRotation is the result of 'tyk key rotate'
+type keyRotation struct {
+	OldKeyID      string `json:"old_key_id"`
+	NewKeyID      string `json:"new_key_id"`
+	Revoked       bool   `json:"revoked"`
+	OldKeyExpires string `json:"old_key_expires,omitempty"`
+}
+
+// sessionFieldsNotCopied are reset on a replacement key: identity, bookkeeping and secrets
+var sessionFieldsNotCopied = []string{"key_id", "key_hash", "last_check", "last_updated", "date_created", "hmac_string"}
+
+func runKeyRotate(cmd *cobra.Command, args []string) error {
+	keyID := args[0]
+	overlap, _ := cmd.Flags().GetDuration("overlap")
+	if overlap < 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--overlap must not be negative"}
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	session, err := c.GetKey(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("key '%s' not found", keyID))
+		}
+		return wrapAPIError(err, "failed to get key")
+	}
+	if basicAuth, ok := session["basic_auth_data"].(map[string]interface{}); ok {
+		if password, _ := basicAuth["password"].(string); password != "" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "basic auth keys are identified by username and cannot be rotated; change the password instead"}
+		}
+	}
+
+	replacement := replacementSession(session)
+	newKeyID, err := c.CreateKey(ctx, replacement)
+	if err != nil {
+		return wrapAPIError(err, "failed to create replacement key")
+	}
+	result := &keyRotation{OldKeyID: keyID, NewKeyID: newKeyID}
+
+	var retireErr error
+	if overlap > 0 {
+		expires := time.Now().Add(overlap)
+		if current := sessionExpiry(session); !current.IsZero() && current.Before(expires) {
+			expires = current
+		}
+		session["expires"] = expires.Unix()
+		if err := c.UpdateKey(ctx, keyID, session); err != nil {
+			retireErr = wrapAPIError(err, "created replacement key but failed to set expiry on the old key")
+		} else {
+			result.OldKeyExpires = expires.UTC().Format(time.RFC3339)
+		}
+	} else {
+		if err := c.DeleteKey(ctx, keyID); err != nil {
+			retireErr = wrapAPIError(err, "created replacement key but failed to revoke the old key")
+		} else {
+			result.Revoked = true
+		}
+	}
+
+	// Always report the new key, even when retiring the old one failed
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if err := writeStructured(format, result); err != nil {
+			return err
+		}
+	} else {
+		printKeyRotation(result)
+	}
+	return retireErr
+}
+
+// replacementSession copies a session, dropping fields that identify or belong to the old key
+func replacementSession(session types.Session) types.Session {
+	replacement := make(types.Session, len(session))
+	for field, value := range session {
+		replacement[field] = value
+	}
+	for _, field := range sessionFieldsNotCopied {
+		delete(replacement, field)
+	}
+	return replacement
+}
+
+// sessionExpiry returns when a session expires, or the zero time if it never does
+func sessionExpiry(session types.Session) time.Time {
+	expires, _ := session["expires"].(float64)
+	if expires <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(expires), 0)
+}
+
+// NewKeyResetQuotaCommand creates the 'tyk key reset-quota' command
+func NewKeyResetQuotaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-quota <key-id>",
+		Short: "Restore a key's full request quota",
+		Long: `Reset a key's quota so a consumer that exhausted it can make requests again.
+Per-API quotas on the key are reset too. The quota period is not changed.
+
+Examples:
+  tyk key reset-quota 5e9d9544a1dcd60001d0ed20
+  tyk key reset-quota 5e9d9544a1dcd60001d0ed20 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runKeyResetQuota,
+	}
+}
+
+func runKeyResetQuota(cmd *cobra.Command, args []string) error {
+	keyID := args[0]
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	session, err := c.GetKey(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return notFoundError(err, fmt.Sprintf("key '%s' not found", keyID))
+		}
+		return wrapAPIError(err, "failed to get key")
+	}
+
+	// A negative quota_max is unlimited: there is no quota to reset
+	quotaMax, _ := session["quota_max"].(float64)
+	unlimited := quotaMax < 0
+	if !unlimited {
+		if err := c.ResetKeyQuota(ctx, keyID, session); err != nil {
+			return wrapAPIError(err, "failed to reset quota")
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		result := map[string]interface{}{
+			"key_id":    keyID,
+			"quota_max": int64(quotaMax),
+			"unlimited": unlimited,
+		}
+		if !unlimited {
+			result["quota_remaining"] = int64(quotaMax)
+		}
+		return writeStructured(format, result)
+	}
+
+	if unlimited {
+		color.New(color.FgYellow).Printf("⚠ Key %s has an unlimited quota; nothing to reset\n", keyID)
+		return nil
+	}
+	color.New(color.FgGreen, color.Bold).Printf("✓ Quota reset for key %s (%d requests available)\n", keyID, int64(quotaMax))
+	return nil
+}
+
+func printKeyRotation(result *keyRotation) {
+	green := color.New(color.FgGreen, color.Bold)
+	green.Printf("✓ Created key %s\n", result.NewKeyID)
+	fmt.Printf("  Policies, access rights and metadata copied from %s\n", result.OldKeyID)
+	switch {
+	case result.Revoked:
+		green.Printf("✓ Revoked key %s\n", result.OldKeyID)
+	case result.OldKeyExpires != "":
+		color.New(color.FgYellow).Printf("⚠ Key %s stays valid until %s\n", result.OldKeyID, result.OldKeyExpires)
+	}
+}
@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewKeyImportCommand creates the 'tyk key import' command
+func NewKeyImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create keys in bulk from a CSV file",
+		Long: `Create one key per row of a CSV file and write the new key IDs to --out.
+
+The first row is a header. Recognised columns:
+
+  alias     key alias, usually the consumer's name (required)
+  policy    policy ID to apply; separate several with ';' (required)
+  expires   expiry as YYYY-MM-DD or RFC 3339; empty or "never" for no expiry
+
+Any other column is stored in the key's metadata under the column name.
+
+Every row is checked before any key is created. The credentials file is created with
+owner-only permissions and is never overwritten.
+
+Examples:
+  tyk key import --file consumers.csv --out credentials.csv
+  tyk key import --file consumers.csv --out credentials.csv -o json`,
+		Args: cobra.NoArgs,
+		RunE: runKeyImport,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "CSV file of consumers (required)")
+	cmd.Flags().String("out", "", "CSV file to write the created key IDs to (required, must not exist)")
+	cmd.MarkFlagRequired("file")
+	cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+// keyImportRow is one consumer read from the CSV file
+type keyImportRow struct {
+	Line     int
+	Alias    string
+	Policies []string
+	Expires  time.Time
+	Meta     map[string]interface{}
+}
+
+// keyImportError reports a row that failed to import
+type keyImportError struct {
+	Line  int    `json:"line"`
+	Alias string `json:"alias"`
+	Error string `json:"error"`
+}
+
+func runKeyImport(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	outPath, _ := cmd.Flags().GetString("out")
+
+	rows, err := readKeyImportFile(filePath)
+	if err != nil {
+		return err
+	}
+	progress, err := newProgressReporter(cmd, "key import")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	// Open the credentials file first so no key is created that cannot be recorded
+	out, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("output file %s already exists; choose another --out", outPath)}
+		}
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer out.Close()
+	credentials := csv.NewWriter(out)
+	credentials.Write([]string{"line", "alias", "key_id"})
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	failures := []keyImportError{}
+	created := 0
+	progress.Start(len(rows))
+	for _, row := range rows {
+		step := fmt.Sprintf("%d:%s", row.Line, row.Alias)
+		progress.StepStarted(step)
+
+		keyID, err := c.CreateKey(ctx, row.session(env.OrgID))
+		if err != nil {
+			msg := wrapAPIError(err, "failed to create key").Error()
+			failures = append(failures, keyImportError{Line: row.Line, Alias: row.Alias, Error: msg})
+			progress.StepFinished(step, msg)
+			continue
+		}
+		credentials.Write([]string{fmt.Sprint(row.Line), row.Alias, keyID})
+		credentials.Flush()
+		created++
+		progress.StepFinished(step, "")
+	}
+	progress.Finish()
+
+	if err := credentials.Error(); err != nil {
+		return fmt.Errorf("failed to write %s (%d key(s) were created): %w", outPath, created, err)
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if err := writeStructured(format, map[string]interface{}{
+			"file":    filePath,
+			"out":     outPath,
+			"created": created,
+			"failed":  len(failures),
+			"errors":  failures,
+		}); err != nil {
+			return err
+		}
+	} else {
+		if created > 0 {
+			color.New(color.FgGreen, color.Bold).Printf("✓ Created %d key(s); credentials written to %s\n", created, outPath)
+		}
+		for _, failure := range failures {
+			color.New(color.FgRed).Fprintf(os.Stderr, "✗ line %d (%s): %s\n", failure.Line, failure.Alias, failure.Error)
+		}
+	}
+
+	if len(failures) > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d key(s) failed to import", len(failures), len(rows))}
+	}
+	return nil
+}
+
+// session builds the session object for a new key
+func (r *keyImportRow) session(orgID string) types.Session {
+	policies := make([]interface{}, len(r.Policies))
+	for i, policy := range r.Policies {
+		policies[i] = policy
+	}
+	session := types.Session{
+		"alias":          r.Alias,
+		"apply_policies": policies,
+		"org_id":         orgID,
+		"expires":        int64(0),
+	}
+	if !r.Expires.IsZero() {
+		session["expires"] = r.Expires.Unix()
+	}
+	if len(r.Meta) > 0 {
+		session["meta_data"] = r.Meta
+	}
+	return session
+}
+
+// readKeyImportFile parses and validates every row of a consumers CSV file
+func readKeyImportFile(path string) ([]*keyImportRow, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to open %s: %v", path, err)}
+	}
+	defer f.Close()
+	return parseKeyImportCSV(f)
+}
+
+func parseKeyImportCSV(r io.Reader) ([]*keyImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read CSV header: %v", err)}
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "policies" {
+			name = "policy"
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"alias", "policy"} {
+		if _, ok := columns[required]; !ok {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("CSV header is missing the '%s' column", required)}
+		}
+	}
+
+	var rows []*keyImportRow
+	var problems []string
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid CSV: %v", err)}
+		}
+
+		row := &keyImportRow{Line: line, Meta: make(map[string]interface{})}
+		for name, i := range columns {
+			value := strings.TrimSpace(record[i])
+			switch name {
+			case "alias":
+				row.Alias = value
+			case "policy":
+				for _, policy := range strings.Split(value, ";") {
+					if policy = strings.TrimSpace(policy); policy != "" {
+						row.Policies = append(row.Policies, policy)
+					}
+				}
+			case "expires":
+				expires, err := parseKeyExpiry(value)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("line %d: %v", line, err))
+				}
+				row.Expires = expires
+			default:
+				if value != "" {
+					row.Meta[header[i]] = value
+				}
+			}
+		}
+		if row.Alias == "" {
+			problems = append(problems, fmt.Sprintf("line %d: alias is empty", line))
+		}
+		if len(row.Policies) == 0 {
+			problems = append(problems, fmt.Sprintf("line %d: no policy given", line))
+		}
+		rows = append(rows, row)
+	}
+
+	if len(problems) > 0 {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: "invalid rows, no keys were created:\n  " + strings.Join(problems, "\n  ")}
+	}
+	if len(rows) == 0 {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: "CSV file has no rows"}
+	}
+	return rows, nil
+}
+
+// parseKeyExpiry accepts YYYY-MM-DD (end of that day, UTC), RFC 3339, or empty/"never"
+func parseKeyExpiry(value string) (time.Time, error) {
+	if value == "" || strings.EqualFold(value, "never") {
+		return time.Time{}, nil
+	}
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return day.Add(24*time.Hour - time.Second), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry '%s' (expected YYYY-MM-DD or RFC 3339)", value)
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func seedKey(d *fakeDashboard, keyID string) {
+	d.keys[keyID] = types.Session{
+		"apply_policies": []interface{}{"pol-1"},
+		"meta_data":      map[string]interface{}{"team": "payments"},
+		"quota_max":      float64(1000),
+		"hmac_string":    "old-secret",
+		"last_updated":   "1700000000",
+		"expires":        float64(0),
+	}
+}
+
+func TestKeyRotate(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedKey(dashboard, "old-key")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "key", "rotate", "old-key", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("key-rotate", out), string(out))
+
+	var result keyRotation
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.True(t, result.Revoked)
+	assert.NotContains(t, dashboard.keys, "old-key")
+
+	replacement := dashboard.keys[result.NewKeyID]
+	require.NotNil(t, replacement)
+	assert.Equal(t, []interface{}{"pol-1"}, replacement["apply_policies"])
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, replacement["meta_data"])
+	assert.NotContains(t, replacement, "hmac_string")
+	assert.NotContains(t, replacement, "last_updated")
+}
+
+func TestKeyRotate_Overlap(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedKey(dashboard, "old-key")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "key", "rotate", "old-key", "--overlap", "24h", "-o", "json")
+	require.NoError(t, err)
+
+	var result keyRotation
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.False(t, result.Revoked)
+	require.Contains(t, dashboard.keys, "old-key")
+	expires := time.Unix(int64(dashboard.keys["old-key"]["expires"].(float64)), 0)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expires, time.Minute)
+	assert.NotEmpty(t, result.OldKeyExpires)
+
+	// An earlier existing expiry is kept
+	soon := time.Now().Add(time.Hour).Unix()
+	dashboard.keys[result.NewKeyID]["expires"] = float64(soon)
+	_, err = runRootCommand(t, "key", "rotate", result.NewKeyID, "--overlap", "24h", "-o", "json")
+	require.NoError(t, err)
+	assert.Equal(t, float64(soon), dashboard.keys[result.NewKeyID]["expires"])
+}
+
+func TestKeyRotate_NotFound(t *testing.T) {
+	_, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "key", "rotate", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
+
+func TestKeyImport(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "consumers.csv")
+	require.NoError(t, os.WriteFile(file, []byte("alias,policy,expires,partner_id\nacme,pol-1;pol-2,2030-01-31,P-1\nglobex,pol-1,,\n"), 0644))
+	out := filepath.Join(dir, "credentials.csv")
+
+	stdout, err := runRootCommand(t, "key", "import", "--file", file, "--out", out, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("key-import", stdout), string(stdout))
+	assert.Len(t, dashboard.keys, 2)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"line", "alias", "key_id"}, records[0])
+	assert.Equal(t, "acme", records[1][1])
+
+	acme := dashboard.keys[records[1][2]]
+	assert.Equal(t, []interface{}{"pol-1", "pol-2"}, acme["apply_policies"])
+	assert.Equal(t, map[string]interface{}{"partner_id": "P-1"}, acme["meta_data"])
+	assert.Equal(t, "org", acme["org_id"])
+	assert.Equal(t, float64(time.Date(2030, 1, 31, 23, 59, 59, 0, time.UTC).Unix()), acme["expires"])
+	globex := dashboard.keys[records[2][2]]
+	assert.Equal(t, float64(0), globex["expires"])
+	assert.NotContains(t, globex, "meta_data")
+
+	info, err := os.Stat(out)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// The credentials file is never overwritten
+	_, err = runRootCommand(t, "key", "import", "--file", file, "--out", out)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Len(t, dashboard.keys, 2)
+}
+
+func TestParseKeyImportCSV_Invalid(t *testing.T) {
+	_, err := parseKeyImportCSV(strings.NewReader("alias,expires\nacme,\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'policy' column")
+
+	_, err = parseKeyImportCSV(strings.NewReader("alias,policy,expires\nacme,pol-1,tomorrow\n,pol-1,\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: invalid expiry 'tomorrow'")
+	assert.Contains(t, err.Error(), "line 3: alias is empty")
+
+	rows, err := parseKeyImportCSV(strings.NewReader("Alias,Policies\nacme,pol-1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pol-1"}, rows[0].Policies)
+}
+
+func TestKeyResetQuota(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dashboard.keys["exhausted"] = types.Session{
+		"quota_max":       float64(1000),
+		"quota_remaining": float64(0),
+		"access_rights": map[string]interface{}{
+			"api-1": map[string]interface{}{
+				"api_id": "api-1",
+				"limit":  map[string]interface{}{"quota_max": float64(50), "quota_remaining": float64(0)},
+			},
+		},
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "key", "reset-quota", "exhausted", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("key-reset-quota", out), string(out))
+
+	session := dashboard.keys["exhausted"]
+	assert.Equal(t, float64(1000), session["quota_remaining"])
+	limit := session["access_rights"].(map[string]interface{})["api-1"].(map[string]interface{})["limit"].(map[string]interface{})
+	assert.Equal(t, float64(50), limit["quota_remaining"])
+	assert.Equal(t, 1, dashboard.quotaResets)
+
+	// Other key updates leave the live quota alone
+	_, err = runRootCommand(t, "key", "rotate", "exhausted", "--overlap", "1h", "-o", "json")
+	require.NoError(t, err)
+	assert.Equal(t, 1, dashboard.quotaResets)
+
+	// Unlimited keys have nothing to reset and are not saved
+	dashboard.keys["unlimited"] = types.Session{"quota_max": float64(-1), "quota_remaining": float64(-1)}
+	out, err = runRootCommand(t, "key", "reset-quota", "unlimited", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("key-reset-quota", out), string(out))
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, true, result["unlimited"])
+	assert.NotContains(t, result, "quota_remaining")
+	assert.Equal(t, 1, dashboard.quotaResets)
+}
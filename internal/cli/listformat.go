@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// listOutputOptions holds the --columns/--template settings shared by list commands
+type listOutputOptions struct {
+	Columns   []string
+	Template  *template.Template
+	NoHeaders bool
+}
+
+// templateEscapes lets shell-quoted templates such as '{{.ID}}\t{{.Name}}' contain tabs
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// addListOutputFlags registers --columns, --template and --no-headers on a list command
+func addListOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("columns", nil, "Only print these fields, by JSON name (e.g. id,name,listen_path)")
+	cmd.Flags().String("template", "", "Go template applied to each item (e.g. '{{.ID}}\\t{{.Name}}')")
+	cmd.Flags().Bool("no-headers", false, "Omit the header row when using --columns")
+}
+
+// getListOutputOptions reads the list output flags; it returns nil when neither is set
+func getListOutputOptions(cmd *cobra.Command) (*listOutputOptions, error) {
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	tmpl, _ := cmd.Flags().GetString("template")
+	noHeaders, _ := cmd.Flags().GetBool("no-headers")
+
+	if len(columns) == 0 && tmpl == "" {
+		return nil, nil
+	}
+	if len(columns) > 0 && tmpl != "" {
+		return nil, &ExitError{Code: 2, Message: "--columns and --template cannot be used together"}
+	}
+	if GetOutputFormatFromContext(cmd.Context()).IsStructured() {
+		return nil, &ExitError{Code: 2, Message: "--columns and --template cannot be combined with JSON or YAML output"}
+	}
+
+	opts := &listOutputOptions{Columns: columns, NoHeaders: noHeaders}
+	if tmpl != "" {
+		parsed, err := template.New("item").Parse(templateEscapes.Replace(tmpl))
+		if err != nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("invalid --template: %v", err)}
+		}
+		opts.Template = parsed
+	}
+	return opts, nil
+}
+
+// render writes every element of items (a slice) using the selected columns or template
+func (o *listOutputOptions) render(w io.Writer, items interface{}) error {
+	value := reflect.ValueOf(items)
+	if value.Kind() != reflect.Slice {
+		return fmt.Errorf("list output expects a slice, got %T", items)
+	}
+
+	if o.Template != nil {
+		for i := 0; i < value.Len(); i++ {
+			if err := o.Template.Execute(w, value.Index(i).Interface()); err != nil {
+				return &ExitError{Code: 2, Message: fmt.Sprintf("failed to execute --template: %v", err)}
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+
+	if known := jsonFieldNames(value.Type().Elem()); known != nil {
+		for _, column := range o.Columns {
+			if !known[column] {
+				return &ExitError{Code: 2, Message: fmt.Sprintf("unknown column '%s'; available: %s", column, strings.Join(sortedKeys(known), ", "))}
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if !o.NoHeaders {
+		headers := make([]string, len(o.Columns))
+		for i, column := range o.Columns {
+			headers[i] = strings.ToUpper(column)
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		fields, err := itemFields(value.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		cells := make([]string, len(o.Columns))
+		for j, column := range o.Columns {
+			cells[j] = formatCell(fields[column])
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// itemFields converts an item to a map keyed by its JSON field names
+func itemFields(item interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// formatCell renders a single value; nested objects are printed as compact JSON
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonFieldNames returns the JSON names of a struct type's fields, or nil for non-structs
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func newListOutputTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "list"}
+	addListOutputFlags(cmd)
+	cmd.SetContext(context.Background())
+	require.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+var listOutputTestAPIs = []*types.OASAPI{
+	{ID: "a1", Name: "Users", ListenPath: "/users/"},
+	{ID: "b2", Name: "Orders", ListenPath: "/orders/", CustomDomain: "api.example.com"},
+}
+
+func TestListOutput_Columns(t *testing.T) {
+	opts, err := getListOutputOptions(newListOutputTestCommand(t, "--columns", "id,custom_domain"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, opts.render(&buf, listOutputTestAPIs))
+	assert.Equal(t, "ID  CUSTOM_DOMAIN\na1  \nb2  api.example.com\n", buf.String())
+}
+
+func TestListOutput_ColumnsNoHeaders(t *testing.T) {
+	opts, err := getListOutputOptions(newListOutputTestCommand(t, "--columns", "name", "--no-headers"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, opts.render(&buf, listOutputTestAPIs))
+	assert.Equal(t, "Users\nOrders\n", buf.String())
+}
+
+func TestListOutput_UnknownColumn(t *testing.T) {
+	opts, err := getListOutputOptions(newListOutputTestCommand(t, "--columns", "id,owner"))
+	require.NoError(t, err)
+
+	err = opts.render(&bytes.Buffer{}, listOutputTestAPIs)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "listen_path")
+}
+
+func TestListOutput_Template(t *testing.T) {
+	opts, err := getListOutputOptions(newListOutputTestCommand(t, "--template", `{{.ID}}\t{{.Name}}`))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, opts.render(&buf, listOutputTestAPIs))
+	assert.Equal(t, "a1\tUsers\nb2\tOrders\n", buf.String())
+}
+
+func TestListOutput_Conflicts(t *testing.T) {
+	_, err := getListOutputOptions(newListOutputTestCommand(t, "--columns", "id", "--template", "{{.ID}}"))
+	assert.Error(t, err)
+
+	cmd := newListOutputTestCommand(t, "--columns", "id")
+	cmd.SetContext(withOutputFormat(cmd.Context(), types.OutputJSON))
+	_, err = getListOutputOptions(cmd)
+	assert.Error(t, err)
+
+	opts, err := getListOutputOptions(newListOutputTestCommand(t))
+	require.NoError(t, err)
+	assert.Nil(t, opts)
+}
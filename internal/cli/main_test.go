@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tyktech/tyk-cli/internal/audit"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/history"
+	"github.com/tyktech/tyk-cli/internal/trash"
+)
+
+// TestMain keeps the changes tests make against fake servers out of the real audit
+// log, revision history, trash and API cache, and shortens retry backoffs
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "tyk-audit")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv(audit.EnvLogPath, filepath.Join(dir, "audit.jsonl"))
+	os.Setenv(history.EnvDir, filepath.Join(dir, "history"))
+	os.Setenv(trash.EnvDir, filepath.Join(dir, "trash"))
+	os.Setenv(client.EnvAPICacheDir, filepath.Join(dir, "apis"))
+	client.DefaultRetryPolicy.Backoff = time.Millisecond
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/metrics"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// cliMetrics holds the metrics of long-running commands, served by --metrics-addr
+var cliMetrics = metrics.NewRegistry()
+
+var instrumentOnce sync.Once
+
+// instrumentRequests records every API request the CLI makes in cliMetrics. Installing
+// it more than once is a no-op.
+func instrumentRequests() {
+	instrumentOnce.Do(func() {
+		cliMetrics.Describe("tyk_cli_api_requests_total", metrics.Counter, "API requests made to Tyk, by method and status code (0 when the request failed).")
+		cliMetrics.Describe("tyk_cli_api_request_duration_seconds_total", metrics.Counter, "Total time spent in API requests to Tyk.")
+		client.Use(client.Instrument(func(event client.RequestEvent) {
+			cliMetrics.Add("tyk_cli_api_requests_total", metrics.Labels{"method": event.Method, "code": strconv.Itoa(event.Status)}, 1)
+			cliMetrics.Add("tyk_cli_api_request_duration_seconds_total", nil, event.Duration.Seconds())
+		}))
+	})
+}
+
+// serveMetrics serves cliMetrics, and any routes, on addr until ctx is done
+func serveMetrics(ctx context.Context, addr string, routes ...metrics.Route) error {
+	listening, err := metrics.Listen(ctx, addr, cliMetrics, routes...)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--metrics-addr: %v", err)}
+	}
+	fmt.Fprintf(os.Stderr, "Serving metrics on http://%s/metrics\n", listening)
+	return nil
+}
+
+// describeSyncMetrics declares the metrics of 'tyk sync --watch' and starts recording
+// API requests
+func describeSyncMetrics() {
+	instrumentRequests()
+	cliMetrics.Describe("tyk_sync_runs_total", metrics.Counter, "Sync runs, successful or not.")
+	cliMetrics.Describe("tyk_sync_run_failures_total", metrics.Counter, "Sync runs that failed to plan or had a change fail to apply.")
+	cliMetrics.Describe("tyk_sync_applies_total", metrics.Counter, "Changes applied, by environment and operation.")
+	cliMetrics.Describe("tyk_sync_apply_failures_total", metrics.Counter, "Changes that failed to apply, by environment.")
+	cliMetrics.Describe("tyk_sync_drift_apis", metrics.Gauge, "APIs that differed from their spec on the last run, by environment.")
+	cliMetrics.Describe("tyk_sync_last_run_timestamp_seconds", metrics.Gauge, "Unix time the last run started.")
+	cliMetrics.Describe("tyk_sync_last_success_timestamp_seconds", metrics.Gauge, "Unix time the last successful run started.")
+	cliMetrics.Describe("tyk_sync_last_run_duration_seconds", metrics.Gauge, "How long the last run took.")
+}
+
+// recordSyncRun records one sync run; report is nil when the run failed before
+// applying anything
+func recordSyncRun(report *syncReport, err error, started time.Time) {
+	cliMetrics.Add("tyk_sync_runs_total", nil, 1)
+	cliMetrics.Set("tyk_sync_last_run_timestamp_seconds", nil, float64(started.Unix()))
+	cliMetrics.Set("tyk_sync_last_run_duration_seconds", nil, time.Since(started).Seconds())
+	if err != nil || report.failed > 0 {
+		cliMetrics.Add("tyk_sync_run_failures_total", nil, 1)
+	} else {
+		cliMetrics.Set("tyk_sync_last_success_timestamp_seconds", nil, float64(started.Unix()))
+	}
+	if report == nil {
+		return
+	}
+
+	drift := map[string]int{}
+	for _, target := range report.targets {
+		if _, ok := drift[target.Environment]; !ok {
+			drift[target.Environment] = 0
+		}
+		for _, action := range target.Plan.Actions {
+			if action.Action != planNoChange {
+				drift[target.Environment]++
+			}
+		}
+		for _, result := range target.Results {
+			if result.Error != "" {
+				cliMetrics.Add("tyk_sync_apply_failures_total", metrics.Labels{"environment": target.Environment}, 1)
+				continue
+			}
+			cliMetrics.Add("tyk_sync_applies_total", metrics.Labels{"environment": target.Environment, "operation": result.Operation}, 1)
+		}
+	}
+	for env, count := range drift {
+		cliMetrics.Set("tyk_sync_drift_apis", metrics.Labels{"environment": env}, float64(count))
+	}
+}
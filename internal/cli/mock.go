@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/mock"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewMockCommand creates the 'tyk mock' command
+func NewMockCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Serve example responses for an OAS spec locally",
+		Long: `Run a local HTTP server that answers every operation in an OAS spec with an example
+response, so clients can be built before the API exists in Tyk.
+
+Response bodies come from, in order: the media type's named examples, its example,
+and finally a value generated from the response schema (which itself honours schema
+example, examples, default, enum and format). The lowest documented 2xx response is
+returned unless the request asks for another:
+
+  Prefer: code=404            return the documented 404 response
+  Prefer: example=notFound    return the named example
+
+Unknown paths get 404 and unsupported methods 405. Responses allow any origin (CORS).
+Stop the server with Ctrl-C.
+
+Examples:
+  tyk mock --file petstore.yaml
+  tyk mock --file petstore.yaml --port 9000 --host 0.0.0.0
+  curl -H 'Prefer: code=404' http://localhost:8081/pets/1`,
+		Args: cobra.NoArgs,
+		RunE: runMock,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "OAS spec to mock (required)")
+	cmd.Flags().Int("port", 8081, "Port to listen on")
+	cmd.Flags().String("host", "127.0.0.1", "Address to listen on; 0.0.0.0 exposes the mock to your network")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runMock(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	port, _ := cmd.Flags().GetInt("port")
+	host, _ := cmd.Flags().GetString("host")
+
+	if port < 0 || port > 65535 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --port %d", port)}
+	}
+	doc, err := loadOASFromFile(filePath)
+	if err != nil {
+		return err
+	}
+	server, err := mock.NewServer(doc)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", filePath, err)}
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("failed to listen: %v", err)}
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serveMock(ctx, listener, server, oas.GetAPIName(doc))
+}
+
+// serveMock serves until ctx is cancelled, logging each request to stderr
+func serveMock(ctx context.Context, listener net.Listener, server *mock.Server, title string) error {
+	bold := color.New(color.Bold)
+	bold.Printf("Mocking %s on http://%s\n\n", title, listener.Addr())
+	for _, route := range server.Routes() {
+		fmt.Printf("  %-7s %-40s → %s\n", route.Method, route.Path, route.Status)
+	}
+	fmt.Println("\nPress Ctrl-C to stop.")
+
+	logged := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		server.ServeHTTP(recorder, r)
+		fmt.Fprintf(os.Stderr, "%s %s %s → %d\n", time.Now().Format("15:04:05"), r.Method, r.URL.RequestURI(), recorder.status)
+	})
+	httpServer := &http.Server{Handler: logged, ReadHeaderTimeout: 10 * time.Second}
+
+	errs := make(chan error, 1)
+	go func() { errs <- httpServer.Serve(listener) }()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// statusRecorder remembers the status code written through it
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/mock"
+)
+
+func TestServeMock(t *testing.T) {
+	doc, err := loadOASFromFile(createTempOASFile(t, mockCleanOAS()))
+	require.NoError(t, err)
+	server, err := mock.NewServer(doc)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := captureStdout(func() error { return serveMock(ctx, listener, server, "test") })
+		done <- err
+	}()
+
+	route := server.Routes()[0]
+	req, err := http.NewRequest(route.Method, "http://"+listener.Addr().String()+route.Path, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	assert.Less(t, resp.StatusCode, 500)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestMock_BadArgs(t *testing.T) {
+	for _, args := range [][]string{
+		{"mock", "--file", "missing.yaml"},
+		{"mock", "--file", createTempOASFile(t, mockCleanOAS()), "--port", "70000"},
+	} {
+		_, err := runRootCommand(t, args...)
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr, args)
+		assert.Equal(t, 2, exitErr.Code, args)
+	}
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// upgradeFile reports the extension changes made to one spec
+type upgradeFile struct {
+	File    string              `json:"file"`
+	Changes []oas.UpgradeChange `json:"changes"`
+}
+
+// NewOASCommand creates the 'tyk oas' command and its subcommands
+func NewOASCommand() *cobra.Command {
+	oasCmd := &cobra.Command{
+		Use:   "oas",
+		Short: "Work with local OAS spec files",
+		Long:  `Commands that operate on OAS spec files on disk, without contacting the Dashboard.`,
+	}
+
+	oasCmd.AddCommand(NewOASUpgradeCommand())
+	oasCmd.AddCommand(NewOASValidateCommand())
+	oasCmd.AddCommand(NewOASLintCommand())
+	oasCmd.AddCommand(NewOASBreakingCommand())
+	oasCmd.AddCommand(NewOASNewCommand())
+	oasCmd.AddCommand(NewOASSchemaCommand())
+
+	return oasCmd
+}
+
+// NewOASUpgradeCommand creates the 'tyk oas upgrade' command
+func NewOASUpgradeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Rewrite specs for the Tyk extension layout of a newer Tyk version",
+		Long: `Rewrite the x-tyk-api-gateway extension of local specs for a Tyk release, moving
+fields that the release renamed or restructured. Every migration introduced at or before
+--to is applied; specs already using the new layout are left untouched, so upgrading
+twice is safe.
+
+Only changed files are rewritten. YAML comments and key order are not preserved, so
+review the result with your usual diff tooling before committing. Use --check in CI to
+fail while any spec still needs upgrading.
+
+Examples:
+  tyk oas upgrade --to 5.5 --dir ./apis
+  tyk oas upgrade --to 5.3 --file api.yaml --dry-run
+  tyk oas upgrade --to 5.5 --dir ./apis --check`,
+		Args: cobra.NoArgs,
+		RunE: runOASUpgrade,
+	}
+
+	cmd.Flags().String("to", "", "Tyk version to upgrade to, e.g. 5.5 (required)")
+	cmd.Flags().String("dir", "", "Directory of OAS specs to upgrade")
+	cmd.Flags().StringP("file", "f", "", "Single OAS spec to upgrade")
+	cmd.Flags().Bool("dry-run", false, "Report the changes without rewriting files")
+	cmd.Flags().Bool("check", false, "Report the changes without rewriting files and exit 1 if any are needed")
+	cmd.MarkFlagRequired("to")
+	cmd.MarkFlagsMutuallyExclusive("dir", "file")
+
+	return cmd
+}
+
+func runOASUpgrade(cmd *cobra.Command, args []string) error {
+	to, _ := cmd.Flags().GetString("to")
+	dir, _ := cmd.Flags().GetString("dir")
+	filePath, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	check, _ := cmd.Flags().GetBool("check")
+	dryRun = dryRun || check
+
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if _, err := oas.ParseTykVersion(to); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	files := []string{filePath}
+	if filePath == "" {
+		if dir == "" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "one of --dir or --file is required"}
+		}
+		if files, err = filehandler.FindSpecFiles(dir); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		if len(files) == 0 {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("no OAS files found in %s", dir)}
+		}
+	}
+
+	upgraded := []upgradeFile{}
+	total := 0
+	for _, file := range files {
+		doc, err := loadOASFromFile(file)
+		if err != nil {
+			return err
+		}
+		changes, err := oas.Upgrade(doc, to)
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if !dryRun {
+			if err := filehandler.SaveFile(file, doc); err != nil {
+				return &ExitError{Code: int(types.ExitGeneral), Message: err.Error()}
+			}
+		}
+		upgraded = append(upgraded, upgradeFile{File: file, Changes: changes})
+		total += len(changes)
+	}
+
+	if format.IsStructured() {
+		if err := writeStructured(format, map[string]interface{}{
+			"to":      to,
+			"dry_run": dryRun,
+			"files":   upgraded,
+			"summary": map[string]int{
+				"files_scanned": len(files),
+				"files_changed": len(upgraded),
+				"changes":       total,
+			},
+		}); err != nil {
+			return err
+		}
+	} else {
+		printOASUpgrade(to, len(files), upgraded, total, dryRun)
+	}
+
+	if check && total > 0 {
+		return differencesFoundError(cmd)
+	}
+	return nil
+}
+
+// printOASUpgrade prints the changes per file and a summary line
+func printOASUpgrade(to string, scanned int, upgraded []upgradeFile, total int, dryRun bool) {
+	if len(upgraded) == 0 {
+		color.New(color.FgGreen).Printf("✓ %d spec(s) already use the Tyk %s extension layout\n", scanned, to)
+		return
+	}
+	bold := color.New(color.Bold)
+	for _, file := range upgraded {
+		bold.Println(file.File)
+		for _, change := range file.Changes {
+			fmt.Printf("  %s  (%s, Tyk %s)\n", change.Path, change.Migration, change.Version)
+		}
+	}
+	fmt.Println()
+	if dryRun {
+		color.New(color.FgYellow).Printf("Dry run: %d change(s) needed in %d of %d spec(s) for Tyk %s; no files were written\n", total, len(upgraded), scanned, to)
+		return
+	}
+	color.New(color.FgGreen).Printf("✓ Upgraded %d of %d spec(s) to Tyk %s (%d change(s))\n", len(upgraded), scanned, to, total)
+}
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const breakingChangesHelp = `Breaking changes are those that fail clients written against the old spec: removed
+operations and success responses, new required parameters, request bodies or body
+fields, request schemas that accept less (a changed type, fewer enum values, a
+tighter minimum, maximum or length, a new pattern), response fields no longer
+returned, new authentication requirements and a moved listen path. Additions and
+relaxed constraints are listed as non-breaking.
+
+Breaking changes need a version bump: a new major info.version (a new minor version
+before 1.0; any change when versions are not numeric). The command exits 1 when the
+new spec has breaking changes without one, so it can gate CI.`
+
+// NewOASBreakingCommand creates the 'tyk oas breaking' command
+func NewOASBreakingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "breaking",
+		Short: "Classify the changes between two versions of a spec as breaking or not",
+		Long: `Compare two versions of a local spec and classify every change as breaking or
+non-breaking for the API's clients.
+
+` + breakingChangesHelp + `
+
+Examples:
+  tyk oas breaking --old v1.yaml --new v2.yaml
+  tyk oas breaking --old main/users.yaml --new users.yaml -o json`,
+		Args: cobra.NoArgs,
+		RunE: runOASBreaking,
+	}
+
+	cmd.Flags().String("old", "", "The spec clients were written against (required)")
+	cmd.Flags().String("new", "", "The new version of the spec (required)")
+	cmd.MarkFlagRequired("old")
+	cmd.MarkFlagRequired("new")
+
+	return cmd
+}
+
+// NewAPIBreakingCommand creates the 'tyk api breaking' command
+func NewAPIBreakingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "breaking <api-id>",
+		Short: "Classify the changes a local spec makes to a deployed API as breaking or not",
+		Long: `Compare a local spec with the deployed API it would replace and classify every
+change as breaking or non-breaking for the API's clients.
+
+` + breakingChangesHelp + `
+
+Examples:
+  tyk api breaking 7c2f4a1b --file users.yaml
+  tyk api breaking 7c2f4a1b --file users.yaml -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runAPIBreaking,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Local OAS file to compare (required)")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runOASBreaking(cmd *cobra.Command, args []string) error {
+	oldPath, _ := cmd.Flags().GetString("old")
+	newPath, _ := cmd.Flags().GetString("new")
+
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	oldDoc, err := loadOASFromFile(oldPath)
+	if err != nil {
+		return err
+	}
+	newDoc, err := loadOASFromFile(newPath)
+	if err != nil {
+		return err
+	}
+
+	report := oas.Compatibility(oldDoc, newDoc)
+	return outputCompatReport(cmd, format, map[string]interface{}{"old": oldPath, "new": newPath}, oldPath, report)
+}
+
+func runAPIBreaking(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	filePath, _ := cmd.Flags().GetString("file")
+
+	local, err := loadOASFromFile(filePath)
+	if err != nil {
+		return err
+	}
+	ctx, c, cancel, err := apiEditClient(cmd)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+
+	remote := api.OAS
+	if !oas.HasTykExtensions(local) {
+		remote = withoutTykExtension(remote)
+	}
+	report := oas.Compatibility(remote, local)
+	format := GetOutputFormatFromContext(cmd.Context())
+	label := fmt.Sprintf("%s (%s)", api.Name, apiID)
+	return outputCompatReport(cmd, format, map[string]interface{}{"api_id": apiID, "old": label, "new": filePath}, label, report)
+}
+
+// outputCompatReport shows the changes, and fails when breaking changes come without a
+// version bump
+func outputCompatReport(cmd *cobra.Command, format types.OutputFormat, fields map[string]interface{}, oldLabel string, report *oas.CompatReport) error {
+	if format.IsStructured() {
+		fields["old_version"] = report.OldVersion
+		fields["new_version"] = report.NewVersion
+		fields["version_bumped"] = report.VersionBumped
+		fields["breaking"] = report.BreakingCount()
+		fields["unreleased"] = report.Unreleased()
+		fields["changes"] = report.Changes
+		if err := writeStructured(format, fields); err != nil {
+			return err
+		}
+	} else {
+		printCompatReport(oldLabel, report)
+	}
+
+	if report.Unreleased() {
+		// The changes have been shown; only the exit status is left to report
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &ExitError{Code: int(types.ExitGeneral)}
+	}
+	return nil
+}
+
+// printCompatReport lists the breaking changes, then the others, and a verdict
+func printCompatReport(oldLabel string, report *oas.CompatReport) {
+	breaking := report.BreakingCount()
+	if breaking > 0 {
+		fmt.Printf("Breaking changes (%d):\n", breaking)
+		for _, change := range report.Changes {
+			if change.Breaking {
+				color.New(color.FgRed).Printf("  ✗ %s\n", compatChangeLine(change))
+			}
+		}
+	}
+	if n := len(report.Changes) - breaking; n > 0 {
+		fmt.Printf("Non-breaking changes (%d):\n", n)
+		for _, change := range report.Changes {
+			if !change.Breaking {
+				fmt.Printf("  • %s\n", compatChangeLine(change))
+			}
+		}
+	}
+
+	versions := fmt.Sprintf("info.version %s → %s", report.OldVersion, report.NewVersion)
+	if report.OldVersion == report.NewVersion {
+		versions = fmt.Sprintf("info.version %s unchanged", report.OldVersion)
+	}
+	switch {
+	case report.Unreleased():
+		color.New(color.FgRed).Printf("✗ %d breaking change(s) against %s without a version bump (%s)\n", breaking, oldLabel, versions)
+	case breaking > 0:
+		color.New(color.FgYellow).Printf("⚠ %d breaking change(s) against %s, released as a new version (%s)\n", breaking, oldLabel, versions)
+	default:
+		color.New(color.FgGreen).Printf("✓ No breaking changes against %s\n", oldLabel)
+	}
+}
+
+func compatChangeLine(change oas.CompatChange) string {
+	if change.Operation == "" {
+		return change.Message
+	}
+	return change.Operation + ": " + change.Message
+}
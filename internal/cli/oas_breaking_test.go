@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const breakingSpecV1 = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.4.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: ok
+  /users/{id}:
+    delete:
+      responses:
+        "204":
+          description: deleted
+`
+
+func TestOASBreaking(t *testing.T) {
+	dir := t.TempDir()
+	oldSpec := filepath.Join(dir, "v1.yaml")
+	require.NoError(t, os.WriteFile(oldSpec, []byte(breakingSpecV1), 0644))
+
+	// Dropping an operation breaks clients
+	newSpec := filepath.Join(dir, "v2.yaml")
+	v2 := strings.Replace(breakingSpecV1, "  /users/{id}:\n    delete:\n      responses:\n        \"204\":\n          description: deleted\n", "", 1)
+	v2 = strings.Replace(v2, "    get:\n", "    get:\n      parameters:\n        - name: page\n          in: query\n          schema:\n            type: integer\n", 1)
+	require.NoError(t, os.WriteFile(newSpec, []byte(v2), 0644))
+
+	out, err := runRootCommand(t, "oas", "breaking", "--old", oldSpec, "--new", newSpec, "-o", "json")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+	require.NoError(t, outputschema.Validate("oas-breaking", out), string(out))
+	var result struct {
+		Breaking   int  `json:"breaking"`
+		Unreleased bool `json:"unreleased"`
+		Changes    []struct {
+			Breaking  bool   `json:"breaking"`
+			Operation string `json:"operation"`
+			Message   string `json:"message"`
+		} `json:"changes"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 1, result.Breaking)
+	assert.True(t, result.Unreleased)
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, "DELETE /users/{id}", result.Changes[0].Operation)
+	assert.Equal(t, "operation removed", result.Changes[0].Message)
+	assert.False(t, result.Changes[1].Breaking)
+
+	out, err = runRootCommand(t, "oas", "breaking", "--old", oldSpec, "--new", newSpec)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+	assert.Contains(t, string(out), "Breaking changes (1):")
+	assert.Contains(t, string(out), "  • GET /users: adds optional query parameter 'page'")
+
+	// A new major version releases them
+	require.NoError(t, os.WriteFile(newSpec, []byte(strings.Replace(v2, "version: 1.4.0", "version: 2.0.0", 1)), 0644))
+	_, err = runRootCommand(t, "oas", "breaking", "--old", oldSpec, "--new", newSpec)
+	require.NoError(t, err)
+
+	out, err = runRootCommand(t, "oas", "breaking", "--old", oldSpec, "--new", oldSpec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "changes (")
+}
+
+func TestAPIBreaking(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	dashboard.apis["users-1"]["paths"] = map[string]interface{}{
+		"/users": map[string]interface{}{"get": map[string]interface{}{}},
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	// The local spec has no /users
+	spec := writePlanSpec(t, t.TempDir(), "users", "1.1.0")
+	out, err := runRootCommand(t, "api", "breaking", "users-1", "--file", spec, "-o", "json")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+	require.NoError(t, outputschema.Validate("oas-breaking", out), string(out))
+	assert.Contains(t, string(out), `"api_id": "users-1"`)
+	assert.Contains(t, string(out), "GET /users")
+
+	_, err = runRootCommand(t, "api", "breaking", "missing", "--file", spec)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
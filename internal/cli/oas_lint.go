@@ -0,0 +1,383 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// lintedFile holds the findings of the ruleset in one spec
+type lintedFile struct {
+	File     string        `json:"file"`
+	Findings []lintFinding `json:"findings"`
+}
+
+// lintFinding is a finding positioned in its spec
+type lintFinding struct {
+	oas.LintFinding
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// lintSeverityRank orders lint severities, most severe first
+var lintSeverityRank = map[string]int{oas.SeverityError: 0, oas.SeverityWarning: 1, oas.SeverityInfo: 2}
+
+// NewOASLintCommand creates the 'tyk oas lint' command
+func NewOASLintCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check local specs against a ruleset of style and consistency rules",
+		Long: `Check local specs for style and consistency problems: duplicate or missing
+operationIds, operations without a description or a 4xx response, endpoints that do not
+require authentication, and more. Every finding names its rule and carries the line and
+column of the offending key.
+
+--ruleset takes a built-in ruleset (tyk-recommended, the default, or tyk-basic, the
+checks of 'tyk oas validate --lint') or a ruleset file changing the severity of rules
+of a built-in one; "off" disables a rule:
+
+  extends: tyk-recommended
+  rules:
+    operation-4xx-response: error
+    info-description: "off"
+
+Severities are error, warning and info. The command exits 1 when a finding is at least
+as severe as --fail-on (error by default). --sarif writes the findings as a SARIF log
+for code scanning tools to annotate pull requests with; use --list-rules to see every
+rule and its severity in the ruleset.
+
+Examples:
+  tyk oas lint --file api.yaml
+  tyk oas lint --dir ./apis --ruleset .tyk-lint.yaml --fail-on warning
+  tyk oas lint --dir ./apis --sarif lint.sarif
+  tyk oas lint --file api.yaml -o json
+  tyk oas lint --list-rules`,
+		Args: cobra.NoArgs,
+		RunE: runOASLint,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Single OAS spec to lint")
+	cmd.Flags().String("dir", "", "Directory of OAS specs to lint")
+	cmd.Flags().String("ruleset", oas.DefaultRuleset, "Built-in ruleset or ruleset file")
+	cmd.Flags().String("fail-on", oas.SeverityError, "Exit 1 on findings of this severity or higher: error, warning, info or none")
+	cmd.Flags().String("sarif", "", "Also write the findings to this file as a SARIF log")
+	cmd.Flags().Bool("list-rules", false, "List the rules and their severity in the ruleset")
+	cmd.MarkFlagsMutuallyExclusive("dir", "file")
+
+	return cmd
+}
+
+func runOASLint(cmd *cobra.Command, args []string) error {
+	filePath, _ := cmd.Flags().GetString("file")
+	dir, _ := cmd.Flags().GetString("dir")
+	rulesetName, _ := cmd.Flags().GetString("ruleset")
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	sarifPath, _ := cmd.Flags().GetString("sarif")
+	listRules, _ := cmd.Flags().GetBool("list-rules")
+
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if _, ok := lintSeverityRank[failOn]; !ok && failOn != "none" {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --fail-on '%s' (expected error, warning, info or none)", failOn)}
+	}
+	ruleset, err := loadLintRuleset(rulesetName)
+	if err != nil {
+		return err
+	}
+	if listRules {
+		return outputLintRules(format, ruleset)
+	}
+
+	files := []string{filePath}
+	if filePath == "" {
+		if dir == "" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "one of --dir or --file is required"}
+		}
+		if files, err = filehandler.FindSpecFiles(dir); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		if len(files) == 0 {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("no OAS files found in %s", dir)}
+		}
+	}
+
+	results := make([]*lintedFile, 0, len(files))
+	counts := map[string]int{}
+	failed := false
+	for _, file := range files {
+		result, err := lintFile(file, ruleset)
+		if err != nil {
+			return err
+		}
+		for _, finding := range result.Findings {
+			counts[finding.Severity]++
+			if failOn != "none" && lintSeverityRank[finding.Severity] <= lintSeverityRank[failOn] {
+				failed = true
+			}
+		}
+		results = append(results, result)
+	}
+
+	if sarifPath != "" {
+		if err := writeLintSARIF(sarifPath, cmd.Root().Version, ruleset, results); err != nil {
+			return err
+		}
+	}
+
+	if format.IsStructured() {
+		if err := writeStructured(format, map[string]interface{}{
+			"ruleset": ruleset.Name,
+			"files":   results,
+			"summary": map[string]int{
+				"files":    len(results),
+				"errors":   counts[oas.SeverityError],
+				"warnings": counts[oas.SeverityWarning],
+				"info":     counts[oas.SeverityInfo],
+			},
+		}); err != nil {
+			return err
+		}
+	} else {
+		printOASLint(results, ruleset, counts, failed)
+	}
+
+	if failed {
+		// The findings have been shown; only the exit status is left to report
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &ExitError{Code: int(types.ExitGeneral)}
+	}
+	return nil
+}
+
+// loadLintRuleset returns a built-in ruleset, or reads a ruleset file
+func loadLintRuleset(name string) (*oas.Ruleset, error) {
+	if ruleset, err := oas.NewRuleset(name); err == nil {
+		return ruleset, nil
+	}
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("ruleset '%s' is neither a built-in ruleset (%s) nor a file", name, strings.Join(oas.Rulesets(), ", "))}
+	}
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read ruleset: %v", err)}
+	}
+	var config oas.RulesetConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to parse ruleset %s: %v", name, err)}
+	}
+	ruleset, err := config.Ruleset(name)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("ruleset %s: %v", name, err)}
+	}
+	return ruleset, nil
+}
+
+// lintFile runs the ruleset against one spec, positioning each finding
+func lintFile(file string, ruleset *oas.Ruleset) (*lintedFile, error) {
+	doc, root, err := loadRPCDocument(rpcDocument{URI: file})
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %v", file, err)}
+	}
+	result := &lintedFile{File: file, Findings: []lintFinding{}}
+	for _, finding := range ruleset.Lint(doc) {
+		line, column := locateYAMLPath(root, finding.Path)
+		result.Findings = append(result.Findings, lintFinding{LintFinding: finding, Line: line, Column: column})
+	}
+	return result, nil
+}
+
+// printOASLint prints each finding as file:line:column with its rule, and a summary line
+func printOASLint(results []*lintedFile, ruleset *oas.Ruleset, counts map[string]int, failed bool) {
+	styles := map[string]*color.Color{
+		oas.SeverityError:   color.New(color.FgRed),
+		oas.SeverityWarning: color.New(color.FgYellow),
+		oas.SeverityInfo:    color.New(color.FgCyan),
+	}
+	symbols := map[string]string{oas.SeverityError: "✗", oas.SeverityWarning: "⚠", oas.SeverityInfo: "ℹ"}
+	for _, result := range results {
+		for _, f := range result.Findings {
+			location := result.File
+			if f.Line > 0 {
+				location = fmt.Sprintf("%s:%d:%d", result.File, f.Line, f.Column)
+			}
+			styles[f.Severity].Printf("%s %s: %s [%s]\n", symbols[f.Severity], location, f.Message, f.Rule)
+		}
+	}
+
+	summary := fmt.Sprintf("%d error(s), %d warning(s), %d info in %d spec(s) (%s)",
+		counts[oas.SeverityError], counts[oas.SeverityWarning], counts[oas.SeverityInfo], len(results), ruleset.Name)
+	switch {
+	case failed:
+		color.New(color.FgRed).Printf("✗ %s\n", summary)
+	case counts[oas.SeverityError]+counts[oas.SeverityWarning]+counts[oas.SeverityInfo] > 0:
+		fmt.Println(summary)
+	default:
+		color.New(color.FgGreen).Printf("✓ %d spec(s) pass %s\n", len(results), ruleset.Name)
+	}
+}
+
+// outputLintRules lists every rule with its severity in the ruleset
+func outputLintRules(format types.OutputFormat, ruleset *oas.Ruleset) error {
+	type ruleInfo struct {
+		ID          string `json:"id"`
+		Severity    string `json:"severity"`
+		Description string `json:"description"`
+	}
+	rules := []ruleInfo{}
+	for _, rule := range oas.LintRules() {
+		rules = append(rules, ruleInfo{ID: rule.ID, Severity: ruleset.Severity(rule.ID), Description: rule.Description})
+	}
+	if format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{"ruleset": ruleset.Name, "rules": rules})
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tSEVERITY\tDESCRIPTION")
+	for _, rule := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", rule.ID, rule.Severity, rule.Description)
+	}
+	return w.Flush()
+}
+
+// SARIF 2.1.0, the subset code scanning tools read
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevel maps a lint severity to a SARIF result level
+func sarifLevel(severity string) string {
+	switch severity {
+	case oas.SeverityError:
+		return "error"
+	case oas.SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// writeLintSARIF writes the findings as a SARIF log. File paths are written relative to
+// the working directory, as code scanning tools resolve them against the repository.
+func writeLintSARIF(path, version string, ruleset *oas.Ruleset, results []*lintedFile) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "tyk oas lint",
+			Version:        version,
+			InformationURI: "https://github.com/sedkis/tyk-cli",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for _, rule := range oas.LintRules() {
+		if severity := ruleset.Severity(rule.ID); severity != oas.SeverityOff {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:                   rule.ID,
+				ShortDescription:     sarifMessage{Text: rule.Description},
+				DefaultConfiguration: sarifConfiguration{Level: sarifLevel(severity)},
+			})
+		}
+	}
+
+	cwd, _ := os.Getwd()
+	for _, result := range results {
+		uri := result.File
+		if rel, err := filepath.Rel(cwd, result.File); err == nil && !strings.HasPrefix(rel, "..") {
+			uri = rel
+		}
+		uri = filepath.ToSlash(uri)
+		for _, f := range result.Findings {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}}
+			if f.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    f.Rule,
+				Level:     sarifLevel(f.Severity),
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write SARIF log: %w", err)
+	}
+	return nil
+}
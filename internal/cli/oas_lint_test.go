@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const lintSpec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+servers:
+  - url: https://users.internal
+security:
+  - api_key: []
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      summary: List users
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: listUsers
+      responses:
+        "201":
+          description: created
+components:
+  securitySchemes:
+    api_key:
+      type: apiKey
+      in: header
+      name: Authorization
+`
+
+func TestOASLint(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "users.yaml")
+	require.NoError(t, os.WriteFile(spec, []byte(lintSpec), 0644))
+	sarifPath := filepath.Join(dir, "lint.sarif")
+
+	out, err := runRootCommand(t, "oas", "lint", "--file", spec, "--sarif", sarifPath, "-o", "json")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+	require.NoError(t, outputschema.Validate("oas-lint", out), string(out))
+	var result struct {
+		Ruleset string       `json:"ruleset"`
+		Files   []lintedFile `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "tyk-recommended", result.Ruleset)
+	require.Len(t, result.Files, 1)
+	rules := map[string]lintFinding{}
+	for _, f := range result.Files[0].Findings {
+		rules[f.Rule] = f
+	}
+	assert.Equal(t, "error", rules["operation-operationId-unique"].Severity)
+	assert.Equal(t, 18, rules["operation-operationId-unique"].Line)
+	assert.Contains(t, rules, "operation-description")
+	assert.Contains(t, rules, "operation-4xx-response")
+	assert.Contains(t, rules, "info-description")
+
+	var sarif sarifLog
+	data, err := os.ReadFile(sarifPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	require.Len(t, sarif.Runs, 1)
+	assert.Len(t, sarif.Runs[0].Results, len(result.Files[0].Findings))
+	first := sarif.Runs[0].Results[0]
+	assert.Equal(t, "note", first.Level)
+	assert.Equal(t, filepath.ToSlash(spec), first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+
+	// A ruleset file can demote the error and silence other rules
+	ruleset := filepath.Join(dir, "ruleset.yaml")
+	require.NoError(t, os.WriteFile(ruleset, []byte("rules:\n  operation-operationId-unique: warning\n  info-description: \"off\"\n"), 0644))
+	out, err = runRootCommand(t, "oas", "lint", "--file", spec, "--ruleset", ruleset, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	for _, f := range result.Files[0].Findings {
+		assert.NotEqual(t, "info-description", f.Rule)
+		assert.Equal(t, "warning", f.Severity)
+	}
+
+	_, err = runRootCommand(t, "oas", "lint", "--file", spec, "--ruleset", ruleset, "--fail-on", "warning")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+
+	_, err = runRootCommand(t, "oas", "lint", "--file", spec, "--ruleset", "missing")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+
+	out, err = runRootCommand(t, "oas", "lint", "--list-rules", "--ruleset", ruleset)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "info-description")
+	assert.Contains(t, string(out), "off")
+}
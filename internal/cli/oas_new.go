@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewOASNewCommand creates the 'tyk oas new' command
+func NewOASNewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "new",
+		Short: "Scaffold a new OAS spec with Tyk extensions",
+		Long: `Write a new OpenAPI 3 document with Tyk extensions, ready for 'tyk api apply', instead
+of hand-writing one or copying an old spec.
+
+The spec proxies to --upstream under a listen path derived from the title, and is secured
+with --auth (apikey by default; none leaves it open). With --resource, it gets list,
+create, get, replace and delete operations for that resource and a schema to fill in:
+--resource order adds /orders and /orders/{orderId}.
+
+The spec passes 'tyk oas validate --lint'. An existing --out file is never overwritten.
+
+Examples:
+  tyk oas new --title "Orders API" --upstream https://orders.svc --out orders.yaml
+  tyk oas new --title "Orders API" --upstream https://orders.svc --resource order --auth jwt --out orders.yaml
+  tyk oas new --title Billing --upstream http://billing:8080 --resource line-item > billing.yaml`,
+		Args: cobra.NoArgs,
+		RunE: runOASNew,
+	}
+
+	cmd.Flags().String("title", "", "API title (required)")
+	cmd.Flags().String("upstream", "", "Upstream URL the API proxies to (required)")
+	cmd.Flags().String("out", "", "File to write the spec to, .yaml or .json (default: standard output)")
+	cmd.Flags().String("resource", "", "Add CRUD operations for this resource, e.g. order")
+	cmd.Flags().String("auth", oas.ScaffoldAPIKey, "Authentication: "+strings.Join(oas.ScaffoldAuthTypes, ", "))
+	cmd.Flags().String("listen-path", "", "Listen path (default: derived from the title)")
+	cmd.Flags().String("api-version", "1.0.0", "info.version of the spec")
+	cmd.Flags().String("description", "", "info.description of the spec")
+	cmd.MarkFlagRequired("title")
+	cmd.MarkFlagRequired("upstream")
+
+	return cmd
+}
+
+func runOASNew(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	opts := oas.ScaffoldOptions{}
+	opts.Title, _ = cmd.Flags().GetString("title")
+	opts.UpstreamURL, _ = cmd.Flags().GetString("upstream")
+	opts.Resource, _ = cmd.Flags().GetString("resource")
+	opts.Auth, _ = cmd.Flags().GetString("auth")
+	opts.ListenPath, _ = cmd.Flags().GetString("listen-path")
+	opts.Version, _ = cmd.Flags().GetString("api-version")
+	opts.Description, _ = cmd.Flags().GetString("description")
+
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	doc, err := oas.Scaffold(opts)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	if out == "" {
+		if format.IsStructured() {
+			return writeStructured(format, doc)
+		}
+		data, err := filehandler.ConvertToYAML(doc)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if _, err := os.Stat(out); err == nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s already exists; choose another --out or remove it", out)}
+	}
+	if err := filehandler.SaveFile(out, doc); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", out, err)}
+	}
+
+	color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote %s (%s at %s)\n", out, opts.Title, oas.GetListenPath(doc))
+	fmt.Fprintf(os.Stderr, "  Next: fill it in, then 'tyk oas validate --lint --file %s' and 'tyk api apply --file %s'\n", out, out)
+	return nil
+}
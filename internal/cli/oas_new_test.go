@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+func TestOASNew(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "orders.yaml")
+	_, err := runRootCommand(t, "oas", "new", "--title", "Orders API", "--upstream", "https://orders.svc", "--resource", "order", "--out", out)
+	require.NoError(t, err)
+
+	doc, err := loadOASFromFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "Orders API", oas.GetAPIName(doc))
+	assert.Contains(t, doc["paths"], "/orders/{orderId}")
+
+	// The scaffold is clean enough to apply as it is
+	data, err := runRootCommand(t, "oas", "validate", "--lint", "--file", out, "-o", "json")
+	require.NoError(t, err)
+	var result struct {
+		Files []validatedFile `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(data, &result))
+	require.Len(t, result.Files, 1)
+	assert.Empty(t, result.Files[0].Diagnostics)
+
+	_, err = runRootCommand(t, "oas", "new", "--title", "Orders API", "--upstream", "https://orders.svc", "--out", out)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "already exists")
+
+	_, err = runRootCommand(t, "oas", "new", "--title", "Orders API", "--upstream", "orders.svc")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
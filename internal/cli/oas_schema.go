@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewOASSchemaCommand creates the 'tyk oas schema' command
+func NewOASSchemaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Write a JSON Schema for specs with Tyk extensions, for editor completion",
+		Long: `Write a JSON Schema covering OpenAPI 3 documents and their x-tyk-api-gateway
+extension, so editors complete and validate specs while you write them. Extension
+fields note the Tyk release that introduced them, and the plugin hooks replaced by
+'tyk oas upgrade' are marked deprecated.
+
+For VS Code with the YAML extension (redhat.vscode-yaml), point specs at the file in
+.vscode/settings.json:
+
+  "yaml.schemas": { "./tyk-oas.schema.json": ["apis/**/*.yaml"] },
+  "json.schemas": [{ "url": "./tyk-oas.schema.json", "fileMatch": ["apis/**/*.json"] }]
+
+or start a single spec with:
+
+  # yaml-language-server: $schema=./tyk-oas.schema.json
+
+Regenerate the file after upgrading the CLI.
+
+Examples:
+  tyk oas schema --out tyk-oas.schema.json
+  tyk oas schema > .vscode/tyk-oas.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: runOASSchema,
+	}
+
+	cmd.Flags().String("out", "", "File to write the schema to (default: standard output)")
+
+	return cmd
+}
+
+func runOASSchema(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	schema, err := oas.EditorSchema()
+	if err != nil {
+		return err
+	}
+	schema = append(schema, '\n')
+
+	if out == "" {
+		_, err = os.Stdout.Write(schema)
+		return err
+	}
+	if err := os.WriteFile(out, schema, 0644); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write %s: %v", out, err)}
+	}
+	color.New(color.FgGreen).Fprintf(os.Stderr, "✓ Wrote %s\n", out)
+	fmt.Fprintf(os.Stderr, "  Next: map your specs to it with \"yaml.schemas\" in .vscode/settings.json (see 'tyk oas schema --help')\n")
+	return nil
+}
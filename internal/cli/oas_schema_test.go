@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+func TestOASSchema(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "tyk-oas.schema.json")
+	_, err := runRootCommand(t, "oas", "schema", "--out", out)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, oas.EditorSchemaID, schema["$id"])
+	assert.Contains(t, schema["definitions"], oas.TykExtensionKey)
+
+	_, err = runRootCommand(t, "oas", "schema", "--out", filepath.Join(t.TempDir(), "missing", "schema.json"))
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
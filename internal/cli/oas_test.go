@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+)
+
+func TestOASUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	legacy := writePlanSpec(t, dir, "users", "1.0.0")
+	doc, err := loadOASFromFile(legacy)
+	require.NoError(t, err)
+	doc[oas.TykExtensionKey] = map[string]interface{}{
+		"middleware": map[string]interface{}{
+			"global": map[string]interface{}{
+				"responsePlugin": map[string]interface{}{"enabled": true, "functionName": "scrub"},
+			},
+		},
+	}
+	require.NoError(t, filehandler.SaveFile(legacy, doc))
+	writePlanSpec(t, dir, "accounts", "1.0.0")
+
+	// --check reports without writing and fails while changes remain
+	out, err := runRootCommand(t, "oas", "upgrade", "--to", "5.5", "--dir", dir, "--check", "-o", "json")
+	require.Error(t, err)
+	require.NoError(t, outputschema.Validate("oas-upgrade", out), string(out))
+	var report struct {
+		DryRun  bool `json:"dry_run"`
+		Files   []upgradeFile
+		Summary map[string]int
+	}
+	require.NoError(t, json.Unmarshal(out, &report))
+	assert.True(t, report.DryRun)
+	require.Len(t, report.Files, 1)
+	assert.Equal(t, legacy, report.Files[0].File)
+	assert.Equal(t, map[string]int{"files_scanned": 2, "files_changed": 1, "changes": 1}, report.Summary)
+
+	_, err = runRootCommand(t, "oas", "upgrade", "--to", "5.5", "--dir", dir)
+	require.NoError(t, err)
+	doc, err = loadOASFromFile(legacy)
+	require.NoError(t, err)
+	global := doc[oas.TykExtensionKey].(map[string]interface{})["middleware"].(map[string]interface{})["global"].(map[string]interface{})
+	assert.NotContains(t, global, "responsePlugin")
+	assert.Len(t, global["responsePlugins"], 1)
+
+	_, err = runRootCommand(t, "oas", "upgrade", "--to", "5.5", "--file", filepath.Join(dir, filepath.Base(legacy)), "--check")
+	assert.NoError(t, err)
+
+	_, err = runRootCommand(t, "oas", "upgrade", "--to", "4.0", "--dir", dir)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
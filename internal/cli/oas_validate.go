@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// validationCacheVersion is part of every cache key; bump it when oas.Validate or
+// oas.Lint change so cached results from older rules are not reused
+const validationCacheVersion = "2"
+
+// validatedFile holds the diagnostics found in one spec
+type validatedFile struct {
+	File        string          `json:"file"`
+	Cached      bool            `json:"cached"`
+	Diagnostics []rpcDiagnostic `json:"diagnostics"`
+}
+
+// hasErrors reports whether any diagnostic is an error
+func (f *validatedFile) hasErrors() bool {
+	for _, d := range f.Diagnostics {
+		if d.Severity == oas.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// validationCache stores diagnostics keyed by a hash of the spec content, the checks
+// run and the CLI version, so unchanged specs are not validated again
+type validationCache struct {
+	dir    string
+	prefix string
+}
+
+// NewOASValidateCommand creates the 'tyk oas validate' command
+func NewOASValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate local specs, in parallel and with cached results",
+		Long: `Check local specs for problems that would make import or apply fail, and with --lint
+for style issues too. Diagnostics carry the line and column of the offending key.
+
+Specs are validated in parallel. Results are cached by file content in the user cache
+directory, so repeated runs (in CI, with the cache directory persisted) only validate
+specs that changed. The cache is keyed by CLI version, so upgrading the CLI revalidates
+everything.
+
+With --strict-yaml, YAML that parsers read differently is reported as errors too:
+unquoted on/off/yes/no, numbers with leading zeros or colons, non-string keys such as
+unquoted response codes, and numbers in string fields such as "version: 1.10".
+
+Exits 1 when any spec has errors; warnings alone do not fail.
+
+Examples:
+  tyk oas validate --dir ./apis
+  tyk oas validate --file api.yaml --lint
+  tyk oas validate --dir ./apis --strict-yaml
+  tyk oas validate --dir ./apis --jobs 16 --cache-dir .cache/tyk -o json`,
+		Args: cobra.NoArgs,
+		RunE: runOASValidate,
+	}
+
+	cmd.Flags().String("dir", "", "Directory of OAS specs to validate")
+	cmd.Flags().StringP("file", "f", "", "Single OAS spec to validate")
+	cmd.Flags().Bool("lint", false, "Also report style issues as warnings")
+	addStrictYAMLFlag(cmd)
+	cmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "Number of specs to validate at once")
+	cmd.Flags().String("cache-dir", "", "Directory for cached results (default: tyk/validate in the user cache directory)")
+	cmd.Flags().Bool("no-cache", false, "Validate every spec, ignoring and not writing cached results")
+	cmd.MarkFlagsMutuallyExclusive("dir", "file")
+
+	return cmd
+}
+
+func runOASValidate(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	filePath, _ := cmd.Flags().GetString("file")
+	checks := validationChecks{strictYAML: strictYAML(cmd)}
+	checks.lint, _ = cmd.Flags().GetBool("lint")
+	jobs, _ := cmd.Flags().GetInt("jobs")
+	cacheDir, _ := cmd.Flags().GetString("cache-dir")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+
+	format, err := outputFormatFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if jobs < 1 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --jobs %d", jobs)}
+	}
+
+	files := []string{filePath}
+	if filePath == "" {
+		if dir == "" {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: "one of --dir or --file is required"}
+		}
+		if files, err = filehandler.FindSpecFiles(dir); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		if len(files) == 0 {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("no OAS files found in %s", dir)}
+		}
+	}
+
+	var cache *validationCache
+	if !noCache {
+		if cache, err = newValidationCache(cacheDir, cmd.Root().Version, checks); err != nil {
+			return err
+		}
+	}
+
+	results, err := validateFiles(files, checks, jobs, cache)
+	if err != nil {
+		return err
+	}
+
+	failed, cached := 0, 0
+	for _, result := range results {
+		if result.hasErrors() {
+			failed++
+		}
+		if result.Cached {
+			cached++
+		}
+	}
+
+	if format.IsStructured() {
+		if err := writeStructured(format, map[string]interface{}{
+			"files": results,
+			"summary": map[string]int{
+				"files":  len(results),
+				"failed": failed,
+				"cached": cached,
+			},
+		}); err != nil {
+			return err
+		}
+	} else {
+		printOASValidate(results, failed, cached)
+	}
+
+	if failed > 0 {
+		// The diagnostics have been shown; only the exit status is left to report
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &ExitError{Code: int(types.ExitGeneral)}
+	}
+	return nil
+}
+
+// validationChecks are the optional checks of 'tyk oas validate'
+type validationChecks struct {
+	lint       bool
+	strictYAML bool
+}
+
+// validateFiles checks files with up to jobs workers and returns results in file order
+func validateFiles(files []string, checks validationChecks, jobs int, cache *validationCache) ([]*validatedFile, error) {
+	results := make([]*validatedFile, len(files))
+	errs := make([]error, len(files))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(jobs, len(files)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = validateFile(files[i], checks, cache)
+			}
+		}()
+	}
+	for i := range files {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// validateFile checks one spec, reusing a cached result when its content is unchanged
+func validateFile(file string, checks validationChecks, cache *validationCache) (*validatedFile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read %s: %v", file, err)}
+	}
+
+	result := &validatedFile{File: file}
+	if cache != nil {
+		if diagnostics, ok := cache.get(data); ok {
+			result.Cached = true
+			result.Diagnostics = diagnostics
+			return result, nil
+		}
+	}
+
+	result.Diagnostics = diagnoseDocument(rpcDocument{Text: string(data)}, func(doc map[string]interface{}) []oas.Diagnostic {
+		diagnostics := oas.Validate(doc)
+		if checks.lint {
+			diagnostics = append(diagnostics, oas.Lint(doc)...)
+		}
+		return diagnostics
+	})
+	if checks.strictYAML {
+		result.Diagnostics = append(result.Diagnostics, strictYAMLDiagnostics(data)...)
+	}
+	if cache != nil {
+		cache.put(data, result.Diagnostics)
+	}
+	return result, nil
+}
+
+// newValidationCache opens the cache in dir, or in the user cache directory
+func newValidationCache(dir, version string, checks validationChecks) (*validationCache, error) {
+	if dir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find cache directory, use --cache-dir or --no-cache: %w", err)
+		}
+		dir = filepath.Join(userCache, "tyk", "validate")
+	}
+	return &validationCache{dir: dir, prefix: fmt.Sprintf("%s\x00%s\x00lint=%t\x00strict-yaml=%t\x00", validationCacheVersion, version, checks.lint, checks.strictYAML)}, nil
+}
+
+func (c *validationCache) path(data []byte) string {
+	sum := sha256.Sum256(append([]byte(c.prefix), data...))
+	key := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+func (c *validationCache) get(data []byte) ([]rpcDiagnostic, bool) {
+	raw, err := os.ReadFile(c.path(data))
+	if err != nil {
+		return nil, false
+	}
+	var diagnostics []rpcDiagnostic
+	if err := json.Unmarshal(raw, &diagnostics); err != nil || diagnostics == nil {
+		return nil, false
+	}
+	return diagnostics, true
+}
+
+// put stores a result; the cache is an optimisation, so failures are ignored. Entries
+// are written to a temporary file and renamed so concurrent runs never read a partial one.
+func (c *validationCache) put(data []byte, diagnostics []rpcDiagnostic) {
+	path := c.path(data)
+	raw, err := json.Marshal(diagnostics)
+	if err != nil || os.MkdirAll(filepath.Dir(path), 0755) != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// printOASValidate prints each diagnostic as file:line:column and a summary line
+func printOASValidate(results []*validatedFile, failed, cached int) {
+	red := color.New(color.FgRed)
+	yellow := color.New(color.FgYellow)
+	for _, result := range results {
+		for _, d := range result.Diagnostics {
+			location := result.File
+			if d.Line > 0 {
+				location = fmt.Sprintf("%s:%d:%d", result.File, d.Line, d.Column)
+			}
+			if d.Severity == oas.SeverityError {
+				red.Printf("✗ %s: %s\n", location, d.Message)
+			} else {
+				yellow.Printf("⚠ %s: %s\n", location, d.Message)
+			}
+		}
+	}
+
+	fromCache := ""
+	if cached > 0 {
+		fromCache = fmt.Sprintf(" (%d from cache)", cached)
+	}
+	if failed > 0 {
+		red.Printf("✗ %d of %d spec(s) have errors%s\n", failed, len(results), fromCache)
+		return
+	}
+	color.New(color.FgGreen).Printf("✓ %d spec(s) valid%s\n", len(results), fromCache)
+}
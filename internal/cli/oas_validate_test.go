@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestOASValidate_ParallelWithCache(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := t.TempDir()
+	for i := 0; i < 12; i++ {
+		writePlanSpec(t, dir, fmt.Sprintf("svc-%02d", i), "1.0.0")
+	}
+	broken := filepath.Join(dir, "broken.yaml")
+	require.NoError(t, os.WriteFile(broken, []byte("openapi: 2.0.0\ninfo:\n  title: broken\n  version: 1.0.0\npaths: {}\n"), 0644))
+
+	type report struct {
+		Files   []validatedFile
+		Summary map[string]int
+	}
+	validate := func(args ...string) (report, error) {
+		out, err := runRootCommand(t, append([]string{"oas", "validate", "--dir", dir, "--cache-dir", cacheDir, "--jobs", "4", "-o", "json"}, args...)...)
+		require.NoError(t, outputschema.Validate("oas-validate", out), string(out))
+		var r report
+		require.NoError(t, json.Unmarshal(out, &r))
+		return r, err
+	}
+
+	r, err := validate()
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitGeneral), exitErr.Code)
+	assert.Equal(t, map[string]int{"files": 13, "failed": 1, "cached": 0}, r.Summary)
+	require.Equal(t, broken, r.Files[0].File, "results keep file order")
+	require.NotEmpty(t, r.Files[0].Diagnostics)
+	assert.Equal(t, "error", r.Files[0].Diagnostics[0].Severity)
+	assert.Equal(t, 1, r.Files[0].Diagnostics[0].Line)
+
+	// Unchanged specs come from the cache, with the same diagnostics
+	r2, err := validate()
+	require.Error(t, err)
+	assert.Equal(t, 13, r2.Summary["cached"])
+	assert.Equal(t, r.Files[0].Diagnostics, r2.Files[0].Diagnostics)
+
+	// Only the fixed spec is validated again
+	require.NoError(t, os.WriteFile(broken, []byte("openapi: 3.0.3\ninfo:\n  title: fixed\n  version: 1.0.0\npaths: {}\n"), 0644))
+	r, err = validate()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"files": 13, "failed": 0, "cached": 12}, r.Summary)
+	assert.False(t, r.Files[0].Cached)
+
+	// Lint results are cached separately
+	r, err = validate("--lint")
+	require.NoError(t, err)
+	assert.Equal(t, 0, r.Summary["cached"])
+
+	r, err = validate("--no-cache")
+	require.NoError(t, err)
+	assert.Equal(t, 0, r.Summary["cached"])
+}
+
+func TestOASValidate_Args(t *testing.T) {
+	dir := t.TempDir()
+	writePlanSpec(t, dir, "users", "1.0.0")
+
+	_, err := runRootCommand(t, "oas", "validate", "--file", filepath.Join(dir, "users.yaml"), "--no-cache")
+	require.NoError(t, err, "warnings alone do not fail")
+
+	_, err = runRootCommand(t, "oas", "validate", "--dir", dir, "--jobs", "0")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// parseOutputFormat resolves --output and the deprecated --json alias into an OutputFormat
+func parseOutputFormat(output string, jsonFlag bool) (types.OutputFormat, error) {
+	if jsonFlag {
+		if output != "" && output != string(types.OutputJSON) {
+			return "", &ExitError{Code: 2, Message: fmt.Sprintf("--json conflicts with --output %s", output)}
+		}
+		return types.OutputJSON, nil
+	}
+
+	switch types.OutputFormat(output) {
+	case "", types.OutputHuman:
+		return types.OutputHuman, nil
+	case types.OutputJSON, types.OutputYAML, types.OutputWide:
+		return types.OutputFormat(output), nil
+	default:
+		return "", &ExitError{Code: 2, Message: fmt.Sprintf("invalid --output '%s': must be one of human, wide, json, yaml", output)}
+	}
+}
+
+// writeStructured writes v to stdout as indented JSON or YAML
+func writeStructured(format types.OutputFormat, v interface{}) error {
+	return encodeStructured(os.Stdout, format, v)
+}
+
+// encodeStructured encodes v as JSON or YAML. YAML is produced from the JSON form
+// so field names and omitempty rules stay identical across both formats.
+func encodeStructured(w io.Writer, format types.OutputFormat, v interface{}) error {
+	if format != types.OutputYAML {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(generic); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// apiOperationResult records what happened to a single API in a multi-API operation
+type apiOperationResult struct {
+	File      string `json:"file,omitempty"`
+	APIID     string `json:"api_id,omitempty"`
+	Name      string `json:"name"`
+	Operation string `json:"operation"`
+	Error     string `json:"error,omitempty"`
+}
+
+// outputAPIOperationResults prints per-API results in the requested format
+func outputAPIOperationResults(cmd *cobra.Command, results []apiOperationResult) error {
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if results == nil {
+			results = []apiOperationResult{}
+		}
+		return writeStructured(format, map[string]interface{}{
+			"results": results,
+			"count":   len(results),
+		})
+	}
+
+	green := color.New(color.FgGreen, color.Bold)
+	red := color.New(color.FgRed)
+	for _, r := range results {
+		if r.Error != "" {
+			label := r.Name
+			if label == "" {
+				label = r.File
+			}
+			red.Printf("✗ %s: %s\n", label, r.Error)
+			continue
+		}
+		green.Printf("✓ %s API '%s' (%s)\n", strings.ToUpper(r.Operation[:1])+r.Operation[1:], r.Name, r.APIID)
+	}
+	return nil
+}
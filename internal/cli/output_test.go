@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+func TestEncodeStructured_YAMLUsesJSONFieldNames(t *testing.T) {
+	api := &types.OASAPI{ID: "abc", Name: "Users", ListenPath: "/users/"}
+
+	var buf bytes.Buffer
+	require.NoError(t, encodeStructured(&buf, types.OutputYAML, api))
+
+	var decoded map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "abc", decoded["id"])
+	assert.Equal(t, "/users/", decoded["listen_path"])
+	assert.NotContains(t, decoded, "custom_domain")
+}
+
+func TestEncodeStructured_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, encodeStructured(&buf, types.OutputJSON, map[string]interface{}{"count": 1}))
+	assert.Equal(t, "{\n  \"count\": 1\n}\n", buf.String())
+}
+
+func TestExtractOutputFormat(t *testing.T) {
+	args, format := extractOutputFormat([]string{"api", "list", "-o", "yaml", "--page", "2"})
+	assert.Equal(t, []string{"api", "list", "--page", "2"}, args)
+	assert.Equal(t, types.OutputYAML, format)
+
+	args, format = extractOutputFormat([]string{"api", "list", "--json"})
+	assert.Equal(t, []string{"api", "list"}, args)
+	assert.Equal(t, types.OutputJSON, format)
+
+	_, format = extractOutputFormat([]string{"api", "list", "--output=human"})
+	assert.Equal(t, types.OutputFormat(""), format)
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// EnvPager selects the pager for long human output; PAGER is used when it is unset
+const EnvPager = "TYK_PAGER"
+
+// withPager renders output into a buffer and shows it through a pager when stdout is a
+// terminal, like git does. An empty pager, "cat", a pager that fails to start or
+// noPager writes straight to stdout.
+func withPager(noPager bool, render func(w io.Writer)) error {
+	if noPager || !term.IsTerminal(int(os.Stdout.Fd())) {
+		render(os.Stdout)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	render(&buf)
+
+	args := strings.Fields(pagerCommand())
+	if len(args) == 0 || args[0] == "cat" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = &buf
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	// Like git: quit when the output fits one screen, keep colors, leave it on screen
+	if os.Getenv("LESS") == "" {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := pager.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			// The pager ran; quitting it early is not an error
+			return nil
+		}
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return nil
+}
+
+// pagerCommand returns $TYK_PAGER, $PAGER or less
+func pagerCommand() string {
+	if pager, ok := os.LookupEnv(EnvPager); ok {
+		return pager
+	}
+	if pager, ok := os.LookupEnv("PAGER"); ok {
+		return pager
+	}
+	return "less"
+}
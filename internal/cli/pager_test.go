@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "more")
+	t.Setenv(EnvPager, "")
+	assert.Equal(t, "", pagerCommand(), "an empty TYK_PAGER disables paging")
+
+	t.Setenv(EnvPager, "less -S")
+	assert.Equal(t, "less -S", pagerCommand())
+}
+
+func TestWithPager_NotATerminal(t *testing.T) {
+	out, err := captureStdout(func() error {
+		return withPager(false, func(w io.Writer) { io.WriteString(w, "diff\n") })
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "diff\n", string(out))
+}
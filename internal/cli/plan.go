@@ -0,0 +1,572 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// planFormatVersion is bumped whenever the saved plan layout changes incompatibly
+const planFormatVersion = 1
+
+// Plan actions
+const (
+	planCreate   = "create"
+	planUpdate   = "update"
+	planDelete   = "delete"
+	planNoChange = "no-change"
+)
+
+// planAction is what apply will do to a single API
+type planAction struct {
+	Action   string                 `json:"action"`
+	File     string                 `json:"file,omitempty"`
+	APIID    string                 `json:"api_id,omitempty"`
+	Name     string                 `json:"name"`
+	Changes  []oas.Change           `json:"changes,omitempty"`
+	Document map[string]interface{} `json:"document,omitempty"`
+	// ETag of the deployed API when planned, so apply refuses to overwrite later changes
+	ETag string `json:"etag,omitempty"`
+
+	// remote is the deployed document for matched specs; it is not saved in the plan
+	remote map[string]interface{}
+}
+
+// apiPlan is the saved output of 'tyk plan', executed by 'tyk apply --plan'
+type apiPlan struct {
+	Version     int            `json:"version"`
+	Environment string         `json:"environment"`
+	Dir         string         `json:"dir"`
+	Prune       bool           `json:"prune"`
+	CreatedAt   string         `json:"created_at"`
+	Actions     []*planAction  `json:"actions"`
+	Summary     map[string]int `json:"summary"`
+}
+
+// NewPlanCommand creates the 'tyk plan' command
+func NewPlanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Compare a directory of specs with the Dashboard and show what apply would change",
+		Long: `Compare every OAS spec in a directory with the APIs in the environment and
+print a plan of creates, updates and (with --prune) deletes.
+
+Specs are matched to remote APIs by the ID in x-tyk-api-gateway.info.id, then by
+API name. Fields the Dashboard adds to x-tyk-api-gateway on its own are ignored.
+
+Certificate IDs and webhook URLs referenced by the specs are checked when planning and
+again before applying; any unresolved reference fails the run before anything changes.
+So are the Tyk extension features they use: a spec needing a newer Tyk than the
+environment runs (its tyk_version setting, or the version its health endpoint reports)
+fails with the release each feature requires.
+
+With --categories-from-dirs, each API is filed under Dashboard categories named after
+its folders below --dir (apis/payments/cards.yaml gets category payments), keeping
+the Dashboard organised like the repository.
+
+Save the plan with --out and execute exactly that plan later with 'tyk apply --plan'.
+
+Examples:
+  tyk plan --dir ./apis
+  tyk plan --dir ./apis --prune --out plan.json
+  tyk apply --plan plan.json`,
+		Args: cobra.NoArgs,
+		RunE: runPlan,
+	}
+
+	cmd.Flags().String("dir", "", "Directory of OAS specs (YAML or JSON) (required)")
+	cmd.Flags().Bool("prune", false, "Delete remote APIs that have no local spec")
+	cmd.Flags().String("out", "", "Save the plan to this file")
+	cmd.Flags().Bool("categories-from-dirs", false, "File each API under Dashboard categories named after its folders below --dir")
+	cmd.Flags().Bool("skip-compat-check", false, "Do not check specs against the environment's Tyk version")
+	cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+// NewApplyCommand creates the 'tyk apply' command
+func NewApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Execute a plan saved by 'tyk plan'",
+		Long: `Execute the creates, updates and deletes recorded in a plan file.
+
+The plan must have been created against the same environment. Documents are taken
+from the plan itself, so later edits to the spec files do not leak into the apply.
+
+Examples:
+  tyk plan --dir ./apis --prune --out plan.json
+  tyk apply --plan plan.json`,
+		Args: cobra.NoArgs,
+		RunE: runApply,
+	}
+
+	cmd.Flags().String("plan", "", "Plan file written by 'tyk plan --out' (required)")
+	cmd.Flags().Bool("skip-compat-check", false, "Do not check specs against the environment's Tyk version")
+	cmd.MarkFlagRequired("plan")
+
+	return cmd
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	prune, _ := cmd.Flags().GetBool("prune")
+	outFile, _ := cmd.Flags().GetString("out")
+	categoriesFromDirs, _ := cmd.Flags().GetBool("categories-from-dirs")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+
+	files, err := filehandler.FindSpecFiles(dir)
+	if err != nil {
+		return &ExitError{Code: 2, Message: err.Error()}
+	}
+	if len(files) == 0 && prune {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("no OAS files found in %s: refusing to prune every API", dir)}
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	scope := planScope{prune: prune, envPrefix: env.ListenPathPrefix}
+	if categoriesFromDirs {
+		scope.categoryRoot = dir
+	}
+	plan, err := buildPlan(ctx, c, files, scope)
+	if err != nil {
+		return err
+	}
+	if err := checkPlanReferences(ctx, c, plan); err != nil {
+		return err
+	}
+	if err := checkPlanCompatibility(ctx, cmd, c, env, plan); err != nil {
+		return err
+	}
+	plan.Environment = env.Name
+	plan.Dir = dir
+	// Unchanged APIs need no document to apply
+	for _, action := range plan.Actions {
+		if action.Action == planNoChange {
+			action.Document = nil
+		}
+	}
+
+	if outFile != "" {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(outFile, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write plan: %w", err)
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, plan)
+	}
+
+	printPlan(plan, len(files))
+	if outFile != "" {
+		fmt.Printf("\nSaved plan to %s. Apply it with: tyk apply --plan %s\n", outFile, outFile)
+	}
+	return nil
+}
+
+// planScope limits what a plan manages
+type planScope struct {
+	// prune deletes remote APIs in scope that have no local spec
+	prune bool
+	// prefix, when set, mounts every spec under this listen path and narrows pruning
+	// to remote APIs listening under it
+	prefix string
+	// envPrefix is the environment's listen_path_prefix, mounted above prefix
+	envPrefix string
+	// categoryRoot, when set, files every spec under Dashboard categories named after
+	// its folders below this directory: root/payments/cards.yaml gets "payments"
+	categoryRoot string
+}
+
+// mount moves a spec under the scope's prefixes
+func (s planScope) mount(doc map[string]interface{}) error {
+	for _, prefix := range []string{s.prefix, s.envPrefix} {
+		if prefix == "" {
+			continue
+		}
+		if err := oas.MountListenPath(doc, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// categorize adds the categories named after the folders between the scope's
+// categoryRoot and file
+func (s planScope) categorize(file string, doc map[string]interface{}) error {
+	if s.categoryRoot == "" {
+		return nil
+	}
+	rel, err := filepath.Rel(s.categoryRoot, filepath.Dir(file))
+	if err != nil || rel == "." {
+		return nil
+	}
+	categories := strings.Split(filepath.ToSlash(rel), "/")
+	for _, category := range categories {
+		if err := oas.CheckCategory(category); err != nil {
+			return fmt.Errorf("folder %q cannot be a category: %v", category, err)
+		}
+	}
+	return oas.AddCategories(doc, categories)
+}
+
+// owns reports whether a remote API is within the scope's prefixes
+func (s planScope) owns(api *types.OASAPI) bool {
+	listenPath := api.ListenPath
+	if listenPath == "" {
+		listenPath = oas.GetListenPath(api.OAS)
+	}
+	return oas.UnderListenPath(listenPath, path.Join("/", s.envPrefix, s.prefix))
+}
+
+// buildPlan compares local spec files with the remote APIs
+func buildPlan(ctx context.Context, c *client.Client, files []string, scope planScope) (*apiPlan, error) {
+	remote, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return nil, wrapAPIError(err, "failed to list APIs")
+	}
+	byID := make(map[string]*types.OASAPI)
+	byName := make(map[string]*types.OASAPI)
+	// byBaseName matches specs whose Dashboard categories changed since the last apply
+	byBaseName := make(map[string]*types.OASAPI)
+	for _, api := range remote {
+		byID[api.ID] = api
+		if _, ok := byName[api.Name]; !ok {
+			byName[api.Name] = api
+		}
+		if base, _ := oas.SplitCategories(api.Name); byBaseName[base] == nil {
+			byBaseName[base] = api
+		}
+	}
+
+	plan := &apiPlan{
+		Version:   planFormatVersion,
+		Prune:     scope.prune,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Actions:   []*planAction{},
+		Summary:   map[string]int{planCreate: 0, planUpdate: 0, planDelete: 0, planNoChange: 0},
+	}
+	claimed := make(map[string]string)
+
+	for _, file := range files {
+		doc, err := loadOASFromFile(file)
+		if err != nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s: %s", file, errorMessage(err))}
+		}
+		if !oas.HasTykExtensions(doc) {
+			if doc, err = oas.AddTykExtensions(doc); err != nil {
+				return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s: failed to generate Tyk extensions: %v", file, err)}
+			}
+		}
+		if _, err := oas.ExpandRateLimits(doc); err != nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s: %v", file, err)}
+		}
+		if err := scope.mount(doc); err != nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s: %v", file, err)}
+		}
+		if err := scope.categorize(file, doc); err != nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s: %v", file, err)}
+		}
+
+		action := &planAction{File: file, Name: oas.GetAPIName(doc), Document: doc}
+		id, hasID := oas.ExtractAPIIDFromTykExtensions(doc)
+		match := byID[id]
+		if !hasID {
+			match = byName[action.Name]
+			if base, _ := oas.SplitCategories(action.Name); match == nil {
+				match = byBaseName[base]
+			}
+		}
+		if match == nil {
+			action.Action = planCreate
+			action.APIID = id
+			plan.add(action)
+			continue
+		}
+
+		if other, ok := claimed[match.ID]; ok {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("%s and %s both target API %s", other, file, match.ID)}
+		}
+		claimed[match.ID] = file
+		action.APIID = match.ID
+		setTykAPIID(doc, match.ID)
+
+		current, err := c.GetOASAPI(ctx, match.ID, "")
+		if err != nil {
+			return nil, wrapAPIError(err, fmt.Sprintf("failed to get API %s", match.ID))
+		}
+		action.remote = current.OAS
+		action.ETag = current.ETag
+		action.Changes = oas.SignificantChanges(oas.Diff(current.OAS, doc))
+		if len(action.Changes) == 0 {
+			action.Action = planNoChange
+		} else {
+			action.Action = planUpdate
+		}
+		plan.add(action)
+	}
+
+	if scope.prune {
+		var orphans []*types.OASAPI
+		for _, api := range remote {
+			if _, ok := claimed[api.ID]; !ok && scope.owns(api) {
+				orphans = append(orphans, api)
+			}
+		}
+		sort.Slice(orphans, func(i, j int) bool { return orphans[i].Name < orphans[j].Name })
+		for _, api := range orphans {
+			plan.add(&planAction{Action: planDelete, APIID: api.ID, Name: api.Name})
+		}
+	}
+
+	return plan, nil
+}
+
+func (p *apiPlan) add(action *planAction) {
+	p.Actions = append(p.Actions, action)
+	p.Summary[action.Action]++
+}
+
+// printPlan prints a human-readable plan
+func printPlan(plan *apiPlan, specCount int) {
+	color.New(color.FgBlue, color.Bold).Printf("Plan for environment '%s' (%d spec(s) in %s):\n", plan.Environment, specCount, plan.Dir)
+
+	symbols := map[string]*color.Color{
+		planCreate:   color.New(color.FgGreen),
+		planUpdate:   color.New(color.FgYellow),
+		planDelete:   color.New(color.FgRed),
+		planNoChange: color.New(color.FgHiBlack),
+	}
+	marks := map[string]string{planCreate: "+", planUpdate: "~", planDelete: "-", planNoChange: "="}
+
+	for _, action := range plan.Actions {
+		label := action.Name
+		if action.APIID != "" {
+			label = fmt.Sprintf("%s (%s)", action.Name, action.APIID)
+		}
+		if action.File != "" {
+			label = fmt.Sprintf("%s  %s", label, filepath.Base(action.File))
+		}
+		symbols[action.Action].Printf("  %s %-9s %s\n", marks[action.Action], action.Action, label)
+		for _, change := range action.Changes {
+			fmt.Printf("      %s %s\n", change.Type, strings.Join(change.Path, "."))
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		plan.Summary[planCreate], plan.Summary[planUpdate], plan.Summary[planDelete], plan.Summary[planNoChange])
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	planFile, _ := cmd.Flags().GetString("plan")
+
+	plan, err := loadPlan(planFile)
+	if err != nil {
+		return err
+	}
+	progress, err := newProgressReporter(cmd, "apply")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+	if env.Name != plan.Environment {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("plan was created for environment '%s' but the active environment is '%s' (use --env %s)", plan.Environment, env.Name, plan.Environment)}
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	var pending []*planAction
+	for _, action := range plan.Actions {
+		if action.Action != planNoChange {
+			pending = append(pending, action)
+		}
+	}
+	if err := checkEnvironmentPrefix(env, plan); err != nil {
+		return err
+	}
+	// Certificates may have been removed since the plan was made
+	if err := checkPlanReferences(ctx, c, plan); err != nil {
+		return err
+	}
+	if err := checkPlanCompatibility(ctx, cmd, c, env, plan); err != nil {
+		return err
+	}
+
+	results := []apiOperationResult{}
+	failed := 0
+	progress.Start(len(pending))
+	for _, action := range pending {
+		step := action.Name
+		progress.StepStarted(step)
+		result := applyPlanAction(ctx, c, action)
+		if result.Error != "" {
+			failed++
+		}
+		progress.StepFinished(step, result.Error)
+		results = append(results, result)
+	}
+	progress.Finish()
+
+	if len(pending) == 0 && !GetOutputFormatFromContext(cmd.Context()).IsStructured() {
+		color.New(color.FgGreen).Println("No changes. Remote APIs already match the plan.")
+		return nil
+	}
+	if err := outputAPIOperationResults(cmd, results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d planned change(s) failed", failed, len(pending))}
+	}
+	return nil
+}
+
+// checkPlanReferences validates the certificates and webhooks referenced by every API
+// the plan creates or updates
+func checkPlanReferences(ctx context.Context, c *client.Client, plan *apiPlan) error {
+	checker := newReferenceChecker(ctx, c)
+	for _, action := range plan.Actions {
+		if action.Action != planCreate && action.Action != planUpdate {
+			continue
+		}
+		if err := checker.checkAPI(actionResource(action), action.Document); err != nil {
+			return err
+		}
+	}
+	return checker.err()
+}
+
+// checkPlanCompatibility validates every API the plan creates or updates against the
+// Tyk release of the environment, unless --skip-compat-check is set
+func checkPlanCompatibility(ctx context.Context, cmd *cobra.Command, c *client.Client, env *types.Environment, plan *apiPlan) error {
+	if skip, _ := cmd.Flags().GetBool("skip-compat-check"); skip {
+		return nil
+	}
+	checker, err := newCompatibilityChecker(ctx, c, env)
+	if err != nil {
+		return err
+	}
+	for _, action := range plan.Actions {
+		if action.Action == planCreate || action.Action == planUpdate {
+			checker.checkAPI(actionResource(action), action.Document)
+		}
+	}
+	return checker.err()
+}
+
+// actionResource names the API an action changes in error messages
+func actionResource(action *planAction) string {
+	resource := fmt.Sprintf("API '%s'", action.Name)
+	if action.File != "" {
+		resource = fmt.Sprintf("%s (%s)", resource, filepath.Base(action.File))
+	}
+	return resource
+}
+
+// applyPlanAction performs a single create, update or delete
+func applyPlanAction(ctx context.Context, c *client.Client, action *planAction) apiOperationResult {
+	result := apiOperationResult{File: action.File, APIID: action.APIID, Name: action.Name}
+
+	switch action.Action {
+	case planCreate:
+		api, err := c.CreateOASAPI(ctx, action.Document)
+		if err != nil {
+			result.Error = wrapAPIError(err, "failed to create API").Error()
+			return result
+		}
+		result.APIID = api.ID
+		result.Operation = "created"
+	case planUpdate:
+		previous := action.remote
+		if previous == nil {
+			// Saved plans do not carry the deployed document
+			if current, err := c.GetOASAPI(ctx, action.APIID, ""); err == nil {
+				previous = current.OAS
+			}
+		}
+		saveRevision(c, action.APIID, action.Name, previous)
+		stored, err := c.UpdateOASAPIIfMatch(ctx, action.APIID, action.Document, action.ETag)
+		if err != nil {
+			result.Error = wrapAPIError(err, "failed to update API").Error()
+			return result
+		}
+		warnServerEcho(action.Document, stored)
+		result.Operation = "updated"
+	case planDelete:
+		if err := deleteTrashedAPI(ctx, c, action.APIID, true); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Operation = "deleted"
+	default:
+		result.Error = fmt.Sprintf("unknown plan action '%s'", action.Action)
+	}
+	return result
+}
+
+// loadPlan reads and checks a saved plan
+func loadPlan(path string) (*apiPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to read plan: %v", err)}
+	}
+	var plan apiPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("failed to parse plan %s: %v", path, err)}
+	}
+	if plan.Version != planFormatVersion {
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("unsupported plan version %d (expected %d): re-run tyk plan", plan.Version, planFormatVersion)}
+	}
+	for _, action := range plan.Actions {
+		if (action.Action == planCreate || action.Action == planUpdate) && action.Document == nil {
+			return nil, &ExitError{Code: 2, Message: fmt.Sprintf("plan action %s for '%s' has no document", action.Action, action.Name)}
+		}
+	}
+	return &plan, nil
+}
@@ -0,0 +1,290 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func writePlanSpec(t *testing.T, dir, name, version string) string {
+	t.Helper()
+	path := filepath.Join(dir, name+".yaml")
+	spec := fmt.Sprintf("openapi: 3.0.3\ninfo:\n  title: %s\n  version: %s\nservers:\n  - url: https://%s.internal\npaths: {}\n", name, version, name)
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0644))
+	return path
+}
+
+// seedRemoteAPI stores the Tyk form of a spec in the fake Dashboard under id
+func seedRemoteAPI(t *testing.T, d *fakeDashboard, id, name, version string) {
+	t.Helper()
+	doc, err := loadOASFromFile(writePlanSpec(t, t.TempDir(), name, version))
+	require.NoError(t, err)
+	doc, err = oas.AddTykExtensions(doc)
+	require.NoError(t, err)
+	setTykAPIID(doc, id)
+	d.apis[id] = doc
+}
+
+func runRootCommand(t *testing.T, args ...string) ([]byte, error) {
+	t.Helper()
+	root := NewRootCommand("test", "", "")
+	root.SetArgs(args)
+	return captureStdout(root.Execute)
+}
+
+func TestPlanAndApply(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "orders", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-3", "legacy", "1.0.0")
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+			"prod": {Name: "prod", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	writePlanSpec(t, dir, "users", "1.0.0")
+	writePlanSpec(t, dir, "orders", "1.1.0")
+	writePlanSpec(t, dir, "payments", "1.0.0")
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	out, err := runRootCommand(t, "plan", "--dir", dir, "--prune", "--out", planFile, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("plan", out), string(out))
+
+	saved, err := os.ReadFile(planFile)
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("plan", saved))
+
+	var plan apiPlan
+	require.NoError(t, json.Unmarshal(saved, &plan))
+	assert.Equal(t, "test", plan.Environment)
+	assert.Equal(t, map[string]int{planCreate: 1, planUpdate: 1, planDelete: 1, planNoChange: 1}, plan.Summary)
+
+	actions := map[string]*planAction{}
+	for _, action := range plan.Actions {
+		actions[action.Name] = action
+	}
+	assert.Equal(t, planNoChange, actions["users"].Action)
+	assert.Nil(t, actions["users"].Document)
+	assert.Equal(t, planUpdate, actions["orders"].Action)
+	assert.Equal(t, []string{"info", "version"}, actions["orders"].Changes[0].Path)
+	assert.Equal(t, planCreate, actions["payments"].Action)
+	assert.Equal(t, planDelete, actions["legacy"].Action)
+	assert.Equal(t, "remote-3", actions["legacy"].APIID)
+
+	// Planning does not change anything remotely
+	assert.Equal(t, 3, dashboard.count())
+
+	_, err = runRootCommand(t, "apply", "--plan", planFile, "--env", "prod")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	out, err = runRootCommand(t, "apply", "--plan", planFile, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("api-operation-results", out), string(out))
+
+	assert.Equal(t, 3, dashboard.count())
+	assert.Nil(t, dashboard.apis["remote-3"])
+	assert.Equal(t, "1.1.0", dashboard.apis["remote-2"]["info"].(map[string]interface{})["version"])
+
+	// A fresh plan is now empty
+	out, err = runRootCommand(t, "plan", "--dir", dir, "--prune", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &plan))
+	assert.Equal(t, 3, plan.Summary[planNoChange], string(out))
+}
+
+func TestPlanAndApply_RefusesAPIChangedSincePlan(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dashboard.etags = true
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "orders", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	writePlanSpec(t, dir, "users", "1.1.0")
+	writePlanSpec(t, dir, "orders", "1.1.0")
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	_, err := runRootCommand(t, "plan", "--dir", dir, "--out", planFile)
+	require.NoError(t, err)
+	saved, err := os.ReadFile(planFile)
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("plan", saved))
+
+	// Someone else changes users after the plan was made
+	dashboard.apis["remote-1"]["info"].(map[string]interface{})["description"] = "changed elsewhere"
+
+	out, err := runRootCommand(t, "apply", "--plan", planFile, "-o", "json")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+	var results struct {
+		Results []apiOperationResult `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(out, &results), string(out))
+	require.Len(t, results.Results, 2)
+	for _, result := range results.Results {
+		if result.APIID == "remote-1" {
+			assert.Contains(t, result.Error, "changed by someone else")
+		} else {
+			assert.Empty(t, result.Error)
+		}
+	}
+	assert.Equal(t, "changed elsewhere", dashboard.apis["remote-1"]["info"].(map[string]interface{})["description"])
+	assert.Equal(t, "1.1.0", dashboard.apis["remote-2"]["info"].(map[string]interface{})["version"])
+}
+
+func TestPlan_RefusesToPruneEmptyDirectory(t *testing.T) {
+	_, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "plan", "--dir", t.TempDir(), "--prune")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+func TestPlanAndApply_ExpandsRateLimitShorthand(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	spec := "openapi: 3.0.3\ninfo:\n  title: users\n  version: 1.0.0\nservers:\n  - url: https://users.internal\npaths:\n  /users:\n    get:\n      operationId: listUsers\n      x-tyk-ratelimit: {rate: 10, per: 60}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.yaml"), []byte(spec), 0644))
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	_, err := runRootCommand(t, "plan", "--dir", dir, "--out", planFile)
+	require.NoError(t, err)
+	_, err = runRootCommand(t, "apply", "--plan", planFile)
+	require.NoError(t, err)
+	require.Equal(t, 1, dashboard.count())
+
+	for _, doc := range dashboard.apis {
+		operations := doc[oas.TykExtensionKey].(map[string]interface{})["middleware"].(map[string]interface{})["operations"].(map[string]interface{})
+		rateLimit := operations["listUsers"].(map[string]interface{})["rateLimit"].(map[string]interface{})
+		assert.Equal(t, float64(10), rateLimit["rate"])
+		assert.Equal(t, "60s", rateLimit["per"])
+	}
+
+	// The shorthand and its expansion compare equal, so nothing is left to change
+	out, err := runRootCommand(t, "plan", "--dir", dir, "-o", "json")
+	require.NoError(t, err)
+	var plan apiPlan
+	require.NoError(t, json.Unmarshal(out, &plan))
+	assert.Equal(t, 1, plan.Summary[planNoChange], string(out))
+}
+
+func TestPlanAndApply_RejectsFeaturesNewerThanEnvironment(t *testing.T) {
+	dashboard, fake := newFakeDashboard(t)
+	// The Dashboard reports its release on the health endpoint
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			json.NewEncoder(w).Encode(map[string]string{"status": "ok", "version": "v5.3.1"})
+			return
+		}
+		dashboard.serve(w, r)
+	}))
+	t.Cleanup(server.Close)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test":   {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+			"pinned": {Name: "pinned", DashboardURL: fake.URL, AuthToken: "token", OrgID: "org", TykVersion: "5.4"},
+		},
+	})
+
+	dir := t.TempDir()
+	spec := "openapi: 3.0.3\ninfo:\n  title: users\n  version: 1.0.0\nservers:\n  - url: https://users.internal\npaths:\n  /users:\n    get:\n      operationId: listUsers\n      x-tyk-ratelimit: {rate: 10, per: 60}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.yaml"), []byte(spec), 0644))
+
+	_, err := runRootCommand(t, "plan", "--dir", dir)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "runs Tyk 5.3.1")
+	assert.Contains(t, exitErr.Message, "API 'users' (users.yaml): x-tyk-api-gateway.middleware.operations.listUsers.rateLimit: per-endpoint rate limits require Tyk >= 5.4")
+
+	_, err = runRootCommand(t, "plan", "--dir", dir, "--skip-compat-check")
+	assert.NoError(t, err)
+
+	// A configured tyk_version is trusted without asking the server
+	_, err = runRootCommand(t, "plan", "--dir", dir, "--env", "pinned")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dashboard.count())
+}
+
+func TestPlanAndApply_CategoriesFromDirs(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "payments"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "search", "internal"), 0755))
+	writePlanSpec(t, filepath.Join(dir, "payments"), "users", "1.0.0")
+	writePlanSpec(t, filepath.Join(dir, "search", "internal"), "index", "1.0.0")
+	writePlanSpec(t, dir, "orders", "1.0.0")
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	// The existing API is matched by name even though its categories change
+	out, err := runRootCommand(t, "plan", "--dir", dir, "--categories-from-dirs", "--out", planFile, "-o", "json")
+	require.NoError(t, err)
+	var plan apiPlan
+	require.NoError(t, json.Unmarshal(out, &plan))
+	assert.Equal(t, map[string]int{planCreate: 2, planUpdate: 1, planDelete: 0, planNoChange: 0}, plan.Summary)
+
+	_, err = runRootCommand(t, "apply", "--plan", planFile)
+	require.NoError(t, err)
+	names := []string{}
+	for _, doc := range dashboard.apis {
+		names = append(names, oas.GetAPIName(doc))
+	}
+	assert.ElementsMatch(t, []string{"users #payments", "index #search #internal", "orders"}, names)
+
+	out, err = runRootCommand(t, "drift", "--dir", dir, "--categories-from-dirs", "-o", "json")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"drifted": 0`)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "two words"), 0755))
+	writePlanSpec(t, filepath.Join(dir, "two words"), "misc", "1.0.0")
+	_, err = runRootCommand(t, "plan", "--dir", dir, "--categories-from-dirs")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
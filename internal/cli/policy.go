@@ -0,0 +1,373 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultPolicyNamePattern names generated policies when the template does not
+const defaultPolicyNamePattern = "{api} {tier}"
+
+// policyTemplate describes a set of tiers applied identically to every API
+type policyTemplate struct {
+	// Name is the policy name pattern; {api} and {tier} are replaced
+	Name  string       `yaml:"name"`
+	Tags  []string     `yaml:"tags"`
+	Tiers []policyTier `yaml:"tiers"`
+}
+
+// policyTier is one tier's rate limit and quota
+type policyTier struct {
+	Name             string   `yaml:"name"`
+	Rate             float64  `yaml:"rate"`
+	Per              float64  `yaml:"per"`
+	QuotaMax         int64    `yaml:"quota_max"`
+	QuotaRenewalRate int64    `yaml:"quota_renewal_rate"`
+	Tags             []string `yaml:"tags"`
+}
+
+// generatedPolicy is one policy created, updated or left alone by 'tyk policy generate'
+type generatedPolicy struct {
+	Action   string `json:"action"`
+	APIID    string `json:"api_id"`
+	Tier     string `json:"tier"`
+	Name     string `json:"name"`
+	PolicyID string `json:"policy_id,omitempty"`
+
+	policy *types.Policy
+}
+
+// NewPolicyCommand creates the 'tyk policy' command and its subcommands
+func NewPolicyCommand() *cobra.Command {
+	policyCmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage security policies",
+		Long:  `Commands for managing the security policies that set access, rate limits and quotas for keys.`,
+	}
+
+	policyCmd.AddCommand(NewPolicyGenerateCommand())
+
+	return policyCmd
+}
+
+// NewPolicyGenerateCommand creates the 'tyk policy generate' command
+func NewPolicyGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Create or update tiered policies for APIs from a template",
+		Long: `Generate one policy per tier for each API from a tier template, so tiers such as
+Bronze, Silver and Gold have the same limits on every API.
+
+Policies are matched by name. Re-running after editing the template updates the existing
+policies in place; policies that already match are left alone.
+
+Example tiering.yaml:
+  name: "{api} {tier}"        # optional, this is the default
+  tags: [tiered]
+  tiers:
+    - name: Bronze
+      rate: 10                # requests...
+      per: 60                 # ...per this many seconds
+      quota_max: 10000        # -1 for unlimited
+      quota_renewal_rate: 2592000
+    - name: Gold
+      rate: 1000
+      per: 60
+      quota_max: -1
+
+Examples:
+  tyk policy generate --template tiering.yaml --api 5e9d9544a1dcd60001d0ed20
+  tyk policy generate --template tiering.yaml --api users-api --api orders-api --dry-run
+  tyk policy generate --template tiering.yaml --api users-api -o json`,
+		Args: cobra.NoArgs,
+		RunE: runPolicyGenerate,
+	}
+
+	cmd.Flags().StringP("template", "t", "", "Tier template file (required)")
+	cmd.Flags().StringSlice("api", nil, "API ID to generate policies for; repeat for several (required)")
+	cmd.Flags().Bool("dry-run", false, "Show what would be created or updated without changing anything")
+	cmd.MarkFlagRequired("template")
+	cmd.MarkFlagRequired("api")
+
+	return cmd
+}
+
+func runPolicyGenerate(cmd *cobra.Command, args []string) error {
+	templatePath, _ := cmd.Flags().GetString("template")
+	apiIDs, _ := cmd.Flags().GetStringSlice("api")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	tmpl, err := loadPolicyTemplate(templatePath)
+	if err != nil {
+		return err
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	if c.IsGateway() {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "policies are managed by the Dashboard; use a dashboard environment"}
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	// Resolve every API before changing anything
+	apis := make([]*types.OASAPI, 0, len(apiIDs))
+	for _, apiID := range apiIDs {
+		api, err := c.GetOASAPI(ctx, apiID, "")
+		if err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				return notFoundError(err, fmt.Sprintf("API '%s' not found", apiID))
+			}
+			return wrapAPIError(err, "failed to get API")
+		}
+		apis = append(apis, api)
+	}
+
+	existing, err := c.ListPolicies(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list policies")
+	}
+	byName := make(map[string]*types.Policy, len(existing))
+	for _, policy := range existing {
+		byName[policy.Name] = policy
+	}
+
+	var results []*generatedPolicy
+	for _, api := range apis {
+		for _, tier := range tmpl.Tiers {
+			results = append(results, planTierPolicy(tmpl, tier, api, env.OrgID, byName))
+		}
+	}
+
+	if !dryRun {
+		for _, result := range results {
+			switch result.Action {
+			case planCreate:
+				id, err := c.CreatePolicy(ctx, result.policy)
+				if err != nil {
+					return wrapAPIError(err, fmt.Sprintf("failed to create policy '%s'", result.Name))
+				}
+				result.PolicyID = id
+			case planUpdate:
+				// Patch the stored policy: types.Policy leaves out fields such as partitions
+				// and per-API limits, which a PUT of it would wipe
+				doc, err := c.GetPolicyDocument(ctx, result.PolicyID)
+				if err != nil {
+					return wrapAPIError(err, fmt.Sprintf("failed to get policy '%s'", result.Name))
+				}
+				patchPolicyDocument(doc, result.policy)
+				if err := c.UpdatePolicyDocument(ctx, result.PolicyID, doc); err != nil {
+					return wrapAPIError(err, fmt.Sprintf("failed to update policy '%s'", result.Name))
+				}
+			}
+		}
+	}
+
+	summary := map[string]int{planCreate: 0, planUpdate: 0, planNoChange: 0}
+	for _, result := range results {
+		summary[result.Action]++
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"template": templatePath,
+			"dry_run":  dryRun,
+			"policies": results,
+			"summary":  summary,
+		})
+	}
+	printGeneratedPolicies(results, summary, dryRun)
+	return nil
+}
+
+// planTierPolicy builds the policy for one API and tier and decides whether it must be created or updated
+func planTierPolicy(tmpl *policyTemplate, tier policyTier, api *types.OASAPI, orgID string, byName map[string]*types.Policy) *generatedPolicy {
+	name := tmpl.policyName(api.Name, tier.Name)
+	desired := &types.Policy{
+		Name:             name,
+		OrgID:            orgID,
+		Active:           true,
+		Rate:             tier.Rate,
+		Per:              tier.Per,
+		QuotaMax:         tier.QuotaMax,
+		QuotaRenewalRate: tier.QuotaRenewalRate,
+		Tags:             mergeTags(tmpl.Tags, tier.Tags),
+		AccessRights: map[string]*types.PolicyAccessRights{
+			api.ID: {APIID: api.ID, APIName: api.Name, Versions: []string{"Default"}},
+		},
+	}
+	result := &generatedPolicy{Action: planCreate, APIID: api.ID, Tier: tier.Name, Name: name, policy: desired}
+
+	current, ok := byName[name]
+	if !ok {
+		return result
+	}
+	result.PolicyID = current.ID
+	if policyMatches(current, desired) {
+		result.Action = planNoChange
+		return result
+	}
+	result.Action = planUpdate
+	desired.ID = current.ID
+	if current.OrgID != "" {
+		desired.OrgID = current.OrgID
+	}
+	return result
+}
+
+// patchPolicyDocument sets the limits, tags and access of a generated policy on the
+// stored document of an existing one. Every other field is kept, and so are the settings
+// of an access_rights entry the policy already had, such as its allowed URLs and limits.
+func patchPolicyDocument(doc map[string]interface{}, desired *types.Policy) {
+	doc["active"] = desired.Active
+	doc["rate"] = desired.Rate
+	doc["per"] = desired.Per
+	doc["quota_max"] = desired.QuotaMax
+	doc["quota_renewal_rate"] = desired.QuotaRenewalRate
+	doc["tags"] = desired.Tags
+
+	current, _ := doc["access_rights"].(map[string]interface{})
+	access := make(map[string]interface{}, len(desired.AccessRights))
+	for apiID, rights := range desired.AccessRights {
+		entry, ok := current[apiID].(map[string]interface{})
+		if !ok {
+			entry = map[string]interface{}{}
+		}
+		entry["api_id"] = rights.APIID
+		entry["api_name"] = rights.APIName
+		if versions, _ := entry["versions"].([]interface{}); len(versions) == 0 {
+			entry["versions"] = rights.Versions
+		}
+		access[apiID] = entry
+	}
+	doc["access_rights"] = access
+}
+
+// policyMatches reports whether an existing policy already has the desired limits and access
+func policyMatches(current, desired *types.Policy) bool {
+	if !current.Active || current.Rate != desired.Rate || current.Per != desired.Per ||
+		current.QuotaMax != desired.QuotaMax || current.QuotaRenewalRate != desired.QuotaRenewalRate {
+		return false
+	}
+	if !slices.Equal(mergeTags(current.Tags), desired.Tags) || len(current.AccessRights) != len(desired.AccessRights) {
+		return false
+	}
+	for apiID := range desired.AccessRights {
+		if _, ok := current.AccessRights[apiID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeTags combines tag lists, sorted and without duplicates
+func mergeTags(lists ...[]string) []string {
+	tags := []string{}
+	for _, list := range lists {
+		for _, tag := range list {
+			if tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+func (t *policyTemplate) policyName(apiName, tierName string) string {
+	return strings.NewReplacer("{api}", apiName, "{tier}", tierName).Replace(t.Name)
+}
+
+// loadPolicyTemplate reads and validates a tier template
+func loadPolicyTemplate(path string) (*policyTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read template: %v", err)}
+	}
+	var tmpl policyTemplate
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to parse template: %v", err)}
+	}
+	if tmpl.Name == "" {
+		tmpl.Name = defaultPolicyNamePattern
+	}
+	if err := tmpl.validate(); err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid template %s: %v", path, err)}
+	}
+	return &tmpl, nil
+}
+
+func (t *policyTemplate) validate() error {
+	if len(t.Tiers) == 0 {
+		return fmt.Errorf("no tiers defined")
+	}
+	if !strings.Contains(t.Name, "{tier}") {
+		return fmt.Errorf("name pattern '%s' must contain {tier} so each tier gets its own policy", t.Name)
+	}
+	seen := make(map[string]bool)
+	for i, tier := range t.Tiers {
+		switch {
+		case tier.Name == "":
+			return fmt.Errorf("tier %d has no name", i+1)
+		case seen[tier.Name]:
+			return fmt.Errorf("tier '%s' is defined more than once", tier.Name)
+		case tier.Rate == -1:
+			// unlimited rate
+		case tier.Rate <= 0 || tier.Per <= 0:
+			return fmt.Errorf("tier '%s' needs a positive rate and per (or rate: -1 for unlimited)", tier.Name)
+		}
+		switch {
+		case tier.QuotaMax < -1:
+			return fmt.Errorf("tier '%s' has an invalid quota_max %d (use -1 for unlimited)", tier.Name, tier.QuotaMax)
+		case tier.QuotaMax > 0 && tier.QuotaRenewalRate <= 0:
+			return fmt.Errorf("tier '%s' has a quota but no quota_renewal_rate", tier.Name)
+		}
+		seen[tier.Name] = true
+	}
+	return nil
+}
+
+func printGeneratedPolicies(results []*generatedPolicy, summary map[string]int, dryRun bool) {
+	symbols := map[string]*color.Color{
+		planCreate:   color.New(color.FgGreen),
+		planUpdate:   color.New(color.FgYellow),
+		planNoChange: color.New(color.FgHiBlack),
+	}
+	marks := map[string]string{planCreate: "+", planUpdate: "~", planNoChange: "="}
+
+	for _, result := range results {
+		label := result.Name
+		if result.PolicyID != "" {
+			label = fmt.Sprintf("%s (%s)", result.Name, result.PolicyID)
+		}
+		symbols[result.Action].Printf("  %s %-9s %s\n", marks[result.Action], result.Action, label)
+	}
+
+	if dryRun {
+		fmt.Printf("\nDry run: %d to create, %d to update, %d unchanged.\n", summary[planCreate], summary[planUpdate], summary[planNoChange])
+		return
+	}
+	fmt.Printf("\nPolicies: %d created, %d updated, %d unchanged.\n", summary[planCreate], summary[planUpdate], summary[planNoChange])
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const tieringTemplate = `tags: [tiered]
+tiers:
+  - name: Bronze
+    rate: 10
+    per: 60
+    quota_max: 1000
+    quota_renewal_rate: 3600
+  - name: Gold
+    rate: 1000
+    per: 60
+    quota_max: -1
+    tags: [premium]
+`
+
+func writePolicyTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tiering.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+type policyGenerateOutput struct {
+	DryRun   bool               `json:"dry_run"`
+	Policies []*generatedPolicy `json:"policies"`
+	Summary  map[string]int     `json:"summary"`
+}
+
+func generatePolicies(t *testing.T, args ...string) (*policyGenerateOutput, error) {
+	t.Helper()
+	out, err := runRootCommand(t, append([]string{"policy", "generate", "-o", "json"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	require.NoError(t, outputschema.Validate("policy-generate", out), string(out))
+	var result policyGenerateOutput
+	require.NoError(t, json.Unmarshal(out, &result))
+	return &result, nil
+}
+
+func TestPolicyGenerate(t *testing.T) {
+	d, server := newFakeDashboard(t)
+	seedRemoteAPI(t, d, "remote-1", "Users", "1.0.0")
+	seedRemoteAPI(t, d, "remote-2", "Orders", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "dev",
+		Environments: map[string]*types.Environment{
+			"dev": {Name: "dev", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	template := writePolicyTemplate(t, tieringTemplate)
+
+	// A dry run changes nothing
+	result, err := generatePolicies(t, "--template", template, "--api", "remote-1", "--dry-run")
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Summary[planCreate])
+	assert.Empty(t, d.policies)
+
+	result, err = generatePolicies(t, "--template", template, "--api", "remote-1", "--api", "remote-2")
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Summary[planCreate])
+	require.Len(t, d.policies, 4)
+
+	var gold *types.Policy
+	for _, policy := range d.policies {
+		if policy.Name == "Users Gold" {
+			gold = policy
+		}
+	}
+	require.NotNil(t, gold)
+	assert.Equal(t, "org", gold.OrgID)
+	assert.Equal(t, float64(1000), gold.Rate)
+	assert.Equal(t, int64(-1), gold.QuotaMax)
+	assert.Equal(t, []string{"premium", "tiered"}, gold.Tags)
+	assert.Contains(t, gold.AccessRights, "remote-1")
+
+	// Running again is a no-op; changing a tier updates every API's policy in place
+	result, err = generatePolicies(t, "--template", template, "--api", "remote-1", "--api", "remote-2")
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Summary[planNoChange])
+
+	// Fields tiers do not manage survive the update
+	var bronzeID string
+	for id, policy := range d.policies {
+		if policy.Name == "Orders Bronze" {
+			bronzeID = id
+		}
+	}
+	doc := d.policyDocs[bronzeID]
+	doc["partitions"] = map[string]interface{}{"quota": true}
+	doc["access_rights"].(map[string]interface{})["remote-2"].(map[string]interface{})["allowed_urls"] = []interface{}{"/orders"}
+
+	template = writePolicyTemplate(t, strings.Replace(tieringTemplate, "rate: 10\n", "rate: 20\n", 1))
+	result, err = generatePolicies(t, "--template", template, "--api", "remote-1", "--api", "remote-2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Summary[planUpdate])
+	assert.Equal(t, 2, result.Summary[planNoChange])
+	require.Len(t, d.policies, 4)
+	assert.Equal(t, float64(20), d.policies[bronzeID].Rate)
+	doc = d.policyDocs[bronzeID]
+	assert.Equal(t, map[string]interface{}{"quota": true}, doc["partitions"])
+	rights := doc["access_rights"].(map[string]interface{})["remote-2"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"/orders"}, rights["allowed_urls"])
+	assert.Equal(t, []interface{}{"Default"}, rights["versions"])
+}
+
+func TestPolicyGenerate_Errors(t *testing.T) {
+	d, server := newFakeDashboard(t)
+	seedRemoteAPI(t, d, "remote-1", "Users", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "dev",
+		Environments: map[string]*types.Environment{
+			"dev": {Name: "dev", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		api      string
+		code     types.ExitCode
+	}{
+		{"unknown API", tieringTemplate, "missing", types.ExitNotFound},
+		{"no tiers", "tags: [x]\n", "remote-1", types.ExitBadArgs},
+		{"duplicate tier", "tiers:\n  - {name: A, rate: 1, per: 1}\n  - {name: A, rate: 1, per: 1}\n", "remote-1", types.ExitBadArgs},
+		{"missing per", "tiers:\n  - {name: A, rate: 1}\n", "remote-1", types.ExitBadArgs},
+		{"quota without renewal", "tiers:\n  - {name: A, rate: 1, per: 1, quota_max: 10}\n", "remote-1", types.ExitBadArgs},
+		{"name without tier", "name: \"{api}\"\ntiers:\n  - {name: A, rate: 1, per: 1}\n", "remote-1", types.ExitBadArgs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generatePolicies(t, "--template", writePolicyTemplate(t, tt.template), "--api", tt.api)
+			var exitErr *ExitError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, int(tt.code), exitErr.Code)
+			assert.Empty(t, d.policies)
+		})
+	}
+}
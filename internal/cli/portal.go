@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// catalogueVersion marks policy-based catalogue entries, the only kind new portals list
+const catalogueVersion = "v2"
+
+// portalPublishResult is the structured output of 'tyk portal publish'
+type portalPublishResult struct {
+	APIID           string `json:"api_id" yaml:"api_id"`
+	PolicyID        string `json:"policy_id" yaml:"policy_id"`
+	Name            string `json:"name" yaml:"name"`
+	DocumentationID string `json:"documentation_id" yaml:"documentation_id"`
+	Show            bool   `json:"show" yaml:"show"`
+	Operation       string `json:"operation" yaml:"operation"`
+}
+
+// NewPortalCommand creates the 'tyk portal' command and its subcommands
+func NewPortalCommand() *cobra.Command {
+	portalCmd := &cobra.Command{
+		Use:   "portal",
+		Short: "Publish APIs to the developer portal catalogue",
+		Long: `Commands for the classic developer portal's API catalogue, where developers find
+APIs and request keys for them through a policy.`,
+	}
+
+	portalCmd.AddCommand(NewPortalPublishCommand())
+	portalCmd.AddCommand(NewPortalListCommand())
+	portalCmd.AddCommand(NewPortalUnpublishCommand())
+
+	return portalCmd
+}
+
+// NewPortalPublishCommand creates the 'tyk portal publish' command
+func NewPortalPublishCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish <api-id>",
+		Short: "Publish an API in the developer portal catalogue",
+		Long: `Publish an API in the developer portal catalogue with its documentation. Keys
+developers request from the catalogue entry are created from --policy, which should
+grant access to the API.
+
+The documentation is --docs, or the deployed API's OpenAPI document without its Tyk
+extension. Publishing again with the same policy updates the entry and replaces its
+documentation.
+
+Examples:
+  tyk portal publish 7c2f4a1b --policy 5f1a2b3c4d5e6f7a8b9c0d1e --docs users.yaml
+  tyk portal publish 7c2f4a1b --policy 5f1a2b3c4d5e6f7a8b9c0d1e --description "User accounts" --hidden`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPortalPublish,
+	}
+
+	cmd.Flags().String("policy", "", "Policy keys are requested through (required)")
+	cmd.Flags().String("docs", "", "OpenAPI document to publish as documentation (default: the deployed API's)")
+	cmd.Flags().String("name", "", "Catalogue name (default: the API name)")
+	cmd.Flags().String("description", "", "Short description (default: the document's info.description)")
+	cmd.Flags().Bool("hidden", false, "Add the entry without showing it in the catalogue")
+	cmd.MarkFlagRequired("policy")
+
+	return cmd
+}
+
+// NewPortalListCommand creates the 'tyk portal list' command
+func NewPortalListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the APIs in the developer portal catalogue",
+		Args:  cobra.NoArgs,
+		RunE:  runPortalList,
+	}
+}
+
+// NewPortalUnpublishCommand creates the 'tyk portal unpublish' command
+func NewPortalUnpublishCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpublish <api-id | policy-id>",
+		Short: "Remove an API from the developer portal catalogue",
+		Long: `Remove catalogue entries, and their documentation, by API ID or policy ID. Keys
+already issued through the entries keep working.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPortalUnpublish,
+	}
+}
+
+func runPortalPublish(cmd *cobra.Command, args []string) error {
+	apiID := args[0]
+	policyID, _ := cmd.Flags().GetString("policy")
+	docsPath, _ := cmd.Flags().GetString("docs")
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+	hidden, _ := cmd.Flags().GetBool("hidden")
+
+	var docs map[string]interface{}
+	if docsPath != "" {
+		var err error
+		if docs, err = loadOASFromFile(docsPath); err != nil {
+			return err
+		}
+	}
+
+	ctx, c, cancel, err := dashboardClient(cmd, "portal catalogue entries")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	api, err := getAPIForEdit(ctx, c, apiID)
+	if err != nil {
+		return err
+	}
+	policies, err := c.ListPolicies(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list policies")
+	}
+	var policy *types.Policy
+	for _, candidate := range policies {
+		if candidate.ID == policyID {
+			policy = candidate
+		}
+	}
+	if policy == nil {
+		return notFoundError(nil, fmt.Sprintf("policy '%s' not found", policyID))
+	}
+	if _, ok := policy.AccessRights[apiID]; !ok {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Policy '%s' does not grant access to %s; keys requested from the catalogue will be rejected by it.\n", policy.Name, api.Name)
+	}
+
+	if docs == nil {
+		docs = api.OAS
+	}
+	published := make(map[string]interface{}, len(docs))
+	for key, value := range docs {
+		if key != oas.TykExtensionKey {
+			published[key] = value
+		}
+	}
+	encoded, err := json.Marshal(published)
+	if err != nil {
+		return fmt.Errorf("failed to encode documentation: %w", err)
+	}
+	if name == "" {
+		name = api.Name
+	}
+	if description == "" {
+		if info, ok := published["info"].(map[string]interface{}); ok {
+			description, _ = info["description"].(string)
+		}
+	}
+
+	// The catalogue is replaced whole, so it is edited as stored to keep every field
+	catalogue, err := c.GetCatalogueDocument(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to get the portal catalogue")
+	}
+	entries, _ := catalogue["apis"].([]interface{})
+	var entry map[string]interface{}
+	for _, candidate := range entries {
+		if candidate, ok := candidate.(map[string]interface{}); ok && candidate["policy_id"] == policyID {
+			entry = candidate
+		}
+	}
+	operation := "updated"
+	if entry == nil {
+		operation = "published"
+		if entry, err = itemFields(&types.CatalogueAPI{PolicyID: policyID, Version: catalogueVersion}); err != nil {
+			return err
+		}
+		catalogue["apis"] = append(entries, entry)
+	}
+	previousDocs, _ := entry["documentation"].(string)
+
+	docID, err := c.CreateDocumentation(ctx, &types.PortalDocumentation{
+		APIID:         apiID,
+		DocType:       "swagger",
+		Documentation: base64.StdEncoding.EncodeToString(encoded),
+	})
+	if err != nil {
+		return wrapAPIError(err, "failed to upload documentation")
+	}
+	entry["name"], entry["short_description"], entry["api_id"] = name, description, apiID
+	entry["show"], entry["documentation"] = !hidden, docID
+	if err := c.UpdateCatalogueDocument(ctx, catalogue); err != nil {
+		return wrapAPIError(err, "failed to update the portal catalogue")
+	}
+	if previousDocs != "" {
+		if err := c.DeleteDocumentation(ctx, previousDocs); err != nil {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ The previous documentation (%s) could not be deleted: %v\n", previousDocs, err)
+		}
+	}
+
+	result := &portalPublishResult{APIID: apiID, PolicyID: policyID, Name: name, DocumentationID: docID, Show: !hidden, Operation: operation}
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, result)
+	}
+	visibility := ""
+	if hidden {
+		visibility = " (hidden)"
+	}
+	color.New(color.FgGreen).Printf("✓ %s %s in the portal catalogue%s\n", name, operation, visibility)
+	fmt.Printf("  API ID:         %s\n", apiID)
+	fmt.Printf("  Policy ID:      %s\n", policyID)
+	fmt.Printf("  Documentation:  %s\n", docID)
+	return nil
+}
+
+func runPortalList(cmd *cobra.Command, args []string) error {
+	ctx, c, cancel, err := dashboardClient(cmd, "portal catalogue entries")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	catalogue, err := c.GetCatalogue(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to get the portal catalogue")
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{"apis": catalogue.APIs})
+	}
+	if len(catalogue.APIs) == 0 {
+		fmt.Println("No APIs are published in the portal catalogue.")
+		return nil
+	}
+	tbl := &table{
+		Columns:   []tableColumn{{Header: "Name", Min: 10}, {Header: "API ID"}, {Header: "Policy ID"}, {Header: "Shown"}, {Header: "Docs"}},
+		Width:     terminalWidth(os.Stdout),
+		Separator: "  ",
+	}
+	rows := make([][]string, len(catalogue.APIs))
+	for i, entry := range catalogue.APIs {
+		rows[i] = []string{entry.Name, entry.APIID, entry.PolicyID, yesNo(entry.Show), yesNo(entry.Documentation != "")}
+	}
+	tbl.Write(os.Stdout, rows)
+	return nil
+}
+
+func runPortalUnpublish(cmd *cobra.Command, args []string) error {
+	ref := args[0]
+
+	ctx, c, cancel, err := dashboardClient(cmd, "portal catalogue entries")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	catalogue, err := c.GetCatalogueDocument(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to get the portal catalogue")
+	}
+	entries, _ := catalogue["apis"].([]interface{})
+	kept := []interface{}{}
+	removed := []*types.CatalogueAPI{}
+	for _, raw := range entries {
+		entry := catalogueEntry(raw)
+		if entry.APIID == ref || entry.PolicyID == ref {
+			removed = append(removed, entry)
+		} else {
+			kept = append(kept, raw)
+		}
+	}
+	if len(removed) == 0 {
+		return notFoundError(nil, fmt.Sprintf("no catalogue entry for API or policy '%s'", ref))
+	}
+
+	catalogue["apis"] = kept
+	if err := c.UpdateCatalogueDocument(ctx, catalogue); err != nil {
+		return wrapAPIError(err, "failed to update the portal catalogue")
+	}
+	for _, entry := range removed {
+		if entry.Documentation == "" {
+			continue
+		}
+		if err := c.DeleteDocumentation(ctx, entry.Documentation); err != nil {
+			color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ The documentation of %s (%s) could not be deleted: %v\n", entry.Name, entry.Documentation, err)
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{"removed": removed})
+	}
+	for _, entry := range removed {
+		color.New(color.FgGreen).Printf("✓ Removed %s (policy %s) from the portal catalogue\n", entry.Name, entry.PolicyID)
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// catalogueEntry reads an entry of a stored catalogue; entries it cannot read are empty
+func catalogueEntry(raw interface{}) *types.CatalogueAPI {
+	entry := &types.CatalogueAPI{}
+	if data, err := json.Marshal(raw); err == nil {
+		json.Unmarshal(data, entry)
+	}
+	return entry
+}
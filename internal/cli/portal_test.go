@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestPortalPublish(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	dashboard.policies["policy-gold"] = &types.Policy{
+		ID:           "policy-gold",
+		Name:         "Users Gold",
+		AccessRights: map[string]*types.PolicyAccessRights{"remote-1": {APIID: "remote-1"}},
+	}
+	// Fields the CLI does not model must survive its updates
+	dashboard.catalogue = map[string]interface{}{
+		"email": "portal@acme.io",
+		"apis": []interface{}{
+			map[string]interface{}{"name": "Orders", "policy_id": "policy-orders", "show": true, "oas_url": "https://acme.io/orders.json"},
+		},
+	}
+	catalogue := func() *types.Catalogue {
+		data, err := json.Marshal(dashboard.catalogue)
+		require.NoError(t, err)
+		var decoded types.Catalogue
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		return &decoded
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "portal", "publish", "remote-1", "--policy", "policy-gold", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("portal-publish", out), string(out))
+	var result portalPublishResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "published", result.Operation)
+
+	require.Len(t, catalogue().APIs, 2)
+	entry := catalogue().APIs[1]
+	assert.Equal(t, "users", entry.Name)
+	assert.Equal(t, "remote-1", entry.APIID)
+	assert.True(t, entry.Show)
+	require.Contains(t, dashboard.docs, entry.Documentation)
+	// The published documentation is the deployed spec without the Tyk extension
+	decoded, err := base64.StdEncoding.DecodeString(dashboard.docs[entry.Documentation].Documentation)
+	require.NoError(t, err)
+	var published map[string]interface{}
+	require.NoError(t, json.Unmarshal(decoded, &published))
+	assert.NotContains(t, published, oas.TykExtensionKey)
+	assert.Contains(t, published, "paths")
+
+	// Publishing again replaces the documentation of the same entry
+	docs := filepath.Join(t.TempDir(), "users.json")
+	require.NoError(t, os.WriteFile(docs, []byte(`{"openapi":"3.0.3","info":{"title":"Users","version":"2.0.0","description":"User accounts"},"paths":{}}`), 0644))
+	out, err = runRootCommand(t, "portal", "publish", "remote-1", "--policy", "policy-gold", "--docs", docs, "--hidden", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "updated", result.Operation)
+	require.Len(t, catalogue().APIs, 2)
+	assert.False(t, catalogue().APIs[1].Show)
+	assert.Equal(t, "User accounts", catalogue().APIs[1].ShortDescription)
+	assert.Len(t, dashboard.docs, 1)
+
+	out, err = runRootCommand(t, "portal", "list", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("portal-list", out), string(out))
+
+	out, err = runRootCommand(t, "portal", "unpublish", "remote-1", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("portal-unpublish", out), string(out))
+	require.Len(t, catalogue().APIs, 1)
+	assert.Equal(t, "Orders", catalogue().APIs[0].Name)
+	assert.Empty(t, dashboard.docs)
+	assert.Equal(t, "portal@acme.io", dashboard.catalogue["email"])
+	orders := dashboard.catalogue["apis"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "https://acme.io/orders.json", orders["oas_url"])
+
+	_, err = runRootCommand(t, "portal", "publish", "remote-1", "--policy", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+}
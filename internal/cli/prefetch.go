@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewPrefetchCommand creates the 'tyk prefetch' command
+func NewPrefetchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prefetch",
+		Short: "Warm the local cache of the active environment",
+		Long: `Fill the local cache of the active environment so later commands and completions do
+not wait on the Dashboard, which matters most over high-latency links such as VPNs:
+
+  API list      completion of API IDs and 'tyk api search --cached'
+  definitions   'tyk api get <api-id> --cached'
+  capabilities  the Tyk release compatibility checks use, for 24 hours
+
+The cache lives in the user cache directory, or TYK_API_CACHE_DIR. Definitions of APIs
+that no longer exist are dropped, and the CLI drops the definition of any API it
+updates or deletes. Run prefetch again to refresh everything.
+
+Examples:
+  tyk prefetch
+  tyk prefetch --env production --jobs 16
+  tyk prefetch --no-specs`,
+		Args: cobra.NoArgs,
+		RunE: runPrefetch,
+	}
+
+	cmd.Flags().Bool("no-specs", false, "Cache only the API list and capabilities, not every API definition")
+	cmd.Flags().IntP("jobs", "j", 8, "Number of API definitions to fetch at once")
+
+	return cmd
+}
+
+// prefetchFailure is an API whose definition could not be cached
+type prefetchFailure struct {
+	APIID string `json:"api_id"`
+	Error string `json:"error"`
+}
+
+// prefetchResult is the output of 'tyk prefetch'
+type prefetchResult struct {
+	Environment string `json:"environment"`
+	APIs        int    `json:"apis"`
+	// Specs is the number of definitions cached, or null with --no-specs
+	Specs *int `json:"specs"`
+	// Skipped counts listed APIs without an OAS definition, such as GraphQL APIs
+	Skipped int `json:"skipped"`
+	// Pruned counts cached definitions dropped because their API is gone
+	Pruned       int                  `json:"pruned"`
+	Capabilities *client.Capabilities `json:"capabilities"`
+	Failures     []prefetchFailure    `json:"failures"`
+	Duration     string               `json:"duration"`
+}
+
+func runPrefetch(cmd *cobra.Command, args []string) error {
+	noSpecs, _ := cmd.Flags().GetBool("no-specs")
+	jobs, _ := cmd.Flags().GetInt("jobs")
+	if jobs < 1 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --jobs %d", jobs)}
+	}
+
+	progress, err := newProgressReporter(cmd, "prefetch")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	ctx, cancel := apiContext(config, 10*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	result := &prefetchResult{Environment: c.Environment(), Failures: []prefetchFailure{}}
+
+	stopSpinner := startSpinner(cmd, "Listing APIs...")
+	cache, err := c.RefreshAPICache(ctx)
+	stopSpinner()
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	result.APIs = len(cache.APIs)
+
+	// The compatibility checks fall back to asking the server, so this is not fatal
+	if result.Capabilities, err = c.RefreshCapabilities(ctx); err != nil {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Capabilities not cached: %v\n", err)
+	}
+
+	if !noSpecs {
+		specs := prefetchSpecs(ctx, c, cache.APIs, jobs, progress, result)
+		result.Specs = &specs
+		ids := make([]string, 0, len(cache.APIs))
+		for _, api := range cache.APIs {
+			ids = append(ids, api.ID)
+		}
+		if result.Pruned, err = c.PruneAPISpecs(ids); err != nil {
+			return fmt.Errorf("failed to prune API cache: %w", err)
+		}
+	}
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if err := writeStructured(format, result); err != nil {
+			return err
+		}
+	} else {
+		printPrefetch(result)
+	}
+	if len(result.Failures) > 0 {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("%d of %d API definition(s) could not be cached", len(result.Failures), result.APIs)}
+	}
+	return nil
+}
+
+// prefetchSpecs caches the definition of every API, jobs at a time, and returns how
+// many were cached. APIs without an OAS definition are counted as skipped.
+func prefetchSpecs(ctx context.Context, c *client.Client, apis []*types.OASAPI, jobs int, progress *progressReporter, result *prefetchResult) int {
+	var mu sync.Mutex
+	cached := 0
+	indexes := make(chan int)
+
+	progress.Start(len(apis))
+	var wg sync.WaitGroup
+	for w := 0; w < min(jobs, len(apis)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				apiID := apis[i].ID
+				mu.Lock()
+				progress.StepStarted(apiID)
+				mu.Unlock()
+
+				_, err := c.RefreshAPISpec(ctx, apiID)
+
+				mu.Lock()
+				errMsg := ""
+				switch {
+				case err == nil:
+					cached++
+				case errors.Is(err, client.ErrNotFound):
+					result.Skipped++
+				default:
+					errMsg = wrapAPIError(err, "failed to get API").Error()
+					result.Failures = append(result.Failures, prefetchFailure{APIID: apiID, Error: errMsg})
+				}
+				progress.StepFinished(apiID, errMsg)
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range apis {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	progress.Finish()
+
+	sort.Slice(result.Failures, func(i, j int) bool { return result.Failures[i].APIID < result.Failures[j].APIID })
+	return cached
+}
+
+func printPrefetch(result *prefetchResult) {
+	green := color.New(color.FgGreen)
+	green.Printf("✓ Cached %d API(s) for environment '%s'\n", result.APIs, result.Environment)
+	if result.Specs != nil {
+		line := fmt.Sprintf("✓ Cached %d API definition(s)", *result.Specs)
+		if result.Skipped > 0 {
+			line += fmt.Sprintf(", skipped %d without an OAS definition", result.Skipped)
+		}
+		if result.Pruned > 0 {
+			line += fmt.Sprintf(", dropped %d of deleted APIs", result.Pruned)
+		}
+		green.Println(line)
+	}
+	if caps := result.Capabilities; caps != nil {
+		version := caps.Version
+		if version == "" {
+			version = "version not reported"
+		}
+		green.Printf("✓ Cached capabilities (%s)\n", version)
+	}
+	for _, failure := range result.Failures {
+		color.New(color.FgRed).Printf("✗ %s: %s\n", failure.APIID, failure.Error)
+	}
+	fmt.Printf("Done in %s\n", result.Duration)
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestPrefetch(t *testing.T) {
+	t.Setenv(client.EnvAPICacheDir, t.TempDir())
+	dashboard, server := newFakeDashboard(t)
+	dashboard.version = "v5.3.0"
+	seedRemoteAPI(t, dashboard, "users-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "orders-1", "orders", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "prefetch", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("prefetch", out), string(out))
+	var result prefetchResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 2, result.APIs)
+	require.NotNil(t, result.Specs)
+	assert.Equal(t, 2, *result.Specs)
+	require.NotNil(t, result.Capabilities)
+	assert.Equal(t, "v5.3.0", result.Capabilities.Version)
+
+	cache, err := client.LoadAPICache("test")
+	require.NoError(t, err)
+	assert.Len(t, cache.APIs, 2)
+
+	// Cached definitions are served without the Dashboard, as of the prefetch
+	dashboard.apis["users-1"]["info"].(map[string]interface{})["version"] = "2.0.0"
+	out, err = runRootCommand(t, "api", "get", "users-1", "--cached", "-o", "json")
+	require.NoError(t, err)
+	var api types.OASAPI
+	require.NoError(t, json.Unmarshal(out, &api))
+	assert.Equal(t, "1.0.0", api.OAS["info"].(map[string]interface{})["version"])
+	assert.Equal(t, "users", oas.GetAPIName(api.OAS))
+
+	// Definitions of deleted APIs are dropped on the next prefetch
+	delete(dashboard.apis, "orders-1")
+	out, err = runRootCommand(t, "prefetch", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, 1, result.Pruned)
+
+	_, err = runRootCommand(t, "api", "get", "orders-1", "--cached")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "tyk prefetch")
+
+	out, err = runRootCommand(t, "prefetch", "--no-specs", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("prefetch", out), string(out))
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Nil(t, result.Specs)
+
+	// Compatibility checks use the cached capabilities instead of asking the server
+	dashboard.version = ""
+	dir := t.TempDir()
+	spec := "openapi: 3.0.3\ninfo:\n  title: users\n  version: 1.0.0\nservers:\n  - url: https://users.internal\npaths:\n  /users:\n    get:\n      operationId: listUsers\n      x-tyk-ratelimit: {rate: 10, per: 60}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.yaml"), []byte(spec), 0644))
+	_, err = runRootCommand(t, "plan", "--dir", dir)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "runs Tyk 5.3.0")
+}
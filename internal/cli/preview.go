@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewPreviewCommand creates the 'tyk preview' command and its subcommands
+func NewPreviewCommand() *cobra.Command {
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Manage ephemeral preview deployments",
+		Long: `Deploy a directory of API specs under a unique prefix and tear it down again.
+
+Designed for per-pull-request preview environments: every API name and listen path
+is prefixed so previews never collide with each other or with shared APIs.`,
+	}
+
+	previewCmd.AddCommand(NewPreviewCreateCommand())
+	previewCmd.AddCommand(NewPreviewDestroyCommand())
+
+	return previewCmd
+}
+
+// NewPreviewCreateCommand creates the 'tyk preview create' command
+func NewPreviewCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Deploy all specs in a directory under a prefix",
+		Long: `Deploy every OAS file in a directory with prefixed names and listen paths.
+
+Re-running with the same prefix updates the existing preview APIs instead of
+creating duplicates, so it is safe to call on every push.
+
+Examples:
+  tyk preview create --from-dir ./apis --prefix pr-123-`,
+		RunE: runPreviewCreate,
+	}
+
+	cmd.Flags().String("from-dir", "", "Directory containing OAS files (required)")
+	cmd.Flags().String("prefix", "", "Prefix applied to API names and listen paths (required)")
+	cmd.MarkFlagRequired("from-dir")
+	cmd.MarkFlagRequired("prefix")
+
+	return cmd
+}
+
+// NewPreviewDestroyCommand creates the 'tyk preview destroy' command
+func NewPreviewDestroyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "destroy <prefix>",
+		Short: "Delete all APIs deployed under a preview prefix",
+		Long: `Delete every API whose name starts with the given preview prefix.
+
+Examples:
+  tyk preview destroy pr-123- --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: runPreviewDestroy,
+	}
+
+	return cmd
+}
+
+func runPreviewCreate(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("from-dir")
+	prefix, _ := cmd.Flags().GetString("prefix")
+
+	if strings.TrimSpace(prefix) == "" {
+		return &ExitError{Code: 2, Message: "--prefix must not be empty"}
+	}
+	progress, err := newProgressReporter(cmd, "preview create")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	files, err := filehandler.FindSpecFiles(dir)
+	if err != nil {
+		return &ExitError{Code: 2, Message: err.Error()}
+	}
+	if len(files) == 0 {
+		return &ExitError{Code: 2, Message: fmt.Sprintf("no OAS files found in %s", dir)}
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	// Index existing preview APIs by name so re-runs update in place
+	existing, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	existingIDs := make(map[string]string)
+	for _, api := range existing {
+		if strings.HasPrefix(api.Name, prefix) {
+			existingIDs[api.Name] = api.ID
+		}
+	}
+
+	var results []apiOperationResult
+	failed := 0
+	progress.Start(len(files))
+	for _, file := range files {
+		progress.StepStarted(file)
+		result := deployPreviewFile(ctx, cmd, c, config, file, prefix, existingIDs)
+		if result.Error != "" {
+			failed++
+		}
+		progress.StepFinished(file, result.Error)
+		results = append(results, result)
+	}
+	progress.Finish()
+
+	if err := outputAPIOperationResults(cmd, results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d preview API(s) failed to deploy", failed, len(files))}
+	}
+	return nil
+}
+
+// deployPreviewFile prefixes a single spec and creates or updates it
+func deployPreviewFile(ctx context.Context, cmd *cobra.Command, c *client.Client, config *types.Config, file, prefix string, existingIDs map[string]string) apiOperationResult {
+	result := apiOperationResult{File: file}
+
+	oasData, err := loadOASFromFile(file)
+	if err != nil {
+		result.Error = errorMessage(err)
+		return result
+	}
+
+	if !oas.HasTykExtensions(oasData) {
+		oasData, err = oas.AddTykExtensions(oasData)
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to generate Tyk extensions: %v", err)
+			return result
+		}
+	}
+	if _, err := oas.ExpandRateLimits(oasData); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	// Previews always get their own IDs so they never overwrite the source API
+	oasData = stripExistingAPIID(oasData)
+	if err := oas.ApplyPrefix(oasData, prefix); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Name = oas.GetAPIName(oasData)
+	if err := mountEnvironmentPrefix(config, fmt.Sprintf("API '%s'", result.Name), oasData); err != nil {
+		result.Error = errorMessage(err)
+		return result
+	}
+
+	if id, ok := existingIDs[result.Name]; ok {
+		existing, err := c.GetOASAPI(ctx, id, "")
+		if err != nil {
+			result.Error = errorMessage(wrapAPIError(err, fmt.Sprintf("failed to get API '%s'", id)))
+			return result
+		}
+		setTykAPIID(oasData, id)
+		api, err := replaceDeployedAPI(ctx, cmd, c, id, existing, oasData)
+		if api != nil {
+			result.APIID = api.ID
+			result.Operation = "updated"
+		}
+		if err != nil {
+			result.Error = errorMessage(err)
+		}
+		return result
+	}
+
+	api, err := c.CreateOASAPI(ctx, oasData)
+	if err != nil {
+		result.Error = wrapAPIError(err, "failed to create API").Error()
+		return result
+	}
+	result.APIID = api.ID
+	result.Operation = "created"
+	return result
+}
+
+func runPreviewDestroy(cmd *cobra.Command, args []string) error {
+	prefix := args[0]
+	skipConfirmation := assumeYes(cmd)
+
+	if strings.TrimSpace(prefix) == "" {
+		return &ExitError{Code: 2, Message: "prefix must not be empty"}
+	}
+	progress, err := newProgressReporter(cmd, "preview destroy")
+	if err != nil {
+		return err
+	}
+	defer progress.Stop()
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+
+	var targets []*types.OASAPI
+	for _, api := range apis {
+		if strings.HasPrefix(api.Name, prefix) {
+			targets = append(targets, api)
+		}
+	}
+
+	if len(targets) == 0 {
+		if GetOutputFormatFromContext(cmd.Context()).IsStructured() {
+			return outputAPIOperationResults(cmd, nil)
+		}
+		color.New(color.FgYellow).Printf("No APIs found with prefix '%s'\n", prefix)
+		return nil
+	}
+
+	if !skipConfirmation {
+		fmt.Printf("The following %d API(s) will be deleted:\n", len(targets))
+		for _, api := range targets {
+			fmt.Printf("  %s  %s\n", api.ID, api.Name)
+		}
+		ok, err := confirm(cmd, "Continue?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Destroy operation cancelled")
+			return nil
+		}
+	}
+
+	var results []apiOperationResult
+	failed := 0
+	progress.Start(len(targets))
+	for _, api := range targets {
+		progress.StepStarted(api.ID)
+		result := apiOperationResult{APIID: api.ID, Name: api.Name, Operation: "deleted"}
+		if err := c.DeleteOASAPI(ctx, api.ID); err != nil {
+			result.Operation = ""
+			result.Error = wrapAPIError(err, "failed to delete API").Error()
+			failed++
+		}
+		progress.StepFinished(api.ID, result.Error)
+		results = append(results, result)
+	}
+	progress.Finish()
+
+	if err := outputAPIOperationResults(cmd, results); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d of %d preview API(s) failed to delete", failed, len(targets))}
+	}
+	return nil
+}
+
+// setTykAPIID sets x-tyk-api-gateway.info.id on an OAS document
+func setTykAPIID(oasData map[string]interface{}, apiID string) {
+	if tykExt, ok := oasData[oas.TykExtensionKey].(map[string]interface{}); ok {
+		info, ok := tykExt["info"].(map[string]interface{})
+		if !ok {
+			info = map[string]interface{}{}
+			tykExt["info"] = info
+		}
+		info["id"] = apiID
+	}
+}
@@ -0,0 +1,497 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// fakeDashboard keeps OAS documents in memory and serves the endpoints preview relies on
+type fakeDashboard struct {
+	mu       sync.Mutex
+	apis     map[string]map[string]interface{}
+	keys     map[string]types.Session
+	policies map[string]*types.Policy
+	// policyDocs holds the stored documents of policies, with the fields types.Policy
+	// does not model; policies seeded without one are served from policies
+	policyDocs map[string]map[string]interface{}
+	access     map[string]*types.APIAccess
+	hooks      map[string]*types.Webhook
+	// catalogue, docs and developers back the classic portal endpoints
+	catalogue  map[string]interface{}
+	docs       map[string]*types.PortalDocumentation
+	developers []*types.PortalDeveloper
+	certs      []string
+	nextID     int
+	// dropOnSave are top-level document keys PUT requests lose, like extension keys
+	// the Dashboard does not know
+	dropOnSave []string
+	// quotaResets counts key updates sent without suppress_reset
+	quotaResets int
+	// classic holds classic API definitions, such as GraphQL APIs', by API ID
+	classic map[string]map[string]interface{}
+	// etags makes OAS API reads return an ETag of the document and updates honour
+	// If-Match, like Dashboards that enforce optimistic locking
+	etags bool
+	// blindUpdates counts OAS API updates sent without If-Match while etags is set
+	blindUpdates int
+	// version is reported by the health endpoint; without it health checks fail
+	version string
+}
+
+func newFakeDashboard(t *testing.T) (*fakeDashboard, *httptest.Server) {
+	t.Helper()
+	d := &fakeDashboard{
+		apis:       make(map[string]map[string]interface{}),
+		keys:       make(map[string]types.Session),
+		policies:   make(map[string]*types.Policy),
+		policyDocs: make(map[string]map[string]interface{}),
+		access:     make(map[string]*types.APIAccess),
+		hooks:      make(map[string]*types.Webhook),
+		docs:       make(map[string]*types.PortalDocumentation),
+		classic:    make(map[string]map[string]interface{}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(d.serve))
+	t.Cleanup(server.Close)
+	return d, server
+}
+
+func (d *fakeDashboard) serve(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.HasPrefix(r.URL.Path, "/api/keys") {
+		d.serveKeys(w, r)
+		return
+	}
+	if r.URL.Path == "/health" && d.version != "" {
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok", "version": d.version})
+		return
+	}
+	if r.URL.Path == "/api/certs" {
+		json.NewEncoder(w).Encode(types.CertificateListResponse{Certs: append([]string{}, d.certs...)})
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/portal/policies") {
+		d.servePolicies(w, r)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/hooks") {
+		d.serveHooks(w, r)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/portal/catalogue") || strings.HasPrefix(r.URL.Path, "/api/portal/documentation") {
+		d.servePortal(w, r)
+		return
+	}
+
+	if r.URL.Path == "/api/portal/developers" {
+		json.NewEncoder(w).Encode(types.PortalDeveloperListResponse{Data: append([]*types.PortalDeveloper{}, d.developers...)})
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/apis/") && strings.HasSuffix(r.URL.Path, "/access") {
+		d.serveAccess(w, r)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/apis/") && strings.HasSuffix(r.URL.Path, "/keys") {
+		// Keys whose access rights name the API, in ID order
+		apiID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/apis/"), "/keys")
+		var result types.KeyListResponse
+		for _, keyID := range slices.Sorted(maps.Keys(d.keys)) {
+			if rights, _ := d.keys[keyID]["access_rights"].(map[string]interface{}); rights[apiID] != nil {
+				result.Data.Keys = append(result.Data.Keys, keyID)
+			}
+		}
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	if r.URL.Path == "/api/apis" && r.Method == http.MethodPost || strings.HasPrefix(r.URL.Path, "/api/apis/") && !strings.HasPrefix(r.URL.Path, "/api/apis/oas") {
+		d.serveClassic(w, r)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/apis/oas/")
+	switch {
+	case r.URL.Path == "/api/apis":
+		// Pages of client.DefaultPageSize, in ID order like a stable Dashboard listing
+		ids := make([]string, 0, len(d.apis))
+		for apiID := range d.apis {
+			ids = append(ids, apiID)
+		}
+		sort.Strings(ids)
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		start := (page - 1) * client.DefaultPageSize
+		items := []interface{}{}
+		if page >= 1 && start < len(ids) {
+			for _, apiID := range ids[start:min(start+client.DefaultPageSize, len(ids))] {
+				doc := d.apis[apiID]
+				items = append(items, map[string]interface{}{
+					"api_definition": map[string]interface{}{
+						"api_id": apiID,
+						"name":   oas.GetAPIName(doc),
+						"proxy":  map[string]interface{}{"listen_path": oas.GetListenPath(doc)},
+					},
+				})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"apis": items})
+	case r.URL.Path == "/api/apis/oas" && r.Method == http.MethodPost:
+		var doc map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&doc)
+		d.nextID++
+		apiID := fmt.Sprintf("api-%d", d.nextID)
+		// Like the Dashboard, stamp the assigned ID into the stored document
+		setTykAPIID(doc, apiID)
+		d.apis[apiID] = doc
+		json.NewEncoder(w).Encode(map[string]interface{}{"ID": apiID, "Status": "OK"})
+	case d.apis[id] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "not found"})
+	case r.Method == http.MethodGet:
+		if d.etags {
+			w.Header().Set("ETag", documentETag(d.apis[id]))
+		}
+		json.NewEncoder(w).Encode(d.apis[id])
+	case r.Method == http.MethodPut:
+		if match := r.Header.Get("If-Match"); d.etags && match != "" && match != documentETag(d.apis[id]) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "API has been modified"})
+			return
+		}
+		if d.etags && r.Header.Get("If-Match") == "" {
+			d.blindUpdates++
+		}
+		var doc map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&doc)
+		for _, key := range d.dropOnSave {
+			delete(doc, key)
+		}
+		d.apis[id] = doc
+		json.NewEncoder(w).Encode(map[string]interface{}{"ID": id, "Status": "OK"})
+	case r.Method == http.MethodDelete:
+		delete(d.apis, id)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	}
+}
+
+// documentETag is the fake Dashboard's entity tag for a stored document
+func documentETag(doc map[string]interface{}) string {
+	data, _ := json.Marshal(doc)
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(data))
+}
+
+// serveClassic handles POST /api/apis and /api/apis/{id} for classic definitions;
+// callers hold d.mu
+func (d *fakeDashboard) serveClassic(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/apis/")
+	var body struct {
+		APIDefinition map[string]interface{} `json:"api_definition"`
+	}
+	switch {
+	case r.Method == http.MethodPost:
+		json.NewDecoder(r.Body).Decode(&body)
+		apiID, _ := body.APIDefinition["api_id"].(string)
+		d.classic[apiID] = body.APIDefinition
+		d.nextID++
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Meta": fmt.Sprintf("record-%d", d.nextID)})
+	case d.classic[id] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "not found"})
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{"api_definition": d.classic[id]})
+	case r.Method == http.MethodPut:
+		json.NewDecoder(r.Body).Decode(&body)
+		d.classic[id] = body.APIDefinition
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	}
+}
+
+// serveHooks handles /api/hooks and /api/hooks/{id}; callers hold d.mu
+func (d *fakeDashboard) serveHooks(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/hooks"), "/")
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(d.hooks))
+		for hookID := range d.hooks {
+			ids = append(ids, hookID)
+		}
+		sort.Strings(ids)
+		hooks := []*types.Webhook{}
+		for _, hookID := range ids {
+			hooks = append(hooks, d.hooks[hookID])
+		}
+		json.NewEncoder(w).Encode(types.WebhookListResponse{Hooks: hooks})
+	case id == "" && r.Method == http.MethodPost:
+		var hook types.Webhook
+		json.NewDecoder(r.Body).Decode(&hook)
+		d.nextID++
+		hook.ID = fmt.Sprintf("hook-%d", d.nextID)
+		d.hooks[hook.ID] = &hook
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Message": hook.ID})
+	case d.hooks[id] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "not found"})
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(d.hooks[id])
+	case r.Method == http.MethodPut:
+		var hook types.Webhook
+		json.NewDecoder(r.Body).Decode(&hook)
+		hook.ID = id
+		d.hooks[id] = &hook
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	case r.Method == http.MethodDelete:
+		delete(d.hooks, id)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	}
+}
+
+// servePortal handles the portal catalogue and its documentation; callers hold d.mu
+func (d *fakeDashboard) servePortal(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api/portal/catalogue" && r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(d.catalogue)
+	case r.URL.Path == "/api/portal/catalogue" && r.Method == http.MethodPut:
+		d.catalogue = nil
+		json.NewDecoder(r.Body).Decode(&d.catalogue)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	case r.URL.Path == "/api/portal/documentation" && r.Method == http.MethodPost:
+		var doc types.PortalDocumentation
+		json.NewDecoder(r.Body).Decode(&doc)
+		d.nextID++
+		docID := fmt.Sprintf("doc-%d", d.nextID)
+		d.docs[docID] = &doc
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Message": docID})
+	case r.Method == http.MethodDelete:
+		delete(d.docs, strings.TrimPrefix(r.URL.Path, "/api/portal/documentation/"))
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	}
+}
+
+// serveAccess handles /api/apis/{id}/access; callers hold d.mu
+func (d *fakeDashboard) serveAccess(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/apis/"), "/access")
+	switch {
+	case d.apis[id] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "not found"})
+	case r.Method == http.MethodGet:
+		access := d.access[id]
+		if access == nil {
+			access = &types.APIAccess{}
+		}
+		json.NewEncoder(w).Encode(access)
+	case r.Method == http.MethodPut:
+		var access types.APIAccess
+		json.NewDecoder(r.Body).Decode(&access)
+		d.access[id] = &access
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK"})
+	}
+}
+
+// serveKeys handles /api/keys; callers hold d.mu
+func (d *fakeDashboard) serveKeys(w http.ResponseWriter, r *http.Request) {
+	keyID := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/keys"), "/")
+	switch {
+	case keyID == "" && r.Method == http.MethodPost:
+		var session types.Session
+		json.NewDecoder(r.Body).Decode(&session)
+		d.nextID++
+		keyID = fmt.Sprintf("key-%d", d.nextID)
+		d.keys[keyID] = session
+		json.NewEncoder(w).Encode(types.KeyResponse{KeyID: keyID, Data: session})
+	case r.Method == http.MethodPost:
+		// Custom keys are created under the ID of the path
+		var session types.Session
+		json.NewDecoder(r.Body).Decode(&session)
+		d.keys[keyID] = session
+		json.NewEncoder(w).Encode(types.KeyResponse{KeyID: keyID, Data: session})
+	case d.keys[keyID] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "Key not found"})
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(types.KeyResponse{KeyID: keyID, Data: d.keys[keyID]})
+	case r.Method == http.MethodPut:
+		var session types.Session
+		json.NewDecoder(r.Body).Decode(&session)
+		d.keys[keyID] = session
+		if r.URL.Query().Get("suppress_reset") != "1" {
+			d.quotaResets++
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Message": "Key updated"})
+	case r.Method == http.MethodDelete:
+		delete(d.keys, keyID)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Message": "Key deleted"})
+	}
+}
+
+// servePolicies handles /api/portal/policies; callers hold d.mu
+func (d *fakeDashboard) servePolicies(w http.ResponseWriter, r *http.Request) {
+	policyID := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/portal/policies"), "/")
+	switch {
+	case policyID == "" && r.Method == http.MethodGet:
+		list := map[string]interface{}{"Pages": 1}
+		data := []interface{}{}
+		for id := range d.policies {
+			data = append(data, d.policyDocument(id))
+		}
+		list["Data"] = data
+		json.NewEncoder(w).Encode(list)
+	case policyID == "" && r.Method == http.MethodPost:
+		policyID = d.storePolicy("", r)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Message": policyID})
+	case d.policies[policyID] == nil:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "Policy not found"})
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(d.policyDocument(policyID))
+	case r.Method == http.MethodPut:
+		d.storePolicy(policyID, r)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "OK", "Message": "Policy updated"})
+	}
+}
+
+// policyDocument returns the stored document of a policy; callers hold d.mu
+func (d *fakeDashboard) policyDocument(policyID string) interface{} {
+	if doc := d.policyDocs[policyID]; doc != nil {
+		return doc
+	}
+	return d.policies[policyID]
+}
+
+// storePolicy saves the policy in the body of r under policyID and returns its ID. New
+// policies, without a policyID, keep the ID of the body or get a new one. Callers hold
+// d.mu.
+func (d *fakeDashboard) storePolicy(policyID string, r *http.Request) string {
+	body, _ := io.ReadAll(r.Body)
+	var policy types.Policy
+	var doc map[string]interface{}
+	json.Unmarshal(body, &policy)
+	json.Unmarshal(body, &doc)
+	if policyID == "" {
+		policyID = policy.ID
+	}
+	if policyID == "" {
+		d.nextID++
+		policyID = fmt.Sprintf("policy-%d", d.nextID)
+	}
+	policy.ID = policyID
+	doc["_id"] = policyID
+	d.policies[policyID] = &policy
+	d.policyDocs[policyID] = doc
+	return policyID
+}
+
+func (d *fakeDashboard) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.apis)
+}
+
+func writePreviewSpecs(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"users", "orders"} {
+		spec := fmt.Sprintf("openapi: 3.0.3\ninfo:\n  title: %s\n  version: 1.0.0\nservers:\n  - url: https://%s.internal\npaths: {}\n", name, name)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(spec), 0644))
+	}
+	return dir
+}
+
+func runPreviewCommand(t *testing.T, serverURL string, args ...string) ([]apiOperationResult, error) {
+	t.Helper()
+	cmd := NewPreviewCommand()
+	cmd.SetArgs(args)
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: serverURL, AuthToken: "token", OrgID: "org"},
+		},
+	}
+	cmd.SetContext(withOutputFormat(withConfig(context.Background(), config), types.OutputJSON))
+
+	out, err := captureStdout(cmd.Execute)
+	var decoded struct {
+		Results []apiOperationResult `json:"results"`
+	}
+	if len(out) > 0 {
+		require.NoError(t, json.Unmarshal(out, &decoded))
+	}
+	return decoded.Results, err
+}
+
+func TestPreview_CreateUpdateDestroy(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dashboard.etags = true
+	dir := writePreviewSpecs(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	results, err := runPreviewCommand(t, server.URL, "create", "--from-dir", dir, "--prefix", "pr-1-")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		assert.Equal(t, "created", r.Operation)
+		assert.True(t, strings.HasPrefix(r.Name, "pr-1-"), r.Name)
+	}
+	for _, doc := range dashboard.apis {
+		assert.True(t, strings.HasPrefix(oas.GetListenPath(doc), "/pr-1-"), oas.GetListenPath(doc))
+	}
+
+	// Re-running updates in place instead of duplicating
+	results, err = runPreviewCommand(t, server.URL, "create", "--from-dir", dir, "--prefix", "pr-1-")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		assert.Equal(t, "updated", r.Operation)
+	}
+	assert.Equal(t, 2, dashboard.count())
+	assert.Zero(t, dashboard.blindUpdates, "previews are replaced only if unchanged since they were read")
+	// Updates keep the replaced definition for rollback
+	out, err := runRootCommand(t, "api", "history", results[0].APIID, "-o", "json")
+	require.NoError(t, err)
+	var revisions []map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &revisions))
+	assert.Len(t, revisions, 1)
+
+	t.Setenv(EnvAssumeYes, "1")
+	results, err = runPreviewCommand(t, server.URL, "destroy", "pr-1-")
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 0, dashboard.count())
+}
+
+func TestPreview_CreateEmptyDirectory(t *testing.T) {
+	_, server := newFakeDashboard(t)
+
+	_, err := runPreviewCommand(t, server.URL, "create", "--from-dir", t.TempDir(), "--prefix", "pr-1-")
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
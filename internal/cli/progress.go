@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Progress event types emitted by --progress-format json
+const (
+	progressStarted      = "started"
+	progressStepStarted  = "step_started"
+	progressStepFinished = "step_finished"
+	progressFinished     = "finished"
+)
+
+// progressEvent is a single line of the newline-delimited progress stream
+type progressEvent struct {
+	Event     string `json:"event"`
+	Operation string `json:"operation"`
+	Step      string `json:"step,omitempty"`
+	Total     int    `json:"total"`
+	Completed int    `json:"completed"`
+	Failed    int    `json:"failed"`
+	Error     string `json:"error,omitempty"`
+	Time      string `json:"time"`
+}
+
+// progressReporter writes progress events for a bulk operation, or shows its progress
+// next to a spinner on a terminal. A nil reporter is valid and discards everything, so
+// callers never need to check the flag.
+type progressReporter struct {
+	w         io.Writer
+	spinner   *spinner
+	operation string
+	total     int
+	completed int
+	failed    int
+}
+
+// newProgressReporter returns a reporter for --progress-format, a spinner reporter for
+// human output on a terminal, or nil when neither applies (--progress-format none turns
+// both off). Events and the spinner go to stderr so stdout stays parseable.
+func newProgressReporter(cmd *cobra.Command, operation string) (*progressReporter, error) {
+	flag := cmd.Flags().Lookup("progress-format")
+	if flag == nil {
+		return nil, nil
+	}
+	switch flag.Value.String() {
+	case "":
+		if s := newSpinner(cmd); s != nil {
+			return &progressReporter{spinner: s, operation: operation}, nil
+		}
+		return nil, nil
+	case "none":
+		return nil, nil
+	case "json":
+		return &progressReporter{w: os.Stderr, operation: operation}, nil
+	default:
+		return nil, &ExitError{Code: 2, Message: fmt.Sprintf("invalid --progress-format '%s': must be json or none", flag.Value.String())}
+	}
+}
+
+// Start announces the number of steps the operation will run
+func (p *progressReporter) Start(total int) {
+	if p == nil {
+		return
+	}
+	p.total = total
+	p.spinner.Start(fmt.Sprintf("%s: 0/%d", p.operation, total))
+	p.emit(progressEvent{Event: progressStarted})
+}
+
+// StepStarted reports that work on step has begun
+func (p *progressReporter) StepStarted(step string) {
+	if p == nil {
+		return
+	}
+	p.spinner.Update(fmt.Sprintf("%s: %d/%d %s", p.operation, p.completed+1, p.total, step))
+	p.emit(progressEvent{Event: progressStepStarted, Step: step})
+}
+
+// StepFinished reports that step completed; a non-empty errMsg marks it as failed
+func (p *progressReporter) StepFinished(step, errMsg string) {
+	if p == nil {
+		return
+	}
+	p.completed++
+	if errMsg != "" {
+		p.failed++
+	}
+	event := progressEvent{Event: progressStepFinished, Step: step, Error: errMsg}
+	p.emit(event)
+}
+
+// Finish reports that the operation is over
+func (p *progressReporter) Finish() {
+	if p == nil {
+		return
+	}
+	p.spinner.Stop()
+	p.emit(progressEvent{Event: progressFinished})
+}
+
+// Stop clears the spinner of an operation cut short; defer it so errors are not
+// printed over the status line
+func (p *progressReporter) Stop() {
+	if p == nil {
+		return
+	}
+	p.spinner.Stop()
+}
+
+func (p *progressReporter) emit(event progressEvent) {
+	if p.w == nil {
+		return
+	}
+	event.Operation = p.operation
+	event.Total = p.total
+	event.Completed = p.completed
+	event.Failed = p.failed
+	event.Time = time.Now().UTC().Format(time.RFC3339)
+	json.NewEncoder(p.w).Encode(event)
+}
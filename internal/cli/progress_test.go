@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+)
+
+func TestProgressReporter_EmitsNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	p := &progressReporter{w: &buf, operation: "api delete"}
+
+	p.Start(2)
+	p.StepStarted("a1")
+	p.StepFinished("a1", "")
+	p.StepStarted("b2")
+	p.StepFinished("b2", "boom")
+	p.Finish()
+
+	var events []progressEvent
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		require.NoError(t, outputschema.Validate("progress-event", scanner.Bytes()), scanner.Text())
+		var event progressEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+
+	require.Len(t, events, 6)
+	assert.Equal(t, progressStarted, events[0].Event)
+	assert.Equal(t, 2, events[0].Total)
+	assert.Equal(t, progressStepFinished, events[4].Event)
+	assert.Equal(t, "boom", events[4].Error)
+	assert.Equal(t, 2, events[5].Completed)
+	assert.Equal(t, 1, events[5].Failed)
+	assert.Equal(t, "api delete", events[5].Operation)
+}
+
+func TestNewProgressReporter(t *testing.T) {
+	newCmd := func(value string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().String("progress-format", "", "")
+		require.NoError(t, cmd.Flags().Set("progress-format", value))
+		return cmd
+	}
+
+	p, err := newProgressReporter(newCmd(""), "op")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = newProgressReporter(newCmd("none"), "op")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	p, err = newProgressReporter(newCmd("json"), "op")
+	require.NoError(t, err)
+	assert.NotNil(t, p)
+
+	_, err = newProgressReporter(newCmd("xml"), "op")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	// A nil reporter is a no-op
+	var nilReporter *progressReporter
+	nilReporter.Start(1)
+	nilReporter.StepFinished("x", "")
+	nilReporter.Finish()
+}
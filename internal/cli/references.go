@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// referenceProblem is a reference to a resource that does not exist or is malformed
+type referenceProblem struct {
+	Resource string
+	Path     string
+	Message  string
+}
+
+// referenceChecker validates cross-resource references before anything is changed so a
+// bad reference fails the whole run instead of leaving it half applied. Each kind of
+// remote resource is fetched at most once.
+type referenceChecker struct {
+	ctx      context.Context
+	c        *client.Client
+	certs    map[string]bool
+	apiIDs   map[string]bool
+	problems []referenceProblem
+}
+
+func newReferenceChecker(ctx context.Context, c *client.Client) *referenceChecker {
+	return &referenceChecker{ctx: ctx, c: c}
+}
+
+// checkAPI validates the certificates and webhook URLs an API definition refers to
+func (r *referenceChecker) checkAPI(resource string, doc map[string]interface{}) error {
+	for _, ref := range oas.References(doc) {
+		switch ref.Kind {
+		case oas.RefWebhook:
+			if err := oas.ValidateWebhookURL(ref.Value); err != nil {
+				r.add(resource, ref.Path, "webhook "+err.Error())
+			}
+		case oas.RefCertificate:
+			if r.certs == nil {
+				certs, err := r.c.ListCertificates(r.ctx)
+				if err != nil {
+					return wrapAPIError(err, "failed to list certificates")
+				}
+				r.certs = make(map[string]bool, len(certs))
+				for _, id := range certs {
+					r.certs[id] = true
+				}
+			}
+			if !r.certs[ref.Value] {
+				r.add(resource, ref.Path, fmt.Sprintf("certificate '%s' is not in the certificate store", ref.Value))
+			}
+		}
+	}
+	return nil
+}
+
+// checkAPIID validates that a policy grants access to an API that exists
+func (r *referenceChecker) checkAPIID(resource, apiID string) error {
+	if r.apiIDs == nil {
+		apis, err := r.c.ListAllAPIs(r.ctx)
+		if err != nil {
+			return wrapAPIError(err, "failed to list APIs")
+		}
+		r.apiIDs = make(map[string]bool, len(apis))
+		for _, api := range apis {
+			r.apiIDs[api.ID] = true
+		}
+	}
+	if !r.apiIDs[apiID] {
+		r.add(resource, "apis", fmt.Sprintf("'%s' is neither an API declared in the file nor an existing API ID", apiID))
+	}
+	return nil
+}
+
+func (r *referenceChecker) add(resource, path, message string) {
+	r.problems = append(r.problems, referenceProblem{Resource: resource, Path: path, Message: message})
+}
+
+// err returns one error listing every problem found, or nil
+func (r *referenceChecker) err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	lines := make([]string, len(r.problems))
+	for i, p := range r.problems {
+		lines[i] = fmt.Sprintf("%s: %s: %s", p.Resource, p.Path, p.Message)
+	}
+	return &ExitError{
+		Code:    int(types.ExitBadArgs),
+		Message: fmt.Sprintf("%d unresolved reference(s), nothing was changed:\n  %s", len(r.problems), strings.Join(lines, "\n  ")),
+	}
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// writeReferencingSpec writes a Tyk OAS spec that uses a custom domain certificate and a webhook
+func writeReferencingSpec(t *testing.T, dir, name, certID, webhookURL string) {
+	t.Helper()
+	doc, err := loadOASFromFile(writePlanSpec(t, dir, name, "1.0.0"))
+	require.NoError(t, err)
+	doc, err = oas.AddTykExtensions(doc)
+	require.NoError(t, err)
+	server := doc[oas.TykExtensionKey].(map[string]interface{})["server"].(map[string]interface{})
+	server["customDomain"] = map[string]interface{}{"enabled": true, "name": name + ".example.com", "certificates": []interface{}{certID}}
+	server["eventHandlers"] = []interface{}{
+		map[string]interface{}{"enabled": true, "type": "webhook", "trigger": "AuthFailure", "url": webhookURL},
+	}
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(dir, name+".yaml")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), data, 0644))
+}
+
+func TestPlan_UnresolvedReferences(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dashboard.certs = []string{"org-cert-1"}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	writeReferencingSpec(t, dir, "users", "org-cert-1", "https://hooks.example.com/tyk")
+	writeReferencingSpec(t, dir, "orders", "org-cert-missing", "hooks.example.com")
+
+	_, err := runRootCommand(t, "plan", "--dir", dir)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "2 unresolved reference(s)")
+	assert.Contains(t, exitErr.Message, "API 'orders' (orders.json): x-tyk-api-gateway.server.customDomain.certificates[0]: certificate 'org-cert-missing'")
+	assert.Contains(t, exitErr.Message, "x-tyk-api-gateway.server.eventHandlers[0].url: webhook URL")
+	assert.NotContains(t, exitErr.Message, "users")
+}
+
+func TestApply_RechecksReferences(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dashboard.certs = []string{"org-cert-1"}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	dir := t.TempDir()
+	writeReferencingSpec(t, dir, "users", "org-cert-1", "https://hooks.example.com/tyk")
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+	_, err := runRootCommand(t, "plan", "--dir", dir, "--out", planFile)
+	require.NoError(t, err)
+
+	// The certificate is deleted between plan and apply
+	dashboard.certs = nil
+	_, err = runRootCommand(t, "apply", "--plan", planFile)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Equal(t, 0, dashboard.count())
+}
+
+func TestBootstrap_UnknownPolicyAPI(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "legacy", "1.0.0")
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	bootstrap := `apis:
+  - name: Users
+    upstream_url: https://users.internal
+policies:
+  - name: Free tier
+    rate: 100
+    per: 60
+    apis: [Users, remote-1, Orders]
+`
+	path := filepath.Join(t.TempDir(), "environment.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(bootstrap), 0644))
+
+	_, err := runRootCommand(t, "bootstrap", "-f", path)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+	assert.Contains(t, exitErr.Message, "policy 'Free tier': apis: 'Orders' is neither")
+	assert.NotContains(t, exitErr.Message, "remote-1")
+	// Nothing was created
+	assert.Equal(t, 1, dashboard.count())
+	assert.Empty(t, dashboard.policies)
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewReportCommand creates the 'tyk report' command and its subcommands
+func NewReportCommand() *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate inventory reports across every API in an environment",
+		Long: `Reports summarise the APIs in an environment for platform and infrastructure teams.
+They only read from the Dashboard or Gateway.`,
+	}
+
+	reportCmd.AddCommand(NewReportUpstreamsCommand())
+	reportCmd.AddCommand(NewReportTLSCommand())
+	reportCmd.AddCommand(NewReportAuthCommand())
+
+	return reportCmd
+}
+
+// NewReportUpstreamsCommand creates the 'tyk report upstreams' command
+func NewReportUpstreamsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upstreams",
+		Short: "List each distinct upstream host and the APIs routing to it",
+		Long: `List every distinct upstream (scheme, host and port) with the APIs that route to it.
+
+Plaintext http:// upstreams are flagged so they can be moved to TLS.
+
+Examples:
+  tyk report upstreams
+  tyk report upstreams --plaintext-only
+  tyk report upstreams -o json`,
+		Args: cobra.NoArgs,
+		RunE: runReportUpstreams,
+	}
+
+	cmd.Flags().Bool("plaintext-only", false, "Only show upstreams reached over plaintext HTTP")
+
+	return cmd
+}
+
+// apiRef identifies an API in a report
+type apiRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// upstreamEntry groups the APIs that share an upstream scheme, host and port
+type upstreamEntry struct {
+	Host      string   `json:"host"`
+	Scheme    string   `json:"scheme"`
+	Port      string   `json:"port"`
+	Plaintext bool     `json:"plaintext"`
+	APIs      []apiRef `json:"apis"`
+}
+
+func runReportUpstreams(cmd *cobra.Command, args []string) error {
+	plaintextOnly, _ := cmd.Flags().GetBool("plaintext-only")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list APIs")
+	}
+	fillUpstreamURLs(ctx, c, apis)
+
+	upstreams, unresolved := groupUpstreams(apis)
+	if plaintextOnly {
+		var plaintext []*upstreamEntry
+		for _, entry := range upstreams {
+			if entry.Plaintext {
+				plaintext = append(plaintext, entry)
+			}
+		}
+		upstreams = plaintext
+	}
+
+	plaintextCount := 0
+	for _, entry := range upstreams {
+		if entry.Plaintext {
+			plaintextCount++
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if upstreams == nil {
+			upstreams = []*upstreamEntry{}
+		}
+		if unresolved == nil {
+			unresolved = []apiRef{}
+		}
+		return writeStructured(format, map[string]interface{}{
+			"upstreams":  upstreams,
+			"count":      len(upstreams),
+			"plaintext":  plaintextCount,
+			"unresolved": unresolved,
+		})
+	}
+
+	if len(upstreams) == 0 {
+		fmt.Fprintln(os.Stderr, "No upstreams found.")
+	} else {
+		printUpstreamTable(upstreams)
+		fmt.Fprintf(os.Stderr, "\n%d upstream(s), %d plaintext\n", len(upstreams), plaintextCount)
+	}
+	if len(unresolved) > 0 {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "%d API(s) have no parseable upstream URL:\n", len(unresolved))
+		for _, api := range unresolved {
+			fmt.Fprintf(os.Stderr, "  %s  %s\n", api.ID, api.Name)
+		}
+	}
+	return nil
+}
+
+// fillUpstreamURLs fetches the full definition of APIs the listing returned without an
+// upstream. Lookups that fail leave the API unresolved rather than failing the report.
+func fillUpstreamURLs(ctx context.Context, c *client.Client, apis []*types.OASAPI) {
+	for _, api := range apis {
+		if api.UpstreamURL != "" {
+			continue
+		}
+		full, err := c.GetOASAPI(ctx, api.ID, "")
+		if err != nil {
+			continue
+		}
+		api.UpstreamURL = full.UpstreamURL
+	}
+}
+
+// fetchAPIDefinitions lists every API and loads its full definition. APIs whose
+// definition cannot be read are returned separately so reports can mention them.
+func fetchAPIDefinitions(ctx context.Context, c *client.Client) ([]*types.OASAPI, []apiRef, error) {
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return nil, nil, wrapAPIError(err, "failed to list APIs")
+	}
+
+	var loaded []*types.OASAPI
+	var unreadable []apiRef
+	for _, api := range apis {
+		if api.OAS != nil {
+			loaded = append(loaded, api)
+			continue
+		}
+		full, err := c.GetOASAPI(ctx, api.ID, "")
+		if err != nil {
+			unreadable = append(unreadable, apiRef{ID: api.ID, Name: api.Name})
+			continue
+		}
+		loaded = append(loaded, full)
+	}
+	return loaded, unreadable, nil
+}
+
+// groupUpstreams groups APIs by upstream scheme, host and port, sorted by host.
+// APIs without an absolute upstream URL are returned separately.
+func groupUpstreams(apis []*types.OASAPI) ([]*upstreamEntry, []apiRef) {
+	byKey := make(map[string]*upstreamEntry)
+	var unresolved []apiRef
+
+	for _, api := range apis {
+		ref := apiRef{ID: api.ID, Name: api.Name}
+		u, err := url.Parse(api.UpstreamURL)
+		if err != nil || u.Scheme == "" || u.Hostname() == "" {
+			unresolved = append(unresolved, ref)
+			continue
+		}
+
+		scheme := strings.ToLower(u.Scheme)
+		port := u.Port()
+		if port == "" {
+			port = defaultPort(scheme)
+		}
+		key := scheme + "://" + strings.ToLower(u.Hostname()) + ":" + port
+		entry, ok := byKey[key]
+		if !ok {
+			entry = &upstreamEntry{
+				Host:      strings.ToLower(u.Hostname()),
+				Scheme:    scheme,
+				Port:      port,
+				Plaintext: scheme == "http" || scheme == "ws",
+			}
+			byKey[key] = entry
+		}
+		entry.APIs = append(entry.APIs, ref)
+	}
+
+	entries := make([]*upstreamEntry, 0, len(byKey))
+	for _, entry := range byKey {
+		sort.Slice(entry.APIs, func(i, j int) bool { return entry.APIs[i].Name < entry.APIs[j].Name })
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Host != entries[j].Host {
+			return entries[i].Host < entries[j].Host
+		}
+		if entries[i].Port != entries[j].Port {
+			return entries[i].Port < entries[j].Port
+		}
+		return entries[i].Scheme < entries[j].Scheme
+	})
+	return entries, unresolved
+}
+
+// defaultPort returns the implied port for an upstream scheme
+func defaultPort(scheme string) string {
+	switch scheme {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	}
+	return ""
+}
+
+// printUpstreamTable prints upstreams as a table with a TLS column to spot plaintext ones
+func printUpstreamTable(upstreams []*upstreamEntry) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "HOST\tSCHEME\tPORT\tTLS\tAPIS\tNAMES")
+	for _, entry := range upstreams {
+		names := make([]string, len(entry.APIs))
+		for i, api := range entry.APIs {
+			names[i] = api.Name
+		}
+		tls := "yes"
+		if entry.Plaintext {
+			tls = "no"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n", entry.Host, entry.Scheme, entry.Port, tls, len(entry.APIs), strings.Join(names, ", "))
+	}
+	w.Flush()
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// authModes lists every mode 'tyk report auth' can report, in display order
+var authModes = []string{
+	oas.AuthKeyless, oas.AuthAPIKey, oas.AuthJWT, oas.AuthOAuth2, oas.AuthOIDC,
+	oas.AuthMTLS, oas.AuthBasic, oas.AuthHMAC, oas.AuthCustom, oas.AuthUnknown,
+}
+
+// NewReportAuthCommand creates the 'tyk report auth' command
+func NewReportAuthCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Summarize the authentication mode of every API",
+		Long: `List the authentication modes each API enforces and how many APIs use each one.
+
+Modes: keyless, api_key, jwt, oauth2, oidc, mtls, basic, hmac, custom and unknown
+(authentication enabled without a scheme the CLI recognises).
+
+Use --fail-on in security reviews to exit 1 when any API uses one of the given
+modes.
+
+Examples:
+  tyk report auth
+  tyk report auth --fail-on keyless
+  tyk report auth --fail-on keyless,basic -o json`,
+		Args: cobra.NoArgs,
+		RunE: runReportAuth,
+	}
+
+	cmd.Flags().StringSlice("fail-on", nil, "Exit with status 1 when any API uses one of these modes (comma-separated)")
+
+	return cmd
+}
+
+// apiAuth is the authentication summary of one API
+type apiAuth struct {
+	APIID string   `json:"api_id"`
+	Name  string   `json:"name"`
+	Modes []string `json:"modes"`
+}
+
+func runReportAuth(cmd *cobra.Command, args []string) error {
+	failOn, _ := cmd.Flags().GetStringSlice("fail-on")
+	for _, mode := range failOn {
+		if !slices.Contains(authModes, mode) {
+			return &ExitError{Code: 2, Message: fmt.Sprintf("unknown auth mode '%s' in --fail-on (valid: %s)", mode, strings.Join(authModes, ", "))}
+		}
+	}
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	apis, unreadable, err := fetchAPIDefinitions(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]apiAuth, 0, len(apis))
+	counts := make(map[string]int)
+	for _, api := range apis {
+		modes := oas.AuthModes(api.OAS)
+		for _, mode := range modes {
+			counts[mode]++
+		}
+		entries = append(entries, apiAuth{APIID: api.ID, Name: api.Name, Modes: modes})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	var violations []apiAuth
+	for _, entry := range entries {
+		for _, mode := range entry.Modes {
+			if slices.Contains(failOn, mode) {
+				violations = append(violations, entry)
+				break
+			}
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if unreadable == nil {
+			unreadable = []apiRef{}
+		}
+		if err := writeStructured(format, map[string]interface{}{
+			"apis":       entries,
+			"count":      len(entries),
+			"by_mode":    counts,
+			"violations": len(violations),
+			"unreadable": unreadable,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printAuthReport(entries, counts)
+		printUnreadable(unreadable)
+	}
+
+	if len(violations) > 0 {
+		names := make([]string, len(violations))
+		for i, entry := range violations {
+			names[i] = entry.Name
+		}
+		return &ExitError{Code: 1, Message: fmt.Sprintf("%d API(s) use a disallowed auth mode (%s): %s",
+			len(violations), strings.Join(failOn, ", "), strings.Join(names, ", "))}
+	}
+	return nil
+}
+
+// printAuthReport prints the per-API table followed by counts per mode
+func printAuthReport(entries []apiAuth, counts map[string]int) {
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "No APIs found.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "API\tID\tAUTH")
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Name, entry.APIID, strings.Join(entry.Modes, ", "))
+	}
+	w.Flush()
+
+	var summary []string
+	for _, mode := range authModes {
+		if counts[mode] > 0 {
+			summary = append(summary, fmt.Sprintf("%s %d", mode, counts[mode]))
+		}
+	}
+	fmt.Fprintf(os.Stderr, "\n%d API(s): %s\n", len(entries), strings.Join(summary, ", "))
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestReportAuth(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "orders", "1.0.0")
+	orders := dashboard.apis["remote-2"]
+	orders["components"] = map[string]interface{}{
+		"securitySchemes": map[string]interface{}{
+			"jwt": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+		},
+	}
+	orders[oas.TykExtensionKey].(map[string]interface{})["server"].(map[string]interface{})["authentication"] = map[string]interface{}{
+		"enabled":         true,
+		"securitySchemes": map[string]interface{}{"jwt": map[string]interface{}{"enabled": true}},
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "report", "auth", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("report-auth", out), string(out))
+
+	var report struct {
+		APIs   []apiAuth      `json:"apis"`
+		ByMode map[string]int `json:"by_mode"`
+	}
+	require.NoError(t, json.Unmarshal(out, &report))
+	require.Len(t, report.APIs, 2)
+	assert.Equal(t, "orders", report.APIs[0].Name)
+	assert.Equal(t, []string{oas.AuthJWT}, report.APIs[0].Modes)
+	assert.Equal(t, []string{oas.AuthKeyless}, report.APIs[1].Modes)
+	assert.Equal(t, map[string]int{oas.AuthJWT: 1, oas.AuthKeyless: 1}, report.ByMode)
+
+	_, err = runRootCommand(t, "report", "auth", "--fail-on", "keyless", "-o", "json")
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 1, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "users")
+
+	_, err = runRootCommand(t, "report", "auth", "--fail-on", "oauth2", "-o", "json")
+	assert.NoError(t, err)
+
+	_, err = runRootCommand(t, "report", "auth", "--fail-on", "none")
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 2, exitErr.Code)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestGroupUpstreams(t *testing.T) {
+	upstreams, unresolved := groupUpstreams([]*types.OASAPI{
+		{ID: "1", Name: "Users", UpstreamURL: "https://users.internal/v1"},
+		{ID: "2", Name: "Accounts", UpstreamURL: "https://USERS.internal:443"},
+		{ID: "3", Name: "Legacy", UpstreamURL: "http://users.internal"},
+		{ID: "4", Name: "Orders", UpstreamURL: "http://orders.internal:8080"},
+		{ID: "5", Name: "Broken", UpstreamURL: "orders.internal"},
+	})
+
+	require.Len(t, upstreams, 3)
+	assert.Equal(t, upstreamEntry{Host: "orders.internal", Scheme: "http", Port: "8080", Plaintext: true,
+		APIs: []apiRef{{ID: "4", Name: "Orders"}}}, *upstreams[0])
+	assert.Equal(t, upstreamEntry{Host: "users.internal", Scheme: "https", Port: "443",
+		APIs: []apiRef{{ID: "2", Name: "Accounts"}, {ID: "1", Name: "Users"}}}, *upstreams[1])
+	assert.Equal(t, "80", upstreams[2].Port)
+	assert.True(t, upstreams[2].Plaintext)
+	assert.Equal(t, []apiRef{{ID: "5", Name: "Broken"}}, unresolved)
+}
+
+func TestReportUpstreams_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		items := []interface{}{}
+		if r.URL.Query().Get("p") == "1" {
+			for _, api := range [][3]string{{"1", "Users", "https://users.internal"}, {"2", "Orders", "http://orders.internal"}} {
+				items = append(items, map[string]interface{}{"api_definition": map[string]interface{}{
+					"api_id": api[0], "name": api[1], "proxy": map[string]interface{}{"target_url": api[2]},
+				}})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"apis": items})
+	}))
+	defer server.Close()
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "report", "upstreams", "--plaintext-only", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("report-upstreams", out), string(out))
+
+	var report struct {
+		Count     int              `json:"count"`
+		Plaintext int              `json:"plaintext"`
+		Upstreams []*upstreamEntry `json:"upstreams"`
+	}
+	require.NoError(t, json.Unmarshal(out, &report))
+	assert.Equal(t, 1, report.Count)
+	assert.Equal(t, 1, report.Plaintext)
+	assert.Equal(t, "orders.internal", report.Upstreams[0].Host)
+}
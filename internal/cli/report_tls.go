@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// NewReportTLSCommand creates the 'tyk report tls' command
+func NewReportTLSCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tls",
+		Short: "Flag APIs with weak TLS settings as a prioritized remediation list",
+		Long: `Check every API's Tyk extension for TLS weaknesses and list them most urgent first:
+
+  critical  tls_verification_disabled          upstream certificates are not verified
+  high      plaintext_upstream                 upstream uses http:// or ws://
+  medium    custom_domain_without_certificate  custom domain enabled without a certificate
+  low       weak_tls_min_version               upstream accepts TLS 1.0 or 1.1
+
+Examples:
+  tyk report tls
+  tyk report tls -o json`,
+		Args: cobra.NoArgs,
+		RunE: runReportTLS,
+	}
+}
+
+// apiFinding is a posture finding attributed to an API
+type apiFinding struct {
+	APIID string `json:"api_id"`
+	Name  string `json:"name"`
+	oas.Finding
+}
+
+func runReportTLS(cmd *cobra.Command, args []string) error {
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 5*time.Minute)
+	defer cancel()
+
+	apis, unreadable, err := fetchAPIDefinitions(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	findings := []apiFinding{}
+	affected := make(map[string]bool)
+	for _, api := range apis {
+		for _, finding := range oas.TLSFindings(api.OAS) {
+			findings = append(findings, apiFinding{APIID: api.ID, Name: api.Name, Finding: finding})
+			affected[api.ID] = true
+		}
+	}
+	sortFindings(findings)
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		if unreadable == nil {
+			unreadable = []apiRef{}
+		}
+		return writeStructured(format, map[string]interface{}{
+			"findings":     findings,
+			"count":        len(findings),
+			"by_severity":  countBySeverity(findings),
+			"apis_checked": len(apis),
+			"unreadable":   unreadable,
+		})
+	}
+
+	if len(findings) == 0 {
+		color.New(color.FgGreen).Printf("No TLS issues found across %d API(s)\n", len(apis))
+	} else {
+		printFindings(findings)
+		fmt.Fprintf(os.Stderr, "\n%d finding(s) across %d of %d API(s)\n", len(findings), len(affected), len(apis))
+	}
+	printUnreadable(unreadable)
+	return nil
+}
+
+// sortFindings orders findings most urgent first, then by API name
+func sortFindings(findings []apiFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		ri, rj := oas.SeverityRank(findings[i].Severity), oas.SeverityRank(findings[j].Severity)
+		if ri != rj {
+			return ri < rj
+		}
+		return findings[i].Name < findings[j].Name
+	})
+}
+
+func countBySeverity(findings []apiFinding) map[string]int {
+	counts := map[string]int{oas.SeverityCritical: 0, oas.SeverityHigh: 0, oas.SeverityMedium: 0, oas.SeverityLow: 0}
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	return counts
+}
+
+// printFindings prints a numbered remediation list
+func printFindings(findings []apiFinding) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tSEVERITY\tAPI\tCHECK\tDETAIL")
+	for i, finding := range findings {
+		fmt.Fprintf(w, "%d\t%s\t%s (%s)\t%s\t%s\n", i+1, finding.Severity, finding.Name, finding.APIID, finding.Check, finding.Message)
+	}
+	w.Flush()
+}
+
+// printUnreadable warns about APIs a report could not inspect
+func printUnreadable(unreadable []apiRef) {
+	if len(unreadable) == 0 {
+		return
+	}
+	color.New(color.FgYellow).Fprintf(os.Stderr, "%d API(s) could not be read and were not checked:\n", len(unreadable))
+	for _, api := range unreadable {
+		fmt.Fprintf(os.Stderr, "  %s  %s\n", api.ID, api.Name)
+	}
+}
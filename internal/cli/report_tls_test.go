@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestReportTLS(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	seedRemoteAPI(t, dashboard, "remote-1", "users", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-2", "orders", "1.0.0")
+	seedRemoteAPI(t, dashboard, "remote-3", "payments", "1.0.0")
+	tyk := func(id string) map[string]interface{} {
+		return dashboard.apis[id][oas.TykExtensionKey].(map[string]interface{})
+	}
+	tyk("remote-2")["upstream"] = map[string]interface{}{"url": "http://orders.internal"}
+	tyk("remote-3")["upstream"] = map[string]interface{}{
+		"url":          "https://payments.internal",
+		"tlsTransport": map[string]interface{}{"insecureSkipVerify": true},
+	}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "report", "tls", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("report-tls", out), string(out))
+
+	var report struct {
+		Findings    []apiFinding   `json:"findings"`
+		BySeverity  map[string]int `json:"by_severity"`
+		APIsChecked int            `json:"apis_checked"`
+	}
+	require.NoError(t, json.Unmarshal(out, &report))
+	assert.Equal(t, 3, report.APIsChecked)
+	require.Len(t, report.Findings, 2)
+	// Most urgent first
+	assert.Equal(t, "payments", report.Findings[0].Name)
+	assert.Equal(t, oas.CheckTLSVerifyDisabled, report.Findings[0].Check)
+	assert.Equal(t, "orders", report.Findings[1].Name)
+	assert.Equal(t, oas.CheckPlaintextUpstream, report.Findings[1].Check)
+	assert.Equal(t, 1, report.BySeverity[oas.SeverityCritical])
+	assert.Equal(t, 0, report.BySeverity[oas.SeverityLow])
+}
@@ -2,10 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/tyktech/tyk-cli/internal/audit"
 	"github.com/tyktech/tyk-cli/internal/config"
+	"github.com/tyktech/tyk-cli/internal/logging"
 	"github.com/tyktech/tyk-cli/pkg/types"
 )
 
@@ -14,7 +18,12 @@ type GlobalFlags struct {
 	DashURL   string
 	AuthToken string
 	OrgID     string
+	Env       string
+	Output    string
 	JSON      bool
+	Verbose   int
+	Timeout   time.Duration
+	Yes       bool
 }
 
 // NewRootCommand creates the root cobra command
@@ -28,9 +37,15 @@ func NewRootCommand(version, commit, buildTime string) *cobra.Command {
 It provides commands to create, update, delete, and manage API versions
 with support for OpenAPI 3.0 specifications.`,
 		Version: version,
+		// main reports failures itself, with their error code
+		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			logging.SetLevel(max(globalFlags.Verbose, logging.LevelFromEnv()))
+			audit.SetCommand(cmd.CommandPath())
+			migrateLegacyConfig(os.Stderr)
+
 			// Skip configuration loading for setup and info commands
-			skipCommands := []string{"version", "help", "init", "config"}
+			skipCommands := []string{"version", "help", "init", "config", "foreach-env", "bootstrap", "schema", "serve", "doctor", "mock", "oas", "audit", "explain"}
 			for _, skipCmd := range skipCommands {
 				if cmd.Name() == skipCmd || 
 				   (cmd.Parent() != nil && cmd.Parent().Name() == skipCmd) ||
@@ -56,13 +71,56 @@ with support for OpenAPI 3.0 specifications.`,
 		"Dashboard API auth token (TYK_AUTH_TOKEN)")
 	rootCmd.PersistentFlags().StringVar(&globalFlags.OrgID, "org-id", "", 
 		"Organization ID (TYK_ORG_ID)")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.Env, "env", "", 
+		"Environment to use instead of the default (TYK_ENV)")
+	rootCmd.PersistentFlags().StringVarP(&globalFlags.Output, "output", "o", "", 
+		"Output format: human, wide, json or yaml (default human)")
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.JSON, "json", false, 
 		"Output in JSON format")
+	rootCmd.PersistentFlags().MarkDeprecated("json", "use --output json instead")
+	rootCmd.PersistentFlags().DurationVar(&globalFlags.Timeout, "timeout", 0,
+		"Timeout for each API request, e.g. 2m (default 30s, or the environment's timeout)")
+	rootCmd.PersistentFlags().CountVarP(&globalFlags.Verbose, "verbose", "v",
+		"Log HTTP requests to stderr; repeat (-vv) to include redacted headers and bodies (TYK_CLI_DEBUG)")
+	rootCmd.PersistentFlags().BoolVarP(&globalFlags.Yes, "yes", "y", false,
+		"Answer yes to confirmation prompts, e.g. before deleting ("+EnvAssumeYes+")")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.Yes, "force", false,
+		"Same as --yes")
+	rootCmd.PersistentFlags().String("progress-format", "",
+		"Progress during bulk operations: json (newline-delimited events on stderr), or none to hide the spinner shown on terminals")
 
 	// Add subcommands
 	rootCmd.AddCommand(NewInitCommand())
 	rootCmd.AddCommand(NewAPICommand())
 	rootCmd.AddCommand(NewConfigCommand())
+	rootCmd.AddCommand(NewForeachEnvCommand())
+	rootCmd.AddCommand(NewBootstrapCommand())
+	rootCmd.AddCommand(NewGatewayCommand())
+	rootCmd.AddCommand(NewPreviewCommand())
+	rootCmd.AddCommand(NewSchemaCommand())
+	rootCmd.AddCommand(NewServeCommand())
+	rootCmd.AddCommand(NewPlanCommand())
+	rootCmd.AddCommand(NewApplyCommand())
+	rootCmd.AddCommand(NewReportCommand())
+	rootCmd.AddCommand(NewDriftCommand())
+	rootCmd.AddCommand(NewSyncCommand())
+	rootCmd.AddCommand(NewBackupCommand())
+	rootCmd.AddCommand(NewRestoreCommand())
+	rootCmd.AddCommand(NewKeyCommand())
+	rootCmd.AddCommand(NewPolicyCommand())
+	rootCmd.AddCommand(NewWebhookCommand())
+	rootCmd.AddCommand(NewPortalCommand())
+	rootCmd.AddCommand(NewWhoamiCommand())
+	rootCmd.AddCommand(NewDoctorCommand())
+	rootCmd.AddCommand(NewMockCommand())
+	rootCmd.AddCommand(NewOASCommand())
+	rootCmd.AddCommand(NewAnalyticsCommand())
+	rootCmd.AddCommand(NewAuditCommand())
+	rootCmd.AddCommand(NewExplainCommand())
+	rootCmd.AddCommand(NewGraphQLCommand())
+	rootCmd.AddCommand(NewPrefetchCommand())
+	registerAPIIDCompletion(rootCmd)
+	silenceUsageForStructuredOutput(rootCmd)
 
 	return rootCmd
 }
@@ -77,31 +135,52 @@ func initConfig(cmd *cobra.Command, flags *GlobalFlags) error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	// Select a specific environment for this invocation only
+	envName := flags.Env
+	if envName == "" {
+		envName = os.Getenv(config.EnvEnvName)
+	}
+	if envName != "" {
+		if err := configManager.SetDefaultEnvironment(envName); err != nil {
+			return &ExitError{Code: 2, Message: err.Error()}
+		}
+	}
+
 	// Override with command line flags
 	configManager.SetFromFlags(flags.DashURL, flags.AuthToken, flags.OrgID)
+	if err := applyTimeoutFlag(cmd, configManager.GetConfig()); err != nil {
+		return err
+	}
 
 	// Validate configuration
 	config := configManager.GetConfig()
 	if err := config.Validate(); err != nil {
 		return err
 	}
+	if env, err := config.GetActiveEnvironment(); err == nil {
+		applyEnvironmentVariables(env)
+	}
+
+	outputFormat, err := parseOutputFormat(flags.Output, flags.JSON)
+	if err != nil {
+		return err
+	}
 
 	// Get effective config for API operations (resolves environment values)
 	effectiveConfig := configManager.GetEffectiveConfig()
 
 	// Store in command context
 	cmd.SetContext(withConfig(cmd.Context(), effectiveConfig))
-	cmd.SetContext(withOutputFormat(cmd.Context(), getOutputFormat(flags.JSON)))
+	cmd.SetContext(withOutputFormat(cmd.Context(), outputFormat))
 	
 	return nil
 }
 
-// getOutputFormat converts boolean JSON flag to OutputFormat
-func getOutputFormat(jsonFlag bool) types.OutputFormat {
-	if jsonFlag {
-		return types.OutputJSON
-	}
-	return types.OutputHuman
+// outputFormatFromFlags resolves the output format for commands that skip initConfig
+func outputFormatFromFlags(cmd *cobra.Command) (types.OutputFormat, error) {
+	output, _ := cmd.Flags().GetString("output")
+	jsonFlag, _ := cmd.Flags().GetBool("json")
+	return parseOutputFormat(output, jsonFlag)
 }
 
 // SetupViper configures viper settings
@@ -2,8 +2,11 @@ package cli
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,22 +53,34 @@ func TestGlobalFlags(t *testing.T) {
 	assert.Equal(t, "bool", jsonFlag.Value.Type())
 }
 
-func TestGetOutputFormat(t *testing.T) {
+func TestParseOutputFormat(t *testing.T) {
 	tests := []struct {
 		name     string
+		output   string
 		jsonFlag bool
 		expected types.OutputFormat
 	}{
-		{"human format", false, types.OutputHuman},
-		{"json format", true, types.OutputJSON},
+		{"human format", "", false, types.OutputHuman},
+		{"explicit human format", "human", false, types.OutputHuman},
+		{"json format", "json", false, types.OutputJSON},
+		{"yaml format", "yaml", false, types.OutputYAML},
+		{"wide format", "wide", false, types.OutputWide},
+		{"deprecated json flag", "", true, types.OutputJSON},
+		{"json flag with matching output", "json", true, types.OutputJSON},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getOutputFormat(tt.jsonFlag)
+			result, err := parseOutputFormat(tt.output, tt.jsonFlag)
+			require.NoError(t, err)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
+
+	_, err := parseOutputFormat("xml", false)
+	assert.Error(t, err)
+	_, err = parseOutputFormat("yaml", true)
+	assert.Error(t, err)
 }
 
 func TestInitConfigWithEnvironment(t *testing.T) {
@@ -179,4 +194,53 @@ func TestHelpCommand(t *testing.T) {
 	rootCmd.SetArgs([]string{"help", "api"})
 	err = rootCmd.Execute()
 	assert.NoError(t, err)
-}
\ No newline at end of file
+}
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apis":[],"pages":1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org", Timeout: "5s"},
+		},
+	})
+
+	_, err := runRootCommand(t, "api", "list", "-o", "json")
+	require.NoError(t, err)
+
+	// --timeout overrides the environment's timeout
+	_, err = runRootCommand(t, "api", "list", "-o", "json", "--timeout", "50ms")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout")
+
+	_, err = runRootCommand(t, "api", "list", "--timeout", "-1s")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+func TestAPIContext(t *testing.T) {
+	config := &types.Config{
+		DefaultEnvironment: "test",
+		Environments:       map[string]*types.Environment{"test": {Name: "test", Timeout: "10m"}},
+	}
+
+	// A longer environment timeout extends the command's budget
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.Greater(t, time.Until(deadline), 9*time.Minute)
+
+	// but never shortens it
+	config.Environments["test"].Timeout = "1s"
+	ctx, cancel = apiContext(config, time.Minute)
+	defer cancel()
+	deadline, _ = ctx.Deadline()
+	assert.Greater(t, time.Until(deadline), 50*time.Second)
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+)
+
+// NewSchemaCommand creates the 'tyk schema' command and its subcommands
+func NewSchemaCommand() *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print JSON Schemas describing CLI output",
+		Long: `Print the JSON Schemas that describe the structured (--output json / yaml)
+output of each command, so integrations can code against a stable contract.`,
+	}
+
+	schemaCmd.AddCommand(NewSchemaOutputCommand())
+
+	return schemaCmd
+}
+
+// NewSchemaOutputCommand creates the 'tyk schema output' command
+func NewSchemaOutputCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "output [name]",
+		Short: "Print the JSON Schema for a command's structured output",
+		Long: fmt.Sprintf(`Print the JSON Schema for a command's structured output.
+
+Without a name, the available schema names are listed. Schemas are versioned
+through their $id; the current contract version is %s.
+
+Examples:
+  tyk schema output
+  tyk schema output api-list > api-list.schema.json`, outputschema.Version),
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: outputschema.Names(),
+		RunE:      runSchemaOutput,
+	}
+}
+
+func runSchemaOutput(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		for _, name := range outputschema.Names() {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	schema, err := outputschema.Get(args[0])
+	if err != nil {
+		return &ExitError{Code: 2, Message: err.Error()}
+	}
+	_, err = os.Stdout.Write(schema)
+	return err
+}
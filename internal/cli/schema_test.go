@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestSchemaOutput(t *testing.T) {
+	root := NewRootCommand("test", "", "")
+	root.SetArgs([]string{"schema", "output", "api-list"})
+	out, err := captureStdout(root.Execute)
+	require.NoError(t, err)
+
+	expected, err := outputschema.Get("api-list")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(out))
+
+	root = NewRootCommand("test", "", "")
+	root.SetArgs([]string{"schema", "output"})
+	out, err = captureStdout(root.Execute)
+	require.NoError(t, err)
+	assert.Equal(t, outputschema.Names(), strings.Fields(string(out)))
+
+	root = NewRootCommand("test", "", "")
+	root.SetArgs([]string{"schema", "output", "nope"})
+	_, err = captureStdout(root.Execute)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+// TestCommandOutputMatchesSchema runs commands against a fake Dashboard and checks
+// their JSON output against the published schemas, so the contract cannot drift silently.
+func TestCommandOutputMatchesSchema(t *testing.T) {
+	tests := []struct {
+		schema string
+		args   []string
+	}{
+		{schema: "api-list", args: []string{"api", "list"}},
+		{schema: "api-search", args: []string{"api", "search", "users"}},
+		{schema: "api-get", args: []string{"api", "get", "api-1"}},
+		{schema: "api-gc", args: []string{"api", "gc", "--prefix", "users", "--older-than", "1h", "--dry-run"}},
+		{schema: "api-operation-results", args: []string{"api", "delete", "--filter", "name=orders", "--yes"}},
+		{schema: "api-delete", args: []string{"api", "delete", "api-1", "--yes"}},
+		{schema: "api-create", args: []string{"api", "create", "--name", "Billing", "--upstream-url", "https://billing.internal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.schema, func(t *testing.T) {
+			dashboard, server := newFakeDashboard(t)
+			for i, name := range []string{"users", "orders"} {
+				doc, err := oas.AddTykExtensions(map[string]interface{}{
+					"openapi": "3.0.3",
+					"info":    map[string]interface{}{"title": name, "version": "1.0.0"},
+					"servers": []interface{}{map[string]interface{}{"url": "https://" + name + ".internal"}},
+					"paths":   map[string]interface{}{},
+				})
+				require.NoError(t, err)
+				dashboard.apis[[]string{"api-1", "api-2"}[i]] = doc
+			}
+			writeTestConfigFile(t, &types.Config{
+				DefaultEnvironment: "test",
+				Environments: map[string]*types.Environment{
+					"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+				},
+			})
+
+			root := NewRootCommand("test", "", "")
+			root.SetArgs(append(tt.args, "-o", "json"))
+			out, err := captureStdout(root.Execute)
+			require.NoError(t, err)
+			assert.NoError(t, outputschema.Validate(tt.schema, out), string(out))
+		})
+	}
+}
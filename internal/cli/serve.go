@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"gopkg.in/yaml.v3"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// maxRPCMessageSize bounds a single request line; whole specs travel inline
+const maxRPCMessageSize = 32 * 1024 * 1024
+
+// NewServeCommand creates the 'tyk serve' command
+func NewServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "serve",
+		Aliases: []string{"lsp"},
+		Short:   "Serve validate, lint and diff to editor plugins over JSON-RPC",
+		Long: `Run a JSON-RPC 2.0 server so editor plugins can show Tyk diagnostics while a spec
+is being written. Each request and response is a single line of JSON.
+
+Methods:
+  initialize  {}                                  -> {name, version, methods}
+  validate    {uri?, text?}                       -> {diagnostics}
+  lint        {uri?, text?}                       -> {diagnostics}
+  diff        {old: {uri?, text?}, new: {uri?, text?}} -> {changes}
+  shutdown    {}                                  -> null, then the server exits
+
+Documents are given inline as text (YAML or JSON) or by a file path or file:// URI.
+Diagnostics carry the 1-based line and column of the offending key when known.
+
+Examples:
+  tyk serve --stdio
+  echo '{"jsonrpc":"2.0","id":1,"method":"validate","params":{"uri":"api.yaml"}}' | tyk serve --stdio`,
+		Args: cobra.NoArgs,
+		RunE: runServe,
+	}
+
+	cmd.Flags().Bool("stdio", true, "Communicate over stdin/stdout (the only supported transport)")
+
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	if stdio, _ := cmd.Flags().GetBool("stdio"); !stdio {
+		return &ExitError{Code: 2, Message: "only --stdio transport is supported"}
+	}
+	server := &rpcServer{version: cmd.Root().Version}
+	return server.serve(os.Stdin, os.Stdout)
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcDocument identifies a spec either inline or on disk
+type rpcDocument struct {
+	URI  string `json:"uri,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// rpcDiagnostic is an oas.Diagnostic positioned in the source text
+type rpcDiagnostic struct {
+	oas.Diagnostic
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+type rpcServer struct {
+	version string
+}
+
+var rpcMethods = []string{"initialize", "validate", "lint", "diff", "shutdown"}
+
+// serve answers one request per input line until shutdown or end of input
+func (s *rpcServer) serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxRPCMessageSize)
+	encoder := json.NewEncoder(out)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var req rpcRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			encoder.Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+				Error: &rpcError{Code: rpcParseError, Message: err.Error()}})
+			continue
+		}
+
+		result, rpcErr := s.handle(req)
+		// Requests without an id are notifications and get no response
+		if len(req.ID) > 0 {
+			resp := rpcResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+			if rpcErr == nil {
+				data, err := json.Marshal(result)
+				if err != nil {
+					return err
+				}
+				resp.Result = data
+			}
+			if err := encoder.Encode(resp); err != nil {
+				return err
+			}
+		}
+		if req.Method == "shutdown" || req.Method == "exit" {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *rpcServer) handle(req rpcRequest) (interface{}, *rpcError) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: "expected a JSON-RPC 2.0 request with a method"}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{"name": "tyk", "version": s.version, "methods": rpcMethods}, nil
+	case "shutdown", "exit":
+		return nil, nil
+	case "validate", "lint":
+		var doc rpcDocument
+		if err := decodeRPCParams(req.Params, &doc); err != nil {
+			return nil, err
+		}
+		check := oas.Validate
+		if req.Method == "lint" {
+			check = oas.Lint
+		}
+		return map[string]interface{}{"diagnostics": diagnoseDocument(doc, check)}, nil
+	case "diff":
+		var params struct {
+			Old rpcDocument `json:"old"`
+			New rpcDocument `json:"new"`
+		}
+		if err := decodeRPCParams(req.Params, &params); err != nil {
+			return nil, err
+		}
+		oldDoc, _, err := loadRPCDocument(params.Old)
+		if err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "old: " + err.Error()}
+		}
+		newDoc, _, err := loadRPCDocument(params.New)
+		if err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: "new: " + err.Error()}
+		}
+		changes := oas.Diff(oldDoc, newDoc)
+		if changes == nil {
+			changes = []oas.Change{}
+		}
+		return map[string]interface{}{"changes": changes}, nil
+	}
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("unknown method '%s'", req.Method)}
+}
+
+func decodeRPCParams(params json.RawMessage, v interface{}) *rpcError {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// diagnoseDocument runs check and positions each diagnostic. Documents that cannot
+// be read or parsed yield a single error diagnostic rather than an RPC error, so the
+// editor can surface it inline like any other problem.
+func diagnoseDocument(doc rpcDocument, check func(map[string]interface{}) []oas.Diagnostic) []rpcDiagnostic {
+	content, root, err := loadRPCDocument(doc)
+	if err != nil {
+		return []rpcDiagnostic{{Diagnostic: oas.Diagnostic{Severity: oas.SeverityError, Path: []string{}, Message: err.Error()}}}
+	}
+
+	diagnostics := []rpcDiagnostic{}
+	for _, d := range check(content) {
+		line, column := locateYAMLPath(root, d.Path)
+		diagnostics = append(diagnostics, rpcDiagnostic{Diagnostic: d, Line: line, Column: column})
+	}
+	return diagnostics
+}
+
+// loadRPCDocument parses a document given inline or by path
+func loadRPCDocument(doc rpcDocument) (map[string]interface{}, *yaml.Node, error) {
+	text := doc.Text
+	if text == "" {
+		if doc.URI == "" {
+			return nil, nil, fmt.Errorf("either uri or text is required")
+		}
+		data, err := os.ReadFile(strings.TrimPrefix(doc.URI, "file://"))
+		if err != nil {
+			return nil, nil, err
+		}
+		text = string(data)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &root); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse document: %v", err)
+	}
+	content, err := filehandler.DecodeYAML(&root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("document must be a YAML or JSON object: %v", err)
+	}
+	return content, &root, nil
+}
+
+// locateYAMLPath returns the position of the deepest key along path that exists
+func locateYAMLPath(root *yaml.Node, path []string) (int, int) {
+	if root == nil || len(root.Content) == 0 {
+		return 0, 0
+	}
+	node := root.Content[0]
+	line, column := node.Line, node.Column
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line, column = node.Content[i].Line, node.Content[i].Column
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line, column
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runRPC feeds newline-delimited requests to the server and decodes each response line
+func runRPC(t *testing.T, requests ...string) []map[string]interface{} {
+	t.Helper()
+	var out bytes.Buffer
+	server := &rpcServer{version: "test"}
+	require.NoError(t, server.serve(strings.NewReader(strings.Join(requests, "\n")), &out))
+
+	var responses []map[string]interface{}
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var resp map[string]interface{}
+		require.NoError(t, decoder.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+const serveTestSpec = `openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths: {}
+x-tyk-api-gateway:
+  info:
+    name: Users
+  server:
+    listenPath:
+      value: users
+  upstream:
+    url: https://users.internal
+`
+
+func TestServe_Validate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(serveTestSpec), 0644))
+
+	responses := runRPC(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"validate","params":{"uri":"file://`+path+`"}}`,
+	)
+	require.Len(t, responses, 2)
+	assert.Equal(t, "test", responses[0]["result"].(map[string]interface{})["version"])
+
+	diagnostics := responses[1]["result"].(map[string]interface{})["diagnostics"].([]interface{})
+	require.Len(t, diagnostics, 1)
+	d := diagnostics[0].(map[string]interface{})
+	assert.Equal(t, "error", d["severity"])
+	assert.Contains(t, d["message"], "must start with /")
+	assert.Equal(t, float64(11), d["line"])
+	assert.Equal(t, float64(7), d["column"])
+}
+
+func TestServe_LintAndDiffInline(t *testing.T) {
+	text, _ := json.Marshal(serveTestSpec)
+	changed, _ := json.Marshal(strings.Replace(serveTestSpec, "1.0.0", "1.1.0", 1))
+
+	responses := runRPC(t,
+		`{"jsonrpc":"2.0","id":"a","method":"lint","params":{"text":`+string(text)+`}}`,
+		`{"jsonrpc":"2.0","id":"b","method":"diff","params":{"old":{"text":`+string(text)+`},"new":{"text":`+string(changed)+`}}}`,
+	)
+	require.Len(t, responses, 2)
+	assert.Empty(t, responses[0]["result"].(map[string]interface{})["diagnostics"])
+
+	changes := responses[1]["result"].(map[string]interface{})["changes"].([]interface{})
+	require.Len(t, changes, 1)
+	assert.Equal(t, []interface{}{"info", "version"}, changes[0].(map[string]interface{})["path"])
+}
+
+func TestServe_Errors(t *testing.T) {
+	responses := runRPC(t,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"format"}`,
+		`{"jsonrpc":"2.0","method":"validate","params":{"text":"a: b"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"validate","params":{"text":"a: [b"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"initialize"}`,
+	)
+	// The notification gets no reply and nothing after shutdown is processed
+	require.Len(t, responses, 4)
+	assert.Equal(t, float64(rpcParseError), responses[0]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(rpcMethodNotFound), responses[1]["error"].(map[string]interface{})["code"])
+
+	diagnostics := responses[2]["result"].(map[string]interface{})["diagnostics"].([]interface{})
+	require.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].(map[string]interface{})["message"], "failed to parse document")
+
+	assert.Contains(t, responses[3], "result")
+	assert.Nil(t, responses[3]["result"])
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const (
+	// spinnerDelay keeps quick requests from flashing a spinner
+	spinnerDelay    = 300 * time.Millisecond
+	spinnerInterval = 100 * time.Millisecond
+	// spinnerElapsedAfter is when the elapsed time is added to the message
+	spinnerElapsedAfter = 3 * time.Second
+)
+
+// spinner animates a status line on stderr while a command waits on the Dashboard, so
+// long requests do not look like hangs. A nil spinner is valid and does nothing, so
+// callers never need to check whether it is enabled.
+type spinner struct {
+	w        io.Writer
+	noColor  bool
+	delay    time.Duration
+	interval time.Duration
+
+	mu      sync.Mutex
+	message string
+	started time.Time
+	drawn   bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// newSpinner returns a spinner for cmd, or nil when it would get in the way: with
+// structured output, --progress-format, or when stderr is not a terminal. NO_COLOR
+// keeps the animation but drops its colour.
+func newSpinner(cmd *cobra.Command) *spinner {
+	if flag := cmd.Flags().Lookup("progress-format"); flag != nil && flag.Value.String() != "" {
+		return nil
+	}
+	if format, err := outputFormatFromFlags(cmd); err != nil || format.IsStructured() {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stderr.Fd())) || os.Getenv("TERM") == "dumb" {
+		return nil
+	}
+	_, noColor := os.LookupEnv("NO_COLOR")
+	return &spinner{w: os.Stderr, noColor: noColor || color.NoColor, delay: spinnerDelay, interval: spinnerInterval}
+}
+
+// startSpinner shows message with a spinner until the returned function is called
+func startSpinner(cmd *cobra.Command, message string) (stop func()) {
+	s := newSpinner(cmd)
+	s.Start(message)
+	return s.Stop
+}
+
+// Start begins animating message; it is drawn once the spinner delay has passed
+func (s *spinner) Start(message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = message
+	if s.stop != nil {
+		return
+	}
+	s.started = time.Now()
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run(s.stop, s.done)
+}
+
+// Update replaces the message shown next to the spinner
+func (s *spinner) Update(message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.message = message
+	s.mu.Unlock()
+}
+
+// Stop ends the animation and clears the status line; stopping twice is safe
+func (s *spinner) Stop() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (s *spinner) run(stop, done chan struct{}) {
+	defer close(done)
+	select {
+	case <-stop:
+		return
+	case <-time.After(s.delay):
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		s.draw(spinnerFrames[frame%len(spinnerFrames)])
+		select {
+		case <-stop:
+			s.mu.Lock()
+			if s.drawn {
+				fmt.Fprint(s.w, "\r\033[K")
+			}
+			s.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *spinner) draw(frame string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.noColor {
+		frame = color.New(color.FgCyan).Sprint(frame)
+	}
+	line := frame + " " + s.message
+	if elapsed := time.Since(s.started); elapsed >= spinnerElapsedAfter {
+		line += fmt.Sprintf(" (%ds)", int(elapsed.Seconds()))
+	}
+	fmt.Fprint(s.w, "\r\033[K"+line)
+	s.drawn = true
+}
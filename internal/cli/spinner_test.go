@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while the spinner goroutine writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSpinner(t *testing.T) {
+	var buf syncBuffer
+	s := &spinner{w: &buf, noColor: true, delay: time.Millisecond, interval: time.Millisecond}
+
+	s.Start("Listing APIs...")
+	assert.Eventually(t, func() bool { return strings.Contains(buf.String(), "Listing APIs...") }, time.Second, time.Millisecond)
+	s.Update("Writing the backup archive...")
+	assert.Eventually(t, func() bool { return strings.Contains(buf.String(), "Writing the backup archive...") }, time.Second, time.Millisecond)
+	s.Stop()
+	s.Stop()
+
+	out := buf.String()
+	assert.True(t, strings.HasSuffix(out, "\r\033[K"), "the status line is cleared")
+	assert.NotContains(t, out, "\033[36m", "NO_COLOR drops the colour")
+	assert.NotContains(t, out, "\n")
+}
+
+func TestSpinner_QuickOperationsDrawNothing(t *testing.T) {
+	var buf syncBuffer
+	s := &spinner{w: &buf, delay: time.Hour, interval: time.Millisecond}
+	s.Start("Creating API...")
+	s.Stop()
+	assert.Empty(t, buf.String())
+
+	// A nil spinner, as returned when it is disabled, is safe to use
+	var disabled *spinner
+	disabled.Start("Creating API...")
+	disabled.Update("Creating API...")
+	disabled.Stop()
+}
+
+func TestSpinner_ProgressReporter(t *testing.T) {
+	var buf syncBuffer
+	p := &progressReporter{operation: "api delete", spinner: &spinner{w: &buf, noColor: true, delay: time.Millisecond, interval: time.Millisecond}}
+	p.Start(2)
+	p.StepStarted("users-1")
+	assert.Eventually(t, func() bool { return strings.Contains(buf.String(), "api delete: 1/2 users-1") }, time.Second, time.Millisecond)
+	p.StepFinished("users-1", "")
+	p.Finish()
+	assert.NotContains(t, buf.String(), "{", "no JSON events without --progress-format json")
+}
+
+func TestNewSpinner_Disabled(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringP("output", "o", "", "")
+	cmd.Flags().Bool("json", false, "")
+	cmd.Flags().String("progress-format", "", "")
+
+	// Test output is never a terminal
+	assert.Nil(t, newSpinner(cmd))
+	cmd.Flags().Set("output", "json")
+	assert.Nil(t, newSpinner(cmd))
+	cmd.Flags().Set("output", "human")
+	cmd.Flags().Set("progress-format", "json")
+	assert.Nil(t, newSpinner(cmd))
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// addStrictYAMLFlag adds --strict-yaml to a command reading specs
+func addStrictYAMLFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("strict-yaml", false, "Fail on YAML that parsers read differently, such as unquoted on/off, 1.0 versions and leading zeros")
+}
+
+// loadUploadSpec loads the spec a command uploads from a file or a URL, and with
+// --strict-yaml fails when it has risky YAML constructs
+func loadUploadSpec(cmd *cobra.Command, filePath, urlStr string) (map[string]interface{}, error) {
+	if filePath != "" {
+		doc, err := loadOASFromFile(filePath)
+		if err != nil || !strictYAML(cmd) {
+			return doc, err
+		}
+		data, err := filehandler.ReadFile(filePath)
+		if err != nil {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read %s: %v", filePath, err)}
+		}
+		if err := checkStrictYAML(cmd, filePath, data); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+
+	body, err := fetchOASFromURL(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStrictYAML(cmd, urlStr, body); err != nil {
+		return nil, err
+	}
+	return parseOASDocument(body)
+}
+
+func strictYAML(cmd *cobra.Command) bool {
+	strict, _ := cmd.Flags().GetBool("strict-yaml")
+	return strict
+}
+
+// checkStrictYAML fails with every risky construct of data listed, when --strict-yaml
+// is given
+func checkStrictYAML(cmd *cobra.Command, source string, data []byte) error {
+	if !strictYAML(cmd) {
+		return nil
+	}
+	issues, err := filehandler.CheckYAML(data)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to parse %s: %v", source, err)}
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	lines := []string{fmt.Sprintf("%s has %d YAML construct(s) that parsers read differently (--strict-yaml):", source, len(issues))}
+	for _, issue := range issues {
+		lines = append(lines, fmt.Sprintf("  %s:%d:%d: %s", source, issue.Line, issue.Column, issue.Message))
+	}
+	return &ExitError{Code: int(types.ExitBadArgs), Message: strings.Join(lines, "\n")}
+}
+
+// strictYAMLDiagnostics reports the risky YAML constructs of data as errors
+func strictYAMLDiagnostics(data []byte) []rpcDiagnostic {
+	issues, err := filehandler.CheckYAML(data)
+	if err != nil {
+		// The spec's own validation reports documents that do not parse
+		return nil
+	}
+	diagnostics := make([]rpcDiagnostic, len(issues))
+	for i, issue := range issues {
+		diagnostics[i] = rpcDiagnostic{
+			Diagnostic: oas.Diagnostic{Severity: oas.SeverityError, Path: issue.Path, Message: issue.Message},
+			Line:       issue.Line,
+			Column:     issue.Column,
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestStrictYAML(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	spec := writeEnhancedSpec(t, t.TempDir(), nil)
+	data, err := os.ReadFile(spec)
+	require.NoError(t, err)
+	risky := strings.Replace(string(data), "version: 1.0.0", "version: 1.10", 1)
+	require.NotEqual(t, string(data), risky)
+	require.NoError(t, os.WriteFile(spec, []byte(risky), 0644))
+
+	_, err = runRootCommand(t, "api", "apply", "--file", spec, "--strict-yaml")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "version 1.10 is read as a number; quote it")
+	assert.Equal(t, 0, dashboard.count())
+
+	// Without the flag the version is uploaded as written
+	_, err = runRootCommand(t, "api", "apply", "--file", spec)
+	require.NoError(t, err)
+	require.Equal(t, 1, dashboard.count())
+	for _, doc := range dashboard.apis {
+		assert.Equal(t, "1.10", doc["info"].(map[string]interface{})["version"])
+	}
+
+	_, err = runRootCommand(t, "api", "import-oas", "--file", spec, "--strict-yaml")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	out, err := runRootCommand(t, "oas", "validate", "--file", spec, "--strict-yaml", "--no-cache", "-o", "json")
+	require.ErrorAs(t, err, &exitErr)
+	var result struct {
+		Files []validatedFile `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Len(t, result.Files, 1)
+	var strict []rpcDiagnostic
+	for _, d := range result.Files[0].Diagnostics {
+		if d.Severity == oas.SeverityError {
+			strict = append(strict, d)
+		}
+	}
+	require.Len(t, strict, 1)
+	assert.Equal(t, []string{"info", "version"}, strict[0].Path)
+	assert.Positive(t, strict[0].Line)
+
+	quoted := filepath.Join(t.TempDir(), "users.yaml")
+	require.NoError(t, os.WriteFile(quoted, []byte(strings.Replace(risky, "version: 1.10", `version: "1.10"`, 1)), 0644))
+	_, err = runRootCommand(t, "oas", "validate", "--file", quoted, "--strict-yaml", "--no-cache")
+	assert.NoError(t, err)
+}
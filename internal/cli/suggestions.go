@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// nextStep is a follow-up command a result suggests. Human output prints them under
+// "Next steps:"; structured output lists the commands as "suggestions" so wrappers can
+// offer the same guidance.
+type nextStep struct {
+	Command     string
+	Description string
+}
+
+// apiNextSteps suggests what to do with an API that has just been created, imported or
+// updated; operation is the "operation" of the structured result
+func apiNextSteps(api *types.OASAPI, operation string) []nextStep {
+	steps := []nextStep{
+		{Command: "tyk api get " + api.ID, Description: "View full configuration"},
+		{Command: "tyk api get " + api.ID + " --oas-only > api.yaml", Description: "Export for editing"},
+	}
+	if operation == "updated" {
+		steps = append(steps, nextStep{Command: "tyk api rollback " + api.ID, Description: "Undo this update"})
+	}
+	return append(steps, nextStep{Command: "tyk api url " + api.ID + " --probe", Description: "Check the Gateway serves it"})
+}
+
+// suggestions returns the commands of steps for structured output, never nil
+func suggestions(steps []nextStep) []string {
+	commands := make([]string, len(steps))
+	for i, step := range steps {
+		commands[i] = step.Command
+	}
+	return commands
+}
+
+// printNextSteps prints steps as an aligned, dimmed "Next steps:" block
+func printNextSteps(steps []nextStep) {
+	if len(steps) == 0 {
+		return
+	}
+	width := 0
+	for _, step := range steps {
+		width = max(width, len(step.Command))
+	}
+	dim := color.New(color.FgHiBlack)
+	fmt.Println()
+	dim.Println("Next steps:")
+	for _, step := range steps {
+		dim.Printf("  %-*s  # %s\n", width, step.Command, step.Description)
+	}
+}
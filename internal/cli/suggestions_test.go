@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestSuggestions(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "api", "create", "--name", "Billing", "--upstream-url", "https://billing.internal", "-o", "json")
+	require.NoError(t, err)
+	var result struct {
+		APIID       string   `json:"api_id"`
+		Suggestions []string `json:"suggestions"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Equal(t, 1, dashboard.count())
+	assert.Equal(t, []string{
+		"tyk api get " + result.APIID,
+		"tyk api get " + result.APIID + " --oas-only > api.yaml",
+		"tyk api url " + result.APIID + " --probe",
+	}, result.Suggestions)
+
+	steps := apiNextSteps(&types.OASAPI{ID: "a1"}, "updated")
+	assert.Contains(t, suggestions(steps), "tyk api rollback a1")
+	assert.Equal(t, []string{}, suggestions(nil))
+}
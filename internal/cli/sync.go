@@ -0,0 +1,398 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/metrics"
+	"github.com/tyktech/tyk-cli/internal/workspace"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// syncTarget is one workspace project deployed to one environment
+type syncTarget struct {
+	Path               string               `json:"path"`
+	Environment        string               `json:"environment"`
+	Prefix             string               `json:"prefix,omitempty"`
+	Prune              bool                 `json:"prune"`
+	CategoriesFromDirs bool                 `json:"categories_from_dirs,omitempty"`
+	Plan               *apiPlan             `json:"plan"`
+	Results            []apiOperationResult `json:"results"`
+
+	specs  int
+	client *client.Client
+}
+
+// NewSyncCommand creates the 'tyk sync' command
+func NewSyncCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Plan and apply every project of a tyk.workspace.yaml",
+		Long: `Deploy a monorepo of many teams in one run. tyk.workspace.yaml, found in the working
+directory or the nearest parent, maps spec directories to the environments they are
+deployed to:
+
+  projects:
+    - path: teams/payments
+      environment: prod
+      prefix: /pay
+      prune: true
+      categories_from_dirs: true   # apis in teams/payments/cards/ get category cards
+    - path: teams/search
+      environments: [staging, prod]
+
+Each project is planned like 'tyk plan --dir <path>' against each of its environments.
+With a prefix, every API of the project is mounted under that listen path, and prune
+only deletes APIs listening under it, so teams never delete each other's APIs.
+
+Every project is planned and checked before anything is applied; a project that fails
+to plan stops the whole sync. Use --env or --project to sync part of the workspace.
+
+With --watch the sync runs again after every interval until interrupted, re-reading
+the workspace each time; a failed run is reported and retried on the next one. Add
+--metrics-addr to serve Prometheus metrics at /metrics: runs, failures, applies,
+drift (APIs that differed from their spec on the last run), the last run and success
+timestamps, and the API requests made.
+
+--daemon runs sync as a long-lived reconciler of a Git checkout, for teams without
+Kubernetes: every --interval it optionally pulls the checkout (--git-pull) and syncs
+it. A lock file next to the workspace manifest (--lock-file) keeps a single daemon per
+checkout; a lock not refreshed for three intervals is taken over. With --metrics-addr
+the daemon also serves /healthz, and /readyz which fails until a run succeeds and
+whenever the last run failed. SIGINT or SIGTERM stops it after the current run.
+
+Examples:
+  tyk sync --dry-run
+  tyk sync --project teams/payments
+  tyk sync --workspace ./tyk.workspace.yaml --env staging -o json
+  tyk sync --watch 5m --metrics-addr :9090
+  tyk sync --daemon --interval 5m --git-pull --metrics-addr :9090`,
+		Args: cobra.NoArgs,
+		RunE: runSync,
+	}
+
+	cmd.Flags().String("workspace", "", "Workspace manifest (default: tyk.workspace.yaml in this or a parent directory)")
+	cmd.Flags().StringSlice("project", nil, "Only sync these project paths (repeatable)")
+	cmd.Flags().Bool("dry-run", false, "Show the plans without applying them")
+	cmd.Flags().Bool("skip-compat-check", false, "Do not check specs against the environment's Tyk version")
+	cmd.Flags().Duration("watch", 0, "Sync again after every interval until interrupted, e.g. 5m")
+	cmd.Flags().String("metrics-addr", "", "With --watch or --daemon, serve Prometheus metrics on this address, e.g. :9090")
+	cmd.Flags().Bool("daemon", false, "Reconcile the workspace every --interval as a long-lived process")
+	cmd.Flags().Duration("interval", 5*time.Minute, "With --daemon, how often to reconcile")
+	cmd.Flags().Bool("git-pull", false, "With --daemon, run 'git pull --ff-only' in the workspace before every sync")
+	cmd.Flags().String("lock-file", "", "With --daemon, lock file keeping one daemon per workspace (default: .tyk-sync.lock next to the manifest)")
+	cmd.MarkFlagsMutuallyExclusive("watch", "daemon")
+
+	return cmd
+}
+
+// syncReport is the outcome of one sync run
+type syncReport struct {
+	manifest *workspace.Manifest
+	targets  []*syncTarget
+	dryRun   bool
+	failed   int
+	pending  int
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	watch, _ := cmd.Flags().GetDuration("watch")
+	metricsAddr, _ := cmd.Flags().GetString("metrics-addr")
+
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	if watch < 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--watch must be a positive interval"}
+	}
+	if daemon, _ := cmd.Flags().GetBool("daemon"); daemon {
+		return runSyncDaemon(cmd, config, metricsAddr)
+	}
+	for _, flag := range []string{"interval", "git-pull", "lock-file"} {
+		if cmd.Flags().Changed(flag) {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--%s needs --daemon", flag)}
+		}
+	}
+	if metricsAddr != "" && watch == 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--metrics-addr needs --watch or --daemon: a single sync exits before it could be scraped"}
+	}
+	if watch > 0 {
+		return watchSync(cmd, config, syncLoop{interval: watch, metricsAddr: metricsAddr})
+	}
+
+	report, err := syncOnce(cmd, config)
+	if err != nil {
+		return err
+	}
+	if err := outputSync(cmd, report); err != nil {
+		return err
+	}
+	if report.failed > 0 {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("%d of %d change(s) failed", report.failed, report.pending)}
+	}
+	return nil
+}
+
+// syncLoop configures watchSync
+type syncLoop struct {
+	interval    time.Duration
+	metricsAddr string
+	// before runs ahead of every sync; an error fails that run, and a stopLoopError
+	// error stops the loop
+	before func(ctx context.Context) error
+	// health, when set, is updated after every run and served next to the metrics
+	health *syncHealth
+}
+
+// watchSync syncs after every interval until the command is interrupted, recording
+// each run in cliMetrics
+func watchSync(cmd *cobra.Command, config *types.Config, loop syncLoop) error {
+	// The first interrupt stops the loop after the current run; a second one kills it
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	describeSyncMetrics()
+	if loop.metricsAddr != "" {
+		var routes []metrics.Route
+		if loop.health != nil {
+			routes = loop.health.routes()
+		}
+		if err := serveMetrics(ctx, loop.metricsAddr, routes...); err != nil {
+			return err
+		}
+	}
+
+	for {
+		started := time.Now()
+		var report *syncReport
+		var err error
+		if loop.before != nil {
+			err = loop.before(ctx)
+		}
+		var stopErr *stopLoopError
+		if errors.As(err, &stopErr) {
+			return stopErr.err
+		}
+		if err == nil {
+			report, err = syncOnce(cmd, config)
+		}
+		recordSyncRun(report, err, started)
+		if loop.health != nil {
+			loop.health.record(report, err, started)
+		}
+		switch {
+		case err != nil:
+			color.New(color.FgRed).Fprintf(os.Stderr, "✗ Sync failed: %s\n", errorMessage(err))
+		default:
+			if err := outputSync(cmd, report); err != nil {
+				return err
+			}
+			if report.failed > 0 {
+				color.New(color.FgRed).Fprintf(os.Stderr, "✗ %d of %d change(s) failed\n", report.failed, report.pending)
+			}
+		}
+
+		timer := time.NewTimer(loop.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// syncOnce plans every target of the workspace and, unless --dry-run, applies the plans
+func syncOnce(cmd *cobra.Command, config *types.Config) (*syncReport, error) {
+	manifestPath, _ := cmd.Flags().GetString("workspace")
+	only, _ := cmd.Flags().GetStringSlice("project")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if manifestPath == "" {
+		found, err := workspace.Find(".")
+		if err != nil {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		manifestPath = found
+	}
+	manifest, err := workspace.Load(manifestPath)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	// --env narrows the sync to one environment rather than redirecting every project
+	envFilter := ""
+	if flag := cmd.Flag("env"); flag != nil && flag.Changed {
+		envFilter = config.DefaultEnvironment
+	}
+	targets, err := selectSyncTargets(manifest, only, envFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := apiContext(config, 10*time.Minute)
+	defer cancel()
+
+	for _, target := range targets {
+		if err := planSyncTarget(ctx, cmd, config, manifest, target); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &syncReport{manifest: manifest, targets: targets, dryRun: dryRun}
+	if !dryRun {
+		for _, target := range targets {
+			for _, action := range target.Plan.Actions {
+				if action.Action == planNoChange {
+					continue
+				}
+				report.pending++
+				result := applyPlanAction(ctx, target.client, action)
+				if result.Error != "" {
+					report.failed++
+				}
+				target.Results = append(target.Results, result)
+			}
+		}
+	}
+	return report, nil
+}
+
+// outputSync prints the plans and results of a sync run
+func outputSync(cmd *cobra.Command, report *syncReport) error {
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"workspace": report.manifest.File,
+			"dry_run":   report.dryRun,
+			"projects":  report.targets,
+			"failed":    report.failed,
+		})
+	}
+	printSync(cmd, report.targets, report.dryRun, report.failed)
+	return nil
+}
+
+// selectSyncTargets expands the manifest into one target per project and environment,
+// keeping only the requested projects and environment
+func selectSyncTargets(manifest *workspace.Manifest, only []string, env string) ([]*syncTarget, error) {
+	wanted := make(map[string]bool)
+	for _, path := range only {
+		wanted[path] = false
+	}
+
+	targets := []*syncTarget{}
+	for i := range manifest.Projects {
+		project := &manifest.Projects[i]
+		if _, ok := wanted[project.Path]; len(only) > 0 && !ok {
+			continue
+		}
+		wanted[project.Path] = true
+		for _, name := range project.Targets() {
+			if env != "" && name != env {
+				continue
+			}
+			targets = append(targets, &syncTarget{
+				Path:               project.Path,
+				Environment:        name,
+				Prefix:             project.Prefix,
+				Prune:              project.Prune,
+				CategoriesFromDirs: project.CategoriesFromDirs,
+				Results:            []apiOperationResult{},
+			})
+		}
+	}
+
+	for _, path := range only {
+		if !wanted[path] {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("project '%s' is not in %s", path, manifest.File)}
+		}
+	}
+	if len(targets) == 0 {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("no project in %s is deployed to environment '%s'", manifest.File, env)}
+	}
+	return targets, nil
+}
+
+// planSyncTarget builds and checks the plan of one project against one environment
+func planSyncTarget(ctx context.Context, cmd *cobra.Command, config *types.Config, manifest *workspace.Manifest, target *syncTarget) error {
+	env, ok := config.Environments[target.Environment]
+	if !ok {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("project %s: environment '%s' not found", target.Path, target.Environment)}
+	}
+	targetConfig := *config
+	targetConfig.DefaultEnvironment = target.Environment
+
+	dir := manifest.Dir(&workspace.Project{Path: target.Path})
+	files, err := filehandler.FindSpecFiles(dir)
+	if err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("project %s: %v", target.Path, err)}
+	}
+	if len(files) == 0 && target.Prune {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("no OAS files found in %s: refusing to prune every API under %s", dir, target.Prefix)}
+	}
+	target.specs = len(files)
+
+	c, err := commandClient(cmd.Context(), &targetConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create client for environment '%s': %w", target.Environment, err)
+	}
+	target.client = c
+
+	scope := planScope{prune: target.Prune, prefix: target.Prefix, envPrefix: env.ListenPathPrefix}
+	if target.CategoriesFromDirs {
+		scope.categoryRoot = dir
+	}
+	plan, err := buildPlan(ctx, c, files, scope)
+	if err != nil {
+		return err
+	}
+	if err := checkPlanReferences(ctx, c, plan); err != nil {
+		return err
+	}
+	if err := checkPlanCompatibility(ctx, cmd, c, env, plan); err != nil {
+		return err
+	}
+	plan.Environment = target.Environment
+	plan.Dir = target.Path
+	for _, action := range plan.Actions {
+		if action.Action == planNoChange {
+			action.Document = nil
+		}
+	}
+	target.Plan = plan
+	return nil
+}
+
+// printSync prints the plan of every target followed by what was applied
+func printSync(cmd *cobra.Command, targets []*syncTarget, dryRun bool, failed int) {
+	for i, target := range targets {
+		if i > 0 {
+			fmt.Println()
+		}
+		printPlan(target.Plan, target.specs)
+		if len(target.Results) > 0 {
+			fmt.Println()
+			outputAPIOperationResults(cmd, target.Results)
+		}
+	}
+
+	switch {
+	case dryRun:
+		fmt.Printf("\nDry run: nothing was applied to %d project target(s).\n", len(targets))
+	case failed == 0:
+		color.New(color.FgGreen).Printf("\n✓ Synced %d project target(s)\n", len(targets))
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/lock"
+	"github.com/tyktech/tyk-cli/internal/metrics"
+	"github.com/tyktech/tyk-cli/internal/workspace"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// stopLoopError stops watchSync with err instead of failing a single run
+type stopLoopError struct {
+	err error
+}
+
+func (e *stopLoopError) Error() string {
+	return e.err.Error()
+}
+
+// runSyncDaemon reconciles the workspace every --interval, holding the workspace lock
+// for as long as it runs
+func runSyncDaemon(cmd *cobra.Command, config *types.Config, metricsAddr string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	gitPull, _ := cmd.Flags().GetBool("git-pull")
+	lockPath, _ := cmd.Flags().GetString("lock-file")
+	manifestPath, _ := cmd.Flags().GetString("workspace")
+
+	if interval <= 0 {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: "--interval must be a positive interval"}
+	}
+	// Resolve the workspace once, so every run syncs the same checkout
+	if manifestPath == "" {
+		found, err := workspace.Find(".")
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+		}
+		manifestPath = found
+	}
+	manifestPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Flags().Set("workspace", manifestPath); err != nil {
+		return err
+	}
+	dir := filepath.Dir(manifestPath)
+	if gitPull {
+		if _, err := runGit(cmd.Context(), dir, "rev-parse", "--show-toplevel"); err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("--git-pull: %s is not in a Git checkout: %v", dir, err)}
+		}
+	}
+	if lockPath == "" {
+		lockPath = filepath.Join(dir, ".tyk-sync.lock")
+	}
+
+	staleAfter := 3 * interval
+	held, err := lock.Acquire(lockPath, staleAfter)
+	var heldErr *lock.HeldError
+	if errors.As(err, &heldErr) {
+		return &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("another sync daemon is running: %v", err)}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create lock file: %w", err)
+	}
+	defer held.Release()
+	// A run can outlast the stale period, so refresh between runs is not enough
+	defer held.KeepFresh(staleAfter / 3)()
+	if held.Stale != nil {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ Took over the stale lock %s from %s\n", lockPath, held.Stale)
+	}
+	fmt.Fprintf(os.Stderr, "Reconciling %s every %s\n", manifestPath, interval)
+
+	return watchSync(cmd, config, syncLoop{
+		interval:    interval,
+		metricsAddr: metricsAddr,
+		health:      &syncHealth{},
+		before: func(ctx context.Context) error {
+			if err := held.Refresh(); err != nil {
+				if errors.Is(err, lock.ErrLost) {
+					return &stopLoopError{err: &ExitError{Code: int(types.ExitGeneral), Message: fmt.Sprintf("stopping: %v", err)}}
+				}
+				return err
+			}
+			if gitPull {
+				if _, err := runGit(ctx, dir, "pull", "--ff-only"); err != nil {
+					return fmt.Errorf("git pull failed: %w", err)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// runGit runs git in dir and returns its trimmed output; errors carry git's message
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// syncHealth tracks the runs of a sync daemon for its health endpoints
+type syncHealth struct {
+	mu          sync.Mutex
+	runs        int
+	lastRun     time.Time
+	lastSuccess time.Time
+	lastError   string
+}
+
+// syncHealthStatus is the body of /healthz and /readyz
+type syncHealthStatus struct {
+	// Status is starting until the first run finishes, then ok or failing
+	Status      string     `json:"status"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+func (h *syncHealth) record(report *syncReport, err error, started time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.runs++
+	h.lastRun = started.UTC()
+	switch {
+	case err != nil:
+		h.lastError = errorMessage(err)
+	case report.failed > 0:
+		h.lastError = fmt.Sprintf("%d of %d change(s) failed", report.failed, report.pending)
+	default:
+		h.lastError = ""
+		h.lastSuccess = started.UTC()
+	}
+}
+
+func (h *syncHealth) status() syncHealthStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := syncHealthStatus{Status: "starting", Error: h.lastError}
+	if h.runs > 0 {
+		lastRun := h.lastRun
+		status.LastRun = &lastRun
+		status.Status = "ok"
+		if h.lastError != "" {
+			status.Status = "failing"
+		}
+	}
+	if !h.lastSuccess.IsZero() {
+		lastSuccess := h.lastSuccess
+		status.LastSuccess = &lastSuccess
+	}
+	return status
+}
+
+// routes returns /healthz, which answers while the daemon runs, and /readyz, which
+// only succeeds when the last run did
+func (h *syncHealth) routes() []metrics.Route {
+	serve := func(ready bool) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			status := h.status()
+			code := http.StatusOK
+			if ready && status.Status != "ok" {
+				code = http.StatusServiceUnavailable
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(code)
+			json.NewEncoder(w).Encode(status)
+		})
+	}
+	return []metrics.Route{
+		{Path: "/healthz", Handler: serve(false)},
+		{Path: "/readyz", Handler: serve(true)},
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/lock"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestSync_Daemon(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		args = append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	origin := t.TempDir()
+	git(origin, "init", "-q")
+	require.NoError(t, os.MkdirAll(filepath.Join(origin, "apis"), 0755))
+	writePlanSpec(t, filepath.Join(origin, "apis"), "users", "1.0.0")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "tyk.workspace.yaml"), []byte("projects:\n  - path: apis\n    environment: test\n"), 0644))
+	git(origin, "add", "-A")
+	git(origin, "commit", "-qm", "users")
+	checkout := filepath.Join(t.TempDir(), "checkout")
+	git(filepath.Dir(checkout), "clone", "-q", origin, checkout)
+	manifest := filepath.Join(checkout, "tyk.workspace.yaml")
+	lockPath := filepath.Join(checkout, ".tyk-sync.lock")
+
+	// A second daemon on the same checkout refuses to start
+	other, err := lock.Acquire(lockPath, time.Hour)
+	require.NoError(t, err)
+	_, err = runRootCommand(t, "sync", "--daemon", "--workspace", manifest)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "another sync daemon is running")
+	require.NoError(t, other.Release())
+
+	_, err = runRootCommand(t, "sync", "--git-pull", "--workspace", manifest)
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	// Commits pushed to the origin are pulled and synced on the next run
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		defer cancel()
+		deadline := time.Now().Add(10 * time.Second)
+		for dashboard.count() < 1 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		writePlanSpec(t, filepath.Join(origin, "apis"), "orders", "1.0.0")
+		git(origin, "add", "-A")
+		git(origin, "commit", "-qm", "orders")
+		for dashboard.count() < 2 && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	cmd := NewRootCommand("test", "", "")
+	cmd.SetArgs([]string{"sync", "--daemon", "--interval", "10ms", "--git-pull", "--workspace", manifest, "--metrics-addr", "127.0.0.1:0", "-o", "json"})
+	_, err = captureStdout(func() error { return cmd.ExecuteContext(ctx) })
+	require.NoError(t, err)
+	assert.Equal(t, 2, dashboard.count())
+
+	// The lock is released on exit
+	_, err = os.Stat(lockPath)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSyncHealth(t *testing.T) {
+	health := &syncHealth{}
+	routes := health.routes()
+	get := func(path string) (int, syncHealthStatus) {
+		t.Helper()
+		for _, route := range routes {
+			if route.Path != path {
+				continue
+			}
+			rec := httptest.NewRecorder()
+			route.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			var status syncHealthStatus
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+			return rec.Code, status
+		}
+		t.Fatalf("no route %s", path)
+		return 0, syncHealthStatus{}
+	}
+
+	code, status := get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "starting", status.Status)
+	code, _ = get("/healthz")
+	assert.Equal(t, http.StatusOK, code)
+
+	health.record(&syncReport{}, nil, time.Now())
+	code, status = get("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotNil(t, status.LastSuccess)
+
+	health.record(&syncReport{failed: 1, pending: 2}, nil, time.Now())
+	code, status = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "failing", status.Status)
+	assert.Equal(t, "1 of 2 change(s) failed", status.Error)
+	assert.NotNil(t, status.LastSuccess)
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/metrics"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestSync(t *testing.T) {
+	prod, prodServer := newFakeDashboard(t)
+	staging, stagingServer := newFakeDashboard(t)
+	// Owned by payments and no longer in its directory, so pruned
+	seedRemoteAPI(t, prod, "legacy", "legacy", "1.0.0")
+	oas.SetListenPath(prod.apis["legacy"], "/pay/legacy/")
+	// Outside the payments prefix, so left alone
+	seedRemoteAPI(t, prod, "other", "other", "1.0.0")
+
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "staging",
+		Environments: map[string]*types.Environment{
+			"staging": {Name: "staging", DashboardURL: stagingServer.URL, AuthToken: "token", OrgID: "org"},
+			"prod":    {Name: "prod", DashboardURL: prodServer.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	root := t.TempDir()
+	payments := filepath.Join(root, "teams", "payments")
+	search := filepath.Join(root, "teams", "search")
+	require.NoError(t, os.MkdirAll(payments, 0755))
+	require.NoError(t, os.MkdirAll(search, 0755))
+	writePlanSpec(t, payments, "refunds", "1.0.0")
+	writePlanSpec(t, search, "query", "1.0.0")
+	manifest := filepath.Join(root, "tyk.workspace.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte(`projects:
+  - path: teams/payments
+    environment: prod
+    prefix: /pay
+    prune: true
+  - path: teams/search
+    environments: [staging, prod]
+`), 0644))
+
+	out, err := runRootCommand(t, "sync", "--workspace", manifest, "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("sync", out), string(out))
+	var result struct {
+		Projects []syncTarget `json:"projects"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Len(t, result.Projects, 3)
+	assert.Equal(t, map[string]int{planCreate: 1, planUpdate: 0, planDelete: 1, planNoChange: 0}, result.Projects[0].Plan.Summary)
+	assert.Equal(t, 2, prod.count())
+	assert.Equal(t, 0, staging.count())
+
+	// --env syncs only the projects deployed there
+	out, err = runRootCommand(t, "sync", "--workspace", manifest, "--env", "staging", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	require.Len(t, result.Projects, 1)
+	assert.Equal(t, "teams/search", result.Projects[0].Path)
+	assert.Equal(t, 1, staging.count())
+	assert.Equal(t, 2, prod.count())
+
+	out, err = runRootCommand(t, "sync", "--workspace", manifest, "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("sync", out), string(out))
+
+	assert.Nil(t, prod.apis["legacy"])
+	assert.NotNil(t, prod.apis["other"])
+	listenPaths := map[string]string{}
+	for _, doc := range prod.apis {
+		listenPaths[oas.GetAPIName(doc)] = oas.GetListenPath(doc)
+	}
+	assert.Equal(t, "/pay/refunds/", listenPaths["refunds"])
+	assert.Equal(t, "/query/", listenPaths["query"])
+
+	// A second sync has nothing left to do
+	out, err = runRootCommand(t, "sync", "--workspace", manifest, "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	for _, project := range result.Projects {
+		assert.Equal(t, len(project.Plan.Actions), project.Plan.Summary[planNoChange], project.Path)
+	}
+
+	_, err = runRootCommand(t, "sync", "--workspace", manifest, "--project", "teams/missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+}
+
+func TestSync_Watch(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "apis"), 0755))
+	writePlanSpec(t, filepath.Join(root, "apis"), "users", "1.0.0")
+	manifest := filepath.Join(root, "tyk.workspace.yaml")
+	require.NoError(t, os.WriteFile(manifest, []byte("projects:\n  - path: apis\n    environment: test\n"), 0644))
+
+	_, err := runRootCommand(t, "sync", "--workspace", manifest, "--metrics-addr", "127.0.0.1:0")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+
+	runs := func() float64 {
+		value, _ := cliMetrics.Value("tyk_sync_runs_total", nil)
+		return value
+	}
+	before := runs()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for runs() < before+2 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		cancel()
+	}()
+
+	cmd := NewRootCommand("test", "", "")
+	cmd.SetArgs([]string{"sync", "--workspace", manifest, "--watch", "10ms", "--metrics-addr", "127.0.0.1:0", "-o", "json"})
+	_, err = captureStdout(func() error { return cmd.ExecuteContext(ctx) })
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, dashboard.count())
+	created, _ := cliMetrics.Value("tyk_sync_applies_total", metrics.Labels{"environment": "test", "operation": "created"})
+	assert.GreaterOrEqual(t, created, 1.0)
+	// The second run found the API already in place
+	drift, ok := cliMetrics.Value("tyk_sync_drift_apis", metrics.Labels{"environment": "test"})
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, drift)
+	requests, _ := cliMetrics.Value("tyk_cli_api_requests_total", metrics.Labels{"method": "GET", "code": "200"})
+	assert.Positive(t, requests)
+	_, ok = cliMetrics.Value("tyk_sync_last_success_timestamp_seconds", nil)
+	assert.True(t, ok)
+}
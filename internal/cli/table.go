@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// tableColumn is one column of a table
+type tableColumn struct {
+	Header string
+	// Min is the narrowest the column is truncated to on a narrow terminal; 0 means
+	// its values are never truncated (IDs, listen paths)
+	Min int
+}
+
+// table renders rows as columns sized to their content. On a terminal, truncatable
+// columns shrink to fit its width, and when even that does not fit each row is
+// printed as a stacked block of "Header: value" lines instead. The layout is fixed by
+// the first rows written, so later batches of a streamed listing stay aligned.
+type table struct {
+	Columns []tableColumn
+	// Width is the terminal width; 0 when the output is not a terminal, in which case
+	// nothing is truncated
+	Width int
+	// NoTruncate keeps every value whole, stacking rows that do not fit the terminal
+	NoTruncate bool
+	Separator  string
+	// HeaderColor and DividerColor style the header lines; fatih/color drops styling
+	// when NO_COLOR is set or the output is not a terminal
+	HeaderColor  *color.Color
+	DividerColor *color.Color
+
+	widths  []int
+	stacked bool
+	started bool
+}
+
+// terminalWidth returns the width of the terminal f writes to, or 0 when f is not a terminal
+func terminalWidth(f *os.File) int {
+	if !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// Write writes rows, preceded by the header the first time it is called
+func (t *table) Write(w io.Writer, rows [][]string) {
+	if !t.started {
+		t.layout(rows)
+		t.started = true
+		if !t.stacked {
+			t.writeHeader(w)
+		}
+	}
+	for _, row := range rows {
+		if t.stacked {
+			t.writeStacked(w, row)
+			continue
+		}
+		cells := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			cells[i] = t.cell(row, i)
+		}
+		fmt.Fprintln(w, strings.TrimRight(strings.Join(cells, t.Separator), " "))
+	}
+}
+
+// layout sizes each column to its widest value, then shrinks or stacks to fit Width
+func (t *table) layout(rows [][]string) {
+	t.widths = make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		t.widths[i] = len(column.Header)
+		for _, row := range rows {
+			if i < len(row) && len(row[i]) > t.widths[i] {
+				t.widths[i] = len(row[i])
+			}
+		}
+	}
+	if t.Width <= 0 {
+		return
+	}
+
+	over := len(t.Separator)*(len(t.Columns)-1) - t.Width
+	for _, width := range t.widths {
+		over += width
+	}
+	if over > 0 && !t.NoTruncate {
+		// Take from the widest truncatable column first, one character at a time
+		for over > 0 {
+			widest := -1
+			for i, column := range t.Columns {
+				if column.Min > 0 && t.widths[i] > max(column.Min, len(column.Header)) && (widest < 0 || t.widths[i] > t.widths[widest]) {
+					widest = i
+				}
+			}
+			if widest < 0 {
+				break
+			}
+			t.widths[widest]--
+			over--
+		}
+	}
+	t.stacked = over > 0
+}
+
+func (t *table) cell(row []string, i int) string {
+	value := ""
+	if i < len(row) {
+		value = row[i]
+	}
+	if t.Width > 0 && !t.NoTruncate && t.Columns[i].Min > 0 {
+		value = truncateWithEllipsis(value, t.widths[i])
+	}
+	return fmt.Sprintf("%-*s", t.widths[i], value)
+}
+
+func (t *table) writeHeader(w io.Writer) {
+	headers := make([]string, len(t.Columns))
+	dividers := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		headers[i] = fmt.Sprintf("%-*s", t.widths[i], column.Header)
+		dividers[i] = strings.Repeat("-", t.widths[i])
+	}
+	printLine(w, t.HeaderColor, strings.TrimRight(strings.Join(headers, t.Separator), " "))
+	printLine(w, t.DividerColor, strings.Join(dividers, t.Separator))
+}
+
+func (t *table) writeStacked(w io.Writer, row []string) {
+	label := 0
+	for _, column := range t.Columns {
+		label = max(label, len(column.Header)+1)
+	}
+	for i, column := range t.Columns {
+		value := ""
+		if i < len(row) {
+			value = row[i]
+		}
+		fmt.Fprintf(w, "%-*s %s\n", label, column.Header+":", value)
+	}
+	printLine(w, t.DividerColor, strings.Repeat("-", min(32, max(t.Width, 1))))
+}
+
+func printLine(w io.Writer, c *color.Color, line string) {
+	if c == nil {
+		fmt.Fprintln(w, line)
+		return
+	}
+	c.Fprintln(w, line)
+}
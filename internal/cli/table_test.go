@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	rows := [][]string{
+		{"a1", "Payments Gateway Service", "/payments/"},
+		{"b2", "Users", "/users/"},
+	}
+	render := func(tbl *table) string {
+		var buf bytes.Buffer
+		tbl.Write(&buf, rows)
+		return buf.String()
+	}
+
+	// Off a terminal columns fit their content and nothing is truncated
+	tbl := &table{Columns: interactiveAPIColumns, Separator: "  "}
+	assert.Equal(t, "ID  Name                      Listen Path\n"+
+		"--  ------------------------  -----------\n"+
+		"a1  Payments Gateway Service  /payments/\n"+
+		"b2  Users                     /users/\n", render(tbl))
+
+	// Narrow terminals shorten names only
+	tbl = &table{Columns: interactiveAPIColumns, Separator: "  ", Width: 35}
+	assert.Equal(t, "ID  Name                Listen Path\n"+
+		"--  ------------------  -----------\n"+
+		"a1  Payments Gatewa...  /payments/\n"+
+		"b2  Users               /users/\n", render(tbl))
+
+	// Rows that cannot fit, or must not be truncated, are stacked
+	for _, tbl := range []*table{
+		{Columns: interactiveAPIColumns, Separator: "  ", Width: 25},
+		{Columns: interactiveAPIColumns, Separator: "  ", Width: 35, NoTruncate: true},
+	} {
+		out := render(tbl)
+		assert.Contains(t, out, "ID:          a1\nName:        Payments Gateway Service\nListen Path: /payments/\n")
+		assert.NotContains(t, out, "...")
+	}
+
+	// Later batches keep the first batch's layout and get no second header
+	tbl = &table{Columns: interactiveAPIColumns, Separator: "  "}
+	var buf bytes.Buffer
+	tbl.Write(&buf, rows[1:])
+	tbl.Write(&buf, [][]string{{"c3", "Orders", "/orders/"}})
+	assert.Equal(t, "ID  Name   Listen Path\n--  -----  -----------\nb2  Users  /users/\nc3  Orders  /orders/\n", buf.String())
+}
+
+func TestColumnZeroWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &columnZeroWriter{w: &buf}
+	w.Write([]byte("one\ntw"))
+	w.Write([]byte("o\n"))
+	assert.Equal(t, "\x1b[0Gone\n\x1b[0Gtwo\n", buf.String())
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Who rejected a spec, in a validation report
+const (
+	rejectedByValidation = "validation"
+	rejectedByServer     = "server"
+)
+
+// validationReport lists every problem found in a rejected spec, so its author can fix
+// them all in one pass rather than one upload at a time
+type validationReport struct {
+	Source string `json:"source" yaml:"source"`
+	API    string `json:"api,omitempty" yaml:"api,omitempty"`
+	// RejectedBy is validation when the spec failed the checks made before uploading,
+	// or server when the Dashboard or Gateway refused it
+	RejectedBy  string             `json:"rejected_by" yaml:"rejected_by"`
+	ServerError string             `json:"server_error,omitempty" yaml:"server_error,omitempty"`
+	Errors      int                `json:"errors" yaml:"errors"`
+	Warnings    int                `json:"warnings" yaml:"warnings"`
+	Diagnostics []reportDiagnostic `json:"diagnostics" yaml:"diagnostics"`
+}
+
+// reportDiagnostic is a problem of a validation report, positioned in the source file
+// when the spec was read from one
+type reportDiagnostic struct {
+	Severity string   `json:"severity" yaml:"severity"`
+	Path     []string `json:"path" yaml:"path"`
+	Message  string   `json:"message" yaml:"message"`
+	Line     int      `json:"line,omitempty" yaml:"line,omitempty"`
+	Column   int      `json:"column,omitempty" yaml:"column,omitempty"`
+}
+
+// addValidationReportFlag adds --validation-report to a command uploading a spec
+func addValidationReportFlag(cmd *cobra.Command) {
+	cmd.Flags().String("validation-report", "", "If the spec is rejected, write every problem found in it to this file (YAML for .yaml/.yml, JSON otherwise)")
+}
+
+// validateBeforeUpload checks doc when --validation-report is given, and fails with the
+// report written when it has errors. Without the flag the server has the only say.
+func validateBeforeUpload(cmd *cobra.Command, doc map[string]interface{}) error {
+	path, _ := cmd.Flags().GetString("validation-report")
+	if path == "" {
+		return nil
+	}
+	report := newValidationReport(cmd, doc, rejectedByValidation)
+	if report.Errors == 0 {
+		return nil
+	}
+	if err := writeValidationReport(path, report); err != nil {
+		return err
+	}
+	var first reportDiagnostic
+	for _, d := range report.Diagnostics {
+		if d.Severity == oas.SeverityError {
+			first = d
+			break
+		}
+	}
+	more := ""
+	if report.Errors > 1 {
+		more = fmt.Sprintf(" and %d more error(s)", report.Errors-1)
+	}
+	return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("%s: %s%s; every problem is listed in %s", report.Source, first.Message, more, path)}
+}
+
+// uploadError describes a failed upload like wrapAPIError. When the server rejected the
+// spec and --validation-report is given, the report is written and referenced.
+func uploadError(cmd *cobra.Command, doc map[string]interface{}, err error, action string) error {
+	path, _ := cmd.Flags().GetString("validation-report")
+	var resp *types.ErrorResponse
+	if path == "" || !errors.As(err, &resp) || errors.Is(err, types.ErrNotFound) ||
+		(resp.Status != http.StatusBadRequest && resp.Status != http.StatusUnprocessableEntity) {
+		return wrapAPIError(err, action)
+	}
+
+	report := newValidationReport(cmd, doc, rejectedByServer)
+	report.ServerError = resp.Message
+	report.Errors++
+	if writeErr := writeValidationReport(path, report); writeErr != nil {
+		return fmt.Errorf("%s: %w (%v)", action, err, writeErr)
+	}
+	return fmt.Errorf("%s: %w; the validation report in %s lists %d problem(s)", action, err, path, report.Errors+report.Warnings)
+}
+
+// newValidationReport validates doc, positioning the diagnostics in the source file
+// when the spec was read from one
+func newValidationReport(cmd *cobra.Command, doc map[string]interface{}, rejectedBy string) *validationReport {
+	report := &validationReport{Source: specSource(cmd), API: oas.GetAPIName(doc), RejectedBy: rejectedBy, Diagnostics: []reportDiagnostic{}}
+
+	var root *yaml.Node
+	if data, err := os.ReadFile(report.Source); err == nil {
+		var node yaml.Node
+		if yaml.Unmarshal(data, &node) == nil {
+			root = &node
+		}
+	}
+	for _, d := range oas.Validate(doc) {
+		diagnostic := reportDiagnostic{Severity: d.Severity, Path: d.Path, Message: d.Message}
+		if root != nil {
+			diagnostic.Line, diagnostic.Column = locateYAMLPath(root, d.Path)
+		}
+		if d.Severity == oas.SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+		report.Diagnostics = append(report.Diagnostics, diagnostic)
+	}
+	return report
+}
+
+// specSource names where the command read its spec from
+func specSource(cmd *cobra.Command) string {
+	for _, flag := range []string{"file", "url"} {
+		if f := cmd.Flags().Lookup(flag); f != nil && f.Value.String() != "" {
+			if f.Value.String() == "-" {
+				return "stdin"
+			}
+			return f.Value.String()
+		}
+	}
+	return "spec"
+}
+
+func writeValidationReport(path string, report *validationReport) error {
+	var data []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(report)
+	default:
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to write validation report: %v", err)}
+	}
+	return nil
+}
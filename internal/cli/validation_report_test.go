@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/filehandler"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// writeEnhancedSpec writes a Tyk-enhanced spec, letting edit break it first
+func writeEnhancedSpec(t *testing.T, dir string, edit func(doc map[string]interface{})) string {
+	t.Helper()
+	doc, err := loadOASFromFile(writePlanSpec(t, t.TempDir(), "users", "1.0.0"))
+	require.NoError(t, err)
+	doc, err = oas.AddTykExtensions(doc)
+	require.NoError(t, err)
+	if edit != nil {
+		edit(doc)
+	}
+	path := filepath.Join(dir, "users.yaml")
+	require.NoError(t, filehandler.SaveFile(path, doc))
+	return path
+}
+
+func TestValidationReport_LocalValidation(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	dir := t.TempDir()
+	spec := writeEnhancedSpec(t, dir, func(doc map[string]interface{}) {
+		oas.SetListenPath(doc, "users")
+		delete(doc["x-tyk-api-gateway"].(map[string]interface{}), "upstream")
+		delete(doc, "paths")
+	})
+	reportPath := filepath.Join(dir, "report.yaml")
+
+	_, err := runRootCommand(t, "api", "apply", "--file", spec, "--validation-report", reportPath)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 2, exitErr.Code)
+	assert.Contains(t, exitErr.Message, "and 2 more error(s); every problem is listed in "+reportPath)
+	assert.Equal(t, 0, dashboard.count())
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report validationReport
+	require.NoError(t, yaml.Unmarshal(data, &report))
+	assert.Equal(t, rejectedByValidation, report.RejectedBy)
+	assert.Equal(t, spec, report.Source)
+	assert.Equal(t, 3, report.Errors)
+	for _, d := range report.Diagnostics {
+		assert.Positive(t, d.Line, d.Message)
+	}
+
+	// Without the flag the spec goes to the Dashboard as before
+	_, err = runRootCommand(t, "api", "apply", "--file", writeEnhancedSpec(t, t.TempDir(), nil), "--validation-report", reportPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dashboard.count())
+}
+
+func TestValidationReport_ServerRejection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{"Status": "Error", "Message": "x-tyk-api-gateway.middleware: unknown field"})
+	}))
+	t.Cleanup(server.Close)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+	dir := t.TempDir()
+	spec := writeEnhancedSpec(t, dir, nil)
+	reportPath := filepath.Join(dir, "report.json")
+
+	_, err := runRootCommand(t, "api", "import-oas", "--file", spec, "--validation-report", reportPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the validation report in "+reportPath)
+
+	data, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+	var report validationReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, rejectedByServer, report.RejectedBy)
+	assert.Contains(t, report.ServerError, "x-tyk-api-gateway.middleware: unknown field")
+	assert.Equal(t, 1, report.Errors)
+	assert.Equal(t, "users", report.API)
+}
@@ -0,0 +1,502 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/internal/client"
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"github.com/tyktech/tyk-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// webhookMethods are the HTTP methods a Dashboard webhook can call its target with
+var webhookMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// webhookFile is the file 'tyk webhook apply' reads
+type webhookFile struct {
+	Webhooks []*types.Webhook `yaml:"webhooks"`
+}
+
+// appliedWebhook is one webhook created, updated, deleted or left alone by 'tyk webhook apply'
+type appliedWebhook struct {
+	Action    string `json:"action"`
+	Name      string `json:"name"`
+	WebhookID string `json:"webhook_id,omitempty"`
+
+	hook *types.Webhook
+}
+
+// NewWebhookCommand creates the 'tyk webhook' command and its subcommands
+func NewWebhookCommand() *cobra.Command {
+	webhookCmd := &cobra.Command{
+		Use:     "webhook",
+		Aliases: []string{"webhooks"},
+		Short:   "Manage Dashboard webhooks",
+		Long: `Commands for managing the Dashboard webhooks that API event handlers call, such as
+alerts on quota exhaustion or breaker trips.`,
+	}
+
+	webhookCmd.AddCommand(NewWebhookListCommand())
+	webhookCmd.AddCommand(NewWebhookGetCommand())
+	webhookCmd.AddCommand(NewWebhookCreateCommand())
+	webhookCmd.AddCommand(NewWebhookDeleteCommand())
+	webhookCmd.AddCommand(NewWebhookApplyCommand())
+
+	return webhookCmd
+}
+
+// NewWebhookListCommand creates the 'tyk webhook list' command
+func NewWebhookListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List webhooks",
+		Args:  cobra.NoArgs,
+		RunE:  runWebhookList,
+	}
+}
+
+// NewWebhookGetCommand creates the 'tyk webhook get' command
+func NewWebhookGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <webhook-id | name>",
+		Short: "Show a webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWebhookGet,
+	}
+}
+
+// NewWebhookCreateCommand creates the 'tyk webhook create' command
+func NewWebhookCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a webhook",
+		Long: `Create a Dashboard webhook that event handlers can reference by ID.
+
+Examples:
+  tyk webhook create --name "Quota alerts" --url https://alerts.example.com/tyk
+  tyk webhook create --name Slack --url https://hooks.slack.com/services/T0/B0/X --header "X-Source: tyk" --timeout 10`,
+		Args: cobra.NoArgs,
+		RunE: runWebhookCreate,
+	}
+
+	cmd.Flags().String("name", "", "Webhook name (required)")
+	cmd.Flags().String("url", "", "Target URL the webhook calls (required)")
+	cmd.Flags().String("method", http.MethodPost, "HTTP method: "+strings.Join(webhookMethods, ", "))
+	cmd.Flags().StringArray("header", nil, "Header sent with each call as 'Name: value'; repeat for several")
+	cmd.Flags().String("template", "", "Path of the body template on the Gateway")
+	cmd.Flags().Int64("timeout", 0, "Seconds before the same event can fire the webhook again")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+// NewWebhookDeleteCommand creates the 'tyk webhook delete' command
+func NewWebhookDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <webhook-id | name>",
+		Short: "Delete a webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runWebhookDelete,
+	}
+
+	return cmd
+}
+
+// NewWebhookApplyCommand creates the 'tyk webhook apply' command
+func NewWebhookApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply -f <file>",
+		Short: "Create or update webhooks from a file",
+		Long: `Make the Dashboard's webhooks match a file kept in version control.
+
+Webhooks are matched by name. Missing ones are created, changed ones updated and the
+rest left alone; with --prune, webhooks the file does not name are deleted.
+
+Example webhooks.yaml:
+  webhooks:
+    - name: Quota alerts
+      method: POST                       # optional, this is the default
+      target_path: https://alerts.example.com/tyk
+      header_map:
+        X-Source: tyk
+      event_timeout: 10                  # optional
+
+Examples:
+  tyk webhook apply -f webhooks.yaml --dry-run
+  tyk webhook apply -f webhooks.yaml --prune -o json`,
+		Args: cobra.NoArgs,
+		RunE: runWebhookApply,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Webhook file (required)")
+	cmd.Flags().Bool("prune", false, "Delete webhooks the file does not name")
+	cmd.Flags().Bool("dry-run", false, "Show what would change without changing anything")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runWebhookList(cmd *cobra.Command, args []string) error {
+	ctx, c, cancel, err := dashboardClient(cmd, "webhooks")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	hooks, err := c.ListWebhooks(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list webhooks")
+	}
+	if hooks == nil {
+		hooks = []*types.Webhook{}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{"webhooks": hooks})
+	}
+	if len(hooks) == 0 {
+		fmt.Println("No webhooks found.")
+		return nil
+	}
+	tbl := &table{
+		Columns:   []tableColumn{{Header: "ID"}, {Header: "Name", Min: 10}, {Header: "Method"}, {Header: "Target", Min: 20}},
+		Width:     terminalWidth(os.Stdout),
+		Separator: "  ",
+	}
+	rows := make([][]string, len(hooks))
+	for i, hook := range hooks {
+		rows[i] = []string{hook.ID, hook.Name, hook.Method, hook.TargetPath}
+	}
+	tbl.Write(os.Stdout, rows)
+	return nil
+}
+
+func runWebhookGet(cmd *cobra.Command, args []string) error {
+	ctx, c, cancel, err := dashboardClient(cmd, "webhooks")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	hook, err := findWebhook(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, hook)
+	}
+	printWebhook(hook)
+	return nil
+}
+
+func runWebhookCreate(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	target, _ := cmd.Flags().GetString("url")
+	method, _ := cmd.Flags().GetString("method")
+	rawHeaders, _ := cmd.Flags().GetStringArray("header")
+	template, _ := cmd.Flags().GetString("template")
+	timeout, _ := cmd.Flags().GetInt64("timeout")
+
+	hook := &types.Webhook{
+		Name:         name,
+		Method:       strings.ToUpper(method),
+		TargetPath:   target,
+		TemplatePath: template,
+		HeaderMap:    map[string]string{},
+		EventTimeout: timeout,
+	}
+	for _, raw := range rawHeaders {
+		header, err := oas.ParseHeader(raw)
+		if err != nil {
+			return &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid --header: %v", err)}
+		}
+		hook.HeaderMap[header.Name] = header.Value
+	}
+	if err := checkWebhook(hook); err != nil {
+		return &ExitError{Code: int(types.ExitBadArgs), Message: err.Error()}
+	}
+
+	ctx, c, cancel, err := dashboardClient(cmd, "webhooks")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	id, err := c.CreateWebhook(ctx, hook)
+	if err != nil {
+		return wrapAPIError(err, fmt.Sprintf("failed to create webhook '%s'", name))
+	}
+	hook.ID = id
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, hook)
+	}
+	color.New(color.FgGreen).Printf("✓ Webhook created: %s (%s)\n", hook.Name, hook.ID)
+	return nil
+}
+
+func runWebhookDelete(cmd *cobra.Command, args []string) error {
+	skipConfirmation := assumeYes(cmd)
+
+	ctx, c, cancel, err := dashboardClient(cmd, "webhooks")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	hook, err := findWebhook(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+
+	if !skipConfirmation {
+		ok, err := confirm(cmd, fmt.Sprintf("Are you sure you want to delete webhook '%s' (%s)?", hook.Name, hook.ID))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Delete operation cancelled")
+			return nil
+		}
+	}
+
+	if err := c.DeleteWebhook(ctx, hook.ID); err != nil {
+		return wrapAPIError(err, "failed to delete webhook")
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"webhook_id": hook.ID,
+			"operation":  "deleted",
+			"success":    true,
+		})
+	}
+	color.New(color.FgGreen).Printf("✓ Webhook deleted: %s (%s)\n", hook.Name, hook.ID)
+	return nil
+}
+
+func runWebhookApply(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	prune, _ := cmd.Flags().GetBool("prune")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	desired, err := loadWebhookFile(path)
+	if err != nil {
+		return err
+	}
+
+	ctx, c, cancel, err := dashboardClient(cmd, "webhooks")
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	existing, err := c.ListWebhooks(ctx)
+	if err != nil {
+		return wrapAPIError(err, "failed to list webhooks")
+	}
+	byName := make(map[string]*types.Webhook, len(existing))
+	for _, hook := range existing {
+		byName[hook.Name] = hook
+	}
+
+	results := []*appliedWebhook{}
+	for _, hook := range desired {
+		result := &appliedWebhook{Action: planCreate, Name: hook.Name, hook: hook}
+		if current, ok := byName[hook.Name]; ok {
+			result.WebhookID = current.ID
+			hook.ID, hook.OrgID = current.ID, current.OrgID
+			result.Action = planUpdate
+			if webhookMatches(current, hook) {
+				result.Action = planNoChange
+			}
+		}
+		results = append(results, result)
+	}
+	if prune {
+		for _, hook := range existing {
+			if !slices.ContainsFunc(desired, func(d *types.Webhook) bool { return d.Name == hook.Name }) {
+				results = append(results, &appliedWebhook{Action: planDelete, Name: hook.Name, WebhookID: hook.ID})
+			}
+		}
+	}
+
+	if !dryRun {
+		for _, result := range results {
+			switch result.Action {
+			case planCreate:
+				id, err := c.CreateWebhook(ctx, result.hook)
+				if err != nil {
+					return wrapAPIError(err, fmt.Sprintf("failed to create webhook '%s'", result.Name))
+				}
+				result.WebhookID = id
+			case planUpdate:
+				if err := c.UpdateWebhook(ctx, result.WebhookID, result.hook); err != nil {
+					return wrapAPIError(err, fmt.Sprintf("failed to update webhook '%s'", result.Name))
+				}
+			case planDelete:
+				if err := c.DeleteWebhook(ctx, result.WebhookID); err != nil {
+					return wrapAPIError(err, fmt.Sprintf("failed to delete webhook '%s'", result.Name))
+				}
+			}
+		}
+	}
+
+	summary := map[string]int{planCreate: 0, planUpdate: 0, planDelete: 0, planNoChange: 0}
+	for _, result := range results {
+		summary[result.Action]++
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, map[string]interface{}{
+			"file":     path,
+			"dry_run":  dryRun,
+			"webhooks": results,
+			"summary":  summary,
+		})
+	}
+	printAppliedWebhooks(results, summary, dryRun)
+	return nil
+}
+
+// findWebhook resolves a webhook by ID, falling back to its name
+func findWebhook(ctx context.Context, c *client.Client, ref string) (*types.Webhook, error) {
+	hook, err := c.GetWebhook(ctx, ref)
+	if err == nil {
+		return hook, nil
+	}
+	if !errors.Is(err, client.ErrNotFound) {
+		return nil, wrapAPIError(err, "failed to get webhook")
+	}
+
+	hooks, listErr := c.ListWebhooks(ctx)
+	if listErr != nil {
+		return nil, wrapAPIError(listErr, "failed to list webhooks")
+	}
+	var matches []*types.Webhook
+	for _, candidate := range hooks {
+		if candidate.Name == ref {
+			matches = append(matches, candidate)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, notFoundError(err, fmt.Sprintf("webhook '%s' not found", ref))
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, &ExitError{Code: int(types.ExitConflict), Message: fmt.Sprintf("%d webhooks are named '%s'; use the webhook ID", len(matches), ref)}
+	}
+}
+
+// loadWebhookFile reads and validates the webhooks of a 'tyk webhook apply' file
+func loadWebhookFile(path string) ([]*types.Webhook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to read webhook file: %v", err)}
+	}
+	var file webhookFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("failed to parse webhook file: %v", err)}
+	}
+
+	seen := make(map[string]bool)
+	for i, hook := range file.Webhooks {
+		if hook.Method == "" {
+			hook.Method = http.MethodPost
+		}
+		hook.Method = strings.ToUpper(hook.Method)
+		if hook.HeaderMap == nil {
+			hook.HeaderMap = map[string]string{}
+		}
+		hook.ID, hook.OrgID = "", ""
+		err := checkWebhook(hook)
+		if err == nil && seen[hook.Name] {
+			err = fmt.Errorf("webhook '%s' is defined more than once", hook.Name)
+		}
+		if err != nil {
+			return nil, &ExitError{Code: int(types.ExitBadArgs), Message: fmt.Sprintf("invalid webhook file %s: webhook %d: %v", path, i+1, err)}
+		}
+		seen[hook.Name] = true
+	}
+	return file.Webhooks, nil
+}
+
+// checkWebhook rejects a webhook the Dashboard could not call
+func checkWebhook(hook *types.Webhook) error {
+	if strings.TrimSpace(hook.Name) == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	if parsed, err := url.Parse(hook.TargetPath); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("target '%s' must be an absolute http(s) URL", hook.TargetPath)
+	}
+	if !slices.Contains(webhookMethods, hook.Method) {
+		return fmt.Errorf("method '%s' must be one of %s", hook.Method, strings.Join(webhookMethods, ", "))
+	}
+	if hook.EventTimeout < 0 {
+		return fmt.Errorf("event_timeout must not be negative")
+	}
+	return nil
+}
+
+// webhookMatches reports whether an existing webhook already calls the desired target the desired way
+func webhookMatches(current, desired *types.Webhook) bool {
+	return current.Method == desired.Method && current.TargetPath == desired.TargetPath &&
+		current.TemplatePath == desired.TemplatePath && current.EventTimeout == desired.EventTimeout &&
+		maps.Equal(current.HeaderMap, desired.HeaderMap)
+}
+
+func printWebhook(hook *types.Webhook) {
+	fmt.Printf("ID:       %s\n", hook.ID)
+	fmt.Printf("Name:     %s\n", hook.Name)
+	fmt.Printf("Method:   %s\n", hook.Method)
+	fmt.Printf("Target:   %s\n", hook.TargetPath)
+	if hook.TemplatePath != "" {
+		fmt.Printf("Template: %s\n", hook.TemplatePath)
+	}
+	if hook.EventTimeout > 0 {
+		fmt.Printf("Timeout:  %ds\n", hook.EventTimeout)
+	}
+	if len(hook.HeaderMap) > 0 {
+		fmt.Println("Headers:")
+		for _, name := range slices.Sorted(maps.Keys(hook.HeaderMap)) {
+			fmt.Printf("  %s: %s\n", name, hook.HeaderMap[name])
+		}
+	}
+}
+
+func printAppliedWebhooks(results []*appliedWebhook, summary map[string]int, dryRun bool) {
+	symbols := map[string]*color.Color{
+		planCreate:   color.New(color.FgGreen),
+		planUpdate:   color.New(color.FgYellow),
+		planDelete:   color.New(color.FgRed),
+		planNoChange: color.New(color.FgHiBlack),
+	}
+	marks := map[string]string{planCreate: "+", planUpdate: "~", planDelete: "-", planNoChange: "="}
+
+	for _, result := range results {
+		label := result.Name
+		if result.WebhookID != "" {
+			label = fmt.Sprintf("%s (%s)", result.Name, result.WebhookID)
+		}
+		symbols[result.Action].Printf("  %s %-9s %s\n", marks[result.Action], result.Action, label)
+	}
+
+	if dryRun {
+		fmt.Printf("\nDry run: %d to create, %d to update, %d to delete, %d unchanged.\n", summary[planCreate], summary[planUpdate], summary[planDelete], summary[planNoChange])
+		return
+	}
+	fmt.Printf("\nWebhooks: %d created, %d updated, %d deleted, %d unchanged.\n", summary[planCreate], summary[planUpdate], summary[planDelete], summary[planNoChange])
+}
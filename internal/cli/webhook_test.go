@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestWebhookCommands(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "webhook", "create", "--name", "Quota alerts", "--url", "https://alerts.example.com/tyk", "--header", "X-Source: tyk", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("webhook", out), string(out))
+	var created types.Webhook
+	require.NoError(t, json.Unmarshal(out, &created))
+	require.Contains(t, dashboard.hooks, created.ID)
+	assert.Equal(t, "POST", dashboard.hooks[created.ID].Method)
+	assert.Equal(t, map[string]string{"X-Source": "tyk"}, dashboard.hooks[created.ID].HeaderMap)
+
+	out, err = runRootCommand(t, "webhook", "list", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("webhook-list", out), string(out))
+
+	// get and delete accept the name as well as the ID
+	out, err = runRootCommand(t, "webhook", "get", "Quota alerts", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("webhook", out), string(out))
+
+	out, err = runRootCommand(t, "webhook", "delete", "Quota alerts", "-y", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("webhook-delete", out), string(out))
+	assert.Empty(t, dashboard.hooks)
+
+	_, err = runRootCommand(t, "webhook", "get", "missing")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitNotFound), exitErr.Code)
+
+	_, err = runRootCommand(t, "webhook", "create", "--name", "bad", "--url", "alerts.example.com")
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
+
+func TestWebhookApply(t *testing.T) {
+	dashboard, server := newFakeDashboard(t)
+	dashboard.hooks["hook-old"] = &types.Webhook{ID: "hook-old", Name: "Legacy", Method: "POST", TargetPath: "https://legacy.example.com"}
+	dashboard.hooks["hook-ops"] = &types.Webhook{ID: "hook-ops", Name: "Ops", Method: "POST", TargetPath: "https://ops.example.com"}
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "test",
+		Environments: map[string]*types.Environment{
+			"test": {Name: "test", DashboardURL: server.URL, AuthToken: "token", OrgID: "org"},
+		},
+	})
+
+	file := filepath.Join(t.TempDir(), "webhooks.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`webhooks:
+  - name: Ops
+    target_path: https://ops.example.com/v2
+    header_map:
+      X-Source: tyk
+  - name: Quota alerts
+    method: put
+    target_path: https://alerts.example.com/tyk
+`), 0644))
+
+	out, err := runRootCommand(t, "webhook", "apply", "-f", file, "--prune", "--dry-run", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("webhook-apply", out), string(out))
+	assert.Len(t, dashboard.hooks, 2)
+
+	out, err = runRootCommand(t, "webhook", "apply", "-f", file, "--prune", "-o", "json")
+	require.NoError(t, err)
+	var result struct {
+		Webhooks []appliedWebhook `json:"webhooks"`
+		Summary  map[string]int   `json:"summary"`
+	}
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, map[string]int{planCreate: 1, planUpdate: 1, planDelete: 1, planNoChange: 0}, result.Summary)
+	assert.Equal(t, "https://ops.example.com/v2", dashboard.hooks["hook-ops"].TargetPath)
+	assert.NotContains(t, dashboard.hooks, "hook-old")
+	require.Len(t, dashboard.hooks, 2)
+
+	// A second run has nothing to do
+	out, err = runRootCommand(t, "webhook", "apply", "-f", file, "--prune", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, map[string]int{planCreate: 0, planUpdate: 0, planDelete: 0, planNoChange: 2}, result.Summary)
+
+	require.NoError(t, os.WriteFile(file, []byte("webhooks:\n  - name: Ops\n    target_path: ops\n"), 0644))
+	_, err = runRootCommand(t, "webhook", "apply", "-f", file)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitBadArgs), exitErr.Code)
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewWhoamiCommand creates the 'tyk whoami' command
+func NewWhoamiCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "whoami",
+		Short: "Show which environment, user and organisation the CLI is acting as",
+		Long: `Verify the active credentials and show who they belong to: the environment and
+URL in use, the masked token, and the Dashboard user with their organisation and
+permissions. Run it before destructive operations to confirm where they will land.
+
+For gateway environments only the secret is verified.
+
+Examples:
+  tyk whoami
+  tyk whoami --env prod
+  tyk whoami -o json`,
+		Args: cobra.NoArgs,
+		RunE: runWhoami,
+	}
+}
+
+// whoamiUser is the user section of 'tyk whoami' output
+type whoamiUser struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Email       string            `json:"email"`
+	OrgID       string            `json:"org_id"`
+	Active      bool              `json:"active"`
+	Admin       bool              `json:"admin"`
+	Permissions map[string]string `json:"permissions"`
+}
+
+// whoamiResult is the output of 'tyk whoami'
+type whoamiResult struct {
+	Environment string      `json:"environment"`
+	Type        string      `json:"type"`
+	URL         string      `json:"url"`
+	Token       string      `json:"token"`
+	OrgID       string      `json:"org_id,omitempty"`
+	User        *whoamiUser `json:"user"`
+	Warnings    []string    `json:"warnings"`
+}
+
+func runWhoami(cmd *cobra.Command, args []string) error {
+	config := GetConfigFromContext(cmd.Context())
+	if config == nil {
+		return fmt.Errorf("configuration not found")
+	}
+	env, err := config.GetActiveEnvironment()
+	if err != nil {
+		return err
+	}
+
+	c, err := commandClient(cmd.Context(), config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := apiContext(config, 30*time.Second)
+	defer cancel()
+
+	result := &whoamiResult{
+		Environment: env.Name,
+		Type:        types.EnvTypeDashboard,
+		URL:         env.ManagementURL(),
+		Token:       maskToken(env.AuthToken),
+		OrgID:       env.OrgID,
+		Warnings:    []string{},
+	}
+
+	if c.IsGateway() {
+		result.Type = types.EnvTypeGateway
+		if _, err := c.ListAllAPIs(ctx); err != nil {
+			return wrapAPIError(err, "gateway secret rejected")
+		}
+	} else {
+		user, err := c.CurrentUser(ctx)
+		var apiErr *types.ErrorResponse
+		switch {
+		case errors.As(err, &apiErr) && apiErr.Status == http.StatusForbidden:
+			// Authenticated, but not allowed to list users
+			result.Warnings = append(result.Warnings, "token is valid but lacks permission to look up its user")
+		case err != nil:
+			return wrapAPIError(err, "failed to verify credentials")
+		case user == nil:
+			result.Warnings = append(result.Warnings, "token is valid but matches no user in the organisation")
+		default:
+			result.User = &whoamiUser{
+				ID:          user.ID,
+				Name:        user.Name(),
+				Email:       user.Email,
+				OrgID:       user.OrgID,
+				Active:      user.Active,
+				Admin:       user.IsAdmin(),
+				Permissions: user.Permissions,
+			}
+			if result.User.Permissions == nil {
+				result.User.Permissions = map[string]string{}
+			}
+			if env.OrgID != "" && user.OrgID != env.OrgID {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("configured org_id %s does not match the user's organisation %s", env.OrgID, user.OrgID))
+			}
+			if !user.Active {
+				result.Warnings = append(result.Warnings, "user account is inactive")
+			}
+		}
+	}
+
+	if format := GetOutputFormatFromContext(cmd.Context()); format.IsStructured() {
+		return writeStructured(format, result)
+	}
+	printWhoami(result)
+	return nil
+}
+
+func printWhoami(result *whoamiResult) {
+	cyan := color.New(color.FgCyan)
+	color.New(color.FgGreen, color.Bold).Println("✓ Credentials accepted")
+	cyan.Printf("  Environment:  %s (%s)\n", result.Environment, result.Type)
+	cyan.Printf("  URL:          %s\n", result.URL)
+	cyan.Printf("  Token:        %s (no expiry; valid until reset)\n", result.Token)
+	if user := result.User; user != nil {
+		cyan.Printf("  User:         %s <%s>\n", user.Name, user.Email)
+		cyan.Printf("  User ID:      %s\n", user.ID)
+		cyan.Printf("  Organisation: %s\n", user.OrgID)
+		cyan.Printf("  Permissions:  %s\n", formatPermissions(user))
+	} else if result.OrgID != "" {
+		cyan.Printf("  Organisation: %s (from config)\n", result.OrgID)
+	}
+	for _, warning := range result.Warnings {
+		color.New(color.FgYellow).Fprintf(os.Stderr, "⚠ %s\n", warning)
+	}
+}
+
+// formatPermissions lists a user's permissions as "object: level", or "admin"
+func formatPermissions(user *whoamiUser) string {
+	if user.Admin {
+		return "admin (all)"
+	}
+	if len(user.Permissions) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(user.Permissions))
+	for object, level := range user.Permissions {
+		parts = append(parts, object+": "+level)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
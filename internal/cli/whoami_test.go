@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/outputschema"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func newUsersServer(t *testing.T, status int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/users", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"Status":"Error","Message":"access denied"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"users": []map[string]interface{}{
+			{"id": "u-1", "first_name": "Other", "email_address": "other@example.com", "org_id": "org", "access_key": "someone-else"},
+			{"id": "u-2", "first_name": "Jane", "last_name": "Doe", "email_address": "jane@example.com", "org_id": "other-org",
+				"active": true, "access_key": "token-1234567890", "user_permissions": map[string]string{"apis": "write", "keys": "read"}},
+		}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWhoami(t *testing.T) {
+	server := newUsersServer(t, http.StatusOK)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "prod",
+		Environments: map[string]*types.Environment{
+			"prod": {Name: "prod", DashboardURL: server.URL, AuthToken: "token-1234567890", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "whoami", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("whoami", out), string(out))
+
+	var result whoamiResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, "prod", result.Environment)
+	assert.Equal(t, "toke****7890", result.Token)
+	require.NotNil(t, result.User)
+	assert.Equal(t, "u-2", result.User.ID)
+	assert.Equal(t, "Jane Doe", result.User.Name)
+	assert.False(t, result.User.Admin)
+	assert.Equal(t, "apis: write, keys: read", formatPermissions(result.User))
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "does not match")
+	assert.NotContains(t, string(out), "token-1234567890")
+}
+
+func TestWhoami_Forbidden(t *testing.T) {
+	server := newUsersServer(t, http.StatusForbidden)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "prod",
+		Environments: map[string]*types.Environment{
+			"prod": {Name: "prod", DashboardURL: server.URL, AuthToken: "token-1234567890", OrgID: "org"},
+		},
+	})
+
+	out, err := runRootCommand(t, "whoami", "-o", "json")
+	require.NoError(t, err)
+	require.NoError(t, outputschema.Validate("whoami", out), string(out))
+	var result whoamiResult
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Nil(t, result.User)
+	assert.Len(t, result.Warnings, 1)
+}
+
+func TestWhoami_Unauthorized(t *testing.T) {
+	server := newUsersServer(t, http.StatusUnauthorized)
+	writeTestConfigFile(t, &types.Config{
+		DefaultEnvironment: "prod",
+		Environments: map[string]*types.Environment{
+			"prod": {Name: "prod", DashboardURL: server.URL, AuthToken: "bad", OrgID: "org"},
+		},
+	})
+
+	_, err := runRootCommand(t, "whoami")
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, int(types.ExitUnauthorized), exitErr.Code)
+}
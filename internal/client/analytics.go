@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// APIUsage returns an API's traffic aggregated by hour between from and to
+func (c *Client) APIUsage(ctx context.Context, apiID string, from, to time.Time) ([]types.AnalyticsRecord, error) {
+	path := fmt.Sprintf(APIUsagePath, url.PathEscape(apiID), from.UTC().Format(AnalyticsDateLayout), to.UTC().Format(AnalyticsDateLayout))
+	return c.getAnalytics(ctx, path+"?res=hour&p=-1")
+}
+
+// EndpointUsage returns an API's traffic aggregated by endpoint between from and to
+func (c *Client) EndpointUsage(ctx context.Context, apiID string, from, to time.Time) ([]types.AnalyticsRecord, error) {
+	values := url.Values{}
+	values.Set("api_id", apiID)
+	values.Set("p", "-1")
+	path := fmt.Sprintf(EndpointUsagePath, from.UTC().Format(AnalyticsDateLayout), to.UTC().Format(AnalyticsDateLayout))
+	return c.getAnalytics(ctx, path+"?"+values.Encode())
+}
+
+func (c *Client) getAnalytics(ctx context.Context, path string) ([]types.AnalyticsRecord, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.AnalyticsResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
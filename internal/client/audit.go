@@ -0,0 +1,144 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/tyktech/tyk-cli/internal/audit"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// auditActions maps the request methods that change state to audit log actions
+var auditActions = map[string]string{
+	http.MethodPost:   audit.ActionCreate,
+	http.MethodPut:    audit.ActionUpdate,
+	http.MethodPatch:  audit.ActionUpdate,
+	http.MethodDelete: audit.ActionDelete,
+}
+
+// auditResources maps collection endpoints to the resource type recorded in the log
+var auditResources = []struct {
+	prefix   string
+	resource string
+}{
+	{OASAPIsPath, "api"},
+	{GatewayOASAPIsPath, "api"},
+	{PoliciesPath, "policy"},
+	{GatewayKeysPath, "key"},
+	{KeysPath, "key"},
+	{"/tyk/keys", "key"},
+}
+
+// recordAudit appends a create, update or delete to the local audit log. The response
+// body is read to find the ID of created resources and restored for the caller.
+// Failing to write the log never fails the request.
+func (c *Client) recordAudit(method, path string, payload []byte, resp *http.Response, reqErr error) {
+	action, ok := auditActions[method]
+	if !ok {
+		return
+	}
+	requestPath := path
+	if u, err := url.Parse(path); err == nil {
+		requestPath = u.Path
+	}
+	resource, resourceID := auditResource(requestPath)
+
+	entry := audit.Entry{
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Method:     method,
+		Path:       requestPath,
+		DiffHash:   audit.Hash(payload),
+	}
+	entry.Environment = c.Environment()
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		if entry.ResourceID == "" && action == audit.ActionCreate {
+			entry.ResourceID = createdID(payload, resp)
+		}
+	}
+	if resource == "key" && resourceID != "" {
+		entry.Path = strings.Replace(entry.Path, resourceID, maskKeyID(resourceID), 1)
+	}
+	if resource == "key" {
+		entry.ResourceID = maskKeyID(entry.ResourceID)
+	}
+
+	if err := audit.Record(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write audit log: %v\n", err)
+	}
+}
+
+// auditResource returns the resource type and ID addressed by a request path
+func auditResource(path string) (string, string) {
+	for _, r := range auditResources {
+		if path == r.prefix {
+			return r.resource, ""
+		}
+		if strings.HasPrefix(path, r.prefix+"/") {
+			id, _ := url.PathUnescape(strings.SplitN(strings.TrimPrefix(path, r.prefix+"/"), "/", 2)[0])
+			return r.resource, id
+		}
+	}
+	return "other", ""
+}
+
+// createdID finds a new resource's ID in the request (APIs created with a fixed ID)
+// or in the response
+func createdID(payload []byte, resp *http.Response) string {
+	var doc map[string]interface{}
+	if json.Unmarshal(payload, &doc) == nil {
+		if ext, ok := doc["x-tyk-api-gateway"].(map[string]interface{}); ok {
+			if info, ok := ext["info"].(map[string]interface{}); ok {
+				if id, ok := info["id"].(string); ok && id != "" {
+					return id
+				}
+			}
+		}
+	}
+
+	if resp.Body == nil || resp.StatusCode >= 400 {
+		return ""
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	var result struct {
+		types.APIResponse
+		KeyID string `json:"key_id"`
+	}
+	if json.Unmarshal(body, &result) != nil {
+		return ""
+	}
+	for _, id := range []string{result.KeyID, result.CreatedID(), result.Meta} {
+		if id != "" {
+			return id
+		}
+	}
+	// Older Dashboards return the new policy ID as the message
+	if result.Status == "OK" && !strings.Contains(result.Message, " ") {
+		return result.Message
+	}
+	return ""
+}
+
+// maskKeyID keeps key IDs, which are credentials, out of the audit log
+func maskKeyID(id string) string {
+	if len(id) <= 8 {
+		return strings.Repeat("*", len(id))
+	}
+	return id[:4] + "****" + id[len(id)-4:]
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/audit"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestClient_RecordsMutations(t *testing.T) {
+	t.Setenv(audit.EnvLogPath, filepath.Join(t.TempDir(), "audit.jsonl"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == OASAPIsPath:
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK", Message: "API created", ID: "new-api"})
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"info":              map[string]interface{}{"title": "Created"},
+				"x-tyk-api-gateway": map[string]interface{}{"info": map[string]interface{}{"id": "new-api", "name": "Created"}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == KeysPath:
+			json.NewEncoder(w).Encode(types.KeyResponse{KeyID: "secretkey12345678"})
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Status":"Error","Message":"not found"}`))
+		default:
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK"})
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = c.CreateOASAPI(ctx, map[string]interface{}{"openapi": "3.0.3"})
+	require.NoError(t, err)
+	_, err = c.CreateKey(ctx, types.Session{"rate": 10})
+	require.NoError(t, err)
+	require.Error(t, c.DeleteOASAPI(ctx, "missing"))
+	_, err = c.ListOASAPIs(ctx, 1)
+	require.NoError(t, err)
+
+	entries, err := audit.Read()
+	require.NoError(t, err)
+	require.Len(t, entries, 3, "reads are not recorded")
+
+	assert.Equal(t, "test", entries[0].Environment)
+	assert.Equal(t, audit.ActionCreate, entries[0].Action)
+	assert.Equal(t, "api", entries[0].Resource)
+	assert.Equal(t, "new-api", entries[0].ResourceID)
+	assert.Equal(t, http.StatusOK, entries[0].Status)
+	assert.Contains(t, entries[0].DiffHash, "sha256:")
+
+	assert.Equal(t, "key", entries[1].Resource)
+	assert.Equal(t, "secr****5678", entries[1].ResourceID)
+
+	assert.Equal(t, audit.ActionDelete, entries[2].Action)
+	assert.Equal(t, "missing", entries[2].ResourceID)
+	assert.Equal(t, http.StatusNotFound, entries[2].Status)
+	assert.False(t, entries[2].Succeeded())
+	assert.Empty(t, entries[2].DiffHash)
+}
+
+func TestAuditResource(t *testing.T) {
+	tests := []struct {
+		path, resource, id string
+	}{
+		{OASAPIsPath, "api", ""},
+		{"/api/apis/oas/abc", "api", "abc"},
+		{"/tyk/apis/oas/abc", "api", "abc"},
+		{"/api/portal/policies/p1", "policy", "p1"},
+		{GatewayKeysPath, "key", ""},
+		{"/tyk/keys/k1", "key", "k1"},
+		{"/api/users", "other", ""},
+	}
+	for _, tt := range tests {
+		resource, id := auditResource(tt.path)
+		assert.Equal(t, tt.resource, resource, tt.path)
+		assert.Equal(t, tt.id, id, tt.path)
+	}
+}
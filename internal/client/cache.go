@@ -0,0 +1,373 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// EnvAPICacheDir overrides where API listings are cached
+const EnvAPICacheDir = "TYK_API_CACHE_DIR"
+
+// Cache misses; match them with errors.Is
+var (
+	// ErrNoAPICache is returned when an environment's API list has never been cached
+	ErrNoAPICache = errors.New("no cached API list")
+	// ErrNoCachedSpec is returned when an API's definition has not been prefetched
+	ErrNoCachedSpec = errors.New("no cached API definition")
+	// ErrNoCapabilities is returned when an environment's capabilities have never been cached
+	ErrNoCapabilities = errors.New("no cached capabilities")
+)
+
+// CapabilitiesMaxAge is how long cached capabilities stand in for a health check
+const CapabilitiesMaxAge = 24 * time.Hour
+
+// APICache is the API metadata of one environment as of its last refresh. It lets
+// completion and search work without a Dashboard round trip on every keystroke.
+type APICache struct {
+	Environment string          `json:"environment"`
+	RefreshedAt time.Time       `json:"refreshed_at"`
+	APIs        []*types.OASAPI `json:"apis"`
+}
+
+// CachedAPISpec is the full definition of one API as of its last prefetch
+type CachedAPISpec struct {
+	Environment string        `json:"environment"`
+	RefreshedAt time.Time     `json:"refreshed_at"`
+	ETag        string        `json:"etag,omitempty"`
+	API         *types.OASAPI `json:"api"`
+}
+
+// Capabilities is what an environment's server reported about itself at its last
+// prefetch
+type Capabilities struct {
+	Environment string    `json:"environment"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	Gateway     bool      `json:"gateway"`
+	// Version is the Tyk release the server reports, such as "v5.3.1", or ""
+	Version string `json:"version,omitempty"`
+}
+
+// Fresh reports whether the capabilities are recent enough to use instead of asking
+// the server
+func (c *Capabilities) Fresh() bool {
+	return time.Since(c.RefreshedAt) < CapabilitiesMaxAge
+}
+
+// apiCacheMu serialises cache writes within the process; writes go through a temporary
+// file and a rename, so other processes never read a partial cache
+var apiCacheMu sync.Mutex
+
+// APICacheDir returns the cache location: $TYK_API_CACHE_DIR, or tyk/apis in the user
+// cache directory
+func APICacheDir() (string, error) {
+	if dir := os.Getenv(EnvAPICacheDir); dir != "" {
+		return dir, nil
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "tyk", "apis"), nil
+}
+
+func apiCachePath(env string) (string, error) {
+	return envCachePath(env, ".json")
+}
+
+// apiSpecCachePath is where an API's prefetched definition is kept; every API of an
+// environment shares one directory
+func apiSpecCachePath(env, apiID string) (string, error) {
+	dir, err := envCachePath(env, "-specs")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, cacheFileName(apiID)+".json"), nil
+}
+
+func capabilitiesCachePath(env string) (string, error) {
+	return envCachePath(env, "-capabilities.json")
+}
+
+// envCachePath names a cache entry of env in the cache directory
+func envCachePath(env, suffix string) (string, error) {
+	dir, err := APICacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "env-"+cacheFileName(env)+suffix), nil
+}
+
+func cacheFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// LoadAPICache reads the cached API list of an environment
+func LoadAPICache(env string) (*APICache, error) {
+	path, err := apiCachePath(env)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("environment '%s': %w", env, ErrNoAPICache)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cache APICache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if cache.APIs == nil {
+		cache.APIs = []*types.OASAPI{}
+	}
+	return &cache, nil
+}
+
+// LoadCachedAPISpec reads the prefetched definition of an API; the returned API
+// carries the ETag it was fetched with
+func LoadCachedAPISpec(env, apiID string) (*CachedAPISpec, error) {
+	path, err := apiSpecCachePath(env, apiID)
+	if err != nil {
+		return nil, err
+	}
+	var spec CachedAPISpec
+	if err := readCacheFile(path, &spec); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("API '%s' in environment '%s': %w", apiID, env, ErrNoCachedSpec)
+	} else if err != nil {
+		return nil, err
+	}
+	if spec.API == nil {
+		return nil, fmt.Errorf("%s: no API definition", path)
+	}
+	spec.API.ETag = spec.ETag
+	return &spec, nil
+}
+
+// LoadCapabilities reads the cached capabilities of an environment
+func LoadCapabilities(env string) (*Capabilities, error) {
+	path, err := capabilitiesCachePath(env)
+	if err != nil {
+		return nil, err
+	}
+	var capabilities Capabilities
+	if err := readCacheFile(path, &capabilities); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("environment '%s': %w", env, ErrNoCapabilities)
+	} else if err != nil {
+		return nil, err
+	}
+	return &capabilities, nil
+}
+
+func readCacheFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// CachedAPIs returns the cached API list of the client's environment
+func (c *Client) CachedAPIs() (*APICache, error) {
+	return LoadAPICache(c.Environment())
+}
+
+// CachedAPISpec returns the prefetched definition of an API in the client's environment
+func (c *Client) CachedAPISpec(apiID string) (*CachedAPISpec, error) {
+	return LoadCachedAPISpec(c.Environment(), apiID)
+}
+
+// CachedCapabilities returns the cached capabilities of the client's environment
+func (c *Client) CachedCapabilities() (*Capabilities, error) {
+	return LoadCapabilities(c.Environment())
+}
+
+// RefreshAPICache lists every API and replaces the environment's cache with the result
+func (c *Client) RefreshAPICache(ctx context.Context) (*APICache, error) {
+	apis, err := c.ListAllAPIs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache := &APICache{
+		Environment: c.Environment(),
+		RefreshedAt: time.Now().UTC(),
+		APIs:        make([]*types.OASAPI, 0, len(apis)),
+	}
+	for _, api := range apis {
+		// Only metadata is cached; full documents are always fetched fresh
+		meta := *api
+		meta.OAS = nil
+		meta.VersionData = nil
+		cache.APIs = append(cache.APIs, &meta)
+	}
+
+	apiCacheMu.Lock()
+	defer apiCacheMu.Unlock()
+	if err := writeAPICache(cache); err != nil {
+		return nil, fmt.Errorf("failed to write API cache: %w", err)
+	}
+	return cache, nil
+}
+
+// RefreshAPISpec fetches an API's full definition and caches it
+func (c *Client) RefreshAPISpec(ctx context.Context, apiID string) (*CachedAPISpec, error) {
+	api, err := c.GetOASAPI(ctx, apiID, "")
+	if err != nil {
+		return nil, err
+	}
+	spec := &CachedAPISpec{Environment: c.Environment(), RefreshedAt: time.Now().UTC(), ETag: api.ETag, API: api}
+	path, err := apiSpecCachePath(spec.Environment, apiID)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCacheFile(path, spec); err != nil {
+		return nil, fmt.Errorf("failed to write API cache: %w", err)
+	}
+	return spec, nil
+}
+
+// PruneAPISpecs drops the cached definitions of every API of the client's environment
+// not in keep, such as APIs deleted elsewhere, and returns how many were dropped
+func (c *Client) PruneAPISpecs(keep []string) (int, error) {
+	dir, err := envCachePath(c.Environment(), "-specs")
+	if err != nil {
+		return 0, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	kept := make(map[string]bool, len(keep))
+	for _, apiID := range keep {
+		kept[cacheFileName(apiID)+".json"] = true
+	}
+	pruned := 0
+	for _, entry := range entries {
+		if kept[entry.Name()] || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// RefreshCapabilities asks the server what it is and caches the answer
+func (c *Client) RefreshCapabilities(ctx context.Context) (*Capabilities, error) {
+	status, err := c.CheckHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := &Capabilities{
+		Environment: c.Environment(),
+		RefreshedAt: time.Now().UTC(),
+		Gateway:     c.gateway,
+		Version:     status.Version,
+	}
+	path, err := capabilitiesCachePath(capabilities.Environment)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCacheFile(path, capabilities); err != nil {
+		return nil, fmt.Errorf("failed to write capabilities cache: %w", err)
+	}
+	return capabilities, nil
+}
+
+// forgetCachedAPI drops an API the client has just deleted from the cache, so
+// completion stops offering it before the next refresh, and the prefetched definition
+// of an API it has just changed or deleted. Failures are ignored: the cache is only an
+// optimisation.
+func (c *Client) forgetCachedAPI(method, path string, resp *http.Response, reqErr error) {
+	if method != http.MethodDelete && method != http.MethodPut || reqErr != nil || resp == nil || resp.StatusCode >= 400 {
+		return
+	}
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	resource, apiID := auditResource(path)
+	if resource != "api" || apiID == "" {
+		return
+	}
+	if specPath, err := apiSpecCachePath(c.Environment(), apiID); err == nil {
+		os.Remove(specPath)
+	}
+	if method != http.MethodDelete {
+		return
+	}
+
+	apiCacheMu.Lock()
+	defer apiCacheMu.Unlock()
+	cache, err := c.CachedAPIs()
+	if err != nil {
+		return
+	}
+	kept := cache.APIs[:0]
+	for _, api := range cache.APIs {
+		if api.ID != apiID {
+			kept = append(kept, api)
+		}
+	}
+	if len(kept) < len(cache.APIs) {
+		cache.APIs = kept
+		writeAPICache(cache)
+	}
+}
+
+func writeAPICache(cache *APICache) error {
+	path, err := apiCachePath(cache.Environment)
+	if err != nil {
+		return err
+	}
+	return writeCacheFile(path, cache)
+}
+
+// writeCacheFile writes v as JSON through a temporary file and a rename
+func writeCacheFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
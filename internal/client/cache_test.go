@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestAPICache(t *testing.T) {
+	t.Setenv(EnvAPICacheDir, t.TempDir())
+
+	lists := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/apis":
+			lists++
+			items := []interface{}{}
+			if r.URL.Query().Get("p") == "1" {
+				for _, id := range []string{"api-1", "api-2"} {
+					items = append(items, map[string]interface{}{
+						"api_definition": map[string]interface{}{"api_id": id, "name": "API " + id},
+					})
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"apis": items})
+		default:
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK"})
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = c.CachedAPIs()
+	require.ErrorIs(t, err, ErrNoAPICache)
+
+	refreshed, err := c.RefreshAPICache(ctx)
+	require.NoError(t, err)
+	require.Len(t, refreshed.APIs, 2)
+
+	lists = 0
+	cache, err := LoadAPICache("test")
+	require.NoError(t, err)
+	assert.Equal(t, "test", cache.Environment)
+	assert.False(t, cache.RefreshedAt.IsZero())
+	assert.Equal(t, "api-1", cache.APIs[0].ID)
+	assert.Zero(t, lists, "reading the cache does not call the Dashboard")
+
+	// Deleting an API drops it from the cache
+	require.NoError(t, c.DeleteOASAPI(ctx, "api-1"))
+	cache, err = c.CachedAPIs()
+	require.NoError(t, err)
+	require.Len(t, cache.APIs, 1)
+	assert.Equal(t, "api-2", cache.APIs[0].ID)
+
+	_, err = LoadAPICache("other")
+	assert.ErrorIs(t, err, ErrNoAPICache)
+}
+
+func TestAPISpecCache(t *testing.T) {
+	t.Setenv(EnvAPICacheDir, t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == HealthPath:
+			json.NewEncoder(w).Encode(map[string]string{"version": "v5.3.1"})
+		case r.Method == http.MethodGet:
+			w.Header().Set("ETag", `"e1"`)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"openapi":           "3.0.3",
+				"info":              map[string]interface{}{"title": "Users", "version": "1.0.0"},
+				"x-tyk-api-gateway": map[string]interface{}{"info": map[string]interface{}{"id": "api-1", "name": "Users"}},
+			})
+		default:
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK", ID: "api-1"})
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = c.CachedAPISpec("api-1")
+	require.ErrorIs(t, err, ErrNoCachedSpec)
+	_, err = c.CachedCapabilities()
+	require.ErrorIs(t, err, ErrNoCapabilities)
+
+	_, err = c.RefreshAPISpec(ctx, "api-1")
+	require.NoError(t, err)
+	spec, err := LoadCachedAPISpec("test", "api-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Users", spec.API.Name)
+	assert.Equal(t, `"e1"`, spec.API.ETag)
+
+	capabilities, err := c.RefreshCapabilities(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "v5.3.1", capabilities.Version)
+	capabilities, err = c.CachedCapabilities()
+	require.NoError(t, err)
+	assert.Equal(t, "v5.3.1", capabilities.Version)
+	assert.True(t, capabilities.Fresh())
+
+	// Updating an API drops its cached definition
+	_, err = c.UpdateOASAPI(ctx, "api-1", map[string]interface{}{"openapi": "3.0.3"})
+	require.NoError(t, err)
+	_, err = c.CachedAPISpec("api-1")
+	assert.ErrorIs(t, err, ErrNoCachedSpec)
+
+	// Definitions of APIs that are gone are pruned
+	_, err = c.RefreshAPISpec(ctx, "api-1")
+	require.NoError(t, err)
+	pruned, err := c.PruneAPISpecs([]string{"api-1"})
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+	pruned, err = c.PruneAPISpecs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+	_, err = c.CachedAPISpec("api-1")
+	assert.ErrorIs(t, err, ErrNoCachedSpec)
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// classicAPIPath returns the endpoint for a single classic API definition
+func (c *Client) classicAPIPath(apiID string) string {
+	if c.gateway {
+		return fmt.Sprintf(GatewayAPIPath, url.PathEscape(apiID))
+	}
+	return fmt.Sprintf(APIMetadataPath, url.PathEscape(apiID))
+}
+
+// classicAPIBody wraps a definition the way the Dashboard expects; the Gateway takes it bare
+func (c *Client) classicAPIBody(def map[string]interface{}) interface{} {
+	if c.gateway {
+		return def
+	}
+	return map[string]interface{}{"api_definition": def}
+}
+
+// GetClassicAPI retrieves a classic (non-OAS) API definition, such as a GraphQL API's
+func (c *Client) GetClassicAPI(ctx context.Context, apiID string) (map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, c.classicAPIPath(apiID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	if c.gateway {
+		return result, nil
+	}
+	def, ok := result["api_definition"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid response format: 'api_definition' field not found")
+	}
+	return def, nil
+}
+
+// CreateClassicAPI creates an API from a classic definition and returns its API ID
+func (c *Client) CreateClassicAPI(ctx context.Context, def map[string]interface{}) (string, error) {
+	path := ClassicAPIsPath
+	if c.gateway {
+		path = GatewayAPIsPath
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, path, c.classicAPIBody(def))
+	if err != nil {
+		return "", err
+	}
+
+	var result types.APIResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
+	}
+	// The Dashboard answers with its own record ID; the definition's API ID is the one
+	// requests use
+	if apiID, _ := def["api_id"].(string); apiID != "" {
+		return apiID, nil
+	}
+	if id := result.CreatedID(); id != "" {
+		return id, nil
+	}
+	if result.Meta == "" {
+		return "", fmt.Errorf("create response missing API ID")
+	}
+	return result.Meta, nil
+}
+
+// UpdateClassicAPI replaces a classic API definition
+func (c *Client) UpdateClassicAPI(ctx context.Context, apiID string, def map[string]interface{}) error {
+	resp, err := c.doRequest(ctx, http.MethodPut, c.classicAPIPath(apiID), c.classicAPIBody(def))
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
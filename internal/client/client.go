@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"time"
 
 	"github.com/tyktech/tyk-cli/pkg/types"
@@ -18,18 +20,69 @@ const (
 	OASAPIsPath        = "/api/apis/oas"
 	OASAPIPath         = "/api/apis/oas/%s"          // {apiId}
 	OASAPIVersionsPath = "/api/apis/oas/%s/versions" // {apiId}
+	APIMetadataPath    = "/api/apis/%s" // {apiId}; classic API definition with its metadata
+	ClassicAPIsPath    = "/api/apis" // classic API definitions, such as GraphQL APIs
+	APIAccessPath      = "/api/apis/%s/access" // {apiId}; API ownership
+	APISearchPath      = "/api/apis/search"
+	PoliciesPath       = "/api/portal/policies"
+	PolicyPath         = "/api/portal/policies/%s" // {policyId}
+	KeysPath           = "/api/keys"
+	KeyPath            = "/api/keys/%s" // {keyId}
+	UsersPath          = "/api/users"
+	HealthPath         = "/health"
+	CertsPath          = "/api/certs"
+	APIUsagePath       = "/api/usage/apis/%s/%s/%s" // {apiId}/{from}/{to}
+	EndpointUsagePath  = "/api/usage/endpoints/%s/%s" // {from}/{to}
+	WebhooksPath       = "/api/hooks"
+	WebhookPath        = "/api/hooks/%s" // {hookId}
+	CataloguePath      = "/api/portal/catalogue"
+	DocumentationPath  = "/api/portal/documentation"
+	DocumentPath       = "/api/portal/documentation/%s" // {documentationId}
+	APIKeysPath        = "/api/apis/%s/keys" // {apiId}
+	DevelopersPath     = "/api/portal/developers"
+
+	// Analytics paths take their start and end dates as D/M/YYYY segments
+	AnalyticsDateLayout = "2/1/2006"
+
+	// Gateway (OSS) API endpoints
+	GatewayOASAPIsPath = "/tyk/apis/oas"
+	GatewayOASAPIPath  = "/tyk/apis/oas/%s" // {apiId}
+	GatewayAPIsPath    = "/tyk/apis"
+	GatewayAPIPath     = "/tyk/apis/%s" // {apiId}
+	GatewayReloadPath  = "/tyk/reload/group"
+	GatewayKeysPath    = "/tyk/keys/create"
+	GatewayKeyPath     = "/tyk/keys/%s" // {keyId}
+	GatewayKeyListPath = "/tyk/keys"
+	GatewayHealthPath  = "/hello"
+	GatewayCertsPath   = "/tyk/certs"
 
 	// Default timeout
 	DefaultTimeout = 30 * time.Second
 
 	// Headers
 	HeaderAuthorization = "authorization"
+	HeaderGatewaySecret = "x-tyk-authorization"
 	HeaderContentType   = "content-type"
 	HeaderAccept        = "accept"
+	HeaderETag          = "etag"
+	HeaderIfMatch       = "if-match"
 
 	// Content types
 	ContentTypeJSON = "application/json"
 	ContentTypeYAML = "application/x-yaml"
+
+	// Number of APIs per page when paginating client-side (matches the Dashboard)
+	DefaultPageSize = 10
+)
+
+// Typed errors returned (wrapped in *types.ErrorResponse) by client calls; match with errors.Is
+var (
+	ErrNotFound     = types.ErrNotFound
+	ErrConflict     = types.ErrConflict
+	ErrUnauthorized = types.ErrUnauthorized
+	ErrRateLimited  = types.ErrRateLimited
+	// ErrPreconditionFailed also matches ErrConflict
+	ErrPreconditionFailed = types.ErrPreconditionFailed
 )
 
 // Client represents a Tyk Dashboard API client
@@ -37,6 +90,7 @@ type Client struct {
 	config     *types.Config
 	httpClient *http.Client
 	baseURL    *url.URL
+	gateway    bool
 }
 
 // NewClient creates a new Tyk Dashboard API client
@@ -51,46 +105,84 @@ func NewClient(config *types.Config) (*Client, error) {
 		return nil, fmt.Errorf("no active environment: %w", err)
 	}
 
-	baseURL, err := url.Parse(activeEnv.DashboardURL)
+	baseURL, err := url.Parse(activeEnv.ManagementURL())
 	if err != nil {
 		return nil, fmt.Errorf("invalid dashboard URL: %w", err)
 	}
 
+	httpClient, err := NewHTTPClient(activeEnv)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-		baseURL: baseURL,
+		config:     config,
+		httpClient: httpClient,
+		baseURL:    baseURL,
+		gateway: activeEnv.IsGateway(),
 	}, nil
 }
 
-// SetTimeout sets the HTTP client timeout
+// IsGateway reports whether the client talks to the OSS Gateway API instead of the Dashboard
+func (c *Client) IsGateway() bool {
+	return c.gateway
+}
+
+// Environment returns the name of the environment the client talks to
+func (c *Client) Environment() string {
+	if env, err := c.config.GetActiveEnvironment(); err == nil {
+		return env.Name
+	}
+	return ""
+}
+
+// oasAPIsPath returns the OAS collection endpoint for the configured API flavour
+func (c *Client) oasAPIsPath() string {
+	if c.gateway {
+		return GatewayOASAPIsPath
+	}
+	return OASAPIsPath
+}
+
+// oasAPIPath returns the endpoint for a single OAS API
+func (c *Client) oasAPIPath(apiID string) string {
+	if c.gateway {
+		return fmt.Sprintf(GatewayOASAPIPath, url.PathEscape(apiID))
+	}
+	return fmt.Sprintf(OASAPIPath, url.PathEscape(apiID))
+}
+
+// SetTimeout sets the HTTP client timeout; set it before sharing the client between goroutines
 func (c *Client) SetTimeout(timeout time.Duration) {
 	c.httpClient.Timeout = timeout
 }
 
 // doRequest performs an HTTP request with proper headers and error handling
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.doRequestWithHeaders(ctx, method, path, body, nil)
+}
+
+// doRequestWithHeaders is doRequest with extra request headers, such as preconditions
+func (c *Client) doRequestWithHeaders(ctx context.Context, method, path string, body interface{}, headers map[string]string) (*http.Response, error) {
 	var reqBody io.Reader
+	var payload []byte
 	var contentType string
 
 	if body != nil {
 		switch v := body.(type) {
 		case []byte:
-			reqBody = bytes.NewReader(v)
-			contentType = ContentTypeJSON
+			payload = v
 		case string:
-			reqBody = bytes.NewReader([]byte(v))
-			contentType = ContentTypeJSON
+			payload = []byte(v)
 		default:
 			jsonBody, err := json.Marshal(body)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal request body: %w", err)
 			}
-			reqBody = bytes.NewReader(jsonBody)
-			contentType = ContentTypeJSON
+			payload = jsonBody
 		}
+		reqBody = bytes.NewReader(payload)
+		contentType = ContentTypeJSON
 	}
 
 	// Build URL
@@ -114,14 +206,34 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		return nil, fmt.Errorf("no active environment for auth: %w", err)
 	}
 
-	// Set headers
-	req.Header.Set(HeaderAuthorization, activeEnv.AuthToken)
+	// The environment's own headers go first so they can never replace the credentials
+	for name, value := range activeEnv.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	// Set headers; the Gateway API authenticates with its shared secret
+	if c.gateway {
+		req.Header.Set(HeaderGatewaySecret, activeEnv.AuthToken)
+	} else {
+		req.Header.Set(HeaderAuthorization, activeEnv.AuthToken)
+	}
 	req.Header.Set(HeaderAccept, ContentTypeJSON)
 	if contentType != "" {
 		req.Header.Set(HeaderContentType, contentType)
 	}
+	if activeEnv.Signing != nil {
+		if err := signRequest(req, activeEnv.Signing, payload, time.Now()); err != nil {
+			return nil, fmt.Errorf("failed to sign request: %w", err)
+		}
+	}
 
-	return c.httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
+	c.recordAudit(method, path, payload, resp, err)
+	c.forgetCachedAPI(method, path, resp, err)
+	return resp, err
 }
 
 // handleResponse processes HTTP response and handles errors
@@ -160,7 +272,7 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 
 // GetOASAPI retrieves an OAS API by ID
 func (c *Client) GetOASAPI(ctx context.Context, apiID string, versionName string) (*types.OASAPI, error) {
-	apiPath := fmt.Sprintf(OASAPIPath, url.PathEscape(apiID))
+	apiPath := c.oasAPIPath(apiID)
 
 	// Add version parameter if specified
 	if versionName != "" {
@@ -206,13 +318,14 @@ func (c *Client) GetOASAPI(ctx context.Context, apiID string, versionName string
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API metadata: %w", err)
 	}
+	api.ETag = resp.Header.Get(HeaderETag)
 
 	return api, nil
 }
 
 // CreateOASAPI creates a new OAS API
 func (c *Client) CreateOASAPI(ctx context.Context, oasDocument map[string]interface{}) (*types.OASAPI, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, OASAPIsPath, oasDocument)
+	resp, err := c.doRequest(ctx, http.MethodPost, c.oasAPIsPath(), oasDocument)
 	if err != nil {
 		return nil, err
 	}
@@ -223,19 +336,32 @@ func (c *Client) CreateOASAPI(ctx context.Context, oasDocument map[string]interf
 	}
 
 	// Create response only returns basic info, need to get full API details
-	if result.ID == "" {
+	apiID := result.CreatedID()
+	if apiID == "" {
 		return nil, fmt.Errorf("create response missing API ID")
 	}
 
 	// Retrieve the full API details
-	return c.GetOASAPI(ctx, result.ID, "")
+	return c.GetOASAPI(ctx, apiID, "")
 }
 
 // UpdateOASAPI updates an existing OAS API
 func (c *Client) UpdateOASAPI(ctx context.Context, apiID string, oasDocument map[string]interface{}) (*types.OASAPI, error) {
-	apiPath := fmt.Sprintf(OASAPIPath, url.PathEscape(apiID))
+	return c.UpdateOASAPIIfMatch(ctx, apiID, oasDocument, "")
+}
+
+// UpdateOASAPIIfMatch updates an existing OAS API only if it is unchanged since it was
+// read with the given ETag (OASAPI.ETag); otherwise the Dashboard refuses the update and
+// the error matches ErrPreconditionFailed. Without an ETag, as from Dashboards that do
+// not return them, the update is unconditional.
+func (c *Client) UpdateOASAPIIfMatch(ctx context.Context, apiID string, oasDocument map[string]interface{}, etag string) (*types.OASAPI, error) {
+	apiPath := c.oasAPIPath(apiID)
 
-	resp, err := c.doRequest(ctx, http.MethodPut, apiPath, oasDocument)
+	var headers map[string]string
+	if etag != "" {
+		headers = map[string]string{HeaderIfMatch: etag}
+	}
+	resp, err := c.doRequestWithHeaders(ctx, http.MethodPut, apiPath, oasDocument, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +378,7 @@ func (c *Client) UpdateOASAPI(ctx context.Context, apiID string, oasDocument map
 
 // DeleteOASAPI deletes an OAS API by ID
 func (c *Client) DeleteOASAPI(ctx context.Context, apiID string) error {
-	apiPath := fmt.Sprintf(OASAPIPath, url.PathEscape(apiID))
+	apiPath := c.oasAPIPath(apiID)
 
 	resp, err := c.doRequest(ctx, http.MethodDelete, apiPath, nil)
 	if err != nil {
@@ -262,6 +388,35 @@ func (c *Client) DeleteOASAPI(ctx context.Context, apiID string) error {
 	return c.handleResponse(resp, nil)
 }
 
+// GetAPIAccess returns the users and user groups that own an API
+func (c *Client) GetAPIAccess(ctx context.Context, apiID string) (*types.APIAccess, error) {
+	if c.gateway {
+		return nil, fmt.Errorf("API ownership is only available for dashboard environments")
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf(APIAccessPath, url.PathEscape(apiID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &types.APIAccess{}
+	if err := c.handleResponse(resp, access); err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+// SetAPIAccess replaces the users and user groups that own an API
+func (c *Client) SetAPIAccess(ctx context.Context, apiID string, access *types.APIAccess) error {
+	if c.gateway {
+		return fmt.Errorf("API ownership is only available for dashboard environments")
+	}
+	resp, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf(APIAccessPath, url.PathEscape(apiID)), access)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
 // ListOASAPIs retrieves a paginated list of OAS APIs from the OAS endpoint. Page numbers are 1-based.
 func (c *Client) ListOASAPIs(ctx context.Context, page int) ([]*types.OASAPI, error) {
     listPath := OASAPIsPath
@@ -285,6 +440,10 @@ func (c *Client) ListOASAPIs(ctx context.Context, page int) ([]*types.OASAPI, er
 
 // ListAPIsDashboard retrieves a paginated list of APIs from the Dashboard aggregate endpoint and maps them.
 func (c *Client) ListAPIsDashboard(ctx context.Context, page int) ([]*types.OASAPI, error) {
+    if c.gateway {
+        return c.listGatewayAPIs(ctx, page)
+    }
+
     listPath := "/api/apis"
     if page > 0 {
         values := url.Values{}
@@ -292,6 +451,22 @@ func (c *Client) ListAPIsDashboard(ctx context.Context, page int) ([]*types.OASA
         listPath += "?" + values.Encode()
     }
 
+    return c.getDashboardAPIList(ctx, listPath)
+}
+
+// SearchAPIs asks the Dashboard search endpoint for APIs matching query.
+// Older Dashboards without the endpoint return ErrNotFound.
+func (c *Client) SearchAPIs(ctx context.Context, query string) ([]*types.OASAPI, error) {
+    if c.gateway {
+        return nil, ErrNotFound
+    }
+    values := url.Values{}
+    values.Set("q", query)
+    return c.getDashboardAPIList(ctx, APISearchPath+"?"+values.Encode())
+}
+
+// getDashboardAPIList fetches a Dashboard aggregate API listing and maps each item
+func (c *Client) getDashboardAPIList(ctx context.Context, listPath string) ([]*types.OASAPI, error) {
     resp, err := c.doRequest(ctx, http.MethodGet, listPath, nil)
     if err != nil {
         return nil, err
@@ -341,12 +516,13 @@ func (c *Client) ListAPIsDashboard(ctx context.Context, page int) ([]*types.OASA
 
         apiID, _ := apiDef["api_id"].(string)
         name, _ := apiDef["name"].(string)
-        var listenPath string
+        var listenPath, upstreamURL string
         if proxyInterface, ok := apiDef["proxy"]; ok {
             if proxy, ok := proxyInterface.(map[string]interface{}); ok {
                 if path, ok := proxy["listen_path"].(string); ok {
                     listenPath = path
                 }
+                upstreamURL, _ = proxy["target_url"].(string)
             }
         }
 
@@ -356,12 +532,140 @@ func (c *Client) ListAPIsDashboard(ctx context.Context, page int) ([]*types.OASA
                 Name:           name,
                 ListenPath:     listenPath,
                 DefaultVersion: "v1",
+                UpstreamURL:    upstreamURL,
+                CreatedAt:      listTimestamp(apiItem, apiDef, "created_at"),
+                UpdatedAt:      listTimestamp(apiItem, apiDef, "updated_at"),
+                Active:         getBool(apiDef, "active", true),
+                Internal:       getBool(apiDef, "internal", false),
+                Tags:           getStringSlice(apiDef, "tags"),
             })
         }
     }
     return apis, nil
 }
 
+// listTimestamp reads a timestamp from a Dashboard payload, which may carry it on
+// the wrapper object or on the API definition depending on Dashboard version
+func listTimestamp(item, apiDef map[string]interface{}, key string) string {
+	if value, ok := item[key].(string); ok && value != "" {
+		return value
+	}
+	value, _ := apiDef[key].(string)
+	return value
+}
+
+// ListAllAPIs walks every page of the API listing and returns the combined result
+func (c *Client) ListAllAPIs(ctx context.Context) ([]*types.OASAPI, error) {
+	var all []*types.OASAPI
+	err := c.EachAPIPage(ctx, 1, func(apis []*types.OASAPI) error {
+		all = append(all, apis...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// EachAPIPage calls fn with every page of the API listing from page onwards, as each
+// arrives, so callers can stream large catalogs. It stops at the first error from fn.
+func (c *Client) EachAPIPage(ctx context.Context, page int, fn func(apis []*types.OASAPI) error) error {
+	var previousFirstID string
+	for ; ; page++ {
+		apis, err := c.ListAPIsDashboard(ctx, page)
+		if err != nil {
+			return err
+		}
+		// Stop on an empty page, or if the server ignored the page parameter
+		if len(apis) == 0 || apis[0].ID == previousFirstID {
+			return nil
+		}
+		previousFirstID = apis[0].ID
+		if err := fn(apis); err != nil {
+			return err
+		}
+	}
+}
+
+// errPageFull stops EachAPIPage once ListAPIsPage has enough APIs
+var errPageFull = errors.New("page full")
+
+// ListAPIsPage returns page (1-based) of the API listing split into pages of pageSize,
+// fetching as many server pages of DefaultPageSize as that takes
+func (c *Client) ListAPIsPage(ctx context.Context, page, pageSize int) ([]*types.OASAPI, error) {
+	if pageSize <= 0 || pageSize == DefaultPageSize {
+		return c.ListAPIsDashboard(ctx, page)
+	}
+	start := (page - 1) * pageSize
+	serverPage := start/DefaultPageSize + 1
+	skip := start % DefaultPageSize
+
+	apis := []*types.OASAPI{}
+	err := c.EachAPIPage(ctx, serverPage, func(batch []*types.OASAPI) error {
+		if skip > 0 {
+			batch = batch[min(skip, len(batch)):]
+			skip = 0
+		}
+		apis = append(apis, batch...)
+		if len(apis) >= pageSize {
+			return errPageFull
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPageFull) {
+		return nil, err
+	}
+	return apis[:min(len(apis), pageSize)], nil
+}
+
+// listGatewayAPIs lists OAS APIs from the Gateway API, paginating client-side since the Gateway returns everything
+func (c *Client) listGatewayAPIs(ctx context.Context, page int) ([]*types.OASAPI, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, GatewayOASAPIsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []map[string]interface{}
+	if err := c.handleResponse(resp, &docs); err != nil {
+		return nil, err
+	}
+
+	var apis []*types.OASAPI
+	for _, doc := range docs {
+		api, err := c.parseOASDocumentToAPI(doc)
+		if err != nil {
+			continue
+		}
+		apis = append(apis, api)
+	}
+
+	if page <= 0 {
+		return apis, nil
+	}
+	start := (page - 1) * DefaultPageSize
+	if start >= len(apis) {
+		return []*types.OASAPI{}, nil
+	}
+	end := start + DefaultPageSize
+	if end > len(apis) {
+		end = len(apis)
+	}
+	return apis[start:end], nil
+}
+
+// ReloadGateway triggers a hot reload across the Gateway group so API changes take effect
+func (c *Client) ReloadGateway(ctx context.Context) error {
+	if !c.gateway {
+		return fmt.Errorf("reload is only available for gateway environments")
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, GatewayReloadPath, nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
 // ListOASAPIVersions lists all versions for an OAS API
 func (c *Client) ListOASAPIVersions(ctx context.Context, apiID string) ([]string, string, error) {
 	versionsPath := fmt.Sprintf(OASAPIVersionsPath, url.PathEscape(apiID))
@@ -395,19 +699,340 @@ func (c *Client) SwitchDefaultVersion(ctx context.Context, apiID string, version
 	return c.handleResponse(resp, nil)
 }
 
+// ListCertificates returns the IDs of every certificate in the certificate store
+func (c *Client) ListCertificates(ctx context.Context) ([]string, error) {
+	certsPath := CertsPath + "?p=-1"
+	if c.gateway {
+		certsPath = GatewayCertsPath
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, certsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.CertificateListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Certs, nil
+}
+
+// ListPolicies returns every security policy in the organisation
+func (c *Client) ListPolicies(ctx context.Context) ([]*types.Policy, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, PoliciesPath+"?p=-1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.PolicyListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// GetPolicyDocument returns a security policy as the Dashboard stores it, including the
+// fields types.Policy does not model, such as partitions and per-API limits
+func (c *Client) GetPolicyDocument(ctx context.Context, policyID string) (map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf(PolicyPath, url.PathEscape(policyID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := c.handleResponse(resp, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// UpdatePolicyDocument replaces a security policy with a stored document, such as one
+// read by GetPolicyDocument
+func (c *Client) UpdatePolicyDocument(ctx context.Context, policyID string, doc map[string]interface{}) error {
+	resp, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf(PolicyPath, url.PathEscape(policyID)), doc)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// ListPolicyDocuments returns every security policy in the organisation as the
+// Dashboard stores it, including the fields types.Policy does not model
+func (c *Client) ListPolicyDocuments(ctx context.Context) ([]map[string]interface{}, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, PoliciesPath+"?p=-1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data []map[string]interface{} `json:"Data"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// CreatePolicy creates a security policy and returns its ID
+func (c *Client) CreatePolicy(ctx context.Context, policy *types.Policy) (string, error) {
+	return c.createPolicy(ctx, policy)
+}
+
+// CreatePolicyDocument creates a security policy from a stored document, such as one
+// read by ListPolicyDocuments, and returns its ID
+func (c *Client) CreatePolicyDocument(ctx context.Context, doc map[string]interface{}) (string, error) {
+	return c.createPolicy(ctx, doc)
+}
+
+func (c *Client) createPolicy(ctx context.Context, policy interface{}) (string, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, PoliciesPath, policy)
+	if err != nil {
+		return "", err
+	}
+
+	var result types.APIResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
+	}
+
+	// The Dashboard returns the new policy ID in Message (newer versions also set ID)
+	if result.ID != "" {
+		return result.ID, nil
+	}
+	if result.Message == "" {
+		return "", fmt.Errorf("create policy response missing policy ID")
+	}
+	return result.Message, nil
+}
+
+// keyPath returns the endpoint for a single key
+func (c *Client) keyPath(keyID string) string {
+	if c.gateway {
+		return fmt.Sprintf(GatewayKeyPath, url.PathEscape(keyID))
+	}
+	return fmt.Sprintf(KeyPath, url.PathEscape(keyID))
+}
+
+// GetKey retrieves the session object of a key
+func (c *Client) GetKey(ctx context.Context, keyID string) (types.Session, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, c.keyPath(keyID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Gateway returns the bare session; the Dashboard wraps it
+	if c.gateway {
+		var session types.Session
+		if err := c.handleResponse(resp, &session); err != nil {
+			return nil, err
+		}
+		return session, nil
+	}
+
+	var result types.KeyResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("get key response missing session data")
+	}
+	return result.Data, nil
+}
+
+// CreateKey creates a key with a generated ID from a session object and returns the new key ID
+func (c *Client) CreateKey(ctx context.Context, session types.Session) (string, error) {
+	createPath := KeysPath
+	if c.gateway {
+		createPath = GatewayKeysPath
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, createPath, session)
+	if err != nil {
+		return "", err
+	}
+
+	if c.gateway {
+		var result types.APIResponse
+		if err := c.handleResponse(resp, &result); err != nil {
+			return "", err
+		}
+		if result.Key == "" {
+			return "", fmt.Errorf("create key response missing key ID")
+		}
+		return result.Key, nil
+	}
+
+	var result types.KeyResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
+	}
+	if result.KeyID == "" {
+		return "", fmt.Errorf("create key response missing key ID")
+	}
+	return result.KeyID, nil
+}
+
+// CreateCustomKey creates a key with the given ID, such as one restored from a backup
+func (c *Client) CreateCustomKey(ctx context.Context, keyID string, session types.Session) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, c.keyPath(keyID), session)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// UpdateKey replaces the session object of a key, leaving its live quota counter alone
+func (c *Client) UpdateKey(ctx context.Context, keyID string, session types.Session) error {
+	return c.putKey(ctx, keyID, session, true)
+}
+
+// ResetKeyQuota restores a key's full quota, including per-API quotas, given its
+// session as read by GetKey
+func (c *Client) ResetKeyQuota(ctx context.Context, keyID string, session types.Session) error {
+	resetQuota(session)
+	if access, ok := session["access_rights"].(map[string]interface{}); ok {
+		for _, value := range access {
+			if rights, ok := value.(map[string]interface{}); ok {
+				if limit, ok := rights["limit"].(map[string]interface{}); ok {
+					resetQuota(limit)
+				}
+			}
+		}
+	}
+
+	// Saving a key without suppress_reset makes the Gateway reinitialise its quota
+	return c.putKey(ctx, keyID, session, false)
+}
+
+// resetQuota sets quota_remaining back to quota_max in a session or access limit
+func resetQuota(fields map[string]interface{}) {
+	if quotaMax, ok := fields["quota_max"]; ok {
+		fields["quota_remaining"] = quotaMax
+	}
+}
+
+func (c *Client) putKey(ctx context.Context, keyID string, session types.Session, suppressReset bool) error {
+	keyPath := c.keyPath(keyID)
+	if suppressReset {
+		keyPath += "?suppress_reset=1"
+	}
+	resp, err := c.doRequest(ctx, http.MethodPut, keyPath, session)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// DeleteKey revokes a key
+func (c *Client) DeleteKey(ctx context.Context, keyID string) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, c.keyPath(keyID), nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// ListAPIKeys returns the IDs of the keys with access to an API. Keys are listed by
+// hash when the deployment hashes them.
+func (c *Client) ListAPIKeys(ctx context.Context, apiID string) ([]string, error) {
+	path := fmt.Sprintf(APIKeysPath, url.PathEscape(apiID)) + "?p=-1"
+	if c.gateway {
+		path = GatewayKeyListPath + "?api_id=" + url.QueryEscape(apiID)
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.KeyListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	if c.gateway {
+		return result.Keys, nil
+	}
+	return result.Data.Keys, nil
+}
+
+// CurrentUser finds the Dashboard user the configured auth token belongs to. It returns
+// nil without an error when the token is valid but matches no listed user.
+func (c *Client) CurrentUser(ctx context.Context) (*types.User, error) {
+	if c.gateway {
+		return nil, fmt.Errorf("users are only available for dashboard environments")
+	}
+	activeEnv, err := c.config.GetActiveEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, UsersPath+"?p=-1", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result types.UserListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	for _, user := range result.Users {
+		if user.AccessKey != "" && user.AccessKey == activeEnv.AuthToken {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+// HealthStatus describes a successful health check
+type HealthStatus struct {
+	// Latency is the round-trip time of the health request
+	Latency time.Duration
+	// ServerTime is the server's clock from the Date header, or zero if it sent none
+	ServerTime time.Time
+	// ClockSkew is how far the server's clock is ahead of the local one (negative if behind)
+	ClockSkew time.Duration
+	// Version is the release the server reports, such as "v5.3.1", or "" if it reports none
+	Version string
+}
+
 // Health checks the health of the Tyk Dashboard
 func (c *Client) Health(ctx context.Context) error {
-	resp, err := c.doRequest(ctx, http.MethodGet, "/health", nil)
+	_, err := c.CheckHealth(ctx)
+	return err
+}
+
+// CheckHealth calls the Dashboard (or Gateway) health endpoint and reports how long it
+// took and what time the server thinks it is
+func (c *Client) CheckHealth(ctx context.Context) (*HealthStatus, error) {
+	healthPath := HealthPath
+	if c.gateway {
+		healthPath = GatewayHealthPath
+	}
+
+	start := time.Now()
+	resp, err := c.doRequest(ctx, http.MethodGet, healthPath, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
+	latency := time.Since(start)
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("dashboard health check failed: %s", resp.Status)
+		return nil, fmt.Errorf("dashboard health check failed: %s", resp.Status)
 	}
 
-	return nil
+	status := &HealthStatus{Latency: latency}
+	var body struct {
+		Version string `json:"version"`
+	}
+	if json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body) == nil {
+		status.Version = body.Version
+	}
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		status.ServerTime = date
+		// The Date header has one-second resolution, so compare against the request midpoint
+		status.ClockSkew = date.Sub(start.Add(latency / 2)).Truncate(time.Second)
+	}
+	return status, nil
 }
 
 // parseOASDocumentToAPI extracts API metadata from an OAS document with Tyk extensions
@@ -455,11 +1080,25 @@ func (c *Client) parseOASDocumentToAPI(oasDoc map[string]interface{}) (*types.OA
 		ListenPath:  listenPath,
 		UpstreamURL: upstreamURL,
 		OAS:         oasDoc,
-		// For now, we'll set these to empty since they might not be in this format
 		DefaultVersion: "v1",
 		VersionData:    make(map[string]*types.APIVersion),
-		CreatedAt:      "",
-		UpdatedAt:      "",
+		// Dashboards carry timestamps next to the document or in the Tyk info
+		CreatedAt: listTimestamp(oasDoc, apiInfo, "created_at"),
+		UpdatedAt: listTimestamp(oasDoc, apiInfo, "updated_at"),
+	}
+
+	// Extract state; Tyk treats a missing state as active and public
+	if state, ok := apiInfo["state"].(map[string]interface{}); ok {
+		api.Active = getBool(state, "active", true)
+		api.Internal = getBool(state, "internal", false)
+	} else {
+		api.Active = true
+	}
+
+	if server, ok := tykExt["server"].(map[string]interface{}); ok {
+		if gatewayTags, ok := server["gatewayTags"].(map[string]interface{}); ok {
+			api.Tags = getStringSlice(gatewayTags, "tags")
+		}
 	}
 
 	// Extract title from OAS info if name is empty
@@ -477,3 +1116,26 @@ func getString(m map[string]interface{}, key string) string {
 	}
 	return ""
 }
+
+// getStringSlice returns m[key] as a string slice, skipping non-string entries
+func getStringSlice(m map[string]interface{}, key string) []string {
+	items, ok := m[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	var values []string
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getBool returns m[key] as a bool, or fallback when absent or not a bool
+func getBool(m map[string]interface{}, key string, fallback bool) bool {
+	if val, ok := m[key].(bool); ok {
+		return val
+	}
+	return fallback
+}
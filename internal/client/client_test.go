@@ -3,8 +3,10 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -157,6 +159,45 @@ func TestClient_handleResponse(t *testing.T) {
 	})
 }
 
+func TestClient_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{"not found", http.StatusNotFound, `{"message":"API not found"}`, ErrNotFound},
+		{"dashboard 400 for missing ID", http.StatusBadRequest, `{"Message":"Could not retrieve API detail"}`, ErrNotFound},
+		{"conflict", http.StatusConflict, `{"message":"API already exists"}`, ErrConflict},
+		{"unauthorized", http.StatusUnauthorized, `{"message":"Not authorised"}`, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, `{"message":"Access denied"}`, ErrUnauthorized},
+		{"rate limited", http.StatusTooManyRequests, `rate limit exceeded`, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(createTestConfig(server.URL, "token", "org"))
+			require.NoError(t, err)
+
+			_, err = client.GetOASAPI(context.Background(), "some-id", "")
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.expected)
+
+			for _, other := range []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrRateLimited} {
+				if other != tt.expected {
+					assert.NotErrorIs(t, err, other)
+				}
+			}
+		})
+	}
+}
+
 func TestClient_GetOASAPI(t *testing.T) {
 	// Create a mock OAS document with x-tyk-api-gateway extension
 	mockOASDoc := map[string]interface{}{
@@ -258,6 +299,58 @@ func TestClient_CreateOASAPI(t *testing.T) {
 	assert.Equal(t, "New API", api.Name)
 }
 
+func TestClient_UpdateOASAPIIfMatch(t *testing.T) {
+	doc := map[string]interface{}{
+		"openapi":           "3.0.0",
+		"info":              map[string]interface{}{"title": "Test API", "version": "1.0.0"},
+		"x-tyk-api-gateway": map[string]interface{}{"info": map[string]interface{}{"id": "test-api-id", "name": "Test API"}},
+	}
+	etag := `"v1"`
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", etag)
+			json.NewEncoder(w).Encode(doc)
+		case http.MethodPut:
+			ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+			if match := r.Header.Get("If-Match"); match != "" && match != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				w.Write([]byte(`{"Status":"Error","Message":"API has been modified"}`))
+				return
+			}
+			etag = `"v2"`
+			json.NewEncoder(w).Encode(types.APIResponse{Status: "OK", ID: "test-api-id"})
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	api, err := client.GetOASAPI(ctx, "test-api-id", "")
+	require.NoError(t, err)
+	assert.Equal(t, `"v1"`, api.ETag)
+
+	updated, err := client.UpdateOASAPIIfMatch(ctx, "test-api-id", doc, api.ETag)
+	require.NoError(t, err)
+	assert.Equal(t, `"v2"`, updated.ETag)
+
+	// The first ETag is stale now
+	_, err = client.UpdateOASAPIIfMatch(ctx, "test-api-id", doc, api.ETag)
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	assert.ErrorIs(t, err, ErrConflict)
+	var resp *types.ErrorResponse
+	require.ErrorAs(t, err, &resp)
+	assert.Equal(t, types.ErrCodeConflict, resp.ErrorCode())
+
+	// Without an ETag the update is unconditional
+	_, err = client.UpdateOASAPI(ctx, "test-api-id", doc)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`"v1"`, `"v1"`, ""}, ifMatch)
+}
+
 func TestClient_ListOASAPIs(t *testing.T) {
 	// Prepare two mock APIs
 	mockAPIs := []*types.OASAPI{
@@ -367,3 +460,225 @@ func TestLiveEnvironmentClient(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GatewayMode(t *testing.T) {
+	var reloaded bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gw-secret", r.Header.Get(HeaderGatewaySecret))
+		assert.Empty(t, r.Header.Get(HeaderAuthorization))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == GatewayOASAPIsPath:
+			w.Write([]byte(`{"key":"gw-api","status":"ok","action":"added"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/tyk/apis/oas/gw-api":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"openapi": "3.0.3",
+				"info":    map[string]interface{}{"title": "GW API", "version": "1.0.0"},
+				"x-tyk-api-gateway": map[string]interface{}{
+					"info":   map[string]interface{}{"id": "gw-api", "name": "GW API"},
+					"server": map[string]interface{}{"listenPath": map[string]interface{}{"value": "/gw/"}},
+				},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == GatewayReloadPath:
+			reloaded = true
+			w.Write([]byte(`{"status":"ok","message":""}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := &types.Config{
+		DefaultEnvironment: "oss",
+		Environments: map[string]*types.Environment{
+			"oss": {Name: "oss", Type: types.EnvTypeGateway, GatewayURL: server.URL, AuthToken: "gw-secret"},
+		},
+	}
+	client, err := NewClient(config)
+	require.NoError(t, err)
+	assert.True(t, client.IsGateway())
+
+	api, err := client.CreateOASAPI(context.Background(), map[string]interface{}{"openapi": "3.0.3"})
+	require.NoError(t, err)
+	assert.Equal(t, "gw-api", api.ID)
+	assert.Equal(t, "/gw/", api.ListenPath)
+
+	require.NoError(t, client.ReloadGateway(context.Background()))
+	assert.True(t, reloaded)
+}
+
+func TestClient_ReloadRequiresGateway(t *testing.T) {
+	client, err := NewClient(createTestConfig("http://localhost:3000", "token", "org"))
+	require.NoError(t, err)
+	assert.Error(t, client.ReloadGateway(context.Background()))
+}
+
+func TestClient_APITimestamps(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/apis/oas/a" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"info":       map[string]interface{}{"title": "a"},
+				"created_at": "2024-01-02T03:04:05Z",
+				"x-tyk-api-gateway": map[string]interface{}{
+					"info": map[string]interface{}{"id": "a", "name": "a", "updated_at": "2024-02-03T04:05:06Z"},
+				},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"apis": []interface{}{
+				map[string]interface{}{"created_at": "2024-01-02T03:04:05Z", "api_definition": map[string]interface{}{"api_id": "a", "updated_at": "2024-02-03T04:05:06Z"}},
+				map[string]interface{}{"api_definition": map[string]interface{}{"api_id": "b"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+
+	apis, err := client.ListAPIsDashboard(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, apis, 2)
+	assert.Equal(t, "2024-01-02T03:04:05Z", apis[0].CreatedAt)
+	assert.Equal(t, "2024-02-03T04:05:06Z", apis[0].UpdatedAt)
+	assert.Empty(t, apis[1].CreatedAt)
+
+	api, err := client.GetOASAPI(context.Background(), "a", "")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-02T03:04:05Z", api.CreatedAt)
+	assert.Equal(t, "2024-02-03T04:05:06Z", api.UpdatedAt)
+
+	// Nothing is fetched beyond the list and the document
+	assert.Equal(t, []string{"/api/apis", "/api/apis/oas/a"}, requested)
+}
+
+func TestClient_ListAPIsDashboard_State(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"apis": []interface{}{
+				map[string]interface{}{"api_definition": map[string]interface{}{"api_id": "a", "active": true, "proxy": map[string]interface{}{"target_url": "http://a.internal"}}},
+				map[string]interface{}{"api_definition": map[string]interface{}{"api_id": "b", "active": false}},
+				map[string]interface{}{"api_definition": map[string]interface{}{"api_id": "c", "active": true, "internal": true}},
+				map[string]interface{}{"api_definition": map[string]interface{}{"api_id": "d"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+
+	apis, err := client.ListAPIsDashboard(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, apis, 4)
+	assert.Equal(t, types.APIStatusActive, apis[0].Status())
+	assert.Equal(t, types.APIStatusInactive, apis[1].Status())
+	assert.Equal(t, types.APIStatusInternal, apis[2].Status())
+	assert.Equal(t, types.APIStatusActive, apis[3].Status())
+	assert.Equal(t, "http://a.internal", apis[0].UpstreamURL)
+}
+
+func TestClient_ListAPIsPage(t *testing.T) {
+	// 25 APIs served in pages of DefaultPageSize
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		items := []interface{}{}
+		for i := (page-1)*DefaultPageSize + 1; i <= min(page*DefaultPageSize, 25); i++ {
+			items = append(items, map[string]interface{}{"api_definition": map[string]interface{}{"api_id": fmt.Sprintf("api-%02d", i)}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"apis": items})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(createTestConfig(server.URL, "token", "org"))
+	require.NoError(t, err)
+	ids := func(apis []*types.OASAPI) []string {
+		var out []string
+		for _, api := range apis {
+			out = append(out, api.ID)
+		}
+		return out
+	}
+
+	apis, err := client.ListAPIsPage(context.Background(), 2, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api-08", "api-09", "api-10", "api-11", "api-12", "api-13", "api-14"}, ids(apis))
+
+	apis, err = client.ListAPIsPage(context.Background(), 2, 15)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api-16", "api-17", "api-18", "api-19", "api-20", "api-21", "api-22", "api-23", "api-24", "api-25"}, ids(apis))
+
+	apis, err = client.ListAPIsPage(context.Background(), 3, 15)
+	require.NoError(t, err)
+	assert.Empty(t, apis)
+
+	var sizes []int
+	require.NoError(t, client.EachAPIPage(context.Background(), 1, func(apis []*types.OASAPI) error {
+		sizes = append(sizes, len(apis))
+		return nil
+	}))
+	assert.Equal(t, []int{10, 10, 5}, sizes)
+}
+
+func TestClient_GatewayKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tyk/keys/old":
+			w.Write([]byte(`{"apply_policies":["pol-1"],"expires":0}`))
+		case r.Method == http.MethodPost && r.URL.Path == GatewayKeysPath:
+			w.Write([]byte(`{"key":"new","status":"ok","action":"added"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tyk/keys/restored":
+			w.Write([]byte(`{"key":"restored","status":"ok","action":"added"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/tyk/keys/old":
+			w.Write([]byte(`{"key":"old","status":"ok","action":"deleted"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&types.Config{
+		DefaultEnvironment: "oss",
+		Environments: map[string]*types.Environment{
+			"oss": {Name: "oss", Type: types.EnvTypeGateway, GatewayURL: server.URL, AuthToken: "gw-secret"},
+		},
+	})
+	require.NoError(t, err)
+
+	session, err := client.GetKey(context.Background(), "old")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"pol-1"}, session["apply_policies"])
+
+	keyID, err := client.CreateKey(context.Background(), session)
+	require.NoError(t, err)
+	assert.Equal(t, "new", keyID)
+	require.NoError(t, client.CreateCustomKey(context.Background(), "restored", session))
+
+	require.NoError(t, client.DeleteKey(context.Background(), "old"))
+}
+
+func TestClient_CheckHealth_ClockSkew(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(10*time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(createTestConfig(server.URL, "test-token", "test-org"))
+	require.NoError(t, err)
+
+	status, err := client.CheckHealth(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.ServerTime.IsZero())
+	assert.InDelta(t, (10 * time.Minute).Seconds(), status.ClockSkew.Seconds(), 2)
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tyktech/tyk-cli/internal/audit"
+)
+
+// TestMain keeps the changes tests make against fake servers out of the real audit log
+// and API cache, and shortens retry backoffs
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "tyk-audit")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv(audit.EnvLogPath, filepath.Join(dir, "audit.jsonl"))
+	os.Setenv(EnvAPICacheDir, filepath.Join(dir, "apis"))
+	DefaultRetryPolicy.Backoff = time.Millisecond
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tyktech/tyk-cli/internal/logging"
+)
+
+// Middleware wraps the round trips of every HTTP client this package creates, for
+// cross-cutting concerns such as metrics, logging and retries
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper, for writing middleware
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps base in middleware; the first middleware is the outermost, seeing each
+// request first and each response last
+func Chain(base http.RoundTripper, middleware ...Middleware) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		base = middleware[i](base)
+	}
+	return base
+}
+
+var (
+	middlewareMu sync.RWMutex
+	middleware   []Middleware
+)
+
+// Use installs middleware in the clients created from now on, inside the retries so
+// it sees every attempt. Install hooks at startup, before any client is created.
+func Use(mw ...Middleware) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+	middleware = append(middleware, mw...)
+}
+
+// roundTripper returns the chain requests go through: retries, then the installed
+// middleware, then debug logging, then the shared transport
+func roundTripper(transport http.RoundTripper) http.RoundTripper {
+	middlewareMu.RLock()
+	chain := []Middleware{Retry(DefaultRetryPolicy)}
+	chain = append(chain, middleware...)
+	middlewareMu.RUnlock()
+	chain = append(chain, logging.NewTransport)
+	return Chain(transport, chain...)
+}
+
+// RequestEvent describes one completed round trip
+type RequestEvent struct {
+	Method string
+	Host   string
+	Path   string
+	// Status is the response status, or 0 when the request failed
+	Status   int
+	Duration time.Duration
+	Err      error
+}
+
+// Instrument returns middleware calling observe after every round trip, to collect
+// metrics. observe runs on the requesting goroutine and must be safe for concurrent use.
+func Instrument(observe func(RequestEvent)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			event := RequestEvent{Method: req.Method, Host: req.URL.Host, Path: req.URL.Path, Duration: time.Since(start), Err: err}
+			if resp != nil {
+				event.Status = resp.StatusCode
+			}
+			observe(event)
+			return resp, err
+		})
+	}
+}
+
+// RetryPolicy says how often, and after how long, failed idempotent requests are retried
+type RetryPolicy struct {
+	// Retries is how many times a request is retried after its first attempt
+	Retries int
+	// Backoff is the wait before the first retry; it doubles for every retry after
+	Backoff time.Duration
+	// MaxBackoff caps the wait, including a Retry-After the server asks for
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the policy every client retries with
+var DefaultRetryPolicy = RetryPolicy{Retries: 2, Backoff: 250 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// retryableStatus are the responses of an overloaded or restarting server
+var retryableStatus = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// Retry returns middleware retrying GET, HEAD and OPTIONS requests that fail to
+// connect or get a 502, 503 or 504 response. Requests that change something are never
+// retried, as they may have been applied. Rate limiting (429) is reported, not retried.
+func Retry(policy RetryPolicy) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if policy.Retries <= 0 || (req.Method != http.MethodGet && req.Method != http.MethodHead && req.Method != http.MethodOptions) {
+				return next.RoundTrip(req)
+			}
+			wait := policy.Backoff
+			for attempt := 0; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if attempt == policy.Retries || req.Context().Err() != nil {
+					return resp, err
+				}
+				switch {
+				case err != nil:
+				case retryableStatus[resp.StatusCode]:
+					if after, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && after >= 0 {
+						wait = time.Duration(after) * time.Second
+					}
+					resp.Body.Close()
+				default:
+					return resp, err
+				}
+				if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+					wait = policy.MaxBackoff
+				}
+				timer := time.NewTimer(wait)
+				select {
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+				wait *= 2
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestChain_Order(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "transport")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	_, err := Chain(base, record("outer"), record("inner")).RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "transport"}, calls)
+}
+
+func TestRetry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(environmentConfig(&types.Environment{DashboardURL: server.URL}))
+	require.NoError(t, err)
+
+	// A GET is retried until it succeeds
+	require.NoError(t, c.Health(context.Background()))
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// A DELETE may have been applied, so its failure is reported
+	attempts.Store(0)
+	assert.Error(t, c.DeleteOASAPI(context.Background(), "api-1"))
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestUse_Instrument(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(healthHandler))
+	defer server.Close()
+
+	saved := middleware
+	t.Cleanup(func() { middleware = saved })
+	var mu sync.Mutex
+	var events []RequestEvent
+	Use(Instrument(func(event RequestEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}))
+
+	c, err := NewClient(environmentConfig(&types.Environment{DashboardURL: server.URL}))
+	require.NoError(t, err)
+
+	// One client serves concurrent calls
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.Health(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, events, 5)
+	assert.Equal(t, http.MethodGet, events[0].Method)
+	assert.Equal(t, HealthPath, events[0].Path)
+	assert.Equal(t, http.StatusOK, events[0].Status)
+}
+
+func TestNewHTTPClient_SharesTransport(t *testing.T) {
+	env := &types.Environment{DashboardURL: "http://dashboard.example.com"}
+	first, err := sharedTransport(env)
+	require.NoError(t, err)
+	second, err := sharedTransport(&types.Environment{DashboardURL: "http://other.example.com"})
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	proxied, err := sharedTransport(&types.Environment{ProxyURL: "http://proxy.example.com:3128"})
+	require.NoError(t, err)
+	assert.NotSame(t, first, proxied)
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// errGatewayPortal is returned by the portal methods on gateway environments
+var errGatewayPortal = errors.New("the developer portal is only available for dashboard environments")
+
+// GetCatalogue returns the organisation's developer portal catalogue
+func (c *Client) GetCatalogue(ctx context.Context) (*types.Catalogue, error) {
+	if c.gateway {
+		return nil, errGatewayPortal
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, CataloguePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogue := &types.Catalogue{}
+	if err := c.handleResponse(resp, catalogue); err != nil {
+		return nil, err
+	}
+	if catalogue.APIs == nil {
+		catalogue.APIs = []*types.CatalogueAPI{}
+	}
+	return catalogue, nil
+}
+
+// GetCatalogueDocument returns the developer portal catalogue as the Dashboard stores
+// it, including the fields types.Catalogue and its entries do not model
+func (c *Client) GetCatalogueDocument(ctx context.Context) (map[string]interface{}, error) {
+	if c.gateway {
+		return nil, errGatewayPortal
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, CataloguePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+	if err := c.handleResponse(resp, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+	return doc, nil
+}
+
+// UpdateCatalogueDocument replaces the developer portal catalogue with a stored
+// document, such as one read by GetCatalogueDocument
+func (c *Client) UpdateCatalogueDocument(ctx context.Context, doc map[string]interface{}) error {
+	if c.gateway {
+		return errGatewayPortal
+	}
+	resp, err := c.doRequest(ctx, http.MethodPut, CataloguePath, doc)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// CreateDocumentation uploads documentation for a catalogue entry and returns its ID
+func (c *Client) CreateDocumentation(ctx context.Context, doc *types.PortalDocumentation) (string, error) {
+	if c.gateway {
+		return "", errGatewayPortal
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, DocumentationPath, doc)
+	if err != nil {
+		return "", err
+	}
+
+	var result types.APIResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
+	}
+	if result.ID != "" {
+		return result.ID, nil
+	}
+	if result.Message == "" {
+		return "", fmt.Errorf("create documentation response missing documentation ID")
+	}
+	return result.Message, nil
+}
+
+// DeleteDocumentation deletes uploaded catalogue documentation
+func (c *Client) DeleteDocumentation(ctx context.Context, docID string) error {
+	if c.gateway {
+		return errGatewayPortal
+	}
+	resp, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf(DocumentPath, url.PathEscape(docID)), nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// ListPortalDevelopers returns the developers registered on the developer portal
+func (c *Client) ListPortalDevelopers(ctx context.Context) ([]*types.PortalDeveloper, error) {
+	if c.gateway {
+		return nil, errGatewayPortal
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, DevelopersPath+"?p=-1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.PortalDeveloperListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+var signatureParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// verifySignature checks a request signed with hmac-sha256 the way a server would
+func verifySignature(t *testing.T, r *http.Request, secret string) map[string]string {
+	t.Helper()
+	params := map[string]string{}
+	for _, match := range signatureParam.FindAllStringSubmatch(r.Header.Get("Signature"), -1) {
+		params[match[1]] = match[2]
+	}
+	var lines []string
+	for _, name := range strings.Fields(params["headers"]) {
+		switch name {
+		case "(request-target)":
+			lines = append(lines, name+": "+strings.ToLower(r.Method)+" "+r.URL.RequestURI())
+		case "host":
+			lines = append(lines, name+": "+r.Host)
+		default:
+			lines = append(lines, name+": "+r.Header.Get(name))
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join(lines, "\n")))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), params["signature"], "signature of %v", lines)
+	return params
+}
+
+func TestClient_SignsRequests(t *testing.T) {
+	var signatures []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, verifySignature(t, r, "shared-secret"))
+		assert.Equal(t, "token", r.Header.Get(HeaderAuthorization))
+		if r.Method == http.MethodPut {
+			assert.True(t, strings.HasPrefix(r.Header.Get("Digest"), "SHA-256="))
+		}
+		w.Header().Set(HeaderContentType, ContentTypeJSON)
+		w.Write([]byte(`{"Status":"OK","Meta":"api-1"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("DASHBOARD_SIGNING_SECRET", "shared-secret")
+	c, err := NewClient(environmentConfig(&types.Environment{
+		DashboardURL: server.URL,
+		Signing:      &types.RequestSigning{KeyID: "cli", Secret: "env:DASHBOARD_SIGNING_SECRET"},
+	}))
+	require.NoError(t, err)
+
+	require.NoError(t, c.Health(context.Background()))
+	require.NoError(t, c.SetAPIAccess(context.Background(), "api-1", &types.APIAccess{UserIDs: []string{"user-1"}}))
+	require.Len(t, signatures, 2)
+	assert.Equal(t, "cli", signatures[0]["keyId"])
+	assert.Equal(t, "hmac-sha256", signatures[0]["algorithm"])
+	assert.Equal(t, "(request-target) host date", signatures[0]["headers"])
+	assert.Equal(t, "(request-target) host date digest", signatures[1]["headers"])
+
+	t.Setenv("DASHBOARD_SIGNING_SECRET", "")
+	err = c.Health(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DASHBOARD_SIGNING_SECRET is not set")
+}
+
+func TestSignRequest(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "signing.key")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0600))
+	signing := &types.RequestSigning{Secret: "file:" + secretFile, Header: "X-Signature", Headers: []string{"(request-target)", "Date", "x-request-id"}}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	req := httptest.NewRequest(http.MethodGet, "https://dashboard.example.com/api/apis?p=1", nil)
+	req.Header.Set("X-Request-Id", "abc")
+	require.NoError(t, signRequest(req, signing, nil, now))
+	assert.Equal(t, "Fri, 02 Jan 2026 03:04:05 GMT", req.Header.Get(HeaderDate))
+	assert.Empty(t, req.Header.Get("Signature"))
+	mac := hmac.New(sha256.New, []byte("file-secret"))
+	mac.Write([]byte("(request-target): get /api/apis?p=1\ndate: Fri, 02 Jan 2026 03:04:05 GMT\nx-request-id: abc"))
+	assert.Equal(t, `algorithm="hmac-sha256",headers="(request-target) date x-request-id",signature="`+base64.StdEncoding.EncodeToString(mac.Sum(nil))+`"`, req.Header.Get("X-Signature"))
+	assert.Equal(t, []string{"(request-target)", "Date", "x-request-id"}, signing.Headers, "the configuration is left untouched")
+
+	// Headers to sign must be on the request
+	req = httptest.NewRequest(http.MethodGet, "https://dashboard.example.com/api/apis", nil)
+	assert.ErrorContains(t, signRequest(req, signing, nil, now), "x-request-id")
+}
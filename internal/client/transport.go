@@ -0,0 +1,112 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/tyktech/tyk-cli/internal/logging"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// NewHTTPClient returns an HTTP client honouring the environment's request timeout,
+// proxy and TLS settings, for requests made outside the management API such as calls
+// to the Gateway's own listen paths. Requests go through the middleware chain, and
+// clients for environments with the same connection settings share one transport and
+// its connection pool; the clients are safe for concurrent use. The values of the
+// environment's custom headers are redacted from debug logs.
+func NewHTTPClient(env *types.Environment) (*http.Client, error) {
+	timeout := DefaultTimeout
+	if t := env.RequestTimeout(); t > 0 {
+		timeout = t
+	}
+	transport, err := sharedTransport(env)
+	if err != nil {
+		return nil, err
+	}
+	for name := range env.Headers {
+		logging.RedactHeaders(name)
+	}
+	return &http.Client{Timeout: timeout, Transport: roundTripper(transport)}, nil
+}
+
+// transportKey identifies the connection settings of an environment
+type transportKey struct {
+	proxyURL, caCert, clientCert, clientKey string
+	insecureSkipVerify                      bool
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+// sharedTransport returns the transport for an environment's connection settings,
+// building it the first time they are seen
+func sharedTransport(env *types.Environment) (*http.Transport, error) {
+	key := transportKey{env.ProxyURL, env.CACert, env.ClientCert, env.ClientKey, env.InsecureSkipVerify}
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	if transport, ok := transports[key]; ok {
+		return transport, nil
+	}
+	transport, err := newTransport(env)
+	if err != nil {
+		return nil, err
+	}
+	transports[key] = transport
+	return transport, nil
+}
+
+// newTransport builds the HTTP transport for an environment, applying its proxy, CA
+// bundle, client certificate and certificate verification settings
+func newTransport(env *types.Environment) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if env.ProxyURL != "" {
+		proxyURL, err := url.Parse(env.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if env.CACert == "" && env.ClientCert == "" && !env.InsecureSkipVerify {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: env.InsecureSkipVerify,
+	}
+
+	if env.CACert != "" {
+		pem, err := os.ReadFile(env.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", env.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if env.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(env.ClientCert, env.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func environmentConfig(env *types.Environment) *types.Config {
+	env.Name = "test"
+	env.AuthToken = "token"
+	env.OrgID = "org"
+	return &types.Config{DefaultEnvironment: "test", Environments: map[string]*types.Environment{"test": env}}
+}
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
+
+func TestTransport_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(healthHandler))
+	defer server.Close()
+
+	// The test server's self-signed certificate is not trusted by default
+	c, err := NewClient(environmentConfig(&types.Environment{DashboardURL: server.URL}))
+	require.NoError(t, err)
+	assert.Error(t, c.Health(context.Background()))
+
+	caFile := writePEM(t, "CERTIFICATE", server.Certificate().Raw)
+	c, err = NewClient(environmentConfig(&types.Environment{DashboardURL: server.URL, CACert: caFile}))
+	require.NoError(t, err)
+	assert.NoError(t, c.Health(context.Background()))
+
+	c, err = NewClient(environmentConfig(&types.Environment{DashboardURL: server.URL, InsecureSkipVerify: true}))
+	require.NoError(t, err)
+	assert.NoError(t, c.Health(context.Background()))
+
+	_, err = NewClient(environmentConfig(&types.Environment{DashboardURL: server.URL, CACert: filepath.Join(t.TempDir(), "missing.pem")}))
+	assert.ErrorContains(t, err, "CA bundle")
+}
+
+func TestTransport_ClientCert(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile := writePEM(t, "CERTIFICATE", der)
+	keyFile := writePEM(t, "EC PRIVATE KEY", keyDER)
+
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	c, err := NewClient(environmentConfig(&types.Environment{
+		DashboardURL: server.URL, InsecureSkipVerify: true, ClientCert: certFile, ClientKey: keyFile,
+	}))
+	require.NoError(t, err)
+	require.NoError(t, c.Health(context.Background()))
+	assert.Equal(t, 1, presented)
+}
+
+func TestTransport_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	c, err := NewClient(environmentConfig(&types.Environment{DashboardURL: "http://dashboard.internal:3000", ProxyURL: proxy.URL}))
+	require.NoError(t, err)
+	require.NoError(t, c.Health(context.Background()))
+	assert.Equal(t, "http://dashboard.internal:3000/health", proxied)
+}
+
+func TestEnvironmentValidate_TransportSettings(t *testing.T) {
+	env := &types.Environment{Name: "test", DashboardURL: "http://localhost:3000", AuthToken: "token", OrgID: "org"}
+
+	env.ProxyURL = "not a url"
+	assert.ErrorContains(t, env.Validate(), "proxy URL")
+	env.ProxyURL = "http://proxy.corp:8080"
+	assert.NoError(t, env.Validate())
+
+	env.ClientCert = "client.pem"
+	assert.ErrorContains(t, env.Validate(), "client_cert and client_key")
+	env.ClientKey = "client-key.pem"
+	assert.NoError(t, env.Validate())
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// errGatewayWebhooks is returned by the webhook methods on gateway environments
+var errGatewayWebhooks = errors.New("webhooks are only available for dashboard environments")
+
+// ListWebhooks returns every webhook in the organisation
+func (c *Client) ListWebhooks(ctx context.Context) ([]*types.Webhook, error) {
+	if c.gateway {
+		return nil, errGatewayWebhooks
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, WebhooksPath+"?p=-1", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.WebhookListResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return result.Hooks, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (c *Client) GetWebhook(ctx context.Context, hookID string) (*types.Webhook, error) {
+	if c.gateway {
+		return nil, errGatewayWebhooks
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf(WebhookPath, url.PathEscape(hookID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &types.Webhook{}
+	if err := c.handleResponse(resp, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// CreateWebhook creates a webhook and returns its ID
+func (c *Client) CreateWebhook(ctx context.Context, hook *types.Webhook) (string, error) {
+	if c.gateway {
+		return "", errGatewayWebhooks
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, WebhooksPath, hook)
+	if err != nil {
+		return "", err
+	}
+
+	var result types.APIResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
+	}
+
+	// Like policies, the new webhook ID comes back in Message on older Dashboards
+	if result.ID != "" {
+		return result.ID, nil
+	}
+	if result.Message == "" {
+		return "", fmt.Errorf("create webhook response missing webhook ID")
+	}
+	return result.Message, nil
+}
+
+// UpdateWebhook replaces a webhook
+func (c *Client) UpdateWebhook(ctx context.Context, hookID string, hook *types.Webhook) error {
+	if c.gateway {
+		return errGatewayWebhooks
+	}
+	resp, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf(WebhookPath, url.PathEscape(hookID)), hook)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
+
+// DeleteWebhook deletes a webhook by ID
+func (c *Client) DeleteWebhook(ctx context.Context, hookID string) error {
+	if c.gateway {
+		return errGatewayWebhooks
+	}
+	resp, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf(WebhookPath, url.PathEscape(hookID)), nil)
+	if err != nil {
+		return err
+	}
+	return c.handleResponse(resp, nil)
+}
@@ -12,9 +12,11 @@ import (
 
 const (
 	// Environment variable names
-	EnvDashURL   = "TYK_DASH_URL"
-	EnvAuthToken = "TYK_AUTH_TOKEN"
-	EnvOrgID     = "TYK_ORG_ID"
+	EnvDashURL    = "TYK_DASH_URL"
+	EnvAuthToken  = "TYK_AUTH_TOKEN"
+	EnvOrgID      = "TYK_ORG_ID"
+	EnvEnvName    = "TYK_ENV"
+	EnvGatewayURL = "TYK_GATEWAY_URL" // overrides gateway_url for commands that call the Gateway
 
 	// Config file name (without extension)
 	ConfigFileName = "cli"
@@ -176,6 +178,23 @@ func (m *Manager) SetDefaultEnvironment(name string) error {
 	return nil
 }
 
+// ConfigFileUsed returns the path of the config file that was read, or "" when there was none
+func (m *Manager) ConfigFileUsed() string {
+	return m.viper.ConfigFileUsed()
+}
+
+// InConfigFile reports whether a key such as "environments.prod.auth_token" is set in
+// the config file
+func (m *Manager) InConfigFile(key string) bool {
+	return m.viper.InConfig(key)
+}
+
+// EnvVarName returns the environment variable that overrides a config file key, e.g.
+// TYK_ENVIRONMENTS_PROD_AUTH_TOKEN for "environments.prod.auth_token"
+func EnvVarName(key string) string {
+	return "TYK_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
 // GetViperInstance returns the underlying viper instance for testing
 func (m *Manager) GetViperInstance() *viper.Viper {
 	return m.viper
@@ -44,6 +44,70 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "invalid timeout",
+			config: types.Config{
+				DefaultEnvironment: "dev",
+				Environments: map[string]*types.Environment{
+					"dev": {
+						Name:         "dev",
+						DashboardURL: "http://localhost:3000",
+						AuthToken:    "test-token",
+						OrgID:        "test-org",
+						Timeout:      "soon",
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "request signing with a secret reference",
+			config: types.Config{
+				DefaultEnvironment: "dev",
+				Environments: map[string]*types.Environment{
+					"dev": {
+						Name:         "dev",
+						DashboardURL: "http://localhost:3000",
+						AuthToken:    "test-token",
+						OrgID:        "test-org",
+						Signing:      &types.RequestSigning{Algorithm: "hmac-sha512", Secret: "env:DASHBOARD_SIGNING_SECRET"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "request signing with a literal secret",
+			config: types.Config{
+				DefaultEnvironment: "dev",
+				Environments: map[string]*types.Environment{
+					"dev": {
+						Name:         "dev",
+						DashboardURL: "http://localhost:3000",
+						AuthToken:    "test-token",
+						OrgID:        "test-org",
+						Signing:      &types.RequestSigning{Secret: "s3cret"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "request signing with an unsupported algorithm",
+			config: types.Config{
+				DefaultEnvironment: "dev",
+				Environments: map[string]*types.Environment{
+					"dev": {
+						Name:         "dev",
+						DashboardURL: "http://localhost:3000",
+						AuthToken:    "test-token",
+						OrgID:        "test-org",
+						Signing:      &types.RequestSigning{Algorithm: "rsa-sha256", Secret: "file:/etc/tyk/signing.key"},
+					},
+				},
+			},
+			expectError: true,
+		},
 		{
 			name: "missing auth token",
 			config: types.Config{
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// DefaultRemotePath is the file read from a Git repository of shared definitions
+const DefaultRemotePath = "tyk-environments.yaml"
+
+// maxRemoteSize bounds the shared definitions file
+const maxRemoteSize = 1 << 20
+
+// IsGitRemote reports whether a remote URL names a Git repository rather than a file:
+// git+https://..., ssh:// and git@host:repo URLs, and URLs ending in .git
+func IsGitRemote(rawURL string) bool {
+	return strings.HasPrefix(rawURL, "git+") || strings.HasPrefix(rawURL, "ssh://") ||
+		strings.HasPrefix(rawURL, "git@") || strings.HasSuffix(rawURL, ".git")
+}
+
+// FetchRemote reads the shared definitions file of a remote source. Files are fetched
+// over HTTPS (plain HTTP only from the local machine); Git repositories are cloned
+// with the git command, so its credentials apply.
+func FetchRemote(ctx context.Context, source *types.RemoteSource) ([]byte, error) {
+	if IsGitRemote(source.URL) {
+		return fetchGitRemote(ctx, source)
+	}
+	u, err := url.Parse(source.URL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid remote URL '%s'", source.URL)
+	}
+	if u.Scheme != "https" && !(u.Scheme == "http" && isLoopback(u.Hostname())) {
+		return nil, fmt.Errorf("remote URL '%s' must use HTTPS", source.URL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", source.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", source.URL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", source.URL, err)
+	}
+	if len(data) > maxRemoteSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", source.URL, maxRemoteSize)
+	}
+	return data, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// fetchGitRemote reads the definitions file from a shallow clone of the repository
+func fetchGitRemote(ctx context.Context, source *types.RemoteSource) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "tyk-remote-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if source.Ref != "" {
+		args = append(args, "--branch", source.Ref)
+	}
+	args = append(args, "--", strings.TrimPrefix(source.URL, "git+"), dir)
+	var stderr bytes.Buffer
+	clone := exec.CommandContext(ctx, "git", args...)
+	clone.Stderr = &stderr
+	// Fail rather than prompt for credentials
+	clone.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if err := clone.Run(); err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %v: %s", source.URL, err, strings.TrimSpace(stderr.String()))
+	}
+
+	file := filepath.Join(dir, filepath.FromSlash(remotePath(source)))
+	if rel, err := filepath.Rel(dir, file); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("path '%s' is outside the repository", source.Path)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s has no %s: %w", source.URL, remotePath(source), err)
+	}
+	return data, nil
+}
+
+func remotePath(source *types.RemoteSource) string {
+	if source.Path != "" {
+		return source.Path
+	}
+	return DefaultRemotePath
+}
+
+// ParseRemote reads shared definitions, in the layout of the config file: an optional
+// default_environment and the environments. The format follows the file extension
+// (YAML, JSON or TOML; YAML by default). Secrets are refused: auth tokens, client keys
+// and header values belong in each user's own config, though a header may reference a
+// variable, as ${NAME}, that the environment lists in its env block. Other variables
+// are refused, so the file cannot have requests carry whatever the user's shell holds.
+func ParseRemote(source *types.RemoteSource, data []byte) (*types.Config, error) {
+	name := source.URL
+	if IsGitRemote(source.URL) {
+		name = remotePath(source)
+	} else if u, err := url.Parse(source.URL); err == nil {
+		name = u.Path
+	}
+	remote, err := parseEnvironments(name, data, "remote config")
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets, undeclared []string
+	for _, name := range sortedEnvironmentNames(remote.Environments) {
+		env := remote.Environments[name]
+		if env.AuthToken != "" {
+			secrets = append(secrets, "environments."+name+".auth_token")
+		}
+		if env.ClientKey != "" {
+			secrets = append(secrets, "environments."+name+".client_key")
+		}
+		for _, header := range slices.Sorted(maps.Keys(env.Headers)) {
+			match := placeholderPattern.FindStringSubmatch(env.Headers[header])
+			switch {
+			case match == nil:
+				secrets = append(secrets, "environments."+name+".headers."+header)
+			case !declaresVariable(env, match[1]):
+				undeclared = append(undeclared, "environments."+name+".headers."+header+" ("+match[1]+")")
+			}
+		}
+	}
+	if len(secrets) > 0 {
+		return nil, fmt.Errorf("the remote config must not contain secrets: %s", strings.Join(secrets, ", "))
+	}
+	if len(undeclared) > 0 {
+		return nil, fmt.Errorf("the remote config's headers may only reference variables in the environment's env block: %s", strings.Join(undeclared, ", "))
+	}
+	return remote, nil
+}
+
+// declaresVariable reports whether the env block of an environment lists a variable;
+// names are matched regardless of case, as the config file lowercases them
+func declaresVariable(env *types.Environment, variable string) bool {
+	for name := range env.Env {
+		if strings.EqualFold(name, variable) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEnvironments reads environment definitions in the layout of the config file,
+// in the format of the extension of name (YAML, JSON or TOML; YAML by default).
+// Environments are validated without their secrets, which are added locally; what
+// names the file in errors, e.g. "remote config".
+func parseEnvironments(name string, data []byte, what string) (*types.Config, error) {
+	format := strings.TrimPrefix(strings.ToLower(path.Ext(name)), ".")
+	if format != "json" && format != "toml" {
+		format = "yaml"
+	}
+
+	v := viper.New()
+	v.SetConfigType(format)
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s: %w", what, err)
+	}
+	parsed := &types.Config{}
+	if err := v.Unmarshal(parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s: %w", what, err)
+	}
+	if len(parsed.Environments) == 0 {
+		return nil, fmt.Errorf("the %s defines no environments", what)
+	}
+
+	for _, name := range sortedEnvironmentNames(parsed.Environments) {
+		env := parsed.Environments[name]
+		if env == nil {
+			return nil, fmt.Errorf("environment '%s' of the %s is empty", name, what)
+		}
+		env.Name = name
+		// Tokens are added locally, so validate the rest as if one were set
+		check := *env
+		check.AuthToken = "remote"
+		check.ClientCert, check.ClientKey = "", ""
+		if err := check.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", what, err)
+		}
+	}
+	if parsed.DefaultEnvironment != "" && parsed.Environments[parsed.DefaultEnvironment] == nil {
+		return nil, fmt.Errorf("%s: default environment '%s' not found", what, parsed.DefaultEnvironment)
+	}
+	return parsed, nil
+}
+
+// RemoteSync lists what syncing shared definitions changed, by environment name
+type RemoteSync struct {
+	Added     []string `json:"added"`
+	Updated   []string `json:"updated"`
+	Unchanged []string `json:"unchanged"`
+	Removed   []string `json:"removed"`
+	// Shared environments no longer in the remote config, kept without prune
+	Stale []string `json:"stale"`
+	// Synced environments without an auth token
+	MissingToken []string `json:"missing_token"`
+	// Environments whose Dashboard or Gateway URL the sync changed while they hold a
+	// local auth token, which would then be sent to the new URL
+	Redirected []string `json:"redirected"`
+	// Set when the sync chose the default environment
+	DefaultEnvironment string `json:"default_environment,omitempty"`
+}
+
+// MergeRemote applies shared definitions to cfg. Each remote environment replaces the
+// local one of that name, which keeps its own auth token, proxy, certificates and TLS
+// verification setting, and its headers and env variables over those of the remote;
+// shared environments dropped from the remote config are removed when prune is set.
+// Environments with a local token whose URLs change are listed as Redirected, for the
+// caller to confirm before saving.
+func MergeRemote(cfg *types.Config, remote *types.Config, prune bool) *RemoteSync {
+	sync := &RemoteSync{Added: []string{}, Updated: []string{}, Unchanged: []string{}, Removed: []string{}, Stale: []string{}, MissingToken: []string{}, Redirected: []string{}}
+	if cfg.Environments == nil {
+		cfg.Environments = make(map[string]*types.Environment)
+	}
+
+	for _, name := range sortedEnvironmentNames(remote.Environments) {
+		merged := *remote.Environments[name]
+		merged.Shared = true
+		merged.AuthToken, merged.ProxyURL, merged.CACert, merged.ClientCert, merged.ClientKey = "", "", "", "", ""
+		merged.InsecureSkipVerify = false
+		local, exists := cfg.Environments[name]
+		if exists {
+			merged.AuthToken = local.AuthToken
+			merged.ProxyURL = local.ProxyURL
+			merged.CACert = local.CACert
+			merged.ClientCert = local.ClientCert
+			merged.ClientKey = local.ClientKey
+			merged.InsecureSkipVerify = local.InsecureSkipVerify
+			merged.Headers = mergeValues(merged.Headers, local.Headers)
+			merged.Env = mergeValues(merged.Env, local.Env)
+		}
+		switch {
+		case !exists:
+			sync.Added = append(sync.Added, name)
+		case reflect.DeepEqual(*local, merged):
+			sync.Unchanged = append(sync.Unchanged, name)
+		default:
+			sync.Updated = append(sync.Updated, name)
+		}
+		if merged.AuthToken == "" {
+			sync.MissingToken = append(sync.MissingToken, name)
+		}
+		if exists && local.AuthToken != "" && (merged.DashboardURL != local.DashboardURL || merged.GatewayURL != local.GatewayURL) {
+			sync.Redirected = append(sync.Redirected, name)
+		}
+		cfg.Environments[name] = &merged
+	}
+
+	for _, name := range sortedEnvironmentNames(cfg.Environments) {
+		if env := cfg.Environments[name]; env.Shared && remote.Environments[name] == nil {
+			if !prune {
+				sync.Stale = append(sync.Stale, name)
+				continue
+			}
+			delete(cfg.Environments, name)
+			sync.Removed = append(sync.Removed, name)
+		}
+	}
+
+	if cfg.Environments[cfg.DefaultEnvironment] == nil {
+		cfg.DefaultEnvironment = remote.DefaultEnvironment
+		if cfg.DefaultEnvironment == "" {
+			cfg.DefaultEnvironment = sortedEnvironmentNames(cfg.Environments)[0]
+		}
+		sync.DefaultEnvironment = cfg.DefaultEnvironment
+	}
+	return sync
+}
+
+// mergeValues returns the header or env values of a remote environment with the local
+// ones laid over them
+func mergeValues(remote, local map[string]string) map[string]string {
+	if len(local) == 0 {
+		return remote
+	}
+	merged := maps.Clone(remote)
+	if merged == nil {
+		merged = make(map[string]string, len(local))
+	}
+	maps.Copy(merged, local)
+	return merged
+}
+
+func sortedEnvironmentNames(environments map[string]*types.Environment) []string {
+	names := make([]string, 0, len(environments))
+	for name := range environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+const sharedEnvironments = `default_environment: staging
+environments:
+  staging:
+    dashboard_url: https://dashboard.staging.example.com
+    org_id: org
+    listen_path_prefix: /staging
+    insecure_skip_verify: true
+  production:
+    dashboard_url: https://dashboard.example.com
+    org_id: org
+    promote_from:
+      staging:
+        domains:
+          - from: staging.example.com
+            to: example.com
+`
+
+func TestParseRemote(t *testing.T) {
+	source := &types.RemoteSource{URL: "https://platform.example.com/environments.yaml"}
+	remote, err := ParseRemote(source, []byte(sharedEnvironments))
+	require.NoError(t, err)
+	assert.Equal(t, "staging", remote.DefaultEnvironment)
+	require.Contains(t, remote.Environments, "production")
+	assert.Equal(t, "production", remote.Environments["production"].Name)
+	assert.Equal(t, []types.Rewrite{{From: "staging.example.com", To: "example.com"}}, remote.Environments["production"].PromoteFrom["staging"].Domains)
+
+	json := `{"environments": {"dev": {"dashboard_url": "https://dev.example.com", "org_id": "org"}}}`
+	remote, err = ParseRemote(&types.RemoteSource{URL: "https://platform.example.com/environments.json"}, []byte(json))
+	require.NoError(t, err)
+	assert.Contains(t, remote.Environments, "dev")
+
+	_, err = ParseRemote(source, []byte("environments:\n  dev:\n    dashboard_url: https://dev.example.com\n    org_id: org\n    auth_token: secret\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "environments.dev.auth_token")
+
+	// Headers may only reference variables listed in the environment's env block
+	remote, err = ParseRemote(source, []byte("environments:\n  dev:\n    dashboard_url: https://dev.example.com\n    org_id: org\n    env:\n      PROXY_TOKEN: \"\"\n    headers:\n      x-proxy-auth: ${PROXY_TOKEN}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "${PROXY_TOKEN}", remote.Environments["dev"].Headers["x-proxy-auth"])
+	_, err = ParseRemote(source, []byte("environments:\n  dev:\n    dashboard_url: https://dev.example.com\n    org_id: org\n    headers:\n      x-proxy-auth: ${AWS_SECRET_ACCESS_KEY}\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "environments.dev.headers.x-proxy-auth (AWS_SECRET_ACCESS_KEY)")
+	_, err = ParseRemote(source, []byte("environments:\n  dev:\n    dashboard_url: https://dev.example.com\n    org_id: org\n    headers:\n      x-proxy-auth: secret\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "environments.dev.headers.x-proxy-auth")
+
+	_, err = ParseRemote(source, []byte("environments:\n  dev:\n    dashboard_url: not-a-url\n    org_id: org\n"))
+	assert.Error(t, err)
+
+	_, err = ParseRemote(source, []byte("default_environment: dev\n"))
+	assert.Error(t, err)
+}
+
+func TestMergeRemote(t *testing.T) {
+	remote, err := ParseRemote(&types.RemoteSource{URL: "https://platform.example.com/environments.yaml"}, []byte(sharedEnvironments))
+	require.NoError(t, err)
+
+	cfg := &types.Config{
+		DefaultEnvironment: "local",
+		Environments: map[string]*types.Environment{
+			"local": {Name: "local", DashboardURL: "http://localhost:3000", AuthToken: "local-token", OrgID: "org"},
+			"production": {Name: "production", DashboardURL: "https://old.example.com", AuthToken: "prod-token", OrgID: "org", ProxyURL: "http://proxy:3128", InsecureSkipVerify: true,
+				Headers: map[string]string{"x-proxy-auth": "proxy-secret"}, Env: map[string]string{"tyk_gateway_url": "http://gateway.internal"}},
+			"legacy": {Name: "legacy", DashboardURL: "https://legacy.example.com", OrgID: "org", Shared: true},
+		},
+	}
+	sync := MergeRemote(cfg, remote, false)
+	assert.Equal(t, []string{"staging"}, sync.Added)
+	assert.Equal(t, []string{"production"}, sync.Updated)
+	assert.Equal(t, []string{"legacy"}, sync.Stale)
+	assert.Equal(t, []string{"staging"}, sync.MissingToken)
+	assert.Equal(t, []string{"production"}, sync.Redirected)
+	assert.Empty(t, sync.DefaultEnvironment)
+	assert.Equal(t, "local", cfg.DefaultEnvironment)
+
+	// Shared settings are replaced; the token and proxy stay local
+	production := cfg.Environments["production"]
+	assert.Equal(t, "https://dashboard.example.com", production.DashboardURL)
+	assert.Equal(t, "prod-token", production.AuthToken)
+	assert.Equal(t, "http://proxy:3128", production.ProxyURL)
+	assert.True(t, production.InsecureSkipVerify)
+	// The remote cannot turn off TLS verification
+	assert.False(t, cfg.Environments["staging"].InsecureSkipVerify)
+	assert.Equal(t, map[string]string{"x-proxy-auth": "proxy-secret"}, production.Headers)
+	assert.Equal(t, map[string]string{"tyk_gateway_url": "http://gateway.internal"}, production.Env)
+	assert.True(t, production.Shared)
+	assert.False(t, cfg.Environments["local"].Shared)
+
+	sync = MergeRemote(cfg, remote, true)
+	assert.Equal(t, []string{"production", "staging"}, sync.Unchanged)
+	assert.Equal(t, []string{"legacy"}, sync.Removed)
+	assert.Empty(t, sync.Redirected)
+	assert.NotContains(t, cfg.Environments, "legacy")
+
+	// Without a usable default, the remote one is taken
+	cfg = &types.Config{}
+	sync = MergeRemote(cfg, remote, false)
+	assert.Equal(t, "staging", cfg.DefaultEnvironment)
+	assert.Equal(t, "staging", sync.DefaultEnvironment)
+}
+
+func TestIsGitRemote(t *testing.T) {
+	assert.True(t, IsGitRemote("git@github.com:acme/platform.git"))
+	assert.True(t, IsGitRemote("git+https://git.example.com/platform"))
+	assert.True(t, IsGitRemote("https://github.com/acme/platform.git"))
+	assert.False(t, IsGitRemote("https://platform.example.com/environments.yaml"))
+}
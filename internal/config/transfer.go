@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+// placeholderPattern matches a secret exported as a reference to an environment
+// variable, e.g. ${TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN}
+var placeholderPattern = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_.-]*)\}$`)
+
+// SecretPlaceholder returns the placeholder exported in place of the secret key of an
+// environment: a reference to the variable that also overrides it in the config file
+func SecretPlaceholder(envName, key string) string {
+	return "${" + EnvVarName("environments."+envName+"."+key) + "}"
+}
+
+// ValuePlaceholder returns the placeholder exported in place of the value of a header
+// or env variable (field "headers" or "env") of an environment, naming a variable in
+// the style of SecretPlaceholder, e.g. ${TYK_ENVIRONMENTS_STAGING_HEADERS_X_PROXY_AUTH}
+func ValuePlaceholder(envName, field, name string) string {
+	variable := strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, EnvVarName("environments."+envName+"."+field+"."+name))
+	return "${" + variable + "}"
+}
+
+// ExportEnvironments copies the named environments of cfg (all of them when names is
+// empty) for sharing: auth tokens, client keys and the values of headers and env
+// variables are replaced by placeholders, and the remote source and shared markers,
+// which belong to the local config, are dropped. Header values that only reference a
+// variable, e.g. ${PROXY_TOKEN}, hold no secret and are kept.
+func ExportEnvironments(cfg *types.Config, names []string) (*types.Config, error) {
+	if len(names) == 0 {
+		names = sortedEnvironmentNames(cfg.Environments)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no environments configured")
+	}
+
+	exported := &types.Config{Environments: make(map[string]*types.Environment, len(names))}
+	for _, name := range names {
+		env := cfg.Environments[name]
+		if env == nil {
+			return nil, fmt.Errorf("environment '%s' not found", name)
+		}
+		copied := *env
+		copied.Name = name
+		copied.Shared = false
+		if copied.AuthToken != "" {
+			copied.AuthToken = SecretPlaceholder(name, "auth_token")
+		}
+		if copied.ClientKey != "" {
+			copied.ClientKey = SecretPlaceholder(name, "client_key")
+		}
+		copied.Headers = exportValues(name, "headers", env.Headers, true)
+		copied.Env = exportValues(name, "env", env.Env, false)
+		exported.Environments[name] = &copied
+	}
+	if exported.Environments[cfg.DefaultEnvironment] != nil {
+		exported.DefaultEnvironment = cfg.DefaultEnvironment
+	}
+	return exported, nil
+}
+
+// exportValues replaces the values of a header or env map with placeholders, keeping
+// references to variables when keepReferences is set
+func exportValues(envName, field string, values map[string]string, keepReferences bool) map[string]string {
+	if values == nil {
+		return nil
+	}
+	exported := make(map[string]string, len(values))
+	for name, value := range values {
+		if !keepReferences || !placeholderPattern.MatchString(value) {
+			value = ValuePlaceholder(envName, field, name)
+		}
+		exported[name] = value
+	}
+	return exported
+}
+
+// ParseImport reads environment definitions written by ExportEnvironments, or any file
+// in the layout of the config file. The format follows the extension of name (YAML,
+// JSON or TOML; YAML by default).
+func ParseImport(name string, data []byte) (*types.Config, error) {
+	return parseEnvironments(name, data, "import file")
+}
+
+// EnvironmentImport lists what importing environment definitions changed, by name
+type EnvironmentImport struct {
+	Added     []string `json:"added"`
+	Updated   []string `json:"updated"`
+	Unchanged []string `json:"unchanged"`
+	// Environments missing from the file, removed unless merging
+	Removed []string `json:"removed"`
+	// Imported environments without an auth token
+	MissingToken []string `json:"missing_token"`
+	// Header and env values whose placeholders could not be resolved, as
+	// "<env> headers.<name>" or "<env> env.<name>"
+	MissingValues []string `json:"missing_values"`
+	// Set when the import changed the default environment
+	DefaultEnvironment string `json:"default_environment,omitempty"`
+}
+
+// ImportEnvironments applies imported definitions to cfg. Each one replaces the local
+// environment of that name. Secrets given as placeholders are read from the variable
+// they name when it is set, and otherwise keep the local value, as do secrets the file
+// leaves out. Header and env values exported as placeholders are resolved the same way;
+// a header left unresolved keeps its placeholder, read from the variable when requests
+// are made, and an env variable left unresolved is dropped. Without merge, environments
+// missing from the file are removed and the file's default environment is used.
+func ImportEnvironments(cfg *types.Config, imported *types.Config, merge bool) *EnvironmentImport {
+	result := &EnvironmentImport{Added: []string{}, Updated: []string{}, Unchanged: []string{}, Removed: []string{}, MissingToken: []string{}, MissingValues: []string{}}
+	if cfg.Environments == nil {
+		cfg.Environments = make(map[string]*types.Environment)
+	}
+
+	for _, name := range sortedEnvironmentNames(imported.Environments) {
+		env := *imported.Environments[name]
+		local, exists := cfg.Environments[name]
+		if !exists {
+			local = &types.Environment{}
+		}
+		env.AuthToken = importSecret(env.AuthToken, local.AuthToken)
+		env.ClientKey = importSecret(env.ClientKey, local.ClientKey)
+		var missingHeaders, missingEnv []string
+		env.Headers, missingHeaders = importValues(name, "headers", env.Headers, local.Headers, true)
+		env.Env, missingEnv = importValues(name, "env", env.Env, local.Env, false)
+		for _, missing := range append(missingHeaders, missingEnv...) {
+			result.MissingValues = append(result.MissingValues, name+" "+missing)
+		}
+		switch {
+		case !exists:
+			result.Added = append(result.Added, name)
+		case reflect.DeepEqual(*local, env):
+			result.Unchanged = append(result.Unchanged, name)
+		default:
+			result.Updated = append(result.Updated, name)
+		}
+		if env.AuthToken == "" {
+			result.MissingToken = append(result.MissingToken, name)
+		}
+		cfg.Environments[name] = &env
+	}
+
+	if !merge {
+		for _, name := range sortedEnvironmentNames(cfg.Environments) {
+			if imported.Environments[name] == nil {
+				delete(cfg.Environments, name)
+				result.Removed = append(result.Removed, name)
+			}
+		}
+	}
+
+	defaultEnv := cfg.DefaultEnvironment
+	if (!merge && imported.DefaultEnvironment != "") || cfg.Environments[defaultEnv] == nil {
+		defaultEnv = imported.DefaultEnvironment
+		if defaultEnv == "" {
+			defaultEnv = sortedEnvironmentNames(cfg.Environments)[0]
+		}
+	}
+	if defaultEnv != cfg.DefaultEnvironment {
+		cfg.DefaultEnvironment = defaultEnv
+		result.DefaultEnvironment = defaultEnv
+	}
+	return result
+}
+
+// importSecret resolves an imported secret: a placeholder reads the variable it names
+// and an empty value keeps the local one
+func importSecret(value, local string) string {
+	if match := placeholderPattern.FindStringSubmatch(value); match != nil {
+		if resolved := os.Getenv(match[1]); resolved != "" {
+			return resolved
+		}
+		return local
+	}
+	if value == "" {
+		return local
+	}
+	return value
+}
+
+// importValues resolves the placeholders ExportEnvironments left in a header or env
+// map, like importSecret, and returns the values left unresolved as "<field>.<name>".
+// Those keep their placeholder when keepUnresolved is set and are dropped otherwise.
+// Other values, such as references to variables in headers, are kept as they are.
+func importValues(envName, field string, values, local map[string]string, keepUnresolved bool) (map[string]string, []string) {
+	if values == nil {
+		return nil, nil
+	}
+	imported := make(map[string]string, len(values))
+	var missing []string
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		value := values[name]
+		if value == ValuePlaceholder(envName, field, name) {
+			value = importSecret(value, local[name])
+			if value == "" {
+				missing = append(missing, field+"."+name)
+				if !keepUnresolved {
+					continue
+				}
+				value = values[name]
+			}
+		}
+		imported[name] = value
+	}
+	return imported, missing
+}
+
+// LegacyEnvironment returns the environment described by a config file in the legacy
+// layout, with top-level dash_url, auth_token and org_id keys instead of environments,
+// as the "default" environment. It is nil when the file has environments or none of
+// those keys. Values come from the file alone, never from environment variables.
+func (m *Manager) LegacyEnvironment() (*types.Environment, error) {
+	file := m.ConfigFileUsed()
+	if file == "" || m.InConfigFile("environments") {
+		return nil, nil
+	}
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetConfigType(ConfigFileType)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	env := &types.Environment{
+		Name:         "default",
+		DashboardURL: v.GetString("dash_url"),
+		AuthToken:    v.GetString("auth_token"),
+		OrgID:        v.GetString("org_id"),
+	}
+	if env.DashboardURL == "" && env.AuthToken == "" && env.OrgID == "" {
+		return nil, nil
+	}
+	return env, nil
+}
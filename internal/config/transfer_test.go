@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/pkg/types"
+)
+
+func TestExportEnvironments(t *testing.T) {
+	cfg := &types.Config{
+		DefaultEnvironment: "staging",
+		Remote:             &types.RemoteSource{URL: "https://platform.example.com/environments.yaml"},
+		Environments: map[string]*types.Environment{
+			"staging": {Name: "staging", DashboardURL: "https://staging.example.com", AuthToken: "secret", OrgID: "org", ClientCert: "/certs/cli.pem", ClientKey: "/certs/cli.key", Shared: true,
+				Headers: map[string]string{"X-Proxy-Auth": "proxy-secret", "X-Team": "${TEAM_TOKEN}"},
+				Env:     map[string]string{"TYK_GATEWAY_URL": "http://gateway.internal"}},
+			"production": {Name: "production", DashboardURL: "https://example.com", OrgID: "org"},
+		},
+	}
+
+	exported, err := ExportEnvironments(cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", exported.DefaultEnvironment)
+	assert.Nil(t, exported.Remote)
+	staging := exported.Environments["staging"]
+	assert.Equal(t, "${TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN}", staging.AuthToken)
+	assert.Equal(t, "${TYK_ENVIRONMENTS_STAGING_CLIENT_KEY}", staging.ClientKey)
+	assert.Equal(t, map[string]string{"X-Proxy-Auth": "${TYK_ENVIRONMENTS_STAGING_HEADERS_X_PROXY_AUTH}", "X-Team": "${TEAM_TOKEN}"}, staging.Headers)
+	assert.Equal(t, map[string]string{"TYK_GATEWAY_URL": "${TYK_ENVIRONMENTS_STAGING_ENV_TYK_GATEWAY_URL}"}, staging.Env)
+	assert.False(t, staging.Shared)
+	assert.Empty(t, exported.Environments["production"].AuthToken)
+	assert.Equal(t, "secret", cfg.Environments["staging"].AuthToken, "the local config is left untouched")
+	assert.Equal(t, "proxy-secret", cfg.Environments["staging"].Headers["X-Proxy-Auth"])
+
+	exported, err = ExportEnvironments(cfg, []string{"production"})
+	require.NoError(t, err)
+	assert.Len(t, exported.Environments, 1)
+	assert.Empty(t, exported.DefaultEnvironment)
+
+	_, err = ExportEnvironments(cfg, []string{"missing"})
+	assert.Error(t, err)
+}
+
+func TestImportEnvironments(t *testing.T) {
+	imported, err := ParseImport("envs.yaml", []byte(`default_environment: staging
+environments:
+  staging:
+    dashboard_url: https://staging.example.com
+    org_id: org
+    auth_token: ${TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN}
+    headers:
+      X-Proxy-Auth: ${TYK_ENVIRONMENTS_STAGING_HEADERS_X_PROXY_AUTH}
+      X-Team: ${TEAM_TOKEN}
+    env:
+      TYK_GATEWAY_URL: ${TYK_ENVIRONMENTS_STAGING_ENV_TYK_GATEWAY_URL}
+  production:
+    dashboard_url: https://example.com
+    org_id: org
+    auth_token: ${TYK_ENVIRONMENTS_PRODUCTION_AUTH_TOKEN}
+`))
+	require.NoError(t, err)
+	t.Setenv("TYK_ENVIRONMENTS_STAGING_AUTH_TOKEN", "")
+	t.Setenv("TYK_ENVIRONMENTS_PRODUCTION_AUTH_TOKEN", "from-env")
+	t.Setenv("TYK_ENVIRONMENTS_STAGING_HEADERS_X_PROXY_AUTH", "proxy-secret")
+	t.Setenv("TYK_ENVIRONMENTS_STAGING_ENV_TYK_GATEWAY_URL", "")
+
+	local := func() *types.Config {
+		return &types.Config{
+			DefaultEnvironment: "dev",
+			Environments: map[string]*types.Environment{
+				"dev":        {Name: "dev", DashboardURL: "http://localhost:3000", AuthToken: "dev-token", OrgID: "org"},
+				"production": {Name: "production", DashboardURL: "https://old.example.com", AuthToken: "prod-token", OrgID: "org"},
+			},
+		}
+	}
+
+	cfg := local()
+	result := ImportEnvironments(cfg, imported, true)
+	assert.Equal(t, []string{"staging"}, result.Added)
+	assert.Equal(t, []string{"production"}, result.Updated)
+	assert.Empty(t, result.Removed)
+	assert.Equal(t, []string{"staging"}, result.MissingToken)
+	assert.Empty(t, result.DefaultEnvironment)
+	assert.Equal(t, "dev", cfg.DefaultEnvironment)
+	assert.Equal(t, "from-env", cfg.Environments["production"].AuthToken)
+	assert.Equal(t, "https://example.com", cfg.Environments["production"].DashboardURL)
+	// References to variables are kept for requests to resolve; unresolved env values are dropped
+	assert.Equal(t, map[string]string{"x-proxy-auth": "proxy-secret", "x-team": "${TEAM_TOKEN}"}, cfg.Environments["staging"].Headers)
+	assert.Empty(t, cfg.Environments["staging"].Env)
+	assert.Equal(t, []string{"staging env.tyk_gateway_url"}, result.MissingValues)
+
+	t.Setenv("TYK_ENVIRONMENTS_PRODUCTION_AUTH_TOKEN", "")
+	cfg = local()
+	result = ImportEnvironments(cfg, imported, false)
+	assert.Equal(t, []string{"dev"}, result.Removed)
+	assert.Equal(t, "staging", result.DefaultEnvironment)
+	assert.Equal(t, "prod-token", cfg.Environments["production"].AuthToken, "unresolved placeholders keep the local secret")
+	assert.NotContains(t, cfg.Environments, "dev")
+
+	_, err = ParseImport("envs.yaml", []byte("environments:\n  dev:\n    dashboard_url: not-a-url\n    org_id: org\n"))
+	assert.Error(t, err)
+}
+
+func TestLegacyEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv(EnvAuthToken, "from-shell")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "tyk"), 0755))
+	file := filepath.Join(dir, "tyk", "cli.toml")
+	require.NoError(t, os.WriteFile(file, []byte("dash_url = \"http://localhost:3000\"\norg_id = \"org\"\n"), 0600))
+
+	manager := NewManager()
+	require.NoError(t, manager.LoadConfig())
+	env, err := manager.LegacyEnvironment()
+	require.NoError(t, err)
+	assert.Equal(t, &types.Environment{Name: "default", DashboardURL: "http://localhost:3000", OrgID: "org"}, env)
+
+	require.NoError(t, os.WriteFile(file, []byte("[environments.dev]\ndashboard_url = \"http://localhost:3000\"\n"), 0600))
+	manager = NewManager()
+	require.NoError(t, manager.LoadConfig())
+	env, err = manager.LegacyEnvironment()
+	require.NoError(t, err)
+	assert.Nil(t, env)
+}
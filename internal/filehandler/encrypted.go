@@ -0,0 +1,91 @@
+package filehandler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// EnvAgeIdentityFile names the age identity file that encrypted files are opened with
+const EnvAgeIdentityFile = "TYK_AGE_IDENTITY_FILE"
+
+// EncryptedExtension is the extension of age-encrypted files, after that of their content
+// (api.yaml.age)
+const EncryptedExtension = ".age"
+
+// ageIntro starts the header of every age file
+const ageIntro = "age-encryption.org/v1\n"
+
+// IsEncrypted reports whether data is an age file
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(ageIntro))
+}
+
+// ReadFile reads a file, decrypting it when it is encrypted with age. Encrypted files
+// are opened with the identities of the file named by $TYK_AGE_IDENTITY_FILE.
+func ReadFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil || !IsEncrypted(data) {
+		return data, err
+	}
+
+	identityFile := os.Getenv(EnvAgeIdentityFile)
+	if identityFile == "" {
+		return nil, fmt.Errorf("%s is encrypted with age: set %s to the identity file that opens it", path, EnvAgeIdentityFile)
+	}
+	keys, err := os.Open(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the age identity file: %w", err)
+	}
+	defer keys.Close()
+	identities, err := age.ParseIdentities(keys)
+	if err != nil {
+		return nil, fmt.Errorf("age identity file %s: %w", identityFile, err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	return plaintext, nil
+}
+
+// Encrypt encrypts data to age X25519 recipients, written age1...
+func Encrypt(data []byte, recipients []string) ([]byte, error) {
+	parsed := make([]age.Recipient, len(recipients))
+	for i, r := range recipients {
+		recipient, err := age.ParseX25519Recipient(strings.TrimSpace(r))
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = recipient
+	}
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, parsed...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// trimEncryptedExtension strips .age from a path, so that api.yaml.age is read as YAML
+func trimEncryptedExtension(path string) string {
+	if strings.EqualFold(filepath.Ext(path), EncryptedExtension) {
+		return path[:len(path)-len(EncryptedExtension)]
+	}
+	return path
+}
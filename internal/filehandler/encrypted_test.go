@@ -0,0 +1,83 @@
+package filehandler
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadAgeVector splits a file of the age test kit (c2sp.org/CCTV/age) into its
+// headers and the age file that follows them
+func loadAgeVector(t *testing.T, name string) (map[string]string, []byte) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "age", name))
+	require.NoError(t, err)
+	headers := map[string]string{}
+	r := bufio.NewReader(bytes.NewReader(data))
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			break
+		}
+		key, value, _ := strings.Cut(line, ": ")
+		headers[key] = value
+	}
+	file := data[len(data)-r.Buffered():]
+	return headers, file
+}
+
+func TestReadFile_AgeTestKit(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"x25519", "x25519_bad_tag"} {
+		t.Run(name, func(t *testing.T) {
+			headers, file := loadAgeVector(t, name)
+			identityFile := filepath.Join(dir, name+".key")
+			require.NoError(t, os.WriteFile(identityFile, []byte(headers["identity"]+"\n"), 0600))
+			encrypted := filepath.Join(dir, name+".age")
+			require.NoError(t, os.WriteFile(encrypted, file, 0600))
+			t.Setenv(EnvAgeIdentityFile, identityFile)
+			require.True(t, IsEncrypted(file))
+
+			plaintext, err := ReadFile(encrypted)
+			if headers["expect"] != "success" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			sum := sha256.Sum256(plaintext)
+			assert.Equal(t, headers["payload"], hex.EncodeToString(sum[:]))
+		})
+	}
+}
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	headers, _ := loadAgeVector(t, "x25519")
+	identity, err := age.ParseX25519Identity(headers["identity"])
+	require.NoError(t, err)
+	dir := t.TempDir()
+	identityFile := filepath.Join(dir, "key.txt")
+	require.NoError(t, os.WriteFile(identityFile, []byte("# created: today\n"+identity.String()+"\n"), 0600))
+	t.Setenv(EnvAgeIdentityFile, identityFile)
+
+	encrypted, err := Encrypt([]byte("openapi: 3.0.3\n"), []string{identity.Recipient().String()})
+	require.NoError(t, err)
+	file := filepath.Join(dir, "api.yaml.age")
+	require.NoError(t, os.WriteFile(file, encrypted, 0600))
+	plaintext, err := ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.0.3\n", string(plaintext))
+
+	_, err = Encrypt([]byte("x"), []string{"not-a-recipient"})
+	assert.Error(t, err)
+}
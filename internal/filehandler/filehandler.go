@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -36,8 +37,8 @@ func LoadFile(filePath string) (*FileInfo, error) {
 		return nil, fmt.Errorf("file does not exist: %s", filePath)
 	}
 
-	// Read file content
-	content, err := os.ReadFile(filePath)
+	// Read file content, decrypting age files
+	content, err := ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
@@ -53,7 +54,7 @@ func LoadFile(filePath string) (*FileInfo, error) {
 			return nil, fmt.Errorf("failed to parse JSON file %s: %w", filePath, err)
 		}
 	case FileTypeYAML:
-		if err := yaml.Unmarshal(content, &parsedContent); err != nil {
+		if parsedContent, err = ParseYAML(content); err != nil {
 			return nil, fmt.Errorf("failed to parse YAML file %s: %w", filePath, err)
 		}
 	default:
@@ -127,7 +128,7 @@ func ValidateFilePath(filePath string) error {
 		return fmt.Errorf("file path cannot be empty")
 	}
 
-	ext := strings.ToLower(filepath.Ext(filePath))
+	ext := strings.ToLower(filepath.Ext(trimEncryptedExtension(filePath)))
 	for _, supportedExt := range SupportedExtensions {
 		if ext == supportedExt {
 			return nil
@@ -137,6 +138,32 @@ func ValidateFilePath(filePath string) error {
 	return fmt.Errorf("unsupported file extension %s (supported: %v)", ext, SupportedExtensions)
 }
 
+// FindSpecFiles recursively collects files with supported extensions under dir, sorted by path.
+// Hidden directories (e.g. .git) are skipped.
+func FindSpecFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ValidateFilePath(path) == nil {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directory %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
 // GetOASVersion extracts the OpenAPI version from parsed content
 func GetOASVersion(content map[string]interface{}) string {
 	if openapi, ok := content["openapi"].(string); ok {
@@ -178,7 +205,7 @@ func GetOASTitle(content map[string]interface{}) string {
 
 // getFileType determines file type from extension
 func getFileType(filePath string) FileType {
-	ext := strings.ToLower(filepath.Ext(filePath))
+	ext := strings.ToLower(filepath.Ext(trimEncryptedExtension(filePath)))
 	switch ext {
 	case ".json":
 		return FileTypeJSON
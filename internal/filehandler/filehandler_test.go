@@ -371,4 +371,20 @@ func TestRealOASFiles(t *testing.T) {
 	assert.Equal(t, "1.0.0", GetOASInfoVersion(loaded))
 
 	t.Logf("✓ Successfully processed real OAS file with %d paths", len(loaded["paths"].(map[string]interface{})))
-}
\ No newline at end of file
+}
+func TestFindSpecFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.yaml", "a.json", "notes.txt", "nested/c.yml", ".git/ignored.yaml"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+	}
+
+	files, err := FindSpecFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.json"),
+		filepath.Join(dir, "b.yaml"),
+		filepath.Join(dir, "nested", "c.yml"),
+	}, files)
+}
@@ -0,0 +1,210 @@
+package filehandler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stringFields are the keys whose scalar values are always strings in an OAS document
+// or its Tyk extension. An unquoted "version: 1.10" is kept as the string "1.10" rather
+// than becoming the number 1.1. Keys ending in [] name lists of strings.
+var stringFields = map[string]bool{
+	"openapi":        true,
+	"version":        true,
+	"title":          true,
+	"summary":        true,
+	"description":    true,
+	"operationId":    true,
+	"termsOfService": true,
+	"name":           true,
+	"email":          true,
+	"url":            true,
+	"pattern":        true,
+	"$ref":           true,
+	"required[]":     true,
+	"tags[]":         true,
+}
+
+// literalFields hold example and default values, whose fields are not taken for the
+// string fields of a spec
+var literalFields = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"default":  true,
+	"enum":     true,
+	"const":    true,
+}
+
+// yaml11Booleans are the words YAML 1.1 parsers read as booleans, while YAML 1.2 ones
+// (including this CLI's) read them as strings
+var yaml11Booleans = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "on": true, "On": true, "ON": true,
+	"n": false, "N": false, "no": false, "No": false, "NO": false, "off": false, "Off": false, "OFF": false,
+}
+
+var (
+	leadingZeroInt = regexp.MustCompile(`^[-+]?0[0-9_]+$`)
+	sexagesimal    = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?$`)
+)
+
+// YAMLIssue is a construct that YAML parsers disagree on, positioned in the source
+type YAMLIssue struct {
+	Line   int
+	Column int
+	// Path leads to the offending value, or to the mapping holding an offending key
+	Path    []string
+	Message string
+}
+
+// ParseYAML parses a YAML or JSON document into the map a JSON parser would make of
+// the same content: mapping keys are always strings, and unquoted values of string
+// fields and numbers with leading zeros keep the text they were written with.
+func ParseYAML(data []byte) (map[string]interface{}, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return DecodeYAML(&root)
+}
+
+// DecodeYAML decodes a parsed document like ParseYAML. It retags the node's scalars in
+// place, so positions found in it afterwards are unchanged.
+func DecodeYAML(root *yaml.Node) (map[string]interface{}, error) {
+	preserveStrings(root, "", false)
+	var content map[string]interface{}
+	if err := root.Decode(&content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// preserveStrings retags the scalars that would otherwise lose their written form.
+// key is the mapping key node sits under, and literal is set below example values.
+func preserveStrings(node *yaml.Node, key string, literal bool) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			preserveStrings(child, key, literal)
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			preserveStrings(child, key+"[]", literal)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Kind == yaml.ScalarNode && k.Tag != "!!str" && k.Tag != "!!merge" {
+				k.Tag = "!!str"
+			}
+			preserveStrings(v, k.Value, literal || literalFields[k.Value])
+		}
+	case yaml.ScalarNode:
+		if node.Style != 0 {
+			return
+		}
+		switch {
+		case !literal && stringFields[key] && (node.Tag == "!!int" || node.Tag == "!!float" || node.Tag == "!!bool"):
+			node.Tag = "!!str"
+		case node.Tag == "!!int" && leadingZeroInt.MatchString(node.Value):
+			node.Tag = "!!str"
+		}
+	}
+}
+
+// CheckYAML reports the constructs of a document that YAML parsers read differently:
+// YAML 1.1 booleans such as on and no, numbers with leading zeros, base 60 numbers,
+// merge keys, keys that are not strings, and unquoted numbers in string fields.
+func CheckYAML(data []byte) ([]YAMLIssue, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	issues := []YAMLIssue{}
+	checkYAMLNode(&root, "", nil, false, &issues)
+	return issues, nil
+}
+
+// checkYAMLNode walks node like preserveStrings, reporting what it finds
+func checkYAMLNode(node *yaml.Node, key string, path []string, literal bool, issues *[]YAMLIssue) {
+	report := func(n *yaml.Node, format string, args ...interface{}) {
+		*issues = append(*issues, YAMLIssue{
+			Line:    n.Line,
+			Column:  n.Column,
+			Path:    append([]string{}, path...),
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			checkYAMLNode(child, key, path, literal, issues)
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			checkYAMLNode(child, key+"[]", append(path, strconv.Itoa(i)), literal, issues)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			childPath := append(path, k.Value)
+			if k.Kind == yaml.ScalarNode {
+				switch {
+				case k.Tag == "!!merge":
+					report(k, "merge key << is not part of YAML 1.2 and not every parser supports it; write the keys out")
+				case k.Style == 0 && k.Tag != "!!str":
+					report(k, "key %s is read as a %s; quote it", k.Value, scalarKind(k))
+				case k.Style == 0:
+					checkPlainScalar(k, "key "+k.Value, report)
+				}
+			}
+			checkYAMLNode(v, k.Value, childPath, literal || literalFields[k.Value], issues)
+		}
+	case yaml.ScalarNode:
+		if node.Style != 0 {
+			return
+		}
+		if kind := scalarKind(node); !literal && stringFields[key] && kind != "string" && kind != "null" {
+			report(node, "%s %s is read as a %s; quote it", strings.TrimSuffix(key, "[]"), node.Value, kind)
+			return
+		}
+		checkPlainScalar(node, node.Value, report)
+	}
+}
+
+// checkPlainScalar reports an unquoted scalar that YAML 1.1 parsers read differently
+func checkPlainScalar(node *yaml.Node, what string, report func(*yaml.Node, string, ...interface{})) {
+	value := node.Value
+	if b, ok := yaml11Booleans[value]; ok {
+		report(node, "%s is the boolean %t in YAML 1.1; quote it", what, b)
+		return
+	}
+	if leadingZeroInt.MatchString(value) {
+		if octal, err := strconv.ParseInt(strings.ReplaceAll(value, "_", ""), 8, 64); err == nil {
+			report(node, "%s is the octal number %d in YAML 1.1; quote it", what, octal)
+		} else {
+			report(node, "%s has a leading zero that YAML parsers disagree on; quote it", what)
+		}
+		return
+	}
+	if sexagesimal.MatchString(value) {
+		report(node, "%s is a base 60 number in YAML 1.1; quote it", what)
+	}
+}
+
+// scalarKind names what an unquoted scalar is read as
+func scalarKind(node *yaml.Node) string {
+	switch node.Tag {
+	case "!!int", "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
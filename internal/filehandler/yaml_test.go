@@ -0,0 +1,97 @@
+package filehandler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const riskyYAML = `openapi: 3.0
+info:
+  title: on
+  version: 1.10
+paths:
+  /zip:
+    get:
+      tags: [2024]
+      parameters:
+        - name: 1
+          in: query
+          required: true
+          example: 007
+      responses:
+        200:
+          description: ok
+components:
+  schemas:
+    Timer:
+      required: [1]
+      properties:
+        period:
+          example: {version: 2}
+          default: 1:30
+          enum: [on, off]
+`
+
+func TestParseYAML(t *testing.T) {
+	doc, err := ParseYAML([]byte(riskyYAML))
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.0", doc["openapi"])
+	assert.Equal(t, map[string]interface{}{"title": "on", "version": "1.10"}, doc["info"])
+	get := doc["paths"].(map[string]interface{})["/zip"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"2024"}, get["tags"])
+	parameter := get["parameters"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "1", parameter["name"])
+	assert.Equal(t, true, parameter["required"])
+	// YAML 1.1 would read 007 as octal; it is kept as written instead
+	assert.Equal(t, "007", parameter["example"])
+	assert.Contains(t, get["responses"], "200")
+
+	// Fields of example values are not taken for the fields of the spec
+	timer := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})["Timer"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"1"}, timer["required"])
+	period := timer["properties"].(map[string]interface{})["period"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"version": 2}, period["example"])
+
+	// Every key is a string, so the document converts to JSON
+	_, err = json.Marshal(doc)
+	assert.NoError(t, err)
+
+	_, err = ParseYAML([]byte("- a list"))
+	assert.Error(t, err)
+}
+
+func TestCheckYAML(t *testing.T) {
+	issues, err := CheckYAML([]byte(riskyYAML))
+	require.NoError(t, err)
+
+	var got []string
+	for _, issue := range issues {
+		assert.Positive(t, issue.Line)
+		assert.Positive(t, issue.Column)
+		got = append(got, issue.Message)
+	}
+	assert.Equal(t, []string{
+		"openapi 3.0 is read as a number; quote it",
+		"on is the boolean true in YAML 1.1; quote it",
+		"version 1.10 is read as a number; quote it",
+		"tags 2024 is read as a number; quote it",
+		"name 1 is read as a number; quote it",
+		"007 is the octal number 7 in YAML 1.1; quote it",
+		"key 200 is read as a number; quote it",
+		"required 1 is read as a number; quote it",
+		"1:30 is a base 60 number in YAML 1.1; quote it",
+		"on is the boolean true in YAML 1.1; quote it",
+		"off is the boolean false in YAML 1.1; quote it",
+	}, got)
+	assert.Equal(t, []string{"info", "version"}, issues[2].Path)
+	assert.Equal(t, 4, issues[2].Line)
+
+	issues, err = CheckYAML([]byte("openapi: \"3.0\"\ninfo: {version: '1.10', title: \"on\"}\nbase: &base {a: 1}\nderived:\n  <<: *base\n"))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "merge key")
+}
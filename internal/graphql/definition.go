@@ -0,0 +1,342 @@
+package graphql
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"gopkg.in/yaml.v3"
+)
+
+// Execution modes of a GraphQL API definition
+const (
+	// ModeProxyOnly passes queries through to an upstream GraphQL server
+	ModeProxyOnly = "proxyOnly"
+	// ModeExecutionEngine resolves queries on the Gateway from data sources: a
+	// Universal Data Graph
+	ModeExecutionEngine = "executionEngine"
+)
+
+// Data source kinds
+const (
+	KindREST    = "REST"
+	KindGraphQL = "GraphQL"
+)
+
+// DataSource is an upstream a Universal Data Graph resolves fields from
+type DataSource struct {
+	Name string `yaml:"name" json:"name"`
+	// Kind is REST or GraphQL
+	Kind    string            `yaml:"kind" json:"kind"`
+	URL     string            `yaml:"url" json:"url"`
+	Method  string            `yaml:"method,omitempty" json:"method,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	// Body is the request body of REST data sources; it may use {{.arguments.x}}
+	Body string `yaml:"body,omitempty" json:"body,omitempty"`
+	// Fields are the fields the data source resolves, as Type.field
+	Fields []string `yaml:"fields" json:"fields"`
+}
+
+// DataSourceFile is the file --data-sources reads
+type DataSourceFile struct {
+	DataSources []DataSource `yaml:"data_sources"`
+}
+
+// LoadDataSources reads a YAML or JSON data source file
+func LoadDataSources(path string) ([]DataSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file DataSourceFile
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &file); err != nil {
+		return nil, fmt.Errorf("invalid data source file: %w", err)
+	}
+	if len(file.DataSources) == 0 {
+		return nil, fmt.Errorf("no data_sources are defined")
+	}
+	return file.DataSources, nil
+}
+
+// Options describe a GraphQL API to create or update
+type Options struct {
+	Name       string
+	ListenPath string
+	OrgID      string
+	// Schema is the schema in SDL; empty keeps the current schema on update
+	Schema string
+	// UpstreamURL is the GraphQL server a proxy passes queries to
+	UpstreamURL string
+	// DataSources make the API a Universal Data Graph; nil keeps the current ones on update
+	DataSources []DataSource
+	// Keyless opens the API without authentication; otherwise it takes auth tokens
+	Keyless bool
+	// Playground serves GraphiQL under the listen path at /playground
+	Playground bool
+}
+
+// NewDefinition returns the classic API definition of a GraphQL proxy, or of a Universal
+// Data Graph when opts has data sources
+func NewDefinition(opts Options) (map[string]interface{}, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("a name is required")
+	}
+	if opts.Schema == "" {
+		return nil, fmt.Errorf("a schema is required")
+	}
+	if opts.ListenPath == "" {
+		opts.ListenPath = oas.GenerateListenPath(opts.Name)
+	}
+	if !strings.HasPrefix(opts.ListenPath, "/") {
+		return nil, fmt.Errorf("listen path must start with '/' (got '%s')", opts.ListenPath)
+	}
+	if len(opts.DataSources) == 0 && opts.UpstreamURL == "" {
+		return nil, fmt.Errorf("a GraphQL proxy needs an upstream URL; give data sources instead for a Universal Data Graph")
+	}
+
+	def := map[string]interface{}{
+		"api_id":      newAPIID(),
+		"name":        opts.Name,
+		"org_id":      opts.OrgID,
+		"active":      true,
+		"use_keyless": opts.Keyless,
+		"auth":        map[string]interface{}{"auth_header_name": "Authorization"},
+		"proxy": map[string]interface{}{
+			"listen_path":       opts.ListenPath,
+			"target_url":        "",
+			"strip_listen_path": true,
+		},
+		"version_data": map[string]interface{}{
+			"not_versioned":   true,
+			"default_version": "Default",
+			"versions": map[string]interface{}{
+				"Default": map[string]interface{}{"name": "Default", "use_extended_paths": true},
+			},
+		},
+		"graphql": map[string]interface{}{
+			"enabled":                   true,
+			"version":                   "2",
+			"execution_mode":            ModeProxyOnly,
+			"type_field_configurations": []interface{}{},
+			"engine":                    map[string]interface{}{"field_configs": []interface{}{}, "data_sources": []interface{}{}},
+			"playground":                map[string]interface{}{"enabled": opts.Playground, "path": playgroundPath(opts.Playground)},
+		},
+	}
+	if err := Apply(def, opts); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// Apply sets the schema, upstream and data sources of opts that are not empty on a
+// GraphQL API definition. Giving data sources makes the API a Universal Data Graph; an
+// upstream URL without them makes it a proxy. def is left unchanged on error.
+func Apply(def map[string]interface{}, opts Options) error {
+	gql, ok := def["graphql"].(map[string]interface{})
+	if !ok || gql["enabled"] != true {
+		return fmt.Errorf("API is not a GraphQL API")
+	}
+
+	sdl := opts.Schema
+	if sdl == "" {
+		sdl, _ = gql["schema"].(string)
+	}
+	schema, err := ParseSchema(sdl)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+	if opts.UpstreamURL != "" {
+		if u, err := url.Parse(opts.UpstreamURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("upstream URL must be absolute (got '%s')", opts.UpstreamURL)
+		}
+	}
+
+	var engine map[string]interface{}
+	switch {
+	case opts.DataSources != nil:
+		if engine, err = engineConfig(schema, opts.DataSources); err != nil {
+			return err
+		}
+	case opts.UpstreamURL == "" && gql["execution_mode"] == ModeExecutionEngine:
+		// A new schema must still have the fields the data sources resolve
+		if err := checkEngineFields(schema, gql["engine"]); err != nil {
+			return err
+		}
+	}
+
+	proxy, _ := def["proxy"].(map[string]interface{})
+	if proxy == nil {
+		proxy = map[string]interface{}{}
+		def["proxy"] = proxy
+	}
+	gql["schema"] = sdl
+	switch {
+	case engine != nil:
+		gql["execution_mode"] = ModeExecutionEngine
+		gql["engine"] = engine
+		proxy["target_url"] = ""
+	case opts.UpstreamURL != "":
+		gql["execution_mode"] = ModeProxyOnly
+		gql["engine"] = map[string]interface{}{"field_configs": []interface{}{}, "data_sources": []interface{}{}}
+		proxy["target_url"] = opts.UpstreamURL
+	}
+	return nil
+}
+
+// UnresolvedFields returns the root fields of a Universal Data Graph that no data source
+// resolves, which always return null
+func UnresolvedFields(def map[string]interface{}) []string {
+	gql, _ := def["graphql"].(map[string]interface{})
+	if gql["execution_mode"] != ModeExecutionEngine {
+		return nil
+	}
+	sdl, _ := gql["schema"].(string)
+	schema, err := ParseSchema(sdl)
+	if err != nil {
+		return nil
+	}
+	resolved := map[string]bool{}
+	for _, ref := range engineFields(gql["engine"]) {
+		resolved[ref] = true
+	}
+	var unresolved []string
+	for _, ref := range schema.RootFields() {
+		if !resolved[ref] {
+			unresolved = append(unresolved, ref)
+		}
+	}
+	return unresolved
+}
+
+// engineConfig builds the execution engine configuration of a Universal Data Graph
+func engineConfig(schema *Schema, sources []DataSource) (map[string]interface{}, error) {
+	dataSources := []interface{}{}
+	fieldConfigs := []interface{}{}
+	claimed := map[string]string{}
+	for i, source := range sources {
+		if source.Name == "" {
+			source.Name = fmt.Sprintf("data source %d", i+1)
+		}
+		if u, err := url.Parse(source.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("data source '%s': url must be absolute (got '%s')", source.Name, source.URL)
+		}
+		if len(source.Fields) == 0 {
+			return nil, fmt.Errorf("data source '%s': list the fields it resolves, as Type.field", source.Name)
+		}
+
+		var kind string
+		config := map[string]interface{}{"url": source.URL, "headers": headers(source.Headers)}
+		switch strings.ToLower(source.Kind) {
+		case "rest", "":
+			kind = KindREST
+			method := strings.ToUpper(source.Method)
+			if method == "" {
+				method = "GET"
+			}
+			config["method"], config["body"], config["query"] = method, source.Body, []interface{}{}
+		case "graphql":
+			kind = KindGraphQL
+			config["method"] = "POST"
+		default:
+			return nil, fmt.Errorf("data source '%s': kind must be REST or GraphQL (got '%s')", source.Name, source.Kind)
+		}
+
+		byType := map[string][]interface{}{}
+		var types []string
+		for _, ref := range source.Fields {
+			typeName, field, ok := strings.Cut(ref, ".")
+			if !ok || !schema.HasField(typeName, field) {
+				return nil, fmt.Errorf("data source '%s': field '%s' is not in the schema", source.Name, ref)
+			}
+			if other, ok := claimed[ref]; ok {
+				return nil, fmt.Errorf("field '%s' is resolved by both '%s' and '%s'", ref, other, source.Name)
+			}
+			claimed[ref] = source.Name
+			if _, ok := byType[typeName]; !ok {
+				types = append(types, typeName)
+			}
+			byType[typeName] = append(byType[typeName], field)
+			if kind == KindREST {
+				// REST responses are the field's value, not an object holding it
+				fieldConfigs = append(fieldConfigs, map[string]interface{}{
+					"type_name":               typeName,
+					"field_name":              field,
+					"disable_default_mapping": true,
+					"path":                    []interface{}{field},
+				})
+			}
+		}
+		rootFields := make([]interface{}, len(types))
+		for i, typeName := range types {
+			rootFields[i] = map[string]interface{}{"type": typeName, "fields": byType[typeName]}
+		}
+
+		dataSources = append(dataSources, map[string]interface{}{
+			"kind":        kind,
+			"name":        source.Name,
+			"internal":    false,
+			"root_fields": rootFields,
+			"config":      config,
+		})
+	}
+	return map[string]interface{}{"field_configs": fieldConfigs, "data_sources": dataSources}, nil
+}
+
+// checkEngineFields checks every field a definition's data sources resolve is in schema
+func checkEngineFields(schema *Schema, engine interface{}) error {
+	for _, ref := range engineFields(engine) {
+		typeName, field, _ := strings.Cut(ref, ".")
+		if !schema.HasField(typeName, field) {
+			return fmt.Errorf("field '%s' resolved by a data source is not in the schema", ref)
+		}
+	}
+	return nil
+}
+
+// engineFields returns the Type.field references an engine configuration resolves
+func engineFields(engine interface{}) []string {
+	config, _ := engine.(map[string]interface{})
+	sources, _ := config["data_sources"].([]interface{})
+	var refs []string
+	for _, item := range sources {
+		source, _ := item.(map[string]interface{})
+		rootFields, _ := source["root_fields"].([]interface{})
+		for _, item := range rootFields {
+			root, _ := item.(map[string]interface{})
+			typeName, _ := root["type"].(string)
+			fields, _ := root["fields"].([]interface{})
+			for _, field := range fields {
+				refs = append(refs, typeName+"."+fmt.Sprint(field))
+			}
+		}
+	}
+	slices.Sort(refs)
+	return slices.Compact(refs)
+}
+
+func headers(values map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		out[name] = value
+	}
+	return out
+}
+
+func playgroundPath(enabled bool) string {
+	if enabled {
+		return "/playground"
+	}
+	return ""
+}
+
+// newAPIID returns a random API ID in the Gateway's format
+func newAPIID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const usersSchema = `
+"""
+The users graph
+"""
+schema { query: RootQuery }
+
+type RootQuery {
+  "A user by ID"
+  user(id: ID!): User
+  users(first: Int = 10, after: String): [User!]! @deprecated(reason: "use search")
+  search(filter: UserFilter): [User]
+}
+
+# Reviews come from another service
+type User implements Node @key(fields: "id") {
+  id: ID!
+  name: String
+  reviews: [Review]
+}
+
+interface Node { id: ID! }
+
+type Review { id: ID! body: String }
+
+input UserFilter { name: String }
+
+enum Role { ADMIN USER }
+
+scalar DateTime
+
+union Result = User | Review
+
+extend type RootQuery { me: User }
+`
+
+func TestParseSchema(t *testing.T) {
+	schema, err := ParseSchema(usersSchema)
+	require.NoError(t, err)
+	assert.Equal(t, "RootQuery", schema.Query)
+	assert.Equal(t, []string{"user", "users", "search", "me"}, schema.Fields["RootQuery"])
+	assert.Equal(t, []string{"id", "name", "reviews"}, schema.Fields["User"])
+	assert.True(t, schema.HasField("Node", "id"))
+	assert.False(t, schema.HasField("UserFilter", "name"), "input fields are not resolved")
+	assert.Equal(t, []string{"RootQuery.user", "RootQuery.users", "RootQuery.search", "RootQuery.me"}, schema.RootFields())
+
+	_, err = ParseSchema("type Mutation { ping: String }")
+	assert.EqualError(t, err, "schema has no Query type")
+	_, err = ParseSchema("type Query { ping: String")
+	assert.EqualError(t, err, "type Query is not closed")
+}
+
+func TestNewDefinition_Proxy(t *testing.T) {
+	def, err := NewDefinition(Options{Name: "Countries", Schema: "type Query { countries: [String] }", UpstreamURL: "https://countries.example.com/graphql", OrgID: "org"})
+	require.NoError(t, err)
+
+	gql := def["graphql"].(map[string]interface{})
+	assert.Equal(t, true, gql["enabled"])
+	assert.Equal(t, ModeProxyOnly, gql["execution_mode"])
+	proxy := def["proxy"].(map[string]interface{})
+	assert.Equal(t, "https://countries.example.com/graphql", proxy["target_url"])
+	assert.Equal(t, "/countries/", proxy["listen_path"])
+	assert.Equal(t, false, def["use_keyless"])
+	assert.Len(t, def["api_id"], 32)
+	assert.Empty(t, UnresolvedFields(def))
+
+	_, err = NewDefinition(Options{Name: "Countries", Schema: "type Query { countries: [String] }"})
+	assert.ErrorContains(t, err, "needs an upstream URL")
+}
+
+func TestNewDefinition_DataGraph(t *testing.T) {
+	sources := []DataSource{
+		{Name: "users", URL: "https://users.example.com/users/{{.arguments.id}}", Fields: []string{"RootQuery.user", "RootQuery.me"}},
+		{Name: "reviews", Kind: "graphql", URL: "https://reviews.example.com/query", Headers: map[string]string{"Authorization": "Bearer t"}, Fields: []string{"User.reviews"}},
+	}
+	def, err := NewDefinition(Options{Name: "Users", Schema: usersSchema, DataSources: sources})
+	require.NoError(t, err)
+
+	gql := def["graphql"].(map[string]interface{})
+	assert.Equal(t, ModeExecutionEngine, gql["execution_mode"])
+	engine := gql["engine"].(map[string]interface{})
+	dataSources := engine["data_sources"].([]interface{})
+	require.Len(t, dataSources, 2)
+	users := dataSources[0].(map[string]interface{})
+	assert.Equal(t, KindREST, users["kind"])
+	assert.Equal(t, "GET", users["config"].(map[string]interface{})["method"])
+	reviews := dataSources[1].(map[string]interface{})
+	assert.Equal(t, KindGraphQL, reviews["kind"])
+	// Only REST fields need their responses mapped
+	assert.Len(t, engine["field_configs"], 2)
+	assert.Equal(t, []string{"RootQuery.users", "RootQuery.search"}, UnresolvedFields(def))
+
+	_, err = NewDefinition(Options{Name: "Users", Schema: usersSchema, DataSources: []DataSource{{Name: "users", URL: "https://users.example.com", Fields: []string{"Query.user"}}}})
+	assert.EqualError(t, err, "data source 'users': field 'Query.user' is not in the schema")
+
+	duplicate := append(sources, DataSource{Name: "legacy", URL: "https://legacy.example.com", Fields: []string{"RootQuery.me"}})
+	_, err = NewDefinition(Options{Name: "Users", Schema: usersSchema, DataSources: duplicate})
+	assert.EqualError(t, err, "field 'RootQuery.me' is resolved by both 'users' and 'legacy'")
+}
+
+func TestApply(t *testing.T) {
+	def, err := NewDefinition(Options{Name: "Users", Schema: usersSchema, DataSources: []DataSource{{Name: "users", URL: "https://users.example.com", Fields: []string{"RootQuery.me"}}}})
+	require.NoError(t, err)
+
+	// A new schema must keep the fields the data sources resolve
+	err = Apply(def, Options{Schema: "type Query { ping: String }"})
+	assert.EqualError(t, err, "field 'RootQuery.me' resolved by a data source is not in the schema")
+
+	// An upstream turns the data graph into a proxy, keeping the schema
+	require.NoError(t, Apply(def, Options{UpstreamURL: "https://users.example.com/graphql"}))
+	gql := def["graphql"].(map[string]interface{})
+	assert.Equal(t, ModeProxyOnly, gql["execution_mode"])
+	assert.Equal(t, usersSchema, gql["schema"])
+
+	assert.EqualError(t, Apply(map[string]interface{}{"name": "REST"}, Options{}), "API is not a GraphQL API")
+}
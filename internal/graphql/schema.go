@@ -0,0 +1,285 @@
+// Package graphql builds the Tyk API definitions of GraphQL proxies and Universal Data
+// Graphs, which the Gateway serves from classic (non-OAS) API definitions.
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Schema is what the CLI needs to know about a GraphQL schema: its root operation
+// types and the fields of every object type
+type Schema struct {
+	Query        string
+	Mutation     string
+	Subscription string
+	// Fields are the field names of each object and interface type, in schema order
+	Fields map[string][]string
+}
+
+// ParseSchema reads the type definitions of a schema in the GraphQL schema definition
+// language. It checks the structure the Gateway relies on, not every rule of the spec.
+func ParseSchema(sdl string) (*Schema, error) {
+	tokens, err := tokenize(sdl)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{Query: "Query", Mutation: "Mutation", Subscription: "Subscription", Fields: map[string][]string{}}
+	p := &parser{tokens: tokens}
+
+	for p.pos < len(p.tokens) {
+		token := p.next()
+		if isString(token) {
+			// A description
+			continue
+		}
+		if token == "extend" {
+			token = p.next()
+		}
+		switch token {
+		case "schema":
+			if err := p.parseSchemaDefinition(s); err != nil {
+				return nil, err
+			}
+		case "type", "interface", "input":
+			name := p.next()
+			fields, err := p.parseFields(name)
+			if err != nil {
+				return nil, err
+			}
+			if token != "input" {
+				s.Fields[name] = append(s.Fields[name], fields...)
+			}
+		case "enum", "scalar", "union", "directive":
+			if err := p.skipDefinition(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected '%s' at the top level of the schema", token)
+		}
+	}
+
+	if _, ok := s.Fields[s.Query]; !ok {
+		return nil, fmt.Errorf("schema has no %s type", s.Query)
+	}
+	return s, nil
+}
+
+// HasField reports whether typeName is an object or interface type with field
+func (s *Schema) HasField(typeName, field string) bool {
+	return slices.Contains(s.Fields[typeName], field)
+}
+
+// RootFields returns the fields of the query, mutation and subscription types as
+// Type.field references
+func (s *Schema) RootFields() []string {
+	var refs []string
+	for _, root := range []string{s.Query, s.Mutation, s.Subscription} {
+		for _, field := range s.Fields[root] {
+			refs = append(refs, root+"."+field)
+		}
+	}
+	return refs
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) next() string {
+	if p.pos >= len(p.tokens) {
+		p.pos++
+		return ""
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// skipTo skips to the next top-level brace of a definition, over implements clauses and
+// directives, and reports whether the definition has a body
+func (p *parser) skipTo() bool {
+	for depth := 0; p.pos < len(p.tokens); {
+		switch p.peek() {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case "{":
+			if depth == 0 {
+				return true
+			}
+		default:
+			if depth == 0 && isDefinitionKeyword(p.peek()) && p.pos > 0 && p.tokens[p.pos-1] != "@" && p.tokens[p.pos-1] != ":" {
+				return false
+			}
+		}
+		p.pos++
+	}
+	return false
+}
+
+func (p *parser) parseSchemaDefinition(s *Schema) error {
+	if !p.skipTo() {
+		return nil
+	}
+	p.next()
+	for {
+		switch operation := p.next(); operation {
+		case "":
+			return fmt.Errorf("schema definition is not closed")
+		case "}":
+			return nil
+		default:
+			if p.next() != ":" {
+				return fmt.Errorf("expected ':' after '%s' in the schema definition", operation)
+			}
+			typeName := p.next()
+			switch operation {
+			case "query":
+				s.Query = typeName
+			case "mutation":
+				s.Mutation = typeName
+			case "subscription":
+				s.Subscription = typeName
+			}
+		}
+	}
+}
+
+// parseFields reads the field names of a type body; argument lists, types and
+// directives are skipped
+func (p *parser) parseFields(typeName string) ([]string, error) {
+	if !p.skipTo() {
+		return nil, nil
+	}
+	p.next()
+	var fields []string
+	for {
+		token := p.next()
+		switch token {
+		case "":
+			return nil, fmt.Errorf("type %s is not closed", typeName)
+		case "}":
+			return fields, nil
+		}
+		if isString(token) {
+			// A description
+			continue
+		}
+		fields = append(fields, token)
+		// Skip the arguments, type, default value and directives up to the next field
+		depth := 0
+		for p.pos < len(p.tokens) {
+			next := p.peek()
+			if depth == 0 && (next == "}" || isName(next) && p.tokens[p.pos-1] != ":" && p.tokens[p.pos-1] != "@" && p.tokens[p.pos-1] != "=" && p.tokens[p.pos-1] != "(") {
+				break
+			}
+			switch next {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth--
+			}
+			p.pos++
+		}
+	}
+}
+
+// skipDefinition skips an enum, scalar, union or directive definition
+func (p *parser) skipDefinition() error {
+	if p.skipTo() {
+		for depth := 0; ; {
+			switch p.next() {
+			case "":
+				return fmt.Errorf("definition is not closed")
+			case "{":
+				depth++
+			case "}":
+				depth--
+				if depth == 0 {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isDefinitionKeyword(token string) bool {
+	switch token {
+	case "type", "interface", "input", "enum", "scalar", "union", "directive", "schema", "extend":
+		return true
+	}
+	return false
+}
+
+func isName(token string) bool {
+	return token != "" && isNameStart(token[0])
+}
+
+func isString(token string) bool {
+	return strings.HasPrefix(token, `"`)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// tokenize splits SDL into names, punctuation and strings, dropping comments and
+// descriptions
+func tokenize(sdl string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(sdl); {
+		ch := sdl[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			i++
+		case ch == '#':
+			end := strings.IndexByte(sdl[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(sdl[i:], `"""`):
+			end := strings.Index(sdl[i+3:], `"""`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated block string")
+			}
+			i += end + 6
+		case ch == '"':
+			j := i + 1
+			for j < len(sdl) && sdl[j] != '"' && sdl[j] != '\n' {
+				if sdl[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(sdl) || sdl[j] != '"' {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, sdl[i:j+1])
+			i = j + 1
+		case isNameStart(ch) || ch >= '0' && ch <= '9' || ch == '-':
+			j := i + 1
+			for j < len(sdl) && (isNameStart(sdl[j]) || sdl[j] >= '0' && sdl[j] <= '9' || sdl[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, sdl[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(ch))
+			i++
+		}
+	}
+	return tokens, nil
+}
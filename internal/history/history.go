@@ -0,0 +1,190 @@
+// Package history keeps local copies of deployed API definitions as they were before
+// the CLI overwrote them, so a mistaken update can be rolled back.
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvDir overrides the history store location
+const EnvDir = "TYK_HISTORY_DIR"
+
+// MaxRevisions is how many revisions are kept per API; older ones are pruned
+const MaxRevisions = 20
+
+// ErrNotFound is returned by Load when the revision does not exist
+var ErrNotFound = errors.New("revision not found")
+
+// Revision is a deployed API definition as it was before an update replaced it
+type Revision struct {
+	Number      int       `json:"number"`
+	Time        time.Time `json:"time"`
+	Environment string    `json:"environment"`
+	APIID       string    `json:"api_id"`
+	Name        string    `json:"name"`
+	Hash        string    `json:"hash"`
+	// Document is omitted when revisions are listed
+	Document map[string]interface{} `json:"document,omitempty"`
+}
+
+// Dir returns the history store location: $TYK_HISTORY_DIR, or history next to the
+// CLI configuration
+func Dir() (string, error) {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "tyk", "history"), nil
+}
+
+// apiDir is where the revisions of one API in one environment are stored
+func apiDir(env, apiID string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, safeName(env), safeName(apiID)), nil
+}
+
+// Save stores doc as the next revision of the API. Nothing is stored when doc is
+// identical to the latest revision, which is returned instead.
+func Save(env, apiID, name string, doc map[string]interface{}) (*Revision, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	hash := "sha256:" + hex.EncodeToString(sum[:])
+
+	revisions, err := List(env, apiID)
+	if err != nil {
+		return nil, err
+	}
+	number := 1
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		if latest.Hash == hash {
+			return &latest, nil
+		}
+		number = latest.Number + 1
+	}
+
+	dir, err := apiDir(env, apiID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	revision := &Revision{
+		Number:      number,
+		Time:        time.Now().UTC(),
+		Environment: env,
+		APIID:       apiID,
+		Name:        name,
+		Hash:        hash,
+		Document:    doc,
+	}
+	out, err := json.MarshalIndent(revision, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, fileName(number)), out, 0600); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(revisions)+1-MaxRevisions; i++ {
+		os.Remove(filepath.Join(dir, fileName(revisions[i].Number)))
+	}
+	return revision, nil
+}
+
+// List returns the stored revisions of an API without their documents, oldest first
+func List(env, apiID string) ([]Revision, error) {
+	dir, err := apiDir(env, apiID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Revision{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []Revision{}
+	for _, entry := range entries {
+		number, ok := parseFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		revision, err := Load(env, apiID, number)
+		if err != nil {
+			return nil, err
+		}
+		revision.Document = nil
+		revisions = append(revisions, *revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Number < revisions[j].Number })
+	return revisions, nil
+}
+
+// Load returns one revision with its document
+func Load(env, apiID string, number int) (*Revision, error) {
+	dir, err := apiDir(env, apiID)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fileName(number))
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("revision %d of API '%s': %w", number, apiID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var revision Revision
+	if err := json.Unmarshal(data, &revision); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &revision, nil
+}
+
+func fileName(number int) string {
+	return fmt.Sprintf("%06d.json", number)
+}
+
+func parseFileName(name string) (int, bool) {
+	if !strings.HasSuffix(name, ".json") {
+		return 0, false
+	}
+	number, err := strconv.Atoi(strings.TrimSuffix(name, ".json"))
+	return number, err == nil && number > 0
+}
+
+// safeName keeps environment names and API IDs usable as directory names
+func safeName(s string) string {
+	if s == "" || s == "." || s == ".." {
+		return "_" + s
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator || r == ':' {
+			return '_'
+		}
+		return r
+	}, s)
+}
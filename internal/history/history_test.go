@@ -0,0 +1,56 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveListLoad(t *testing.T) {
+	t.Setenv(EnvDir, t.TempDir())
+
+	revisions, err := List("prod", "api-1")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	first, err := Save("prod", "api-1", "Users", map[string]interface{}{"info": map[string]interface{}{"version": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Number)
+
+	// Saving the same document again does not add a revision
+	again, err := Save("prod", "api-1", "Users", map[string]interface{}{"info": map[string]interface{}{"version": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, again.Number)
+
+	_, err = Save("prod", "api-1", "Users", map[string]interface{}{"info": map[string]interface{}{"version": "2"}})
+	require.NoError(t, err)
+	_, err = Save("dev", "api-1", "Users", map[string]interface{}{"info": map[string]interface{}{"version": "3"}})
+	require.NoError(t, err)
+
+	revisions, err = List("prod", "api-1")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[1].Number)
+	assert.Nil(t, revisions[1].Document)
+
+	revision, err := Load("prod", "api-1", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "1", revision.Document["info"].(map[string]interface{})["version"])
+
+	_, err = Load("prod", "api-1", 7)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSave_Prunes(t *testing.T) {
+	t.Setenv(EnvDir, t.TempDir())
+	for i := 0; i < MaxRevisions+5; i++ {
+		_, err := Save("prod", "../api", "Users", map[string]interface{}{"n": i})
+		require.NoError(t, err)
+	}
+	revisions, err := List("prod", "../api")
+	require.NoError(t, err)
+	require.Len(t, revisions, MaxRevisions)
+	assert.Equal(t, 6, revisions[0].Number)
+	assert.Equal(t, MaxRevisions+5, revisions[len(revisions)-1].Number)
+}
@@ -0,0 +1,233 @@
+package importmanifest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+)
+
+// DefaultSpecPath is where discovered services are expected to serve their spec
+const DefaultSpecPath = "/openapi.json"
+
+// Kubernetes service annotations that override the discovery settings per service
+const (
+	AnnotationSpecPath   = "tyk.io/spec-path"
+	AnnotationListenPath = "tyk.io/listen-path"
+)
+
+// Consul service meta keys that override the discovery settings per service
+const (
+	MetaSpecPath   = "tyk-spec-path"
+	MetaListenPath = "tyk-listen-path"
+)
+
+// KubernetesSource finds the services of a namespace matching a label selector; each is
+// imported from the spec it serves, with itself as the upstream
+type KubernetesSource struct {
+	// APIServer defaults to the in-cluster address
+	APIServer     string `yaml:"api_server"`
+	Namespace     string `yaml:"namespace"`
+	LabelSelector string `yaml:"label_selector"`
+	// SpecPath is the path services serve their spec on, DefaultSpecPath by default
+	SpecPath string `yaml:"spec_path"`
+	// Token authenticates to the API server; in a cluster the service account token is
+	// used when it is empty
+	Token string `yaml:"token"`
+}
+
+// The token and CA certificate of the pod's service account
+const (
+	kubernetesTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	kubernetesCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Discover lists the matching services
+func (k *KubernetesSource) Discover(ctx context.Context, client *http.Client) ([]Entry, error) {
+	apiServer := k.APIServer
+	if apiServer == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" {
+			return nil, fmt.Errorf("api_server is required outside a cluster")
+		}
+		apiServer = "https://" + net.JoinHostPort(host, port)
+		// The in-cluster API server has a certificate of the cluster's CA
+		if pem, err := os.ReadFile(kubernetesCAFile); err == nil {
+			pool := x509.NewCertPool()
+			pool.AppendCertsFromPEM(pem)
+			client = &http.Client{Timeout: client.Timeout, Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+		}
+	}
+	namespace := k.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	token := k.Token
+	if token == "" {
+		if data, err := os.ReadFile(kubernetesTokenFile); err == nil {
+			token = strings.TrimSpace(string(data))
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/namespaces/%s/services", strings.TrimSuffix(apiServer, "/"), url.PathEscape(namespace))
+	if k.LabelSelector != "" {
+		endpoint += "?labelSelector=" + url.QueryEscape(k.LabelSelector)
+	}
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name        string            `json:"name"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"metadata"`
+			Spec struct {
+				Ports []struct {
+					Port int `json:"port"`
+				} `json:"ports"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	headers := map[string]string{}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	if err := getJSON(ctx, client, endpoint, headers, &list); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, service := range list.Items {
+		if len(service.Spec.Ports) == 0 {
+			continue
+		}
+		meta := service.Metadata
+		port := service.Spec.Ports[0].Port
+		upstream := fmt.Sprintf("http://%s.%s.svc:%d", meta.Name, namespace, port)
+		if port == 443 {
+			upstream = fmt.Sprintf("https://%s.%s.svc", meta.Name, namespace)
+		}
+		entries = append(entries, discoveredEntry(upstream, specPath(meta.Annotations[AnnotationSpecPath], k.SpecPath), meta.Annotations[AnnotationListenPath]))
+	}
+	return entries, nil
+}
+
+// ConsulSource finds the services of a Consul catalog carrying a tag; each is imported
+// from the spec its first instance serves, with that instance as the upstream
+type ConsulSource struct {
+	// Address defaults to the local agent, http://127.0.0.1:8500
+	Address string `yaml:"address"`
+	Tag     string `yaml:"tag"`
+	// SpecPath is the path services serve their spec on, DefaultSpecPath by default
+	SpecPath string `yaml:"spec_path"`
+	Token    string `yaml:"token"`
+	// Scheme the services are called with, http by default
+	Scheme string `yaml:"scheme"`
+}
+
+// Discover lists the tagged services
+func (c *ConsulSource) Discover(ctx context.Context, client *http.Client) ([]Entry, error) {
+	address := strings.TrimSuffix(c.Address, "/")
+	if address == "" {
+		address = "http://127.0.0.1:8500"
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	headers := map[string]string{}
+	if c.Token != "" {
+		headers["X-Consul-Token"] = c.Token
+	}
+
+	var services map[string][]string
+	if err := getJSON(ctx, client, address+"/v1/catalog/services", headers, &services); err != nil {
+		return nil, err
+	}
+	var names []string
+	for name, tags := range services {
+		if c.Tag == "" || slices.Contains(tags, c.Tag) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var entries []Entry
+	for _, name := range names {
+		endpoint := address + "/v1/catalog/service/" + url.PathEscape(name)
+		if c.Tag != "" {
+			endpoint += "?tag=" + url.QueryEscape(c.Tag)
+		}
+		var instances []struct {
+			Address        string            `json:"Address"`
+			ServiceAddress string            `json:"ServiceAddress"`
+			ServicePort    int               `json:"ServicePort"`
+			ServiceMeta    map[string]string `json:"ServiceMeta"`
+		}
+		if err := getJSON(ctx, client, endpoint, headers, &instances); err != nil {
+			return nil, err
+		}
+		if len(instances) == 0 {
+			continue
+		}
+		instance := instances[0]
+		host := instance.ServiceAddress
+		if host == "" {
+			host = instance.Address
+		}
+		upstream := fmt.Sprintf("%s://%s:%d", scheme, host, instance.ServicePort)
+		entries = append(entries, discoveredEntry(upstream, specPath(instance.ServiceMeta[MetaSpecPath], c.SpecPath), instance.ServiceMeta[MetaListenPath]))
+	}
+	return entries, nil
+}
+
+func discoveredEntry(upstream, specPath, listenPath string) Entry {
+	return Entry{
+		URL:       upstream + specPath,
+		Overrides: Overrides{UpstreamURL: upstream, ListenPath: listenPath},
+	}
+}
+
+// specPath returns the first path set, or DefaultSpecPath
+func specPath(paths ...string) string {
+	for _, path := range paths {
+		if path != "" {
+			if !strings.HasPrefix(path, "/") {
+				path = "/" + path
+			}
+			return path
+		}
+	}
+	return DefaultSpecPath
+}
+
+// getJSON fetches endpoint and decodes its JSON response into v
+func getJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s: HTTP %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: invalid response: %w", endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Package importmanifest reads the manifests of 'tyk api import-oas --manifest', which
+// list the OpenAPI specs to import in one run: spec files and URLs, and services found
+// through Kubernetes or Consul service discovery, each with optional overrides.
+package importmanifest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest lists the APIs to import
+type Manifest struct {
+	// File is the manifest path; spec file paths are relative to its directory
+	File string `yaml:"-"`
+	// Defaults are overrides applied to every entry, under the entry's own
+	Defaults  Overrides  `yaml:"defaults"`
+	APIs      []Entry    `yaml:"apis"`
+	Discovery *Discovery `yaml:"discovery"`
+}
+
+// Overrides change the Tyk extension generated for an imported spec, like the flags of
+// 'tyk api import-oas'
+type Overrides struct {
+	ListenPath   string   `yaml:"listen_path"`
+	UpstreamURL  string   `yaml:"upstream_url"`
+	CustomDomain string   `yaml:"custom_domain"`
+	Inactive     bool     `yaml:"inactive"`
+	Internal     bool     `yaml:"internal"`
+	Tags         []string `yaml:"tags"`
+	Categories   []string `yaml:"categories"`
+	Auth         string   `yaml:"auth"`
+}
+
+// Entry is one spec to import, from a file or a URL
+type Entry struct {
+	File      string `yaml:"file"`
+	URL       string `yaml:"url"`
+	Overrides `yaml:",inline"`
+}
+
+// Source returns the file or URL the entry's spec is read from
+func (e *Entry) Source() string {
+	if e.File != "" {
+		return e.File
+	}
+	return e.URL
+}
+
+// Discovery lists the service registries to find specs in
+type Discovery struct {
+	Kubernetes *KubernetesSource `yaml:"kubernetes"`
+	Consul     *ConsulSource     `yaml:"consul"`
+}
+
+// Load reads and checks a manifest
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	m.File = path
+
+	if len(m.APIs) == 0 && m.Discovery == nil {
+		return nil, fmt.Errorf("%s lists no apis and no discovery source", path)
+	}
+	if m.Defaults.ListenPath != "" || m.Defaults.UpstreamURL != "" {
+		return nil, fmt.Errorf("%s: defaults cannot set listen_path or upstream_url, which differ for every API", path)
+	}
+	for i, entry := range m.APIs {
+		if (entry.File == "") == (entry.URL == "") {
+			return nil, fmt.Errorf("%s: apis[%d] must have exactly one of file or url", path, i)
+		}
+	}
+	if m.Discovery != nil && m.Discovery.Kubernetes == nil && m.Discovery.Consul == nil {
+		return nil, fmt.Errorf("%s: discovery must configure kubernetes or consul", path)
+	}
+	return &m, nil
+}
+
+// Entries returns the manifest's entries followed by the services its discovery sources
+// find, with the defaults applied and file paths made relative to the working directory
+func (m *Manifest) Entries(ctx context.Context, client *http.Client) ([]Entry, error) {
+	var entries []Entry
+	for _, entry := range m.APIs {
+		if entry.File != "" && !filepath.IsAbs(entry.File) {
+			entry.File = filepath.Join(filepath.Dir(m.File), filepath.FromSlash(entry.File))
+		}
+		entries = append(entries, entry)
+	}
+	if m.Discovery != nil {
+		if m.Discovery.Kubernetes != nil {
+			found, err := m.Discovery.Kubernetes.Discover(ctx, client)
+			if err != nil {
+				return nil, fmt.Errorf("kubernetes discovery: %w", err)
+			}
+			entries = append(entries, found...)
+		}
+		if m.Discovery.Consul != nil {
+			found, err := m.Discovery.Consul.Discover(ctx, client)
+			if err != nil {
+				return nil, fmt.Errorf("consul discovery: %w", err)
+			}
+			entries = append(entries, found...)
+		}
+	}
+	for i := range entries {
+		entries[i].Overrides = merge(m.Defaults, entries[i].Overrides)
+	}
+	return entries, nil
+}
+
+// merge returns the overrides of entry on top of defaults; lists are combined
+func merge(defaults, entry Overrides) Overrides {
+	merged := defaults
+	if entry.ListenPath != "" {
+		merged.ListenPath = entry.ListenPath
+	}
+	if entry.UpstreamURL != "" {
+		merged.UpstreamURL = entry.UpstreamURL
+	}
+	if entry.CustomDomain != "" {
+		merged.CustomDomain = entry.CustomDomain
+	}
+	if entry.Auth != "" {
+		merged.Auth = entry.Auth
+	}
+	merged.Inactive = defaults.Inactive || entry.Inactive
+	merged.Internal = defaults.Internal || entry.Internal
+	merged.Tags = appendNew(append([]string{}, defaults.Tags...), entry.Tags...)
+	merged.Categories = appendNew(append([]string{}, defaults.Categories...), entry.Categories...)
+	return merged
+}
+
+func appendNew(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
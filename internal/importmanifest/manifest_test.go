@@ -0,0 +1,114 @@
+package importmanifest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "apis.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadAndEntries(t *testing.T) {
+	path := writeManifest(t, `defaults:
+  tags: [edge]
+  auth: apikey
+apis:
+  - file: specs/orders.yaml
+    tags: [eu, edge]
+    internal: true
+  - url: https://users.example.com/openapi.json
+    listen_path: /users/
+    auth: jwt
+`)
+	manifest, err := Load(path)
+	require.NoError(t, err)
+
+	entries, err := manifest.Entries(context.Background(), http.DefaultClient)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "specs", "orders.yaml"), entries[0].Source())
+	assert.Equal(t, []string{"edge", "eu"}, entries[0].Tags)
+	assert.True(t, entries[0].Internal)
+	assert.Equal(t, "apikey", entries[0].Auth)
+	assert.Equal(t, "https://users.example.com/openapi.json", entries[1].Source())
+	assert.Equal(t, "/users/", entries[1].ListenPath)
+	assert.Equal(t, "jwt", entries[1].Auth)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":             "defaults: {tags: [edge]}\n",
+		"file and url":      "apis:\n  - file: a.yaml\n    url: https://example.com/a.json\n",
+		"shared upstream":   "defaults: {upstream_url: https://example.com}\napis:\n  - file: a.yaml\n",
+		"no discovery kind": "discovery: {}\n",
+	} {
+		_, err := Load(writeManifest(t, content))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestKubernetesDiscover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/namespaces/shop/services", r.URL.Path)
+		assert.Equal(t, "tyk.io/import=true", r.URL.Query().Get("labelSelector"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "orders"},
+				"spec":     map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": 8080}}},
+			},
+			map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "users", "annotations": map[string]interface{}{AnnotationSpecPath: "docs/spec.yaml", AnnotationListenPath: "/people/"}},
+				"spec":     map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": 443}}},
+			},
+			map[string]interface{}{"metadata": map[string]interface{}{"name": "headless"}},
+		}})
+	}))
+	defer server.Close()
+
+	source := &KubernetesSource{APIServer: server.URL, Namespace: "shop", LabelSelector: "tyk.io/import=true", Token: "token"}
+	entries, err := source.Discover(context.Background(), server.Client())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "http://orders.shop.svc:8080/openapi.json", entries[0].URL)
+	assert.Equal(t, "http://orders.shop.svc:8080", entries[0].UpstreamURL)
+	assert.Equal(t, "https://users.shop.svc/docs/spec.yaml", entries[1].URL)
+	assert.Equal(t, "/people/", entries[1].ListenPath)
+}
+
+func TestConsulDiscover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Consul-Token"))
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			json.NewEncoder(w).Encode(map[string][]string{"orders": {"openapi"}, "billing": {"openapi", "v2"}, "cache": {}})
+		case "/v1/catalog/service/orders":
+			json.NewEncoder(w).Encode([]interface{}{map[string]interface{}{"Address": "10.0.0.1", "ServicePort": 8080}})
+		case "/v1/catalog/service/billing":
+			json.NewEncoder(w).Encode([]interface{}{map[string]interface{}{"Address": "10.0.0.2", "ServiceAddress": "billing.internal", "ServicePort": 9000, "ServiceMeta": map[string]string{MetaSpecPath: "/v3/api-docs"}}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	source := &ConsulSource{Address: server.URL, Tag: "openapi", Token: "secret"}
+	entries, err := source.Discover(context.Background(), server.Client())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "http://billing.internal:9000/v3/api-docs", entries[0].URL)
+	assert.Equal(t, "http://10.0.0.1:8080/openapi.json", entries[1].URL)
+	assert.Equal(t, "http://10.0.0.1:8080", entries[1].UpstreamURL)
+}
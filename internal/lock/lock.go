@@ -0,0 +1,173 @@
+// Package lock keeps a single instance of a long-running command per workspace with a
+// lock file. It needs no lock server or OS file locking, so it works the same on every
+// platform and on shared volumes: the holder refreshes the file while it runs, and a
+// file nobody refreshed for longer than the stale period is taken over.
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Holder identifies the process holding a lock
+type Holder struct {
+	PID      int       `json:"pid"`
+	Hostname string    `json:"hostname"`
+	Since    time.Time `json:"since"`
+}
+
+func (h Holder) String() string {
+	return fmt.Sprintf("pid %d on %s since %s", h.PID, h.Hostname, h.Since.Format(time.RFC3339))
+}
+
+// HeldError is returned by Acquire when another live process holds the lock
+type HeldError struct {
+	Path   string
+	Holder Holder
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("%s is held by %s; remove it if that process is gone", e.Path, e.Holder)
+}
+
+// Lock is a held lock file
+type Lock struct {
+	path   string
+	holder Holder
+	// Stale is set when the lock was taken over from a holder that stopped refreshing it
+	Stale *Holder
+}
+
+// Acquire creates the lock file at path. A lock file left by a holder that has not
+// refreshed it within staleAfter is taken over.
+func Acquire(path string, staleAfter time.Duration) (*Lock, error) {
+	hostname, _ := os.Hostname()
+	l := &Lock{path: path, holder: Holder{PID: os.Getpid(), Hostname: hostname, Since: time.Now().UTC()}}
+	data, err := json.Marshal(l.holder)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = file.Write(data)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return l, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		holder, modified, err := read(path)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(modified) < staleAfter {
+			return nil, &HeldError{Path: path, Holder: holder}
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		l.Stale = &holder
+	}
+	return nil, fmt.Errorf("%s was taken by another process while replacing a stale lock", path)
+}
+
+// ErrLost is returned by Refresh when the lock file was removed or taken over
+var ErrLost = errors.New("lock lost")
+
+// Refresh marks the lock as still held. It fails with ErrLost when the lock file is
+// gone or another process has taken it over, and the holder must then stop.
+func (l *Lock) Refresh() error {
+	holder, _, err := read(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s was removed", ErrLost, l.path)
+	}
+	if err != nil {
+		return err
+	}
+	if !l.holds(holder) {
+		return fmt.Errorf("%w: %s is now held by %s", ErrLost, l.path, holder)
+	}
+	now := time.Now()
+	return os.Chtimes(l.path, now, now)
+}
+
+// KeepFresh refreshes the lock every period in the background, so a holder busy for
+// longer than the stale period, such as during a long run, keeps it. It stops when stop
+// is called or the lock is lost; Refresh then reports the loss.
+func (l *Lock) KeepFresh(every time.Duration) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := l.Refresh(); errors.Is(err, ErrLost) {
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+		<-finished
+	}
+}
+
+// Release removes the lock file, unless another process has taken it over
+func (l *Lock) Release() error {
+	holder, _, err := read(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !l.holds(holder) {
+		return nil
+	}
+	return os.Remove(l.path)
+}
+
+// Path returns the path of the lock file
+func (l *Lock) Path() string {
+	return l.path
+}
+
+func (l *Lock) holds(holder Holder) bool {
+	return holder.PID == l.holder.PID && holder.Hostname == l.holder.Hostname && holder.Since.Equal(l.holder.Since)
+}
+
+// read returns the holder recorded in a lock file and when it was last refreshed
+func read(path string) (Holder, time.Time, error) {
+	var holder Holder
+	info, err := os.Stat(path)
+	if err != nil {
+		return holder, time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return holder, time.Time{}, err
+	}
+	// A lock file that cannot be parsed is still a lock; it is only replaced once stale
+	_ = json.Unmarshal(data, &holder)
+	return holder, info.ModTime(), nil
+}
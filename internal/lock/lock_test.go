@@ -0,0 +1,76 @@
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tyk-sync.lock")
+
+	held, err := Acquire(path, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, held.Stale)
+
+	_, err = Acquire(path, time.Minute)
+	var heldErr *HeldError
+	require.ErrorAs(t, err, &heldErr)
+	assert.Equal(t, os.Getpid(), heldErr.Holder.PID)
+
+	require.NoError(t, held.Refresh())
+	require.NoError(t, held.Release())
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	again, err := Acquire(path, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, again.Release())
+}
+
+func TestAcquire_Stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tyk-sync.lock")
+	require.NoError(t, os.WriteFile(path, []byte(`{"pid":1,"hostname":"old-vm"}`), 0644))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	l, err := Acquire(path, 10*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, l.Stale)
+	assert.Equal(t, "old-vm", l.Stale.Hostname)
+
+	// The previous holder must stop, and not remove the lock it lost
+	previous := &Lock{path: path, holder: *l.Stale}
+	assert.ErrorIs(t, previous.Refresh(), ErrLost)
+	require.NoError(t, previous.Release())
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+	require.NoError(t, l.Release())
+	assert.ErrorIs(t, l.Refresh(), ErrLost)
+}
+
+func TestKeepFresh(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".tyk-sync.lock")
+	held, err := Acquire(path, time.Minute)
+	require.NoError(t, err)
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	stop := held.KeepFresh(10 * time.Millisecond)
+	require.Eventually(t, func() bool {
+		info, err := os.Stat(path)
+		return err == nil && time.Since(info.ModTime()) < time.Minute
+	}, time.Second, 10*time.Millisecond)
+	stop()
+	stop()
+
+	// A lock refreshed in the background is not taken over
+	_, err = Acquire(path, time.Minute)
+	var heldErr *HeldError
+	assert.ErrorAs(t, err, &heldErr)
+	require.NoError(t, held.Release())
+}
@@ -0,0 +1,200 @@
+// Package logging writes opt-in debug output to stderr so connection problems can be
+// diagnosed without a packet capture.
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EnvDebug enables debug logging without the --verbose flag ("1" or "2")
+const EnvDebug = "TYK_CLI_DEBUG"
+
+// Verbosity levels
+const (
+	// LevelOff disables debug logging
+	LevelOff = 0
+	// LevelRequests logs method, URL, status and duration of every HTTP request
+	LevelRequests = 1
+	// LevelBodies additionally logs redacted headers and bodies
+	LevelBodies = 2
+)
+
+// maxBodyLog caps how much of a request or response body is logged
+const maxBodyLog = 4096
+
+var (
+	mu     sync.Mutex
+	level  int
+	output io.Writer = os.Stderr
+)
+
+// SetLevel sets the verbosity level
+func SetLevel(l int) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// Level returns the current verbosity level
+func Level() int {
+	mu.Lock()
+	defer mu.Unlock()
+	return level
+}
+
+// SetOutput redirects debug output, mainly for tests
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	output = w
+}
+
+// LevelFromEnv returns the level requested by TYK_CLI_DEBUG; any truthy value
+// other than a number means LevelRequests
+func LevelFromEnv() int {
+	value := os.Getenv(EnvDebug)
+	if value == "" {
+		return LevelOff
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	if b, err := strconv.ParseBool(value); err == nil && !b {
+		return LevelOff
+	}
+	return LevelRequests
+}
+
+// Debugf logs a line when debug logging is enabled
+func Debugf(format string, args ...interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	if level < LevelRequests {
+		return
+	}
+	fmt.Fprintf(output, "[debug] "+format+"\n", args...)
+}
+
+// NewTransport wraps next so every round trip is logged at the current level
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := Level()
+	if l < LevelRequests {
+		return t.next.RoundTrip(req)
+	}
+
+	if l >= LevelBodies {
+		logHeaders("> ", req.Header)
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				body.Close()
+				logBody("> ", data)
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		Debugf("%s %s failed after %s: %v", req.Method, req.URL.Redacted(), elapsed, err)
+		return nil, err
+	}
+	Debugf("%s %s -> %d (%s)", req.Method, req.URL.Redacted(), resp.StatusCode, elapsed)
+
+	if l >= LevelBodies {
+		logHeaders("< ", resp.Header)
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		if readErr != nil {
+			return nil, readErr
+		}
+		logBody("< ", data)
+	}
+	return resp, nil
+}
+
+// sensitiveHeaders are replaced with a placeholder before logging; callers hold mu
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"X-Tyk-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Proxy-Authorization": true,
+	"X-Consul-Token":      true,
+}
+
+// RedactHeaders adds headers whose values must never be logged, such as the custom
+// headers of an environment, which often carry credentials for a proxy in front of
+// the Dashboard
+func RedactHeaders(names ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, name := range names {
+		sensitiveHeaders[http.CanonicalHeaderKey(name)] = true
+	}
+}
+
+func isSensitiveHeader(name string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return sensitiveHeaders[http.CanonicalHeaderKey(name)]
+}
+
+func logHeaders(prefix string, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range header[name] {
+			if isSensitiveHeader(name) {
+				value = "[REDACTED]"
+			}
+			Debugf("%s%s: %s", prefix, name, value)
+		}
+	}
+}
+
+// sensitiveFields matches JSON string fields whose values must not be logged
+var sensitiveFields = regexp.MustCompile(`(?i)("(?:[a-z_]*(?:secret|password|token)|key|key_hash|access_key|access_token|refresh_token)"\s*:\s*)"[^"]*"`)
+
+// RedactBody masks secret-looking JSON string fields in a body
+func RedactBody(data []byte) []byte {
+	return sensitiveFields.ReplaceAll(data, []byte(`$1"[REDACTED]"`))
+}
+
+func logBody(prefix string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	data = RedactBody(data)
+	suffix := ""
+	if len(data) > maxBodyLog {
+		suffix = fmt.Sprintf("... (%d bytes truncated)", len(data)-maxBodyLog)
+		data = data[:maxBodyLog]
+	}
+	Debugf("%s%s%s", prefix, data, suffix)
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureLog(t *testing.T, l int) *bytes.Buffer {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetLevel(l)
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetLevel(LevelOff)
+	})
+	return &buf
+}
+
+func newEchoServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"key":"k-123","status":"ok"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func doRequest(t *testing.T, url string) string {
+	client := &http.Client{Transport: NewTransport(nil)}
+	req, err := http.NewRequest(http.MethodPost, url+"/api/keys", strings.NewReader(`{"secret":"s3cr3t","alias":"ci"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token-123")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestTransport_Requests(t *testing.T) {
+	server := newEchoServer(t)
+	buf := captureLog(t, LevelRequests)
+
+	assert.Equal(t, `{"key":"k-123","status":"ok"}`, doRequest(t, server.URL))
+	assert.Regexp(t, `\[debug\] POST http://.*/api/keys -> 201 \(\d+m?s\)`, buf.String())
+	assert.NotContains(t, buf.String(), "token-123")
+	assert.NotContains(t, buf.String(), "alias")
+}
+
+func TestTransport_BodiesRedacted(t *testing.T) {
+	server := newEchoServer(t)
+	buf := captureLog(t, LevelBodies)
+
+	// The response body is still readable by the caller
+	assert.Equal(t, `{"key":"k-123","status":"ok"}`, doRequest(t, server.URL))
+	out := buf.String()
+	assert.Contains(t, out, "Authorization: [REDACTED]")
+	assert.Contains(t, out, "Set-Cookie: [REDACTED]")
+	assert.Contains(t, out, `"alias":"ci"`)
+	assert.Contains(t, out, `"status":"ok"`)
+	assert.NotContains(t, out, "token-123")
+	assert.NotContains(t, out, "s3cr3t")
+	assert.NotContains(t, out, "k-123")
+}
+
+func TestTransport_Off(t *testing.T) {
+	server := newEchoServer(t)
+	buf := captureLog(t, LevelOff)
+
+	doRequest(t, server.URL)
+	assert.Empty(t, buf.String())
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	for value, want := range map[string]int{"": LevelOff, "0": LevelOff, "false": LevelOff, "1": LevelRequests, "true": LevelRequests, "yes": LevelRequests, "2": LevelBodies} {
+		t.Setenv(EnvDebug, value)
+		assert.Equal(t, want, LevelFromEnv(), value)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{"users":[{"email_address":"jane@example.com","access_key":"abc123"}]}`
+	out := string(RedactBody([]byte(body)))
+	assert.NotContains(t, out, "abc123")
+	assert.Contains(t, out, "jane@example.com")
+}
+
+func TestRedactHeaders(t *testing.T) {
+	server := newEchoServer(t)
+	buf := captureLog(t, LevelBodies)
+	RedactHeaders("x-proxy-auth")
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Proxy-Auth", "proxy-secret")
+	req.Header.Set("X-Consul-Token", "consul-secret")
+	req.Header.Set("X-Request-Id", "req-1")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	out := buf.String()
+	assert.Contains(t, out, "X-Proxy-Auth: [REDACTED]")
+	assert.Contains(t, out, "X-Consul-Token: [REDACTED]")
+	assert.Contains(t, out, "X-Request-Id: req-1")
+	assert.NotContains(t, out, "proxy-secret")
+	assert.NotContains(t, out, "consul-secret")
+}
@@ -0,0 +1,190 @@
+// Package metrics keeps the counters and gauges of long-running commands and serves
+// them in the Prometheus text exposition format, so scheduled syncs can be monitored
+// like any other controller.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metric types
+const (
+	Counter = "counter"
+	Gauge   = "gauge"
+)
+
+// Labels name one series of a metric
+type Labels map[string]string
+
+// Registry holds metric families and their series. It is safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+type family struct {
+	kind   string
+	help   string
+	series map[string]*series
+}
+
+type series struct {
+	value float64
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// Describe declares a metric with its type and help text, so it is exposed before its
+// first sample. Describing a metric again is a no-op.
+func (r *Registry) Describe(name, kind, help string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.family(name, kind, help)
+}
+
+// Add adds delta to a counter
+func (r *Registry) Add(name string, labels Labels, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.series(r.family(name, Counter, ""), labels).value += delta
+}
+
+// Set sets a gauge
+func (r *Registry) Set(name string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.series(r.family(name, Gauge, ""), labels).value = value
+}
+
+// Value returns the current value of a series, and whether it exists
+func (r *Registry) Value(name string, labels Labels) (float64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, ok := r.families[name]
+	if !ok {
+		return 0, false
+	}
+	s, ok := f.series[formatLabels(labels)]
+	if !ok {
+		return 0, false
+	}
+	return s.value, true
+}
+
+func (r *Registry) family(name, kind, help string) *family {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{kind: kind, series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	if f.help == "" {
+		f.help = help
+	}
+	return f
+}
+
+func (r *Registry) series(f *family, labels Labels) *series {
+	key := formatLabels(labels)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{}
+		f.series[key] = s
+	}
+	return s
+}
+
+// WriteTo writes every metric in the Prometheus text format, sorted by name and labels
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	var b strings.Builder
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		f := r.families[name]
+		if f.help != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(f.help))
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, f.kind)
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s%s %s\n", name, key, strconv.FormatFloat(f.series[key].value, 'g', -1, 64))
+		}
+	}
+	r.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics to a Prometheus scrape
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// Route is another endpoint served next to /metrics, such as a health check
+type Route struct {
+	Path    string
+	Handler http.Handler
+}
+
+// Listen serves the registry on addr at /metrics, and any routes, until ctx is done. It
+// returns the address it listens on, which tells the port when addr asks for any (":0").
+func Listen(ctx context.Context, addr string, r *Registry, routes ...Route) (net.Addr, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r)
+	for _, route := range routes {
+		mux.Handle(route.Path, route.Handler)
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go server.Serve(listener)
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdown)
+	}()
+	return listener.Addr(), nil
+}
+
+// formatLabels returns labels as {name="value",...} sorted by name, or "" for none
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf(`%s="%s"`, name, escape.Replace(labels[name]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
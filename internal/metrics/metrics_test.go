@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	r := NewRegistry()
+	r.Describe("tyk_sync_runs_total", Counter, "Sync runs")
+	r.Describe("tyk_sync_drift_apis", Gauge, "APIs that differ from their spec")
+	r.Add("tyk_sync_applies_total", Labels{"operation": "created", "environment": "prod"}, 2)
+	r.Add("tyk_sync_applies_total", Labels{"operation": "created", "environment": "prod"}, 1)
+	r.Add("tyk_sync_applies_total", Labels{"environment": `say "hi"`, "operation": "updated"}, 1)
+	r.Set("tyk_sync_drift_apis", nil, 4)
+	r.Set("tyk_sync_drift_apis", nil, 0.5)
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Equal(t, `# TYPE tyk_sync_applies_total counter
+tyk_sync_applies_total{environment="prod",operation="created"} 3
+tyk_sync_applies_total{environment="say \"hi\"",operation="updated"} 1
+# HELP tyk_sync_drift_apis APIs that differ from their spec
+# TYPE tyk_sync_drift_apis gauge
+tyk_sync_drift_apis 0.5
+# HELP tyk_sync_runs_total Sync runs
+# TYPE tyk_sync_runs_total counter
+`, b.String())
+
+	value, ok := r.Value("tyk_sync_applies_total", Labels{"environment": "prod", "operation": "created"})
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), value)
+	_, ok = r.Value("tyk_sync_runs_total", nil)
+	assert.False(t, ok)
+}
+
+func TestListen(t *testing.T) {
+	r := NewRegistry()
+	r.Add("tyk_sync_runs_total", nil, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	healthz := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	addr, err := Listen(ctx, "127.0.0.1:0", r, Route{Path: "/healthz", Handler: healthz})
+	require.NoError(t, err)
+	resp, err := http.Get("http://" + addr.String() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "version=0.0.4")
+	assert.Contains(t, string(body), "tyk_sync_runs_total 1\n")
+
+	health, err := http.Get("http://" + addr.String() + "/healthz")
+	require.NoError(t, err)
+	health.Body.Close()
+	assert.Equal(t, http.StatusNoContent, health.StatusCode)
+}
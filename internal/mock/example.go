@@ -0,0 +1,203 @@
+package mock
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxDepth bounds schema generation so deeply nested or recursive schemas still terminate
+const maxDepth = 8
+
+// formatExamples are the values generated for well-known string formats
+var formatExamples = map[string]string{
+	"date-time": "2024-01-01T00:00:00Z",
+	"date":      "2024-01-01",
+	"time":      "00:00:00",
+	"email":     "user@example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+	"password":  "********",
+}
+
+// generator builds example values from schemas, resolving $refs within one document
+type generator struct {
+	doc   map[string]interface{}
+	stack []string
+}
+
+// Example returns a value matching schema, preferring the examples and defaults the
+// schema declares. $refs are resolved against doc.
+func Example(doc, schema map[string]interface{}) interface{} {
+	g := &generator{doc: doc}
+	return g.value(schema, 0)
+}
+
+func (g *generator) value(schema map[string]interface{}, depth int) interface{} {
+	if schema == nil || depth > maxDepth {
+		return nil
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		for _, seen := range g.stack {
+			if seen == ref {
+				// Recursive schema: stop instead of expanding forever
+				return nil
+			}
+		}
+		target, _ := Resolve(g.doc, ref).(map[string]interface{})
+		g.stack = append(g.stack, ref)
+		defer func() { g.stack = g.stack[:len(g.stack)-1] }()
+		return g.value(target, depth+1)
+	}
+
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	if examples, ok := schema["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0]
+	}
+	if def, ok := schema["default"]; ok {
+		return def
+	}
+	if constant, ok := schema["const"]; ok {
+		return constant
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		merged := map[string]interface{}{}
+		for _, item := range all {
+			part, _ := item.(map[string]interface{})
+			if object, ok := g.value(part, depth+1).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if choices, ok := schema[key].([]interface{}); ok && len(choices) > 0 {
+			first, _ := choices[0].(map[string]interface{})
+			return g.value(first, depth+1)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		object := map[string]interface{}{}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, property := range properties {
+			propertySchema, _ := property.(map[string]interface{})
+			// Responses never carry write-only properties such as passwords
+			if writeOnly, _ := propertySchema["writeOnly"].(bool); writeOnly {
+				continue
+			}
+			object[name] = g.value(propertySchema, depth+1)
+		}
+		return object
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		count := 1
+		if minItems, ok := number(schema["minItems"]); ok && int(minItems) > count {
+			count = int(minItems)
+		}
+		array := make([]interface{}, count)
+		for i := range array {
+			array[i] = g.value(items, depth+1)
+		}
+		return array
+	case "string":
+		format, _ := schema["format"].(string)
+		if example, ok := formatExamples[format]; ok {
+			return example
+		}
+		value := "string"
+		if minLength, ok := number(schema["minLength"]); ok && int(minLength) > len(value) {
+			value += strings.Repeat("x", int(minLength)-len(value))
+		}
+		return value
+	case "integer":
+		if minimum, ok := number(schema["minimum"]); ok {
+			return int64(minimum)
+		}
+		return 0
+	case "number":
+		if minimum, ok := number(schema["minimum"]); ok {
+			return minimum
+		}
+		return 0.0
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+// schemaType returns the schema's type, inferring object and array from their keywords.
+// OAS 3.1 type lists yield their first non-null entry.
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// Resolve follows a local JSON pointer such as "#/components/schemas/User" within doc
+func Resolve(doc map[string]interface{}, ref string) interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var current interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,147 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const petstore = `
+openapi: 3.0.3
+info: {title: Petstore, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {$ref: "#/components/schemas/Pet"}
+    post:
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              example: {id: 7, name: Rex}
+  /pets/{id}:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              examples:
+                cat: {value: {id: 1, name: Tom}}
+                dog: {value: {id: 2, name: Rex}}
+        "404":
+          description: missing
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Error"}
+  /pets/mine:
+    get:
+      responses:
+        "204": {description: none}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id: {type: integer, minimum: 1}
+        name: {type: string, example: Fido}
+        born: {type: string, format: date}
+        status: {type: string, enum: [available, sold]}
+        secret: {type: string, writeOnly: true}
+        parent: {$ref: "#/components/schemas/Pet"}
+    Error:
+      type: object
+      properties:
+        code: {type: integer, default: 404}
+`
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	var doc map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(petstore), &doc))
+	server, err := NewServer(doc)
+	require.NoError(t, err)
+	return server
+}
+
+func get(t *testing.T, server *Server, method, path string, header http.Header) (*httptest.ResponseRecorder, interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	var body interface{}
+	if rec.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	}
+	return rec, body
+}
+
+func TestServer_SchemaExample(t *testing.T) {
+	server := newTestServer(t)
+	rec, body := get(t, server, http.MethodGet, "/pets", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"id": float64(1), "name": "Fido", "born": "2024-01-01", "status": "available", "parent": nil,
+	}}, body)
+}
+
+func TestServer_ExplicitExamples(t *testing.T) {
+	server := newTestServer(t)
+
+	rec, body := get(t, server, http.MethodPost, "/pets", nil)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, map[string]interface{}{"id": float64(7), "name": "Rex"}, body)
+
+	_, body = get(t, server, http.MethodGet, "/pets/42", nil)
+	assert.Equal(t, "Tom", body.(map[string]interface{})["name"])
+
+	_, body = get(t, server, http.MethodGet, "/pets/42", http.Header{"Prefer": {"example=dog"}})
+	assert.Equal(t, "Rex", body.(map[string]interface{})["name"])
+
+	rec, body = get(t, server, http.MethodGet, "/pets/42", http.Header{"Prefer": {"code=404"}})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, map[string]interface{}{"code": float64(404)}, body)
+}
+
+func TestServer_Routing(t *testing.T) {
+	server := newTestServer(t)
+
+	// Literal segments win over path parameters
+	rec, _ := get(t, server, http.MethodGet, "/pets/mine", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec, _ = get(t, server, http.MethodGet, "/owners", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec, _ = get(t, server, http.MethodDelete, "/pets", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+
+	rec, _ = get(t, server, http.MethodOptions, "/pets", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec, _ = get(t, server, http.MethodGet, "/pets", http.Header{"Prefer": {"code=500"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestNewServer_NoOperations(t *testing.T) {
+	_, err := NewServer(map[string]interface{}{"paths": map[string]interface{}{}})
+	assert.Error(t, err)
+}
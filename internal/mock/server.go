@@ -0,0 +1,250 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// httpMethods are the operation keys of an OAS path item
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Route is one operation the mock server answers
+type Route struct {
+	Method string
+	Path   string
+	Status string
+
+	segments  []string
+	responses map[string]interface{}
+}
+
+// Server answers requests for the operations in an OAS document with example responses.
+// Clients choose another documented response with a "Prefer: code=404" header and a
+// named example with "Prefer: example=name".
+type Server struct {
+	doc    map[string]interface{}
+	routes []*Route
+}
+
+// NewServer builds a mock server for the operations in doc
+func NewServer(doc map[string]interface{}) (*Server, error) {
+	s := &Server{doc: doc}
+	paths, _ := doc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			responses, _ := op["responses"].(map[string]interface{})
+			s.routes = append(s.routes, &Route{
+				Method:    strings.ToUpper(method),
+				Path:      path,
+				Status:    defaultStatus(responses),
+				segments:  strings.Split(strings.Trim(path, "/"), "/"),
+				responses: responses,
+			})
+		}
+	}
+	if len(s.routes) == 0 {
+		return nil, fmt.Errorf("the spec has no operations to mock")
+	}
+	return s, nil
+}
+
+// Routes lists the operations the server answers, in path order
+func (s *Server) Routes() []*Route {
+	return s.routes
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Frontends call mocks from other origins during development
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	matches := s.match(r.URL.Path)
+	if len(matches) == 0 {
+		writeProblem(w, http.StatusNotFound, fmt.Sprintf("no operation matches %s", r.URL.Path))
+		return
+	}
+	var route *Route
+	var allowed []string
+	for _, candidate := range matches {
+		allowed = append(allowed, candidate.Method)
+		if candidate.Method == r.Method && route == nil {
+			route = candidate
+		}
+	}
+	if route == nil {
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowed, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", "*")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeProblem(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s does not support %s", matches[0].Path, r.Method))
+		return
+	}
+
+	prefer := parsePrefer(r.Header.Get("Prefer"))
+	status := route.Status
+	if code, ok := prefer["code"]; ok {
+		if _, documented := route.responses[code]; !documented {
+			writeProblem(w, http.StatusBadRequest, fmt.Sprintf("%s %s does not document a %s response", route.Method, route.Path, code))
+			return
+		}
+		status = code
+	}
+	s.respond(w, r, route, status, prefer["example"])
+}
+
+// respond writes the example body for one documented response
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, route *Route, status, exampleName string) {
+	code := http.StatusOK
+	if n, err := strconv.Atoi(status); err == nil {
+		code = n
+	}
+
+	response, _ := route.responses[status].(map[string]interface{})
+	if ref, ok := response["$ref"].(string); ok {
+		response, _ = Resolve(s.doc, ref).(map[string]interface{})
+	}
+	content, _ := response["content"].(map[string]interface{})
+	mediaType := chooseMediaType(content, r.Header.Get("Accept"))
+	if mediaType == "" || code == http.StatusNoContent || r.Method == http.MethodHead {
+		w.WriteHeader(code)
+		return
+	}
+
+	media, _ := content[mediaType].(map[string]interface{})
+	body := s.mediaExample(media, exampleName)
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(code)
+	if text, ok := body.(string); ok && !strings.Contains(mediaType, "json") {
+		fmt.Fprint(w, text)
+		return
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+// mediaExample picks the named example, else the media type's example or first example,
+// else one generated from the schema
+func (s *Server) mediaExample(media map[string]interface{}, name string) interface{} {
+	if examples, ok := media["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		if _, ok := examples[name]; !ok {
+			name = sortedKeys(examples)[0]
+		}
+		example, _ := examples[name].(map[string]interface{})
+		if ref, ok := example["$ref"].(string); ok {
+			example, _ = Resolve(s.doc, ref).(map[string]interface{})
+		}
+		if value, ok := example["value"]; ok {
+			return value
+		}
+	}
+	if example, ok := media["example"]; ok {
+		return example
+	}
+	schema, _ := media["schema"].(map[string]interface{})
+	return Example(s.doc, schema)
+}
+
+// match returns the routes whose path template matches path, most specific first
+func (s *Server) match(path string) []*Route {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	type scored struct {
+		route    *Route
+		literals int
+	}
+	var matches []scored
+	for _, route := range s.routes {
+		if len(route.segments) != len(segments) {
+			continue
+		}
+		literals := 0
+		for i, segment := range route.segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				continue
+			}
+			if segment != segments[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals >= 0 {
+			matches = append(matches, scored{route, literals})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].literals > matches[j].literals })
+	routes := make([]*Route, len(matches))
+	for i, m := range matches {
+		routes[i] = m.route
+	}
+	return routes
+}
+
+// defaultStatus returns the lowest documented 2xx status, else "default", else the lowest code
+func defaultStatus(responses map[string]interface{}) string {
+	codes := sortedKeys(responses)
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			return code
+		}
+	}
+	if _, ok := responses["default"]; ok {
+		return "default"
+	}
+	if len(codes) > 0 {
+		return codes[0]
+	}
+	return "200"
+}
+
+// chooseMediaType picks the documented media type the client accepts, preferring JSON
+func chooseMediaType(content map[string]interface{}, accept string) string {
+	types := sortedKeys(content)
+	if len(types) == 0 {
+		return ""
+	}
+	for _, part := range strings.Split(accept, ",") {
+		wanted := strings.TrimSpace(strings.Split(part, ";")[0])
+		if _, ok := content[wanted]; ok {
+			return wanted
+		}
+	}
+	for _, mediaType := range types {
+		if strings.Contains(mediaType, "json") {
+			return mediaType
+		}
+	}
+	return types[0]
+}
+
+// parsePrefer reads the key=value preferences of a Prefer header
+func parsePrefer(header string) map[string]string {
+	prefer := make(map[string]string)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			prefer[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prefer
+}
+
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"title":  http.StatusText(status),
+		"status": status,
+		"detail": detail,
+	})
+}
@@ -0,0 +1,151 @@
+package oas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Authentication types accepted by AddAuthentication
+const (
+	ScaffoldAPIKey = "apikey"
+	ScaffoldJWT    = "jwt"
+	ScaffoldOAuth  = "oauth"
+	ScaffoldNone   = "none"
+)
+
+// ScaffoldAuthTypes lists the authentication types AddAuthentication accepts
+var ScaffoldAuthTypes = []string{ScaffoldAPIKey, ScaffoldJWT, ScaffoldOAuth, ScaffoldNone}
+
+// scaffoldModes maps each authentication type to the mode AuthModes reports for it
+var scaffoldModes = map[string]string{
+	ScaffoldAPIKey: AuthAPIKey,
+	ScaffoldJWT:    AuthJWT,
+	ScaffoldOAuth:  AuthOAuth2,
+}
+
+// AddAuthentication secures an API with the given authentication type. A matching scheme
+// already declared in components.securitySchemes is reused; otherwise one is added. The
+// scheme is required in the top-level security list and enabled in
+// x-tyk-api-gateway.server.authentication. "none" disables authentication. It returns
+// the name of the scheme used, or "" for "none".
+func AddAuthentication(oasDoc map[string]interface{}, authType string) (string, error) {
+	server := tykSection(oasDoc, "server", true)
+	auth, ok := server["authentication"].(map[string]interface{})
+	if !ok {
+		auth = map[string]interface{}{}
+		server["authentication"] = auth
+	}
+
+	if authType == ScaffoldNone {
+		auth["enabled"] = false
+		return "", nil
+	}
+	mode, ok := scaffoldModes[authType]
+	if !ok {
+		return "", fmt.Errorf("unknown authentication type '%s' (expected one of %s)", authType, strings.Join(ScaffoldAuthTypes, ", "))
+	}
+
+	components, ok := oasDoc["components"].(map[string]interface{})
+	if !ok {
+		components = map[string]interface{}{}
+		oasDoc["components"] = components
+	}
+	definitions, ok := components["securitySchemes"].(map[string]interface{})
+	if !ok {
+		definitions = map[string]interface{}{}
+		components["securitySchemes"] = definitions
+	}
+
+	name := existingScheme(definitions, mode)
+	if name == "" {
+		var definition map[string]interface{}
+		name, definition = defaultScheme(authType, GetListenPath(oasDoc))
+		definitions[name] = definition
+	}
+	requireScheme(oasDoc, name)
+
+	schemes, ok := auth["securitySchemes"].(map[string]interface{})
+	if !ok {
+		schemes = map[string]interface{}{}
+		auth["securitySchemes"] = schemes
+	}
+	schemes[name] = tykSchemeSettings(authType)
+	auth["enabled"] = true
+	return name, nil
+}
+
+// existingScheme returns the first declared scheme, by name, that authenticates with mode
+func existingScheme(definitions map[string]interface{}, mode string) string {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definition, _ := definitions[name].(map[string]interface{})
+		if classifySecurityScheme(definition) == mode {
+			return name
+		}
+	}
+	return ""
+}
+
+// defaultScheme returns the scheme name and OAS definition added when the spec declares none
+func defaultScheme(authType, listenPath string) (string, map[string]interface{}) {
+	switch authType {
+	case ScaffoldJWT:
+		return "jwtAuth", map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"}
+	case ScaffoldOAuth:
+		// Tyk serves the OAuth endpoints under the API's listen path
+		base := strings.TrimSuffix(listenPath, "/")
+		return "oauth", map[string]interface{}{
+			"type": "oauth2",
+			"flows": map[string]interface{}{
+				"authorizationCode": map[string]interface{}{
+					"authorizationUrl": base + "/oauth/authorize",
+					"tokenUrl":         base + "/oauth/token",
+					"scopes":           map[string]interface{}{},
+				},
+				"clientCredentials": map[string]interface{}{
+					"tokenUrl": base + "/oauth/token",
+					"scopes":   map[string]interface{}{},
+				},
+			},
+		}
+	}
+	return "authToken", map[string]interface{}{"type": "apiKey", "in": "header", "name": "Authorization"}
+}
+
+// tykSchemeSettings returns the x-tyk-api-gateway settings that enable a scheme
+func tykSchemeSettings(authType string) map[string]interface{} {
+	switch authType {
+	case ScaffoldJWT:
+		return map[string]interface{}{
+			"enabled":           true,
+			"signingMethod":     "rsa",
+			"identityBaseField": "sub",
+			"policyFieldName":   "pol",
+		}
+	case ScaffoldOAuth:
+		return map[string]interface{}{
+			"enabled":               true,
+			"allowedAuthorizeTypes": []interface{}{"code"},
+			"allowedAccessTypes":    []interface{}{"authorization_code", "refresh_token", "client_credentials"},
+		}
+	}
+	return map[string]interface{}{"enabled": true}
+}
+
+// requireScheme adds name to the top-level security requirements unless already listed
+func requireScheme(oasDoc map[string]interface{}, name string) {
+	security, _ := oasDoc["security"].([]interface{})
+	for _, item := range security {
+		if requirement, ok := item.(map[string]interface{}); ok {
+			if _, ok := requirement[name]; ok {
+				return
+			}
+		}
+	}
+	oasDoc["security"] = append(security, map[string]interface{}{name: []interface{}{}})
+}
@@ -0,0 +1,55 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAuthentication(t *testing.T) {
+	for authType, mode := range map[string]string{ScaffoldAPIKey: AuthAPIKey, ScaffoldJWT: AuthJWT, ScaffoldOAuth: AuthOAuth2} {
+		t.Run(authType, func(t *testing.T) {
+			doc := validTykDoc()
+			name, err := AddAuthentication(doc, authType)
+			require.NoError(t, err)
+			assert.Equal(t, []string{mode}, AuthModes(doc))
+			assert.Contains(t, doc["security"], map[string]interface{}{name: []interface{}{}})
+		})
+	}
+
+	doc := validTykDoc()
+	_, err := AddAuthentication(doc, ScaffoldAPIKey)
+	require.NoError(t, err)
+	_, err = AddAuthentication(doc, ScaffoldNone)
+	require.NoError(t, err)
+	assert.Equal(t, []string{AuthKeyless}, AuthModes(doc))
+
+	_, err = AddAuthentication(validTykDoc(), "basic")
+	assert.Error(t, err)
+}
+
+func TestAddAuthentication_ReusesDeclaredScheme(t *testing.T) {
+	doc := validTykDoc()
+	doc["components"] = map[string]interface{}{"securitySchemes": map[string]interface{}{
+		"petstore_key": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+	}}
+	doc["security"] = []interface{}{map[string]interface{}{"petstore_key": []interface{}{}}}
+
+	name, err := AddAuthentication(doc, ScaffoldAPIKey)
+	require.NoError(t, err)
+	assert.Equal(t, "petstore_key", name)
+	assert.Len(t, doc["components"].(map[string]interface{})["securitySchemes"], 1)
+	assert.Len(t, doc["security"], 1)
+	assert.Equal(t, []string{AuthAPIKey}, AuthModes(doc))
+}
+
+func TestAddAuthentication_OAuthEndpointsUnderListenPath(t *testing.T) {
+	doc := validTykDoc()
+	SetListenPath(doc, "/pets/")
+	_, err := AddAuthentication(doc, ScaffoldOAuth)
+	require.NoError(t, err)
+	definition := doc["components"].(map[string]interface{})["securitySchemes"].(map[string]interface{})["oauth"].(map[string]interface{})
+	flows := definition["flows"].(map[string]interface{})
+	assert.Equal(t, "/pets/oauth/token", flows["clientCredentials"].(map[string]interface{})["tokenUrl"])
+}
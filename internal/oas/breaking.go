@@ -0,0 +1,550 @@
+package oas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSchemaDepth bounds how deep schemas are compared, so recursive schemas terminate
+const maxSchemaDepth = 16
+
+// CompatChange is a difference between two versions of a spec, classified by whether it
+// breaks clients written against the old one
+type CompatChange struct {
+	Breaking  bool     `json:"breaking"`
+	Operation string   `json:"operation,omitempty"`
+	Path      []string `json:"path"`
+	Message   string   `json:"message"`
+}
+
+// CompatReport is the outcome of comparing two versions of a spec
+type CompatReport struct {
+	OldVersion string `json:"old_version"`
+	NewVersion string `json:"new_version"`
+	// VersionBumped is set when the new info.version is a release that may break
+	// clients: a new major version, or a new minor version before 1.0
+	VersionBumped bool           `json:"version_bumped"`
+	Changes       []CompatChange `json:"changes"`
+}
+
+// BreakingCount is the number of breaking changes
+func (r *CompatReport) BreakingCount() int {
+	n := 0
+	for _, change := range r.Changes {
+		if change.Breaking {
+			n++
+		}
+	}
+	return n
+}
+
+// Unreleased reports whether the new spec breaks clients without bumping its version
+func (r *CompatReport) Unreleased() bool {
+	return r.BreakingCount() > 0 && !r.VersionBumped
+}
+
+// Compatibility compares two versions of a spec. Removed operations, new required
+// parameters, narrowed request schemas, response fields no longer returned, new
+// security requirements and a moved listen path break clients; additions and relaxed
+// constraints do not.
+func Compatibility(oldDoc, newDoc map[string]interface{}) *CompatReport {
+	c := &compatChecker{oldDoc: oldDoc, newDoc: newDoc, changes: []CompatChange{}}
+	report := &CompatReport{OldVersion: infoVersion(oldDoc), NewVersion: infoVersion(newDoc)}
+	report.VersionBumped = BreakingVersionBump(report.OldVersion, report.NewVersion)
+
+	if oldPath, newPath := GetListenPath(oldDoc), GetListenPath(newDoc); oldPath != "" && newPath != "" && oldPath != newPath {
+		c.add(true, "", []string{TykExtensionKey, "server", "listenPath", "value"},
+			fmt.Sprintf("listen path moves from %s to %s", oldPath, newPath))
+	}
+
+	oldOps := operationsByKey(oldDoc)
+	newOps := operationsByKey(newDoc)
+	keys := make([]string, 0, len(oldOps)+len(newOps))
+	for key := range oldOps {
+		keys = append(keys, key)
+	}
+	for key := range newOps {
+		if _, ok := oldOps[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		oldOp, inOld := oldOps[key]
+		newOp, inNew := newOps[key]
+		switch {
+		case !inNew:
+			c.add(true, oldOp.name(), oldOp.path(), "operation removed")
+		case !inOld:
+			c.add(false, newOp.name(), newOp.path(), "operation added")
+		default:
+			c.compareOperation(newOp.name(), oldOp, newOp)
+		}
+	}
+	report.Changes = c.changes
+	return report
+}
+
+// BreakingVersionBump reports whether going from oldVersion to newVersion announces a
+// breaking release: a higher major version, or a higher minor version before 1.0.
+// Versions that are not numeric count as bumped whenever they change.
+func BreakingVersionBump(oldVersion, newVersion string) bool {
+	oldParts, oldOK := parseVersion(oldVersion)
+	newParts, newOK := parseVersion(newVersion)
+	if !oldOK || !newOK {
+		return oldVersion != newVersion
+	}
+	if newParts[0] != oldParts[0] {
+		return newParts[0] > oldParts[0]
+	}
+	return oldParts[0] == 0 && newParts[1] > oldParts[1]
+}
+
+// parseVersion reads the major and minor numbers of a version such as v1.2.3 or 2.0
+func parseVersion(v string) ([2]int, bool) {
+	var parts [2]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) > 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		if i < len(parts) {
+			parts[i] = n
+		}
+	}
+	return parts, true
+}
+
+func infoVersion(oasDoc map[string]interface{}) string {
+	info, _ := oasDoc["info"].(map[string]interface{})
+	if version, ok := info["version"]; ok && version != nil {
+		return fmt.Sprint(version)
+	}
+	return ""
+}
+
+// compatOperation is an operation with the parameters of its path item
+type compatOperation struct {
+	pathKey    string
+	method     string
+	op         map[string]interface{}
+	pathParams []interface{}
+}
+
+// name returns the operation as "METHOD /path"
+func (o compatOperation) name() string {
+	return strings.ToUpper(o.method) + " " + o.pathKey
+}
+
+func (o compatOperation) path() []string {
+	return []string{"paths", o.pathKey, o.method}
+}
+
+// operationsByKey indexes operations as "METHOD /path", with path parameters written
+// {} so that renaming one is not read as a new operation. Operations are reported by
+// their path in the new document.
+func operationsByKey(oasDoc map[string]interface{}) map[string]compatOperation {
+	ops := make(map[string]compatOperation)
+	paths, _ := oasDoc["paths"].(map[string]interface{})
+	for pathKey, itemValue := range paths {
+		item, _ := itemValue.(map[string]interface{})
+		pathParams, _ := item["parameters"].([]interface{})
+		for _, method := range httpMethods {
+			if op, ok := item[method].(map[string]interface{}); ok {
+				ops[strings.ToUpper(method)+" "+normalizePathTemplate(pathKey)] = compatOperation{pathKey: pathKey, method: method, op: op, pathParams: pathParams}
+			}
+		}
+	}
+	return ops
+}
+
+func normalizePathTemplate(path string) string {
+	var b strings.Builder
+	inParam := false
+	for _, r := range path {
+		switch {
+		case r == '{':
+			inParam = true
+			b.WriteString("{}")
+		case r == '}':
+			inParam = false
+		case !inParam:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+type compatChecker struct {
+	oldDoc, newDoc map[string]interface{}
+	changes        []CompatChange
+}
+
+func (c *compatChecker) add(breaking bool, operation string, path []string, message string) {
+	c.changes = append(c.changes, CompatChange{Breaking: breaking, Operation: operation, Path: path, Message: message})
+}
+
+func (c *compatChecker) compareOperation(key string, oldOp, newOp compatOperation) {
+	c.compareParameters(key, oldOp, newOp)
+	c.compareRequestBody(key, oldOp, newOp)
+	c.compareResponses(key, oldOp, newOp)
+
+	oldSecured := operationSecured(c.oldDoc, oldOp.op)
+	if newSecured := operationSecured(c.newDoc, newOp.op); newSecured && !oldSecured {
+		c.add(true, key, childPath(newOp.path(), "security"), "now requires authentication")
+	} else if oldSecured && !newSecured {
+		c.add(false, key, childPath(newOp.path(), "security"), "no longer requires authentication")
+	}
+}
+
+// operationSecured reports whether an operation requires a security scheme
+func operationSecured(oasDoc, op map[string]interface{}) bool {
+	security, ok := op["security"].([]interface{})
+	if !ok {
+		security, _ = oasDoc["security"].([]interface{})
+	}
+	for _, requirement := range security {
+		// An empty requirement makes authentication optional
+		if r, ok := requirement.(map[string]interface{}); ok && len(r) == 0 {
+			return false
+		}
+	}
+	return len(security) > 0
+}
+
+// operationParameters returns the parameters of an operation by "in:name", operation
+// parameters overriding those of the path item
+func operationParameters(doc map[string]interface{}, o compatOperation) map[string]map[string]interface{} {
+	params := make(map[string]map[string]interface{})
+	opParams, _ := o.op["parameters"].([]interface{})
+	for _, list := range [][]interface{}{o.pathParams, opParams} {
+		for _, value := range list {
+			param, _ := resolveRef(doc, value).(map[string]interface{})
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			if name == "" {
+				continue
+			}
+			key := in + ":" + name
+			if i := strings.Index(o.pathKey, "{"+name+"}"); in == "path" && i >= 0 {
+				// Path parameters are matched by position in the template
+				key = in + ":" + strconv.Itoa(strings.Count(o.pathKey[:i], "{"))
+			}
+			params[key] = param
+		}
+	}
+	return params
+}
+
+func sortedParameterKeys(params map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *compatChecker) compareParameters(key string, oldOp, newOp compatOperation) {
+	oldParams := operationParameters(c.oldDoc, oldOp)
+	newParams := operationParameters(c.newDoc, newOp)
+	for _, paramKey := range sortedParameterKeys(newParams) {
+		newParam := newParams[paramKey]
+		name, _ := newParam["name"].(string)
+		in, _ := newParam["in"].(string)
+		path := childPath(newOp.path(), "parameters", name)
+		required, _ := newParam["required"].(bool)
+		oldParam, existed := oldParams[paramKey]
+		if !existed {
+			if required {
+				c.add(true, key, path, fmt.Sprintf("adds required %s parameter '%s'", in, name))
+			} else {
+				c.add(false, key, path, fmt.Sprintf("adds optional %s parameter '%s'", in, name))
+			}
+			continue
+		}
+		wasRequired, _ := oldParam["required"].(bool)
+		switch {
+		case required && !wasRequired:
+			c.add(true, key, path, fmt.Sprintf("%s parameter '%s' becomes required", in, name))
+		case !required && wasRequired:
+			c.add(false, key, path, fmt.Sprintf("%s parameter '%s' becomes optional", in, name))
+		}
+		oldSchema, _ := oldParam["schema"].(map[string]interface{})
+		newSchema, _ := newParam["schema"].(map[string]interface{})
+		c.compareSchema(key, childPath(path, "schema"), fmt.Sprintf("%s parameter '%s'", in, name), oldSchema, newSchema, true, 0)
+	}
+	for _, paramKey := range sortedParameterKeys(oldParams) {
+		if _, ok := newParams[paramKey]; !ok {
+			oldParam := oldParams[paramKey]
+			name, _ := oldParam["name"].(string)
+			in, _ := oldParam["in"].(string)
+			c.add(false, key, childPath(oldOp.path(), "parameters", name), fmt.Sprintf("removes %s parameter '%s'", in, name))
+		}
+	}
+}
+
+func (c *compatChecker) compareRequestBody(key string, oldOp, newOp compatOperation) {
+	oldBody, _ := resolveRef(c.oldDoc, oldOp.op["requestBody"]).(map[string]interface{})
+	newBody, _ := resolveRef(c.newDoc, newOp.op["requestBody"]).(map[string]interface{})
+	if newBody == nil {
+		return
+	}
+	path := childPath(newOp.path(), "requestBody")
+	required, _ := newBody["required"].(bool)
+	wasRequired, _ := oldBody["required"].(bool)
+	if oldBody == nil {
+		if required {
+			c.add(true, key, path, "adds a required request body")
+		} else {
+			c.add(false, key, path, "adds an optional request body")
+		}
+		return
+	}
+	if required && !wasRequired {
+		c.add(true, key, path, "request body becomes required")
+	}
+	oldContent, _ := oldBody["content"].(map[string]interface{})
+	newContent, _ := newBody["content"].(map[string]interface{})
+	for _, mediaType := range sortedKeys(oldContent) {
+		newMedia, ok := newContent[mediaType].(map[string]interface{})
+		if !ok {
+			c.add(true, key, childPath(path, "content", mediaType), fmt.Sprintf("no longer accepts %s request bodies", mediaType))
+			continue
+		}
+		oldMedia, _ := oldContent[mediaType].(map[string]interface{})
+		oldSchema, _ := oldMedia["schema"].(map[string]interface{})
+		newSchema, _ := newMedia["schema"].(map[string]interface{})
+		c.compareSchema(key, childPath(path, "content", mediaType, "schema"), "request body", oldSchema, newSchema, true, 0)
+	}
+}
+
+func (c *compatChecker) compareResponses(key string, oldOp, newOp compatOperation) {
+	oldResponses, _ := oldOp.op["responses"].(map[string]interface{})
+	newResponses, _ := newOp.op["responses"].(map[string]interface{})
+	for _, status := range sortedKeys(oldResponses) {
+		path := childPath(newOp.path(), "responses", status)
+		newResponse, ok := resolveRef(c.newDoc, newResponses[status]).(map[string]interface{})
+		if !ok {
+			// Clients rely on the success responses they were written against
+			success := strings.HasPrefix(status, "2")
+			c.add(success, key, childPath(oldOp.path(), "responses", status), fmt.Sprintf("removes the %s response", status))
+			continue
+		}
+		oldResponse, _ := resolveRef(c.oldDoc, oldResponses[status]).(map[string]interface{})
+		oldContent, _ := oldResponse["content"].(map[string]interface{})
+		newContent, _ := newResponse["content"].(map[string]interface{})
+		for _, mediaType := range sortedKeys(oldContent) {
+			newMedia, ok := newContent[mediaType].(map[string]interface{})
+			if !ok {
+				c.add(true, key, childPath(path, "content", mediaType), fmt.Sprintf("the %s response is no longer returned as %s", status, mediaType))
+				continue
+			}
+			oldMedia, _ := oldContent[mediaType].(map[string]interface{})
+			oldSchema, _ := oldMedia["schema"].(map[string]interface{})
+			newSchema, _ := newMedia["schema"].(map[string]interface{})
+			c.compareSchema(key, childPath(path, "content", mediaType, "schema"), status+" response", oldSchema, newSchema, false, 0)
+		}
+	}
+	for _, status := range sortedKeys(newResponses) {
+		if _, ok := oldResponses[status]; !ok {
+			c.add(false, key, childPath(newOp.path(), "responses", status), fmt.Sprintf("adds a %s response", status))
+		}
+	}
+}
+
+// compareSchema compares the schemas of a value clients send (request) or receive.
+// Requests break when the schema accepts less than before; responses break when they
+// may lack or change what clients read.
+func (c *compatChecker) compareSchema(key string, path []string, what string, oldSchema, newSchema map[string]interface{}, request bool, depth int) {
+	oldSchema, _ = resolveRef(c.oldDoc, oldSchema).(map[string]interface{})
+	newSchema, _ = resolveRef(c.newDoc, newSchema).(map[string]interface{})
+	if oldSchema == nil || newSchema == nil || depth > maxSchemaDepth {
+		return
+	}
+
+	if oldType, newType := oldSchema["type"], newSchema["type"]; oldType != nil && newType != nil && !reflect.DeepEqual(oldType, newType) {
+		c.add(true, key, childPath(path, "type"), fmt.Sprintf("%s changes type from %v to %v", what, oldType, newType))
+		return
+	}
+
+	if request {
+		c.compareEnum(key, path, what, oldSchema, newSchema)
+		c.compareBounds(key, path, what, oldSchema, newSchema)
+		if oldPattern, newPattern := oldSchema["pattern"], newSchema["pattern"]; newPattern != nil && !reflect.DeepEqual(oldPattern, newPattern) {
+			c.add(true, key, childPath(path, "pattern"), fmt.Sprintf("%s must match the pattern %v", what, newPattern))
+		}
+	}
+
+	oldRequired := stringSet(oldSchema["required"])
+	newRequired := stringSet(newSchema["required"])
+	oldProps, _ := oldSchema["properties"].(map[string]interface{})
+	newProps, _ := newSchema["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(newProps) {
+		propPath := childPath(path, "properties", name)
+		propWhat := fmt.Sprintf("%s field '%s'", what, name)
+		if _, existed := oldProps[name]; !existed {
+			if request && newRequired[name] {
+				c.add(true, key, propPath, fmt.Sprintf("%s requires the new field '%s'", what, name))
+			} else {
+				c.add(false, key, propPath, fmt.Sprintf("%s adds the field '%s'", what, name))
+			}
+			continue
+		}
+		switch {
+		case request && newRequired[name] && !oldRequired[name]:
+			c.add(true, key, propPath, propWhat+" becomes required")
+		case !request && oldRequired[name] && !newRequired[name]:
+			c.add(true, key, propPath, propWhat+" is no longer always returned")
+		}
+		oldProp, _ := oldProps[name].(map[string]interface{})
+		newProp, _ := newProps[name].(map[string]interface{})
+		c.compareSchema(key, propPath, propWhat, oldProp, newProp, request, depth+1)
+	}
+	for _, name := range sortedKeys(oldProps) {
+		if _, ok := newProps[name]; !ok {
+			propPath := childPath(path, "properties", name)
+			if request {
+				c.add(false, key, propPath, fmt.Sprintf("%s drops the field '%s'", what, name))
+			} else {
+				c.add(true, key, propPath, fmt.Sprintf("%s no longer returns the field '%s'", what, name))
+			}
+		}
+	}
+
+	oldItems, _ := oldSchema["items"].(map[string]interface{})
+	newItems, _ := newSchema["items"].(map[string]interface{})
+	c.compareSchema(key, childPath(path, "items"), what+" items", oldItems, newItems, request, depth+1)
+}
+
+// compareEnum reports enum values a request no longer accepts
+func (c *compatChecker) compareEnum(key string, path []string, what string, oldSchema, newSchema map[string]interface{}) {
+	newEnum, ok := newSchema["enum"].([]interface{})
+	if !ok {
+		return
+	}
+	oldEnum, hadEnum := oldSchema["enum"].([]interface{})
+	if !hadEnum {
+		c.add(true, key, childPath(path, "enum"), fmt.Sprintf("%s is restricted to %s", what, formatValues(newEnum)))
+		return
+	}
+	var removed, added []interface{}
+	for _, value := range oldEnum {
+		if !containsValue(newEnum, value) {
+			removed = append(removed, value)
+		}
+	}
+	for _, value := range newEnum {
+		if !containsValue(oldEnum, value) {
+			added = append(added, value)
+		}
+	}
+	if len(removed) > 0 {
+		c.add(true, key, childPath(path, "enum"), fmt.Sprintf("%s no longer accepts %s", what, formatValues(removed)))
+	}
+	if len(added) > 0 {
+		c.add(false, key, childPath(path, "enum"), fmt.Sprintf("%s also accepts %s", what, formatValues(added)))
+	}
+}
+
+// schemaBounds lists the keywords bounding a value, and whether the bound is an upper one
+var schemaBounds = []struct {
+	keyword string
+	upper   bool
+}{
+	{"minimum", false}, {"maximum", true},
+	{"minLength", false}, {"maxLength", true},
+	{"minItems", false}, {"maxItems", true},
+}
+
+// compareBounds reports tightened minimums and maximums of a request
+func (c *compatChecker) compareBounds(key string, path []string, what string, oldSchema, newSchema map[string]interface{}) {
+	for _, bound := range schemaBounds {
+		newValue, ok := number(newSchema[bound.keyword])
+		if !ok {
+			continue
+		}
+		oldValue, hadBound := number(oldSchema[bound.keyword])
+		narrowed := !hadBound || (bound.upper && newValue < oldValue) || (!bound.upper && newValue > oldValue)
+		if narrowed {
+			c.add(true, key, childPath(path, bound.keyword), fmt.Sprintf("%s has a %s of %v", what, bound.keyword, newSchema[bound.keyword]))
+		} else if newValue != oldValue {
+			c.add(false, key, childPath(path, bound.keyword), fmt.Sprintf("%s relaxes its %s to %v", what, bound.keyword, newSchema[bound.keyword]))
+		}
+	}
+}
+
+// resolveRef follows a local $ref (#/components/...) of doc
+func resolveRef(doc map[string]interface{}, value interface{}) interface{} {
+	for i := 0; i < maxSchemaDepth; i++ {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, _ := m["$ref"].(string)
+		if !strings.HasPrefix(ref, "#/") {
+			return value
+		}
+		var target interface{} = doc
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			container, _ := target.(map[string]interface{})
+			target = container[part]
+		}
+		value = target
+	}
+	return value
+}
+
+// childPath returns a copy of path extended with keys
+func childPath(path []string, keys ...string) []string {
+	return append(append([]string{}, path...), keys...)
+}
+
+func stringSet(value interface{}) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range stringItems(value) {
+		set[item] = true
+	}
+	return set
+}
+
+func number(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equalValues(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatValues(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,147 @@
+package oas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compatDoc(t *testing.T, version, paths string) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Users", "version": "`+version+`"},
+		"paths": `+paths+`,
+		"components": {"schemas": {"User": {
+			"type": "object",
+			"required": ["id", "name"],
+			"properties": {"id": {"type": "string"}, "name": {"type": "string"}, "email": {"type": "string"}}
+		}}}
+	}`), &doc))
+	return doc
+}
+
+func compatMessages(report *CompatReport, breaking bool) []string {
+	messages := []string{}
+	for _, change := range report.Changes {
+		if change.Breaking == breaking {
+			messages = append(messages, change.Operation+": "+change.Message)
+		}
+	}
+	return messages
+}
+
+func TestCompatibility(t *testing.T) {
+	oldDoc := compatDoc(t, "1.2.0", `{
+		"/users": {
+			"get": {
+				"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}}],
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/User"}}}}}}
+			},
+			"post": {
+				"requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {
+					"name": {"type": "string"},
+					"role": {"type": "string", "enum": ["admin", "member", "guest"]}
+				}}}}},
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/users/{id}": {
+			"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"get": {"responses": {"200": {"description": "OK"}}},
+			"delete": {"responses": {"204": {"description": "Deleted"}}}
+		}
+	}`)
+
+	report := Compatibility(oldDoc, oldDoc)
+	assert.Empty(t, report.Changes)
+	assert.False(t, report.Unreleased())
+
+	newDoc := compatDoc(t, "1.3.0", `{
+		"/users": {
+			"get": {
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 50}},
+					{"name": "tenant", "in": "header", "required": true, "schema": {"type": "string"}},
+					{"name": "sort", "in": "query", "schema": {"type": "string"}}
+				],
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {
+					"type": "object", "required": ["id"], "properties": {"id": {"type": "string"}, "name": {"type": "string"}}
+				}}}}}}
+			},
+			"post": {
+				"requestBody": {"required": true, "content": {"application/json": {"schema": {"type": "object", "required": ["name"], "properties": {
+					"name": {"type": "string"},
+					"role": {"type": "string", "enum": ["admin", "member", "owner"]}
+				}}}}},
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/users/{userId}": {
+			"parameters": [{"name": "userId", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"get": {"responses": {"200": {"description": "OK"}, "404": {"description": "Not found"}}}
+		}
+	}`)
+
+	report = Compatibility(oldDoc, newDoc)
+	assert.Equal(t, []string{
+		"DELETE /users/{id}: operation removed",
+		"GET /users: adds required header parameter 'tenant'",
+		"GET /users: query parameter 'limit' has a maximum of 50",
+		"GET /users: 200 response items field 'name' is no longer always returned",
+		"GET /users: 200 response items no longer returns the field 'email'",
+		"POST /users: request body becomes required",
+		"POST /users: request body field 'name' becomes required",
+		"POST /users: request body field 'role' no longer accepts guest",
+	}, compatMessages(report, true))
+	assert.Equal(t, []string{
+		"GET /users: adds optional query parameter 'sort'",
+		"GET /users/{userId}: adds a 404 response",
+		"POST /users: request body field 'role' also accepts owner",
+	}, compatMessages(report, false))
+	assert.Equal(t, 8, report.BreakingCount())
+	assert.False(t, report.VersionBumped)
+	assert.True(t, report.Unreleased())
+
+	newDoc["info"].(map[string]interface{})["version"] = "2.0.0"
+	report = Compatibility(oldDoc, newDoc)
+	assert.True(t, report.VersionBumped)
+	assert.False(t, report.Unreleased())
+}
+
+func TestCompatibility_ListenPathAndSecurity(t *testing.T) {
+	oldDoc := validTykDoc()
+	newDoc := validTykDoc()
+	SetListenPath(newDoc, "/people/")
+	newDoc["security"] = []interface{}{map[string]interface{}{"api_key": []interface{}{}}}
+
+	report := Compatibility(oldDoc, newDoc)
+	assert.Equal(t, []string{": listen path moves from /users/ to /people/", "GET /users: now requires authentication"}, compatMessages(report, true))
+
+	// An empty requirement keeps authentication optional
+	newDoc = validTykDoc()
+	newDoc["security"] = []interface{}{map[string]interface{}{"api_key": []interface{}{}}, map[string]interface{}{}}
+	assert.Empty(t, Compatibility(oldDoc, newDoc).Changes)
+}
+
+func TestBreakingVersionBump(t *testing.T) {
+	for _, tc := range []struct {
+		old, new string
+		bumped   bool
+	}{
+		{"1.0.0", "2.0.0", true},
+		{"v1.4", "v2", true},
+		{"1.0.0", "1.9.0", false},
+		{"2.0.0", "1.0.0", false},
+		{"0.3.1", "0.4.0", true},
+		{"0.3.1", "0.3.2", false},
+		{"1.0.0", "2.0.0-beta.1", true},
+		{"beta", "stable", true},
+		{"stable", "stable", false},
+	} {
+		assert.Equal(t, tc.bumped, BreakingVersionBump(tc.old, tc.new), "%s -> %s", tc.old, tc.new)
+	}
+}
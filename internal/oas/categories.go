@@ -0,0 +1,79 @@
+package oas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The Dashboard groups APIs into categories written as "#category" words in the API
+// name, e.g. "Payments #team-payments #pci"; its API list filters and groups on them.
+
+// SplitCategories splits an API name into its display name and its categories, without
+// the leading '#'
+func SplitCategories(name string) (string, []string) {
+	var words []string
+	categories := []string{}
+	for _, word := range strings.Fields(name) {
+		if len(word) > 1 && strings.HasPrefix(word, "#") {
+			categories = append(categories, word[1:])
+			continue
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " "), categories
+}
+
+// Categories returns the Dashboard categories in the document's API name
+func Categories(oasDoc map[string]interface{}) []string {
+	_, categories := SplitCategories(GetAPIName(oasDoc))
+	return categories
+}
+
+// CheckCategory rejects category names the Dashboard could not read back from an API name
+func CheckCategory(category string) error {
+	if category == "" || strings.ContainsAny(category, "# \t\n") {
+		return fmt.Errorf("invalid category '%s': must be non-empty, without spaces or '#'", category)
+	}
+	return nil
+}
+
+// SetCategories rewrites x-tyk-api-gateway.info.name so it carries exactly categories,
+// in the order given. Duplicates are dropped.
+func SetCategories(oasDoc map[string]interface{}, categories []string) error {
+	base, _ := SplitCategories(GetAPIName(oasDoc))
+	words := []string{base}
+	seen := make(map[string]bool)
+	for _, category := range categories {
+		if err := CheckCategory(category); err != nil {
+			return err
+		}
+		if seen[category] {
+			continue
+		}
+		seen[category] = true
+		words = append(words, "#"+category)
+	}
+	tykSection(oasDoc, "info", true)["name"] = strings.TrimSpace(strings.Join(words, " "))
+	return nil
+}
+
+// AddCategories adds categories to the document's API name, keeping those it has
+func AddCategories(oasDoc map[string]interface{}, categories []string) error {
+	return SetCategories(oasDoc, append(Categories(oasDoc), categories...))
+}
+
+// RemoveCategories removes categories from the document's API name; categories it does
+// not have are ignored
+func RemoveCategories(oasDoc map[string]interface{}, categories []string) error {
+	drop := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		drop[category] = true
+	}
+	var kept []string
+	for _, category := range Categories(oasDoc) {
+		if !drop[category] {
+			kept = append(kept, category)
+		}
+	}
+	return SetCategories(oasDoc, kept)
+}
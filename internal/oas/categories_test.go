@@ -0,0 +1,28 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategories(t *testing.T) {
+	name, categories := SplitCategories("Payments #team-payments  API #pci")
+	assert.Equal(t, "Payments API", name)
+	assert.Equal(t, []string{"team-payments", "pci"}, categories)
+
+	doc := map[string]interface{}{
+		"info":          map[string]interface{}{"title": "Payments"},
+		TykExtensionKey: map[string]interface{}{"info": map[string]interface{}{"name": "Payments #pci"}},
+	}
+	require.NoError(t, AddCategories(doc, []string{"team-payments", "pci"}))
+	assert.Equal(t, "Payments #pci #team-payments", GetAPIName(doc))
+	assert.Equal(t, []string{"pci", "team-payments"}, Categories(doc))
+
+	require.NoError(t, RemoveCategories(doc, []string{"pci", "unknown"}))
+	assert.Equal(t, "Payments #team-payments", GetAPIName(doc))
+
+	assert.Error(t, AddCategories(doc, []string{"two words"}))
+	assert.Error(t, AddCategories(doc, []string{"#hash"}))
+}
@@ -0,0 +1,111 @@
+package oas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Places a client presents its credential
+const (
+	CredentialHeader = "header"
+	CredentialQuery  = "query"
+	CredentialCookie = "cookie"
+)
+
+// Credential describes how clients present a credential to the Gateway for an API
+type Credential struct {
+	// Scheme is the security scheme the credential satisfies
+	Scheme string `json:"scheme"`
+	// Mode is the authentication mode, as reported by AuthModes
+	Mode string `json:"mode"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+	// Prefix precedes the credential in the value, e.g. "Bearer "
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Operation is one operation of a document with the route that reaches it
+type Operation struct {
+	ID     string
+	Method string
+	Path   string
+	Spec   map[string]interface{}
+}
+
+// Operations lists the document's operations in path and method order
+func Operations(oasDoc map[string]interface{}) []Operation {
+	var ops []Operation
+	paths, _ := oasDoc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			if op, ok := item[method].(map[string]interface{}); ok {
+				ops = append(ops, Operation{ID: operationID(path, method, op), Method: strings.ToUpper(method), Path: path, Spec: op})
+			}
+		}
+	}
+	return ops
+}
+
+// CustomDomain returns the API's enabled custom domain, or "" when it answers on any host
+func CustomDomain(oasDoc map[string]interface{}) string {
+	server := tykSection(oasDoc, "server", false)
+	domain, _ := server["customDomain"].(map[string]interface{})
+	if enabled, _ := domain["enabled"].(bool); !enabled {
+		return ""
+	}
+	name, _ := domain["name"].(string)
+	return name
+}
+
+// ClientCredential returns where clients send their credential for the API, or nil
+// for keyless APIs. When several schemes are enabled the first, by name, that a client
+// can present as a header, query parameter or cookie is used. APIs secured only by
+// mutual TLS, HMAC signatures or custom plugins return an error.
+func ClientCredential(oasDoc map[string]interface{}) (*Credential, error) {
+	server := tykSection(oasDoc, "server", false)
+	auth, _ := server["authentication"].(map[string]interface{})
+	if enabled, _ := auth["enabled"].(bool); !enabled {
+		return nil, nil
+	}
+
+	components, _ := oasDoc["components"].(map[string]interface{})
+	definitions, _ := components["securitySchemes"].(map[string]interface{})
+	schemes, _ := auth["securitySchemes"].(map[string]interface{})
+	for _, name := range sortedKeys(schemes) {
+		settings, _ := schemes[name].(map[string]interface{})
+		if enabled, _ := settings["enabled"].(bool); !enabled {
+			continue
+		}
+		definition, _ := definitions[name].(map[string]interface{})
+		credential := &Credential{Scheme: name, Mode: classifySecurityScheme(definition)}
+		switch credential.Mode {
+		case AuthAPIKey, AuthJWT, AuthOAuth2, AuthOIDC, AuthBasic:
+		default:
+			continue
+		}
+
+		if definition["type"] == "apiKey" {
+			credential.In, _ = definition["in"].(string)
+			credential.Name, _ = definition["name"].(string)
+		} else {
+			credential.In, credential.Name, credential.Prefix = CredentialHeader, "Authorization", "Bearer "
+			if credential.Mode == AuthBasic {
+				credential.Prefix = "Basic "
+			}
+		}
+		// The Gateway can read an auth token from a different header than the spec declares
+		if header, ok := settings["header"].(map[string]interface{}); ok {
+			if enabled, _ := header["enabled"].(bool); enabled {
+				if headerName, _ := header["name"].(string); headerName != "" {
+					credential.In, credential.Name = CredentialHeader, headerName
+				}
+			}
+		}
+		if credential.Name == "" {
+			continue
+		}
+		return credential, nil
+	}
+	return nil, fmt.Errorf("no client credential can be sent for authentication modes %s", strings.Join(AuthModes(oasDoc), ", "))
+}
@@ -0,0 +1,51 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCredential(t *testing.T) {
+	doc := validTykDoc()
+	credential, err := ClientCredential(doc)
+	require.NoError(t, err)
+	assert.Nil(t, credential)
+
+	_, err = AddAuthentication(doc, ScaffoldAPIKey)
+	require.NoError(t, err)
+	credential, err = ClientCredential(doc)
+	require.NoError(t, err)
+	assert.Equal(t, &Credential{Scheme: "authToken", Mode: AuthAPIKey, In: CredentialHeader, Name: "Authorization"}, credential)
+
+	// The Gateway's header override wins over the spec's declaration
+	schemes := lookupSection(doc, []string{"server", "authentication", "securitySchemes"})
+	schemes["authToken"].(map[string]interface{})["header"] = map[string]interface{}{"enabled": true, "name": "X-Api-Key"}
+	credential, err = ClientCredential(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "X-Api-Key", credential.Name)
+
+	jwt := validTykDoc()
+	_, err = AddAuthentication(jwt, ScaffoldJWT)
+	require.NoError(t, err)
+	credential, err = ClientCredential(jwt)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer ", credential.Prefix)
+
+	hmac := validTykDoc()
+	tykSection(hmac, "server", true)["authentication"] = map[string]interface{}{"enabled": true, "hmac": map[string]interface{}{"enabled": true}}
+	_, err = ClientCredential(hmac)
+	assert.Error(t, err)
+}
+
+func TestOperationsAndCustomDomain(t *testing.T) {
+	doc := validTykDoc()
+	ops := Operations(doc)
+	require.Len(t, ops, 1)
+	assert.Equal(t, Operation{ID: "listUsers", Method: "GET", Path: "/users", Spec: ops[0].Spec}, ops[0])
+
+	assert.Empty(t, CustomDomain(doc))
+	tykSection(doc, "server", true)["customDomain"] = map[string]interface{}{"enabled": true, "name": "api.example.com"}
+	assert.Equal(t, "api.example.com", CustomDomain(doc))
+}
@@ -0,0 +1,49 @@
+package oas
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PrepareClone turns the document of a deployed API into one that creates a new API:
+// the IDs the Dashboard assigned are removed, and so are the servers entries it added
+// for the Gateway's URL of the current listen path, since the new API gets its own
+func PrepareClone(oasDoc map[string]interface{}) {
+	if info := tykSection(oasDoc, "info", false); info != nil {
+		for _, assigned := range serverAssigned {
+			delete(info, assigned[len(assigned)-1])
+		}
+	}
+
+	listenPath := strings.Trim(GetListenPath(oasDoc), "/")
+	servers, ok := oasDoc["servers"].([]interface{})
+	if !ok || listenPath == "" {
+		return
+	}
+	kept := []interface{}{}
+	for _, item := range servers {
+		server, _ := item.(map[string]interface{})
+		raw, _ := server["url"].(string)
+		if u, err := url.Parse(raw); err == nil && strings.Trim(u.Path, "/") == listenPath {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	if len(kept) == 0 {
+		delete(oasDoc, "servers")
+		return
+	}
+	oasDoc["servers"] = kept
+}
+
+// SetAPIName renames the API in x-tyk-api-gateway.info.name and info.title. The
+// Dashboard categories of the current name are kept, and those of name are added.
+func SetAPIName(oasDoc map[string]interface{}, name string) error {
+	categories := Categories(oasDoc)
+	base, added := SplitCategories(name)
+	tykSection(oasDoc, "info", true)["name"] = base
+	if info, ok := oasDoc["info"].(map[string]interface{}); ok {
+		info["title"] = base
+	}
+	return SetCategories(oasDoc, append(categories, added...))
+}
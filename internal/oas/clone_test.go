@@ -0,0 +1,42 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareClone(t *testing.T) {
+	doc := validTykDoc()
+	info := doc[TykExtensionKey].(map[string]interface{})["info"].(map[string]interface{})
+	info["id"] = "abc123"
+	info["dbId"] = "64f0c0ffee"
+	info["orgId"] = "org"
+	SetListenPath(doc, "/users/")
+	doc["servers"] = []interface{}{
+		map[string]interface{}{"url": "http://gateway:8080/users/"},
+		map[string]interface{}{"url": "https://users.internal"},
+	}
+
+	PrepareClone(doc)
+	assert.NotContains(t, info, "id")
+	assert.NotContains(t, info, "dbId")
+	assert.NotContains(t, info, "orgId")
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://users.internal"}}, doc["servers"])
+
+	doc["servers"] = []interface{}{map[string]interface{}{"url": "http://gateway:8080/users"}}
+	PrepareClone(doc)
+	assert.NotContains(t, doc, "servers")
+}
+
+func TestSetAPIName(t *testing.T) {
+	doc := validTykDoc()
+	require.NoError(t, SetCategories(doc, []string{"payments"}))
+
+	require.NoError(t, SetAPIName(doc, "Copy of Users #sandbox"))
+	assert.Equal(t, "Copy of Users #payments #sandbox", GetAPIName(doc))
+	assert.Equal(t, "Copy of Users", doc["info"].(map[string]interface{})["title"])
+
+	assert.Error(t, SetAPIName(doc, "Copy #"+"a#b"))
+}
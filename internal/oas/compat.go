@@ -0,0 +1,89 @@
+package oas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Feature is an x-tyk-api-gateway capability that older Gateways reject
+type Feature struct {
+	ID          string
+	Description string
+	// Since is the first Tyk release supporting the feature
+	Since string
+
+	// global are the paths of API-wide settings within the extension
+	global [][]string
+	// operation is the key of a per-operation setting under middleware.operations
+	operation string
+}
+
+// Incompatibility is a use of a feature the target Tyk version does not support
+type Incompatibility struct {
+	Feature string `json:"feature"`
+	Since   string `json:"since"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Features lists the extension features checked by CheckCompatibility
+var Features = []Feature{
+	{ID: "plugin-hook-lists", Description: "custom plugin hook lists", Since: "5.3", global: [][]string{
+		{"middleware", "global", "prePlugins"},
+		{"middleware", "global", "postAuthenticationPlugins"},
+		{"middleware", "global", "postPlugins"},
+		{"middleware", "global", "responsePlugins"},
+	}},
+	{ID: "service-discovery-cache", Description: "service discovery cache settings", Since: "5.3", global: [][]string{{"upstream", "serviceDiscovery", "cache"}}},
+	{ID: "mock-response", Description: "operation mock responses", Since: "5.3", operation: "mockResponse"},
+	{ID: "circuit-breaker", Description: "operation circuit breakers", Since: "5.3", operation: "circuitBreaker"},
+	{ID: "request-size-limit", Description: "operation request size limits", Since: "5.3", operation: "requestSizeLimit"},
+	{ID: "internal-endpoint", Description: "internal endpoints", Since: "5.3", operation: "internal"},
+	{ID: "upstream-rate-limit", Description: "API-level rate limits", Since: "5.4", global: [][]string{{"upstream", "rateLimit"}}},
+	{ID: "operation-rate-limit", Description: "per-endpoint rate limits", Since: "5.4", operation: "rateLimit"},
+}
+
+// CheckCompatibility reports the features doc uses that Tyk version does not support,
+// ordered by path
+func CheckCompatibility(oasDoc map[string]interface{}, version string) ([]Incompatibility, error) {
+	target, err := ParseTykVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	problems := []Incompatibility{}
+	add := func(f Feature, path string) {
+		problems = append(problems, Incompatibility{
+			Feature: f.ID,
+			Since:   f.Since,
+			Path:    path,
+			Message: fmt.Sprintf("%s require Tyk >= %s", f.Description, f.Since),
+		})
+	}
+
+	operations := lookupSection(oasDoc, []string{"middleware", "operations"})
+	for _, f := range Features {
+		since, _ := ParseTykVersion(f.Since)
+		if compareVersions(target, since) >= 0 {
+			continue
+		}
+		for _, path := range f.global {
+			parent := lookupSection(oasDoc, path[:len(path)-1])
+			if _, ok := parent[path[len(path)-1]]; ok {
+				add(f, TykExtensionKey+"."+strings.Join(path, "."))
+			}
+		}
+		if f.operation == "" {
+			continue
+		}
+		for id, settings := range operations {
+			if op, ok := settings.(map[string]interface{}); ok {
+				if _, ok := op[f.operation]; ok {
+					add(f, fmt.Sprintf("%s.middleware.operations.%s.%s", TykExtensionKey, id, f.operation))
+				}
+			}
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Path < problems[j].Path })
+	return problems, nil
+}
@@ -0,0 +1,37 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	doc := validTykDoc()
+	require.NoError(t, SetMiddleware(doc, MiddlewareRateLimit, true, []string{"listUsers"}, map[string]interface{}{"rate": 10, "per": "60s"}))
+	tykSection(doc, "middleware", true)["global"] = map[string]interface{}{"prePlugins": []interface{}{}}
+
+	problems, err := CheckCompatibility(doc, "5.2.1")
+	require.NoError(t, err)
+	require.Len(t, problems, 2)
+	assert.Equal(t, Incompatibility{
+		Feature: "plugin-hook-lists",
+		Since:   "5.3",
+		Path:    TykExtensionKey + ".middleware.global.prePlugins",
+		Message: "custom plugin hook lists require Tyk >= 5.3",
+	}, problems[0])
+	assert.Equal(t, TykExtensionKey+".middleware.operations.listUsers.rateLimit", problems[1].Path)
+	assert.Equal(t, "5.4", problems[1].Since)
+
+	problems, err = CheckCompatibility(doc, "5.3")
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+
+	problems, err = CheckCompatibility(doc, "5.4.0")
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	_, err = CheckCompatibility(doc, "latest")
+	assert.Error(t, err)
+}
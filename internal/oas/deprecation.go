@@ -0,0 +1,117 @@
+package oas
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DeprecationKey is the info extension recording an API's end of life
+const DeprecationKey = "x-tyk-deprecation"
+
+// DateLayout is how deprecation dates are written
+const DateLayout = "2006-01-02"
+
+// Deprecation is the end of life of an API, as recorded under info.x-tyk-deprecation
+type Deprecation struct {
+	// Since is the date the API was deprecated
+	Since string `json:"since"`
+	// Sunset is the last day the API is served
+	Sunset string `json:"sunset"`
+	// Link is a page about the deprecation, such as a migration guide
+	Link string `json:"link,omitempty"`
+	// Retired is the date the API was disabled after its sunset
+	Retired string `json:"retired,omitempty"`
+}
+
+// SunsetTime returns the moment the API stops being served: the end of its sunset day, UTC
+func (d *Deprecation) SunsetTime() time.Time {
+	day, _ := time.Parse(DateLayout, d.Sunset)
+	return day.Add(24*time.Hour - time.Second)
+}
+
+// GetDeprecation returns the API's recorded deprecation, or nil when it is not deprecated
+func GetDeprecation(oasDoc map[string]interface{}) *Deprecation {
+	info, _ := oasDoc["info"].(map[string]interface{})
+	recorded, ok := info[DeprecationKey].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	d := &Deprecation{}
+	d.Since, _ = recorded["since"].(string)
+	d.Sunset, _ = recorded["sunset"].(string)
+	d.Link, _ = recorded["link"].(string)
+	d.Retired, _ = recorded["retired"].(string)
+	return d
+}
+
+// Deprecate records d in info.x-tyk-deprecation, marks every operation deprecated and
+// makes the Gateway announce it on every response with the Deprecation (RFC 9745) and
+// Sunset (RFC 8594) headers, and a Link to d.Link when there is one. Deprecating again
+// moves the dates.
+func Deprecate(oasDoc map[string]interface{}, d Deprecation) error {
+	since, err := time.Parse(DateLayout, d.Since)
+	if err != nil {
+		return fmt.Errorf("invalid deprecation date '%s': expected YYYY-MM-DD", d.Since)
+	}
+	if _, err := time.Parse(DateLayout, d.Sunset); err != nil {
+		return fmt.Errorf("invalid sunset date '%s': expected YYYY-MM-DD", d.Sunset)
+	}
+	if d.Sunset < d.Since {
+		return fmt.Errorf("sunset date %s is before the deprecation date %s", d.Sunset, d.Since)
+	}
+	if d.Link != "" {
+		if u, err := url.Parse(d.Link); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("deprecation link must be an absolute URL (got '%s')", d.Link)
+		}
+	}
+
+	info, ok := oasDoc["info"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("API definition has no info section")
+	}
+	recorded := map[string]interface{}{"since": d.Since, "sunset": d.Sunset}
+	if d.Link != "" {
+		recorded["link"] = d.Link
+	}
+	info[DeprecationKey] = recorded
+
+	for _, op := range operations(oasDoc) {
+		if op, ok := op.(map[string]interface{}); ok {
+			op["deprecated"] = true
+		}
+	}
+
+	headers := []Header{
+		{Name: "Deprecation", Value: "@" + strconv.FormatInt(since.Unix(), 10)},
+		{Name: "Sunset", Value: d.SunsetTime().Format(http.TimeFormat)},
+	}
+	if d.Link != "" {
+		headers = append(headers, Header{Name: "Link", Value: fmt.Sprintf("<%s>; rel=\"deprecation\"", d.Link)})
+	}
+	return TransformHeaders(oasDoc, HeadersResponse, headers, nil)
+}
+
+// Retire disables a deprecated API and records the day it was retired on. Retiring an
+// API again keeps the first date.
+func Retire(oasDoc map[string]interface{}, day string) error {
+	info, _ := oasDoc["info"].(map[string]interface{})
+	recorded, ok := info[DeprecationKey].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("API is not deprecated")
+	}
+	if retired, _ := recorded["retired"].(string); retired == "" {
+		recorded["retired"] = day
+	}
+
+	tykInfo := tykSection(oasDoc, "info", true)
+	state, ok := tykInfo["state"].(map[string]interface{})
+	if !ok {
+		state = map[string]interface{}{}
+		tykInfo["state"] = state
+	}
+	state["active"] = false
+	return nil
+}
@@ -0,0 +1,44 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecate(t *testing.T) {
+	doc := validTykDoc()
+	assert.Nil(t, GetDeprecation(doc))
+
+	require.NoError(t, Deprecate(doc, Deprecation{Since: "2025-06-01", Sunset: "2025-12-31", Link: "https://docs.example.com/users-v2"}))
+	assert.Equal(t, &Deprecation{Since: "2025-06-01", Sunset: "2025-12-31", Link: "https://docs.example.com/users-v2"}, GetDeprecation(doc))
+	assert.Equal(t, true, doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"].(map[string]interface{})["deprecated"])
+
+	headers := tykSection(doc, "middleware", false)["global"].(map[string]interface{})["transformResponseHeaders"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "Deprecation", "value": "@1748736000"},
+		map[string]interface{}{"name": "Sunset", "value": "Wed, 31 Dec 2025 23:59:59 GMT"},
+		map[string]interface{}{"name": "Link", "value": `<https://docs.example.com/users-v2>; rel="deprecation"`},
+	}, headers["add"])
+
+	// Deprecating again moves the dates without duplicating the headers
+	require.NoError(t, Deprecate(doc, Deprecation{Since: "2025-06-01", Sunset: "2026-03-31"}))
+	assert.Equal(t, "2026-03-31", GetDeprecation(doc).Sunset)
+	assert.Len(t, headers["add"], 3)
+
+	assert.ErrorContains(t, Deprecate(doc, Deprecation{Since: "2025-06-01", Sunset: "31/12/2025"}), "invalid sunset date")
+	assert.ErrorContains(t, Deprecate(doc, Deprecation{Since: "2025-06-01", Sunset: "2025-01-01"}), "before the deprecation date")
+	assert.ErrorContains(t, Deprecate(doc, Deprecation{Since: "2025-06-01", Sunset: "2025-12-31", Link: "docs/v2"}), "absolute URL")
+}
+
+func TestRetire(t *testing.T) {
+	doc := validTykDoc()
+	assert.ErrorContains(t, Retire(doc, "2026-01-05"), "not deprecated")
+
+	require.NoError(t, Deprecate(doc, Deprecation{Since: "2025-06-01", Sunset: "2025-12-31"}))
+	require.NoError(t, Retire(doc, "2026-01-05"))
+	require.NoError(t, Retire(doc, "2026-02-01"))
+	assert.Equal(t, "2026-01-05", GetDeprecation(doc).Retired)
+	assert.Equal(t, false, tykSection(doc, "info", false)["state"].(map[string]interface{})["active"])
+}
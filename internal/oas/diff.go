@@ -0,0 +1,97 @@
+package oas
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Change types reported by Diff
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Change is a single difference between two documents
+type Change struct {
+	Type string      `json:"type"`
+	Path []string    `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Diff returns the structural differences between two documents, ordered by path.
+// Objects are compared key by key; arrays and scalars are compared as whole values.
+func Diff(oldDoc, newDoc map[string]interface{}) []Change {
+	var changes []Change
+	diffValue(nil, oldDoc, newDoc, &changes)
+	return changes
+}
+
+func diffValue(path []string, oldValue, newValue interface{}, changes *[]Change) {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := map[string]interface{}{}
+		for k := range oldMap {
+			keys[k] = nil
+		}
+		for k := range newMap {
+			keys[k] = nil
+		}
+		for _, key := range sortedKeys(keys) {
+			child := append(append([]string{}, path...), key)
+			o, inOld := oldMap[key]
+			n, inNew := newMap[key]
+			switch {
+			case !inOld:
+				*changes = append(*changes, Change{Type: ChangeAdded, Path: child, New: n})
+			case !inNew:
+				*changes = append(*changes, Change{Type: ChangeRemoved, Path: child, Old: o})
+			default:
+				diffValue(child, o, n, changes)
+			}
+		}
+		return
+	}
+
+	if !equalValues(oldValue, newValue) {
+		*changes = append(*changes, Change{Type: ChangeModified, Path: path, Old: oldValue, New: newValue})
+	}
+}
+
+// equalValues compares decoded values, treating numbers of different Go types
+// (YAML ints vs JSON float64) as equal when they print the same
+func equalValues(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	switch a.(type) {
+	case int, int64, float64, uint64:
+		switch b.(type) {
+		case int, int64, float64, uint64:
+			return fmt.Sprint(a) == fmt.Sprint(b)
+		}
+	}
+	aSlice, aOK := a.([]interface{})
+	bSlice, bOK := b.([]interface{})
+	if aOK && bOK && len(aSlice) == len(bSlice) {
+		for i := range aSlice {
+			if !equalValues(aSlice[i], bSlice[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	aMap, aOK := a.(map[string]interface{})
+	bMap, bOK := b.(map[string]interface{})
+	if aOK && bOK && len(aMap) == len(bMap) {
+		for k, v := range aMap {
+			if w, ok := bMap[k]; !ok || !equalValues(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
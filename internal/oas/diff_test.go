@@ -0,0 +1,29 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	oldDoc := validTykDoc()
+	newDoc := validTykDoc()
+	assert.Empty(t, Diff(oldDoc, newDoc))
+
+	newDoc["info"].(map[string]interface{})["version"] = "1.1.0"
+	newDoc["info"].(map[string]interface{})["description"] = "User service"
+	delete(newDoc["paths"].(map[string]interface{}), "/users")
+
+	assert.Equal(t, []Change{
+		{Type: ChangeAdded, Path: []string{"info", "description"}, New: "User service"},
+		{Type: ChangeModified, Path: []string{"info", "version"}, Old: "1.0.0", New: "1.1.0"},
+		{Type: ChangeRemoved, Path: []string{"paths", "/users"}, Old: oldDoc["paths"].(map[string]interface{})["/users"]},
+	}, Diff(oldDoc, newDoc))
+}
+
+func TestDiff_NumbersCompareByValue(t *testing.T) {
+	oldDoc := map[string]interface{}{"rate": 10, "tags": []interface{}{1, "a"}}
+	newDoc := map[string]interface{}{"rate": float64(10), "tags": []interface{}{float64(1), "a"}}
+	assert.Empty(t, Diff(oldDoc, newDoc))
+}
@@ -0,0 +1,76 @@
+package oas
+
+import (
+	"slices"
+	"strconv"
+)
+
+// serverAssigned are the Tyk extension fields the Dashboard sets itself on save
+var serverAssigned = [][]string{
+	{TykExtensionKey, "info", "id"},
+	{TykExtensionKey, "info", "dbId"},
+	{TykExtensionKey, "info", "orgId"},
+}
+
+// EchoChanges compares a document sent to the Dashboard with the copy it stored and
+// returns what the Dashboard dropped (removed) or rewrote (modified). Fields and array
+// items that only the stored copy has are defaults the Dashboard filled in, and are
+// not reported; nor are the IDs it assigns.
+func EchoChanges(sent, stored map[string]interface{}) []Change {
+	var changes []Change
+	echoValue(nil, sent, stored, &changes)
+	return changes
+}
+
+func echoValue(path []string, sent, stored interface{}, changes *[]Change) {
+	for _, assigned := range serverAssigned {
+		if slices.Equal(path, assigned) {
+			return
+		}
+	}
+
+	sentMap, sentIsMap := sent.(map[string]interface{})
+	storedMap, storedIsMap := stored.(map[string]interface{})
+	if sentIsMap && storedIsMap {
+		for _, key := range sortedKeys(sentMap) {
+			child := append(append([]string{}, path...), key)
+			value, ok := storedMap[key]
+			if !ok {
+				*changes = append(*changes, Change{Type: ChangeRemoved, Path: child, Old: sentMap[key]})
+				continue
+			}
+			echoValue(child, sentMap[key], value, changes)
+		}
+		return
+	}
+
+	sentSlice, sentIsSlice := sent.([]interface{})
+	storedSlice, storedIsSlice := stored.([]interface{})
+	if sentIsSlice && storedIsSlice {
+		// The Dashboard adds items to some lists (servers gets the Gateway's URL), so an
+		// item is only reported when no stored item holds it. When the list kept its length
+		// and the stored item in its place holds no sent item, that item is its rewrite.
+		for i, item := range sentSlice {
+			child := append(append([]string{}, path...), strconv.Itoa(i))
+			switch {
+			case slices.ContainsFunc(storedSlice, func(candidate interface{}) bool { return covers(candidate, item) }):
+			case len(sentSlice) == len(storedSlice) && !slices.ContainsFunc(sentSlice, func(other interface{}) bool { return covers(storedSlice[i], other) }):
+				echoValue(child, item, storedSlice[i], changes)
+			default:
+				*changes = append(*changes, Change{Type: ChangeRemoved, Path: child, Old: item})
+			}
+		}
+		return
+	}
+
+	if !equalValues(sent, stored) {
+		*changes = append(*changes, Change{Type: ChangeModified, Path: path, Old: sent, New: stored})
+	}
+}
+
+// covers reports whether stored holds everything in sent, allowing extra object keys
+func covers(stored, sent interface{}) bool {
+	var changes []Change
+	echoValue(nil, sent, stored, &changes)
+	return len(changes) == 0
+}
@@ -0,0 +1,49 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEchoChanges(t *testing.T) {
+	sent := validTykDoc()
+	stored := validTykDoc()
+	assert.Empty(t, EchoChanges(sent, stored))
+
+	sent[TykExtensionKey].(map[string]interface{})["x-team"] = "payments"
+	sent[TykExtensionKey].(map[string]interface{})["upstream"] = map[string]interface{}{"url": "https://users.internal/"}
+	assert.Equal(t, []Change{
+		{Type: ChangeModified, Path: []string{TykExtensionKey, "upstream", "url"}, Old: "https://users.internal/", New: "https://users.internal"},
+		{Type: ChangeRemoved, Path: []string{TykExtensionKey, "x-team"}, Old: "payments"},
+	}, EchoChanges(sent, stored))
+}
+
+func TestEchoChanges_IgnoresServerAdditions(t *testing.T) {
+	sent := validTykDoc()
+	sent["servers"] = []interface{}{map[string]interface{}{"url": "https://api.example.com"}}
+	stored := validTykDoc()
+	stored["servers"] = []interface{}{
+		map[string]interface{}{"url": "http://gateway:8080/users/"},
+		map[string]interface{}{"url": "https://api.example.com"},
+	}
+	// IDs the Dashboard assigns, and defaults it fills in, are expected
+	stored[TykExtensionKey].(map[string]interface{})["info"] = map[string]interface{}{
+		"name": "Users", "id": "abc123", "state": map[string]interface{}{"active": true},
+	}
+	sent[TykExtensionKey].(map[string]interface{})["info"].(map[string]interface{})["id"] = ""
+	assert.Empty(t, EchoChanges(sent, stored))
+
+	sent["servers"] = append(sent["servers"].([]interface{}), map[string]interface{}{"url": "https://backup.example.com"})
+	assert.Equal(t, []Change{
+		{Type: ChangeRemoved, Path: []string{"servers", "1"}, Old: map[string]interface{}{"url": "https://backup.example.com"}},
+	}, EchoChanges(sent, stored))
+}
+
+func TestEchoChanges_RewrittenListItem(t *testing.T) {
+	sent := map[string]interface{}{"servers": []interface{}{map[string]interface{}{"url": "https://API.example.com"}}}
+	stored := map[string]interface{}{"servers": []interface{}{map[string]interface{}{"url": "https://api.example.com"}}}
+	assert.Equal(t, []Change{
+		{Type: ChangeModified, Path: []string{"servers", "0", "url"}, Old: "https://API.example.com", New: "https://api.example.com"},
+	}, EchoChanges(sent, stored))
+}
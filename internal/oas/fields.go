@@ -0,0 +1,71 @@
+package oas
+
+import (
+	"sort"
+	"strings"
+)
+
+// SelectFields returns a copy of doc pruned to the given fields, each a dot-separated
+// path such as "info" or "x-tyk-api-gateway.upstream". Objects on the way to a field
+// keep only the selected keys; the field's own value is kept whole. Fields that do not
+// exist in doc are returned in missing.
+func SelectFields(doc map[string]interface{}, fields []string) (selected map[string]interface{}, missing []string) {
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.Trim(strings.TrimSpace(field), "."); field != "" {
+			paths = append(paths, strings.Split(field, "."))
+		}
+	}
+	// Shorter paths first, so a field already kept whole is not pruned by a deeper one
+	sort.SliceStable(paths, func(i, j int) bool { return len(paths[i]) < len(paths[j]) })
+
+	selected = map[string]interface{}{}
+	kept := map[string]bool{}
+	for _, path := range paths {
+		field := strings.Join(path, ".")
+		if coveredBy(kept, path) {
+			continue
+		}
+		value, ok := lookupPath(doc, path)
+		if !ok {
+			missing = append(missing, field)
+			continue
+		}
+		dst := selected
+		for _, key := range path[:len(path)-1] {
+			next, ok := dst[key].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				dst[key] = next
+			}
+			dst = next
+		}
+		dst[path[len(path)-1]] = value
+		kept[field] = true
+	}
+	return selected, missing
+}
+
+// coveredBy reports whether path, or an object containing it, has already been kept
+func coveredBy(kept map[string]bool, path []string) bool {
+	for i := 1; i <= len(path); i++ {
+		if kept[strings.Join(path[:i], ".")] {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupPath(doc map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = doc
+	for _, key := range path {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
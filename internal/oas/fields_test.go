@@ -0,0 +1,33 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectFields(t *testing.T) {
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    map[string]interface{}{"title": "Users", "version": "1.0.0"},
+		"servers": []interface{}{map[string]interface{}{"url": "https://users.internal"}},
+		"paths":   map[string]interface{}{"/users": map[string]interface{}{}},
+		TykExtensionKey: map[string]interface{}{
+			"info":     map[string]interface{}{"name": "Users"},
+			"upstream": map[string]interface{}{"url": "https://users.internal"},
+		},
+	}
+
+	selected, missing := SelectFields(doc, []string{"x-tyk-api-gateway.upstream", "info", "info.title", "servers", "components.schemas", "openapi.version"})
+	assert.Equal(t, map[string]interface{}{
+		"info":    map[string]interface{}{"title": "Users", "version": "1.0.0"},
+		"servers": []interface{}{map[string]interface{}{"url": "https://users.internal"}},
+		TykExtensionKey: map[string]interface{}{
+			"upstream": map[string]interface{}{"url": "https://users.internal"},
+		},
+	}, selected)
+	assert.Equal(t, []string{"components.schemas", "openapi.version"}, missing)
+
+	// The source document is left alone
+	assert.Contains(t, doc[TykExtensionKey], "info")
+}
@@ -0,0 +1,127 @@
+package oas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Directions accepted by TransformHeaders
+const (
+	HeadersRequest  = "request"
+	HeadersResponse = "response"
+)
+
+// headerSections maps a direction to its global header transform in x-tyk-api-gateway.middleware
+var headerSections = map[string]string{
+	HeadersRequest:  "transformRequestHeaders",
+	HeadersResponse: "transformResponseHeaders",
+}
+
+// Header is a header added by a header transform
+type Header struct {
+	Name  string
+	Value string
+}
+
+// ParseHeader reads a header written as "Name: value"
+func ParseHeader(s string) (Header, error) {
+	name, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return Header{}, fmt.Errorf("header %q must be written as 'Name: value'", s)
+	}
+	name = strings.TrimSpace(name)
+	if err := checkHeaderName(name); err != nil {
+		return Header{}, err
+	}
+	return Header{Name: name, Value: strings.TrimSpace(value)}, nil
+}
+
+// TransformHeaders updates the API-wide request or response header transform: headers
+// in add are set (replacing any existing value for the same name) and names in remove
+// are stripped. A header moves between the two lists rather than appearing in both.
+func TransformHeaders(oasDoc map[string]interface{}, direction string, add []Header, remove []string) error {
+	key, ok := headerSections[direction]
+	if !ok {
+		return fmt.Errorf("unknown header direction '%s'", direction)
+	}
+	for _, name := range remove {
+		if err := checkHeaderName(name); err != nil {
+			return err
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	middleware := tykSection(oasDoc, "middleware", true)
+	global, ok := middleware["global"].(map[string]interface{})
+	if !ok {
+		global = map[string]interface{}{}
+		middleware["global"] = global
+	}
+	section, ok := global[key].(map[string]interface{})
+	if !ok {
+		section = map[string]interface{}{}
+		global[key] = section
+	}
+
+	added, _ := section["add"].([]interface{})
+	removed := stringItems(section["remove"])
+	for _, header := range add {
+		added = withoutHeader(added, header.Name)
+		added = append(added, map[string]interface{}{"name": header.Name, "value": header.Value})
+		removed = withoutName(removed, header.Name)
+	}
+	for _, name := range remove {
+		added = withoutHeader(added, name)
+		removed = append(withoutName(removed, name), name)
+	}
+
+	if added == nil {
+		added = []interface{}{}
+	}
+	removedItems := make([]interface{}, len(removed))
+	for i, name := range removed {
+		removedItems[i] = name
+	}
+	section["add"] = added
+	section["remove"] = removedItems
+	section["enabled"] = true
+	return nil
+}
+
+// withoutHeader drops the added header called name, ignoring case
+func withoutHeader(headers []interface{}, name string) []interface{} {
+	var kept []interface{}
+	for _, item := range headers {
+		if header, ok := item.(map[string]interface{}); ok {
+			if existing, _ := header["name"].(string); strings.EqualFold(existing, name) {
+				continue
+			}
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// withoutName drops name from names, ignoring case
+func withoutName(names []string, name string) []string {
+	var kept []string
+	for _, existing := range names {
+		if !strings.EqualFold(existing, name) {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
+
+// checkHeaderName rejects names that cannot be sent as an HTTP header
+func checkHeaderName(name string) error {
+	if name == "" {
+		return fmt.Errorf("header name must not be empty")
+	}
+	if strings.ContainsAny(name, " \t:\r\n") {
+		return fmt.Errorf("invalid header name %q", name)
+	}
+	return nil
+}
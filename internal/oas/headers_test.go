@@ -0,0 +1,38 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeader(t *testing.T) {
+	header, err := ParseHeader("X-Env: prod:eu")
+	require.NoError(t, err)
+	assert.Equal(t, Header{Name: "X-Env", Value: "prod:eu"}, header)
+
+	for _, bad := range []string{"X-Env", ": prod", "X Env: prod"} {
+		_, err := ParseHeader(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestTransformHeaders(t *testing.T) {
+	doc := validTykDoc()
+	require.NoError(t, TransformHeaders(doc, HeadersRequest, []Header{{Name: "X-Env", Value: "dev"}}, []string{"X-Debug"}))
+	require.NoError(t, TransformHeaders(doc, HeadersRequest, []Header{{Name: "x-env", Value: "prod"}, {Name: "X-Debug", Value: "1"}}, []string{"Cookie"}))
+
+	section := lookupSection(doc, []string{"middleware", "global", "transformRequestHeaders"})
+	assert.Equal(t, map[string]interface{}{
+		"enabled": true,
+		"add": []interface{}{
+			map[string]interface{}{"name": "x-env", "value": "prod"},
+			map[string]interface{}{"name": "X-Debug", "value": "1"},
+		},
+		"remove": []interface{}{"Cookie"},
+	}, section)
+	assert.Nil(t, lookupSection(doc, []string{"middleware", "global", "transformResponseHeaders"}))
+
+	assert.Error(t, TransformHeaders(doc, HeadersResponse, nil, []string{"Bad Header"}))
+}
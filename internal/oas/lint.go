@@ -0,0 +1,296 @@
+package oas
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SeverityOff disables a rule in a ruleset
+const SeverityOff = "off"
+
+// DefaultRuleset is the ruleset 'tyk oas lint' applies when none is given
+const DefaultRuleset = "tyk-recommended"
+
+// Lint rules
+const (
+	RuleServersDefined       = "servers-defined"
+	RuleUpstreamTLS          = "upstream-tls"
+	RuleInfoDescription      = "info-description"
+	RuleOperationIDUnique    = "operation-operationId-unique"
+	RuleOperationID          = "operation-operationId"
+	RuleOperationDescription = "operation-description"
+	RuleOperation4xxResponse = "operation-4xx-response"
+	RuleOperationSecurity    = "operation-security"
+)
+
+// LintRule is a style or consistency check a ruleset can enable
+type LintRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// check returns the problems found; the ruleset sets their severity
+	check func(oasDoc map[string]interface{}) []Diagnostic
+}
+
+// LintFinding is a problem found by a rule of a ruleset
+type LintFinding struct {
+	Rule string `json:"rule"`
+	Diagnostic
+}
+
+// lintRules lists every rule in the order findings are reported
+var lintRules = []LintRule{
+	{ID: RuleServersDefined, Description: "specs without a Tyk extension define servers, from which the upstream URL is derived", check: lintServersDefined},
+	{ID: RuleUpstreamTLS, Description: "the upstream URL uses TLS", check: lintUpstreamTLS},
+	{ID: RuleInfoDescription, Description: "info has a description", check: lintInfoDescription},
+	{ID: RuleOperationIDUnique, Description: "operationIds are unique across the spec", check: lintOperationIDUnique},
+	{ID: RuleOperationID, Description: "every operation has an operationId", check: lintOperationID},
+	{ID: RuleOperationDescription, Description: "every operation has a summary or description", check: lintOperationDescription},
+	{ID: RuleOperation4xxResponse, Description: "every operation documents a 4xx response", check: lintOperation4xxResponse},
+	{ID: RuleOperationSecurity, Description: "every operation requires authentication", check: lintOperationSecurity},
+}
+
+// builtinRulesets maps the name of each built-in ruleset to the severity of its rules
+var builtinRulesets = map[string]map[string]string{
+	"tyk-recommended": {
+		RuleServersDefined:       SeverityWarning,
+		RuleUpstreamTLS:          SeverityWarning,
+		RuleInfoDescription:      SeverityInfo,
+		RuleOperationIDUnique:    SeverityError,
+		RuleOperationID:          SeverityWarning,
+		RuleOperationDescription: SeverityWarning,
+		RuleOperation4xxResponse: SeverityWarning,
+		RuleOperationSecurity:    SeverityWarning,
+	},
+	// tyk-basic holds the checks of Lint and 'tyk oas validate --lint'
+	"tyk-basic": {
+		RuleServersDefined:       SeverityWarning,
+		RuleUpstreamTLS:          SeverityWarning,
+		RuleOperationID:          SeverityWarning,
+		RuleOperationDescription: SeverityWarning,
+	},
+}
+
+// Ruleset is a set of lint rules, each with its severity
+type Ruleset struct {
+	Name     string
+	severity map[string]string
+}
+
+// RulesetConfig is a custom ruleset, as read from a ruleset file: a built-in ruleset
+// and the severity of the rules it changes, "off" disabling them
+type RulesetConfig struct {
+	Extends string            `json:"extends" yaml:"extends"`
+	Rules   map[string]string `json:"rules" yaml:"rules"`
+}
+
+// LintRules returns every rule a ruleset can enable
+func LintRules() []LintRule {
+	return slices.Clone(lintRules)
+}
+
+// Rulesets returns the names of the built-in rulesets
+func Rulesets() []string {
+	names := make([]string, 0, len(builtinRulesets))
+	for name := range builtinRulesets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRuleset returns a built-in ruleset
+func NewRuleset(name string) (*Ruleset, error) {
+	rules, ok := builtinRulesets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown ruleset '%s' (built-in rulesets: %s)", name, strings.Join(Rulesets(), ", "))
+	}
+	severity := make(map[string]string, len(rules))
+	for id, level := range rules {
+		severity[id] = level
+	}
+	return &Ruleset{Name: name, severity: severity}, nil
+}
+
+// Ruleset builds the ruleset c describes, naming it name. It extends DefaultRuleset
+// unless c names another.
+func (c RulesetConfig) Ruleset(name string) (*Ruleset, error) {
+	base := c.Extends
+	if base == "" {
+		base = DefaultRuleset
+	}
+	ruleset, err := NewRuleset(base)
+	if err != nil {
+		return nil, err
+	}
+	ruleset.Name = name
+	for id, level := range c.Rules {
+		if !slices.ContainsFunc(lintRules, func(rule LintRule) bool { return rule.ID == id }) {
+			return nil, fmt.Errorf("unknown rule '%s'", id)
+		}
+		switch level {
+		case SeverityError, SeverityWarning, SeverityInfo:
+			ruleset.severity[id] = level
+		case SeverityOff:
+			delete(ruleset.severity, id)
+		default:
+			return nil, fmt.Errorf("rule %s: invalid severity '%s' (expected error, warning, info or off)", id, level)
+		}
+	}
+	return ruleset, nil
+}
+
+// Severity returns the severity of a rule, or SeverityOff when the ruleset does not
+// enable it
+func (r *Ruleset) Severity(rule string) string {
+	if level, ok := r.severity[rule]; ok {
+		return level
+	}
+	return SeverityOff
+}
+
+// Lint runs the enabled rules against a document
+func (r *Ruleset) Lint(oasDoc map[string]interface{}) []LintFinding {
+	findings := []LintFinding{}
+	for _, rule := range lintRules {
+		level, ok := r.severity[rule.ID]
+		if !ok {
+			continue
+		}
+		for _, d := range rule.check(oasDoc) {
+			d.Severity = level
+			findings = append(findings, LintFinding{Rule: rule.ID, Diagnostic: d})
+		}
+	}
+	return findings
+}
+
+// eachOperation calls fn for every operation of the document, in path order
+func eachOperation(oasDoc map[string]interface{}, fn func(path, method string, op map[string]interface{})) {
+	paths, _ := oasDoc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			if op, ok := item[method].(map[string]interface{}); ok {
+				fn(path, method, op)
+			}
+		}
+	}
+}
+
+func lintOperationIDUnique(oasDoc map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+	first := make(map[string]string)
+	eachOperation(oasDoc, func(path, method string, op map[string]interface{}) {
+		id, _ := op["operationId"].(string)
+		if id == "" {
+			return
+		}
+		operation := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+		if previous, ok := first[id]; ok {
+			diags = append(diags, Diagnostic{Path: []string{"paths", path, method, "operationId"},
+				Message: fmt.Sprintf("%s reuses operationId %s of %s", operation, id, previous)})
+			return
+		}
+		first[id] = operation
+	})
+	return diags
+}
+
+func lintOperationID(oasDoc map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+	eachOperation(oasDoc, func(path, method string, op map[string]interface{}) {
+		if id, _ := op["operationId"].(string); id == "" {
+			diags = append(diags, Diagnostic{Path: []string{"paths", path, method},
+				Message: fmt.Sprintf("%s %s has no operationId", strings.ToUpper(method), path)})
+		}
+	})
+	return diags
+}
+
+func lintOperationDescription(oasDoc map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+	eachOperation(oasDoc, func(path, method string, op map[string]interface{}) {
+		summary, _ := op["summary"].(string)
+		description, _ := op["description"].(string)
+		if summary == "" && description == "" {
+			diags = append(diags, Diagnostic{Path: []string{"paths", path, method},
+				Message: fmt.Sprintf("%s %s has no summary or description", strings.ToUpper(method), path)})
+		}
+	})
+	return diags
+}
+
+func lintOperation4xxResponse(oasDoc map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+	eachOperation(oasDoc, func(path, method string, op map[string]interface{}) {
+		responses, _ := op["responses"].(map[string]interface{})
+		for code := range responses {
+			if strings.HasPrefix(code, "4") || code == "default" {
+				return
+			}
+		}
+		location := []string{"paths", path, method}
+		if responses != nil {
+			location = append(location, "responses")
+		}
+		diags = append(diags, Diagnostic{Path: location,
+			Message: fmt.Sprintf("%s %s documents no 4xx response", strings.ToUpper(method), path)})
+	})
+	return diags
+}
+
+func lintOperationSecurity(oasDoc map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+	keyless := HasTykExtensions(oasDoc) && slices.Equal(AuthModes(oasDoc), []string{AuthKeyless})
+	eachOperation(oasDoc, func(path, method string, op map[string]interface{}) {
+		operation := fmt.Sprintf("%s %s", strings.ToUpper(method), path)
+		if keyless {
+			diags = append(diags, Diagnostic{Path: []string{"paths", path, method},
+				Message: fmt.Sprintf("%s is not secured: the API is keyless", operation)})
+			return
+		}
+		requirements, own := op["security"].([]interface{})
+		if !own {
+			requirements, _ = oasDoc["security"].([]interface{})
+		}
+		optional := len(requirements) == 0
+		for _, requirement := range requirements {
+			if schemes, _ := requirement.(map[string]interface{}); len(schemes) == 0 {
+				optional = true
+			}
+		}
+		if optional {
+			location := []string{"paths", path, method}
+			if own {
+				location = append(location, "security")
+			}
+			diags = append(diags, Diagnostic{Path: location,
+				Message: fmt.Sprintf("%s is not secured: no security requirement applies to it", operation)})
+		}
+	})
+	return diags
+}
+
+func lintInfoDescription(oasDoc map[string]interface{}) []Diagnostic {
+	info, _ := oasDoc["info"].(map[string]interface{})
+	if description, _ := info["description"].(string); description != "" {
+		return nil
+	}
+	return []Diagnostic{{Path: []string{"info"}, Message: "info has no description"}}
+}
+
+func lintServersDefined(oasDoc map[string]interface{}) []Diagnostic {
+	if servers, _ := oasDoc["servers"].([]interface{}); len(servers) == 0 && !HasTykExtensions(oasDoc) {
+		return []Diagnostic{{Path: []string{"servers"}, Message: "no servers defined: the upstream URL cannot be derived on import"}}
+	}
+	return nil
+}
+
+func lintUpstreamTLS(oasDoc map[string]interface{}) []Diagnostic {
+	if upstream, _ := tykSection(oasDoc, "upstream", false)["url"].(string); strings.HasPrefix(upstream, "http://") {
+		return []Diagnostic{{Path: []string{TykExtensionKey, "upstream", "url"}, Message: "upstream URL does not use TLS"}}
+	}
+	return nil
+}
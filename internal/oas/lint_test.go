@@ -0,0 +1,75 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintFindings(findings []LintFinding) []string {
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Severity+":"+f.Rule+":"+f.Message)
+	}
+	return got
+}
+
+func TestRuleset_Lint(t *testing.T) {
+	ruleset, err := NewRuleset(DefaultRuleset)
+	require.NoError(t, err)
+
+	doc := validTykDoc()
+	_, err = AddAuthentication(doc, ScaffoldAPIKey)
+	require.NoError(t, err)
+	doc["info"].(map[string]interface{})["description"] = "Users"
+	paths := doc["paths"].(map[string]interface{})
+	paths["/users"].(map[string]interface{})["get"].(map[string]interface{})["responses"] = map[string]interface{}{"200": map[string]interface{}{}, "404": map[string]interface{}{}}
+	assert.Empty(t, ruleset.Lint(doc))
+
+	paths["/users"].(map[string]interface{})["post"] = map[string]interface{}{
+		"operationId": "listUsers",
+		"summary":     "Create a user",
+		"responses":   map[string]interface{}{"default": map[string]interface{}{}},
+		"security":    []interface{}{},
+	}
+	paths["/health"] = map[string]interface{}{"get": map[string]interface{}{"responses": map[string]interface{}{"200": map[string]interface{}{}}}}
+	delete(doc["info"].(map[string]interface{}), "description")
+	assert.Equal(t, []string{
+		"info:info-description:info has no description",
+		"error:operation-operationId-unique:POST /users reuses operationId listUsers of GET /users",
+		"warning:operation-operationId:GET /health has no operationId",
+		"warning:operation-description:GET /health has no summary or description",
+		"warning:operation-4xx-response:GET /health documents no 4xx response",
+		"warning:operation-security:POST /users is not secured: no security requirement applies to it",
+	}, lintFindings(ruleset.Lint(doc)))
+
+	// Without authentication in the Tyk extension, every endpoint is open
+	keyless := validTykDoc()
+	keyless["security"] = []interface{}{map[string]interface{}{"api_key": []interface{}{}}}
+	findings := ruleset.Lint(keyless)
+	assert.Contains(t, lintFindings(findings), "warning:operation-security:GET /users is not secured: the API is keyless")
+}
+
+func TestRulesetConfig(t *testing.T) {
+	ruleset, err := RulesetConfig{Rules: map[string]string{
+		RuleOperation4xxResponse: SeverityError,
+		RuleInfoDescription:      SeverityOff,
+	}}.Ruleset("custom.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "custom.yaml", ruleset.Name)
+	assert.Equal(t, SeverityError, ruleset.Severity(RuleOperation4xxResponse))
+	assert.Equal(t, SeverityOff, ruleset.Severity(RuleInfoDescription))
+	assert.Equal(t, SeverityWarning, ruleset.Severity(RuleOperationSecurity))
+
+	basic, err := RulesetConfig{Extends: "tyk-basic"}.Ruleset("basic.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityOff, basic.Severity(RuleOperationSecurity))
+
+	_, err = RulesetConfig{Rules: map[string]string{"no-such-rule": SeverityError}}.Ruleset("x")
+	assert.ErrorContains(t, err, "unknown rule")
+	_, err = RulesetConfig{Rules: map[string]string{RuleOperationID: "fatal"}}.Ruleset("x")
+	assert.ErrorContains(t, err, "invalid severity")
+	_, err = RulesetConfig{Extends: "spectral:oas"}.Ruleset("x")
+	assert.ErrorContains(t, err, "unknown ruleset")
+}
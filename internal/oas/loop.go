@@ -0,0 +1,94 @@
+package oas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoopSelf is the looping target that sends a request back into the same API
+const LoopSelf = "self"
+
+// IsInternal reports whether x-tyk-api-gateway.info.state.internal is set
+func IsInternal(oasDoc map[string]interface{}) bool {
+	state, _ := tykSection(oasDoc, "info", false)["state"].(map[string]interface{})
+	internal, _ := state["internal"].(bool)
+	return internal
+}
+
+// SetInternal sets x-tyk-api-gateway.info.state.internal. Internal APIs are not routed
+// from outside the Gateway and can only be reached by looping from other APIs.
+func SetInternal(oasDoc map[string]interface{}, internal bool) {
+	info := tykSection(oasDoc, "info", true)
+	state, ok := info["state"].(map[string]interface{})
+	if !ok {
+		state = map[string]interface{}{"active": true}
+		info["state"] = state
+	}
+	state["internal"] = internal
+}
+
+// LoopURL returns the tyk:// URL that loops a request to target, an API ID or "self", at path
+func LoopURL(target, path string) string {
+	return "tyk://" + target + "/" + strings.TrimPrefix(path, "/")
+}
+
+// SetLoop routes requests to loopURL inside the Gateway instead of over the network. With
+// no operations the whole API's upstream becomes loopURL; otherwise each operation gets a
+// URL rewrite to it.
+func SetLoop(oasDoc map[string]interface{}, loopURL string, operations []string) error {
+	if !strings.HasPrefix(loopURL, "tyk://") {
+		return fmt.Errorf("looping target %q must be a tyk:// URL", loopURL)
+	}
+	if len(operations) == 0 {
+		tykSection(oasDoc, "upstream", true)["url"] = loopURL
+		return nil
+	}
+
+	ops, err := findOperations(oasDoc, operations)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		operationSettings(oasDoc, op)["urlRewrite"] = map[string]interface{}{
+			"enabled":   true,
+			"pattern":   ".*",
+			"rewriteTo": loopURL,
+		}
+	}
+	return nil
+}
+
+// findOperations looks up operations by ID, failing on the first that does not exist
+func findOperations(oasDoc map[string]interface{}, ids []string) ([]documentOperation, error) {
+	known := make(map[string]documentOperation)
+	for _, op := range operationIDs(oasDoc) {
+		known[op.id] = op
+	}
+	ops := make([]documentOperation, 0, len(ids))
+	for _, id := range ids {
+		op, ok := known[id]
+		if !ok {
+			return nil, fmt.Errorf("operation '%s' not found", id)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// operationSettings returns x-tyk-api-gateway.middleware.operations.<id>, creating it. Tyk
+// matches these settings by operationId, so the operation gets one written down.
+func operationSettings(oasDoc map[string]interface{}, op documentOperation) map[string]interface{} {
+	op.op["operationId"] = op.id
+	middleware := tykSection(oasDoc, "middleware", true)
+	operations, ok := middleware["operations"].(map[string]interface{})
+	if !ok {
+		operations = map[string]interface{}{}
+		middleware["operations"] = operations
+	}
+	settings, ok := operations[op.id].(map[string]interface{})
+	if !ok {
+		settings = map[string]interface{}{}
+		operations[op.id] = settings
+	}
+	return settings
+}
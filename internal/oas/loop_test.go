@@ -0,0 +1,32 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetInternal(t *testing.T) {
+	doc := validTykDoc()
+	assert.False(t, IsInternal(doc))
+	SetInternal(doc, true)
+	assert.True(t, IsInternal(doc))
+	SetInternal(doc, false)
+	assert.False(t, IsInternal(doc))
+}
+
+func TestSetLoop(t *testing.T) {
+	assert.Equal(t, "tyk://self/health", LoopURL(LoopSelf, "/health"))
+
+	doc := validTykDoc()
+	require.NoError(t, SetLoop(doc, LoopURL("9d3e5b2c", "/"), nil))
+	assert.Equal(t, "tyk://9d3e5b2c/", tykSection(doc, "upstream", false)["url"])
+
+	require.NoError(t, SetLoop(doc, LoopURL(LoopSelf, "/v2/users"), []string{"listUsers"}))
+	assert.Equal(t, map[string]interface{}{"enabled": true, "pattern": ".*", "rewriteTo": "tyk://self/v2/users"},
+		lookupSection(doc, []string{"middleware", "operations", "listUsers", "urlRewrite"}))
+
+	assert.Error(t, SetLoop(doc, LoopURL(LoopSelf, "/"), []string{"missing"}))
+	assert.Error(t, SetLoop(doc, "http://users.internal", nil))
+}
@@ -0,0 +1,231 @@
+package oas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Middleware names accepted by SetMiddleware and MiddlewareStatus
+const (
+	MiddlewareCache            = "cache"
+	MiddlewareCORS             = "cors"
+	MiddlewareRateLimit        = "rate-limit"
+	MiddlewareValidateRequest  = "validate-request"
+	MiddlewareContextVariables = "context-variables"
+	MiddlewareTrafficLogs      = "traffic-logs"
+)
+
+// MiddlewareNames lists the middleware SetMiddleware can toggle
+var MiddlewareNames = []string{
+	MiddlewareCache, MiddlewareCORS, MiddlewareRateLimit,
+	MiddlewareValidateRequest, MiddlewareContextVariables, MiddlewareTrafficLogs,
+}
+
+// middlewareSpec says where a middleware lives in x-tyk-api-gateway. apiPath is the
+// API-level section; opKey is the key under middleware.operations.<operationId>.
+// Either may be empty when the middleware has no setting at that level.
+type middlewareSpec struct {
+	apiPath  []string
+	opKey    string
+	defaults func() map[string]interface{}
+}
+
+var middlewareSpecs = map[string]middlewareSpec{
+	MiddlewareCache: {
+		apiPath: []string{"middleware", "global", "cache"},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"timeout": 60, "cacheAllSafeRequests": true}
+		},
+	},
+	MiddlewareCORS: {
+		apiPath: []string{"middleware", "global", "cors"},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"allowedOrigins": []interface{}{"*"},
+				"allowedMethods": []interface{}{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+				"allowedHeaders": []interface{}{"Origin", "Accept", "Content-Type", "Authorization"},
+			}
+		},
+	},
+	// The API-wide rate limit is an upstream setting in Tyk OAS
+	MiddlewareRateLimit: {
+		apiPath:  []string{"upstream", "rateLimit"},
+		opKey:    "rateLimit",
+		defaults: func() map[string]interface{} { return map[string]interface{}{} },
+	},
+	MiddlewareValidateRequest: {
+		opKey:    "validateRequest",
+		defaults: func() map[string]interface{} { return map[string]interface{}{"errorResponseCode": 422} },
+	},
+	MiddlewareContextVariables: {
+		apiPath:  []string{"middleware", "global", "contextVariables"},
+		defaults: func() map[string]interface{} { return map[string]interface{}{} },
+	},
+	MiddlewareTrafficLogs: {
+		apiPath:  []string{"middleware", "global", "trafficLogs"},
+		defaults: func() map[string]interface{} { return map[string]interface{}{} },
+	},
+}
+
+// MiddlewareSetting is the state of one middleware, API-wide or for one operation
+type MiddlewareSetting struct {
+	Middleware string                 `json:"middleware"`
+	Operation  string                 `json:"operation,omitempty"`
+	Path       string                 `json:"path"`
+	Enabled    bool                   `json:"enabled"`
+	Settings   map[string]interface{} `json:"settings,omitempty"`
+}
+
+// SetMiddleware enables or disables a middleware. With operations it applies to those
+// operation IDs; middleware that only exists per operation applies to every operation
+// when none are given. When enabling, settings are merged over the existing settings,
+// or over defaults when the middleware is not configured yet. Disabling keeps the
+// settings so enabling again restores them.
+func SetMiddleware(oasDoc map[string]interface{}, name string, enabled bool, operations []string, settings map[string]interface{}) error {
+	spec, ok := middlewareSpecs[name]
+	if !ok {
+		return fmt.Errorf("unknown middleware '%s' (expected one of %s)", name, strings.Join(MiddlewareNames, ", "))
+	}
+
+	if len(operations) == 0 && spec.apiPath != nil {
+		return setMiddlewareSection(oasDoc, spec.apiPath, spec, enabled, settings, name)
+	}
+	if spec.opKey == "" {
+		return fmt.Errorf("%s is configured for the whole API and cannot be set per operation", name)
+	}
+
+	ops := operationIDs(oasDoc)
+	if len(operations) > 0 {
+		var err error
+		if ops, err = findOperations(oasDoc, operations); err != nil {
+			return err
+		}
+	} else if len(ops) == 0 {
+		return fmt.Errorf("%s is configured per operation and the API has no operations", name)
+	}
+	for _, op := range ops {
+		// Tyk matches operation middleware by operationId, so make sure it is written down
+		op.op["operationId"] = op.id
+		path := []string{"middleware", "operations", op.id, spec.opKey}
+		if err := setMiddlewareSection(oasDoc, path, spec, enabled, settings, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setMiddlewareSection toggles the middleware section at path within the Tyk extension
+func setMiddlewareSection(oasDoc map[string]interface{}, path []string, spec middlewareSpec, enabled bool, settings map[string]interface{}, name string) error {
+	section := lookupSection(oasDoc, path)
+	if !enabled {
+		if section != nil {
+			section["enabled"] = false
+		}
+		return nil
+	}
+	if section == nil {
+		section = spec.defaults()
+	}
+	for key, value := range settings {
+		section[key] = value
+	}
+	if name == MiddlewareRateLimit && (section["rate"] == nil || section["per"] == nil) {
+		return fmt.Errorf("%s needs a rate and per to be enabled", name)
+	}
+	section["enabled"] = true
+
+	parent := tykSection(oasDoc, path[0], true)
+	for _, key := range path[1 : len(path)-1] {
+		child, ok := parent[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			parent[key] = child
+		}
+		parent = child
+	}
+	parent[path[len(path)-1]] = section
+	return nil
+}
+
+// MiddlewareStatus reports how a middleware is configured: one entry for the API-wide
+// setting and one per operation that configures it
+func MiddlewareStatus(oasDoc map[string]interface{}, name string) ([]MiddlewareSetting, error) {
+	spec, ok := middlewareSpecs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown middleware '%s' (expected one of %s)", name, strings.Join(MiddlewareNames, ", "))
+	}
+
+	var status []MiddlewareSetting
+	if spec.apiPath != nil {
+		status = append(status, middlewareSetting(oasDoc, name, "", spec.apiPath))
+	}
+	if spec.opKey != "" {
+		for _, op := range operationIDs(oasDoc) {
+			path := []string{"middleware", "operations", op.id, spec.opKey}
+			if lookupSection(oasDoc, path) != nil {
+				status = append(status, middlewareSetting(oasDoc, name, op.id, path))
+			}
+		}
+	}
+	if len(status) == 0 {
+		// Operation-only middleware that no operation configures
+		status = append(status, MiddlewareSetting{Middleware: name, Path: TykExtensionKey + ".middleware.operations.*." + spec.opKey})
+	}
+	return status, nil
+}
+
+func middlewareSetting(oasDoc map[string]interface{}, name, operation string, path []string) MiddlewareSetting {
+	setting := MiddlewareSetting{Middleware: name, Operation: operation, Path: TykExtensionKey + "." + strings.Join(path, ".")}
+	if section := lookupSection(oasDoc, path); section != nil {
+		setting.Enabled, _ = section["enabled"].(bool)
+		setting.Settings = make(map[string]interface{}, len(section))
+		for key, value := range section {
+			if key != "enabled" {
+				setting.Settings[key] = value
+			}
+		}
+	}
+	return setting
+}
+
+// lookupSection returns the object at path within the Tyk extension, or nil
+func lookupSection(oasDoc map[string]interface{}, path []string) map[string]interface{} {
+	section := tykSection(oasDoc, path[0], false)
+	for _, key := range path[1:] {
+		if section == nil {
+			return nil
+		}
+		section, _ = section[key].(map[string]interface{})
+	}
+	return section
+}
+
+// documentOperation is an operation with the ID Tyk knows it by
+type documentOperation struct {
+	id string
+	op map[string]interface{}
+}
+
+// operationIDs lists the document's operations in path and method order
+func operationIDs(oasDoc map[string]interface{}) []documentOperation {
+	var ops []documentOperation
+	paths, _ := oasDoc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			if op, ok := item[method].(map[string]interface{}); ok {
+				ops = append(ops, documentOperation{id: operationID(path, method, op), op: op})
+			}
+		}
+	}
+	return ops
+}
+
+// operationID returns an operation's operationId, or the one the Dashboard generates
+// for it when the spec has none
+func operationID(path, method string, op map[string]interface{}) string {
+	if id, _ := op["operationId"].(string); id != "" {
+		return id
+	}
+	return strings.TrimPrefix(path, "/") + strings.ToUpper(method)
+}
@@ -0,0 +1,57 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMiddleware(t *testing.T) {
+	doc := validTykDoc()
+
+	require.NoError(t, SetMiddleware(doc, MiddlewareCache, true, nil, nil))
+	status, err := MiddlewareStatus(doc, MiddlewareCache)
+	require.NoError(t, err)
+	require.Len(t, status, 1)
+	assert.True(t, status[0].Enabled)
+	assert.Equal(t, 60, status[0].Settings["timeout"])
+
+	// Disabling keeps the settings for the next enable
+	require.NoError(t, SetMiddleware(doc, MiddlewareCache, false, nil, nil))
+	cache := lookupSection(doc, []string{"middleware", "global", "cache"})
+	assert.Equal(t, false, cache["enabled"])
+	assert.Equal(t, 60, cache["timeout"])
+
+	assert.Error(t, SetMiddleware(doc, "gzip", true, nil, nil))
+	assert.Error(t, SetMiddleware(doc, MiddlewareCache, true, []string{"listUsers"}, nil))
+}
+
+func TestSetMiddleware_PerOperation(t *testing.T) {
+	doc := validTykDoc()
+	doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["post"] = map[string]interface{}{}
+
+	require.NoError(t, SetMiddleware(doc, MiddlewareValidateRequest, true, nil, nil))
+	status, err := MiddlewareStatus(doc, MiddlewareValidateRequest)
+	require.NoError(t, err)
+	require.Len(t, status, 2)
+	assert.Equal(t, "listUsers", status[0].Operation)
+	assert.Equal(t, "usersPOST", status[1].Operation)
+	assert.Equal(t, "usersPOST", doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["post"].(map[string]interface{})["operationId"])
+
+	assert.Error(t, SetMiddleware(doc, MiddlewareValidateRequest, true, []string{"deleteUser"}, nil))
+}
+
+func TestSetMiddleware_RateLimit(t *testing.T) {
+	doc := validTykDoc()
+	assert.Error(t, SetMiddleware(doc, MiddlewareRateLimit, true, nil, nil))
+
+	settings, err := RateLimitSettings(100, "1m")
+	require.NoError(t, err)
+	require.NoError(t, SetMiddleware(doc, MiddlewareRateLimit, true, nil, settings))
+	assert.Equal(t, map[string]interface{}{"enabled": true, "rate": int64(100), "per": "60s"},
+		lookupSection(doc, []string{"upstream", "rateLimit"}))
+
+	require.NoError(t, SetMiddleware(doc, MiddlewareRateLimit, true, []string{"listUsers"}, settings))
+	assert.NotNil(t, lookupSection(doc, []string{"middleware", "operations", "listUsers", "rateLimit"}))
+}
@@ -0,0 +1,626 @@
+package oas
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// postmanVariable matches {{name}} collection and environment variable references
+var postmanVariable = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+type postmanCollection struct {
+	Info struct {
+		Name        string          `json:"name"`
+		Description json.RawMessage `json:"description"`
+		Schema      string          `json:"schema"`
+	} `json:"info"`
+	Item     []*postmanItem `json:"item"`
+	Variable []postmanKV    `json:"variable"`
+	Auth     *postmanAuth   `json:"auth"`
+}
+
+// postmanItem is a folder when it has items of its own, otherwise a request
+type postmanItem struct {
+	Name        string            `json:"name"`
+	Description json.RawMessage   `json:"description"`
+	Item        []*postmanItem    `json:"item"`
+	Request     *postmanRequest   `json:"request"`
+	Response    []postmanResponse `json:"response"`
+}
+
+type postmanRequest struct {
+	Method      string          `json:"method"`
+	Header      []postmanKV     `json:"header"`
+	URL         json.RawMessage `json:"url"`
+	Body        *postmanBody    `json:"body"`
+	Description json.RawMessage `json:"description"`
+	Auth        *postmanAuth    `json:"auth"`
+}
+
+type postmanURL struct {
+	Raw      string          `json:"raw"`
+	Protocol string          `json:"protocol"`
+	Host     json.RawMessage `json:"host"`
+	Port     string          `json:"port"`
+	Path     json.RawMessage `json:"path"`
+	Query    []postmanKV     `json:"query"`
+	Variable []postmanKV     `json:"variable"`
+}
+
+type postmanBody struct {
+	Mode       string      `json:"mode"`
+	Raw        string      `json:"raw"`
+	URLEncoded []postmanKV `json:"urlencoded"`
+	FormData   []postmanKV `json:"formdata"`
+	Options    struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+type postmanResponse struct {
+	Name   string      `json:"name"`
+	Code   int         `json:"code"`
+	Header []postmanKV `json:"header"`
+	Body   string      `json:"body"`
+}
+
+type postmanAuth struct {
+	Type   string      `json:"type"`
+	APIKey []postmanKV `json:"apikey"`
+}
+
+type postmanKV struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Type     string      `json:"type"`
+	Disabled bool        `json:"disabled"`
+}
+
+func (kv postmanKV) value() string {
+	if s, ok := kv.Value.(string); ok {
+		return s
+	}
+	if kv.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(kv.Value)
+}
+
+// postmanConverter carries what the collection's requests build up
+type postmanConverter struct {
+	variables map[string]string
+	paths     map[string]interface{}
+	servers   []string
+	tags      []interface{}
+	opIDs     map[string]bool
+}
+
+// FromPostman converts a Postman collection (schema v2.0 or v2.1) into an OpenAPI 3
+// document. Every request becomes an operation, tagged with the folder it sits in; ":id"
+// and unresolved "{{id}}" path segments become path parameters, and example bodies of
+// requests and saved responses become examples. The hosts requests are sent to,
+// with collection variables resolved, become the servers. Requests repeating an
+// operation only add the responses it does not have yet.
+func FromPostman(data []byte) (map[string]interface{}, error) {
+	var collection postmanCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse Postman collection: %w", err)
+	}
+	if collection.Info.Name == "" || collection.Item == nil {
+		return nil, fmt.Errorf("not a Postman collection: info.name and item are required")
+	}
+	if schema := collection.Info.Schema; schema != "" && !strings.Contains(schema, "/v2.") {
+		return nil, fmt.Errorf("unsupported Postman collection schema %s; export the collection as v2.1", schema)
+	}
+
+	c := &postmanConverter{
+		variables: make(map[string]string, len(collection.Variable)),
+		paths:     map[string]interface{}{},
+		opIDs:     map[string]bool{},
+	}
+	for _, variable := range collection.Variable {
+		c.variables[variable.Key] = variable.value()
+	}
+	if err := c.items(collection.Item, ""); err != nil {
+		return nil, err
+	}
+
+	info := map[string]interface{}{"title": collection.Info.Name, "version": "1.0.0"}
+	if description := postmanDescription(collection.Info.Description); description != "" {
+		info["description"] = description
+	}
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    info,
+		"paths":   c.paths,
+	}
+	if len(c.servers) > 0 {
+		servers := make([]interface{}, len(c.servers))
+		for i, server := range c.servers {
+			servers[i] = map[string]interface{}{"url": server}
+		}
+		doc["servers"] = servers
+	}
+	if len(c.tags) > 0 {
+		doc["tags"] = c.tags
+	}
+	if name, scheme := postmanSecurityScheme(collection.Auth); scheme != nil {
+		doc["components"] = map[string]interface{}{
+			"securitySchemes": map[string]interface{}{name: scheme},
+		}
+		doc["security"] = []interface{}{map[string]interface{}{name: []interface{}{}}}
+	}
+	return doc, nil
+}
+
+func (c *postmanConverter) items(items []*postmanItem, folder string) error {
+	for _, item := range items {
+		if item.Request == nil {
+			// Folders are tags; nested folders tag their requests with the innermost one
+			if item.Item != nil {
+				tag := map[string]interface{}{"name": item.Name}
+				if description := postmanDescription(item.Description); description != "" {
+					tag["description"] = description
+				}
+				c.tags = append(c.tags, tag)
+			}
+			if err := c.items(item.Item, item.Name); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.request(item, folder); err != nil {
+			return fmt.Errorf("request '%s': %w", item.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *postmanConverter) request(item *postmanItem, folder string) error {
+	req := item.Request
+	method := strings.ToLower(req.Method)
+	if method == "" {
+		method = "get"
+	}
+	if !slices.Contains(httpMethods, method) {
+		return fmt.Errorf("unsupported method %s", req.Method)
+	}
+
+	u, err := c.parseURL(req.URL)
+	if err != nil {
+		return err
+	}
+	if server := c.server(u); server != "" && !slices.Contains(c.servers, server) {
+		c.servers = append(c.servers, server)
+	}
+
+	var params []interface{}
+	pathVariables := make(map[string]string, len(u.Variable))
+	for _, variable := range u.Variable {
+		pathVariables[variable.Key] = variable.value()
+	}
+	var segments []string
+	for _, segment := range postmanStrings(u.Path) {
+		name := ""
+		if strings.HasPrefix(segment, ":") {
+			name = segment[1:]
+		} else if m := postmanVariable.FindStringSubmatch(segment); m != nil && m[0] == segment {
+			if value, ok := c.variables[m[1]]; ok {
+				segments = append(segments, value)
+				continue
+			}
+			name = m[1]
+		}
+		if name == "" {
+			segments = append(segments, segment)
+			continue
+		}
+		segments = append(segments, "{"+name+"}")
+		param := map[string]interface{}{"name": name, "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}}
+		if value := pathVariables[name]; value != "" {
+			param["example"] = value
+		}
+		params = append(params, param)
+	}
+	path := "/" + strings.Join(segments, "/")
+
+	for _, query := range u.Query {
+		if query.Disabled || query.Key == "" {
+			continue
+		}
+		param := map[string]interface{}{"name": query.Key, "in": "query", "schema": map[string]interface{}{"type": "string"}}
+		if value := query.value(); value != "" && !postmanVariable.MatchString(value) {
+			param["example"] = value
+		}
+		params = append(params, param)
+	}
+	for _, header := range req.Header {
+		switch strings.ToLower(header.Key) {
+		case "", "content-type", "accept", "authorization":
+			continue
+		}
+		if header.Disabled {
+			continue
+		}
+		param := map[string]interface{}{"name": header.Key, "in": "header", "schema": map[string]interface{}{"type": "string"}}
+		if value := header.value(); value != "" && !postmanVariable.MatchString(value) {
+			param["example"] = value
+		}
+		params = append(params, param)
+	}
+
+	pathItem, _ := c.paths[path].(map[string]interface{})
+	if pathItem == nil {
+		pathItem = map[string]interface{}{}
+		c.paths[path] = pathItem
+	}
+	responses := postmanResponses(item.Response)
+	if existing, ok := pathItem[method].(map[string]interface{}); ok {
+		known, _ := existing["responses"].(map[string]interface{})
+		for code, response := range responses {
+			if _, ok := known[code]; !ok {
+				known[code] = response
+			}
+		}
+		return nil
+	}
+	if len(responses) == 0 {
+		responses["200"] = map[string]interface{}{"description": "Successful response"}
+	}
+
+	op := map[string]interface{}{
+		"summary":     item.Name,
+		"operationId": c.operationID(item.Name, path, method),
+		"responses":   responses,
+	}
+	if description := postmanDescription(req.Description); description != "" {
+		op["description"] = description
+	} else if description := postmanDescription(item.Description); description != "" {
+		op["description"] = description
+	}
+	if folder != "" {
+		op["tags"] = []interface{}{folder}
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if body := postmanRequestBody(req.Body, req.Header); body != nil {
+		op["requestBody"] = body
+	}
+	if req.Auth != nil && req.Auth.Type == "noauth" {
+		op["security"] = []interface{}{}
+	}
+	pathItem[method] = op
+	return nil
+}
+
+// parseURL returns a request's URL in its structured form; collections may hold it as
+// a plain string
+func (c *postmanConverter) parseURL(raw json.RawMessage) (*postmanURL, error) {
+	var u postmanURL
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("request has no url")
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		u.Raw = s
+	} else if err := json.Unmarshal(raw, &u); err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Host != nil || u.Path != nil || u.Raw == "" {
+		return &u, nil
+	}
+
+	rest := u.Raw
+	if scheme, after, ok := strings.Cut(rest, "://"); ok {
+		u.Protocol, rest = scheme, after
+	}
+	rest, query, _ := strings.Cut(rest, "?")
+	host, path, _ := strings.Cut(rest, "/")
+	u.Host, _ = json.Marshal(host)
+	u.Path, _ = json.Marshal(strings.Split(path, "/"))
+	if query != "" {
+		for _, pair := range strings.Split(query, "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			u.Query = append(u.Query, postmanKV{Key: key, Value: value})
+		}
+	}
+	return &u, nil
+}
+
+// server returns the base URL a request is sent to, or "" when a variable in it has no
+// value in the collection
+func (c *postmanConverter) server(u *postmanURL) string {
+	var host string
+	if json.Unmarshal(u.Host, &host) != nil {
+		host = strings.Join(postmanStrings(u.Host), ".")
+	}
+	if host == "" {
+		return ""
+	}
+	host = postmanVariable.ReplaceAllStringFunc(host, func(ref string) string {
+		if value, ok := c.variables[postmanVariable.FindStringSubmatch(ref)[1]]; ok {
+			return value
+		}
+		return ref
+	})
+	if postmanVariable.MatchString(host) {
+		return ""
+	}
+	if u.Port != "" {
+		host += ":" + u.Port
+	}
+	if !strings.Contains(host, "://") {
+		protocol := u.Protocol
+		if protocol == "" {
+			protocol = "https"
+		}
+		host = protocol + "://" + host
+	}
+	return strings.TrimSuffix(host, "/")
+}
+
+// operationID derives a unique camelCase operationId from a request's name
+func (c *postmanConverter) operationID(name, path, method string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper && b.Len() > 0 {
+				r = unicode.ToUpper(r)
+			} else if b.Len() == 0 {
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	id := b.String()
+	if id == "" {
+		id = operationID(path, method, nil)
+	}
+	unique := id
+	for i := 2; c.opIDs[unique]; i++ {
+		unique = id + strconv.Itoa(i)
+	}
+	c.opIDs[unique] = true
+	return unique
+}
+
+// postmanRequestBody returns the requestBody of a request's raw, urlencoded or form body
+func postmanRequestBody(body *postmanBody, headers []postmanKV) map[string]interface{} {
+	if body == nil {
+		return nil
+	}
+	var mediaType string
+	media := map[string]interface{}{}
+	switch body.Mode {
+	case "raw":
+		if strings.TrimSpace(body.Raw) == "" {
+			return nil
+		}
+		mediaType = rawMediaType(body.Options.Raw.Language, headers)
+		example, schema := exampleAndSchema(mediaType, body.Raw)
+		media["example"] = example
+		if schema != nil {
+			media["schema"] = schema
+		}
+	case "urlencoded", "formdata":
+		mediaType = "application/x-www-form-urlencoded"
+		fields := body.URLEncoded
+		if body.Mode == "formdata" {
+			mediaType = "multipart/form-data"
+			fields = body.FormData
+		}
+		properties := map[string]interface{}{}
+		for _, field := range fields {
+			if field.Disabled || field.Key == "" {
+				continue
+			}
+			property := map[string]interface{}{"type": "string"}
+			if field.Type == "file" {
+				property["format"] = "binary"
+			} else if value := field.value(); value != "" {
+				property["example"] = value
+			}
+			properties[field.Key] = property
+		}
+		if len(properties) == 0 {
+			return nil
+		}
+		media["schema"] = map[string]interface{}{"type": "object", "properties": properties}
+	default:
+		return nil
+	}
+	return map[string]interface{}{
+		"content": map[string]interface{}{mediaType: media},
+	}
+}
+
+// postmanResponses returns the responses of a request's saved examples, keyed by status
+func postmanResponses(saved []postmanResponse) map[string]interface{} {
+	responses := map[string]interface{}{}
+	for _, example := range saved {
+		code := strconv.Itoa(example.Code)
+		if example.Code == 0 {
+			code = "default"
+		}
+		if _, ok := responses[code]; ok {
+			continue
+		}
+		description := example.Name
+		if description == "" {
+			description = http.StatusText(example.Code)
+		}
+		if description == "" {
+			description = "Response"
+		}
+		response := map[string]interface{}{"description": description}
+		if strings.TrimSpace(example.Body) != "" {
+			mediaType := rawMediaType("", example.Header)
+			if mediaType == "text/plain" && json.Valid([]byte(example.Body)) {
+				mediaType = "application/json"
+			}
+			media := map[string]interface{}{}
+			example, schema := exampleAndSchema(mediaType, example.Body)
+			media["example"] = example
+			if schema != nil {
+				media["schema"] = schema
+			}
+			response["content"] = map[string]interface{}{mediaType: media}
+		}
+		responses[code] = response
+	}
+	return responses
+}
+
+// rawMediaType returns the media type of a raw body from the Content-Type header, or
+// else the language Postman's editor was set to
+func rawMediaType(language string, headers []postmanKV) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Key, "Content-Type") && !header.Disabled {
+			if mediaType, _, _ := strings.Cut(header.value(), ";"); strings.TrimSpace(mediaType) != "" {
+				return strings.TrimSpace(mediaType)
+			}
+		}
+	}
+	switch language {
+	case "json":
+		return "application/json"
+	case "xml":
+		return "application/xml"
+	case "html":
+		return "text/html"
+	case "javascript":
+		return "application/javascript"
+	}
+	return "text/plain"
+}
+
+// exampleAndSchema parses a JSON body into its example value and a schema inferred
+// from it; other bodies are string examples without a schema
+func exampleAndSchema(mediaType, body string) (interface{}, map[string]interface{}) {
+	if strings.Contains(mediaType, "json") {
+		var value interface{}
+		if err := json.Unmarshal([]byte(body), &value); err == nil {
+			return value, inferSchema(value)
+		}
+	}
+	return body, nil
+}
+
+// inferSchema describes the shape of an example value
+func inferSchema(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			properties[key] = inferSchema(child)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	case []interface{}:
+		items := map[string]interface{}{}
+		if len(v) > 0 {
+			items = inferSchema(v[0])
+		}
+		return map[string]interface{}{"type": "array", "items": items}
+	case string:
+		return map[string]interface{}{"type": "string"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	case float64:
+		if v == float64(int64(v)) {
+			return map[string]interface{}{"type": "integer"}
+		}
+		return map[string]interface{}{"type": "number"}
+	}
+	return map[string]interface{}{}
+}
+
+// postmanSecurityScheme maps a collection's auth onto an OAS security scheme
+func postmanSecurityScheme(auth *postmanAuth) (string, map[string]interface{}) {
+	if auth == nil {
+		return "", nil
+	}
+	switch auth.Type {
+	case "bearer":
+		return "bearerAuth", map[string]interface{}{"type": "http", "scheme": "bearer"}
+	case "basic":
+		return "basicAuth", map[string]interface{}{"type": "http", "scheme": "basic"}
+	case "oauth2":
+		// Postman keeps the token endpoints in its own settings; only the scheme carries over
+		return "oauth2", map[string]interface{}{"type": "oauth2", "flows": map[string]interface{}{}}
+	case "apikey":
+		name, in := "Authorization", "header"
+		for _, kv := range auth.APIKey {
+			switch kv.Key {
+			case "key":
+				name = kv.value()
+			case "in":
+				in = kv.value()
+			}
+		}
+		return "apiKeyAuth", map[string]interface{}{"type": "apiKey", "name": name, "in": in}
+	}
+	return "", nil
+}
+
+// postmanDescription returns a description Postman stores as a string or as
+// {"content": ...}
+func postmanDescription(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var d struct {
+		Content string `json:"content"`
+	}
+	json.Unmarshal(raw, &d)
+	return d.Content
+}
+
+// postmanStrings returns the segments of a host or path Postman stores as a string or
+// as a list
+func postmanStrings(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, "/")
+	}
+	var segments []interface{}
+	json.Unmarshal(raw, &segments)
+	out := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		switch v := segment.(type) {
+		case string:
+			if v != "" {
+				out = append(out, v)
+			}
+		case map[string]interface{}:
+			// Path segments may be {"type": "string", "value": "users"}
+			if value, _ := v["value"].(string); value != "" {
+				out = append(out, value)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPostmanCollection = `{
+  "info": {
+    "name": "Users Service",
+    "description": "Manage user accounts",
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "variable": [{"key": "baseUrl", "value": "https://users.example.com/v1"}],
+  "auth": {"type": "apikey", "apikey": [{"key": "key", "value": "X-API-Key"}, {"key": "in", "value": "header"}]},
+  "item": [
+    {
+      "name": "Users",
+      "description": "User accounts",
+      "item": [
+        {
+          "name": "List users",
+          "request": {
+            "method": "GET",
+            "url": {
+              "raw": "{{baseUrl}}/users?limit=10",
+              "host": ["{{baseUrl}}"],
+              "path": ["users"],
+              "query": [{"key": "limit", "value": "10"}, {"key": "debug", "value": "1", "disabled": true}]
+            }
+          },
+          "response": [
+            {"name": "Users found", "code": 200, "header": [{"key": "Content-Type", "value": "application/json"}], "body": "[{\"id\": 1, \"name\": \"Ada\"}]"}
+          ]
+        },
+        {
+          "name": "Create user",
+          "request": {
+            "method": "POST",
+            "header": [{"key": "Content-Type", "value": "application/json"}, {"key": "X-Request-ID", "value": "abc"}],
+            "body": {"mode": "raw", "raw": "{\"name\": \"Ada\", \"admin\": false}", "options": {"raw": {"language": "json"}}},
+            "url": "{{baseUrl}}/users"
+          }
+        },
+        {
+          "name": "Get user",
+          "request": {
+            "method": "GET",
+            "url": {
+              "host": ["{{baseUrl}}"],
+              "path": ["users", ":userId"],
+              "variable": [{"key": "userId", "value": "42"}]
+            }
+          }
+        },
+        {
+          "name": "Get user (not found)",
+          "request": {"method": "GET", "url": {"host": ["{{baseUrl}}"], "path": ["users", ":userId"]}},
+          "response": [{"name": "Missing", "code": 404, "body": "not found"}]
+        }
+      ]
+    },
+    {
+      "name": "Health",
+      "request": {"method": "GET", "auth": {"type": "noauth"}, "url": "http://localhost:8080/{{version}}/health"}
+    }
+  ]
+}`
+
+func TestFromPostman(t *testing.T) {
+	doc, err := FromPostman([]byte(testPostmanCollection))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Users Service", GetAPIName(doc))
+	assert.Equal(t, "Manage user accounts", doc["info"].(map[string]interface{})["description"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"url": "https://users.example.com/v1"},
+		map[string]interface{}{"url": "http://localhost:8080"},
+	}, doc["servers"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Users", "description": "User accounts"}}, doc["tags"])
+
+	paths := doc["paths"].(map[string]interface{})
+	require.Len(t, paths, 3)
+
+	list := paths["/users"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, "listUsers", list["operationId"])
+	assert.Equal(t, []interface{}{"Users"}, list["tags"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "limit", "in": "query", "schema": map[string]interface{}{"type": "string"}, "example": "10"},
+	}, list["parameters"])
+	ok := list["responses"].(map[string]interface{})["200"].(map[string]interface{})
+	assert.Equal(t, "Users found", ok["description"])
+	media := ok["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": float64(1), "name": "Ada"}}, media["example"])
+	assert.Equal(t, "array", media["schema"].(map[string]interface{})["type"])
+
+	create := paths["/users"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "createUser", create["operationId"])
+	body := create["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "Ada", "admin": false}, body["example"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":  map[string]interface{}{"type": "string"},
+			"admin": map[string]interface{}{"type": "boolean"},
+		},
+	}, body["schema"])
+	// Content-Type travels with the body; other headers become parameters
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "X-Request-ID", "in": "header", "schema": map[string]interface{}{"type": "string"}, "example": "abc"},
+	}, create["parameters"])
+
+	// The second request for GET /users/{userId} only adds its 404 response
+	get := paths["/users/{userId}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, "getUser", get["operationId"])
+	assert.Equal(t, "42", get["parameters"].([]interface{})[0].(map[string]interface{})["example"])
+	responses := get["responses"].(map[string]interface{})
+	assert.Contains(t, responses, "200")
+	assert.Equal(t, "not found", responses["404"].(map[string]interface{})["content"].(map[string]interface{})["text/plain"].(map[string]interface{})["example"])
+
+	// An unresolved variable in the path becomes a parameter; noauth opts out of security
+	health := paths["/{version}/health"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Nil(t, health["tags"])
+	assert.Equal(t, []interface{}{}, health["security"])
+
+	assert.Equal(t, map[string]interface{}{
+		"apiKeyAuth": map[string]interface{}{"type": "apiKey", "name": "X-API-Key", "in": "header"},
+	}, doc["components"].(map[string]interface{})["securitySchemes"])
+	assert.Empty(t, Lint(doc))
+}
+
+func TestFromPostman_Rejects(t *testing.T) {
+	_, err := FromPostman([]byte(`{"openapi": "3.0.3"}`))
+	assert.ErrorContains(t, err, "not a Postman collection")
+
+	_, err = FromPostman([]byte(`{"info": {"name": "Old", "schema": "https://schema.getpostman.com/json/collection/v1.0.0/collection.json"}, "item": []}`))
+	assert.ErrorContains(t, err, "export the collection as v2.1")
+
+	_, err = FromPostman([]byte(`{"info": {"name": "Bad"}, "item": [{"name": "Copy", "request": {"method": "COPY", "url": "/files"}}]}`))
+	assert.ErrorContains(t, err, "request 'Copy': unsupported method COPY")
+}
@@ -0,0 +1,174 @@
+package oas
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Finding severities, most urgent first
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+)
+
+// SeverityRank orders severities for remediation lists; lower is more urgent
+func SeverityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 3
+	}
+	return 4
+}
+
+// Finding is a security posture problem found in an API definition
+type Finding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// TLS posture checks
+const (
+	CheckTLSVerifyDisabled = "tls_verification_disabled"
+	CheckPlaintextUpstream = "plaintext_upstream"
+	CheckCustomDomainCert  = "custom_domain_without_certificate"
+	CheckWeakTLSVersion    = "weak_tls_min_version"
+)
+
+// TLSFindings reports TLS weaknesses in the Tyk extension of an API
+func TLSFindings(oasDoc map[string]interface{}) []Finding {
+	var findings []Finding
+
+	upstream := tykSection(oasDoc, "upstream", false)
+	transport, _ := upstream["tlsTransport"].(map[string]interface{})
+	if skip, _ := transport["insecureSkipVerify"].(bool); skip {
+		findings = append(findings, Finding{Check: CheckTLSVerifyDisabled, Severity: SeverityCritical,
+			Message: "upstream certificate verification is disabled (upstream.tlsTransport.insecureSkipVerify)"})
+	}
+
+	if raw, _ := upstream["url"].(string); raw != "" {
+		if u, err := url.Parse(raw); err == nil && (strings.EqualFold(u.Scheme, "http") || strings.EqualFold(u.Scheme, "ws")) {
+			findings = append(findings, Finding{Check: CheckPlaintextUpstream, Severity: SeverityHigh,
+				Message: fmt.Sprintf("upstream %s is not encrypted", raw)})
+		}
+	}
+
+	server := tykSection(oasDoc, "server", false)
+	if domain, ok := server["customDomain"].(map[string]interface{}); ok {
+		enabled, _ := domain["enabled"].(bool)
+		name, _ := domain["name"].(string)
+		certs, _ := domain["certificates"].([]interface{})
+		if enabled && name != "" && len(certs) == 0 {
+			findings = append(findings, Finding{Check: CheckCustomDomainCert, Severity: SeverityMedium,
+				Message: fmt.Sprintf("custom domain %s has no certificate attached", name)})
+		}
+	}
+
+	if version, _ := transport["minVersion"].(string); version == "1.0" || version == "1.1" {
+		findings = append(findings, Finding{Check: CheckWeakTLSVersion, Severity: SeverityLow,
+			Message: fmt.Sprintf("upstream allows TLS %s (upstream.tlsTransport.minVersion)", version)})
+	}
+
+	return findings
+}
+
+// Authentication modes reported by AuthModes
+const (
+	AuthKeyless = "keyless"
+	AuthAPIKey  = "api_key"
+	AuthJWT     = "jwt"
+	AuthOAuth2  = "oauth2"
+	AuthOIDC    = "oidc"
+	AuthMTLS    = "mtls"
+	AuthBasic   = "basic"
+	AuthHMAC    = "hmac"
+	AuthCustom  = "custom"
+	AuthUnknown = "unknown"
+)
+
+// AuthModes returns the sorted authentication modes an API enforces. Security schemes
+// enabled in x-tyk-api-gateway.server.authentication are classified by their OAS
+// definition in components.securitySchemes; an API with authentication disabled and
+// no client certificates is keyless.
+func AuthModes(oasDoc map[string]interface{}) []string {
+	modes := make(map[string]bool)
+	server := tykSection(oasDoc, "server", false)
+
+	if certs, ok := server["clientCertificates"].(map[string]interface{}); ok {
+		if enabled, _ := certs["enabled"].(bool); enabled {
+			modes[AuthMTLS] = true
+		}
+	}
+
+	auth, _ := server["authentication"].(map[string]interface{})
+	if enabled, _ := auth["enabled"].(bool); enabled {
+		components, _ := oasDoc["components"].(map[string]interface{})
+		definitions, _ := components["securitySchemes"].(map[string]interface{})
+		schemes, _ := auth["securitySchemes"].(map[string]interface{})
+		for name, value := range schemes {
+			scheme, _ := value.(map[string]interface{})
+			if enabled, _ := scheme["enabled"].(bool); !enabled {
+				continue
+			}
+			definition, _ := definitions[name].(map[string]interface{})
+			modes[classifySecurityScheme(definition)] = true
+		}
+		for key, mode := range map[string]string{"hmac": AuthHMAC, "custom": AuthCustom} {
+			if section, ok := auth[key].(map[string]interface{}); ok {
+				if enabled, _ := section["enabled"].(bool); enabled {
+					modes[mode] = true
+				}
+			}
+		}
+		if len(modes) == 0 {
+			modes[AuthUnknown] = true
+		}
+	}
+
+	if len(modes) == 0 {
+		return []string{AuthKeyless}
+	}
+	result := make([]string, 0, len(modes))
+	for mode := range modes {
+		result = append(result, mode)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// classifySecurityScheme maps an OAS security scheme definition to an auth mode
+func classifySecurityScheme(definition map[string]interface{}) string {
+	schemeType, _ := definition["type"].(string)
+	switch schemeType {
+	case "apiKey":
+		return AuthAPIKey
+	case "oauth2":
+		return AuthOAuth2
+	case "openIdConnect":
+		return AuthOIDC
+	case "mutualTLS":
+		return AuthMTLS
+	case "http":
+		scheme, _ := definition["scheme"].(string)
+		format, _ := definition["bearerFormat"].(string)
+		switch {
+		case strings.EqualFold(scheme, "basic"):
+			return AuthBasic
+		case strings.EqualFold(scheme, "bearer") && strings.EqualFold(format, "jwt"):
+			return AuthJWT
+		case strings.EqualFold(scheme, "bearer"):
+			return AuthAPIKey
+		}
+	}
+	return AuthUnknown
+}
@@ -0,0 +1,79 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func findingChecks(findings []Finding) []string {
+	var checks []string
+	for _, f := range findings {
+		checks = append(checks, f.Severity+":"+f.Check)
+	}
+	return checks
+}
+
+func TestTLSFindings(t *testing.T) {
+	assert.Empty(t, TLSFindings(validTykDoc()))
+
+	doc := validTykDoc()
+	upstream := tykSection(doc, "upstream", false)
+	upstream["url"] = "http://users.internal"
+	upstream["tlsTransport"] = map[string]interface{}{"insecureSkipVerify": true, "minVersion": "1.1"}
+	tykSection(doc, "server", false)["customDomain"] = map[string]interface{}{"enabled": true, "name": "api.example.com"}
+
+	assert.Equal(t, []string{
+		"critical:" + CheckTLSVerifyDisabled,
+		"high:" + CheckPlaintextUpstream,
+		"medium:" + CheckCustomDomainCert,
+		"low:" + CheckWeakTLSVersion,
+	}, findingChecks(TLSFindings(doc)))
+
+	tykSection(doc, "server", false)["customDomain"] = map[string]interface{}{"enabled": true, "name": "api.example.com", "certificates": []interface{}{"cert-id"}}
+	assert.NotContains(t, findingChecks(TLSFindings(doc)), "medium:"+CheckCustomDomainCert)
+}
+
+func TestSeverityRank(t *testing.T) {
+	assert.Less(t, SeverityRank(SeverityCritical), SeverityRank(SeverityHigh))
+	assert.Less(t, SeverityRank(SeverityMedium), SeverityRank(SeverityLow))
+	assert.Less(t, SeverityRank(SeverityLow), SeverityRank("unknown"))
+}
+
+func TestAuthModes(t *testing.T) {
+	assert.Equal(t, []string{AuthKeyless}, AuthModes(validTykDoc()))
+
+	doc := validTykDoc()
+	doc["components"] = map[string]interface{}{
+		"securitySchemes": map[string]interface{}{
+			"token":  map[string]interface{}{"type": "apiKey", "in": "header", "name": "Authorization"},
+			"jwt":    map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			"basic":  map[string]interface{}{"type": "http", "scheme": "basic"},
+			"oauth":  map[string]interface{}{"type": "oauth2"},
+			"legacy": map[string]interface{}{"type": "apiKey"},
+		},
+	}
+	server := tykSection(doc, "server", false)
+	server["authentication"] = map[string]interface{}{
+		"enabled": true,
+		"securitySchemes": map[string]interface{}{
+			"token":  map[string]interface{}{"enabled": true},
+			"jwt":    map[string]interface{}{"enabled": true},
+			"oauth":  map[string]interface{}{"enabled": true},
+			"basic":  map[string]interface{}{"enabled": false},
+			"legacy": map[string]interface{}{"enabled": true},
+		},
+		"hmac": map[string]interface{}{"enabled": true},
+	}
+	server["clientCertificates"] = map[string]interface{}{"enabled": true}
+	assert.Equal(t, []string{AuthAPIKey, AuthHMAC, AuthJWT, AuthMTLS, AuthOAuth2}, AuthModes(doc))
+
+	// Authentication switched off leaves only mTLS
+	server["authentication"].(map[string]interface{})["enabled"] = false
+	assert.Equal(t, []string{AuthMTLS}, AuthModes(doc))
+
+	// Enabled without a recognisable scheme
+	delete(server, "clientCertificates")
+	server["authentication"] = map[string]interface{}{"enabled": true}
+	assert.Equal(t, []string{AuthUnknown}, AuthModes(doc))
+}
@@ -0,0 +1,130 @@
+package oas
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tykSection returns the named object inside x-tyk-api-gateway, creating it when create is set
+func tykSection(oasDoc map[string]interface{}, name string, create bool) map[string]interface{} {
+	tykExt, ok := oasDoc[TykExtensionKey].(map[string]interface{})
+	if !ok {
+		if !create {
+			return nil
+		}
+		tykExt = map[string]interface{}{}
+		oasDoc[TykExtensionKey] = tykExt
+	}
+	section, ok := tykExt[name].(map[string]interface{})
+	if !ok {
+		if !create {
+			return nil
+		}
+		section = map[string]interface{}{}
+		tykExt[name] = section
+	}
+	return section
+}
+
+// GetAPIName returns x-tyk-api-gateway.info.name, falling back to info.title
+func GetAPIName(oasDoc map[string]interface{}) string {
+	if info := tykSection(oasDoc, "info", false); info != nil {
+		if name, ok := info["name"].(string); ok && name != "" {
+			return name
+		}
+	}
+	if info, ok := oasDoc["info"].(map[string]interface{}); ok {
+		if title, ok := info["title"].(string); ok {
+			return title
+		}
+	}
+	return ""
+}
+
+// GetListenPath returns x-tyk-api-gateway.server.listenPath.value
+func GetListenPath(oasDoc map[string]interface{}) string {
+	server := tykSection(oasDoc, "server", false)
+	if server == nil {
+		return ""
+	}
+	listenPath, ok := server["listenPath"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := listenPath["value"].(string)
+	return value
+}
+
+// SetListenPath sets x-tyk-api-gateway.server.listenPath.value
+func SetListenPath(oasDoc map[string]interface{}, value string) {
+	server := tykSection(oasDoc, "server", true)
+	listenPath, ok := server["listenPath"].(map[string]interface{})
+	if !ok {
+		listenPath = map[string]interface{}{"strip": true}
+		server["listenPath"] = listenPath
+	}
+	listenPath["value"] = value
+}
+
+// PrefixListenPath inserts prefix at the start of the first path segment: "/users/" -> "/pr-1-users/"
+func PrefixListenPath(listenPath, prefix string) string {
+	return "/" + prefix + strings.TrimPrefix(listenPath, "/")
+}
+
+// ApplyPrefix namespaces an API by prefixing its name, title and listen path.
+// The document must already carry Tyk extensions.
+func ApplyPrefix(oasDoc map[string]interface{}, prefix string) error {
+	if !HasTykExtensions(oasDoc) {
+		return fmt.Errorf("cannot prefix API without %s extensions", TykExtensionKey)
+	}
+
+	name := GetAPIName(oasDoc)
+	tykInfo := tykSection(oasDoc, "info", true)
+	if !strings.HasPrefix(name, prefix) {
+		tykInfo["name"] = prefix + name
+	}
+
+	if info, ok := oasDoc["info"].(map[string]interface{}); ok {
+		if title, ok := info["title"].(string); ok && !strings.HasPrefix(title, prefix) {
+			info["title"] = prefix + title
+		}
+	}
+
+	listenPath := GetListenPath(oasDoc)
+	if listenPath == "" {
+		listenPath = GenerateListenPath(name)
+	}
+	if !strings.HasPrefix(strings.TrimPrefix(listenPath, "/"), prefix) {
+		SetListenPath(oasDoc, PrefixListenPath(listenPath, prefix))
+	}
+
+	return nil
+}
+
+// MountListenPath moves an API's listen path under base: "/users/" with base "/pay"
+// becomes "/pay/users/". Listen paths already under base are left alone. The document
+// must already carry Tyk extensions.
+func MountListenPath(oasDoc map[string]interface{}, base string) error {
+	if !HasTykExtensions(oasDoc) {
+		return fmt.Errorf("cannot mount API without %s extensions", TykExtensionKey)
+	}
+	listenPath := GetListenPath(oasDoc)
+	if listenPath == "" {
+		listenPath = GenerateListenPath(GetAPIName(oasDoc))
+	}
+	if UnderListenPath(listenPath, base) {
+		return nil
+	}
+	SetListenPath(oasDoc, "/"+strings.Trim(base, "/")+"/"+strings.TrimPrefix(listenPath, "/"))
+	return nil
+}
+
+// UnderListenPath reports whether listenPath is base or a path below it; "/pay/users/"
+// is under "/pay" but "/payments/" is not
+func UnderListenPath(listenPath, base string) bool {
+	base = "/" + strings.Trim(base, "/")
+	if base == "/" {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSuffix(listenPath, "/")+"/", base+"/")
+}
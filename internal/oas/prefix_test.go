@@ -0,0 +1,61 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyPrefix(t *testing.T) {
+	doc := map[string]interface{}{
+		"info": map[string]interface{}{"title": "Users"},
+		TykExtensionKey: map[string]interface{}{
+			"info": map[string]interface{}{"name": "Users"},
+			"server": map[string]interface{}{
+				"listenPath": map[string]interface{}{"value": "/users/", "strip": true},
+			},
+		},
+	}
+
+	require.NoError(t, ApplyPrefix(doc, "pr-123-"))
+	assert.Equal(t, "pr-123-Users", GetAPIName(doc))
+	assert.Equal(t, "pr-123-Users", doc["info"].(map[string]interface{})["title"])
+	assert.Equal(t, "/pr-123-users/", GetListenPath(doc))
+
+	// Applying the same prefix twice is a no-op
+	require.NoError(t, ApplyPrefix(doc, "pr-123-"))
+	assert.Equal(t, "pr-123-Users", GetAPIName(doc))
+	assert.Equal(t, "/pr-123-users/", GetListenPath(doc))
+}
+
+func TestApplyPrefix_RequiresExtensions(t *testing.T) {
+	doc := map[string]interface{}{"info": map[string]interface{}{"title": "Users"}}
+	assert.Error(t, ApplyPrefix(doc, "pr-1-"))
+}
+
+func TestPrefixListenPath(t *testing.T) {
+	assert.Equal(t, "/pr-1-users/", PrefixListenPath("/users/", "pr-1-"))
+	assert.Equal(t, "/pr-1-", PrefixListenPath("/", "pr-1-"))
+	assert.Equal(t, "/pr-1-v1/orders", PrefixListenPath("v1/orders", "pr-1-"))
+}
+
+func TestMountListenPath(t *testing.T) {
+	doc := map[string]interface{}{
+		TykExtensionKey: map[string]interface{}{
+			"info":   map[string]interface{}{"name": "Users"},
+			"server": map[string]interface{}{"listenPath": map[string]interface{}{"value": "/users/", "strip": true}},
+		},
+	}
+	require.NoError(t, MountListenPath(doc, "/pay/"))
+	assert.Equal(t, "/pay/users/", GetListenPath(doc))
+
+	// Mounting twice is a no-op, and paths merely starting with the same letters are moved
+	require.NoError(t, MountListenPath(doc, "/pay"))
+	assert.Equal(t, "/pay/users/", GetListenPath(doc))
+	SetListenPath(doc, "/payments/")
+	require.NoError(t, MountListenPath(doc, "pay"))
+	assert.Equal(t, "/pay/payments/", GetListenPath(doc))
+
+	assert.Error(t, MountListenPath(map[string]interface{}{}, "/pay"))
+}
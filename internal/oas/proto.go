@@ -0,0 +1,871 @@
+package oas
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ProtoOptions choose what FromProto describes
+type ProtoOptions struct {
+	// Service is the service to describe; it may be left empty when the file has one
+	Service string
+	// HTTPRules describes the REST routes of the google.api.http annotations instead of
+	// the gRPC method routes
+	HTTPRules bool
+}
+
+// ProtoService is the service FromProto described
+type ProtoService struct {
+	// FullName is the package-qualified name gRPC routes start with, e.g. greeter.v1.Greeter
+	FullName string
+	// Streaming lists the methods that stream requests or responses
+	Streaming []string
+}
+
+// GRPCListenPath is the listen path gRPC clients call a service on: its method routes
+// are /<package>.<Service>/<Method>
+func (s *ProtoService) GRPCListenPath() string {
+	return "/" + s.FullName + "/"
+}
+
+type protoFile struct {
+	pkg      string
+	services []*protoService
+	messages map[string]*protoMessage
+	enums    map[string][]string
+}
+
+type protoService struct {
+	name    string
+	methods []*protoMethod
+}
+
+type protoMethod struct {
+	name            string
+	input, output   string
+	clientStreaming bool
+	serverStreaming bool
+	// http is the google.api.http rule: the method key (get, post, ...) and path, and body
+	httpMethod, httpPath, httpBody string
+	// scope is the full name of the service, for resolving type names
+	scope string
+}
+
+type protoMessage struct {
+	fullName string
+	fields   []*protoField
+}
+
+type protoField struct {
+	name     string
+	typeName string
+	repeated bool
+	// mapKey is set for map<key, value> fields, whose typeName is the value type
+	mapKey string
+}
+
+// protoScalars maps proto scalar types to OAS schemas, as encoded by the proto3 JSON
+// mapping (64-bit integers are strings)
+var protoScalars = map[string]map[string]interface{}{
+	"double":   {"type": "number", "format": "double"},
+	"float":    {"type": "number", "format": "float"},
+	"int32":    {"type": "integer", "format": "int32"},
+	"sint32":   {"type": "integer", "format": "int32"},
+	"sfixed32": {"type": "integer", "format": "int32"},
+	"uint32":   {"type": "integer", "format": "int64", "minimum": 0},
+	"fixed32":  {"type": "integer", "format": "int64", "minimum": 0},
+	"int64":    {"type": "string", "format": "int64"},
+	"sint64":   {"type": "string", "format": "int64"},
+	"sfixed64": {"type": "string", "format": "int64"},
+	"uint64":   {"type": "string", "format": "uint64"},
+	"fixed64":  {"type": "string", "format": "uint64"},
+	"bool":     {"type": "boolean"},
+	"string":   {"type": "string"},
+	"bytes":    {"type": "string", "format": "byte"},
+}
+
+// protoWellKnown maps the google.protobuf types with a special JSON form
+var protoWellKnown = map[string]map[string]interface{}{
+	"google.protobuf.Timestamp":   {"type": "string", "format": "date-time"},
+	"google.protobuf.Duration":    {"type": "string", "example": "1.5s"},
+	"google.protobuf.Empty":       {"type": "object"},
+	"google.protobuf.Struct":      {"type": "object", "additionalProperties": true},
+	"google.protobuf.Any":         {"type": "object", "additionalProperties": true},
+	"google.protobuf.Value":       {},
+	"google.protobuf.FieldMask":   {"type": "string"},
+	"google.protobuf.StringValue": {"type": "string"},
+	"google.protobuf.BytesValue":  {"type": "string", "format": "byte"},
+	"google.protobuf.BoolValue":   {"type": "boolean"},
+	"google.protobuf.Int32Value":  {"type": "integer", "format": "int32"},
+	"google.protobuf.UInt32Value": {"type": "integer", "format": "int64"},
+	"google.protobuf.Int64Value":  {"type": "string", "format": "int64"},
+	"google.protobuf.UInt64Value": {"type": "string", "format": "uint64"},
+	"google.protobuf.FloatValue":  {"type": "number", "format": "float"},
+	"google.protobuf.DoubleValue": {"type": "number", "format": "double"},
+}
+
+// httpTemplateVariable matches {field} and {field=pattern} in google.api.http paths
+var httpTemplateVariable = regexp.MustCompile(`\{([A-Za-z0-9_.]+)(=[^}]*)?\}`)
+
+// FromProto converts a service of a proto3 file into an OpenAPI 3 document. By default
+// every method is a POST operation on /<Method>, the route of a gRPC call under the
+// service's GRPCListenPath, with its messages as gRPC bodies. With opts.HTTPRules the
+// methods are described by their google.api.http annotations instead, as a
+// grpc-gateway serves them: path variables become path parameters, the rule's body the
+// JSON request body, and the remaining fields of GET and DELETE requests query parameters.
+// Messages become component schemas in their proto3 JSON form.
+func FromProto(data []byte, opts ProtoOptions) (map[string]interface{}, *ProtoService, error) {
+	file, err := parseProto(string(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var service *protoService
+	names := make([]string, len(file.services))
+	for i, candidate := range file.services {
+		names[i] = candidate.name
+		if candidate.name == opts.Service || file.qualify(candidate.name) == opts.Service {
+			service = candidate
+		}
+	}
+	switch {
+	case len(file.services) == 0:
+		return nil, nil, fmt.Errorf("no service is defined")
+	case opts.Service == "" && len(file.services) == 1:
+		service = file.services[0]
+	case opts.Service == "":
+		return nil, nil, fmt.Errorf("several services are defined; choose one of %s", strings.Join(names, ", "))
+	case service == nil:
+		return nil, nil, fmt.Errorf("service '%s' not found (defined: %s)", opts.Service, strings.Join(names, ", "))
+	}
+
+	described := &ProtoService{FullName: file.qualify(service.name)}
+	c := &protoConverter{file: file, schemas: map[string]interface{}{}}
+	paths := map[string]interface{}{}
+	for _, method := range service.methods {
+		if method.clientStreaming || method.serverStreaming {
+			described.Streaming = append(described.Streaming, method.name)
+		}
+
+		op := map[string]interface{}{
+			"operationId": method.name,
+			"summary":     fmt.Sprintf("%s.%s", service.name, method.name),
+			"tags":        []interface{}{service.name},
+		}
+		path, httpMethod := "/"+method.name, "post"
+		mediaType := "application/grpc"
+		if opts.HTTPRules {
+			if method.httpPath == "" {
+				return nil, nil, fmt.Errorf("method %s has no google.api.http annotation; import it without HTTP rules", method.name)
+			}
+			if method.clientStreaming {
+				return nil, nil, fmt.Errorf("method %s streams requests, which HTTP rules cannot describe", method.name)
+			}
+			path, httpMethod, mediaType = method.httpPath, method.httpMethod, "application/json"
+			params, err := c.httpParameters(method)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(params) > 0 {
+				op["parameters"] = params
+			}
+			path = httpTemplateVariable.ReplaceAllString(path, "{$1}")
+		}
+
+		if body := c.requestBody(method, opts.HTTPRules); body != nil {
+			op["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  map[string]interface{}{mediaType: map[string]interface{}{"schema": body}},
+			}
+		}
+		op["responses"] = map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Successful response",
+				"content":     map[string]interface{}{mediaType: map[string]interface{}{"schema": c.typeSchema(method.output, method.scope)}},
+			},
+		}
+		if method.serverStreaming || method.clientStreaming {
+			op["description"] = "Streaming method: " + streamingDescription(method)
+		}
+
+		item, _ := paths[path].(map[string]interface{})
+		if item == nil {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+		if _, ok := item[httpMethod]; ok {
+			return nil, nil, fmt.Errorf("methods share the route %s %s", strings.ToUpper(httpMethod), path)
+		}
+		item[httpMethod] = op
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       service.name,
+			"version":     "1.0.0",
+			"description": fmt.Sprintf("gRPC service %s", described.FullName),
+		},
+		"paths": paths,
+	}
+	if len(c.schemas) > 0 {
+		doc["components"] = map[string]interface{}{"schemas": c.schemas}
+	}
+	return doc, described, nil
+}
+
+func streamingDescription(method *protoMethod) string {
+	switch {
+	case method.clientStreaming && method.serverStreaming:
+		return "requests and responses are streamed"
+	case method.clientStreaming:
+		return "requests are streamed"
+	}
+	return "responses are streamed"
+}
+
+// protoConverter builds component schemas for the messages a service uses
+type protoConverter struct {
+	file    *protoFile
+	schemas map[string]interface{}
+}
+
+// requestBody returns the schema of a method's request body, or nil when it has none
+func (c *protoConverter) requestBody(method *protoMethod, httpRules bool) map[string]interface{} {
+	if !httpRules || method.httpBody == "*" {
+		return c.typeSchema(method.input, method.scope)
+	}
+	if method.httpBody == "" {
+		return nil
+	}
+	message := c.file.resolveMessage(method.input, method.scope)
+	if message == nil {
+		return nil
+	}
+	for _, field := range message.fields {
+		if field.name == method.httpBody {
+			return c.fieldSchema(field, message.fullName)
+		}
+	}
+	return nil
+}
+
+// httpParameters returns the path parameters of a method's HTTP rule and, when the
+// body does not take the whole request, its other top-level fields as query parameters
+func (c *protoConverter) httpParameters(method *protoMethod) ([]interface{}, error) {
+	message := c.file.resolveMessage(method.input, method.scope)
+	bound := map[string]bool{}
+	var params []interface{}
+	for _, match := range httpTemplateVariable.FindAllStringSubmatch(method.httpPath, -1) {
+		name := match[1]
+		bound[strings.SplitN(name, ".", 2)[0]] = true
+		schema := map[string]interface{}{"type": "string"}
+		if message != nil && !strings.Contains(name, ".") {
+			for _, field := range message.fields {
+				if field.name == name {
+					schema = c.fieldSchema(field, message.fullName)
+				}
+			}
+		}
+		params = append(params, map[string]interface{}{"name": name, "in": "path", "required": true, "schema": schema})
+	}
+	if message == nil || method.httpBody == "*" {
+		return params, nil
+	}
+	for _, field := range message.fields {
+		if bound[field.name] || field.name == method.httpBody || field.mapKey != "" {
+			continue
+		}
+		schema := c.fieldSchema(field, message.fullName)
+		if ref, _ := schema["$ref"].(string); ref != "" {
+			// Message fields do not fit in a query string
+			continue
+		}
+		params = append(params, map[string]interface{}{"name": jsonName(field.name), "in": "query", "schema": schema})
+	}
+	return params, nil
+}
+
+// typeSchema returns the schema of a named type used in scope
+func (c *protoConverter) typeSchema(typeName, scope string) map[string]interface{} {
+	if schema, ok := protoScalars[typeName]; ok {
+		return copySchema(schema)
+	}
+	if schema, ok := protoWellKnown[strings.TrimPrefix(typeName, ".")]; ok {
+		return copySchema(schema)
+	}
+	fullName := c.file.resolve(typeName, scope)
+	if values, ok := c.file.enums[fullName]; ok {
+		items := make([]interface{}, len(values))
+		for i, value := range values {
+			items[i] = value
+		}
+		return map[string]interface{}{"type": "string", "enum": items}
+	}
+	message, ok := c.file.messages[fullName]
+	if !ok {
+		return map[string]interface{}{"type": "object", "description": "Imported type " + strings.TrimPrefix(typeName, ".")}
+	}
+
+	name := c.file.schemaName(fullName)
+	if _, ok := c.schemas[name]; !ok {
+		properties := map[string]interface{}{}
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		// Placed before the fields are converted so recursive messages end
+		c.schemas[name] = schema
+		for _, field := range message.fields {
+			properties[jsonName(field.name)] = c.fieldSchema(field, message.fullName)
+		}
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func (c *protoConverter) fieldSchema(field *protoField, scope string) map[string]interface{} {
+	schema := c.typeSchema(field.typeName, scope)
+	if field.mapKey != "" {
+		return map[string]interface{}{"type": "object", "additionalProperties": schema}
+	}
+	if field.repeated {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+func copySchema(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		out[key] = value
+	}
+	return out
+}
+
+// jsonName is the proto3 JSON name of a field: lowerCamelCase
+func jsonName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// qualify prefixes name with the file's package
+func (f *protoFile) qualify(name string) string {
+	if f.pkg == "" {
+		return name
+	}
+	return f.pkg + "." + name
+}
+
+// schemaName is the component schema name of a message: its name within the package
+func (f *protoFile) schemaName(fullName string) string {
+	if f.pkg == "" {
+		return fullName
+	}
+	return strings.TrimPrefix(fullName, f.pkg+".")
+}
+
+// resolve finds the full name of a type referenced in scope, searching from the
+// innermost scope outwards like protoc. Unknown types keep their name.
+func (f *protoFile) resolve(typeName, scope string) string {
+	if strings.HasPrefix(typeName, ".") {
+		return typeName[1:]
+	}
+	for {
+		candidate := typeName
+		if scope != "" {
+			candidate = scope + "." + typeName
+		}
+		if _, ok := f.messages[candidate]; ok {
+			return candidate
+		}
+		if _, ok := f.enums[candidate]; ok {
+			return candidate
+		}
+		if scope == "" {
+			return typeName
+		}
+		if i := strings.LastIndex(scope, "."); i >= 0 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+func (f *protoFile) resolveMessage(typeName, scope string) *protoMessage {
+	return f.messages[f.resolve(typeName, scope)]
+}
+
+// protoParser reads the declarations of a .proto file that describe its services and
+// messages; options other than google.api.http are skipped
+type protoParser struct {
+	tokens []string
+	pos    int
+	file   *protoFile
+}
+
+func parseProto(source string) (*protoFile, error) {
+	tokens, err := tokenizeProto(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &protoParser{tokens: tokens, file: &protoFile{messages: map[string]*protoMessage{}, enums: map[string][]string{}}}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	return p.file, nil
+}
+
+func (p *protoParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *protoParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *protoParser) expect(want string) error {
+	if got := p.next(); got != want {
+		if got == "" {
+			got = "end of file"
+		}
+		return fmt.Errorf("expected '%s' but found '%s'", want, got)
+	}
+	return nil
+}
+
+// skipStatement skips to the end of the current statement, over any nested braces
+func (p *protoParser) skipStatement() error {
+	depth := 0
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case ";":
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unexpected end of file")
+}
+
+func (p *protoParser) parseFile() error {
+	for p.pos < len(p.tokens) {
+		switch token := p.next(); token {
+		case "syntax", "edition":
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			if syntax := strings.Trim(p.next(), `"`); syntax == "proto2" {
+				return fmt.Errorf("proto2 files are not supported; use proto3")
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "package":
+			p.file.pkg = p.next()
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(p.file.pkg); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(p.file.pkg); err != nil {
+				return err
+			}
+		case "service":
+			if err := p.parseService(); err != nil {
+				return err
+			}
+		case ";":
+		default:
+			// import, option and extend
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *protoParser) parseMessage(scope string) error {
+	fullName := qualifyScope(scope, p.next())
+	message := &protoMessage{fullName: fullName}
+	p.file.messages[fullName] = message
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseMessageBody(message)
+}
+
+// parseMessageBody reads fields up to the closing brace; oneof fields belong to the message
+func (p *protoParser) parseMessageBody(message *protoMessage) error {
+	for {
+		switch token := p.peek(); token {
+		case "":
+			return fmt.Errorf("message %s is not closed", message.fullName)
+		case "}":
+			p.next()
+			return nil
+		case ";":
+			p.next()
+		case "message":
+			p.next()
+			if err := p.parseMessage(message.fullName); err != nil {
+				return err
+			}
+		case "enum":
+			p.next()
+			if err := p.parseEnum(message.fullName); err != nil {
+				return err
+			}
+		case "oneof":
+			p.next()
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			if err := p.parseMessageBody(message); err != nil {
+				return err
+			}
+		case "option", "reserved", "extensions", "extend":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			field, err := p.parseField()
+			if err != nil {
+				return fmt.Errorf("message %s: %w", message.fullName, err)
+			}
+			message.fields = append(message.fields, field)
+		}
+	}
+}
+
+func (p *protoParser) parseField() (*protoField, error) {
+	field := &protoField{}
+	switch p.peek() {
+	case "repeated":
+		field.repeated = true
+		p.next()
+	case "optional", "required":
+		p.next()
+	}
+	if p.peek() == "map" {
+		p.next()
+		if err := p.expect("<"); err != nil {
+			return nil, err
+		}
+		field.mapKey = p.next()
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		field.typeName = p.next()
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+	} else {
+		field.typeName = p.next()
+	}
+	field.name = p.next()
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	// The field number and any [options]
+	if err := p.skipStatement(); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (p *protoParser) parseEnum(scope string) error {
+	fullName := qualifyScope(scope, p.next())
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	var values []string
+	for {
+		switch token := p.next(); token {
+		case "":
+			return fmt.Errorf("enum %s is not closed", fullName)
+		case "}":
+			p.file.enums[fullName] = values
+			return nil
+		case ";":
+		case "option", "reserved":
+			p.pos--
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			values = append(values, token)
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoParser) parseService() error {
+	service := &protoService{name: p.next()}
+	p.file.services = append(p.file.services, service)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		switch token := p.next(); token {
+		case "":
+			return fmt.Errorf("service %s is not closed", service.name)
+		case "}":
+			return nil
+		case ";":
+		case "rpc":
+			method, err := p.parseMethod(p.file.qualify(service.name))
+			if err != nil {
+				return fmt.Errorf("service %s: %w", service.name, err)
+			}
+			service.methods = append(service.methods, method)
+		default:
+			p.pos--
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoParser) parseMethod(scope string) (*protoMethod, error) {
+	method := &protoMethod{name: p.next(), scope: scope}
+	readType := func(streaming *bool) (string, error) {
+		if err := p.expect("("); err != nil {
+			return "", err
+		}
+		if p.peek() == "stream" {
+			*streaming = true
+			p.next()
+		}
+		typeName := p.next()
+		return typeName, p.expect(")")
+	}
+	var err error
+	if method.input, err = readType(&method.clientStreaming); err != nil {
+		return nil, fmt.Errorf("rpc %s: %w", method.name, err)
+	}
+	if err := p.expect("returns"); err != nil {
+		return nil, fmt.Errorf("rpc %s: %w", method.name, err)
+	}
+	if method.output, err = readType(&method.serverStreaming); err != nil {
+		return nil, fmt.Errorf("rpc %s: %w", method.name, err)
+	}
+
+	switch p.next() {
+	case ";":
+		return method, nil
+	case "{":
+	default:
+		return nil, fmt.Errorf("rpc %s: expected ';' or '{'", method.name)
+	}
+	for {
+		switch token := p.next(); token {
+		case "":
+			return nil, fmt.Errorf("rpc %s is not closed", method.name)
+		case "}":
+			return method, nil
+		case ";":
+		case "option":
+			if p.peek() == "(" && p.pos+2 < len(p.tokens) && p.tokens[p.pos+1] == "google.api.http" {
+				if err := p.parseHTTPRule(method); err != nil {
+					return nil, fmt.Errorf("rpc %s: %w", method.name, err)
+				}
+				continue
+			}
+			p.pos--
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		default:
+			p.pos--
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// parseHTTPRule reads `option (google.api.http) = { get: "/v1/..." body: "*" };`.
+// Additional bindings are skipped; the first binding describes the method.
+func (p *protoParser) parseHTTPRule(method *protoMethod) error {
+	for _, want := range []string{"(", "google.api.http", ")", "=", "{"} {
+		if err := p.expect(want); err != nil {
+			return err
+		}
+	}
+	for {
+		key := p.next()
+		switch key {
+		case "":
+			return fmt.Errorf("google.api.http option is not closed")
+		case "}":
+			if p.peek() == ";" {
+				p.next()
+			}
+			if method.httpPath == "" {
+				return fmt.Errorf("google.api.http option has no path")
+			}
+			return nil
+		case ",", ";":
+			continue
+		}
+		if p.peek() == ":" {
+			p.next()
+		}
+		if p.peek() == "{" {
+			// additional_bindings and custom { kind: ... path: ... }
+			if key == "custom" {
+				if err := p.parseCustomHTTPRule(method); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := p.skipNested(); err != nil {
+				return err
+			}
+			continue
+		}
+		value := strings.Trim(p.next(), `"`)
+		switch key {
+		case "get", "put", "post", "delete", "patch":
+			method.httpMethod, method.httpPath = key, value
+		case "body":
+			method.httpBody = value
+		}
+	}
+}
+
+// parseCustomHTTPRule reads `custom: { kind: "HEAD" path: "/v1/..." }`
+func (p *protoParser) parseCustomHTTPRule(method *protoMethod) error {
+	p.next()
+	for {
+		key := p.next()
+		switch key {
+		case "":
+			return fmt.Errorf("google.api.http custom rule is not closed")
+		case "}":
+			return nil
+		case ",", ";", ":":
+			continue
+		}
+		if p.peek() == ":" {
+			p.next()
+		}
+		value := strings.Trim(p.next(), `"`)
+		switch key {
+		case "kind":
+			method.httpMethod = strings.ToLower(value)
+		case "path":
+			method.httpPath = value
+		}
+	}
+}
+
+// skipNested skips a braced value starting at the next token
+func (p *protoParser) skipNested() error {
+	p.next()
+	depth := 0
+	for p.pos < len(p.tokens) {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		}
+	}
+	return fmt.Errorf("unexpected end of file")
+}
+
+func qualifyScope(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// tokenizeProto splits proto source into identifiers (dotted names included), numbers,
+// quoted strings and single-character symbols, dropping comments
+func tokenizeProto(source string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(source); {
+		ch := source[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case strings.HasPrefix(source[i:], "//"):
+			end := strings.IndexByte(source[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case ch == '"' || ch == '\'':
+			j := i + 1
+			for j < len(source) && source[j] != ch {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(source) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, `"`+source[i+1:j]+`"`)
+			i = j + 1
+		case isProtoIdent(ch) || ch == '.':
+			j := i
+			for j < len(source) && (isProtoIdent(source[j]) || source[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, source[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(ch))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isProtoIdent(ch byte) bool {
+	return ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
@@ -0,0 +1,172 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProto = `// Orders service
+syntax = "proto3";
+
+package shop.v1;
+
+import "google/api/annotations.proto";
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/shop/v1;shopv1";
+
+/* An order and its lines */
+message Order {
+  string order_id = 1;
+  int64 total_cents = 2 [json_name = "totalCents"];
+  repeated Line lines = 3;
+  Status status = 4;
+  google.protobuf.Timestamp created_at = 5;
+  map<string, string> labels = 6;
+  oneof payment {
+    string card_token = 7;
+    bytes voucher = 8;
+  }
+
+  message Line {
+    string sku = 1;
+    uint32 quantity = 2;
+  }
+
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+    STATUS_OPEN = 1;
+    STATUS_SHIPPED = 2;
+  }
+  reserved 9, 10;
+}
+
+message GetOrderRequest { string order_id = 1; }
+message ListOrdersRequest {
+  int32 page_size = 1;
+  string page_token = 2;
+  Order.Status status = 3;
+}
+message ListOrdersResponse { repeated Order orders = 1; }
+message UpdateOrderRequest {
+  string order_id = 1;
+  Order order = 2;
+}
+
+service Orders {
+  option (google.api.default_host) = "orders.example.com";
+
+  rpc GetOrder(GetOrderRequest) returns (Order) {
+    option (google.api.http) = { get: "/v1/orders/{order_id}" };
+  }
+  rpc ListOrders(ListOrdersRequest) returns (ListOrdersResponse) {
+    option (google.api.http) = {
+      get: "/v1/orders"
+      additional_bindings { get: "/v1/shops/{shop}/orders" }
+    };
+  }
+  rpc UpdateOrder(UpdateOrderRequest) returns (Order) {
+    option (google.api.http) = { patch: "/v1/orders/{order_id=*}" body: "order" };
+  }
+  rpc WatchOrders(ListOrdersRequest) returns (stream Order) {
+    option (google.api.http) = { post: "/v1/orders:watch" body: "*" };
+  }
+}
+
+service Admin {
+  rpc Purge(GetOrderRequest) returns (Order);
+}
+`
+
+func TestFromProto(t *testing.T) {
+	doc, service, err := FromProto([]byte(testProto), ProtoOptions{Service: "Orders"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop.v1.Orders", service.FullName)
+	assert.Equal(t, "/shop.v1.Orders/", service.GRPCListenPath())
+	assert.Equal(t, []string{"WatchOrders"}, service.Streaming)
+	assert.Equal(t, "Orders", GetAPIName(doc))
+
+	// Every method is a POST on its gRPC route
+	paths := doc["paths"].(map[string]interface{})
+	assert.Len(t, paths, 4)
+	get := paths["/GetOrder"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "GetOrder", get["operationId"])
+	assert.Equal(t, []interface{}{"Orders"}, get["tags"])
+	body := get["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/grpc"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/GetOrderRequest"}, body["schema"])
+	assert.Contains(t, paths["/WatchOrders"].(map[string]interface{})["post"].(map[string]interface{})["description"], "responses are streamed")
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	order := schemas["Order"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string"}, order["orderId"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "int64"}, order["totalCents"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Order.Line"}}, order["lines"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"STATUS_UNSPECIFIED", "STATUS_OPEN", "STATUS_SHIPPED"}}, order["status"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, order["createdAt"])
+	assert.Equal(t, map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}}, order["labels"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "byte"}, order["voucher"])
+	assert.Contains(t, order, "cardToken")
+	assert.Contains(t, schemas, "Order.Line")
+	// Only the missing servers are reported: a .proto file has none, so the upstream is given on import
+	diags := Lint(doc)
+	require.Len(t, diags, 1)
+	assert.Equal(t, []string{"servers"}, diags[0].Path)
+}
+
+func TestFromProto_HTTPRules(t *testing.T) {
+	doc, _, err := FromProto([]byte(testProto), ProtoOptions{Service: "shop.v1.Orders", HTTPRules: true})
+	require.NoError(t, err)
+
+	paths := doc["paths"].(map[string]interface{})
+	get := paths["/v1/orders/{order_id}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "order_id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}},
+	}, get["parameters"])
+	assert.Nil(t, get["requestBody"])
+	response := get["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"].(map[string]interface{})
+	assert.Contains(t, response, "application/json")
+
+	// The fields a GET does not bind to the path are query parameters
+	list := paths["/v1/orders"].(map[string]interface{})["get"].(map[string]interface{})
+	var names []string
+	for _, param := range list["parameters"].([]interface{}) {
+		names = append(names, param.(map[string]interface{})["name"].(string))
+	}
+	assert.Equal(t, []string{"pageSize", "pageToken", "status"}, names)
+
+	// {order_id=*} is a plain path parameter, and the body is the named field
+	update := paths["/v1/orders/{order_id}"].(map[string]interface{})["patch"].(map[string]interface{})
+	body := update["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/Order"}, body["schema"])
+
+	watch := paths["/v1/orders:watch"].(map[string]interface{})["post"].(map[string]interface{})
+	body = watch["requestBody"].(map[string]interface{})["content"].(map[string]interface{})["application/json"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/ListOrdersRequest"}, body["schema"])
+	// As without HTTP rules, only the missing servers are reported
+	diags := Lint(doc)
+	require.Len(t, diags, 1)
+	assert.Equal(t, []string{"servers"}, diags[0].Path)
+}
+
+func TestFromProto_Rejects(t *testing.T) {
+	_, _, err := FromProto([]byte(testProto), ProtoOptions{})
+	assert.ErrorContains(t, err, "several services are defined; choose one of Orders, Admin")
+
+	_, _, err = FromProto([]byte(testProto), ProtoOptions{Service: "Billing"})
+	assert.ErrorContains(t, err, "service 'Billing' not found")
+
+	_, _, err = FromProto([]byte(testProto), ProtoOptions{Service: "Admin", HTTPRules: true})
+	assert.ErrorContains(t, err, "method Purge has no google.api.http annotation")
+
+	_, _, err = FromProto([]byte(`syntax = "proto2"; message A {}`), ProtoOptions{})
+	assert.ErrorContains(t, err, "proto2 files are not supported")
+
+	_, _, err = FromProto([]byte(`syntax = "proto3"; message A { string a = 1; }`), ProtoOptions{})
+	assert.ErrorContains(t, err, "no service is defined")
+
+	_, _, err = FromProto([]byte(`service S { rpc M(A) returns (B) { `), ProtoOptions{})
+	assert.ErrorContains(t, err, "rpc M is not closed")
+}
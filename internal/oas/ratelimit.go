@@ -0,0 +1,155 @@
+package oas
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitShorthandKey is the per-operation annotation expanded by ExpandRateLimits
+const RateLimitShorthandKey = "x-tyk-ratelimit"
+
+// ExpandRateLimits replaces every `x-tyk-ratelimit: {rate: 10, per: 60}` operation
+// annotation with the equivalent x-tyk-api-gateway.middleware.operations.<operationId>.rateLimit
+// and removes the annotation. per is in seconds, or a duration such as "1m". Operations
+// without an operationId are given one the way the Dashboard names them. It returns the
+// number of operations expanded; every invalid annotation is reported in one error and
+// nothing is changed.
+func ExpandRateLimits(oasDoc map[string]interface{}) (int, error) {
+	type expansion struct {
+		op   map[string]interface{}
+		id   string
+		rate int64
+		per  time.Duration
+	}
+	var expansions []expansion
+	var problems []string
+
+	paths, _ := oasDoc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range httpMethods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			raw, ok := op[RateLimitShorthandKey]
+			if !ok {
+				continue
+			}
+			location := fmt.Sprintf("paths.%s.%s.%s", path, method, RateLimitShorthandKey)
+			shorthand, ok := raw.(map[string]interface{})
+			if !ok {
+				problems = append(problems, location+": expected an object with rate and per")
+				continue
+			}
+			rate, rateErr := positiveInt(shorthand["rate"])
+			if rateErr != nil {
+				problems = append(problems, fmt.Sprintf("%s.rate: %v", location, rateErr))
+			}
+			per, perErr := ratePeriod(shorthand["per"])
+			if perErr != nil {
+				problems = append(problems, fmt.Sprintf("%s.per: %v", location, perErr))
+			}
+			if rateErr != nil || perErr != nil {
+				continue
+			}
+			expansions = append(expansions, expansion{op: op, id: operationID(path, method, op), rate: rate, per: per})
+		}
+	}
+	if len(problems) > 0 {
+		return 0, fmt.Errorf("invalid %s annotations:\n  %s", RateLimitShorthandKey, strings.Join(problems, "\n  "))
+	}
+	if len(expansions) == 0 {
+		return 0, nil
+	}
+
+	middleware := tykSection(oasDoc, "middleware", true)
+	operations, ok := middleware["operations"].(map[string]interface{})
+	if !ok {
+		operations = map[string]interface{}{}
+		middleware["operations"] = operations
+	}
+	for _, e := range expansions {
+		e.op["operationId"] = e.id
+		delete(e.op, RateLimitShorthandKey)
+		settings, ok := operations[e.id].(map[string]interface{})
+		if !ok {
+			settings = map[string]interface{}{}
+			operations[e.id] = settings
+		}
+		settings["rateLimit"] = map[string]interface{}{
+			"enabled": true,
+			"rate":    e.rate,
+			"per":     formatPeriod(e.per),
+		}
+	}
+	return len(expansions), nil
+}
+
+// RateLimitSettings checks a rate and period given as in the x-tyk-ratelimit annotation
+// and returns them as Tyk rateLimit settings
+func RateLimitSettings(rate, per interface{}) (map[string]interface{}, error) {
+	r, err := positiveInt(rate)
+	if err != nil {
+		return nil, fmt.Errorf("rate %v", err)
+	}
+	p, err := ratePeriod(per)
+	if err != nil {
+		return nil, fmt.Errorf("per %v", err)
+	}
+	return map[string]interface{}{"rate": r, "per": formatPeriod(p)}, nil
+}
+
+// positiveInt reads a whole number greater than zero from a decoded JSON or YAML value
+func positiveInt(value interface{}) (int64, error) {
+	var f float64
+	switch v := value.(type) {
+	case int:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case uint64:
+		f = float64(v)
+	case float64:
+		f = v
+	case nil:
+		return 0, fmt.Errorf("missing")
+	default:
+		return 0, fmt.Errorf("must be a number, got %v", value)
+	}
+	if f <= 0 || f != math.Trunc(f) {
+		return 0, fmt.Errorf("must be a whole number greater than zero, got %v", value)
+	}
+	return int64(f), nil
+}
+
+// ratePeriod reads a rate limit period given in seconds or as a duration string
+func ratePeriod(value interface{}) (time.Duration, error) {
+	if s, ok := value.(string); ok {
+		if seconds, err := strconv.Atoi(s); err == nil {
+			value = seconds
+		} else {
+			d, err := time.ParseDuration(s)
+			if err != nil || d < time.Second {
+				return 0, fmt.Errorf("must be seconds or a duration of at least 1s, got %q", s)
+			}
+			return d, nil
+		}
+	}
+	seconds, err := positiveInt(value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// formatPeriod renders a period the way Tyk examples write it, e.g. "60s"
+func formatPeriod(d time.Duration) string {
+	if d%time.Second == 0 {
+		return fmt.Sprintf("%ds", int64(d/time.Second))
+	}
+	return d.String()
+}
@@ -0,0 +1,49 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandRateLimits(t *testing.T) {
+	doc := validTykDoc()
+	paths := doc["paths"].(map[string]interface{})
+	paths["/users"].(map[string]interface{})["get"].(map[string]interface{})[RateLimitShorthandKey] = map[string]interface{}{"rate": 10, "per": 60}
+	paths["/users/{id}"] = map[string]interface{}{
+		"delete": map[string]interface{}{RateLimitShorthandKey: map[string]interface{}{"rate": 1.0, "per": "1m"}},
+	}
+
+	n, err := ExpandRateLimits(doc)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	operations := tykSection(doc, "middleware", false)["operations"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"enabled": true, "rate": int64(10), "per": "60s"},
+		operations["listUsers"].(map[string]interface{})["rateLimit"])
+	assert.Equal(t, map[string]interface{}{"enabled": true, "rate": int64(1), "per": "60s"},
+		operations["users/{id}DELETE"].(map[string]interface{})["rateLimit"])
+
+	deleteOp := paths["/users/{id}"].(map[string]interface{})["delete"].(map[string]interface{})
+	assert.Equal(t, "users/{id}DELETE", deleteOp["operationId"])
+	assert.NotContains(t, deleteOp, RateLimitShorthandKey)
+
+	// Already expanded documents are left alone
+	n, err = ExpandRateLimits(doc)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestExpandRateLimits_Invalid(t *testing.T) {
+	doc := validTykDoc()
+	op := doc["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"].(map[string]interface{})
+	op[RateLimitShorthandKey] = map[string]interface{}{"rate": 2.5, "per": "soon"}
+
+	_, err := ExpandRateLimits(doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "paths./users.get.x-tyk-ratelimit.rate")
+	assert.Contains(t, err.Error(), "paths./users.get.x-tyk-ratelimit.per")
+	assert.Contains(t, op, RateLimitShorthandKey)
+	assert.Nil(t, tykSection(doc, "middleware", false))
+}
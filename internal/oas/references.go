@@ -0,0 +1,91 @@
+package oas
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Kinds of reference from an API definition to another resource
+const (
+	RefCertificate = "certificate"
+	RefWebhook     = "webhook"
+)
+
+// Reference is a value in the Tyk extension that points at another resource, such as
+// a certificate ID in the certificate store or a webhook URL
+type Reference struct {
+	Kind  string `json:"kind"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// References lists the certificates and webhook URLs an API definition depends on
+func References(oasDoc map[string]interface{}) []Reference {
+	var refs []Reference
+	add := func(kind, value, path string, args ...interface{}) {
+		if value != "" {
+			refs = append(refs, Reference{Kind: kind, Path: TykExtensionKey + "." + fmt.Sprintf(path, args...), Value: value})
+		}
+	}
+
+	server := tykSection(oasDoc, "server", false)
+	if domain, ok := server["customDomain"].(map[string]interface{}); ok {
+		for i, cert := range stringItems(domain["certificates"]) {
+			add(RefCertificate, cert, "server.customDomain.certificates[%d]", i)
+		}
+	}
+	if clientCerts, ok := server["clientCertificates"].(map[string]interface{}); ok {
+		for i, cert := range stringItems(clientCerts["allowlist"]) {
+			add(RefCertificate, cert, "server.clientCertificates.allowlist[%d]", i)
+		}
+	}
+	handlers, _ := server["eventHandlers"].([]interface{})
+	for i, item := range handlers {
+		handler, _ := item.(map[string]interface{})
+		if handlerType, _ := handler["type"].(string); handlerType == "webhook" {
+			target, _ := handler["url"].(string)
+			add(RefWebhook, target, "server.eventHandlers[%d].url", i)
+		}
+	}
+
+	upstream := tykSection(oasDoc, "upstream", false)
+	if mtls, ok := upstream["mutualTLS"].(map[string]interface{}); ok {
+		mappings, _ := mtls["domainToCertificateMapping"].([]interface{})
+		for i, item := range mappings {
+			mapping, _ := item.(map[string]interface{})
+			cert, _ := mapping["certificate"].(string)
+			add(RefCertificate, cert, "upstream.mutualTLS.domainToCertificateMapping[%d].certificate", i)
+		}
+	}
+	// certificatePinning publicKeys are key fingerprints, not certificate IDs
+
+	return refs
+}
+
+// ValidateWebhookURL checks that a webhook target is an absolute http(s) URL
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %v", raw, err)
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("URL %q must use http or https", raw)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("URL %q has no host", raw)
+	}
+	return nil
+}
+
+// stringItems returns the string elements of a JSON array
+func stringItems(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var values []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
@@ -0,0 +1,44 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferences(t *testing.T) {
+	assert.Empty(t, References(validTykDoc()))
+
+	doc := validTykDoc()
+	server := tykSection(doc, "server", false)
+	server["customDomain"] = map[string]interface{}{"enabled": true, "name": "api.example.com", "certificates": []interface{}{"cert-domain"}}
+	server["clientCertificates"] = map[string]interface{}{"enabled": true, "allowlist": []interface{}{"cert-client"}}
+	server["eventHandlers"] = []interface{}{
+		map[string]interface{}{"type": "webhook", "trigger": "AuthFailure", "url": "https://hooks.example.com/tyk"},
+		map[string]interface{}{"type": "custom", "trigger": "QuotaExceeded"},
+	}
+	upstream := tykSection(doc, "upstream", false)
+	upstream["mutualTLS"] = map[string]interface{}{"enabled": true, "domainToCertificateMapping": []interface{}{
+		map[string]interface{}{"domain": "users.internal", "certificate": "cert-upstream"},
+	}}
+	// Pinned public keys are fingerprints, not certificates in the store
+	upstream["certificatePinning"] = map[string]interface{}{"enabled": true, "domainToPublicKeysMapping": []interface{}{
+		map[string]interface{}{"domain": "users.internal", "publicKeys": []interface{}{"sha256/AAAA"}},
+	}}
+
+	assert.Equal(t, []Reference{
+		{Kind: RefCertificate, Path: "x-tyk-api-gateway.server.customDomain.certificates[0]", Value: "cert-domain"},
+		{Kind: RefCertificate, Path: "x-tyk-api-gateway.server.clientCertificates.allowlist[0]", Value: "cert-client"},
+		{Kind: RefWebhook, Path: "x-tyk-api-gateway.server.eventHandlers[0].url", Value: "https://hooks.example.com/tyk"},
+		{Kind: RefCertificate, Path: "x-tyk-api-gateway.upstream.mutualTLS.domainToCertificateMapping[0].certificate", Value: "cert-upstream"},
+	}, References(doc))
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	assert.NoError(t, ValidateWebhookURL("https://hooks.example.com/tyk"))
+	assert.NoError(t, ValidateWebhookURL("http://10.0.0.5:8080/events"))
+	assert.Error(t, ValidateWebhookURL("hooks.example.com/tyk"))
+	assert.Error(t, ValidateWebhookURL("ftp://hooks.example.com"))
+	assert.Error(t, ValidateWebhookURL("https://"))
+	assert.Error(t, ValidateWebhookURL("http://%zz"))
+}
@@ -0,0 +1,97 @@
+package oas
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Swap replaces From with To
+type Swap struct {
+	From string
+	To   string
+}
+
+// RewriteRules reshape an API for the environment it is promoted into
+type RewriteRules struct {
+	// ListenPathPrefixes swap a leading part of the listen path: /staging to / turns
+	// "/staging/users/" into "/users/". The first matching prefix wins.
+	ListenPathPrefixes []Swap
+	// Domains swap the custom domain name
+	Domains []Swap
+	// UpstreamHosts swap the host of the upstream URL; a From without a port matches
+	// any port and the port is kept unless To names one
+	UpstreamHosts []Swap
+}
+
+// Empty reports whether there are no rules
+func (r RewriteRules) Empty() bool {
+	return len(r.ListenPathPrefixes) == 0 && len(r.Domains) == 0 && len(r.UpstreamHosts) == 0
+}
+
+// Rewrite applies rules to a document carrying Tyk extensions and returns the fields
+// it changed
+func Rewrite(oasDoc map[string]interface{}, rules RewriteRules) ([]Change, error) {
+	if !HasTykExtensions(oasDoc) {
+		return nil, fmt.Errorf("cannot rewrite API without %s extensions", TykExtensionKey)
+	}
+	var changes []Change
+
+	listenPath := GetListenPath(oasDoc)
+	for _, swap := range rules.ListenPathPrefixes {
+		if listenPath == "" || !UnderListenPath(listenPath, swap.From) {
+			continue
+		}
+		rest := strings.TrimPrefix(strings.TrimPrefix(listenPath, "/"), strings.Trim(swap.From, "/"))
+		rewritten := "/" + strings.Trim(swap.To, "/") + "/" + strings.TrimPrefix(rest, "/")
+		rewritten = "/" + strings.TrimLeft(rewritten, "/")
+		if rewritten != listenPath {
+			SetListenPath(oasDoc, rewritten)
+			changes = append(changes, Change{Type: ChangeModified, Path: []string{TykExtensionKey, "server", "listenPath", "value"}, Old: listenPath, New: rewritten})
+		}
+		break
+	}
+
+	if domain, _ := tykSection(oasDoc, "server", false)["customDomain"].(map[string]interface{}); domain != nil {
+		name, _ := domain["name"].(string)
+		for _, swap := range rules.Domains {
+			if name != "" && strings.EqualFold(name, swap.From) {
+				domain["name"] = swap.To
+				changes = append(changes, Change{Type: ChangeModified, Path: []string{TykExtensionKey, "server", "customDomain", "name"}, Old: name, New: swap.To})
+				break
+			}
+		}
+	}
+
+	if upstream := tykSection(oasDoc, "upstream", false); upstream != nil {
+		raw, _ := upstream["url"].(string)
+		if u, err := url.Parse(raw); err == nil && u.Host != "" {
+			for _, swap := range rules.UpstreamHosts {
+				host, ok := swapHost(u, swap)
+				if !ok {
+					continue
+				}
+				u.Host = host
+				upstream["url"] = u.String()
+				changes = append(changes, Change{Type: ChangeModified, Path: []string{TykExtensionKey, "upstream", "url"}, Old: raw, New: u.String()})
+				break
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// swapHost returns the host u has after swap, and whether swap matches it
+func swapHost(u *url.URL, swap Swap) (string, bool) {
+	if strings.Contains(swap.From, ":") {
+		return swap.To, strings.EqualFold(u.Host, swap.From)
+	}
+	if !strings.EqualFold(u.Hostname(), swap.From) {
+		return "", false
+	}
+	if port := u.Port(); port != "" && !strings.Contains(swap.To, ":") {
+		return swap.To + ":" + port, true
+	}
+	return swap.To, true
+}
@@ -0,0 +1,47 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewrite(t *testing.T) {
+	doc := validTykDoc()
+	SetListenPath(doc, "/staging/users/")
+	doc[TykExtensionKey].(map[string]interface{})["server"].(map[string]interface{})["customDomain"] = map[string]interface{}{"enabled": true, "name": "stg.example.com"}
+	doc[TykExtensionKey].(map[string]interface{})["upstream"] = map[string]interface{}{"url": "http://users.stg.internal:8080/v1"}
+
+	changes, err := Rewrite(doc, RewriteRules{
+		ListenPathPrefixes: []Swap{{From: "/qa", To: "/"}, {From: "/staging", To: "/"}},
+		Domains:            []Swap{{From: "STG.example.com", To: "api.example.com"}},
+		UpstreamHosts:      []Swap{{From: "users.stg.internal", To: "users.internal"}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, "/users/", GetListenPath(doc))
+	assert.Equal(t, "api.example.com", CustomDomain(doc))
+	// The port is kept when the rule names none
+	assert.Equal(t, "http://users.internal:8080/v1", doc[TykExtensionKey].(map[string]interface{})["upstream"].(map[string]interface{})["url"])
+
+	// Rewriting again changes nothing
+	changes, err = Rewrite(doc, RewriteRules{ListenPathPrefixes: []Swap{{From: "/staging", To: "/"}}})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestRewrite_ListenPathIntoPrefix(t *testing.T) {
+	doc := validTykDoc()
+	changes, err := Rewrite(doc, RewriteRules{
+		ListenPathPrefixes: []Swap{{From: "/", To: "/eu"}},
+		UpstreamHosts:      []Swap{{From: "users.internal:443", To: "users.eu.internal"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/eu/users/", GetListenPath(doc))
+	// A host with a port only matches that port
+	assert.Len(t, changes, 1)
+
+	_, err = Rewrite(map[string]interface{}{"openapi": "3.0.3"}, RewriteRules{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package oas
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// ScaffoldOptions describe a new API document
+type ScaffoldOptions struct {
+	Title       string
+	Version     string
+	Description string
+	// UpstreamURL is the service the API proxies to, listed as its server
+	UpstreamURL string
+	// ListenPath defaults to one derived from the title
+	ListenPath string
+	// Resource, when set, adds list, create, get, update and delete operations for it,
+	// e.g. "order" or "line-item"
+	Resource string
+	// Auth is one of ScaffoldAuthTypes
+	Auth string
+}
+
+// Scaffold returns a new OAS 3 document with Tyk extensions that passes Validate and
+// Lint, for authors to fill in rather than starting from a blank file
+func Scaffold(opts ScaffoldOptions) (map[string]interface{}, error) {
+	if strings.TrimSpace(opts.Title) == "" {
+		return nil, fmt.Errorf("a title is required")
+	}
+	if u, err := url.Parse(opts.UpstreamURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("upstream URL must be an absolute http(s) URL (got '%s')", opts.UpstreamURL)
+	}
+	if opts.ListenPath != "" && !strings.HasPrefix(opts.ListenPath, "/") {
+		return nil, fmt.Errorf("listen path must start with '/' (got '%s')", opts.ListenPath)
+	}
+	version := opts.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+
+	info := map[string]interface{}{"title": opts.Title, "version": version}
+	if opts.Description != "" {
+		info["description"] = opts.Description
+	}
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    info,
+		"servers": []interface{}{map[string]interface{}{"url": opts.UpstreamURL}},
+		"paths":   map[string]interface{}{},
+	}
+	if opts.Resource != "" {
+		if err := addResource(doc, opts.Resource); err != nil {
+			return nil, err
+		}
+	}
+
+	return AddTykExtensionsWithOptions(doc, ExtensionOptions{ListenPath: opts.ListenPath, Auth: opts.Auth})
+}
+
+// addResource adds the CRUD operations of a resource and the schema they exchange
+func addResource(doc map[string]interface{}, resource string) error {
+	words := resourceWords(resource)
+	if len(words) == 0 {
+		return fmt.Errorf("resource '%s' must contain letters or digits", resource)
+	}
+	singular := camel(words, true)
+	plural := camel(append(words[:len(words)-1:len(words)-1], pluralize(words[len(words)-1])), true)
+	collection := "/" + strings.Join(append(words[:len(words)-1:len(words)-1], pluralize(words[len(words)-1])), "-")
+	idParam := camel(words, false) + "Id"
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + singular}
+	label := strings.Join(words, " ")
+
+	body := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"required":    true,
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": ref}},
+		}
+	}
+	response := func(description string, schema interface{}) map[string]interface{} {
+		r := map[string]interface{}{"description": description}
+		if schema != nil {
+			r["content"] = map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+		}
+		return r
+	}
+	notFound := response(fmt.Sprintf("No %s has this ID", label), nil)
+
+	paths := doc["paths"].(map[string]interface{})
+	paths[collection] = map[string]interface{}{
+		"get": map[string]interface{}{
+			"operationId": "list" + plural,
+			"summary":     "List " + pluralize(label),
+			"responses": map[string]interface{}{
+				"200": response("The "+pluralize(label), map[string]interface{}{"type": "array", "items": ref}),
+			},
+		},
+		"post": map[string]interface{}{
+			"operationId": "create" + singular,
+			"summary":     "Create a " + label,
+			"requestBody": body("The " + label + " to create"),
+			"responses": map[string]interface{}{
+				"201": response("The created "+label, ref),
+			},
+		},
+	}
+	paths[collection+"/{"+idParam+"}"] = map[string]interface{}{
+		"parameters": []interface{}{map[string]interface{}{
+			"name":     idParam,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		}},
+		"get": map[string]interface{}{
+			"operationId": "get" + singular,
+			"summary":     "Get a " + label,
+			"responses": map[string]interface{}{
+				"200": response("The "+label, ref),
+				"404": notFound,
+			},
+		},
+		"put": map[string]interface{}{
+			"operationId": "update" + singular,
+			"summary":     "Replace a " + label,
+			"requestBody": body("The new " + label),
+			"responses": map[string]interface{}{
+				"200": response("The updated "+label, ref),
+				"404": notFound,
+			},
+		},
+		"delete": map[string]interface{}{
+			"operationId": "delete" + singular,
+			"summary":     "Delete a " + label,
+			"responses": map[string]interface{}{
+				"204": response("The "+label+" was deleted", nil),
+				"404": notFound,
+			},
+		},
+	}
+
+	doc["components"] = map[string]interface{}{
+		"schemas": map[string]interface{}{
+			singular: map[string]interface{}{
+				"type":     "object",
+				"required": []interface{}{"id"},
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{"type": "string", "readOnly": true},
+				},
+			},
+		},
+	}
+	return nil
+}
+
+// resourceWords splits a resource name such as "line-item", "line_item" or "LineItem"
+// into lowercase words
+func resourceWords(resource string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	runes := []rune(resource)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			// A word starts at an upper case letter after a lower case one, or at the
+			// last letter of an acronym: LineItem, HTTPRequest
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			acronymEnd := i > 0 && unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || acronymEnd {
+				flush()
+			}
+			word = append(word, unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return words
+}
+
+// camel joins words in camelCase, or PascalCase when upper is set
+func camel(words []string, upper bool) string {
+	var b strings.Builder
+	for i, word := range words {
+		if i > 0 || upper {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// pluralize returns the English plural of a word for the common cases
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
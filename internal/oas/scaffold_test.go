@@ -0,0 +1,51 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffold(t *testing.T) {
+	doc, err := Scaffold(ScaffoldOptions{Title: "Orders API", UpstreamURL: "https://orders.svc", Resource: "order", Auth: ScaffoldAPIKey})
+	require.NoError(t, err)
+
+	assert.Empty(t, Validate(doc))
+	assert.Empty(t, Lint(doc))
+	assert.Equal(t, "/orders-api/", GetListenPath(doc))
+	assert.Equal(t, []string{AuthAPIKey}, AuthModes(doc))
+
+	paths := doc["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/orders")
+	item := paths["/orders/{orderId}"].(map[string]interface{})
+	assert.Equal(t, "deleteOrder", item["delete"].(map[string]interface{})["operationId"])
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Contains(t, schemas, "Order")
+}
+
+func TestScaffold_ResourceNames(t *testing.T) {
+	doc, err := Scaffold(ScaffoldOptions{Title: "Billing", UpstreamURL: "http://billing:8080", Resource: "LineItem"})
+	require.NoError(t, err)
+	paths := doc["paths"].(map[string]interface{})
+	collection := paths["/line-items"].(map[string]interface{})
+	assert.Equal(t, "listLineItems", collection["get"].(map[string]interface{})["operationId"])
+	assert.Contains(t, paths, "/line-items/{lineItemId}")
+
+	doc, err = Scaffold(ScaffoldOptions{Title: "Shop", UpstreamURL: "http://shop", Resource: "category"})
+	require.NoError(t, err)
+	assert.Contains(t, doc["paths"], "/categories")
+
+	assert.Equal(t, []string{"http", "request"}, resourceWords("HTTPRequest"))
+}
+
+func TestScaffold_Errors(t *testing.T) {
+	_, err := Scaffold(ScaffoldOptions{Title: "Orders", UpstreamURL: "orders.svc"})
+	assert.ErrorContains(t, err, "absolute http(s) URL")
+	_, err = Scaffold(ScaffoldOptions{UpstreamURL: "https://orders.svc"})
+	assert.ErrorContains(t, err, "title is required")
+	_, err = Scaffold(ScaffoldOptions{Title: "Orders", UpstreamURL: "https://orders.svc", Resource: "--"})
+	assert.ErrorContains(t, err, "letters or digits")
+	_, err = Scaffold(ScaffoldOptions{Title: "Orders", UpstreamURL: "https://orders.svc", Auth: "magic"})
+	assert.ErrorContains(t, err, "unknown authentication type")
+}
@@ -0,0 +1,104 @@
+package oas
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+// EditorSchemaID identifies the schema written by EditorSchema
+const EditorSchemaID = "https://tyk.io/schemas/cli/tyk-oas.schema.json"
+
+//go:embed schema/*.json
+var schemaFiles embed.FS
+
+// EditorSchema returns a JSON Schema (draft-07) for OpenAPI 3.x documents carrying the
+// x-tyk-api-gateway extension, for editors such as VS Code to complete and validate
+// specs. Extension fields are annotated with the Tyk release that introduced them,
+// and the plugin hooks replaced by Upgrade are marked deprecated.
+func EditorSchema() ([]byte, error) {
+	schema, err := loadSchemaFile("openapi.json")
+	if err != nil {
+		return nil, err
+	}
+	extension, err := loadSchemaFile("x-tyk-api-gateway.json")
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := schema["definitions"].(map[string]interface{})
+	for name, definition := range extension["definitions"].(map[string]interface{}) {
+		definitions[name] = definition
+	}
+	delete(extension, "definitions")
+	delete(extension, "$schema")
+	definitions[TykExtensionKey] = extension
+	schema["properties"].(map[string]interface{})[TykExtensionKey] = map[string]interface{}{
+		"$ref": "#/definitions/" + TykExtensionKey,
+	}
+
+	global := schemaProperty(extension, "middleware", "global")
+	for _, hook := range pluginHooks {
+		global["properties"].(map[string]interface{})[hook[0]] = map[string]interface{}{
+			"description": fmt.Sprintf("Replaced by %s in Tyk 5.3; run 'tyk oas upgrade --to 5.3'", hook[1]),
+			"deprecated":  true,
+			"allOf":       []interface{}{map[string]interface{}{"$ref": "#/definitions/tyk-plugin"}},
+		}
+	}
+	operation := definitions["tyk-operation"].(map[string]interface{})
+	for _, f := range Features {
+		note := fmt.Sprintf("Requires Tyk %s or later", f.Since)
+		for _, path := range f.global {
+			annotateSchemaProperty(schemaProperty(extension, path[:len(path)-1]...), path[len(path)-1], note)
+		}
+		if f.operation != "" {
+			annotateSchemaProperty(operation, f.operation, note)
+		}
+	}
+
+	schema["$id"] = EditorSchemaID
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func loadSchemaFile(name string) (map[string]interface{}, error) {
+	data, err := schemaFiles.ReadFile("schema/" + name)
+	if err != nil {
+		return nil, err
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("schema %s is not valid JSON: %w", name, err)
+	}
+	return schema, nil
+}
+
+// schemaProperty follows path through the properties of an object schema
+func schemaProperty(schema map[string]interface{}, path ...string) map[string]interface{} {
+	for _, key := range path {
+		properties, _ := schema["properties"].(map[string]interface{})
+		next, ok := properties[key].(map[string]interface{})
+		if !ok {
+			return map[string]interface{}{}
+		}
+		schema = next
+	}
+	return schema
+}
+
+// annotateSchemaProperty adds note to the description of a property. A $ref property
+// is wrapped, since draft-07 ignores keywords next to $ref.
+func annotateSchemaProperty(schema map[string]interface{}, key, note string) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	property, ok := properties[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if ref, ok := property["$ref"]; ok {
+		property = map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"$ref": ref}}}
+		properties[key] = property
+	}
+	if description, _ := property["description"].(string); description != "" {
+		note = description + ". " + note
+	}
+	property["description"] = note
+}
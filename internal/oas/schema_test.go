@@ -0,0 +1,54 @@
+package oas
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditorSchema(t *testing.T) {
+	data, err := EditorSchema()
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, EditorSchemaID, schema["$id"])
+
+	// Every reference resolves within the combined document
+	definitions := schema["definitions"].(map[string]interface{})
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch typed := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := typed["$ref"].(string); ok {
+				name := strings.TrimPrefix(ref, "#/definitions/")
+				assert.Contains(t, definitions, name, ref)
+			}
+			for _, value := range typed {
+				walk(value)
+			}
+		case []interface{}:
+			for _, value := range typed {
+				walk(value)
+			}
+		}
+	}
+	walk(schema)
+
+	extension := definitions[TykExtensionKey].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/" + TykExtensionKey}, schema["properties"].(map[string]interface{})[TykExtensionKey])
+	listenPath := schemaProperty(extension, "server", "listenPath", "value")
+	assert.Equal(t, "^/", listenPath["pattern"])
+
+	// Version requirements come from Features, deprecations from the upgrade migrations
+	rateLimit := schemaProperty(extension, "upstream", "rateLimit")
+	assert.Contains(t, rateLimit["description"], "Requires Tyk 5.4 or later")
+	assert.NotContains(t, rateLimit, "$ref")
+	mockResponse := schemaProperty(definitions["tyk-operation"].(map[string]interface{}), "mockResponse")
+	assert.Equal(t, "Respond without calling the upstream. Requires Tyk 5.3 or later", mockResponse["description"])
+	prePlugin := schemaProperty(extension, "middleware", "global", "prePlugin")
+	assert.Equal(t, true, prePlugin["deprecated"])
+	assert.Contains(t, prePlugin["description"], "prePlugins")
+}
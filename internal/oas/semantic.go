@@ -0,0 +1,183 @@
+package oas
+
+import (
+	"sort"
+	"strings"
+)
+
+// ValueChange is a before/after pair for a single scalar setting
+type ValueChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// SemanticDiff groups the differences between two API documents by what they mean
+// rather than where they are: operations, upstream, listen path, other Tyk settings
+// and everything else.
+type SemanticDiff struct {
+	OperationsAdded   []string     `json:"operations_added"`
+	OperationsRemoved []string     `json:"operations_removed"`
+	OperationsChanged []string     `json:"operations_changed"`
+	Upstream          *ValueChange `json:"upstream,omitempty"`
+	ListenPath        *ValueChange `json:"listen_path,omitempty"`
+	TykExtension      []Change     `json:"tyk_extension"`
+	Other             []Change     `json:"other"`
+}
+
+// Empty reports whether the documents are equivalent
+func (d *SemanticDiff) Empty() bool {
+	return len(d.OperationsAdded) == 0 && len(d.OperationsRemoved) == 0 && len(d.OperationsChanged) == 0 &&
+		d.Upstream == nil && d.ListenPath == nil && len(d.TykExtension) == 0 && len(d.Other) == 0
+}
+
+// DiffStats counts the differences in a SemanticDiff, like git diff --stat
+type DiffStats struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+}
+
+// Total is the number of differences
+func (s DiffStats) Total() int {
+	return s.Added + s.Removed + s.Changed
+}
+
+// Stats counts operations and settings added, removed and changed. The upstream and
+// listen path count as changes.
+func (d *SemanticDiff) Stats() DiffStats {
+	stats := DiffStats{
+		Added:   len(d.OperationsAdded),
+		Removed: len(d.OperationsRemoved),
+		Changed: len(d.OperationsChanged),
+	}
+	if d.Upstream != nil {
+		stats.Changed++
+	}
+	if d.ListenPath != nil {
+		stats.Changed++
+	}
+	for _, changes := range [][]Change{d.TykExtension, d.Other} {
+		stats = stats.add(CountChanges(changes))
+	}
+	return stats
+}
+
+// CountChanges counts changes by type
+func CountChanges(changes []Change) DiffStats {
+	var stats DiffStats
+	for _, change := range changes {
+		switch change.Type {
+		case ChangeAdded:
+			stats.Added++
+		case ChangeRemoved:
+			stats.Removed++
+		default:
+			stats.Changed++
+		}
+	}
+	return stats
+}
+
+func (s DiffStats) add(other DiffStats) DiffStats {
+	return DiffStats{Added: s.Added + other.Added, Removed: s.Removed + other.Removed, Changed: s.Changed + other.Changed}
+}
+
+// Semantic compares two documents. Fields present only in oldDoc under x-tyk-api-gateway
+// are ignored (see SignificantChanges), so oldDoc should be the deployed copy.
+func Semantic(oldDoc, newDoc map[string]interface{}) *SemanticDiff {
+	d := &SemanticDiff{
+		OperationsAdded:   []string{},
+		OperationsRemoved: []string{},
+		OperationsChanged: []string{},
+		TykExtension:      []Change{},
+		Other:             []Change{},
+	}
+
+	oldOps := operations(oldDoc)
+	newOps := operations(newDoc)
+	for op, oldValue := range oldOps {
+		newValue, ok := newOps[op]
+		switch {
+		case !ok:
+			d.OperationsRemoved = append(d.OperationsRemoved, op)
+		case !equalValues(oldValue, newValue):
+			d.OperationsChanged = append(d.OperationsChanged, op)
+		}
+	}
+	for op := range newOps {
+		if _, ok := oldOps[op]; !ok {
+			d.OperationsAdded = append(d.OperationsAdded, op)
+		}
+	}
+	sort.Strings(d.OperationsAdded)
+	sort.Strings(d.OperationsRemoved)
+	sort.Strings(d.OperationsChanged)
+
+	if oldUpstream, newUpstream := upstreamURL(oldDoc), upstreamURL(newDoc); oldUpstream != newUpstream {
+		d.Upstream = &ValueChange{Old: oldUpstream, New: newUpstream}
+	}
+	if oldPath, newPath := GetListenPath(oldDoc), GetListenPath(newDoc); oldPath != newPath {
+		d.ListenPath = &ValueChange{Old: oldPath, New: newPath}
+	}
+
+	for _, change := range SignificantChanges(Diff(oldDoc, newDoc)) {
+		switch {
+		case len(change.Path) > 0 && change.Path[0] == "paths":
+			// Reported per operation above
+		case hasPrefix(change.Path, TykExtensionKey, "upstream", "url"),
+			hasPrefix(change.Path, TykExtensionKey, "server", "listenPath", "value"):
+			// Reported as Upstream / ListenPath above
+		case len(change.Path) > 0 && change.Path[0] == TykExtensionKey:
+			d.TykExtension = append(d.TykExtension, change)
+		default:
+			d.Other = append(d.Other, change)
+		}
+	}
+
+	return d
+}
+
+// SignificantChanges drops fields that exist only in the old (deployed) document under
+// x-tyk-api-gateway; the Dashboard fills in defaults there that specs rarely spell out
+func SignificantChanges(changes []Change) []Change {
+	var significant []Change
+	for _, change := range changes {
+		if change.Type == ChangeRemoved && len(change.Path) > 0 && change.Path[0] == TykExtensionKey {
+			continue
+		}
+		significant = append(significant, change)
+	}
+	return significant
+}
+
+// operations indexes every operation as "METHOD /path"
+func operations(oasDoc map[string]interface{}) map[string]interface{} {
+	ops := make(map[string]interface{})
+	paths, _ := oasDoc["paths"].(map[string]interface{})
+	for path, itemValue := range paths {
+		item, _ := itemValue.(map[string]interface{})
+		for _, method := range httpMethods {
+			if op, ok := item[method]; ok {
+				ops[strings.ToUpper(method)+" "+path] = op
+			}
+		}
+	}
+	return ops
+}
+
+func upstreamURL(oasDoc map[string]interface{}) string {
+	value, _ := tykSection(oasDoc, "upstream", false)["url"].(string)
+	return value
+}
+
+func hasPrefix(path []string, prefix ...string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
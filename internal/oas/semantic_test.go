@@ -0,0 +1,37 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemantic(t *testing.T) {
+	assert.True(t, Semantic(validTykDoc(), validTykDoc()).Empty())
+
+	remote := validTykDoc()
+	tykSection(remote, "info", false)["dbId"] = "abc123"
+	remote["paths"].(map[string]interface{})["/legacy"] = map[string]interface{}{"get": map[string]interface{}{}}
+
+	local := validTykDoc()
+	local["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"].(map[string]interface{})["summary"] = "All users"
+	local["paths"].(map[string]interface{})["/users"].(map[string]interface{})["post"] = map[string]interface{}{}
+	tykSection(local, "upstream", false)["url"] = "https://users-v2.internal"
+	tykSection(local, "info", false)["state"] = map[string]interface{}{"active": false}
+	local["info"].(map[string]interface{})["version"] = "2.0.0"
+
+	d := Semantic(remote, local)
+	assert.False(t, d.Empty())
+	assert.Equal(t, []string{"POST /users"}, d.OperationsAdded)
+	assert.Equal(t, []string{"GET /legacy"}, d.OperationsRemoved)
+	assert.Equal(t, []string{"GET /users"}, d.OperationsChanged)
+	assert.Equal(t, &ValueChange{Old: "https://users.internal", New: "https://users-v2.internal"}, d.Upstream)
+	assert.Nil(t, d.ListenPath)
+	// The remote-only dbId is a Dashboard default and is not reported
+	assert.Equal(t, []Change{{Type: ChangeAdded, Path: []string{TykExtensionKey, "info", "state"}, New: map[string]interface{}{"active": false}}}, d.TykExtension)
+	assert.Equal(t, []Change{{Type: ChangeModified, Path: []string{"info", "version"}, Old: "1.0.0", New: "2.0.0"}}, d.Other)
+
+	// POST and state added; GET /legacy removed; GET /users, upstream and version changed
+	assert.Equal(t, DiffStats{Added: 2, Removed: 1, Changed: 3}, d.Stats())
+	assert.Equal(t, 6, d.Stats().Total())
+}
@@ -39,10 +39,37 @@ func ExtractAPIIDFromTykExtensions(oasDoc map[string]interface{}) (string, bool)
 	return id, true
 }
 
+// ExtensionOptions overrides values that AddTykExtensionsWithOptions would otherwise
+// derive from the document. Zero values keep the derived value.
+type ExtensionOptions struct {
+	ListenPath   string
+	UpstreamURL  string
+	CustomDomain string
+	Inactive     bool
+	Internal     bool
+	Tags         []string
+	// Categories are Dashboard categories added to the API name
+	Categories []string
+	// Auth is one of ScaffoldAuthTypes; empty leaves authentication as it is
+	Auth string
+	// KeepListenPath proxies requests with the listen path still on them, as the
+	// /<package>.<Service>/<Method> routes of gRPC calls need
+	KeepListenPath bool
+}
+
 // AddTykExtensions adds minimal x-tyk-api-gateway extensions to a plain OAS document
 func AddTykExtensions(oasDoc map[string]interface{}) (map[string]interface{}, error) {
+	return AddTykExtensionsWithOptions(oasDoc, ExtensionOptions{})
+}
+
+// AddTykExtensionsWithOptions adds x-tyk-api-gateway extensions like AddTykExtensions,
+// then applies opts. Documents that already have extensions only get opts applied.
+func AddTykExtensionsWithOptions(oasDoc map[string]interface{}, opts ExtensionOptions) (map[string]interface{}, error) {
 	if HasTykExtensions(oasDoc) {
-		return oasDoc, nil // Already has extensions
+		if err := applyExtensionOptions(oasDoc, opts); err != nil {
+			return nil, err
+		}
+		return oasDoc, nil
 	}
 	
 	// Extract info from OAS
@@ -57,7 +84,10 @@ func AddTykExtensions(oasDoc map[string]interface{}) (map[string]interface{}, er
 	}
 	
 	// Extract upstream URL from servers
-	upstreamURL := extractUpstreamURL(oasDoc)
+	upstreamURL := opts.UpstreamURL
+	if upstreamURL == "" {
+		upstreamURL = extractUpstreamURL(oasDoc)
+	}
 	if upstreamURL == "" {
 		return nil, fmt.Errorf("invalid OAS document: no servers defined or server URL missing")
 	}
@@ -89,10 +119,85 @@ func AddTykExtensions(oasDoc map[string]interface{}) (map[string]interface{}, er
 			},
 		},
 	}
+	if err := applyExtensionOptions(result, opts); err != nil {
+		return nil, err
+	}
 	
 	return result, nil
 }
 
+// applyExtensionOptions writes the non-zero options into the document's Tyk extension
+func applyExtensionOptions(oasDoc map[string]interface{}, opts ExtensionOptions) error {
+	if opts.ListenPath != "" {
+		SetListenPath(oasDoc, opts.ListenPath)
+	}
+	if opts.KeepListenPath {
+		server := tykSection(oasDoc, "server", true)
+		listenPath, ok := server["listenPath"].(map[string]interface{})
+		if !ok {
+			listenPath = map[string]interface{}{"value": "/"}
+			server["listenPath"] = listenPath
+		}
+		listenPath["strip"] = false
+	}
+	if opts.UpstreamURL != "" {
+		tykSection(oasDoc, "upstream", true)["url"] = opts.UpstreamURL
+	}
+	server := tykSection(oasDoc, "server", true)
+	if opts.CustomDomain != "" {
+		server["customDomain"] = map[string]interface{}{"enabled": true, "name": opts.CustomDomain}
+	}
+	if len(opts.Tags) > 0 {
+		SetGatewayTags(oasDoc, opts.Tags)
+	}
+	if len(opts.Categories) > 0 {
+		if err := AddCategories(oasDoc, opts.Categories); err != nil {
+			return err
+		}
+	}
+	if opts.Inactive {
+		info := tykSection(oasDoc, "info", true)
+		state, ok := info["state"].(map[string]interface{})
+		if !ok {
+			state = map[string]interface{}{}
+			info["state"] = state
+		}
+		state["active"] = false
+	}
+	if opts.Internal {
+		SetInternal(oasDoc, true)
+	}
+	if opts.Auth != "" {
+		if _, err := AddAuthentication(oasDoc, opts.Auth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetGatewayTags pins the API to the gateways carrying any of tags by enabling
+// x-tyk-api-gateway.server.gatewayTags; it replaces any tags already set
+func SetGatewayTags(oasDoc map[string]interface{}, tags []string) {
+	values := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		values[i] = tag
+	}
+	tykSection(oasDoc, "server", true)["gatewayTags"] = map[string]interface{}{"enabled": true, "tags": values}
+}
+
+// ServerURLs returns the URL of every entry in the document's servers list
+func ServerURLs(oasDoc map[string]interface{}) []string {
+	servers, _ := oasDoc["servers"].([]interface{})
+	var urls []string
+	for _, item := range servers {
+		server, _ := item.(map[string]interface{})
+		if u, _ := server["url"].(string); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
 // extractUpstreamURL extracts the upstream URL from OAS servers section
 func extractUpstreamURL(oasDoc map[string]interface{}) string {
 	servers, ok := oasDoc["servers"].([]interface{})
@@ -199,6 +199,43 @@ func TestAddTykExtensions(t *testing.T) {
 	}
 }
 
+func TestAddTykExtensionsWithOptions(t *testing.T) {
+	plain := func() map[string]interface{} {
+		return map[string]interface{}{
+			"openapi": "3.0.0",
+			"info":    map[string]interface{}{"title": "Swagger Petstore", "version": "1.0.0"},
+		}
+	}
+
+	// Without servers the upstream must come from the options
+	_, err := AddTykExtensionsWithOptions(plain(), ExtensionOptions{})
+	assert.Error(t, err)
+
+	result, err := AddTykExtensionsWithOptions(plain(), ExtensionOptions{UpstreamURL: "https://pets.internal", Tags: []string{"edge"}})
+	require.NoError(t, err)
+	assert.Equal(t, "https://pets.internal", upstreamURL(result))
+	assert.Equal(t, "/swagger-petstore/", GetListenPath(result))
+	assert.Equal(t, []interface{}{"edge"}, tykSection(result, "server", false)["gatewayTags"].(map[string]interface{})["tags"])
+
+	// Documents that already have extensions only get the overrides
+	result, err = AddTykExtensionsWithOptions(result, ExtensionOptions{ListenPath: "/pets/", Inactive: true, Internal: true})
+	require.NoError(t, err)
+	assert.Equal(t, "/pets/", GetListenPath(result))
+	assert.Equal(t, "https://pets.internal", upstreamURL(result))
+	assert.Equal(t, false, tykSection(result, "info", false)["state"].(map[string]interface{})["active"])
+	assert.True(t, IsInternal(result))
+}
+
+func TestServerURLs(t *testing.T) {
+	doc := map[string]interface{}{"servers": []interface{}{
+		map[string]interface{}{"url": "https://a.example.com"},
+		map[string]interface{}{"description": "no url"},
+		map[string]interface{}{"url": "https://b.example.com"},
+	}}
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, ServerURLs(doc))
+	assert.Empty(t, ServerURLs(map[string]interface{}{}))
+}
+
 func TestGenerateListenPath(t *testing.T) {
 	tests := []struct {
 		title    string
@@ -0,0 +1,133 @@
+package oas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinTykVersion is the first Tyk release with OAS API definitions
+const MinTykVersion = "5.0"
+
+// Migration rewrites x-tyk-api-gateway fields that a Tyk release renamed or moved.
+// Migrations only touch documents that still use the old layout, so running them again
+// changes nothing.
+type Migration struct {
+	// ID names the migration in change reports
+	ID string
+	// Version is the first Tyk release using the new layout
+	Version     string
+	Description string
+
+	apply func(oasDoc map[string]interface{}) []string
+}
+
+// UpgradeChange is one field rewritten by Upgrade
+type UpgradeChange struct {
+	Migration   string `json:"migration"`
+	Version     string `json:"version"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+// pluginHooks maps the single-plugin hooks deprecated in 5.3 to their list replacements
+var pluginHooks = [][2]string{
+	{"prePlugin", "prePlugins"},
+	{"postAuthenticationPlugin", "postAuthenticationPlugins"},
+	{"postPlugin", "postPlugins"},
+	{"responsePlugin", "responsePlugins"},
+}
+
+// Migrations lists every known extension migration, oldest first
+var Migrations = []Migration{
+	{
+		ID:          "plugin-hook-lists",
+		Version:     "5.3",
+		Description: "custom plugin hooks take a list of plugins",
+		apply: func(oasDoc map[string]interface{}) []string {
+			global := lookupSection(oasDoc, []string{"middleware", "global"})
+			var changed []string
+			for _, hook := range pluginHooks {
+				plugin, ok := global[hook[0]].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				plugins, _ := global[hook[1]].([]interface{})
+				global[hook[1]] = append([]interface{}{plugin}, plugins...)
+				delete(global, hook[0])
+				changed = append(changed, fmt.Sprintf("%s.middleware.global.%s → %s", TykExtensionKey, hook[0], hook[1]))
+			}
+			return changed
+		},
+	},
+	{
+		ID:          "service-discovery-cache",
+		Version:     "5.3",
+		Description: "service discovery caching moved to an object with enabled and timeout",
+		apply: func(oasDoc map[string]interface{}) []string {
+			discovery := lookupSection(oasDoc, []string{"upstream", "serviceDiscovery"})
+			timeout, ok := discovery["cacheTimeout"]
+			if !ok {
+				return nil
+			}
+			if _, exists := discovery["cache"]; !exists {
+				discovery["cache"] = map[string]interface{}{"enabled": true, "timeout": timeout}
+			}
+			delete(discovery, "cacheTimeout")
+			return []string{TykExtensionKey + ".upstream.serviceDiscovery.cacheTimeout → cache.timeout"}
+		},
+	},
+}
+
+// Upgrade rewrites the document's Tyk extension for Tyk version to, applying every
+// migration introduced at or before it, and reports what changed
+func Upgrade(oasDoc map[string]interface{}, to string) ([]UpgradeChange, error) {
+	target, err := ParseTykVersion(to)
+	if err != nil {
+		return nil, err
+	}
+	changes := []UpgradeChange{}
+	if !HasTykExtensions(oasDoc) {
+		return changes, nil
+	}
+	for _, m := range Migrations {
+		version, _ := ParseTykVersion(m.Version)
+		if compareVersions(version, target) > 0 {
+			continue
+		}
+		for _, path := range m.apply(oasDoc) {
+			changes = append(changes, UpgradeChange{Migration: m.ID, Version: m.Version, Path: path, Description: m.Description})
+		}
+	}
+	return changes, nil
+}
+
+// ParseTykVersion reads a Tyk release such as "5.5" or "5.3.2" as major, minor, patch.
+// Releases before Tyk OAS support are rejected.
+func ParseTykVersion(v string) ([3]int, error) {
+	var version [3]int
+	parts := strings.Split(strings.TrimPrefix(v, "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return version, fmt.Errorf("invalid Tyk version '%s' (expected e.g. 5.5 or 5.3.2)", v)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version, fmt.Errorf("invalid Tyk version '%s' (expected e.g. 5.5 or 5.3.2)", v)
+		}
+		version[i] = n
+	}
+	if version[0] < 5 {
+		return version, fmt.Errorf("Tyk %s predates OAS API definitions (%s or later)", v, MinTykVersion)
+	}
+	return version, nil
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package oas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgrade(t *testing.T) {
+	doc := validTykDoc()
+	tykSection(doc, "middleware", true)["global"] = map[string]interface{}{
+		"prePlugin":   map[string]interface{}{"enabled": true, "functionName": "auditRequest", "path": "plugins/audit.so"},
+		"postPlugins": []interface{}{map[string]interface{}{"enabled": true, "functionName": "addHeaders"}},
+		"postPlugin":  map[string]interface{}{"enabled": true, "functionName": "signRequest"},
+	}
+	tykSection(doc, "upstream", true)["serviceDiscovery"] = map[string]interface{}{"enabled": true, "cacheTimeout": 60}
+
+	// Releases before a migration leave the document alone
+	changes, err := Upgrade(doc, "5.2")
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	changes, err = Upgrade(doc, "5.5")
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "plugin-hook-lists", changes[0].Migration)
+	assert.Equal(t, "5.3", changes[0].Version)
+
+	global := lookupSection(doc, []string{"middleware", "global"})
+	assert.NotContains(t, global, "prePlugin")
+	assert.NotContains(t, global, "postPlugin")
+	assert.Len(t, global["prePlugins"], 1)
+	postPlugins := global["postPlugins"].([]interface{})
+	require.Len(t, postPlugins, 2)
+	assert.Equal(t, "signRequest", postPlugins[0].(map[string]interface{})["functionName"])
+	assert.Equal(t, map[string]interface{}{"enabled": true, "timeout": 60},
+		lookupSection(doc, []string{"upstream", "serviceDiscovery", "cache"}))
+
+	// Upgrading again finds nothing left to do
+	changes, err = Upgrade(doc, "5.5")
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestParseTykVersion(t *testing.T) {
+	version, err := ParseTykVersion("5.3.2")
+	require.NoError(t, err)
+	assert.Equal(t, [3]int{5, 3, 2}, version)
+
+	for _, invalid := range []string{"", "5", "five.0", "5.x", "4.3"} {
+		_, err := ParseTykVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
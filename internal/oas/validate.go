@@ -0,0 +1,104 @@
+package oas
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Diagnostic severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Diagnostic is a single problem found in an OAS document. Path is the list of
+// keys leading to the offending value, e.g. ["x-tyk-api-gateway", "upstream", "url"].
+type Diagnostic struct {
+	Severity string   `json:"severity"`
+	Path     []string `json:"path"`
+	Message  string   `json:"message"`
+}
+
+// httpMethods are the OAS path item keys that describe operations
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Validate reports problems that would make the document fail to import or apply
+func Validate(oasDoc map[string]interface{}) []Diagnostic {
+	var diags []Diagnostic
+	add := func(severity, message string, path ...string) {
+		diags = append(diags, Diagnostic{Severity: severity, Path: path, Message: message})
+	}
+
+	version, _ := oasDoc["openapi"].(string)
+	switch {
+	case version == "":
+		add(SeverityError, "missing openapi version", "openapi")
+	case !strings.HasPrefix(version, "3."):
+		add(SeverityError, fmt.Sprintf("unsupported openapi version %s: Tyk requires 3.x", version), "openapi")
+	}
+
+	info, ok := oasDoc["info"].(map[string]interface{})
+	if !ok {
+		add(SeverityError, "missing info section", "info")
+	} else {
+		if title, _ := info["title"].(string); title == "" {
+			add(SeverityError, "missing info.title", "info", "title")
+		}
+		if _, ok := info["version"]; !ok {
+			add(SeverityError, "missing info.version", "info", "version")
+		}
+	}
+
+	if _, ok := oasDoc["paths"].(map[string]interface{}); !ok {
+		add(SeverityError, "missing paths section", "paths")
+	}
+
+	if !HasTykExtensions(oasDoc) {
+		add(SeverityWarning, "no x-tyk-api-gateway extension: use import-oas, or add the extension to use apply", TykExtensionKey)
+		return diags
+	}
+
+	if name, _ := tykSection(oasDoc, "info", false)["name"].(string); name == "" {
+		add(SeverityError, "missing API name", TykExtensionKey, "info", "name")
+	}
+
+	listenPath := GetListenPath(oasDoc)
+	switch {
+	case listenPath == "":
+		add(SeverityError, "missing listen path", TykExtensionKey, "server", "listenPath", "value")
+	case !strings.HasPrefix(listenPath, "/"):
+		add(SeverityError, fmt.Sprintf("listen path %q must start with /", listenPath), TykExtensionKey, "server", "listenPath", "value")
+	}
+
+	upstream, _ := tykSection(oasDoc, "upstream", false)["url"].(string)
+	if upstream == "" {
+		add(SeverityError, "missing upstream URL", TykExtensionKey, "upstream", "url")
+	} else if u, err := url.Parse(upstream); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add(SeverityError, fmt.Sprintf("upstream URL %q must be an absolute http(s) URL", upstream), TykExtensionKey, "upstream", "url")
+	}
+
+	return diags
+}
+
+// Lint reports style issues that do not block a deployment: the rules of the
+// tyk-basic ruleset, as warnings
+func Lint(oasDoc map[string]interface{}) []Diagnostic {
+	ruleset, _ := NewRuleset("tyk-basic")
+	var diags []Diagnostic
+	for _, finding := range ruleset.Lint(oasDoc) {
+		diags = append(diags, finding.Diagnostic)
+	}
+	return diags
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,66 @@
+package oas
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validTykDoc() map[string]interface{} {
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    map[string]interface{}{"title": "Users", "version": "1.0.0"},
+		"paths": map[string]interface{}{
+			"/users": map[string]interface{}{
+				"get": map[string]interface{}{"operationId": "listUsers", "summary": "List users"},
+			},
+		},
+		TykExtensionKey: map[string]interface{}{
+			"info":     map[string]interface{}{"name": "Users"},
+			"server":   map[string]interface{}{"listenPath": map[string]interface{}{"value": "/users/"}},
+			"upstream": map[string]interface{}{"url": "https://users.internal"},
+		},
+	}
+}
+
+func diagnosticPaths(diags []Diagnostic) []string {
+	var paths []string
+	for _, d := range diags {
+		paths = append(paths, d.Severity+":"+strings.Join(d.Path, "."))
+	}
+	return paths
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, Validate(validTykDoc()))
+
+	doc := validTykDoc()
+	doc["openapi"] = "2.0"
+	delete(doc["info"].(map[string]interface{}), "version")
+	tykSection(doc, "server", false)["listenPath"] = map[string]interface{}{"value": "users"}
+	tykSection(doc, "upstream", false)["url"] = "users.internal"
+	assert.Equal(t, []string{
+		"error:openapi",
+		"error:info.version",
+		"error:x-tyk-api-gateway.server.listenPath.value",
+		"error:x-tyk-api-gateway.upstream.url",
+	}, diagnosticPaths(Validate(doc)))
+
+	plain := validTykDoc()
+	delete(plain, TykExtensionKey)
+	assert.Equal(t, []string{"warning:x-tyk-api-gateway"}, diagnosticPaths(Validate(plain)))
+}
+
+func TestLint(t *testing.T) {
+	assert.Empty(t, Lint(validTykDoc()))
+
+	doc := validTykDoc()
+	tykSection(doc, "upstream", false)["url"] = "http://users.internal"
+	doc["paths"].(map[string]interface{})["/orders"] = map[string]interface{}{"post": map[string]interface{}{}}
+	assert.Equal(t, []string{
+		"warning:x-tyk-api-gateway.upstream.url",
+		"warning:paths./orders.post",
+		"warning:paths./orders.post",
+	}, diagnosticPaths(Lint(doc)))
+}
@@ -0,0 +1,228 @@
+// Package operator converts API definitions to and from the custom resources of the
+// Tyk Operator, so APIs managed with the CLI can move to a Kubernetes cluster managed
+// by the Operator, and back.
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion of the Tyk Operator custom resources
+const APIVersion = "tyk.tyk.io/v1alpha1"
+
+// Kinds of the resources an API is exported as
+const (
+	KindApiDefinition       = "ApiDefinition"
+	KindTykOasApiDefinition = "TykOasApiDefinition"
+	KindConfigMap           = "ConfigMap"
+)
+
+// Metadata is the metadata of a Kubernetes object
+type Metadata struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// Resource is a Kubernetes object
+type Resource struct {
+	APIVersion string                 `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string                 `yaml:"kind" json:"kind"`
+	Metadata   Metadata               `yaml:"metadata" json:"metadata"`
+	Data       map[string]string      `yaml:"data,omitempty" json:"data,omitempty"`
+	Spec       map[string]interface{} `yaml:"spec,omitempty" json:"spec,omitempty"`
+}
+
+// classicManagedFields are set by the Operator or the Dashboard, not by the resource
+var classicManagedFields = []string{"id", "org_id"}
+
+// OASResources returns the resources of an OAS API: a ConfigMap holding the document,
+// and the TykOasApiDefinition referencing it
+func OASResources(doc map[string]interface{}, name, namespace string) ([]Resource, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the OAS document: %w", err)
+	}
+	key := name + ".json"
+	// Without a namespace the Operator looks for the ConfigMap next to the resource
+	ref := map[string]interface{}{"name": name, "keyName": key}
+	if namespace != "" {
+		ref["namespace"] = namespace
+	}
+	return []Resource{
+		{
+			APIVersion: "v1",
+			Kind:       KindConfigMap,
+			Metadata:   Metadata{Name: name, Namespace: namespace},
+			Data:       map[string]string{key: string(data)},
+		},
+		{
+			APIVersion: APIVersion,
+			Kind:       KindTykOasApiDefinition,
+			Metadata:   Metadata{Name: name, Namespace: namespace},
+			Spec: map[string]interface{}{
+				"tykOAS": map[string]interface{}{"configmapRef": ref},
+			},
+		},
+	}, nil
+}
+
+// ClassicResource returns the ApiDefinition of a classic API definition
+func ClassicResource(def map[string]interface{}, name, namespace string) (Resource, error) {
+	if err := checkName(name); err != nil {
+		return Resource{}, err
+	}
+	spec := make(map[string]interface{}, len(def))
+	for key, value := range def {
+		spec[key] = value
+	}
+	for _, field := range classicManagedFields {
+		delete(spec, field)
+	}
+	return Resource{
+		APIVersion: APIVersion,
+		Kind:       KindApiDefinition,
+		Metadata:   Metadata{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}, nil
+}
+
+// Marshal writes resources as one YAML stream
+func Marshal(resources []Resource) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	for _, resource := range resources {
+		if err := encoder.Encode(resource); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// API is an API definition read back from Operator resources
+type API struct {
+	// Kind is the custom resource the API came from
+	Kind string
+	Name string
+	// OAS is set for a TykOasApiDefinition, Classic for an ApiDefinition
+	OAS     map[string]interface{}
+	Classic map[string]interface{}
+}
+
+// Parse reads the APIs of a YAML stream of resources. The OAS document of a
+// TykOasApiDefinition is read from the ConfigMap it references, which must be in the
+// same stream. Other resources are ignored.
+func Parse(data []byte) ([]API, error) {
+	var resources []Resource
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var resource Resource
+		err := decoder.Decode(&resource)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		if resource.Kind != "" {
+			resources = append(resources, resource)
+		}
+	}
+
+	var apis []API
+	for _, resource := range resources {
+		switch resource.Kind {
+		case KindApiDefinition:
+			if len(resource.Spec) == 0 {
+				return nil, fmt.Errorf("ApiDefinition %s has no spec", resource.Metadata.Name)
+			}
+			apis = append(apis, API{Kind: resource.Kind, Name: resource.Metadata.Name, Classic: resource.Spec})
+		case KindTykOasApiDefinition:
+			doc, err := referencedDocument(resource, resources)
+			if err != nil {
+				return nil, err
+			}
+			apis = append(apis, API{Kind: resource.Kind, Name: resource.Metadata.Name, OAS: doc})
+		}
+	}
+	if len(apis) == 0 {
+		return nil, fmt.Errorf("no %s or %s found", KindTykOasApiDefinition, KindApiDefinition)
+	}
+	return apis, nil
+}
+
+// referencedDocument returns the OAS document in the ConfigMap a TykOasApiDefinition
+// references
+func referencedDocument(resource Resource, resources []Resource) (map[string]interface{}, error) {
+	tykOAS, _ := resource.Spec["tykOAS"].(map[string]interface{})
+	ref, _ := tykOAS["configmapRef"].(map[string]interface{})
+	name, _ := ref["name"].(string)
+	namespace, _ := ref["namespace"].(string)
+	key, _ := ref["keyName"].(string)
+	if name == "" || key == "" {
+		return nil, fmt.Errorf("TykOasApiDefinition %s: spec.tykOAS.configmapRef needs a name and keyName", resource.Metadata.Name)
+	}
+	if namespace == "" {
+		namespace = resource.Metadata.Namespace
+	}
+
+	for _, candidate := range resources {
+		if candidate.Kind != KindConfigMap || candidate.Metadata.Name != name {
+			continue
+		}
+		if namespace != "" && candidate.Metadata.Namespace != "" && candidate.Metadata.Namespace != namespace {
+			continue
+		}
+		value, ok := candidate.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("TykOasApiDefinition %s: ConfigMap %s has no key %s", resource.Metadata.Name, name, key)
+		}
+		var doc map[string]interface{}
+		// JSON is YAML, so this reads documents stored in either
+		if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+			return nil, fmt.Errorf("TykOasApiDefinition %s: ConfigMap %s key %s is not a valid document: %w", resource.Metadata.Name, name, key, err)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("TykOasApiDefinition %s: ConfigMap %s is not in the manifest; add it to the file", resource.Metadata.Name, name)
+}
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+	validName        = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// ResourceName turns an API name into a Kubernetes object name: lowercase letters,
+// digits and dashes, at most 63 characters
+func ResourceName(apiName string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(apiName), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "api"
+	}
+	return name
+}
+
+func checkName(name string) error {
+	if len(name) > 63 || !validName.MatchString(name) {
+		return fmt.Errorf("'%s' is not a valid Kubernetes name: use at most 63 lowercase letters, digits and dashes", name)
+	}
+	return nil
+}
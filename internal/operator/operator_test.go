@@ -0,0 +1,76 @@
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOASResources_RoundTrip(t *testing.T) {
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info":    map[string]interface{}{"title": "Users API", "version": "1.0.0"},
+		"x-tyk-api-gateway": map[string]interface{}{
+			"server": map[string]interface{}{"listenPath": map[string]interface{}{"value": "/users/"}},
+		},
+	}
+	resources, err := OASResources(doc, "users-api", "apis")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, KindConfigMap, resources[0].Kind)
+	assert.Equal(t, KindTykOasApiDefinition, resources[1].Kind)
+
+	data, err := Marshal(resources)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "apiVersion: tyk.tyk.io/v1alpha1\nkind: TykOasApiDefinition\n")
+	assert.Contains(t, string(data), "\n---\n")
+
+	apis, err := Parse(data)
+	require.NoError(t, err)
+	require.Len(t, apis, 1)
+	assert.Equal(t, "users-api", apis[0].Name)
+	assert.Equal(t, doc, apis[0].OAS)
+}
+
+func TestClassicResource(t *testing.T) {
+	def := map[string]interface{}{"id": "record-1", "org_id": "org", "api_id": "abc", "name": "Chat", "protocol": "tcp"}
+	resource, err := ClassicResource(def, "chat", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"api_id": "abc", "name": "Chat", "protocol": "tcp"}, resource.Spec)
+	assert.Contains(t, def, "id", "the definition is not modified")
+
+	data, err := Marshal([]Resource{resource})
+	require.NoError(t, err)
+	apis, err := Parse(data)
+	require.NoError(t, err)
+	require.Len(t, apis, 1)
+	assert.Equal(t, KindApiDefinition, apis[0].Kind)
+	assert.Equal(t, "tcp", apis[0].Classic["protocol"])
+}
+
+func TestParse_Errors(t *testing.T) {
+	_, err := Parse([]byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: users\n"))
+	assert.ErrorContains(t, err, "no TykOasApiDefinition or ApiDefinition found")
+
+	_, err = Parse([]byte(`apiVersion: tyk.tyk.io/v1alpha1
+kind: TykOasApiDefinition
+metadata:
+  name: users
+spec:
+  tykOAS:
+    configmapRef:
+      name: users-oas
+      keyName: users.json
+`))
+	assert.ErrorContains(t, err, "ConfigMap users-oas is not in the manifest")
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "users-api-v2", ResourceName("Users API (v2)"))
+	assert.Equal(t, "api", ResourceName("!!!"))
+	assert.Len(t, ResourceName("a-very-long-name-that-keeps-going-and-going-well-past-the-limit-of-63"), 63)
+
+	_, err := OASResources(map[string]interface{}{}, "Users", "")
+	assert.ErrorContains(t, err, "not a valid Kubernetes name")
+}
@@ -0,0 +1,216 @@
+// Package outputschema publishes the JSON Schemas describing the structured
+// (--output json / yaml) output of each CLI command.
+//
+// Schemas are versioned through their $id. Additive changes (new optional
+// fields) keep the current version; removing or renaming a field, or changing
+// its type, requires a new version.
+package outputschema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Version is the current version of the output contract
+const Version = "v1"
+
+//go:embed schemas/*.json
+var files embed.FS
+
+// Names returns the names of all published schemas, sorted
+func Names() []string {
+	entries, err := files.ReadDir("schemas")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the raw schema document for name
+func Get(name string) ([]byte, error) {
+	data, err := files.ReadFile(path.Join("schemas", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown output schema '%s' (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return data, nil
+}
+
+// Validate checks a JSON document against the named schema. It understands the
+// subset of JSON Schema used by the published schemas: type, required,
+// properties, additionalProperties, items, enum and local $ref.
+func Validate(name string, document []byte) error {
+	raw, err := Get(name)
+	if err != nil {
+		return err
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return fmt.Errorf("schema '%s' is not valid JSON: %w", name, err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(document, &value); err != nil {
+		return fmt.Errorf("document is not valid JSON: %w", err)
+	}
+	v := &validator{root: schema}
+	return v.validate(schema, value, "$")
+}
+
+type validator struct {
+	root map[string]interface{}
+}
+
+func (v *validator) validate(schema map[string]interface{}, value interface{}, at string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := v.resolve(ref)
+		if err != nil {
+			return err
+		}
+		return v.validate(resolved, value, at)
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		return fmt.Errorf("%s: expected %v, got %s", at, t, jsonType(value))
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if allowed == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", at, value, enum)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return v.validateObject(schema, typed, at)
+	case []interface{}:
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range typed {
+			if err := v.validate(items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *validator) validateObject(schema map[string]interface{}, object map[string]interface{}, at string) error {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, field := range required {
+			if _, present := object[field.(string)]; !present {
+				return fmt.Errorf("%s: missing required field '%s'", at, field)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, key := range sortedKeys(object) {
+		fieldAt := at + "." + key
+		if property, ok := properties[key].(map[string]interface{}); ok {
+			if err := v.validate(property, object[key], fieldAt); err != nil {
+				return err
+			}
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: field is not allowed", fieldAt)
+			}
+		case map[string]interface{}:
+			if err := v.validate(additional, object[key], fieldAt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows a local reference such as "#/definitions/api"
+func (v *validator) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref '%s'", ref)
+	}
+	var node interface{} = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref '%s'", ref)
+		}
+		node = object[part]
+	}
+	resolved, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unresolvable $ref '%s'", ref)
+	}
+	return resolved, nil
+}
+
+func matchesType(t interface{}, value interface{}) bool {
+	switch typed := t.(type) {
+	case string:
+		return typeMatches(typed, value)
+	case []interface{}:
+		for _, candidate := range typed {
+			if name, ok := candidate.(string); ok && typeMatches(name, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeMatches(name string, value interface{}) bool {
+	actual := jsonType(value)
+	if name == "number" && actual == "integer" {
+		return true
+	}
+	return name == actual
+}
+
+func jsonType(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if typed == float64(int64(typed)) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package outputschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemasAreVersioned(t *testing.T) {
+	names := Names()
+	require.NotEmpty(t, names)
+
+	for _, name := range names {
+		raw, err := Get(name)
+		require.NoError(t, err, name)
+
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &schema), name)
+		assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"], name)
+		assert.Equal(t, "https://tyk.io/schemas/cli/"+Version+"/"+name+".json", schema["$id"], name)
+		assert.NotEmpty(t, schema["title"], name)
+		assert.NotEmpty(t, schema["type"], name)
+	}
+}
+
+func TestGet_Unknown(t *testing.T) {
+	_, err := Get("nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api-list")
+}
+
+func TestValidate(t *testing.T) {
+	api := `{"id":"a1","name":"Users","listen_path":"/users/","default_version":"v1","version_data":null,"oas":null,"created_at":"","updated_at":"","active":true,"internal":false}`
+
+	tests := []struct {
+		name    string
+		schema  string
+		doc     string
+		wantErr string
+	}{
+		{name: "valid list", schema: "api-list", doc: `{"page":1,"count":1,"apis":[` + api + `]}`},
+		{name: "empty list", schema: "api-list", doc: `{"page":1,"count":0,"apis":[]}`},
+		{name: "missing field", schema: "api-list", doc: `{"page":1,"apis":[]}`, wantErr: "missing required field 'count'"},
+		{name: "wrong type", schema: "api-list", doc: `{"page":"1","count":0,"apis":[]}`, wantErr: "$.page: expected integer"},
+		{name: "bad nested item", schema: "api-list", doc: `{"page":1,"count":1,"apis":[{"id":"a1"}]}`, wantErr: "$.apis[0]"},
+		{name: "enum", schema: "api-delete", doc: `{"api_id":"a1","operation":"removed","success":true}`, wantErr: "is not one of"},
+		{name: "additional properties", schema: "foreach-env", doc: `{"command":"api list","failed":0,"environments":{"dev":{"output":{"apis":[]}}}}`},
+		{name: "additional properties invalid", schema: "foreach-env", doc: `{"command":"api list","failed":0,"environments":{"dev":{"error":1}}}`, wantErr: "$.environments.dev.error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.schema, []byte(tt.doc))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), tt.wantErr), err.Error())
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package ownership
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// Source identifies where ownership data was derived from
+type Source string
+
+const (
+	SourceCodeOwners Source = "codeowners"
+	SourceGitLog     Source = "git-log"
+	SourceGitConfig  Source = "git-config"
+)
+
+// codeOwnersLocations lists the places GitHub/GitLab look for a CODEOWNERS file
+var codeOwnersLocations = []string{"CODEOWNERS", ".github/CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// Owner describes who owns a spec file
+type Owner struct {
+	Name  string
+	Email string
+	// Team is the CODEOWNERS team owning the file, e.g. payments for @acme/payments
+	Team   string
+	Source Source
+}
+
+// Resolve determines the owner of filePath, preferring CODEOWNERS over Git history.
+// An empty filePath (e.g. stdin) falls back to the local Git identity.
+func Resolve(filePath string) (*Owner, error) {
+	if filePath != "" {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if root, err := gitOutput(filepath.Dir(absPath), "rev-parse", "--show-toplevel"); err == nil && root != "" {
+			if rel, err := filepath.Rel(root, absPath); err == nil {
+				if owner := resolveFromCodeOwners(root, filepath.ToSlash(rel)); owner != nil {
+					return owner, nil
+				}
+			}
+
+			if out, err := gitOutput(root, "log", "-1", "--format=%an%x09%ae", "--", absPath); err == nil && out != "" {
+				parts := strings.SplitN(out, "\t", 2)
+				owner := &Owner{Name: parts[0], Source: SourceGitLog}
+				if len(parts) == 2 {
+					owner.Email = parts[1]
+				}
+				return owner, nil
+			}
+		}
+	}
+
+	name, _ := gitOutput("", "config", "user.name")
+	email, _ := gitOutput("", "config", "user.email")
+	if name == "" && email == "" {
+		return nil, nil
+	}
+	return &Owner{Name: name, Email: email, Source: SourceGitConfig}, nil
+}
+
+// resolveFromCodeOwners returns the owners of the last matching CODEOWNERS rule
+func resolveFromCodeOwners(root, relPath string) *Owner {
+	for _, loc := range codeOwnersLocations {
+		file, err := os.Open(filepath.Join(root, loc))
+		if err != nil {
+			continue
+		}
+		owners := MatchCodeOwners(bufio.NewScanner(file), relPath)
+		file.Close()
+		if len(owners) == 0 {
+			return nil
+		}
+		return ownerFromHandles(owners)
+	}
+	return nil
+}
+
+// MatchCodeOwners returns the owners of the last rule matching relPath; later rules win
+func MatchCodeOwners(scanner *bufio.Scanner, relPath string) []string {
+	var owners []string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if matchPattern(fields[0], relPath) {
+			owners = fields[1:]
+		}
+	}
+	return owners
+}
+
+// matchPattern implements the subset of gitignore-style matching used by CODEOWNERS
+func matchPattern(pattern, relPath string) bool {
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	if pattern == "*" || pattern == "**" {
+		return true
+	}
+
+	// Directory rules own everything beneath them
+	if strings.HasSuffix(pattern, "/") {
+		dir := strings.TrimSuffix(pattern, "/")
+		if anchored || strings.Contains(dir, "/") {
+			return strings.HasPrefix(relPath, dir+"/")
+		}
+		for _, segment := range strings.Split(path.Dir(relPath), "/") {
+			if segment == dir {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !anchored && !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(relPath))
+		return ok
+	}
+
+	if ok, _ := path.Match(pattern, relPath); ok {
+		return true
+	}
+	// A path without wildcards may name a directory
+	return strings.HasPrefix(relPath, pattern+"/")
+}
+
+// ownerFromHandles converts CODEOWNERS handles (@team, @user, email) into an Owner
+func ownerFromHandles(handles []string) *Owner {
+	owner := &Owner{Source: SourceCodeOwners}
+	var names []string
+	for _, handle := range handles {
+		if strings.Contains(handle, "@") && !strings.HasPrefix(handle, "@") {
+			if owner.Email == "" {
+				owner.Email = handle
+			}
+			continue
+		}
+		if team := strings.TrimPrefix(handle, "@"); owner.Team == "" && team != handle && strings.Contains(team, "/") {
+			owner.Team = team[strings.LastIndex(team, "/")+1:]
+		}
+		names = append(names, handle)
+	}
+	owner.Name = strings.Join(names, ", ")
+	if owner.Name == "" {
+		owner.Name = owner.Email
+	}
+	return owner
+}
+
+// Inject writes the owner into info.contact, keeping any values already present, and
+// adds the owning team to the API's categories. It reports whether the document was
+// modified.
+func Inject(oasDoc map[string]interface{}, owner *Owner) bool {
+	if owner == nil {
+		return false
+	}
+
+	info, ok := oasDoc["info"].(map[string]interface{})
+	if !ok {
+		info = map[string]interface{}{}
+		oasDoc["info"] = info
+	}
+
+	contact, ok := info["contact"].(map[string]interface{})
+	if !ok {
+		contact = map[string]interface{}{}
+	}
+
+	changed := false
+	if _, exists := contact["name"]; !exists && owner.Name != "" {
+		contact["name"] = owner.Name
+		changed = true
+	}
+	if _, exists := contact["email"]; !exists && owner.Email != "" {
+		contact["email"] = owner.Email
+		changed = true
+	}
+	if changed {
+		info["contact"] = contact
+	}
+
+	categories := oas.Categories(oasDoc)
+	if owner.Team != "" && !slices.Contains(categories, owner.Team) {
+		if err := oas.SetCategories(oasDoc, append(categories, owner.Team)); err == nil {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// gitOutput runs git in dir and returns trimmed stdout
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
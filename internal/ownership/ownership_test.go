@@ -0,0 +1,114 @@
+package ownership
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+func TestMatchCodeOwners(t *testing.T) {
+	codeowners := `# Global owners
+* @acme/platform
+
+apis/payments/ @acme/payments billing@acme.io
+/apis/users.yaml @alice
+*.json @acme/json-team
+`
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{"falls back to global rule", "README.md", []string{"@acme/platform"}},
+		{"directory rule", "apis/payments/charge.yaml", []string{"@acme/payments", "billing@acme.io"}},
+		{"anchored file rule", "apis/users.yaml", []string{"@alice"}},
+		{"extension glob wins when last", "apis/payments/refund.json", []string{"@acme/json-team"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := bufio.NewScanner(strings.NewReader(codeowners))
+			assert.Equal(t, tt.expected, MatchCodeOwners(scanner, tt.path))
+		})
+	}
+}
+
+func TestOwnerFromHandles(t *testing.T) {
+	owner := ownerFromHandles([]string{"@acme/payments", "billing@acme.io"})
+	assert.Equal(t, "@acme/payments", owner.Name)
+	assert.Equal(t, "billing@acme.io", owner.Email)
+	assert.Equal(t, "payments", owner.Team)
+	assert.Equal(t, SourceCodeOwners, owner.Source)
+
+	emailOnly := ownerFromHandles([]string{"billing@acme.io"})
+	assert.Equal(t, "billing@acme.io", emailOnly.Name)
+	assert.Empty(t, ownerFromHandles([]string{"@alice"}).Team)
+}
+
+func TestInject(t *testing.T) {
+	t.Run("fills missing contact", func(t *testing.T) {
+		doc := map[string]interface{}{"info": map[string]interface{}{"title": "Test"}}
+		changed := Inject(doc, &Owner{Name: "@acme/payments", Email: "billing@acme.io"})
+		assert.True(t, changed)
+
+		contact := doc["info"].(map[string]interface{})["contact"].(map[string]interface{})
+		assert.Equal(t, "@acme/payments", contact["name"])
+		assert.Equal(t, "billing@acme.io", contact["email"])
+	})
+
+	t.Run("keeps existing values", func(t *testing.T) {
+		doc := map[string]interface{}{"info": map[string]interface{}{
+			"contact": map[string]interface{}{"name": "Existing"},
+		}}
+		changed := Inject(doc, &Owner{Name: "@acme/payments", Email: "billing@acme.io"})
+		assert.True(t, changed)
+
+		contact := doc["info"].(map[string]interface{})["contact"].(map[string]interface{})
+		assert.Equal(t, "Existing", contact["name"])
+		assert.Equal(t, "billing@acme.io", contact["email"])
+	})
+
+	t.Run("adds the team as a category", func(t *testing.T) {
+		doc := map[string]interface{}{
+			"info":              map[string]interface{}{"contact": map[string]interface{}{"name": "Existing", "email": "x@acme.io"}},
+			"x-tyk-api-gateway": map[string]interface{}{"info": map[string]interface{}{"name": "Payments #internal"}},
+		}
+		assert.True(t, Inject(doc, &Owner{Name: "@acme/payments", Team: "payments"}))
+		assert.Equal(t, []string{"internal", "payments"}, oas.Categories(doc))
+
+		assert.False(t, Inject(doc, &Owner{Name: "@acme/payments", Team: "payments"}))
+	})
+
+	t.Run("nil owner is a no-op", func(t *testing.T) {
+		doc := map[string]interface{}{"info": map[string]interface{}{}}
+		assert.False(t, Inject(doc, nil))
+	})
+}
+
+func TestResolve_FromCodeOwnersInRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", dir).Run())
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".github"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".github", "CODEOWNERS"), []byte("apis/ @acme/apis\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "apis"), 0755))
+	specPath := filepath.Join(dir, "apis", "spec.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte("openapi: 3.0.0\n"), 0644))
+
+	owner, err := Resolve(specPath)
+	require.NoError(t, err)
+	require.NotNil(t, owner)
+	assert.Equal(t, "@acme/apis", owner.Name)
+	assert.Equal(t, SourceCodeOwners, owner.Source)
+}
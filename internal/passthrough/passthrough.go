@@ -0,0 +1,188 @@
+// Package passthrough builds the Tyk API definitions of APIs whose traffic the Gateway
+// passes through without handling HTTP requests one by one: TCP and TLS proxies, and
+// streaming (server-sent events and WebSocket) APIs. They are classic (non-OAS) API
+// definitions.
+package passthrough
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tyktech/tyk-cli/internal/oas"
+)
+
+// Protocols of passthrough APIs
+const (
+	ProtocolTCP       = "tcp"
+	ProtocolTLS       = "tls"
+	ProtocolSSE       = "sse"
+	ProtocolWebSocket = "websocket"
+)
+
+// TCPOptions describe a TCP or TLS proxy
+type TCPOptions struct {
+	Name  string
+	OrgID string
+	// ListenPort is the Gateway port clients connect to
+	ListenPort int
+	// Upstream is host:port, or a tcp:// or tls:// URL for an upstream using TLS
+	Upstream string
+	// TLS terminates TLS on the listen port with Certificate, a certificate ID of the
+	// Dashboard certificate store
+	TLS         bool
+	Certificate string
+}
+
+// StreamOptions describe a server-sent events or WebSocket API
+type StreamOptions struct {
+	Name string
+	// Protocol is ProtocolSSE or ProtocolWebSocket
+	Protocol   string
+	OrgID      string
+	ListenPath string
+	// UpstreamURL is http(s)://, or ws(s):// for WebSocket upstreams
+	UpstreamURL string
+	// Keyless opens the API without authentication; otherwise it takes auth tokens
+	Keyless bool
+}
+
+// NewTCPDefinition returns the classic API definition of a TCP or TLS proxy. The Gateway
+// cannot authenticate raw TCP connections, so the API is keyless.
+func NewTCPDefinition(opts TCPOptions) (map[string]interface{}, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("a name is required")
+	}
+	if opts.ListenPort < 1 || opts.ListenPort > 65535 {
+		return nil, fmt.Errorf("listen port must be between 1 and 65535 (got %d)", opts.ListenPort)
+	}
+	target, err := tcpTarget(opts.Upstream)
+	if err != nil {
+		return nil, err
+	}
+	protocol := ProtocolTCP
+	if opts.TLS {
+		if opts.Certificate == "" {
+			return nil, fmt.Errorf("a TLS proxy needs a certificate to serve")
+		}
+		protocol = ProtocolTLS
+	}
+
+	def := baseDefinition(opts.Name, opts.OrgID, "", target)
+	def["protocol"] = protocol
+	def["listen_port"] = opts.ListenPort
+	def["use_keyless"] = true
+	if opts.Certificate != "" {
+		def["certificates"] = []interface{}{opts.Certificate}
+	}
+	return def, nil
+}
+
+// NewStreamDefinition returns the classic API definition of a server-sent events or
+// WebSocket API. Caching is off, as it would hold streamed responses back.
+func NewStreamDefinition(opts StreamOptions) (map[string]interface{}, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("a name is required")
+	}
+	if opts.Protocol != ProtocolSSE && opts.Protocol != ProtocolWebSocket {
+		return nil, fmt.Errorf("protocol must be %s or %s (got '%s')", ProtocolSSE, ProtocolWebSocket, opts.Protocol)
+	}
+	if opts.ListenPath == "" {
+		opts.ListenPath = oas.GenerateListenPath(opts.Name)
+	} else if !strings.HasPrefix(opts.ListenPath, "/") {
+		opts.ListenPath = "/" + opts.ListenPath
+	}
+
+	u, err := url.Parse(opts.UpstreamURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("upstream URL must be absolute (got '%s')", opts.UpstreamURL)
+	}
+	switch {
+	case u.Scheme == "http" || u.Scheme == "https":
+	case opts.Protocol == ProtocolWebSocket && (u.Scheme == "ws" || u.Scheme == "wss"):
+		// The Gateway proxies the upgrade request over HTTP
+		u.Scheme = strings.Replace(u.Scheme, "ws", "http", 1)
+	default:
+		return nil, fmt.Errorf("upstream URL must be http(s)://%s for %s APIs (got '%s')", wsSchemes(opts.Protocol), opts.Protocol, opts.UpstreamURL)
+	}
+
+	def := baseDefinition(opts.Name, opts.OrgID, opts.ListenPath, u.String())
+	def["use_keyless"] = opts.Keyless
+	def["cache_options"] = map[string]interface{}{"enable_cache": false}
+	return def, nil
+}
+
+// GatewayRequirements returns the Gateway settings an API of protocol needs, which the
+// API definition cannot turn on
+func GatewayRequirements(protocol string, listenPort int) []string {
+	switch protocol {
+	case ProtocolTCP, ProtocolTLS:
+		return []string{fmt.Sprintf("port %d must be open to %s APIs: add it to ports_whitelist.%s, or set disable_ports_whitelist", listenPort, protocol, protocol)}
+	case ProtocolSSE:
+		return []string{"http_server_options.flush_interval must be set (in milliseconds) so events are not buffered"}
+	case ProtocolWebSocket:
+		return []string{"http_server_options.enable_websockets must be true"}
+	}
+	return nil
+}
+
+// baseDefinition returns the fields every passthrough API definition has
+func baseDefinition(name, orgID, listenPath, target string) map[string]interface{} {
+	return map[string]interface{}{
+		"api_id": newAPIID(),
+		"name":   name,
+		"org_id": orgID,
+		"active": true,
+		"auth":   map[string]interface{}{"auth_header_name": "Authorization"},
+		"proxy": map[string]interface{}{
+			"listen_path":       listenPath,
+			"target_url":        target,
+			"strip_listen_path": listenPath != "",
+		},
+		"version_data": map[string]interface{}{
+			"not_versioned":   true,
+			"default_version": "Default",
+			"versions": map[string]interface{}{
+				"Default": map[string]interface{}{"name": "Default"},
+			},
+		},
+	}
+}
+
+// tcpTarget returns the target URL of a TCP upstream given as host:port or a tcp:// or
+// tls:// URL
+func tcpTarget(upstream string) (string, error) {
+	scheme, hostPort := ProtocolTCP, upstream
+	if before, after, ok := strings.Cut(upstream, "://"); ok {
+		scheme, hostPort = before, after
+		if scheme != ProtocolTCP && scheme != ProtocolTLS {
+			return "", fmt.Errorf("upstream must be host:port, tcp://host:port or tls://host:port (got '%s')", upstream)
+		}
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil || host == "" {
+		return "", fmt.Errorf("upstream must be host:port, tcp://host:port or tls://host:port (got '%s')", upstream)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", fmt.Errorf("upstream port must be between 1 and 65535 (got '%s')", port)
+	}
+	return scheme + "://" + hostPort, nil
+}
+
+func wsSchemes(protocol string) string {
+	if protocol == ProtocolWebSocket {
+		return " or ws(s)://"
+	}
+	return ""
+}
+
+// newAPIID returns a random API ID in the Gateway's format
+func newAPIID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,59 @@
+package passthrough
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTCPDefinition(t *testing.T) {
+	def, err := NewTCPDefinition(TCPOptions{Name: "Redis", ListenPort: 6380, Upstream: "redis.internal:6379", OrgID: "org"})
+	require.NoError(t, err)
+	assert.Equal(t, ProtocolTCP, def["protocol"])
+	assert.Equal(t, 6380, def["listen_port"])
+	assert.Equal(t, true, def["use_keyless"])
+	assert.Equal(t, "tcp://redis.internal:6379", def["proxy"].(map[string]interface{})["target_url"])
+
+	def, err = NewTCPDefinition(TCPOptions{Name: "Broker", ListenPort: 9093, Upstream: "tls://broker.internal:9093", TLS: true, Certificate: "cert-1"})
+	require.NoError(t, err)
+	assert.Equal(t, ProtocolTLS, def["protocol"])
+	assert.Equal(t, []interface{}{"cert-1"}, def["certificates"])
+	assert.Equal(t, "tls://broker.internal:9093", def["proxy"].(map[string]interface{})["target_url"])
+
+	for _, opts := range []TCPOptions{
+		{Name: "Redis", ListenPort: 70000, Upstream: "redis.internal:6379"},
+		{Name: "Redis", ListenPort: 6380, Upstream: "redis.internal"},
+		{Name: "Redis", ListenPort: 6380, Upstream: "http://redis.internal:6379"},
+		{Name: "Redis", ListenPort: 6380, Upstream: "redis.internal:6379", TLS: true},
+	} {
+		_, err := NewTCPDefinition(opts)
+		assert.Error(t, err, "%+v", opts)
+	}
+}
+
+func TestNewStreamDefinition(t *testing.T) {
+	def, err := NewStreamDefinition(StreamOptions{Name: "Chat Room", Protocol: ProtocolWebSocket, UpstreamURL: "wss://chat.internal/socket"})
+	require.NoError(t, err)
+	proxy := def["proxy"].(map[string]interface{})
+	assert.Equal(t, "https://chat.internal/socket", proxy["target_url"])
+	assert.Equal(t, "/chat-room/", proxy["listen_path"])
+	assert.Equal(t, false, def["use_keyless"])
+	assert.Equal(t, false, def["cache_options"].(map[string]interface{})["enable_cache"])
+
+	def, err = NewStreamDefinition(StreamOptions{Name: "Prices", Protocol: ProtocolSSE, UpstreamURL: "https://prices.internal/events", ListenPath: "prices", Keyless: true})
+	require.NoError(t, err)
+	assert.Equal(t, "/prices", def["proxy"].(map[string]interface{})["listen_path"])
+	assert.Equal(t, true, def["use_keyless"])
+
+	_, err = NewStreamDefinition(StreamOptions{Name: "Prices", Protocol: ProtocolSSE, UpstreamURL: "wss://prices.internal"})
+	assert.EqualError(t, err, "upstream URL must be http(s):// for sse APIs (got 'wss://prices.internal')")
+	_, err = NewStreamDefinition(StreamOptions{Name: "Prices", Protocol: "grpc", UpstreamURL: "https://prices.internal"})
+	assert.Error(t, err)
+}
+
+func TestGatewayRequirements(t *testing.T) {
+	assert.Equal(t, []string{"port 6380 must be open to tcp APIs: add it to ports_whitelist.tcp, or set disable_ports_whitelist"}, GatewayRequirements(ProtocolTCP, 6380))
+	assert.Len(t, GatewayRequirements(ProtocolWebSocket, 0), 1)
+	assert.Empty(t, GatewayRequirements("http", 0))
+}
@@ -0,0 +1,206 @@
+// Package trash keeps local copies of the API definitions the CLI deleted, so an API
+// removed by mistake can be re-created within a retention window.
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EnvDir overrides the trash location
+const EnvDir = "TYK_TRASH_DIR"
+
+// EnvRetention overrides how long deleted APIs are kept, as a duration such as 72h
+const EnvRetention = "TYK_TRASH_RETENTION"
+
+// DefaultRetention is how long deleted APIs are kept unless $TYK_TRASH_RETENTION says otherwise
+const DefaultRetention = 7 * 24 * time.Hour
+
+// ErrNotFound is returned by Load when the API is not in the trash, or has expired
+var ErrNotFound = errors.New("not in the trash")
+
+// Entry is a deleted API definition
+type Entry struct {
+	Environment string    `json:"environment"`
+	APIID       string    `json:"api_id"`
+	Name        string    `json:"name"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// Document is omitted when entries are listed
+	Document map[string]interface{} `json:"document,omitempty"`
+}
+
+// Dir returns the trash location: $TYK_TRASH_DIR, or trash next to the CLI configuration
+func Dir() (string, error) {
+	if dir := os.Getenv(EnvDir); dir != "" {
+		return dir, nil
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "tyk", "trash"), nil
+}
+
+// Retention returns how long deleted APIs are kept
+func Retention() (time.Duration, error) {
+	value := os.Getenv(EnvRetention)
+	if value == "" {
+		return DefaultRetention, nil
+	}
+	retention, err := time.ParseDuration(value)
+	if err != nil || retention <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s': must be a positive duration such as 72h", EnvRetention, value)
+	}
+	return retention, nil
+}
+
+// envDir is where the deleted APIs of one environment are stored
+func envDir(env string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, safeName(env)), nil
+}
+
+// Save stores doc as the deleted definition of the API, replacing an earlier one,
+// and drops the expired entries of the environment
+func Save(env, apiID, name string, doc map[string]interface{}) (*Entry, error) {
+	retention, err := Retention()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := envDir(env)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	entry := &Entry{
+		Environment: env,
+		APIID:       apiID,
+		Name:        name,
+		DeletedAt:   now,
+		ExpiresAt:   now.Add(retention),
+		Document:    doc,
+	}
+	out, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, fileName(apiID)), out, 0600); err != nil {
+		return nil, err
+	}
+	if _, err := List(env); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// List returns the unexpired entries of an environment without their documents,
+// most recently deleted first. Expired entries are removed.
+func List(env string) ([]Entry, error) {
+	dir, err := envDir(env)
+	if err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := []Entry{}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		entry, err := read(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if now.After(entry.ExpiresAt) {
+			os.Remove(filepath.Join(dir, file.Name()))
+			continue
+		}
+		entry.Document = nil
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DeletedAt.After(entries[j].DeletedAt) })
+	return entries, nil
+}
+
+// Load returns the deleted definition of an API
+func Load(env, apiID string) (*Entry, error) {
+	dir, err := envDir(env)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fileName(apiID))
+	entry, err := read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("API '%s' is %w of environment '%s'", apiID, ErrNotFound, env)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		os.Remove(path)
+		return nil, fmt.Errorf("API '%s' expired from the trash of environment '%s' at %s: %w", apiID, env, entry.ExpiresAt.Format(time.RFC3339), ErrNotFound)
+	}
+	return entry, nil
+}
+
+// Remove drops the entry of an API, once it has been re-created
+func Remove(env, apiID string) error {
+	dir, err := envDir(env)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, fileName(apiID)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func read(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &entry, nil
+}
+
+func fileName(apiID string) string {
+	return safeName(apiID) + ".json"
+}
+
+// safeName keeps environment names and API IDs usable as file names
+func safeName(s string) string {
+	if s == "" || s == "." || s == ".." {
+		return "_" + s
+	}
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator || r == ':' {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,71 @@
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveListLoad(t *testing.T) {
+	t.Setenv(EnvDir, t.TempDir())
+
+	entries, err := List("prod")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	saved, err := Save("prod", "api-1", "Users", map[string]interface{}{"info": map[string]interface{}{"version": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, saved.DeletedAt.Add(DefaultRetention), saved.ExpiresAt)
+	_, err = Save("prod", "api/2", "Orders", map[string]interface{}{})
+	require.NoError(t, err)
+	_, err = Save("dev", "api-3", "Legacy", map[string]interface{}{})
+	require.NoError(t, err)
+
+	entries, err = List("prod")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "api/2", entries[0].APIID)
+	assert.Nil(t, entries[0].Document)
+
+	entry, err := Load("prod", "api-1")
+	require.NoError(t, err)
+	assert.Equal(t, "1", entry.Document["info"].(map[string]interface{})["version"])
+
+	require.NoError(t, Remove("prod", "api-1"))
+	require.NoError(t, Remove("prod", "api-1"))
+	_, err = Load("prod", "api-1")
+	assert.True(t, errors.Is(err, ErrNotFound), err)
+}
+
+func TestExpiry(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvDir, dir)
+	t.Setenv(EnvRetention, "1h")
+
+	saved, err := Save("prod", "api-1", "Users", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, saved.ExpiresAt.Sub(saved.DeletedAt))
+
+	// Age the entry past its expiry
+	saved.ExpiresAt = time.Now().Add(-time.Minute)
+	data, err := json.Marshal(saved)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "prod", "api-1.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	_, err = Load("prod", "api-1")
+	assert.ErrorContains(t, err, "expired from the trash")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	t.Setenv(EnvRetention, "soon")
+	_, err = Save("prod", "api-1", "Users", map[string]interface{}{})
+	assert.ErrorContains(t, err, EnvRetention)
+}
@@ -0,0 +1,162 @@
+// Package workspace reads tyk.workspace.yaml, the manifest that maps the spec
+// directories of a monorepo to the environments they are deployed to.
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tyktech/tyk-cli/internal/oas"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the manifest looked up from the working directory upwards
+const FileName = "tyk.workspace.yaml"
+
+// Manifest lists the projects of a workspace
+type Manifest struct {
+	// File is the manifest path; project paths are relative to its directory
+	File     string    `yaml:"-" json:"file"`
+	Projects []Project `yaml:"projects" json:"projects"`
+}
+
+// Project is a directory of specs deployed to one or more environments
+type Project struct {
+	Path         string   `yaml:"path" json:"path"`
+	Environment  string   `yaml:"environment,omitempty" json:"environment,omitempty"`
+	Environments []string `yaml:"environments,omitempty" json:"environments,omitempty"`
+	// Prefix mounts every API of the project under a listen path, e.g. /pay
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+	// Prune deletes APIs under Prefix that have no spec in the project
+	Prune bool `yaml:"prune,omitempty" json:"prune"`
+	// CategoriesFromDirs files every API under Dashboard categories named after the
+	// folders between the project path and its spec
+	CategoriesFromDirs bool `yaml:"categories_from_dirs,omitempty" json:"categories_from_dirs,omitempty"`
+}
+
+// Targets returns the environments the project is deployed to
+func (p *Project) Targets() []string {
+	if p.Environment != "" {
+		return append([]string{p.Environment}, p.Environments...)
+	}
+	return p.Environments
+}
+
+// Owns reports whether a deployed listen path falls under the project's prefix
+func (p *Project) Owns(listenPath string) bool {
+	if p.Prefix == "" {
+		return false
+	}
+	return oas.UnderListenPath(listenPath, p.Prefix)
+}
+
+// Dir returns the absolute directory of a project
+func (m *Manifest) Dir(p *Project) string {
+	return filepath.Join(filepath.Dir(m.File), filepath.FromSlash(p.Path))
+}
+
+// Find returns the manifest in dir or the nearest parent directory
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, FileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s found in this directory or any parent", FileName)
+		}
+		dir = parent
+	}
+}
+
+// Load reads and checks a manifest
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{File: abs}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// validate normalises prefixes and rejects projects that cannot be synced safely
+func (m *Manifest) validate() error {
+	if len(m.Projects) == 0 {
+		return fmt.Errorf("no projects defined")
+	}
+	seen := make(map[string]bool)
+	for i := range m.Projects {
+		p := &m.Projects[i]
+		if p.Path == "" {
+			return fmt.Errorf("project %d: path is required", i+1)
+		}
+		if filepath.IsAbs(p.Path) || strings.HasPrefix(filepath.ToSlash(filepath.Clean(p.Path)), "../") {
+			return fmt.Errorf("project %s: path must be inside the workspace", p.Path)
+		}
+		p.Path = filepath.ToSlash(filepath.Clean(p.Path))
+		if seen[p.Path] {
+			return fmt.Errorf("project %s is listed twice", p.Path)
+		}
+		seen[p.Path] = true
+		if len(p.Targets()) == 0 {
+			return fmt.Errorf("project %s: environment is required", p.Path)
+		}
+		if p.Prefix != "" {
+			p.Prefix = "/" + strings.Trim(p.Prefix, "/")
+			if p.Prefix == "/" {
+				return fmt.Errorf("project %s: prefix must not be /", p.Path)
+			}
+		}
+		if p.Prune && p.Prefix == "" {
+			return fmt.Errorf("project %s: prune needs a prefix to tell the project's APIs from others", p.Path)
+		}
+	}
+
+	// Pruning projects must not delete each other's APIs
+	for i := range m.Projects {
+		for j := range m.Projects {
+			a, b := &m.Projects[i], &m.Projects[j]
+			if i == j || !a.Prune || b.Prefix == "" || !sharesEnvironment(a, b) {
+				continue
+			}
+			if a.Owns(b.Prefix) {
+				return fmt.Errorf("projects %s and %s share prefix %s in the same environment, so pruning one would delete the other's APIs", a.Path, b.Path, a.Prefix)
+			}
+		}
+	}
+	return nil
+}
+
+func sharesEnvironment(a, b *Project) bool {
+	for _, x := range a.Targets() {
+		for _, y := range b.Targets() {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
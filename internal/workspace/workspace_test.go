@@ -0,0 +1,90 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, FileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadAndFind(t *testing.T) {
+	root := t.TempDir()
+	writeManifest(t, root, `projects:
+  - path: teams/payments/
+    environment: prod
+    prefix: pay/
+    prune: true
+  - path: teams/search
+    environments: [staging, prod]
+`)
+	nested := filepath.Join(root, "teams", "payments", "specs")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	path, err := Find(nested)
+	require.NoError(t, err)
+	manifest, err := Load(path)
+	require.NoError(t, err)
+
+	require.Len(t, manifest.Projects, 2)
+	payments := &manifest.Projects[0]
+	assert.Equal(t, "teams/payments", payments.Path)
+	assert.Equal(t, "/pay", payments.Prefix)
+	assert.Equal(t, []string{"prod"}, payments.Targets())
+	assert.Equal(t, filepath.Join(root, "teams", "payments"), manifest.Dir(payments))
+	assert.Equal(t, []string{"staging", "prod"}, manifest.Projects[1].Targets())
+
+	assert.True(t, payments.Owns("/pay/users/"))
+	assert.True(t, payments.Owns("/pay"))
+	assert.False(t, payments.Owns("/payments/"))
+	assert.False(t, manifest.Projects[1].Owns("/search/"))
+
+	_, err = Find(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no projects":     "projects: []\n",
+		"unknown field":   "projects:\n  - path: a\n    environment: prod\n    env: prod\n",
+		"no environment":  "projects:\n  - path: a\n",
+		"outside":         "projects:\n  - path: ../a\n    environment: prod\n",
+		"duplicate":       "projects:\n  - path: a\n    environment: prod\n  - path: a/\n    environment: dev\n",
+		"prune no prefix": "projects:\n  - path: a\n    environment: prod\n    prune: true\n",
+		"overlapping prune": `projects:
+  - path: a
+    environment: prod
+    prefix: /pay
+    prune: true
+  - path: b
+    environments: [dev, prod]
+    prefix: /pay/refunds
+`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(writeManifest(t, t.TempDir(), content))
+			assert.Error(t, err)
+		})
+	}
+
+	// The same prefixes are fine in different environments
+	_, err := Load(writeManifest(t, t.TempDir(), `projects:
+  - path: a
+    environment: prod
+    prefix: /pay
+    prune: true
+  - path: b
+    environment: dev
+    prefix: /pay/refunds
+`))
+	assert.NoError(t, err)
+}
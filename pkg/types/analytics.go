@@ -0,0 +1,48 @@
+package types
+
+import "time"
+
+// AnalyticsRecord is one aggregate row from the Dashboard analytics endpoints. ID holds
+// the grouping keys: the time bucket (year, month, day, hour) or the endpoint (path, method).
+type AnalyticsRecord struct {
+	ID              map[string]interface{} `json:"id"`
+	Hits            int64                  `json:"hits"`
+	Success         int64                  `json:"success"`
+	Error           int64                  `json:"error"`
+	RequestTime     float64                `json:"request_time"`
+	Latency         float64                `json:"latency"`
+	UpstreamLatency float64                `json:"upstream_latency"`
+	MaxLatency      float64                `json:"max_latency"`
+	MinLatency      float64                `json:"min_latency"`
+	LastTime        string                 `json:"last_time"`
+}
+
+// AnalyticsResponse is a page of analytics records
+type AnalyticsResponse struct {
+	Data  []AnalyticsRecord `json:"data"`
+	Pages int               `json:"pages"`
+}
+
+// Time returns the start of the record's time bucket, or the zero time when the record
+// is not grouped by time
+func (r AnalyticsRecord) Time() time.Time {
+	year, ok := r.idInt("year")
+	if !ok {
+		return time.Time{}
+	}
+	month, _ := r.idInt("month")
+	day, _ := r.idInt("day")
+	hour, _ := r.idInt("hour")
+	return time.Date(year, time.Month(month), day, hour, 0, 0, 0, time.UTC)
+}
+
+// IDString returns a string grouping key such as "path"
+func (r AnalyticsRecord) IDString(key string) string {
+	value, _ := r.ID[key].(string)
+	return value
+}
+
+func (r AnalyticsRecord) idInt(key string) (int, bool) {
+	value, ok := r.ID[key].(float64)
+	return int(value), ok
+}
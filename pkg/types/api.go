@@ -1,6 +1,11 @@
 package types
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
 
 // APIResponse represents the response structure from Tyk Dashboard API
 type APIResponse struct {
@@ -8,8 +13,18 @@ type APIResponse struct {
 	Message string `json:"Message"`
 	Meta    string `json:"Meta"`
 	ID      string `json:"ID,omitempty"`
+	Key     string `json:"key,omitempty"` // Gateway API responses carry the API ID here
 }
 
+// CreatedID returns the ID of the created resource from either Dashboard or Gateway responses
+func (r *APIResponse) CreatedID() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return r.Key
+}
+
+
 // OASAPIResponse represents an OAS API response from Tyk Dashboard
 type OASAPIResponse struct {
 	APIResponse
@@ -34,6 +49,40 @@ type OASAPI struct {
 	UpdatedAt        string                 `json:"updated_at"`
 	CustomDomain     string                 `json:"custom_domain,omitempty"`
 	UpstreamURL      string                 `json:"upstream_url,omitempty"`
+	Active           bool                   `json:"active"`
+	Internal         bool                   `json:"internal"`
+	Tags             []string               `json:"tags,omitempty"`
+
+	// ETag is the entity tag the Dashboard returned with the document, if any; pass it
+	// back when updating to fail instead of overwriting someone else's change
+	ETag string `json:"-"`
+}
+
+// API statuses derived from the active and internal flags
+const (
+	APIStatusActive   = "active"
+	APIStatusInactive = "inactive"
+	APIStatusInternal = "internal"
+)
+
+// Status summarises the API state: inactive APIs are reported as such even when
+// internal, otherwise internal-only APIs are distinguished from public ones
+func (a *OASAPI) Status() string {
+	switch {
+	case !a.Active:
+		return APIStatusInactive
+	case a.Internal:
+		return APIStatusInternal
+	default:
+		return APIStatusActive
+	}
+}
+
+// APIAccess lists the Dashboard users and user groups that own an API. With
+// ownership enabled, Dashboard RBAC only lets owners see and change the API.
+type APIAccess struct {
+	UserIDs      []string `json:"userIds"`
+	UserGroupIDs []string `json:"userGroupIds"`
 }
 
 // APIVersion represents version data for an API
@@ -82,4 +131,53 @@ type ErrorResponse struct {
 // Error implements the error interface
 func (e *ErrorResponse) Error() string {
 	return e.Message
+}
+
+// ErrorCode classifies the response like the CLI's exit codes do
+func (e *ErrorResponse) ErrorCode() ErrorCode {
+	switch {
+	case e.Is(ErrNotFound):
+		return ErrCodeNotFound
+	case e.Is(ErrConflict), e.Is(ErrPreconditionFailed):
+		return ErrCodeConflict
+	case e.Is(ErrUnauthorized):
+		return ErrCodeUnauthorized
+	case e.Is(ErrRateLimited):
+		return ErrCodeRateLimited
+	}
+	return ErrCodeGeneral
+}
+
+// Sentinel errors classifying Dashboard error responses; match them with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+	// ErrPreconditionFailed is a rejected conditional update: the API changed since its
+	// ETag was read. It also matches ErrConflict.
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// Is lets errors.Is match an ErrorResponse against the sentinel for its status code
+func (e *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		if e.Status == http.StatusNotFound {
+			return true
+		}
+		// Some Dashboard versions answer 400 for unknown API IDs
+		msg := strings.ToLower(e.Message)
+		return e.Status == http.StatusBadRequest &&
+			(strings.Contains(msg, "could not retrieve api") || strings.Contains(msg, "not found"))
+	case ErrConflict:
+		return e.Status == http.StatusConflict || e.Status == http.StatusPreconditionFailed
+	case ErrPreconditionFailed:
+		return e.Status == http.StatusPreconditionFailed
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized || e.Status == http.StatusForbidden
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	}
+	return false
 }
\ No newline at end of file
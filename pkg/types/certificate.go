@@ -0,0 +1,7 @@
+package types
+
+// CertificateListResponse lists the IDs in the Dashboard or Gateway certificate store
+type CertificateListResponse struct {
+	Certs []string `json:"certs"`
+	Pages int      `json:"pages"`
+}
@@ -4,24 +4,173 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // Config holds all configuration for the Tyk CLI
 // In the unified approach, config IS environments - no base config fields
 type Config struct {
 	// Default active environment
-	DefaultEnvironment string                   `mapstructure:"default_environment" yaml:"default_environment" json:"default_environment"`
+	DefaultEnvironment string `mapstructure:"default_environment" yaml:"default_environment" json:"default_environment"`
 	// All named environments (this IS the configuration system)
-	Environments       map[string]*Environment  `mapstructure:"environments" yaml:"environments" json:"environments"`
+	Environments map[string]*Environment `mapstructure:"environments" yaml:"environments" json:"environments"`
+	// Shared environment definitions 'tyk config sync-remote' reads
+	Remote *RemoteSource `mapstructure:"remote" yaml:"remote,omitempty" json:"remote,omitempty"`
+}
+
+// RemoteSource locates a file of environment definitions shared by a team: an HTTPS
+// URL, or a Git repository and the path of the file in it
+type RemoteSource struct {
+	URL string `mapstructure:"url" yaml:"url" json:"url"`
+	// Path of the file in a Git repository
+	Path string `mapstructure:"path" yaml:"path,omitempty" json:"path,omitempty"`
+	// Branch or tag of a Git repository (default: its default branch)
+	Ref string `mapstructure:"ref" yaml:"ref,omitempty" json:"ref,omitempty"`
 }
 
 // Environment represents a named configuration environment
 // In the unified model, environments ARE the configuration
 type Environment struct {
 	Name         string `mapstructure:"name" yaml:"name" json:"name"`
+	Type         string `mapstructure:"type" yaml:"type,omitempty" json:"type,omitempty"` // "dashboard" (default) or "gateway"
 	DashboardURL string `mapstructure:"dashboard_url" yaml:"dashboard_url" json:"dashboard_url"`
+	// Gateway URL: the management API of gateway environments, and the data plane that
+	// commands such as 'api try' and 'api url' call for dashboard ones
+	GatewayURL   string `mapstructure:"gateway_url" yaml:"gateway_url,omitempty" json:"gateway_url,omitempty"`
 	AuthToken    string `mapstructure:"auth_token" yaml:"auth_token" json:"auth_token"`
 	OrgID        string `mapstructure:"org_id" yaml:"org_id" json:"org_id"`
+	// Timeout for each API request, as a Go duration such as "2m" (default 30s)
+	Timeout string `mapstructure:"timeout" yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	// Proxy for API requests; when unset HTTPS_PROXY/HTTP_PROXY/NO_PROXY apply
+	ProxyURL string `mapstructure:"proxy_url" yaml:"proxy_url,omitempty" json:"proxy_url,omitempty"`
+	// PEM bundle of extra CAs to trust, e.g. a corporate TLS-interception root
+	CACert string `mapstructure:"ca_cert" yaml:"ca_cert,omitempty" json:"ca_cert,omitempty"`
+	// PEM client certificate and key for mutual TLS
+	ClientCert         string `mapstructure:"client_cert" yaml:"client_cert,omitempty" json:"client_cert,omitempty"`
+	ClientKey          string `mapstructure:"client_key" yaml:"client_key,omitempty" json:"client_key,omitempty"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify" yaml:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+	// Tyk release the environment runs, e.g. "5.3"; detected from the health endpoint when unset
+	TykVersion string `mapstructure:"tyk_version" yaml:"tyk_version,omitempty" json:"tyk_version,omitempty"`
+	// Listen path every API deployed to the environment is mounted under, e.g. "/staging",
+	// so environments sharing a gateway never claim the same paths
+	ListenPathPrefix string `mapstructure:"listen_path_prefix" yaml:"listen_path_prefix,omitempty" json:"listen_path_prefix,omitempty"`
+	// Environment variables set for commands run against the environment, e.g.
+	// TYK_GATEWAY_URL; names are upper-cased and variables already set in the shell win
+	Env map[string]string `mapstructure:"env" yaml:"env,omitempty" json:"env,omitempty"`
+	// Extra headers sent with every management API request; values may reference
+	// environment variables as ${NAME}
+	Headers map[string]string `mapstructure:"headers" yaml:"headers,omitempty" json:"headers,omitempty"`
+	// HMAC signature added to every management API request, for Dashboards behind
+	// proxies that verify request signatures
+	Signing *RequestSigning `mapstructure:"signing" yaml:"signing,omitempty" json:"signing,omitempty"`
+	// Rewrites applied to APIs promoted into this environment, keyed by the name of the
+	// environment they are promoted from
+	PromoteFrom map[string]*PromotionRules `mapstructure:"promote_from" yaml:"promote_from,omitempty" json:"promote_from,omitempty"`
+	// Set on environments defined by the shared remote config; syncing replaces all
+	// but their local settings (auth token, proxy and certificates)
+	Shared bool `mapstructure:"shared" yaml:"shared,omitempty" json:"shared,omitempty"`
+}
+
+// PromotionRules reshape the APIs promoted from one environment into another, so the
+// same logical API lands with each environment's paths, domains and upstreams
+type PromotionRules struct {
+	// Leading listen path segments to swap, e.g. /staging to /
+	ListenPathPrefixes []Rewrite `mapstructure:"listen_path_prefixes" yaml:"listen_path_prefixes,omitempty" json:"listen_path_prefixes,omitempty"`
+	// Custom domain names to swap
+	Domains []Rewrite `mapstructure:"domains" yaml:"domains,omitempty" json:"domains,omitempty"`
+	// Upstream URL hosts to swap; a host without a port matches any port
+	UpstreamHosts []Rewrite `mapstructure:"upstream_hosts" yaml:"upstream_hosts,omitempty" json:"upstream_hosts,omitempty"`
+}
+
+// Rewrite replaces From with To
+type Rewrite struct {
+	From string `mapstructure:"from" yaml:"from" json:"from"`
+	To   string `mapstructure:"to" yaml:"to" json:"to"`
+}
+
+// Request signing algorithms
+const (
+	SigningHMACSHA256 = "hmac-sha256"
+	SigningHMACSHA512 = "hmac-sha512"
+	SigningHMACSHA1   = "hmac-sha1"
+)
+
+// DefaultSigningHeader carries request signatures unless another header is configured
+const DefaultSigningHeader = "Signature"
+
+// RequestSigning signs requests in the format of the HTTP Signatures draft: an HMAC
+// over the request target and headers, keyed by a secret the config only refers to
+type RequestSigning struct {
+	// hmac-sha256 (default), hmac-sha512 or hmac-sha1
+	Algorithm string `mapstructure:"algorithm" yaml:"algorithm,omitempty" json:"algorithm,omitempty"`
+	// Identifies the secret to the server, sent as keyId
+	KeyID string `mapstructure:"key_id" yaml:"key_id,omitempty" json:"key_id,omitempty"`
+	// Where the secret is read from: env:NAME or file:PATH
+	Secret string `mapstructure:"secret" yaml:"secret" json:"secret"`
+	// Header the signature is sent in (default Signature)
+	Header string `mapstructure:"header" yaml:"header,omitempty" json:"header,omitempty"`
+	// Parts of the request signed, in order (default: (request-target) host date, and
+	// digest when there is a body)
+	Headers []string `mapstructure:"headers" yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// SigningAlgorithm returns the configured algorithm, or the default hmac-sha256
+func (s *RequestSigning) SigningAlgorithm() string {
+	if s.Algorithm == "" {
+		return SigningHMACSHA256
+	}
+	return strings.ToLower(s.Algorithm)
+}
+
+// Validate checks the algorithm, the secret reference and the signed headers
+func (s *RequestSigning) Validate() error {
+	switch s.SigningAlgorithm() {
+	case SigningHMACSHA256, SigningHMACSHA512, SigningHMACSHA1:
+	default:
+		return fmt.Errorf("unsupported algorithm '%s' (expected %s, %s or %s)", s.Algorithm, SigningHMACSHA256, SigningHMACSHA512, SigningHMACSHA1)
+	}
+	kind, ref, _ := strings.Cut(s.Secret, ":")
+	if (kind != "env" && kind != "file") || ref == "" {
+		return errors.New("secret must refer to where it is kept, as env:NAME or file:PATH, not hold it")
+	}
+	if strings.ContainsAny(s.Header, " \t\r\n:") {
+		return fmt.Errorf("invalid header name '%s'", s.Header)
+	}
+	for _, name := range s.Headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("invalid signed header '%s'", name)
+		}
+	}
+	return nil
+}
+
+// Environment types
+const (
+	EnvTypeDashboard = "dashboard"
+	EnvTypeGateway   = "gateway"
+)
+
+// IsGateway reports whether the environment talks to the OSS Gateway API directly
+func (e *Environment) IsGateway() bool {
+	return e.Type == EnvTypeGateway
+}
+
+// RequestTimeout returns the configured per-request timeout, or 0 when unset
+func (e *Environment) RequestTimeout() time.Duration {
+	timeout, err := time.ParseDuration(e.Timeout)
+	if err != nil || timeout < 0 {
+		return 0
+	}
+	return timeout
+}
+
+// ManagementURL returns the base URL of the API the CLI manages resources through
+func (e *Environment) ManagementURL() string {
+	if e.IsGateway() {
+		return e.GatewayURL
+	}
+	return e.DashboardURL
 }
 
 // Validate checks if the configuration is valid
@@ -75,6 +224,74 @@ func (e *Environment) Validate() error {
 		return errors.New("environment name is required")
 	}
 
+	switch e.Type {
+	case "", EnvTypeDashboard, EnvTypeGateway:
+	default:
+		return fmt.Errorf("invalid type '%s' for environment '%s' (expected '%s' or '%s')", e.Type, e.Name, EnvTypeDashboard, EnvTypeGateway)
+	}
+
+	if e.Timeout != "" {
+		if timeout, err := time.ParseDuration(e.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout '%s' for environment '%s' (expected a duration such as 30s or 2m)", e.Timeout, e.Name)
+		}
+	}
+
+	if e.ProxyURL != "" {
+		parsedURL, err := url.Parse(e.ProxyURL)
+		if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+			return fmt.Errorf("invalid proxy URL format for environment '%s': %s", e.Name, e.ProxyURL)
+		}
+	}
+
+	if e.ListenPathPrefix != "" && (!strings.HasPrefix(e.ListenPathPrefix, "/") || strings.Trim(e.ListenPathPrefix, "/") == "") {
+		return fmt.Errorf("invalid listen_path_prefix '%s' for environment '%s' (expected a path such as /staging)", e.ListenPathPrefix, e.Name)
+	}
+
+	for name := range e.Env {
+		if !validEnvName(name) {
+			return fmt.Errorf("invalid env variable name '%s' for environment '%s'", name, e.Name)
+		}
+	}
+	for name := range e.Headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("invalid header name '%s' for environment '%s'", name, e.Name)
+		}
+	}
+
+	if e.Signing != nil {
+		if err := e.Signing.Validate(); err != nil {
+			return fmt.Errorf("invalid signing for environment '%s': %w", e.Name, err)
+		}
+	}
+
+	for from, rules := range e.PromoteFrom {
+		if err := rules.Validate(); err != nil {
+			return fmt.Errorf("invalid promote_from.%s for environment '%s': %w", from, e.Name, err)
+		}
+	}
+
+	if (e.ClientCert == "") != (e.ClientKey == "") {
+		return fmt.Errorf("client_cert and client_key must be set together for environment '%s'", e.Name)
+	}
+
+	if e.GatewayURL != "" {
+		parsedURL, err := url.Parse(e.GatewayURL)
+		if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+			return fmt.Errorf("invalid gateway URL format for environment '%s': %s", e.Name, e.GatewayURL)
+		}
+	}
+
+	if e.IsGateway() {
+		if e.GatewayURL == "" {
+			return fmt.Errorf("gateway URL is required for gateway environment '%s'", e.Name)
+		}
+		if e.AuthToken == "" {
+			return fmt.Errorf("gateway secret (auth token) is required for environment '%s'", e.Name)
+		}
+		// The Gateway API is not multi-tenant, so no org ID is needed
+		return nil
+	}
+
 	if e.DashboardURL == "" {
 		return fmt.Errorf("dashboard URL is required for environment '%s'", e.Name)
 	}
@@ -96,15 +313,48 @@ func (e *Environment) Validate() error {
 	return nil
 }
 
+// Validate checks the rewrites are paths and host names
+func (r *PromotionRules) Validate() error {
+	if r == nil {
+		return nil
+	}
+	for _, rewrite := range r.ListenPathPrefixes {
+		if !strings.HasPrefix(rewrite.From, "/") || !strings.HasPrefix(rewrite.To, "/") {
+			return fmt.Errorf("listen_path_prefixes must swap paths such as /staging for /, got '%s' to '%s'", rewrite.From, rewrite.To)
+		}
+	}
+	for _, rewrite := range append(append([]Rewrite{}, r.Domains...), r.UpstreamHosts...) {
+		if rewrite.From == "" || rewrite.To == "" || strings.ContainsAny(rewrite.From+rewrite.To, "/ ") {
+			return fmt.Errorf("domains and upstream_hosts must swap host names, got '%s' to '%s'", rewrite.From, rewrite.To)
+		}
+	}
+	return nil
+}
+
+// validEnvName reports whether name is a portable environment variable name
+func validEnvName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if r != '_' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
 // ExitCode represents different types of CLI exit codes
 type ExitCode int
 
 const (
-	ExitSuccess     ExitCode = 0 // Success
-	ExitGeneral     ExitCode = 1 // Generic failure (I/O, network, unexpected)
-	ExitBadArgs     ExitCode = 2 // Bad arguments (missing file, invalid flag combination)
-	ExitNotFound    ExitCode = 3 // Not found (API or version)
-	ExitConflict    ExitCode = 4 // Conflict (e.g. creating an API that already exists without --force)
+	ExitSuccess      ExitCode = 0 // Success
+	ExitGeneral      ExitCode = 1 // Generic failure (I/O, network, unexpected)
+	ExitBadArgs      ExitCode = 2 // Bad arguments (missing file, invalid flag combination)
+	ExitNotFound     ExitCode = 3 // Not found (API or version)
+	ExitConflict     ExitCode = 4 // Conflict (e.g. creating an API that already exists without --force)
+	ExitUnauthorized ExitCode = 5 // Authentication or authorization rejected by the Dashboard
+	ExitRateLimited  ExitCode = 6 // Dashboard rate limit exceeded
 )
 
 // OutputFormat represents the output format for CLI commands
@@ -113,4 +363,11 @@ type OutputFormat string
 const (
 	OutputHuman OutputFormat = "human"
 	OutputJSON  OutputFormat = "json"
-)
\ No newline at end of file
+	OutputYAML  OutputFormat = "yaml"
+	OutputWide  OutputFormat = "wide" // human output with extra columns
+)
+
+// IsStructured reports whether the format is machine-readable (JSON or YAML)
+func (f OutputFormat) IsStructured() bool {
+	return f == OutputJSON || f == OutputYAML
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrorCode is the stable, machine-readable name of a class of failure. Each code has
+// exactly one exit code; 'tyk explain <code>' describes it.
+type ErrorCode string
+
+const (
+	ErrCodeGeneral      ErrorCode = "E_GENERAL"
+	ErrCodeBadArgs      ErrorCode = "E_BAD_ARGS"
+	ErrCodeNotFound     ErrorCode = "E_NOT_FOUND"
+	ErrCodeConflict     ErrorCode = "E_CONFLICT"
+	ErrCodeUnauthorized ErrorCode = "E_UNAUTHORIZED"
+	ErrCodeRateLimited  ErrorCode = "E_RATE_LIMITED"
+)
+
+// ErrorCodeInfo documents an error code for 'tyk explain'
+type ErrorCodeInfo struct {
+	Code        ErrorCode `json:"code" yaml:"code"`
+	ExitCode    ExitCode  `json:"exit_code" yaml:"exit_code"`
+	Summary     string    `json:"summary" yaml:"summary"`
+	Remediation string    `json:"remediation" yaml:"remediation"`
+}
+
+// ErrorCatalog lists every error code in exit code order
+var ErrorCatalog = []ErrorCodeInfo{
+	{
+		Code:        ErrCodeGeneral,
+		ExitCode:    ExitGeneral,
+		Summary:     "The command failed: an I/O or network error, an unexpected Dashboard response, or a check that did not pass (diff --exit-code, url --probe).",
+		Remediation: "Read the error message; rerun with -v to log each HTTP request, and run 'tyk doctor' to check connectivity and credentials.",
+	},
+	{
+		Code:        ErrCodeBadArgs,
+		ExitCode:    ExitBadArgs,
+		Summary:     "The command was called wrongly: a missing or invalid flag, an unreadable file, or a document that fails validation.",
+		Remediation: "Check the flags in 'tyk <command> --help'; for specs, 'tyk oas validate --file <spec>' reports what is wrong.",
+	},
+	{
+		Code:        ErrCodeNotFound,
+		ExitCode:    ExitNotFound,
+		Summary:     "The API, version, key, policy or other resource does not exist in the active environment.",
+		Remediation: "Check the ID with 'tyk api list' or 'tyk api search', and that the intended environment is active ('tyk config current' or --env).",
+	},
+	{
+		Code:        ErrCodeConflict,
+		ExitCode:    ExitConflict,
+		Summary:     "The write conflicts with existing state, such as an API ID or listen path that is already taken, or the API was changed by someone else since it was read.",
+		Remediation: "Update the existing API with 'tyk api apply' instead of creating it, or change the listen path; 'tyk api list --filter <path>' finds what holds it. If the API changed since it was read, review the change with 'tyk api diff' and run the command again.",
+	},
+	{
+		Code:        ErrCodeUnauthorized,
+		ExitCode:    ExitUnauthorized,
+		Summary:     "The Dashboard rejected the auth token, or the token's user lacks permission for the operation.",
+		Remediation: "Run 'tyk whoami' to see the token's user and permissions; set a new token with 'tyk config set --auth-token <token>'.",
+	},
+	{
+		Code:        ErrCodeRateLimited,
+		ExitCode:    ExitRateLimited,
+		Summary:     "The Dashboard rate limit was exceeded.",
+		Remediation: "Wait and retry; for bulk operations lower the request rate or the number of APIs handled per run.",
+	},
+}
+
+// ErrorCode returns the error code reported with exit code c
+func (c ExitCode) ErrorCode() ErrorCode {
+	for _, info := range ErrorCatalog {
+		if info.ExitCode == c {
+			return info.Code
+		}
+	}
+	return ErrCodeGeneral
+}
+
+// ExitCode returns the exit status the CLI ends with for c
+func (c ErrorCode) ExitCode() ExitCode {
+	for _, info := range ErrorCatalog {
+		if info.Code == c {
+			return info.ExitCode
+		}
+	}
+	return ExitGeneral
+}
+
+// LookupErrorCode finds an error code by name ("E_CONFLICT", "conflict") or exit code ("4")
+func LookupErrorCode(query string) (ErrorCodeInfo, bool) {
+	name := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(query), "-", "_"))
+	exitCode, numeric := strconv.Atoi(name)
+	for _, info := range ErrorCatalog {
+		if numeric == nil && int(info.ExitCode) == exitCode ||
+			string(info.Code) == name || string(info.Code) == "E_"+name {
+			return info, true
+		}
+	}
+	return ErrorCodeInfo{}, false
+}
@@ -0,0 +1,22 @@
+package types
+
+// Session is a Tyk key's session object. It is kept as a generic map so fields the CLI
+// does not model (policies, metadata, quotas, JWT/OAuth data) survive a round trip.
+type Session map[string]interface{}
+
+// KeyResponse is the Dashboard representation of a key
+type KeyResponse struct {
+	KeyID   string  `json:"key_id"`
+	KeyHash string  `json:"key_hash,omitempty"`
+	Data    Session `json:"data"`
+}
+
+// KeyListResponse lists key IDs. The Dashboard nests them under data; the Gateway
+// returns them at the top level.
+type KeyListResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+	Keys  []string `json:"keys"`
+	Pages int      `json:"pages"`
+}
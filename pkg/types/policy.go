@@ -0,0 +1,28 @@
+package types
+
+// Policy represents a Tyk security policy as accepted by the Dashboard
+type Policy struct {
+	ID               string                         `json:"_id,omitempty" yaml:"id,omitempty"`
+	Name             string                         `json:"name" yaml:"name"`
+	OrgID            string                         `json:"org_id,omitempty" yaml:"org_id,omitempty"`
+	Active           bool                           `json:"active" yaml:"active"`
+	Rate             float64                        `json:"rate" yaml:"rate"`
+	Per              float64                        `json:"per" yaml:"per"`
+	QuotaMax         int64                          `json:"quota_max" yaml:"quota_max"`
+	QuotaRenewalRate int64                          `json:"quota_renewal_rate" yaml:"quota_renewal_rate"`
+	Tags             []string                       `json:"tags,omitempty" yaml:"tags,omitempty"`
+	AccessRights     map[string]*PolicyAccessRights `json:"access_rights" yaml:"access_rights,omitempty"`
+}
+
+// PolicyAccessRights grants a policy access to a single API
+type PolicyAccessRights struct {
+	APIID    string   `json:"api_id" yaml:"api_id"`
+	APIName  string   `json:"api_name" yaml:"api_name"`
+	Versions []string `json:"versions" yaml:"versions"`
+}
+
+// PolicyListResponse is the Dashboard's policy list
+type PolicyListResponse struct {
+	Data  []*Policy `json:"Data"`
+	Pages int       `json:"Pages"`
+}
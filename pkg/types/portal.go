@@ -0,0 +1,50 @@
+package types
+
+// Catalogue is the classic developer portal's API catalogue. The Dashboard keeps one
+// per organisation and replaces it whole on update, so updates send the stored
+// document rather than this model, which leaves fields out.
+type Catalogue struct {
+	ID    string          `json:"id,omitempty" yaml:"id,omitempty"`
+	OrgID string          `json:"org_id,omitempty" yaml:"org_id,omitempty"`
+	APIs  []*CatalogueAPI `json:"apis" yaml:"apis"`
+}
+
+// CatalogueAPI is one catalogue entry. Developers request access to it through its
+// policy, so entries are keyed by PolicyID.
+type CatalogueAPI struct {
+	Name             string                 `json:"name" yaml:"name"`
+	ShortDescription string                 `json:"short_description" yaml:"short_description"`
+	LongDescription  string                 `json:"long_description" yaml:"long_description"`
+	Show             bool                   `json:"show" yaml:"show"`
+	APIID            string                 `json:"api_id" yaml:"api_id"`
+	PolicyID         string                 `json:"policy_id" yaml:"policy_id"`
+	Documentation    string                 `json:"documentation" yaml:"documentation"`
+	Version          string                 `json:"version" yaml:"version"`
+	IsKeyless        bool                   `json:"is_keyless" yaml:"is_keyless"`
+	AuthType         string                 `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+	Config           map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+	Fields           map[string]interface{} `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// PortalDocumentation is an API specification uploaded for a catalogue entry; the
+// document is base64 encoded
+type PortalDocumentation struct {
+	APIID         string `json:"api_id"`
+	DocType       string `json:"doc_type"`
+	Documentation string `json:"documentation"`
+}
+
+// PortalDeveloper is a developer registered on the classic developer portal.
+// Subscriptions map the policy of each catalogue entry they have a key for to that key.
+type PortalDeveloper struct {
+	ID            string            `json:"id" yaml:"id"`
+	Email         string            `json:"email" yaml:"email"`
+	Inactive      bool              `json:"inactive" yaml:"inactive"`
+	Subscriptions map[string]string `json:"subscriptions" yaml:"subscriptions"`
+}
+
+// PortalDeveloperListResponse is the Dashboard's portal developer listing
+type PortalDeveloperListResponse struct {
+	Data  []*PortalDeveloper `json:"Data"`
+	Pages int                `json:"Pages"`
+}
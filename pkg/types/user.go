@@ -0,0 +1,30 @@
+package types
+
+import "strings"
+
+// User is a Dashboard user
+type User struct {
+	ID          string            `json:"id"`
+	FirstName   string            `json:"first_name"`
+	LastName    string            `json:"last_name"`
+	Email       string            `json:"email_address"`
+	OrgID       string            `json:"org_id"`
+	Active      bool              `json:"active"`
+	AccessKey   string            `json:"access_key,omitempty"`
+	Permissions map[string]string `json:"user_permissions"`
+}
+
+// UserListResponse is the Dashboard's user list
+type UserListResponse struct {
+	Users []*User `json:"users"`
+}
+
+// Name returns the user's full name
+func (u *User) Name() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// IsAdmin reports whether the user has every permission
+func (u *User) IsAdmin() bool {
+	return u.Permissions["IsAdmin"] == "admin"
+}
@@ -0,0 +1,19 @@
+package types
+
+// Webhook is a Dashboard webhook that API event handlers can call
+type Webhook struct {
+	ID           string            `json:"id,omitempty" yaml:"id,omitempty"`
+	OrgID        string            `json:"org_id,omitempty" yaml:"org_id,omitempty"`
+	Name         string            `json:"name" yaml:"name"`
+	Method       string            `json:"method" yaml:"method"`
+	TargetPath   string            `json:"target_path" yaml:"target_path"`
+	TemplatePath string            `json:"template_path,omitempty" yaml:"template_path,omitempty"`
+	HeaderMap    map[string]string `json:"header_map" yaml:"header_map,omitempty"`
+	EventTimeout int64             `json:"event_timeout,omitempty" yaml:"event_timeout,omitempty"`
+}
+
+// WebhookListResponse is the Dashboard's webhook list
+type WebhookListResponse struct {
+	Hooks []*Webhook `json:"hooks"`
+	Pages int        `json:"pages"`
+}